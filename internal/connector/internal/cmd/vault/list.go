@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/vault"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/secrets"
 	"github.com/golang/glog"
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
@@ -31,7 +31,7 @@ func NewListCommand(env *environments.Env) *cobra.Command {
 	return cmd
 }
 
-func runList(vaultService vault.VaultService) {
+func runList(vaultService secrets.VaultService) {
 	table := tablewriter.NewWriter(os.Stdout)
 	table.SetHeader([]string{"Secret Key", "Owning Resource"})
 	err := vaultService.ForEachSecret(func(key string, owner string) bool {
@@ -29,6 +29,10 @@ type ConnectorsConfig struct {
 	ConnectorMetadataDirs               []string                `json:"connector_metadata"`
 	CatalogEntries                      []ConnectorCatalogEntry `json:"connector_type_urls"`
 	CatalogChecksums                    map[string]string       `json:"connector_catalog_checksums"`
+	// The orphaned secrets are the secrets of the vault whose connector has been deleted
+	OrphanedSecretCheckInterval time.Duration `json:"orphaned_secret_check_interval"`
+	OrphanedSecretGracePeriod   time.Duration `json:"orphaned_secret_grace_period"`
+	OrphanedSecretDryRun        bool          `json:"orphaned_secret_dry_run"`
 }
 
 var _ environments.ConfigModule = &ConnectorsConfig{}
@@ -51,7 +55,9 @@ type ConnectorMetadata struct {
 
 func NewConnectorsConfig() *ConnectorsConfig {
 	return &ConnectorsConfig{
-		CatalogChecksums: make(map[string]string),
+		CatalogChecksums:            make(map[string]string),
+		OrphanedSecretCheckInterval: time.Hour,
+		OrphanedSecretGracePeriod:   24 * time.Hour,
 	}
 }
 
@@ -62,6 +68,9 @@ func (c *ConnectorsConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringArrayVar(&c.ConnectorEvalOrganizations, "connector-eval-organizations", c.ConnectorEvalOrganizations, "Connector eval organization IDs")
 	fs.BoolVar(&c.ConnectorNamespaceLifecycleAPI, "connector-namespace-lifecycle-api", c.ConnectorNamespaceLifecycleAPI, "Enable APIs to create, update, delete non-eval Namespaces")
 	fs.BoolVar(&c.ConnectorEnableUnassignedConnectors, "connector-enable-unassigned-connectors", c.ConnectorEnableUnassignedConnectors, "Enable support for 'unassigned' state for Connectors")
+	fs.DurationVar(&c.OrphanedSecretCheckInterval, "connector-orphaned-secret-check-interval", c.OrphanedSecretCheckInterval, "How often the vault is checked for orphaned secrets in golang duration format, 0 disables the check")
+	fs.DurationVar(&c.OrphanedSecretGracePeriod, "connector-orphaned-secret-grace-period", c.OrphanedSecretGracePeriod, "How long an orphaned secret is kept after its last update in golang duration format")
+	fs.BoolVar(&c.OrphanedSecretDryRun, "connector-orphaned-secret-dry-run", c.OrphanedSecretDryRun, "Only log the orphaned secrets instead of deleting them")
 }
 
 func (c *ConnectorsConfig) ReadFiles() error {
@@ -0,0 +1,14 @@
+package environments
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/secrets"
+	"github.com/goava/di"
+)
+
+// HealthCheckProviders provides the health checks of the dependencies of the connector service which are not shared
+// with the Kafka service
+func HealthCheckProviders() di.Option {
+	return di.Options(
+		di.Provide(secrets.NewVaultHealthCheck),
+	)
+}
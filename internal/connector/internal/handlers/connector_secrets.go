@@ -2,15 +2,14 @@ package handlers
 
 import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
-	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/vault"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	vault "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/secrets"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/secrets"
 	"github.com/spyzhov/ajson"
 )
 
-const OwningResourcePrefix = "/v1/connector/"
-
 func stripSecretReferences(resource *dbapi.Connector, ct *dbapi.ConnectorType) *errors.ServiceError {
 	// clear out secrets..
 	resource.ServiceAccount.ClientSecret = ""
@@ -45,7 +44,7 @@ func moveSecretsToVault(resource *dbapi.Connector, ct *dbapi.ConnectorType, vaul
 	// move secrets to a vault.
 	if resource.ServiceAccount.ClientSecret != "" {
 		keyId := api.NewID()
-		if err := vault.SetSecretString(keyId, resource.ServiceAccount.ClientSecret, OwningResourcePrefix+resource.ID); err != nil {
+		if err := vault.SetSecretString(keyId, resource.ServiceAccount.ClientSecret, services.ConnectorOwningResourcePrefix+resource.ID); err != nil {
 			return errors.GeneralError("could not store kafka client secret in the vault: %v", err.Error())
 		}
 		resource.ServiceAccount.ClientSecret = ""
@@ -60,7 +59,7 @@ func moveSecretsToVault(resource *dbapi.Connector, ct *dbapi.ConnectorType, vaul
 				if err != nil {
 					return err
 				}
-				err = vault.SetSecretString(keyId, s, OwningResourcePrefix+resource.ID)
+				err = vault.SetSecretString(keyId, s, services.ConnectorOwningResourcePrefix+resource.ID)
 				if err != nil {
 					return err
 				}
@@ -5,78 +5,38 @@ import (
 )
 
 const (
-	// CosFleetManager - metrics prefix
+	// CosFleetManager - metrics prefix, the metrics of the operations of the vault service are registered with it by
+	// the shared secrets service
 	CosFleetManager = "cos_fleet_manager"
 
-	// label for operation name
-	labelOperation = "operation"
-
-	VaultServiceTotalCount   = "vault_service_total_count"
-	VaultServiceSuccessCount = "vault_service_success_count"
-	VaultServiceFailureCount = "vault_service_failure_count"
-	VaultServiceErrorsCount  = "vault_service_errors_count"
+	VaultServiceOrphanedSecretsCount        = "vault_service_orphaned_secrets_count"
+	VaultServiceDeletedOrphanedSecretsCount = "vault_service_deleted_orphaned_secrets_count"
 )
 
-var VaultServiceMetricsLabels = []string{
-	labelOperation,
-}
-
 // #### Metrics for Vault Service ####
 
-var vaultServiceTotalCountMetric = prometheus.NewCounterVec(
-	prometheus.CounterOpts{
-		Subsystem: CosFleetManager,
-		Name:      VaultServiceTotalCount,
-		Help:      "total count of operations since start of vault service",
-	}, VaultServiceMetricsLabels)
-
-func IncreaseVaultServiceTotalCount(operationType string) {
-	labels := prometheus.Labels{
-		labelOperation: operationType,
-	}
-	vaultServiceTotalCountMetric.With(labels).Inc()
-}
-
-var vaultServiceSuccessCountMetric = prometheus.NewCounterVec(
-	prometheus.CounterOpts{
-		Subsystem: CosFleetManager,
-		Name:      VaultServiceSuccessCount,
-		Help:      "count of successful operations of vault service",
-	}, VaultServiceMetricsLabels)
-
-func IncreaseVaultServiceSuccessCount(operationType string) {
-	labels := prometheus.Labels{
-		labelOperation: operationType,
-	}
-	vaultServiceSuccessCountMetric.With(labels).Inc()
-}
-
-var vaultServiceFailureCountMetric = prometheus.NewCounterVec(
-	prometheus.CounterOpts{
+var vaultServiceOrphanedSecretsCountMetric = prometheus.NewGauge(
+	prometheus.GaugeOpts{
 		Subsystem: CosFleetManager,
-		Name:      VaultServiceFailureCount,
-		Help:      "count of system failures (e.g. connectivity issues) in the vault service",
-	}, VaultServiceMetricsLabels)
+		Name:      VaultServiceOrphanedSecretsCount,
+		Help:      "number of orphaned secrets found in the vault by the last garbage collection, including the ones in their grace period",
+	})
 
-func IncreaseVaultServiceFailureCount(operationType string) {
-	labels := prometheus.Labels{
-		labelOperation: operationType,
-	}
-	vaultServiceFailureCountMetric.With(labels).Inc()
+// UpdateVaultServiceOrphanedSecretsCount records the number of orphaned secrets found by the last garbage collection
+func UpdateVaultServiceOrphanedSecretsCount(count int) {
+	vaultServiceOrphanedSecretsCountMetric.Set(float64(count))
 }
 
-var vaultServiceErrorsCountMetric = prometheus.NewCounterVec(
+var vaultServiceDeletedOrphanedSecretsCountMetric = prometheus.NewCounter(
 	prometheus.CounterOpts{
 		Subsystem: CosFleetManager,
-		Name:      VaultServiceErrorsCount,
-		Help:      "count of user level errors (e.g. missing secrets) in the vault service",
-	}, VaultServiceMetricsLabels)
+		Name:      VaultServiceDeletedOrphanedSecretsCount,
+		Help:      "count of orphaned secrets deleted from the vault by the garbage collection",
+	})
 
-func IncreaseVaultServiceErrorsCount(operationType string) {
-	labels := prometheus.Labels{
-		labelOperation: operationType,
-	}
-	vaultServiceErrorsCountMetric.With(labels).Inc()
+// IncreaseVaultServiceDeletedOrphanedSecretsCount counts the orphaned secrets deleted by a garbage collection
+func IncreaseVaultServiceDeletedOrphanedSecretsCount(count int) {
+	vaultServiceDeletedOrphanedSecretsCountMetric.Add(float64(count))
 }
 
 // #### Metrics for Vault Service - End ####
@@ -84,22 +44,17 @@ func IncreaseVaultServiceErrorsCount(operationType string) {
 // register the metric(s)
 func init() {
 	// metrics for vault service
-	prometheus.MustRegister(vaultServiceTotalCountMetric)
-	prometheus.MustRegister(vaultServiceSuccessCountMetric)
-	prometheus.MustRegister(vaultServiceFailureCountMetric)
-	prometheus.MustRegister(vaultServiceErrorsCountMetric)
+	prometheus.MustRegister(vaultServiceOrphanedSecretsCountMetric)
+	prometheus.MustRegister(vaultServiceDeletedOrphanedSecretsCountMetric)
 }
 
-// ResetMetricsForVaultService will reset the metrics related to Vault Service requests
+// ResetMetricsForOrphanedSecrets will reset the metrics related to the garbage collection of the orphaned vault secrets
 // This is needed because if current process is not the leader anymore, the metrics need to be reset otherwise staled data will be scraped
-func ResetMetricsForVaultService() {
-	vaultServiceTotalCountMetric.Reset()
-	vaultServiceSuccessCountMetric.Reset()
-	vaultServiceFailureCountMetric.Reset()
-	vaultServiceErrorsCountMetric.Reset()
+func ResetMetricsForOrphanedSecrets() {
+	vaultServiceOrphanedSecretsCountMetric.Set(0)
 }
 
 // Reset the metrics we have defined. It is mainly used for testing.
 func Reset() {
-	ResetMetricsForVaultService()
+	ResetMetricsForOrphanedSecrets()
 }
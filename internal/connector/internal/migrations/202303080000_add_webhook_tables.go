@@ -0,0 +1,79 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addWebhookTables(migrationId string) *gormigrate.Migration {
+	type WebhookSubscription struct {
+		ID         string `gorm:"primaryKey"`
+		CreatedAt  time.Time
+		UpdatedAt  time.Time
+		DeletedAt  gorm.DeletedAt `gorm:"index"`
+		OrgId      string         `gorm:"index"`
+		Owner      string
+		Url        string
+		Secret     string
+		EventTypes string `gorm:"type:jsonb"`
+	}
+
+	type WebhookEvent struct {
+		ID         string    `gorm:"primaryKey"`
+		CreatedAt  time.Time `gorm:"index"`
+		OrgId      string
+		EventType  string
+		OccurredAt time.Time
+		Payload    string `gorm:"type:jsonb"`
+	}
+
+	type WebhookDelivery struct {
+		ID             string `gorm:"primaryKey"`
+		CreatedAt      time.Time
+		UpdatedAt      time.Time
+		SubscriptionId string `gorm:"index"`
+		EventId        string
+		EventType      string
+		Payload        string `gorm:"type:jsonb"`
+		Status         string `gorm:"index:idx_webhook_deliveries_status_next_attempt_at"`
+		Attempts       int
+		NextAttemptAt  time.Time `gorm:"index:idx_webhook_deliveries_status_next_attempt_at"`
+		LastError      string
+		DeliveredAt    *time.Time
+	}
+
+	return db.CreateMigrationFromActions(migrationId,
+		db.FuncAction(func(tx *gorm.DB) error {
+			// We don't want to delete the webhook tables on rollback because they are shared with the kas-fleet-manager
+			// so we just create them here if they do not exist yet.. but we don't drop them on rollback.
+			if err := tx.Migrator().AutoMigrate(&WebhookSubscription{}, &WebhookEvent{}, &WebhookDelivery{}); err != nil {
+				return err
+			}
+			// the lease of the delivery worker is shared with the kas-fleet-manager as well
+			var leases int64
+			if err := tx.Model(&api.LeaderLease{}).Where("lease_type = ?", "webhook_delivery").Count(&leases).Error; err != nil {
+				return err
+			}
+			if leases > 0 {
+				return nil
+			}
+			now := time.Now().Add(-time.Minute) //set to a expired time
+			return tx.Create(&api.LeaderLease{
+				Expires:   &now,
+				LeaseType: "webhook_delivery",
+				Leader:    api.NewID(),
+			}).Error
+		}, func(tx *gorm.DB) error {
+			return nil
+		}),
+	)
+}
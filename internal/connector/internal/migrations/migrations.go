@@ -50,6 +50,9 @@ var migrations = []*gormigrate.Migration{
 	renameNamespaceProfileAnnotations("202211280000"),
 	addOrgIDAnnotations("202212050000"),
 	addConnectorTypeDeprecated("202301180000"),
+	addAuditEventsTable("202303060000"),
+	addWebhookTables("202303080000"),
+	addOrphanedSecretLease("202303090000"),
 }
 
 func New(dbConfig *db.DatabaseConfig) (*db.Migration, func(), error) {
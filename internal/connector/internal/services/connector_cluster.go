@@ -10,15 +10,16 @@ import (
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/phase"
-	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/vault"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/queryparser"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/secrets"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
 	"github.com/golang/glog"
 	"gorm.io/gorm"
 )
@@ -54,15 +55,17 @@ type connectorClusterService struct {
 	connectionFactory         *db.ConnectionFactory
 	bus                       signalbus.SignalBus
 	connectorTypesService     ConnectorTypesService
-	vaultService              vault.VaultService
+	vaultService              secrets.VaultService
 	keycloakService           sso.KafkaKeycloakService
 	connectorsService         ConnectorsService
 	connectorNamespaceService ConnectorNamespaceService
+	webhookService            webhooks.WebhookService
 }
 
-func NewConnectorClusterService(connectionFactory *db.ConnectionFactory, bus signalbus.SignalBus, vaultService vault.VaultService,
+func NewConnectorClusterService(connectionFactory *db.ConnectionFactory, bus signalbus.SignalBus, vaultService secrets.VaultService,
 	connectorTypesService ConnectorTypesService, connectorsService ConnectorsService,
-	keycloakService sso.KafkaKeycloakService, connectorNamespaceService ConnectorNamespaceService) *connectorClusterService {
+	keycloakService sso.KafkaKeycloakService, connectorNamespaceService ConnectorNamespaceService,
+	webhookService webhooks.WebhookService) *connectorClusterService {
 	return &connectorClusterService{
 		connectionFactory:         connectionFactory,
 		bus:                       bus,
@@ -71,6 +74,7 @@ func NewConnectorClusterService(connectionFactory *db.ConnectionFactory, bus sig
 		connectorsService:         connectorsService,
 		keycloakService:           keycloakService,
 		connectorNamespaceService: connectorNamespaceService,
+		webhookService:            webhookService,
 	}
 }
 
@@ -510,7 +514,7 @@ func (k *connectorClusterService) UpdateConnectorDeploymentStatus(ctx context.Co
 	}
 
 	connector := dbapi.Connector{}
-	if err := dbConn.Select("desired_state").
+	if err := dbConn.Select("desired_state", "organisation_id").
 		Where("id = ?", deployment.ConnectorID).
 		First(&connector).Error; err != nil {
 		return services.HandleGetError("Connector", "id", deployment.ConnectorID, err)
@@ -523,6 +527,7 @@ func (k *connectorClusterService) UpdateConnectorDeploymentStatus(ctx context.Co
 		return services.HandleGetError("Connector", "id", deployment.ConnectorID, err)
 	}
 
+	previousPhase := connectorStatus.Phase
 	connectorStatus.Phase = deploymentStatus.Phase
 	if deploymentStatus.Phase == dbapi.ConnectorStatusPhaseDeleted {
 		// we don't need the deployment anymore...
@@ -536,9 +541,32 @@ func (k *connectorClusterService) UpdateConnectorDeploymentStatus(ctx context.Co
 		return services.HandleUpdateError("Connector status", err)
 	}
 
+	if previousPhase != connectorStatus.Phase {
+		k.notifyConnectorPhaseChange(connector.OrganisationId, deployment.ConnectorID, previousPhase, connectorStatus.Phase)
+	}
+
 	return nil
 }
 
+// notifyConnectorPhaseChange notifies the webhook subscriptions of the organisation of a connector of its new phase.
+// Failures are only logged as the phase has been updated already.
+func (k *connectorClusterService) notifyConnectorPhaseChange(orgId string, connectorId string, previousPhase dbapi.ConnectorStatusPhase, phase dbapi.ConnectorStatusPhase) {
+	event := &webhooks.Event{
+		Type:           webhooks.ConnectorPhaseChanged,
+		OrganisationId: orgId,
+		Resource: webhooks.Resource{
+			Id:   connectorId,
+			Kind: "Connector",
+			Href: fmt.Sprintf("/api/connector_mgmt/v1/kafka_connectors/%s", connectorId),
+		},
+		PreviousPhase: string(previousPhase),
+		Phase:         string(phase),
+	}
+	if err := k.webhookService.Notify(event); err != nil {
+		glog.Errorf("failed to notify the webhook subscriptions of the phase %q of connector %q: %v", phase, connectorId, err)
+	}
+}
+
 func (k *connectorClusterService) FindAvailableNamespace(owner string, orgID string, namespaceID *string) (*dbapi.ConnectorNamespace, *errors.ServiceError) {
 	dbConn := k.connectionFactory.New()
 	var namespaces dbapi.ConnectorNamespaceList
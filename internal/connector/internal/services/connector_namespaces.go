@@ -23,6 +23,8 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/queryparser"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
+	"github.com/golang/glog"
 	"gorm.io/gorm"
 )
 
@@ -53,6 +55,7 @@ type connectorNamespaceService struct {
 	connectorsConfig  *config.ConnectorsConfig
 	quotaConfig       *config.ConnectorsQuotaConfig
 	bus               signalbus.SignalBus
+	webhookService    webhooks.WebhookService
 }
 
 func init() {
@@ -61,12 +64,13 @@ func init() {
 }
 
 func NewConnectorNamespaceService(factory *db.ConnectionFactory, config *config.ConnectorsConfig,
-	quotaConfig *config.ConnectorsQuotaConfig, bus signalbus.SignalBus) *connectorNamespaceService {
+	quotaConfig *config.ConnectorsQuotaConfig, bus signalbus.SignalBus, webhookService webhooks.WebhookService) *connectorNamespaceService {
 	return &connectorNamespaceService{
 		connectionFactory: factory,
 		connectorsConfig:  config,
 		quotaConfig:       quotaConfig,
 		bus:               bus,
+		webhookService:    webhookService,
 	}
 }
 
@@ -368,10 +372,12 @@ func (k *connectorNamespaceService) UpdateConnectorNamespaceStatus(ctx context.C
 		return errors.BadRequest("missing required property version")
 	}
 
+	// the webhook subscriptions are only notified of the change of phase once it is committed
+	var phaseChange *webhooks.Event
 	if err := k.connectionFactory.New().Transaction(func(dbConn *gorm.DB) error {
 		var namespace dbapi.ConnectorNamespace
 		if err := dbConn.Unscoped().Where(`id = ?`, namespaceID).
-			Select("id", "deleted_at", "cluster_id", "version", "status_phase", "status_version", "status_conditions").
+			Select("id", "deleted_at", "cluster_id", "tenant_organisation_id", "version", "status_phase", "status_version", "status_conditions").
 			First(&namespace).Error; err != nil {
 			return services.HandleGetError("Connector namespace", "id", namespaceID, err)
 		}
@@ -385,6 +391,7 @@ func (k *connectorNamespaceService) UpdateConnectorNamespaceStatus(ctx context.C
 			return services.HandleGetError("Connector cluster", "id", namespace.ClusterId, err)
 		}
 
+		previousPhase := namespace.Status.Phase
 		updated, serr := phase.PerformNamespaceOperation(&cluster, &namespace, phase.ConnectNamespace)
 		if serr != nil {
 			return serr
@@ -430,11 +437,32 @@ func (k *connectorNamespaceService) UpdateConnectorNamespaceStatus(ctx context.C
 			}
 		}
 
+		if updated && previousPhase != status.Phase && namespace.TenantOrganisationId != nil {
+			phaseChange = &webhooks.Event{
+				Type:           webhooks.ConnectorNamespacePhaseChanged,
+				OrganisationId: *namespace.TenantOrganisationId,
+				Resource: webhooks.Resource{
+					Id:   namespaceID,
+					Kind: "ConnectorNamespace",
+					Href: fmt.Sprintf("/api/connector_mgmt/v1/kafka_connector_namespaces/%s", namespaceID),
+				},
+				PreviousPhase: string(previousPhase),
+				Phase:         string(status.Phase),
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return services.HandleUpdateError("Connector namespace", err)
 	}
 
+	if phaseChange != nil {
+		// failures are only logged as the phase has been updated already
+		if err := k.webhookService.Notify(phaseChange); err != nil {
+			glog.Errorf("failed to notify the webhook subscriptions of the phase %q of connector namespace %q: %v", phaseChange.Phase, namespaceID, err)
+		}
+	}
+
 	return nil
 }
 
@@ -8,10 +8,10 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
-	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/vault"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	serviceError "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/secrets"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
 
 	"github.com/golang/glog"
@@ -25,7 +25,7 @@ type ConnectorManager struct {
 	connectorService        services.ConnectorsService
 	connectorClusterService services.ConnectorClusterService
 	connectorTypesService   services.ConnectorTypesService
-	vaultService            vault.VaultService
+	vaultService            secrets.VaultService
 	lastVersion             int64
 	db                      *db.ConnectionFactory
 	ctx                     context.Context
@@ -36,7 +36,7 @@ func NewConnectorManager(
 	connectorTypesService services.ConnectorTypesService,
 	connectorService services.ConnectorsService,
 	connectorClusterService services.ConnectorClusterService,
-	vaultService vault.VaultService,
+	vaultService secrets.VaultService,
 	db *db.ConnectionFactory,
 	reconciler workers.Reconciler,
 ) *ConnectorManager {
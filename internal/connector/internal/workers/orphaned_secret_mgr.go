@@ -0,0 +1,214 @@
+package workers
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/metrics"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/secrets"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+var _ workers.Worker = &OrphanedSecretManager{}
+
+// OrphanedSecretManager deletes the secrets of the vault whose owning connector has been deleted, as well as the
+// secrets of the connectors which are no longer referenced by their connector e.g. once their value has been
+// updated. The secrets are stored in the vault before the connectors referencing them are stored in the
+// database, so an orphaned secret is only deleted once it has not been updated for the grace period.
+type OrphanedSecretManager struct {
+	workers.BaseWorker
+	vaultService      secrets.VaultService
+	connectionFactory *db.ConnectionFactory
+	connectorsConfig  *config.ConnectorsConfig
+	lastCheck         time.Time
+}
+
+func NewOrphanedSecretManager(vaultService secrets.VaultService, connectionFactory *db.ConnectionFactory,
+	connectorsConfig *config.ConnectorsConfig, reconciler workers.Reconciler) *OrphanedSecretManager {
+	return &OrphanedSecretManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "orphaned_secret",
+			Reconciler: reconciler,
+		},
+		vaultService:      vaultService,
+		connectionFactory: connectionFactory,
+		connectorsConfig:  connectorsConfig,
+	}
+}
+
+func (m *OrphanedSecretManager) Start() {
+	m.StartWorker(m)
+}
+
+func (m *OrphanedSecretManager) Stop() {
+	m.StopWorker(m)
+	metrics.ResetMetricsForOrphanedSecrets()
+}
+
+func (m *OrphanedSecretManager) Reconcile() []error {
+	interval := m.connectorsConfig.OrphanedSecretCheckInterval
+	if interval <= 0 || time.Since(m.lastCheck) < interval {
+		return nil
+	}
+	glog.Infoln("reconciling orphaned vault secrets")
+
+	orphans, err := m.findOrphanedSecrets()
+	if err != nil {
+		return []error{errors.Wrap(err, "failed to find orphaned vault secrets")}
+	}
+	m.lastCheck = time.Now()
+	metrics.UpdateVaultServiceOrphanedSecretsCount(len(orphans))
+
+	var errs []error
+	deleted := 0
+	for _, name := range orphans {
+		expired, err := m.isGracePeriodExpired(name)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to get the versions of orphaned vault secret %q", name))
+			continue
+		}
+		if !expired {
+			continue
+		}
+		if m.connectorsConfig.OrphanedSecretDryRun {
+			glog.Infof("orphaned vault secret %q would be deleted (dry run)", name)
+			continue
+		}
+		if err := m.vaultService.DeleteSecretString(name); err != nil && !secrets.IsNotFound(err) {
+			errs = append(errs, errors.Wrapf(err, "failed to delete orphaned vault secret %q", name))
+			continue
+		}
+		glog.Infof("deleted orphaned vault secret %q", name)
+		deleted++
+	}
+	metrics.IncreaseVaultServiceDeletedOrphanedSecretsCount(deleted)
+
+	return errs
+}
+
+// findOrphanedSecrets returns the names of the secrets owned by connectors which are orphaned
+func (m *OrphanedSecretManager) findOrphanedSecrets() ([]string, error) {
+	connectorSecrets := map[string][]string{}
+	err := m.vaultService.ForEachSecret(func(name string, owningResource string) bool {
+		if strings.HasPrefix(owningResource, services.ConnectorOwningResourcePrefix) {
+			id := strings.TrimPrefix(owningResource, services.ConnectorOwningResourcePrefix)
+			connectorSecrets[id] = append(connectorSecrets[id], name)
+		}
+		// the secrets of the other resources, e.g. the kafkas when the vault is shared, are left alone
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	secretRefs, err := m.findConnectorSecretRefs(mapKeys(connectorSecrets))
+	if err != nil {
+		return nil, err
+	}
+
+	var orphans []string
+	for id, names := range connectorSecrets {
+		for _, name := range names {
+			// the secrets of deleted connectors have no references
+			if !secretRefs[id][name] {
+				orphans = append(orphans, name)
+			}
+		}
+	}
+	sort.Strings(orphans)
+	return orphans, nil
+}
+
+// findConnectorSecretRefs returns the names of the secrets referenced by each of the given connectors which exists
+func (m *OrphanedSecretManager) findConnectorSecretRefs(ids []string) (map[string]map[string]bool, error) {
+	refs := map[string]map[string]bool{}
+	if len(ids) == 0 {
+		return refs, nil
+	}
+
+	var connectors []dbapi.Connector
+	dbConn := m.connectionFactory.New()
+	if err := dbConn.Select("id", "service_account_client_secret", "connector_spec").Where("id IN (?)", ids).Find(&connectors).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to find the connectors owning vault secrets")
+	}
+
+	for _, connector := range connectors {
+		connectorRefs := map[string]bool{}
+		if connector.ServiceAccount.ClientSecretRef != "" {
+			connectorRefs[connector.ServiceAccount.ClientSecretRef] = true
+		}
+		if err := addConnectorSpecSecretRefs(connector.ConnectorSpec, connectorRefs); err != nil {
+			return nil, errors.Wrapf(err, "failed to read the secrets of connector %q", connector.ID)
+		}
+		refs[connector.ID] = connectorRefs
+	}
+	return refs, nil
+}
+
+// isGracePeriodExpired returns true when the secret has not been updated for the grace period
+func (m *OrphanedSecretManager) isGracePeriodExpired(name string) (bool, error) {
+	versions, err := m.vaultService.ListSecretVersions(name)
+	if secrets.IsNotFound(err) {
+		// the secret has been deleted in the meantime
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if len(versions) == 0 {
+		return false, nil
+	}
+	return time.Since(versions[len(versions)-1].CreatedAt) >= m.connectorsConfig.OrphanedSecretGracePeriod, nil
+}
+
+// addConnectorSpecSecretRefs adds the secrets referenced by the connector spec to refs. The secret fields of the
+// connector specs are replaced by objects holding the kind of the vault and the name of the secret in its ref.
+func addConnectorSpecSecretRefs(spec api.JSON, refs map[string]bool) error {
+	if len(spec) == 0 {
+		return nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(spec, &value); err != nil {
+		return err
+	}
+	addSecretRefs(value, refs)
+	return nil
+}
+
+func addSecretRefs(value interface{}, refs map[string]bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["ref"].(string); ok {
+			if _, ok := v["kind"]; ok {
+				refs[ref] = true
+			}
+		}
+		for _, field := range v {
+			addSecretRefs(field, refs)
+		}
+	case []interface{}:
+		for _, item := range v {
+			addSecretRefs(item, refs)
+		}
+	}
+}
+
+func mapKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
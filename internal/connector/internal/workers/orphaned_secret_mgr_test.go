@@ -0,0 +1,173 @@
+package workers
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/secrets"
+	w "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	mocket "github.com/selvatico/go-mocket"
+
+	"github.com/onsi/gomega"
+)
+
+func TestOrphanedSecretManager_Reconcile(t *testing.T) {
+	type secret struct {
+		owningResource string
+		updatedAt      time.Time
+	}
+	connectorOwner := func(id string) string { return services.ConnectorOwningResourcePrefix + id }
+	longAgo := time.Now().Add(-48 * time.Hour)
+	recently := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		secrets map[string]secret
+		// connectors are the rows of the connectors found in the database
+		connectors    []map[string]interface{}
+		checkInterval time.Duration
+		dryRun        bool
+		forEachErr    error
+		versionsErr   error
+		deleteErr     error
+		wantErr       bool
+		wantDeleted   []string
+	}{
+		{
+			name:    "should not check the vault when the check is disabled",
+			secrets: map[string]secret{"orphan": {owningResource: connectorOwner("deleted-connector"), updatedAt: longAgo}},
+		},
+		{
+			name:          "should return an error when the secrets of the vault cannot be listed",
+			checkInterval: time.Hour,
+			forEachErr:    fmt.Errorf("test"),
+			wantErr:       true,
+		},
+		{
+			name:          "should keep the secrets referenced by their connector",
+			checkInterval: time.Hour,
+			secrets: map[string]secret{
+				"service-account": {owningResource: connectorOwner("connector-id"), updatedAt: longAgo},
+				"spec-secret":     {owningResource: connectorOwner("connector-id"), updatedAt: longAgo},
+			},
+			connectors: []map[string]interface{}{{
+				"id":                            "connector-id",
+				"service_account_client_secret": "service-account",
+				"connector_spec":                []byte(`{"aws": {"secret_key": {"kind": "base64", "ref": "spec-secret"}}}`),
+			}},
+		},
+		{
+			name:          "should delete the secrets no longer referenced by their connector and the secrets of the deleted connectors",
+			checkInterval: time.Hour,
+			secrets: map[string]secret{
+				"service-account":   {owningResource: connectorOwner("connector-id"), updatedAt: longAgo},
+				"previous-value":    {owningResource: connectorOwner("connector-id"), updatedAt: longAgo},
+				"deleted-connector": {owningResource: connectorOwner("deleted-connector-id"), updatedAt: longAgo},
+			},
+			connectors: []map[string]interface{}{{
+				"id":                            "connector-id",
+				"service_account_client_secret": "service-account",
+			}},
+			wantDeleted: []string{"deleted-connector", "previous-value"},
+		},
+		{
+			name:          "should leave the secrets of the other resources alone",
+			checkInterval: time.Hour,
+			secrets: map[string]secret{
+				"kafka":      {owningResource: "kafka-id", updatedAt: longAgo},
+				"not-owned":  {updatedAt: longAgo},
+				"connectors": {owningResource: "/v1/connectors/", updatedAt: longAgo},
+				"cluster":    {owningResource: "/v1/connector_cluster/cluster-id", updatedAt: longAgo},
+			},
+		},
+		{
+			name:          "should not delete the orphaned secrets updated within the grace period",
+			checkInterval: time.Hour,
+			secrets:       map[string]secret{"orphan": {owningResource: connectorOwner("deleted-connector-id"), updatedAt: recently}},
+		},
+		{
+			name:          "should not delete the orphaned secrets in dry run mode",
+			checkInterval: time.Hour,
+			dryRun:        true,
+			secrets:       map[string]secret{"orphan": {owningResource: connectorOwner("deleted-connector-id"), updatedAt: longAgo}},
+		},
+		{
+			name:          "should ignore the orphaned secrets deleted in the meantime",
+			checkInterval: time.Hour,
+			secrets:       map[string]secret{"orphan": {owningResource: connectorOwner("deleted-connector-id"), updatedAt: longAgo}},
+			versionsErr:   secrets.NotFound,
+		},
+		{
+			name:          "should return an error when the versions of an orphaned secret cannot be listed",
+			checkInterval: time.Hour,
+			secrets:       map[string]secret{"orphan": {owningResource: connectorOwner("deleted-connector-id"), updatedAt: longAgo}},
+			versionsErr:   fmt.Errorf("test"),
+			wantErr:       true,
+		},
+		{
+			name:          "should ignore the orphaned secrets which no longer exist when they are deleted",
+			checkInterval: time.Hour,
+			secrets:       map[string]secret{"orphan": {owningResource: connectorOwner("deleted-connector-id"), updatedAt: longAgo}},
+			deleteErr:     secrets.NotFound,
+			wantDeleted:   []string{"orphan"},
+		},
+		{
+			name:          "should return an error when an orphaned secret cannot be deleted",
+			checkInterval: time.Hour,
+			secrets:       map[string]secret{"orphan": {owningResource: connectorOwner("deleted-connector-id"), updatedAt: longAgo}},
+			deleteErr:     fmt.Errorf("test"),
+			wantErr:       true,
+			wantDeleted:   []string{"orphan"},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			vaultService := &secrets.VaultServiceMock{
+				ForEachSecretFunc: func(f func(name string, owningResource string) bool) error {
+					for name, s := range tt.secrets {
+						if !f(name, s.owningResource) {
+							break
+						}
+					}
+					return tt.forEachErr
+				},
+				ListSecretVersionsFunc: func(name string) ([]secrets.SecretVersion, error) {
+					if tt.versionsErr != nil {
+						return nil, tt.versionsErr
+					}
+					return []secrets.SecretVersion{{Version: "1", CreatedAt: tt.secrets[name].updatedAt}}, nil
+				},
+				DeleteSecretStringFunc: func(name string) error {
+					return tt.deleteErr
+				},
+			}
+
+			connectionFactory := db.NewMockConnectionFactory(nil)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(`FROM "connectors"`).WithReply(tt.connectors)
+
+			connectorsConfig := &config.ConnectorsConfig{
+				OrphanedSecretCheckInterval: tt.checkInterval,
+				OrphanedSecretGracePeriod:   24 * time.Hour,
+				OrphanedSecretDryRun:        tt.dryRun,
+			}
+
+			m := NewOrphanedSecretManager(vaultService, connectionFactory, connectorsConfig, w.Reconciler{})
+			g.Expect(len(m.Reconcile()) > 0).To(gomega.Equal(tt.wantErr))
+
+			var deleted []string
+			for _, call := range vaultService.DeleteSecretStringCalls() {
+				deleted = append(deleted, call.Name)
+			}
+			g.Expect(deleted).To(gomega.Equal(tt.wantDeleted))
+		})
+	}
+}
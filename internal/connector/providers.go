@@ -5,15 +5,16 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/environments"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/handlers"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/metrics"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/migrations"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/routes"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/authz"
-	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/vault"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/workers"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	environments2 "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/providers"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/secrets"
 	coreWorkers "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
 
 	"github.com/goava/di"
@@ -37,7 +38,10 @@ func ConfigProviders(kafkaEnabled bool) di.Option {
 			di.Provide(environments.NewStageEnvLoader, di.Tags{"env": environments2.StageEnv}),
 			di.Provide(environments.NewIntegrationEnvLoader, di.Tags{"env": environments2.IntegrationEnv}),
 			di.Provide(environments.NewTestingEnvLoader, di.Tags{"env": environments2.TestingEnv}),
-			vault.ConfigProviders(),
+			secrets.ConfigProviders(func(c *secrets.Config) {
+				c.SecretPrefix = "managed-connectors"
+				c.MetricsSubsystem = metrics.CosFleetManager
+			}),
 			providers.CoreConfigProviders(),
 			result,
 			di.Provide(environments2.Func(serviceProvidersNoKafka)),
@@ -64,6 +68,7 @@ func serviceProviders() di.Option {
 		di.Provide(workers.NewClusterManager, di.As(new(coreWorkers.Worker))),
 		di.Provide(workers.NewConnectorManager, di.As(new(coreWorkers.Worker))),
 		di.Provide(workers.NewNamespaceManager, di.As(new(coreWorkers.Worker))),
+		di.Provide(workers.NewOrphanedSecretManager, di.As(new(coreWorkers.Worker))),
 		di.Provide(workers.NewApiServerReadyCondition),
 	)
 }
@@ -71,5 +76,7 @@ func serviceProviders() di.Option {
 func serviceProvidersNoKafka() di.Option {
 	return di.Options(
 		di.Provide(handlers.NewAuthenticationBuilder),
+		// the Kafka service checks the vault when the connectors are enabled in the kas-fleet-manager
+		environments.HealthCheckProviders(),
 	)
 }
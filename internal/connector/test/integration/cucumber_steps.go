@@ -16,8 +16,8 @@ import (
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/api/public"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/config"
-	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/services/vault"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/connector/internal/workers"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/secrets"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/test/cucumber"
 	"github.com/cucumber/godog"
 )
@@ -27,11 +27,11 @@ type extender struct {
 }
 
 func (s *extender) iResetTheVaultCounters() error {
-	var service vault.VaultService
+	var service secrets.VaultService
 	if err := s.Suite.Helper.Env.ServiceContainer.Resolve(&service); err != nil {
 		return err
 	}
-	if v, ok := service.(*vault.TmpVaultService); ok {
+	if v, ok := service.(*secrets.TmpVaultService); ok {
 		v.ResetCounters()
 	}
 	return nil
@@ -39,12 +39,12 @@ func (s *extender) iResetTheVaultCounters() error {
 
 func (s *extender) theVaultDeleteCounterShouldBe(expected int64) error {
 	// we can only check the delete count on the TmpVault service impl...
-	var service vault.VaultService
+	var service secrets.VaultService
 	if err := s.Suite.Helper.Env.ServiceContainer.Resolve(&service); err != nil {
 		return err
 	}
 
-	if v, ok := service.(*vault.TmpVaultService); ok {
+	if v, ok := service.(*secrets.TmpVaultService); ok {
 		actual := v.Counters().Deletes
 		if actual != expected {
 			return fmt.Errorf("vault delete counter does not match expected: %v, actual: %v", expected, actual)
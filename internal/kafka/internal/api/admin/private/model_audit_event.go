@@ -0,0 +1,36 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// AuditEvent struct for AuditEvent
+type AuditEvent struct {
+	Id        string    `json:"id"`
+	Kind      string    `json:"kind"`
+	Href      string    `json:"href"`
+	CreatedAt time.Time `json:"created_at"`
+	// The username of the caller
+	Actor string `json:"actor"`
+	// The organisation of the caller
+	OrgId  string `json:"org_id,omitempty"`
+	Method string `json:"method"`
+	// The name of the API operation that was called (e.g. update-kafka)
+	RouteName string `json:"route_name,omitempty"`
+	Path      string `json:"path"`
+	// The id of the resource the call was made against
+	TargetId   string `json:"target_id,omitempty"`
+	StatusCode int32  `json:"status_code"`
+	// The body of the request. The values of the sensitive fields are redacted
+	RequestBody string `json:"request_body,omitempty"`
+}
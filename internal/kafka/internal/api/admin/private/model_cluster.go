@@ -0,0 +1,50 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// Cluster struct for Cluster
+type Cluster struct {
+	Id            string `json:"id"`
+	Kind          string `json:"kind"`
+	Href          string `json:"href"`
+	ClusterId     string `json:"cluster_id"`
+	ExternalId    string `json:"external_id,omitempty"`
+	CloudProvider string `json:"cloud_provider,omitempty"`
+	Region        string `json:"region,omitempty"`
+	MultiAz       bool   `json:"multi_az"`
+	// Values: [cluster_accepted, cluster_provisioning, cluster_provisioned, cleanup, waiting_for_kas_fleetshard_operator, ready, full, failed, deprovisioning]
+	Status string `json:"status"`
+	// Values: [managed, enterprise]
+	ClusterType string `json:"cluster_type,omitempty"`
+	// Values: [ocm, aws_eks, standalone]
+	ProviderType string `json:"provider_type,omitempty"`
+	// The organisation owning the data plane cluster. Only set for enterprise data plane clusters
+	OrganizationId string `json:"organization_id,omitempty"`
+	// Comma separated list of the Kafka instance types that can be placed on the data plane cluster
+	SupportedInstanceType string `json:"supported_instance_type,omitempty"`
+	ClusterDns            string `json:"cluster_dns,omitempty"`
+	// The capacity of the data plane cluster per Kafka instance type
+	DynamicCapacityInfo map[string]ClusterDynamicCapacityInfo `json:"dynamic_capacity_info,omitempty"`
+	// The number of Kafka instances placed on the data plane cluster
+	KafkaInstanceCount int32 `json:"kafka_instance_count"`
+	// When the data plane cluster has been cordoned. Not set when the data plane cluster is not cordoned
+	CordonedAt *time.Time `json:"cordoned_at,omitempty"`
+	// Indicates that the data plane cluster has been cordoned by an administrator. Such a data plane cluster is never uncordoned by the dynamic scale down
+	ManuallyCordoned bool `json:"manually_cordoned"`
+	// When the reconcile of the resources of the data plane cluster has been requested. Not set once the resources have been reconciled
+	ResourcesReconcileRequestedAt *time.Time `json:"resources_reconcile_requested_at,omitempty"`
+	CreatedAt                     time.Time  `json:"created_at,omitempty"`
+	UpdatedAt                     time.Time  `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,19 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// ClusterPlacementDryRun struct for ClusterPlacementDryRun
+type ClusterPlacementDryRun struct {
+	Kind string `json:"kind"`
+	// The cluster placement strategy configured for the instance type of the Kafka instance
+	ConfiguredStrategy string                       `json:"configured_strategy"`
+	Items              []ClusterPlacementDryRunItem `json:"items"`
+}
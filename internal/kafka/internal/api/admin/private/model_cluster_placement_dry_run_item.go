@@ -0,0 +1,20 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// ClusterPlacementDryRunItem struct for ClusterPlacementDryRunItem
+type ClusterPlacementDryRunItem struct {
+	Strategy string `json:"strategy"`
+	// The data plane cluster picked by the strategy. It is empty when no data plane cluster can receive the Kafka instance
+	ClusterId string `json:"cluster_id,omitempty"`
+	// The reason why the strategy failed to pick a data plane cluster
+	Error string `json:"error,omitempty"`
+}
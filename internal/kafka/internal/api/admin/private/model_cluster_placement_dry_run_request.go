@@ -0,0 +1,27 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// ClusterPlacementDryRunRequest struct for ClusterPlacementDryRunRequest
+type ClusterPlacementDryRunRequest struct {
+	CloudProvider string `json:"cloud_provider"`
+	Region        string `json:"region"`
+	MultiAz       bool   `json:"multi_az,omitempty"`
+	// The instance type of the Kafka instance. The configured cluster placement strategy depends on it
+	InstanceType string `json:"instance_type"`
+	SizeId       string `json:"size_id"`
+	// The billing model of the Kafka instance. Enterprise Kafka instances are only placed on the data plane cluster given in cluster_id
+	BillingModel string `json:"billing_model,omitempty"`
+	// The data plane cluster of enterprise Kafka instances
+	ClusterId string `json:"cluster_id,omitempty"`
+	// The organisation the enterprise Kafka instance belongs to
+	OrganisationId string `json:"organisation_id,omitempty"`
+}
@@ -0,0 +1,29 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// ClusterScalingDecision struct for ClusterScalingDecision
+type ClusterScalingDecision struct {
+	Id        string    `json:"id"`
+	Kind      string    `json:"kind"`
+	ClusterId string    `json:"cluster_id"`
+	CreatedAt time.Time `json:"created_at"`
+	// The decision taken about the data plane cluster
+	Decision string `json:"decision"`
+	// Why the decision has been taken
+	Reason string `json:"reason"`
+	// Indicates that the decision has only been evaluated. No action has been taken on the data plane cluster
+	DryRun bool `json:"dry_run"`
+}
@@ -0,0 +1,17 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// ClusterUpdateRequest struct for ClusterUpdateRequest
+type ClusterUpdateRequest struct {
+	// The status the data plane cluster is forced into. Values: [cluster_accepted, cluster_provisioning, cluster_provisioned, cleanup, waiting_for_kas_fleetshard_operator, ready, full, failed, deprovisioning]
+	Status string `json:"status"`
+}
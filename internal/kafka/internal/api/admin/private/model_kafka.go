@@ -53,4 +53,12 @@ type Kafka struct {
 	Namespace              string                           `json:"namespace,omitempty"`
 	SizeId                 string                           `json:"size_id,omitempty"`
 	MaxDataRetentionSize   SupportedKafkaSizeBytesValueItem `json:"max_data_retention_size,omitempty"`
+	// Status of the migration of the Kafka instance to another data plane cluster. Values: [provisioning, cutting_over, deprovisioning, failed]. Not set when the Kafka instance is not being migrated
+	MigrationStatus string `json:"migration_status,omitempty"`
+	// Data plane cluster the Kafka instance is being deprovisioned from after its migration
+	MigrationSourceClusterId string `json:"migration_source_cluster_id,omitempty"`
+	// Data plane cluster the Kafka instance is being migrated to
+	MigrationTargetClusterId string `json:"migration_target_cluster_id,omitempty"`
+	// Reason of the failure of the last migration of the Kafka instance
+	MigrationDetails string `json:"migration_details,omitempty"`
 }
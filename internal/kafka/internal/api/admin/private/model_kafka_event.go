@@ -0,0 +1,32 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// KafkaEvent struct for KafkaEvent
+type KafkaEvent struct {
+	Id        string    `json:"id"`
+	Kind      string    `json:"kind"`
+	KafkaId   string    `json:"kafka_id"`
+	CreatedAt time.Time `json:"created_at"`
+	// Values: [status, suspend_requested, resume_requested, resize_requested, resized, resize_failed, upgrade_started, upgraded]
+	Type string `json:"type"`
+	// Status of the Kafka instance before the event. It is not set for the first event of the Kafka instance
+	PreviousStatus  string `json:"previous_status,omitempty"`
+	Status          string `json:"status"`
+	FailedReason    string `json:"failed_reason,omitempty"`
+	PromotionStatus string `json:"promotion_status,omitempty"`
+	// Additional information about the event e.g. the error reported by the data plane
+	Details string `json:"details,omitempty"`
+}
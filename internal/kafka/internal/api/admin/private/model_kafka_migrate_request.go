@@ -0,0 +1,17 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// KafkaMigrateRequest struct for KafkaMigrateRequest
+type KafkaMigrateRequest struct {
+	// Data plane cluster to migrate the Kafka instance to. When not set, the first ready data plane cluster of the cloud provider and region of the Kafka instance that can receive it is chosen. It is required for enterprise Kafka instances
+	ClusterId string `json:"cluster_id,omitempty"`
+}
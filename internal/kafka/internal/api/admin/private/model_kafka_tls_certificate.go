@@ -0,0 +1,37 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// KafkaTlsCertificate struct for KafkaTlsCertificate
+type KafkaTlsCertificate struct {
+	Id   string `json:"id"`
+	Kind string `json:"kind"`
+	Href string `json:"href"`
+	// The base domain of the Kafka instances the wildcard certificate is issued for
+	Domain       string `json:"domain"`
+	Subject      string `json:"subject,omitempty"`
+	Issuer       string `json:"issuer,omitempty"`
+	SerialNumber string `json:"serial_number,omitempty"`
+	// Not set when the certificate could not be read from the certificate storage
+	NotBefore *time.Time `json:"not_before,omitempty"`
+	// When the certificate expires. Not set when the certificate could not be read from the certificate storage
+	NotAfter *time.Time `json:"not_after,omitempty"`
+	// When the last renewal attempt of the certificate has been recorded. The attempts of the proactive renewal are all recorded while the on-demand renewals are only recorded when their outcome changes. Not set when none has been recorded
+	LastRenewalAttemptAt *time.Time `json:"last_renewal_attempt_at,omitempty"`
+	// The error of the last renewal attempt. Not set when it succeeded
+	LastRenewalError string `json:"last_renewal_error,omitempty"`
+	// When the certificate has last been read from the certificate storage
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -19,4 +19,6 @@ type KafkaUpdateRequest struct {
 	MaxDataRetentionSize string `json:"max_data_retention_size,omitempty"`
 	// boolean value indicating whether kafka should be suspended or not depending on the value provided. Suspended kafkas have their certain resources removed and become inaccessible until fully unsuspended (restored to Ready state).
 	Suspended *bool `json:"suspended,omitempty"`
+	// Roll out the pending upgrade of the Kafka instance without waiting for its maintenance window. It only applies to the pending upgrade, the next upgrades wait for the maintenance window again
+	ForceUpgrade bool `json:"force_upgrade,omitempty"`
 }
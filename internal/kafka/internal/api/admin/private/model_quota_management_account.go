@@ -0,0 +1,18 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// QuotaManagementAccount struct for QuotaManagementAccount
+type QuotaManagementAccount struct {
+	Username            string                        `json:"username"`
+	MaxAllowedInstances int32                         `json:"max_allowed_instances,omitempty"`
+	GrantedQuota        []QuotaManagementGrantedQuota `json:"granted_quota,omitempty"`
+}
@@ -0,0 +1,21 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// QuotaManagementBillingModel struct for QuotaManagementBillingModel
+type QuotaManagementBillingModel struct {
+	// The id of the billing model. For example: standard, eval
+	Id string `json:"id"`
+	// The date the billing model expires, in the 'YYYY-MM-DD {+,-}HH:MM' format. The billing model never expires if not set
+	ExpirationDate string `json:"expiration_date,omitempty"`
+	// Maximum number of streaming units that can be created with this billing model. When 0 the value of the owning entry is used
+	MaxAllowedInstances int32 `json:"max_allowed_instances,omitempty"`
+}
@@ -0,0 +1,17 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// QuotaManagementGrantedQuota struct for QuotaManagementGrantedQuota
+type QuotaManagementGrantedQuota struct {
+	InstanceTypeId     string                        `json:"instance_type_id"`
+	KafkaBillingModels []QuotaManagementBillingModel `json:"kafka_billing_models,omitempty"`
+}
@@ -0,0 +1,23 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// QuotaManagementOrganisation struct for QuotaManagementOrganisation
+type QuotaManagementOrganisation struct {
+	Id   string `json:"id"`
+	Kind string `json:"kind"`
+	Href string `json:"href"`
+	// Whether any user of the organisation can use its quota when no users are registered
+	AnyUser             bool                          `json:"any_user"`
+	MaxAllowedInstances int32                         `json:"max_allowed_instances"`
+	RegisteredUsers     []QuotaManagementAccount      `json:"registered_users,omitempty"`
+	GrantedQuota        []QuotaManagementGrantedQuota `json:"granted_quota,omitempty"`
+}
@@ -0,0 +1,21 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// QuotaManagementOrganisationRequest struct for QuotaManagementOrganisationRequest
+type QuotaManagementOrganisationRequest struct {
+	// The id of the organisation
+	Id                  string                        `json:"id"`
+	AnyUser             bool                          `json:"any_user,omitempty"`
+	MaxAllowedInstances int32                         `json:"max_allowed_instances,omitempty"`
+	RegisteredUsers     []QuotaManagementAccount      `json:"registered_users,omitempty"`
+	GrantedQuota        []QuotaManagementGrantedQuota `json:"granted_quota,omitempty"`
+}
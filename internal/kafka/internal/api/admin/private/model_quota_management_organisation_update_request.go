@@ -0,0 +1,19 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// QuotaManagementOrganisationUpdateRequest Only the provided fields are updated. An empty list removes all the registered users or granted quota
+type QuotaManagementOrganisationUpdateRequest struct {
+	AnyUser             *bool                         `json:"any_user,omitempty"`
+	MaxAllowedInstances *int32                        `json:"max_allowed_instances,omitempty"`
+	RegisteredUsers     []QuotaManagementAccount      `json:"registered_users,omitempty"`
+	GrantedQuota        []QuotaManagementGrantedQuota `json:"granted_quota,omitempty"`
+}
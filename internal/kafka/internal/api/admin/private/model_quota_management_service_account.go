@@ -0,0 +1,21 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// QuotaManagementServiceAccount struct for QuotaManagementServiceAccount
+type QuotaManagementServiceAccount struct {
+	Id                  string                        `json:"id"`
+	Kind                string                        `json:"kind"`
+	Href                string                        `json:"href"`
+	Username            string                        `json:"username"`
+	MaxAllowedInstances int32                         `json:"max_allowed_instances"`
+	GrantedQuota        []QuotaManagementGrantedQuota `json:"granted_quota,omitempty"`
+}
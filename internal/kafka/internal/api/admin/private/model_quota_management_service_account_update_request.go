@@ -0,0 +1,17 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// QuotaManagementServiceAccountUpdateRequest Only the provided fields are updated. An empty list removes all the granted quota
+type QuotaManagementServiceAccountUpdateRequest struct {
+	MaxAllowedInstances *int32                        `json:"max_allowed_instances,omitempty"`
+	GrantedQuota        []QuotaManagementGrantedQuota `json:"granted_quota,omitempty"`
+}
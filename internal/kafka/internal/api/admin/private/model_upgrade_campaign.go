@@ -0,0 +1,49 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// UpgradeCampaign struct for UpgradeCampaign
+type UpgradeCampaign struct {
+	Id   string `json:"id"`
+	Kind string `json:"kind"`
+	Href string `json:"href"`
+	Name string `json:"name"`
+	// The Strimzi version the Kafka instances are upgraded to. The Strimzi version of the instances is not changed if empty
+	StrimziVersion string `json:"strimzi_version,omitempty"`
+	// The Kafka version the Kafka instances are upgraded to. The Kafka version of the instances is not changed if empty
+	KafkaVersion string `json:"kafka_version,omitempty"`
+	// The Kafka IBP version the Kafka instances are upgraded to. The Kafka IBP version of the instances is not changed if empty
+	KafkaIbpVersion string `json:"kafka_ibp_version,omitempty"`
+	// Search query selecting the Kafka instances to upgrade. All the Kafka instances are selected if empty
+	KafkaSelector string `json:"kafka_selector"`
+	// Search query selecting the data plane clusters whose Kafka instances are upgraded. All the data plane clusters are selected if empty
+	ClusterSelector string `json:"cluster_selector"`
+	// The maximum number of Kafka instances upgraded in each wave
+	BatchSize int32 `json:"batch_size"`
+	// The number of Kafka instances failing to upgrade the campaign is paused at
+	FailureThreshold int32 `json:"failure_threshold"`
+	// Values: [in_progress, paused, completed, cancelled]
+	Status string `json:"status"`
+	// Why the campaign has been paused
+	StatusReason string `json:"status_reason,omitempty"`
+	// The number of the latest wave started, 0 until the first wave is started
+	CurrentWave     int32     `json:"current_wave"`
+	KafkasUpgrading int32     `json:"kafkas_upgrading"`
+	KafkasUpgraded  int32     `json:"kafkas_upgraded"`
+	KafkasFailed    int32     `json:"kafkas_failed"`
+	KafkasSkipped   int32     `json:"kafkas_skipped"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
@@ -0,0 +1,31 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+import (
+	"time"
+)
+
+// UpgradeCampaignKafka struct for UpgradeCampaignKafka
+type UpgradeCampaignKafka struct {
+	Id        string `json:"id"`
+	Kind      string `json:"kind"`
+	KafkaId   string `json:"kafka_id"`
+	ClusterId string `json:"cluster_id"`
+	// The number of the wave the Kafka instance is processed in
+	Wave int32 `json:"wave"`
+	// Values: [upgrading, upgraded, failed, skipped]
+	Status string `json:"status"`
+	// Why the Kafka instance failed or was skipped
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
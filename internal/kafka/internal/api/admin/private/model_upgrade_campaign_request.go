@@ -0,0 +1,27 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// UpgradeCampaignRequest struct for UpgradeCampaignRequest
+type UpgradeCampaignRequest struct {
+	Name            string `json:"name"`
+	StrimziVersion  string `json:"strimzi_version,omitempty"`
+	KafkaVersion    string `json:"kafka_version,omitempty"`
+	KafkaIbpVersion string `json:"kafka_ibp_version,omitempty"`
+	// Search query selecting the Kafka instances to upgrade. Allowed fields are `id`, `name`, `region`, `cloud_provider`, `cluster_id`, `multi_az`, `owner`, `organisation_id`, `instance_type`, `size_id`, `actual_kafka_billing_model`, `actual_strimzi_version`, `actual_kafka_version` and `actual_kafka_ibp_version`. Example: instance_type = standard and actual_kafka_version = 3.3.1
+	KafkaSelector string `json:"kafka_selector,omitempty"`
+	// Search query selecting the data plane clusters whose Kafka instances are upgraded. Allowed fields are `cluster_id`, `external_id`, `cloud_provider`, `region`, `status`, `cluster_type`, `provider_type`, `organization_id` and `supported_instance_type`. Example: region = us-east-1
+	ClusterSelector string `json:"cluster_selector,omitempty"`
+	// The maximum number of Kafka instances upgraded in each wave
+	BatchSize int32 `json:"batch_size"`
+	// The number of Kafka instances failing to upgrade the campaign is paused at. Defaults to 1
+	FailureThreshold int32 `json:"failure_threshold,omitempty"`
+}
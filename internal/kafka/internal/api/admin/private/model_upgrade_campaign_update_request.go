@@ -0,0 +1,19 @@
+/*
+ * Kafka Service Fleet Manager Admin APIs
+ *
+ * The admin APIs for the fleet manager of Kafka service
+ *
+ * API version: 0.2.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// UpgradeCampaignUpdateRequest Only the provided fields are updated
+type UpgradeCampaignUpdateRequest struct {
+	// Values: [in_progress, paused, cancelled]
+	Status           *string `json:"status,omitempty"`
+	BatchSize        *int32  `json:"batch_size,omitempty"`
+	FailureThreshold *int32  `json:"failure_threshold,omitempty"`
+}
@@ -0,0 +1,57 @@
+package dbapi
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"gorm.io/gorm"
+)
+
+type ClusterScalingDecisionType string
+
+const (
+	// ClusterScalingDecisionKept the cluster is not removed e.g. because it is not empty or because removing it would trigger a scale up
+	ClusterScalingDecisionKept ClusterScalingDecisionType = "kept"
+	// ClusterScalingDecisionCordoned the cluster can be removed. It no longer receives new kafkas.
+	ClusterScalingDecisionCordoned ClusterScalingDecisionType = "cordoned"
+	// ClusterScalingDecisionWouldCordon the cluster can be removed but the dynamic scale down runs in dry run mode: it has not been cordoned
+	ClusterScalingDecisionWouldCordon ClusterScalingDecisionType = "would_cordon"
+	// ClusterScalingDecisionUncordoned the cluster can no longer be removed. It receives new kafkas again.
+	ClusterScalingDecisionUncordoned ClusterScalingDecisionType = "uncordoned"
+	// ClusterScalingDecisionWaitingForGracePeriod the cluster is cordoned and it is waiting for the grace period to elapse before being removed
+	ClusterScalingDecisionWaitingForGracePeriod ClusterScalingDecisionType = "waiting_for_grace_period"
+	// ClusterScalingDecisionMigratingKafkas the grace period elapsed and the remaining kafkas are being migrated off the cluster
+	ClusterScalingDecisionMigratingKafkas ClusterScalingDecisionType = "migrating_kafkas"
+	// ClusterScalingDecisionDeprovisioned the cluster has been marked for deprovisioning
+	ClusterScalingDecisionDeprovisioned ClusterScalingDecisionType = "deprovisioned"
+)
+
+func (d ClusterScalingDecisionType) String() string {
+	return string(d)
+}
+
+// ClusterScalingDecision explains a decision taken by the dynamic scale down about a data plane cluster. Decisions are
+// never updated once they are created.
+type ClusterScalingDecision struct {
+	ID        string `json:"id" gorm:"primaryKey"`
+	ClusterId string
+	CreatedAt time.Time
+	Decision  ClusterScalingDecisionType
+	Reason    string
+	// DryRun indicates that the decision has only been evaluated, no action has been taken on the cluster
+	DryRun bool
+}
+
+type ClusterScalingDecisionList []*ClusterScalingDecision
+
+func (d *ClusterScalingDecision) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == "" {
+		d.ID = api.NewID()
+	}
+	return nil
+}
+
+// HasSameOutcomeAs returns true when the decision, the reason and the dry run mode of both decisions are the same
+func (d *ClusterScalingDecision) HasSameOutcomeAs(other *ClusterScalingDecision) bool {
+	return d.Decision == other.Decision && d.Reason == other.Reason && d.DryRun == other.DryRun
+}
@@ -13,6 +13,9 @@ type DataPlaneKafkaStatus struct {
 	StrimziVersion  string
 	KafkaIBPVersion string
 	AdminServerURI  string
+	// CanaryPrincipal is the client id of the service account the canary is authenticated with
+	CanaryPrincipal string
+	CanaryReady     bool
 }
 
 type DataPlaneKafkaStatusCondition struct {
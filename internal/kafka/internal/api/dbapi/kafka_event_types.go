@@ -0,0 +1,57 @@
+package dbapi
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"gorm.io/gorm"
+)
+
+type KafkaEventType string
+
+const (
+	// KafkaEventTypeStatus is the type of the events recording a change of the status of the instance, or details
+	// reported by the data plane about its status e.g. an error
+	KafkaEventTypeStatus           KafkaEventType = "status"
+	KafkaEventTypeSuspendRequested KafkaEventType = "suspend_requested"
+	KafkaEventTypeResumeRequested  KafkaEventType = "resume_requested"
+	KafkaEventTypeResizeRequested  KafkaEventType = "resize_requested"
+	KafkaEventTypeResized          KafkaEventType = "resized"
+	KafkaEventTypeResizeFailed     KafkaEventType = "resize_failed"
+	KafkaEventTypeUpgradeStarted   KafkaEventType = "upgrade_started"
+	KafkaEventTypeUpgraded         KafkaEventType = "upgraded"
+)
+
+func (t KafkaEventType) String() string {
+	return string(t)
+}
+
+// KafkaEvent is an entry of the lifecycle history of a Kafka instance. A new event is recorded every time the status
+// of the instance changes, when the data plane reports details about the instance e.g. an error, and when the
+// instance is suspended, resumed, resized or upgraded.
+// Events are never updated once they are created.
+type KafkaEvent struct {
+	ID        string `json:"id" gorm:"primaryKey"`
+	KafkaId   string
+	CreatedAt time.Time
+	Type      KafkaEventType
+	// PreviousStatus is the status of the Kafka instance in the previous event, if any
+	PreviousStatus  string
+	Status          string
+	FailedReason    string
+	PromotionStatus string
+	// Details contains additional information about the event e.g. the error reported by the data plane.
+	// It is only intended for administrators.
+	Details string
+	// OrganisationId is the organisation of the Kafka instance, used to notify its webhook subscriptions. It is not stored.
+	OrganisationId string `gorm:"-"`
+}
+
+type KafkaEventList []*KafkaEvent
+
+func (e *KafkaEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = api.NewID()
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/encryption"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 
@@ -18,21 +19,21 @@ import (
 
 type KafkaRequest struct {
 	api.Meta
-	Region                           string `json:"region"`
-	ClusterID                        string `json:"cluster_id" gorm:"index"`
-	CloudProvider                    string `json:"cloud_provider"`
-	MultiAZ                          bool   `json:"multi_az"`
-	Name                             string `json:"name" gorm:"index"`
-	Status                           string `json:"status" gorm:"index"`
-	CanaryServiceAccountClientID     string `json:"canary_service_account_client_id"`
-	CanaryServiceAccountClientSecret string `json:"canary_service_account_client_secret"`
-	SubscriptionId                   string `json:"subscription_id"`
-	Owner                            string `json:"owner" gorm:"index"` // TODO: ocm owner?
-	OwnerAccountId                   string `json:"owner_account_id"`
-	BootstrapServerHost              string `json:"bootstrap_server_host"`
-	AdminApiServerURL                string `json:"admin_api_server_url"`
-	OrganisationId                   string `json:"organisation_id" gorm:"index"`
-	FailedReason                     string `json:"failed_reason"`
+	Region                           string                     `json:"region"`
+	ClusterID                        string                     `json:"cluster_id" gorm:"index"`
+	CloudProvider                    string                     `json:"cloud_provider"`
+	MultiAZ                          bool                       `json:"multi_az"`
+	Name                             string                     `json:"name" gorm:"index"`
+	Status                           string                     `json:"status" gorm:"index"`
+	CanaryServiceAccountClientID     string                     `json:"canary_service_account_client_id"`
+	CanaryServiceAccountClientSecret encryption.EncryptedString `json:"canary_service_account_client_secret"`
+	SubscriptionId                   string                     `json:"subscription_id"`
+	Owner                            string                     `json:"owner" gorm:"index"` // TODO: ocm owner?
+	OwnerAccountId                   string                     `json:"owner_account_id"`
+	BootstrapServerHost              string                     `json:"bootstrap_server_host"`
+	AdminApiServerURL                string                     `json:"admin_api_server_url"`
+	OrganisationId                   string                     `json:"organisation_id" gorm:"index"`
+	FailedReason                     string                     `json:"failed_reason"`
 	// PlacementId field should be updated every time when a KafkaRequest is assigned to an OSD cluster (even if it's the same one again)
 	PlacementId string `json:"placement_id"`
 
@@ -56,8 +57,11 @@ type KafkaRequest struct {
 	RoutesCreated bool `json:"routes_created"`
 	// Namespace is the namespace of the provisioned kafka instance.
 	// We store this in the database to ensure that old kafkas whose namespace contained "owner-<kafka-id>" information will continue to work.
-	Namespace                string               `json:"namespace"`
-	ReauthenticationEnabled  bool                 `json:"reauthentication_enabled"`
+	Namespace               string `json:"namespace"`
+	ReauthenticationEnabled bool   `json:"reauthentication_enabled"`
+	// RoutesCreationId is the id of the DNS change creating the CNAME records of the routes, qualified by the kind of
+	// the DNS provider which made it e.g. "route53:/change/C2682N5HXP0BZ4". The ids which are not qualified are the ids
+	// of Route53 changes.
 	RoutesCreationId         string               `json:"routes_creation_id"`
 	SizeId                   string               `json:"size_id"`
 	BillingCloudAccountId    string               `json:"billing_cloud_account_id"`
@@ -66,6 +70,28 @@ type KafkaRequest struct {
 	DesiredKafkaBillingModel string               `json:"desired_kafka_billing_model"`
 	PromotionStatus          KafkaPromotionStatus `json:"promotion_status"`
 	PromotionDetails         string               `json:"promotion_details"`
+	// DesiredSizeId is the size the Kafka instance is being resized to. It is empty when no resize is in progress
+	DesiredSizeId string            `json:"desired_size_id"`
+	ResizeStatus  KafkaResizeStatus `json:"resize_status"`
+	ResizeDetails string            `json:"resize_details"`
+	// ResizeSubscriptionId is the subscription the quota of the DesiredSizeId is reserved in. It is recorded as soon as
+	// the quota is reserved so that the quota is not reserved again when the resize is retried
+	ResizeSubscriptionId string `json:"resize_subscription_id"`
+	// MigrationStatus is the status of the migration of the Kafka instance to another data plane cluster.
+	// It is empty when the Kafka instance is not being migrated and its last migration, if any, has completed.
+	MigrationStatus KafkaMigrationStatus `json:"migration_status"`
+	// MigrationSourceClusterId is the data plane cluster the Kafka instance has been migrated from.
+	// It is set from the cut over of the Kafka instance to its target cluster until it is deprovisioned from the source cluster.
+	MigrationSourceClusterId string `json:"migration_source_cluster_id"`
+	// MigrationTargetClusterId is the data plane cluster the Kafka instance is migrated to.
+	// It is set until the Kafka instance is cut over to it or, when the migration fails, until the Kafka instance is deprovisioned from it.
+	MigrationTargetClusterId string `json:"migration_target_cluster_id"`
+	// MigrationRoutes are the routes of the Kafka instance on the target cluster of its migration
+	MigrationRoutes api.JSON `json:"migration_routes"`
+	// MigrationRoutesChangeId is the id of the DNS change pointing the routes of the Kafka instance to the target cluster,
+	// qualified by the kind of the DNS provider like RoutesCreationId
+	MigrationRoutesChangeId string `json:"migration_routes_change_id"`
+	MigrationDetails        string `json:"migration_details"`
 	// ExpiresAt contains the timestamp of when a Kafka instance is scheduled to expire.
 	// On expiration, the Kafka instance will be marked for deletion, its status will be set to 'deprovision'.
 	ExpiresAt sql.NullTime `json:"expires_at"`
@@ -75,6 +101,39 @@ type KafkaRequest struct {
 	KafkasRoutesBaseDomainTLSKeyRef string
 	// KafkasRoutesBaseDomainTLSCrtRef is the key referencing the TLS certificate crt (public part of the certificate) for the base kafka domain
 	KafkasRoutesBaseDomainTLSCrtRef string
+	// SuspendedBy contains the username of the user that suspended the Kafka instance.
+	// It is empty when the instance has not been suspended by a user e.g. when it was suspended on entering its grace period.
+	SuspendedBy string `json:"suspended_by"`
+	// SuspendedAt contains the timestamp of when a user suspended the Kafka instance
+	SuspendedAt sql.NullTime `json:"suspended_at"`
+	// MaintenanceWindowDay is the day of the weekly maintenance window chosen for the Kafka instance e.g. 'monday'.
+	// It is empty when the maintenance window of the organisation of the Kafka instance applies.
+	MaintenanceWindowDay string `json:"maintenance_window_day"`
+	// MaintenanceWindowStartHour is the hour (UTC) the weekly maintenance window of the Kafka instance starts at
+	MaintenanceWindowStartHour int `json:"maintenance_window_start_hour"`
+	// UpgradeForced indicates that an administrator requested the pending upgrade of the Kafka instance to be rolled out
+	// without waiting for its maintenance window. It is reset once the upgrade has completed.
+	UpgradeForced bool `json:"upgrade_forced"`
+	// UpgradeReleasedAt is the start of the latest maintenance window the pending upgrade of the Kafka instance has been
+	// released to the data plane in. It is reset whenever the desired versions change.
+	UpgradeReleasedAt *time.Time `json:"upgrade_released_at"`
+	// PendingCanaryServiceAccountClientID is the client id of the service account the canary of the Kafka instance is
+	// being rotated to. It is empty when no rotation of the canary credentials is in progress.
+	// The client secrets of the canary service accounts are stored in the vault, CanaryServiceAccountClientSecret is
+	// only set for Kafka instances whose secret has not been moved to the vault yet.
+	PendingCanaryServiceAccountClientID string `json:"pending_canary_service_account_client_id"`
+	// PendingCanaryServiceAccountReady indicates that the canary reported being ready with the pending service account
+	PendingCanaryServiceAccountReady bool `json:"pending_canary_service_account_ready"`
+	// CanaryServiceAccountRotatedAt contains the timestamp of the last rotation of the canary credentials
+	CanaryServiceAccountRotatedAt *time.Time `json:"canary_service_account_rotated_at"`
+	// Version is bumped by a database trigger on every insert or update of the Kafka request.
+	// It is used by the kas-fleetshard agent to only watch for changes since the last version it has seen.
+	Version int64 `json:"version" gorm:"type:bigserial;index"`
+
+	// storedStatus is the status of the Kafka request as stored in the database, as far as it is known: the status it
+	// was loaded with, then the status of its latest recorded transition. It is used to record the status transitions
+	// in the lifecycle history of the Kafka instance without reading its latest event.
+	storedStatus string
 }
 
 type KafkaPromotionStatus string
@@ -105,6 +164,35 @@ func ParseKafkaPromotionStatus(status string) (KafkaPromotionStatus, error) {
 	return parsedStatus, nil
 }
 
+type KafkaResizeStatus string
+
+const (
+	KafkaResizeStatusResizing KafkaResizeStatus = "resizing"
+	KafkaResizeStatusFailed   KafkaResizeStatus = "failed"
+	KafkaResizeStatusNoResize KafkaResizeStatus = ""
+)
+
+func (s KafkaResizeStatus) String() string {
+	return string(s)
+}
+
+type KafkaMigrationStatus string
+
+const (
+	// KafkaMigrationStatusProvisioning the Kafka instance is being provisioned on the target cluster. It is still served by the source cluster.
+	KafkaMigrationStatusProvisioning KafkaMigrationStatus = "provisioning"
+	// KafkaMigrationStatusCuttingOver the Kafka instance is ready on the target cluster and its routes are being pointed to the target cluster.
+	KafkaMigrationStatusCuttingOver KafkaMigrationStatus = "cutting_over"
+	// KafkaMigrationStatusDeprovisioning the Kafka instance is served by the target cluster and is being deprovisioned from the source cluster.
+	KafkaMigrationStatusDeprovisioning KafkaMigrationStatus = "deprovisioning"
+	KafkaMigrationStatusFailed         KafkaMigrationStatus = "failed"
+	KafkaMigrationStatusNoMigration    KafkaMigrationStatus = ""
+)
+
+func (s KafkaMigrationStatus) String() string {
+	return string(s)
+}
+
 type KafkaList []*KafkaRequest
 type KafkaIndex map[string]*KafkaRequest
 
@@ -125,6 +213,22 @@ func (k *KafkaRequest) BeforeCreate(scope *gorm.DB) error {
 	return nil
 }
 
+func (k *KafkaRequest) AfterFind(tx *gorm.DB) error {
+	k.storedStatus = k.Status
+	return nil
+}
+
+// StoredStatus returns the status of the Kafka request as stored in the database, empty for a Kafka request which has
+// not been loaded from the database
+func (k *KafkaRequest) StoredStatus() string {
+	return k.storedStatus
+}
+
+// SetStoredStatus sets the status of the Kafka request as stored in the database once it has been updated
+func (k *KafkaRequest) SetStoredStatus(status string) {
+	k.storedStatus = status
+}
+
 func (k *KafkaRequest) GetRoutes() ([]DataPlaneKafkaRoute, error) {
 	var routes []DataPlaneKafkaRoute
 	if k.Routes == nil {
@@ -146,6 +250,82 @@ func (k *KafkaRequest) SetRoutes(routes []DataPlaneKafkaRoute) error {
 	}
 }
 
+func (k *KafkaRequest) GetMigrationRoutes() ([]DataPlaneKafkaRoute, error) {
+	var routes []DataPlaneKafkaRoute
+	if k.MigrationRoutes == nil {
+		return routes, nil
+	}
+	if err := json.Unmarshal(k.MigrationRoutes, &routes); err != nil {
+		return nil, err
+	}
+	return routes, nil
+}
+
+func (k *KafkaRequest) SetMigrationRoutes(routes []DataPlaneKafkaRoute) error {
+	r, err := json.Marshal(routes)
+	if err != nil {
+		return err
+	}
+	k.MigrationRoutes = r
+	return nil
+}
+
+// MigrationInProgress returns whether the Kafka instance is deployed on another data plane cluster than its own
+// because of a migration: either the migration has not completed yet or the Kafka instance has not been
+// deprovisioned from the target cluster of a failed migration yet.
+func (k *KafkaRequest) MigrationInProgress() bool {
+	return k.MigrationTargetClusterId != "" || k.MigrationSourceClusterId != ""
+}
+
+// MigrationDeprovisionedFrom returns whether the kafka has to be deprovisioned from the given cluster because of its
+// migration: from the source cluster once the kafka has been cut over to the target cluster and from the target
+// cluster when the migration failed.
+func (k *KafkaRequest) MigrationDeprovisionedFrom(clusterID string) bool {
+	if clusterID == "" || clusterID == k.ClusterID {
+		return false
+	}
+	return clusterID == k.MigrationSourceClusterId ||
+		(clusterID == k.MigrationTargetClusterId && k.MigrationStatus == KafkaMigrationStatusFailed)
+}
+
+// OwnMaintenanceWindow returns the maintenance window chosen for the Kafka instance. It is nil when no maintenance
+// window has been chosen for the Kafka instance.
+func (k *KafkaRequest) OwnMaintenanceWindow() *MaintenanceWindowSchedule {
+	if k.MaintenanceWindowDay == "" {
+		return nil
+	}
+	schedule, err := NewMaintenanceWindowSchedule(k.MaintenanceWindowDay, k.MaintenanceWindowStartHour)
+	if err != nil {
+		return nil
+	}
+	return schedule
+}
+
+// HasPendingUpgrade returns true when one of the desired versions of the Kafka instance differs from the version
+// running on the data plane. The versions which have never been reported by the data plane are not pending.
+func (k *KafkaRequest) HasPendingUpgrade() bool {
+	isPending := func(desired, actual string) bool {
+		return actual != "" && desired != "" && desired != actual
+	}
+	return isPending(k.DesiredStrimziVersion, k.ActualStrimziVersion) ||
+		isPending(k.DesiredKafkaVersion, k.ActualKafkaVersion) ||
+		isPending(k.DesiredKafkaIBPVersion, k.ActualKafkaIBPVersion)
+}
+
+// CanaryServiceAccountRotationInProgress returns true when the canary credentials of the Kafka instance are being rotated
+func (k *KafkaRequest) CanaryServiceAccountRotationInProgress() bool {
+	return k.PendingCanaryServiceAccountClientID != ""
+}
+
+// PublishedCanaryServiceAccountClientID returns the client id of the service account published to the canary of the
+// Kafka instance: the pending one while a rotation is in progress, the current one otherwise
+func (k *KafkaRequest) PublishedCanaryServiceAccountClientID() string {
+	if k.CanaryServiceAccountRotationInProgress() {
+		return k.PendingCanaryServiceAccountClientID
+	}
+	return k.CanaryServiceAccountClientID
+}
+
 // GetExpirationTime returns when the Kafka request will expire based on the
 // provided lifespanSeconds value. lifespanSeconds is assumed to be greater
 // than 0
@@ -235,6 +415,22 @@ func (k *KafkaRequest) CanBeAutomaticallySuspended() bool {
 	return arrays.Contains(validSuspensionStatuses, k.Status)
 }
 
+// IsSuspendedByUser returns true if the kafka instance has been suspended, or is being suspended, on a user's request
+func (k *KafkaRequest) IsSuspendedByUser() bool {
+	return k.SuspendedBy != "" && arrays.Contains(constants.GetSuspendedStatuses(), k.Status)
+}
+
+// GetGracePeriodStart returns the start of the grace period of the kafka instance given the grace period days of its billing model.
+// The returned boolean is false when the instance has no expiration date set, in which case it has no grace period
+func (k *KafkaRequest) GetGracePeriodStart(gracePeriodDays int) (time.Time, bool) {
+	if !k.ExpiresAt.Valid {
+		return time.Time{}, false
+	}
+
+	durationGracePeriodDays := time.Duration(gracePeriodDays*86400) * time.Second
+	return k.ExpiresAt.Time.Add(-durationGracePeriodDays), true
+}
+
 // DesiredBillingModelIsEnterprise returns true if the Kafka has enterprise billing model.
 // Otherwise returns false.
 func (k *KafkaRequest) DesiredBillingModelIsEnterprise() bool {
@@ -261,3 +457,8 @@ func (k *KafkaRequest) IsUsingSharedTLSCertificate(kafkaConfig *config.KafkaConf
 func (k *KafkaRequest) IsADeveloperInstance() bool {
 	return k.InstanceType == types.DEVELOPER.String()
 }
+
+// IsUpgrading returns true when the data plane reports an upgrade of the Kafka instance in progress
+func (k *KafkaRequest) IsUpgrading() bool {
+	return k.StrimziUpgrading || k.KafkaUpgrading || k.KafkaIBPUpgrading
+}
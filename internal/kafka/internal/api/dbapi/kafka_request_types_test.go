@@ -1,7 +1,9 @@
 package dbapi
 
 import (
+	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
@@ -184,3 +186,169 @@ func TestKafkaRequest_IsADeveloperInstance(t *testing.T) {
 		})
 	}
 }
+
+func TestKafkaRequest_IsSuspendedByUser(t *testing.T) {
+	type fields struct {
+		Status      string
+		SuspendedBy string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   bool
+	}{
+		{
+			name: "return true if the kafka is suspended and was suspended by a user",
+			fields: fields{
+				Status:      constants.KafkaRequestStatusSuspended.String(),
+				SuspendedBy: "some-user",
+			},
+			want: true,
+		},
+		{
+			name: "return true if the kafka is being suspended by a user",
+			fields: fields{
+				Status:      constants.KafkaRequestStatusSuspending.String(),
+				SuspendedBy: "some-user",
+			},
+			want: true,
+		},
+		{
+			name: "return false if the kafka is suspended but not by a user",
+			fields: fields{
+				Status: constants.KafkaRequestStatusSuspended.String(),
+			},
+			want: false,
+		},
+		{
+			name: "return false if the kafka is not suspended",
+			fields: fields{
+				Status:      constants.KafkaRequestStatusResuming.String(),
+				SuspendedBy: "some-user",
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			t.Parallel()
+			k := &KafkaRequest{
+				Status:      testcase.fields.Status,
+				SuspendedBy: testcase.fields.SuspendedBy,
+			}
+			g.Expect(k.IsSuspendedByUser()).To(gomega.Equal(testcase.want))
+		})
+	}
+}
+
+func TestKafkaRequest_GetGracePeriodStart(t *testing.T) {
+	expiresAt := time.Date(2023, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		expiresAt       sql.NullTime
+		gracePeriodDays int
+		wantStart       time.Time
+		wantOk          bool
+	}{
+		{
+			name:            "return false if the kafka has no expiration date",
+			expiresAt:       sql.NullTime{},
+			gracePeriodDays: 14,
+			wantOk:          false,
+		},
+		{
+			name:            "return the start of the grace period based on the expiration date",
+			expiresAt:       sql.NullTime{Time: expiresAt, Valid: true},
+			gracePeriodDays: 14,
+			wantStart:       time.Date(2023, 2, 24, 0, 0, 0, 0, time.UTC),
+			wantOk:          true,
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			t.Parallel()
+			k := &KafkaRequest{
+				ExpiresAt: testcase.expiresAt,
+			}
+			start, ok := k.GetGracePeriodStart(testcase.gracePeriodDays)
+			g.Expect(ok).To(gomega.Equal(testcase.wantOk))
+			g.Expect(start).To(gomega.Equal(testcase.wantStart))
+		})
+	}
+}
+
+func TestKafkaRequest_HasPendingUpgrade(t *testing.T) {
+	tests := []struct {
+		name  string
+		kafka KafkaRequest
+		want  bool
+	}{
+		{
+			name: "return false if the desired versions are running",
+			kafka: KafkaRequest{
+				DesiredStrimziVersion: "strimzi-cluster-operator.v0.32.0-1", ActualStrimziVersion: "strimzi-cluster-operator.v0.32.0-1",
+				DesiredKafkaVersion: "3.3.1", ActualKafkaVersion: "3.3.1",
+				DesiredKafkaIBPVersion: "3.3", ActualKafkaIBPVersion: "3.3",
+			},
+			want: false,
+		},
+		{
+			name: "return false if the versions have not been reported by the data plane yet",
+			kafka: KafkaRequest{
+				DesiredStrimziVersion:  "strimzi-cluster-operator.v0.32.0-1",
+				DesiredKafkaVersion:    "3.3.1",
+				DesiredKafkaIBPVersion: "3.3",
+			},
+			want: false,
+		},
+		{
+			name: "return true if one of the desired versions differs from the running version",
+			kafka: KafkaRequest{
+				DesiredStrimziVersion: "strimzi-cluster-operator.v0.32.0-1", ActualStrimziVersion: "strimzi-cluster-operator.v0.32.0-1",
+				DesiredKafkaVersion: "3.4.0", ActualKafkaVersion: "3.3.1",
+				DesiredKafkaIBPVersion: "3.3", ActualKafkaIBPVersion: "3.3",
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			t.Parallel()
+			g.Expect(testcase.kafka.HasPendingUpgrade()).To(gomega.Equal(testcase.want))
+		})
+	}
+}
+
+func TestKafkaRequest_PublishedCanaryServiceAccountClientID(t *testing.T) {
+	tests := []struct {
+		name  string
+		kafka KafkaRequest
+		want  string
+	}{
+		{
+			name:  "return the current canary service account when no rotation is in progress",
+			kafka: KafkaRequest{CanaryServiceAccountClientID: "canary-kafka-id"},
+			want:  "canary-kafka-id",
+		},
+		{
+			name:  "return the pending canary service account while the canary credentials are rotated",
+			kafka: KafkaRequest{CanaryServiceAccountClientID: "canary-kafka-id", PendingCanaryServiceAccountClientID: "canary-kafka-id-1679616000"},
+			want:  "canary-kafka-id-1679616000",
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			t.Parallel()
+			g.Expect(testcase.kafka.PublishedCanaryServiceAccountClientID()).To(gomega.Equal(testcase.want))
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package dbapi
+
+import (
+	"time"
+)
+
+// KafkaTLSCertificate is the inventory entry of the automatically managed wildcard tls certificate of a base domain of
+// the kafkas. The entries are refreshed from the certificate storage, so that the certificates close to their expiry
+// and the failed renewals can be found without reading the storage.
+type KafkaTLSCertificate struct {
+	ID        string `json:"id" gorm:"primaryKey"`
+	CreatedAt time.Time
+	// UpdatedAt is the last time the entry was refreshed from the certificate storage
+	UpdatedAt time.Time
+	// Domain is the base domain of the kafkas the wildcard certificate is issued for
+	Domain string
+	// TLSCertRef and TLSKeyRef are the keys of the certificate and of its private key in the certificate storage
+	TLSCertRef   string
+	TLSKeyRef    string
+	Subject      string
+	Issuer       string
+	SerialNumber string
+	NotBefore    *time.Time
+	NotAfter     *time.Time
+	// LastRenewalAttemptAt is the time of the last recorded renewal attempt of the certificate, nil if none was
+	// recorded. The attempts of the proactive renewal are all recorded while the on-demand renewals done when managing
+	// the certificates of the kafkas are only recorded when their outcome changes.
+	LastRenewalAttemptAt *time.Time
+	// LastRenewalError is the error of the last renewal attempt, empty if it succeeded
+	LastRenewalError string
+}
+
+type KafkaTLSCertificateList []*KafkaTLSCertificate
+
+// ExpiresWithin returns true when the certificate expires within the given duration. The certificates which have not
+// been read from the storage yet are not considered to expire.
+func (c *KafkaTLSCertificate) ExpiresWithin(d time.Duration) bool {
+	return c.NotAfter != nil && time.Until(*c.NotAfter) < d
+}
+
+// Lifetime returns the validity period of the certificate, 0 if it has not been read from the storage yet
+func (c *KafkaTLSCertificate) Lifetime() time.Duration {
+	if c.NotBefore == nil || c.NotAfter == nil {
+		return 0
+	}
+	return c.NotAfter.Sub(*c.NotBefore)
+}
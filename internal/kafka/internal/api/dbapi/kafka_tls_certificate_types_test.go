@@ -0,0 +1,49 @@
+package dbapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func TestKafkaTLSCertificate_ExpiresWithin(t *testing.T) {
+	inTenDays := time.Now().Add(10 * 24 * time.Hour)
+
+	tests := []struct {
+		name        string
+		certificate *KafkaTLSCertificate
+		want        bool
+	}{
+		{
+			name:        "should return false when the certificate has not been read from the storage",
+			certificate: &KafkaTLSCertificate{},
+			want:        false,
+		},
+		{
+			name:        "should return true when the certificate expires within the duration",
+			certificate: &KafkaTLSCertificate{NotAfter: &inTenDays},
+			want:        true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(tt.certificate.ExpiresWithin(21 * 24 * time.Hour)).To(gomega.Equal(tt.want))
+		})
+	}
+
+	g := gomega.NewWithT(t)
+	g.Expect((&KafkaTLSCertificate{NotAfter: &inTenDays}).ExpiresWithin(7 * 24 * time.Hour)).To(gomega.BeFalse())
+}
+
+func TestKafkaTLSCertificate_Lifetime(t *testing.T) {
+	g := gomega.NewWithT(t)
+	notBefore := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := notBefore.Add(90 * 24 * time.Hour)
+
+	g.Expect((&KafkaTLSCertificate{}).Lifetime()).To(gomega.BeZero())
+	g.Expect((&KafkaTLSCertificate{NotBefore: &notBefore, NotAfter: &notAfter}).Lifetime()).To(gomega.Equal(90 * 24 * time.Hour))
+}
@@ -0,0 +1,22 @@
+package dbapi
+
+import (
+	"time"
+)
+
+// KafkaTombstone records that a kafka has left a data plane cluster, i.e. the kafka has been deleted, migrated away
+// from the cluster or is no longer in a status managed by the kas-fleetshard agent of the cluster. The tombstones are
+// written by a database trigger on the kafka_requests table and are returned to the agents watching the changes of the
+// kafkas of a cluster from a version, as those kafkas are no longer returned by the version filtered listing. They are
+// pruned once the agent asks for the changes since a later version.
+type KafkaTombstone struct {
+	KafkaId   string `gorm:"primaryKey"`
+	ClusterId string `gorm:"primaryKey"`
+	// Version is the version of the kafka request the kafka left the cluster with
+	Version   int64 `gorm:"primaryKey"`
+	Name      string
+	Namespace string
+	CreatedAt time.Time
+}
+
+type KafkaTombstoneList []*KafkaTombstone
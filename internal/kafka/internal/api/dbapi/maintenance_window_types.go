@@ -0,0 +1,91 @@
+package dbapi
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"gorm.io/gorm"
+)
+
+// MaintenanceWindow is the weekly maintenance window chosen by an organisation. It applies to the Kafka instances of
+// the organisation for which no maintenance window has been chosen.
+type MaintenanceWindow struct {
+	ID             string `json:"id" gorm:"primaryKey"`
+	OrganisationId string `json:"organisation_id"`
+	// DayOfWeek is the day the maintenance window starts on e.g. 'monday'
+	DayOfWeek string `json:"day_of_week"`
+	// StartHour is the hour (UTC) the maintenance window starts at
+	StartHour int       `json:"start_hour"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (w *MaintenanceWindow) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == "" {
+		w.ID = api.NewID()
+	}
+	return nil
+}
+
+// Schedule returns the schedule of the maintenance window. It is nil when the maintenance window is invalid.
+func (w *MaintenanceWindow) Schedule() *MaintenanceWindowSchedule {
+	schedule, err := NewMaintenanceWindowSchedule(w.DayOfWeek, w.StartHour)
+	if err != nil {
+		return nil
+	}
+	return schedule
+}
+
+// MaintenanceWindowSchedule is a weekly maintenance window. The upgrades of the Kafka instances are only rolled out
+// while their maintenance window is open.
+type MaintenanceWindowSchedule struct {
+	DayOfWeek time.Weekday
+	StartHour int
+}
+
+// NewMaintenanceWindowSchedule returns the schedule of the maintenance window starting at the given hour (UTC) of the
+// given day of the week e.g. 'monday'
+func NewMaintenanceWindowSchedule(dayOfWeek string, startHour int) (*MaintenanceWindowSchedule, error) {
+	if startHour < 0 || startHour > 23 {
+		return nil, fmt.Errorf("the start hour of the maintenance window must be between 0 and 23, got %d", startHour)
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), dayOfWeek) {
+			return &MaintenanceWindowSchedule{DayOfWeek: day, StartHour: startHour}, nil
+		}
+	}
+	return nil, fmt.Errorf("%q is not a day of the week", dayOfWeek)
+}
+
+// DayOfWeekName returns the day the maintenance window starts on in lowercase e.g. 'monday'
+func (s *MaintenanceWindowSchedule) DayOfWeekName() string {
+	return strings.ToLower(s.DayOfWeek.String())
+}
+
+// LatestStart returns the start of the latest maintenance window that started before or at the given time
+func (s *MaintenanceWindowSchedule) LatestStart(now time.Time) time.Time {
+	now = now.UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day(), s.StartHour, 0, 0, 0, time.UTC)
+	start = start.AddDate(0, 0, -int((now.Weekday()-s.DayOfWeek+7)%7))
+	if start.After(now) {
+		start = start.AddDate(0, 0, -7)
+	}
+	return start
+}
+
+// IsOpen returns true when the maintenance window lasting the given duration is open at the given time
+func (s *MaintenanceWindowSchedule) IsOpen(now time.Time, duration time.Duration) bool {
+	return now.Before(s.LatestStart(now).Add(duration))
+}
+
+// NextStart returns the start of the maintenance window lasting the given duration that is either open at the given
+// time or that opens next
+func (s *MaintenanceWindowSchedule) NextStart(now time.Time, duration time.Duration) time.Time {
+	start := s.LatestStart(now)
+	if now.Before(start.Add(duration)) {
+		return start
+	}
+	return start.AddDate(0, 0, 7)
+}
@@ -0,0 +1,105 @@
+package dbapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func TestNewMaintenanceWindowSchedule(t *testing.T) {
+	tests := []struct {
+		name      string
+		dayOfWeek string
+		startHour int
+		want      *MaintenanceWindowSchedule
+		wantErr   bool
+	}{
+		{
+			name:      "should parse the day of the week regardless of its case",
+			dayOfWeek: "Tuesday",
+			startHour: 22,
+			want:      &MaintenanceWindowSchedule{DayOfWeek: time.Tuesday, StartHour: 22},
+		},
+		{
+			name:      "should return an error if the day of the week is unknown",
+			dayOfWeek: "tue",
+			wantErr:   true,
+		},
+		{
+			name:      "should return an error if the start hour is out of range",
+			dayOfWeek: "sunday",
+			startHour: 24,
+			wantErr:   true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			got, err := NewMaintenanceWindowSchedule(tt.dayOfWeek, tt.startHour)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func TestMaintenanceWindowSchedule(t *testing.T) {
+	// 2023-03-20 is a monday
+	schedule := MaintenanceWindowSchedule{DayOfWeek: time.Monday, StartHour: 22}
+	duration := 4 * time.Hour
+	windowStart := time.Date(2023, 3, 20, 22, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		now             time.Time
+		wantLatestStart time.Time
+		wantOpen        bool
+		wantNextStart   time.Time
+	}{
+		{
+			name:            "should be closed before the window starts in the same week",
+			now:             time.Date(2023, 3, 20, 21, 59, 0, 0, time.UTC),
+			wantLatestStart: windowStart.AddDate(0, 0, -7),
+			wantNextStart:   windowStart,
+		},
+		{
+			name:            "should be open when the window starts",
+			now:             windowStart,
+			wantLatestStart: windowStart,
+			wantOpen:        true,
+			wantNextStart:   windowStart,
+		},
+		{
+			name:            "should be open when the window spans the next day",
+			now:             time.Date(2023, 3, 21, 1, 30, 0, 0, time.UTC),
+			wantLatestStart: windowStart,
+			wantOpen:        true,
+			wantNextStart:   windowStart,
+		},
+		{
+			name:            "should be closed once the window has elapsed",
+			now:             time.Date(2023, 3, 21, 2, 0, 0, 0, time.UTC),
+			wantLatestStart: windowStart,
+			wantNextStart:   windowStart.AddDate(0, 0, 7),
+		},
+		{
+			name:            "should compare the times in UTC",
+			now:             time.Date(2023, 3, 21, 1, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60)),
+			wantLatestStart: windowStart,
+			wantOpen:        true,
+			wantNextStart:   windowStart,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(schedule.LatestStart(tt.now)).To(gomega.Equal(tt.wantLatestStart))
+			g.Expect(schedule.IsOpen(tt.now, duration)).To(gomega.Equal(tt.wantOpen))
+			g.Expect(schedule.NextStart(tt.now, duration)).To(gomega.Equal(tt.wantNextStart))
+		})
+	}
+}
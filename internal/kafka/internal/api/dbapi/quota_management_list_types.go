@@ -0,0 +1,110 @@
+package dbapi
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/quota_management"
+)
+
+// QuotaManagementListOrganisation is an organisation entry of the quota management list
+type QuotaManagementListOrganisation struct {
+	// OrganisationId is the id of the organisation. It uniquely identifies the entry
+	OrganisationId      string `gorm:"primaryKey"`
+	AnyUser             bool
+	MaxAllowedInstances int
+	// RegisteredUsers is the list of quota_management.Account registered in the organisation
+	RegisteredUsers api.JSON
+	// GrantedQuota is the quota_management.QuotaList granted to the organisation
+	GrantedQuota api.JSON
+	// ManagedByConfiguration tells whether the entry is defined in the quota management list configuration file. Such
+	// entries are read-only through the admin API
+	ManagedByConfiguration bool
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+}
+
+// QuotaManagementListServiceAccount is a service account entry of the quota management list
+type QuotaManagementListServiceAccount struct {
+	// Username is the username of the service account. It uniquely identifies the entry
+	Username            string `gorm:"primaryKey"`
+	MaxAllowedInstances int
+	// GrantedQuota is the quota_management.QuotaList granted to the service account
+	GrantedQuota api.JSON
+	// ManagedByConfiguration tells whether the entry is defined in the quota management list configuration file. Such
+	// entries are read-only through the admin API
+	ManagedByConfiguration bool
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+}
+
+func NewQuotaManagementListOrganisation(org quota_management.Organisation) (*QuotaManagementListOrganisation, error) {
+	// api.JSON can not be scanned from NULL: always store a list
+	if org.RegisteredUsers == nil {
+		org.RegisteredUsers = quota_management.AccountList{}
+	}
+	if org.GrantedQuota == nil {
+		org.GrantedQuota = quota_management.QuotaList{}
+	}
+	registeredUsers, err := json.Marshal(org.RegisteredUsers)
+	if err != nil {
+		return nil, err
+	}
+	grantedQuota, err := json.Marshal(org.GrantedQuota)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QuotaManagementListOrganisation{
+		OrganisationId:      org.Id,
+		AnyUser:             org.AnyUser,
+		MaxAllowedInstances: org.MaxAllowedInstances,
+		RegisteredUsers:     registeredUsers,
+		GrantedQuota:        grantedQuota,
+	}, nil
+}
+
+func (o *QuotaManagementListOrganisation) ToOrganisation() (quota_management.Organisation, error) {
+	org := quota_management.Organisation{
+		Id:                  o.OrganisationId,
+		AnyUser:             o.AnyUser,
+		MaxAllowedInstances: o.MaxAllowedInstances,
+	}
+	if err := o.RegisteredUsers.Unmarshal(&org.RegisteredUsers); err != nil {
+		return quota_management.Organisation{}, err
+	}
+	if err := o.GrantedQuota.Unmarshal(&org.GrantedQuota); err != nil {
+		return quota_management.Organisation{}, err
+	}
+
+	return org, nil
+}
+
+func NewQuotaManagementListServiceAccount(account quota_management.Account) (*QuotaManagementListServiceAccount, error) {
+	if account.GrantedQuota == nil {
+		account.GrantedQuota = quota_management.QuotaList{}
+	}
+	grantedQuota, err := json.Marshal(account.GrantedQuota)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QuotaManagementListServiceAccount{
+		Username:            account.Username,
+		MaxAllowedInstances: account.MaxAllowedInstances,
+		GrantedQuota:        grantedQuota,
+	}, nil
+}
+
+func (a *QuotaManagementListServiceAccount) ToAccount() (quota_management.Account, error) {
+	account := quota_management.Account{
+		Username:            a.Username,
+		MaxAllowedInstances: a.MaxAllowedInstances,
+	}
+	if err := a.GrantedQuota.Unmarshal(&account.GrantedQuota); err != nil {
+		return quota_management.Account{}, err
+	}
+
+	return account, nil
+}
@@ -0,0 +1,20 @@
+package dbapi
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/encryption"
+)
+
+// TLSCertificateStorageItem is an item stored by the certificate management library when the kafka tls certificates are
+// stored in the database e.g. a certificate, its private key or the ACME account.
+type TLSCertificateStorageItem struct {
+	ID        string `gorm:"primaryKey"`
+	CreatedAt time.Time
+	// UpdatedAt is the last time the value of the item was stored
+	UpdatedAt time.Time
+	// Key is the path like key of the item given by the library e.g. "certificates/<issuer>/<domain>/<domain>.crt"
+	Key string
+	// Value is the base64 encoded value of the item, it is encrypted at rest as the items include private keys
+	Value encryption.EncryptedString
+}
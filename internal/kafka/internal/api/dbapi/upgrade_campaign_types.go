@@ -0,0 +1,147 @@
+package dbapi
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"gorm.io/gorm"
+)
+
+type UpgradeCampaignStatus string
+
+const (
+	// UpgradeCampaignStatusInProgress the kafkas of the campaign are being upgraded wave after wave
+	UpgradeCampaignStatusInProgress UpgradeCampaignStatus = "in_progress"
+	// UpgradeCampaignStatusPaused no new wave is started. The campaign is paused by an administrator or when the
+	// number of kafkas that failed to upgrade reaches the failure threshold of the campaign.
+	UpgradeCampaignStatusPaused UpgradeCampaignStatus = "paused"
+	// UpgradeCampaignStatusCompleted all the kafkas selected by the campaign have been processed
+	UpgradeCampaignStatusCompleted UpgradeCampaignStatus = "completed"
+	// UpgradeCampaignStatusCancelled the campaign has been cancelled by an administrator. The kafkas of the ongoing
+	// wave carry on upgrading.
+	UpgradeCampaignStatusCancelled UpgradeCampaignStatus = "cancelled"
+)
+
+// UpgradeCampaignStatuses are the statuses an administrator can set on an upgrade campaign
+var UpgradeCampaignStatuses = []UpgradeCampaignStatus{UpgradeCampaignStatusInProgress, UpgradeCampaignStatusPaused, UpgradeCampaignStatusCancelled}
+
+func (s UpgradeCampaignStatus) String() string {
+	return string(s)
+}
+
+// IsFinal returns true when the campaign will no longer start any wave
+func (s UpgradeCampaignStatus) IsFinal() bool {
+	return s == UpgradeCampaignStatusCompleted || s == UpgradeCampaignStatusCancelled
+}
+
+// UpgradeCampaign upgrades the Strimzi, Kafka and Kafka IBP versions of the kafkas selected by its selectors in waves
+// of at most BatchSize kafkas. A wave is started once all the kafkas of the previous wave have finished upgrading.
+type UpgradeCampaign struct {
+	api.Meta
+	Name string
+	// StrimziVersion, KafkaVersion and KafkaIBPVersion are the versions the kafkas are upgraded to. The versions left
+	// empty are not changed.
+	StrimziVersion  string
+	KafkaVersion    string
+	KafkaIBPVersion string
+	// KafkaSelector is a search query over the kafkas e.g. "instance_type = standard"
+	KafkaSelector string
+	// ClusterSelector is a search query over the data plane clusters hosting the kafkas e.g. "region = us-east-1"
+	ClusterSelector string
+	BatchSize       int
+	// FailureThreshold is the number of kafkas failing to upgrade the campaign is paused at. Only the kafkas failing
+	// since the campaign was last resumed count against it.
+	FailureThreshold int
+	// FailedKafkasAtResume is the number of kafkas that had failed to upgrade when the campaign was last resumed
+	FailedKafkasAtResume int
+	Status               UpgradeCampaignStatus
+	StatusReason         string
+	// CurrentWave is the number of the latest wave started, 0 until the first wave is started
+	CurrentWave int
+}
+
+type UpgradeCampaignList []*UpgradeCampaign
+
+func (c *UpgradeCampaign) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = api.NewID()
+	}
+	return nil
+}
+
+// TargetVersions returns the versions the given kafka is upgraded to: the version of the kafka is kept for the
+// versions the campaign leaves empty.
+func (c *UpgradeCampaign) TargetVersions(kafka *KafkaRequest) (strimziVersion, kafkaVersion, kafkaIBPVersion string) {
+	strimziVersion, kafkaVersion, kafkaIBPVersion = c.StrimziVersion, c.KafkaVersion, c.KafkaIBPVersion
+	if strimziVersion == "" {
+		strimziVersion = kafka.DesiredStrimziVersion
+	}
+	if kafkaVersion == "" {
+		kafkaVersion = kafka.DesiredKafkaVersion
+	}
+	if kafkaIBPVersion == "" {
+		kafkaIBPVersion = kafka.DesiredKafkaIBPVersion
+	}
+	return strimziVersion, kafkaVersion, kafkaIBPVersion
+}
+
+// IsUpgradeFinished returns true when the data plane reports the target versions of the campaign for the given kafka
+// and no upgrade is in progress
+func (c *UpgradeCampaign) IsUpgradeFinished(kafka *KafkaRequest) bool {
+	if kafka.IsUpgrading() {
+		return false
+	}
+	return (c.StrimziVersion == "" || kafka.ActualStrimziVersion == c.StrimziVersion) &&
+		(c.KafkaVersion == "" || kafka.ActualKafkaVersion == c.KafkaVersion) &&
+		(c.KafkaIBPVersion == "" || kafka.ActualKafkaIBPVersion == c.KafkaIBPVersion)
+}
+
+// IsTargetOf returns true when the desired versions of the given kafka are still the target versions of the campaign.
+// They are no longer when an administrator changes them while the kafka is upgraded by the campaign.
+func (c *UpgradeCampaign) IsTargetOf(kafka *KafkaRequest) bool {
+	return (c.StrimziVersion == "" || kafka.DesiredStrimziVersion == c.StrimziVersion) &&
+		(c.KafkaVersion == "" || kafka.DesiredKafkaVersion == c.KafkaVersion) &&
+		(c.KafkaIBPVersion == "" || kafka.DesiredKafkaIBPVersion == c.KafkaIBPVersion)
+}
+
+type UpgradeCampaignKafkaStatus string
+
+const (
+	// UpgradeCampaignKafkaStatusUpgrading the target versions have been set on the kafka, the data plane is upgrading it
+	UpgradeCampaignKafkaStatusUpgrading UpgradeCampaignKafkaStatus = "upgrading"
+	// UpgradeCampaignKafkaStatusUpgraded the data plane reports the target versions for the kafka
+	UpgradeCampaignKafkaStatusUpgraded UpgradeCampaignKafkaStatus = "upgraded"
+	// UpgradeCampaignKafkaStatusFailed the kafka failed while it was upgraded
+	UpgradeCampaignKafkaStatusFailed UpgradeCampaignKafkaStatus = "failed"
+	// UpgradeCampaignKafkaStatusSkipped the kafka is not upgraded by the campaign e.g. because the target versions are
+	// not available in its data plane cluster
+	UpgradeCampaignKafkaStatusSkipped UpgradeCampaignKafkaStatus = "skipped"
+)
+
+func (s UpgradeCampaignKafkaStatus) String() string {
+	return string(s)
+}
+
+// UpgradeCampaignKafka records the processing of a kafka by an upgrade campaign. Each kafka is processed at most once
+// by a campaign.
+type UpgradeCampaignKafka struct {
+	ID         string `json:"id" gorm:"primaryKey"`
+	CampaignId string
+	KafkaId    string
+	ClusterId  string
+	// Wave is the number of the wave the kafka is processed in
+	Wave      int
+	Status    UpgradeCampaignKafkaStatus
+	Reason    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type UpgradeCampaignKafkaList []*UpgradeCampaignKafka
+
+func (k *UpgradeCampaignKafka) BeforeCreate(tx *gorm.DB) error {
+	if k.ID == "" {
+		k.ID = api.NewID()
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package dbapi
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestUpgradeCampaign_TargetVersions(t *testing.T) {
+	g := gomega.NewWithT(t)
+	kafka := &KafkaRequest{DesiredStrimziVersion: "strimzi-cluster-operator.v0.32.0-0", DesiredKafkaVersion: "3.3.1", DesiredKafkaIBPVersion: "3.3"}
+	campaign := &UpgradeCampaign{KafkaVersion: "3.4.0"}
+
+	strimziVersion, kafkaVersion, kafkaIBPVersion := campaign.TargetVersions(kafka)
+	g.Expect(strimziVersion).To(gomega.Equal("strimzi-cluster-operator.v0.32.0-0"))
+	g.Expect(kafkaVersion).To(gomega.Equal("3.4.0"))
+	g.Expect(kafkaIBPVersion).To(gomega.Equal("3.3"))
+}
+
+func TestUpgradeCampaign_IsUpgradeFinished(t *testing.T) {
+	campaign := &UpgradeCampaign{KafkaVersion: "3.4.0", KafkaIBPVersion: "3.4"}
+
+	tests := []struct {
+		name  string
+		kafka *KafkaRequest
+		want  bool
+	}{
+		{
+			name:  "should return false while the kafka is upgrading",
+			kafka: &KafkaRequest{ActualKafkaVersion: "3.4.0", ActualKafkaIBPVersion: "3.3", KafkaIBPUpgrading: true},
+			want:  false,
+		},
+		{
+			name:  "should return false when one of the target versions is not reported by the data plane",
+			kafka: &KafkaRequest{ActualKafkaVersion: "3.4.0", ActualKafkaIBPVersion: "3.3"},
+			want:  false,
+		},
+		{
+			name:  "should return true when the data plane reports all the target versions",
+			kafka: &KafkaRequest{ActualStrimziVersion: "strimzi-cluster-operator.v0.32.0-0", ActualKafkaVersion: "3.4.0", ActualKafkaIBPVersion: "3.4"},
+			want:  true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(campaign.IsUpgradeFinished(tt.kafka)).To(gomega.Equal(tt.want))
+		})
+	}
+}
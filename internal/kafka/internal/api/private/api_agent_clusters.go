@@ -15,6 +15,8 @@ import (
 	_nethttp "net/http"
 	_neturl "net/url"
 	"strings"
+
+	"github.com/antihax/optional"
 )
 
 // Linger please
@@ -122,14 +124,23 @@ func (a *AgentClustersApiService) GetKafkaAgent(ctx _context.Context, id string)
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
+// GetKafkasOpts Optional parameters for the method 'GetKafkas'
+type GetKafkasOpts struct {
+	GtVersion optional.Int64
+	Watch     optional.String
+}
+
 /*
 GetKafkas Get the list of ManagedaKafkas for the specified agent cluster
   - @param ctx _context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
   - @param id The ID of record
+  - @param optional nil or *GetKafkasOpts - Optional Parameters:
+  - @param "GtVersion" (optional.Int64) -  filters the ManagedKafkas to those with a version greater than the given value. The ManagedKafkas which left the agent cluster since the given version are returned with `spec.deleted` set to true
+  - @param "Watch" (optional.String) -  watch for changes to the ManagedKafkas and return them as a stream of watch events. Specify gt_version to specify the starting point.
 
 @return ManagedKafkaList
 */
-func (a *AgentClustersApiService) GetKafkas(ctx _context.Context, id string) (ManagedKafkaList, *_nethttp.Response, error) {
+func (a *AgentClustersApiService) GetKafkas(ctx _context.Context, id string, localVarOptionals *GetKafkasOpts) (ManagedKafkaList, *_nethttp.Response, error) {
 	var (
 		localVarHTTPMethod   = _nethttp.MethodGet
 		localVarPostBody     interface{}
@@ -147,6 +158,13 @@ func (a *AgentClustersApiService) GetKafkas(ctx _context.Context, id string) (Ma
 	localVarQueryParams := _neturl.Values{}
 	localVarFormParams := _neturl.Values{}
 
+	if localVarOptionals != nil && localVarOptionals.GtVersion.IsSet() {
+		localVarQueryParams.Add("gt_version", parameterToString(localVarOptionals.GtVersion.Value(), ""))
+	}
+	if localVarOptionals != nil && localVarOptionals.Watch.IsSet() {
+		localVarQueryParams.Add("watch", parameterToString(localVarOptionals.Watch.Value(), ""))
+	}
+
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -157,7 +175,7 @@ func (a *AgentClustersApiService) GetKafkas(ctx _context.Context, id string) (Ma
 	}
 
 	// to determine the Accept header
-	localVarHTTPHeaderAccepts := []string{"application/json"}
+	localVarHTTPHeaderAccepts := []string{"application/json", "application/json;stream=watch"}
 
 	// set Accept header
 	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
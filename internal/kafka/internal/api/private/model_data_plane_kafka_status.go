@@ -18,4 +18,5 @@ type DataPlaneKafkaStatus struct {
 	// Routes created for a Kafka cluster
 	Routes         *[]DataPlaneKafkaStatusRoutes `json:"routes,omitempty"`
 	AdminServerURI string                        `json:"adminServerURI,omitempty"`
+	Canary         DataPlaneKafkaStatusCanary    `json:"canary,omitempty"`
 }
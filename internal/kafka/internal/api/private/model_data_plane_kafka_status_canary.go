@@ -0,0 +1,18 @@
+/*
+ * Kafka Service Fleet Manager
+ *
+ * Kafka Service Fleet Manager APIs that are used by internal services e.g kas-fleetshard operators.
+ *
+ * API version: 1.7.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// DataPlaneKafkaStatusCanary Status of the canary of a Kafka cluster
+type DataPlaneKafkaStatusCanary struct {
+	// The client id of the service account the canary is authenticated with
+	Principal string `json:"principal,omitempty"`
+	// Whether the canary is successfully producing and consuming messages
+	Ready bool `json:"ready,omitempty"`
+}
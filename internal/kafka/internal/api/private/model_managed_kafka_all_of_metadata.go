@@ -11,8 +11,9 @@ package private
 
 // ManagedKafkaAllOfMetadata struct for ManagedKafkaAllOfMetadata
 type ManagedKafkaAllOfMetadata struct {
-	Name        string                               `json:"name,omitempty"`
-	Namespace   string                               `json:"namespace,omitempty"`
-	Annotations ManagedKafkaAllOfMetadataAnnotations `json:"annotations,omitempty"`
-	Labels      ManagedKafkaAllOfMetadataLabels      `json:"labels,omitempty"`
+	Name            string                               `json:"name,omitempty"`
+	Namespace       string                               `json:"namespace,omitempty"`
+	ResourceVersion int64                                `json:"resourceVersion,omitempty"`
+	Annotations     ManagedKafkaAllOfMetadataAnnotations `json:"annotations,omitempty"`
+	Labels          ManagedKafkaAllOfMetadataLabels      `json:"labels,omitempty"`
 }
@@ -0,0 +1,17 @@
+/*
+ * Kafka Service Fleet Manager
+ *
+ * Kafka Service Fleet Manager APIs that are used by internal services e.g kas-fleetshard operators.
+ *
+ * API version: 1.7.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package private
+
+// ManagedKafkaWatchEvent struct for ManagedKafkaWatchEvent
+type ManagedKafkaWatchEvent struct {
+	Type   string       `json:"type"`
+	Error  Error        `json:"error,omitempty"`
+	Object ManagedKafka `json:"object,omitempty"`
+}
@@ -55,7 +55,11 @@ type APIClient struct {
 
 	ErrorsApi *ErrorsApiService
 
+	MaintenanceApi *MaintenanceApiService
+
 	SecurityApi *SecurityApiService
+
+	WebhooksApi *WebhooksApiService
 }
 
 type service struct {
@@ -77,7 +81,9 @@ func NewAPIClient(cfg *Configuration) *APIClient {
 	c.DefaultApi = (*DefaultApiService)(&c.common)
 	c.EnterpriseDataplaneClustersApi = (*EnterpriseDataplaneClustersApiService)(&c.common)
 	c.ErrorsApi = (*ErrorsApiService)(&c.common)
+	c.MaintenanceApi = (*MaintenanceApiService)(&c.common)
 	c.SecurityApi = (*SecurityApiService)(&c.common)
+	c.WebhooksApi = (*WebhooksApiService)(&c.common)
 
 	return c
 }
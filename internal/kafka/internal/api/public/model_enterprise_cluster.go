@@ -26,7 +26,9 @@ type EnterpriseCluster struct {
 	// The region of this cluster. This valus will be used as the Kafka's region value when a Kafka is created on this cluster
 	Region string `json:"region,omitempty"`
 	// A flag indicating whether this cluster is available on multiple availability zones or not
-	MultiAz                bool                                      `json:"multi_az"`
-	SupportedInstanceTypes SupportedKafkaInstanceTypesList           `json:"supported_instance_types,omitempty"`
-	CapacityInformation    EnterpriseClusterAllOfCapacityInformation `json:"capacity_information,omitempty"`
+	MultiAz                bool                            `json:"multi_az"`
+	SupportedInstanceTypes SupportedKafkaInstanceTypesList `json:"supported_instance_types,omitempty"`
+	// Returns the capacity related information of each Kafka instance type supported by this cluster
+	InstanceTypesCapacityInformation []EnterpriseClusterInstanceTypeCapacityInformation `json:"instance_types_capacity_information,omitempty"`
+	CapacityInformation              EnterpriseClusterAllOfCapacityInformation          `json:"capacity_information,omitempty"`
 }
@@ -10,7 +10,7 @@
 
 package public
 
-// EnterpriseClusterAllOfCapacityInformation Returns the capacity related information
+// EnterpriseClusterAllOfCapacityInformation Returns the capacity related information of the standard Kafka instance type. Use instance_types_capacity_information for the capacity of the other Kafka instance types supported by this cluster
 type EnterpriseClusterAllOfCapacityInformation struct {
 	// The kafka machine pool node count provided during cluster registration
 	KafkaMachinePoolNodeCount int32 `json:"kafka_machine_pool_node_count"`
@@ -0,0 +1,21 @@
+/*
+ * Kafka Management API
+ *
+ * Kafka Management API is a REST API to manage Kafka instances
+ *
+ * API version: 1.15.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// EnterpriseClusterUpdatePayload Schema for the request body sent to /clusters/{id} PATCH
+type EnterpriseClusterUpdatePayload struct {
+	// Sets whether Kafkas created on this data plane cluster have to be accessed via private network. It can only be changed while the data plane cluster has no Kafka instances
+	AccessKafkasViaPrivateNetwork *bool `json:"access_kafkas_via_private_network,omitempty"`
+	// The node count of the kafka machine pool. The machine pool must be scaled via /api/clusters_mgmt/v1/clusters/<cluster_id>/machine_pools/kafka-standard prior to passing this value. The node count value has to be a multiple of 3 with a minimum of 3 nodes. It cannot be lowered below the capacity consumed by the Kafka instances of the data plane cluster. It changes the kafka machine pool of the standard Kafka instance type and cannot be set together with `instance_types`.
+	KafkaMachinePoolNodeCount *int32 `json:"kafka_machine_pool_node_count,omitempty"`
+	// The node counts of the kafka machine pools of the Kafka instance types supported by the data plane cluster. It cannot be set together with `kafka_machine_pool_node_count`.
+	InstanceTypes []EnterpriseOsdClusterInstanceTypePayload `json:"instance_types,omitempty"`
+}
@@ -0,0 +1,19 @@
+/*
+ * Kafka Management API
+ *
+ * Kafka Management API is a REST API to manage Kafka instances
+ *
+ * API version: 1.15.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// EnterpriseOsdClusterInstanceTypePayload A Kafka instance type an Enterprise cluster is registered for
+type EnterpriseOsdClusterInstanceTypePayload struct {
+	// The id of the Kafka instance type e.g `standard` or `developer`. The instance type must support the enterprise billing model.
+	InstanceType string `json:"instance_type"`
+	// The node count given to the kafka machine pool of the instance type. The machine pool must be created via /api/clusters_mgmt/v1/clusters/<cluster_id>/machine_pools prior to passing this value. The created machine pool must have a `bf2.org/kafkaInstanceProfileType=<instance_type>` label and a `bf2.org/kafkaInstanceProfileType=<instance_type>:NoExecute` taint. The name of the machine pool must be `kafka-<instance_type>` The node count value has to be a multiple of 3 with a minimum of 3 nodes.
+	KafkaMachinePoolNodeCount int32 `json:"kafka_machine_pool_node_count"`
+}
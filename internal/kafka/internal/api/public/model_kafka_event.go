@@ -0,0 +1,31 @@
+/*
+ * Kafka Management API
+ *
+ * Kafka Management API is a REST API to manage Kafka instances
+ *
+ * API version: 1.15.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+import (
+	"time"
+)
+
+// KafkaEvent An entry of the lifecycle history of a Kafka instance
+type KafkaEvent struct {
+	Id        string    `json:"id"`
+	Kind      string    `json:"kind"`
+	KafkaId   string    `json:"kafka_id"`
+	CreatedAt time.Time `json:"created_at"`
+	// Values: [status, suspend_requested, resume_requested, resize_requested, resized, resize_failed, upgrade_started, upgraded]
+	Type string `json:"type"`
+	// Status of the Kafka instance before the event. It is not set for the first event of the Kafka instance
+	PreviousStatus string `json:"previous_status,omitempty"`
+	// Values: [accepted, preparing, provisioning, ready, failed, deprovision, deleting, suspending, suspended, resuming]
+	Status          string `json:"status"`
+	FailedReason    string `json:"failed_reason,omitempty"`
+	PromotionStatus string `json:"promotion_status,omitempty"`
+}
@@ -71,4 +71,18 @@ type KafkaRequest struct {
 	ClusterId *string `json:"cluster_id,omitempty"`
 	// Details of the Kafka request promotion. It can be set when a Kafka request promotion is in progress or has failed
 	PromotionDetails string `json:"promotion_details,omitempty"`
+	// Status of the Kafka request resize. Possible values: ['resizing', 'failed']. If unset it means no resize is in progress.
+	ResizeStatus string `json:"resize_status,omitempty"`
+	// Details of the Kafka request resize. It can be set when a Kafka request resize is in progress or has failed
+	ResizeDetails string `json:"resize_details,omitempty"`
+	// Day of the weekly maintenance window the Strimzi and Kafka upgrades of the Kafka instance are rolled out in. It is either the maintenance window chosen for the Kafka instance or the maintenance window of its organisation. If unset, the upgrades are rolled out straight away.
+	MaintenanceWindowDay string `json:"maintenance_window_day,omitempty"`
+	// Hour (UTC) the weekly maintenance window of the Kafka instance starts at
+	MaintenanceWindowStartHour *int32 `json:"maintenance_window_start_hour,omitempty"`
+	// Start of the maintenance window the pending upgrade of the Kafka instance is rolled out in. It is unset when no upgrade is pending or when no maintenance window applies
+	NextMaintenanceWindowStart *time.Time `json:"next_maintenance_window_start,omitempty"`
+	// Whether an upgrade of the Kafka instance is waiting to be rolled out
+	UpgradePending bool `json:"upgrade_pending,omitempty"`
+	// The Kafka version the Kafka instance is upgraded to by its pending upgrade. It is unset when the pending upgrade does not change the Kafka version
+	PendingVersion string `json:"pending_version,omitempty"`
 }
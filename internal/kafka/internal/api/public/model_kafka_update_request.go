@@ -15,4 +15,10 @@ type KafkaUpdateRequest struct {
 	Owner *string `json:"owner,omitempty"`
 	// Whether connection reauthentication is enabled or not. If set to true, connection reauthentication on the Kafka instance will be required every 5 minutes.
 	ReauthenticationEnabled *bool `json:"reauthentication_enabled,omitempty"`
+	// The plan to resize the Kafka instance to, in the format '<instance_type>.<size_id>'. Only the sizes of the instance type of the Kafka instance are supported. The Kafka instance must be ready and the resize happens asynchronously: its progress is reported in the resize_status field of the Kafka instance.
+	Plan *string `json:"plan,omitempty"`
+	// Day of the weekly maintenance window the Strimzi and Kafka upgrades of the Kafka instance are rolled out in. Values: [monday, tuesday, wednesday, thursday, friday, saturday, sunday]. Set it to an empty string for the maintenance window of the organisation to apply to the Kafka instance.
+	MaintenanceWindowDay *string `json:"maintenance_window_day,omitempty"`
+	// Hour (UTC), between 0 and 23, the weekly maintenance window of the Kafka instance starts at
+	MaintenanceWindowStartHour *int32 `json:"maintenance_window_start_hour,omitempty"`
 }
@@ -0,0 +1,19 @@
+/*
+ * Kafka Management API
+ *
+ * Kafka Management API is a REST API to manage Kafka instances
+ *
+ * API version: 1.15.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// MaintenanceWindow A weekly maintenance window the Strimzi and Kafka upgrades of the Kafka instances are rolled out in
+type MaintenanceWindow struct {
+	// Day the maintenance window starts on. Values: [monday, tuesday, wednesday, thursday, friday, saturday, sunday]
+	DayOfWeek string `json:"day_of_week"`
+	// Hour (UTC), between 0 and 23, the maintenance window starts at
+	StartHour int32 `json:"start_hour"`
+}
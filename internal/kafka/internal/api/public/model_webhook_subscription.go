@@ -0,0 +1,29 @@
+/*
+ * Kafka Management API
+ *
+ * Kafka Management API is a REST API to manage Kafka instances
+ *
+ * API version: 1.15.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+import (
+	"time"
+)
+
+// WebhookSubscription An endpoint notified of the changes of phase of the resources of an organisation
+type WebhookSubscription struct {
+	Id   string `json:"id"`
+	Kind string `json:"kind"`
+	Href string `json:"href"`
+	Url  string `json:"url"`
+	// The types of the events the endpoint is notified of. Values: [kafka.phase_changed, connector.phase_changed, connector_namespace.phase_changed, enterprise_cluster.phase_changed]
+	EventTypes []string  `json:"event_types"`
+	Owner      string    `json:"owner,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	// The key used to sign the notifications. It is only returned when the subscription is created
+	Secret string `json:"secret,omitempty"`
+}
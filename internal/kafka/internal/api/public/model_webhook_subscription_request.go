@@ -0,0 +1,21 @@
+/*
+ * Kafka Management API
+ *
+ * Kafka Management API is a REST API to manage Kafka instances
+ *
+ * API version: 1.15.0
+ * Contact: rhosak-support@redhat.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package public
+
+// WebhookSubscriptionRequest Schema for the request to create a webhook subscription
+type WebhookSubscriptionRequest struct {
+	// The HTTPS endpoint the notifications are POSTed to. Its host must only resolve to public addresses
+	Url string `json:"url"`
+	// The types of the events the endpoint is notified of. The endpoint is notified of all the events when it is empty. Values: [kafka.phase_changed, connector.phase_changed, connector_namespace.phase_changed, enterprise_cluster.phase_changed]
+	EventTypes []string `json:"event_types,omitempty"`
+	// The key used to sign the notifications, of at least 16 characters. A random secret is generated when it is not provided
+	Secret string `json:"secret,omitempty"`
+}
@@ -0,0 +1,536 @@
+package clusters
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/cloudproviders"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/clusters/types"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	awsclient "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/aws"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// eksClusterNamePrefix is the prefix of the name of the EKS clusters created by the kas-fleet-manager
+	eksClusterNamePrefix = "kas-"
+	// eksDefaultNodegroupName is the name of the node group created along with each EKS cluster.
+	// The operators installed in the cluster are scheduled on this node group
+	eksDefaultNodegroupName = "kas-default"
+	// eksManagedByTagKey is the tag set on the AWS resources created by the kas-fleet-manager
+	eksManagedByTagKey   = "managed-by"
+	eksManagedByTagValue = "kas-fleet-manager"
+)
+
+// k8sToEKSTaintEffects maps kubernetes taint effects to the values accepted by the EKS API
+var k8sToEKSTaintEffects = map[string]string{
+	"NoSchedule":       eks.TaintEffectNoSchedule,
+	"NoExecute":        eks.TaintEffectNoExecute,
+	"PreferNoSchedule": eks.TaintEffectPreferNoSchedule,
+}
+
+type EKSProvider struct {
+	eksClientFactory  awsclient.EKSClientFactory
+	awsConfig         *config.AWSConfig
+	connectionFactory *db.ConnectionFactory
+	// olmResourcesBuilder builds the OLM resources used to install the strimzi and kas-fleetshard operators
+	olmResourcesBuilder *StandaloneProvider
+}
+
+// blank assignment to verify that EKSProvider implements Provider
+var _ Provider = &EKSProvider{}
+
+func newEKSProvider(eksClientFactory awsclient.EKSClientFactory, awsConfig *config.AWSConfig, connectionFactory *db.ConnectionFactory, dataplaneClusterConfig *config.DataplaneClusterConfig) *EKSProvider {
+	return &EKSProvider{
+		eksClientFactory:    eksClientFactory,
+		awsConfig:           awsConfig,
+		connectionFactory:   connectionFactory,
+		olmResourcesBuilder: newStandaloneProvider(connectionFactory, dataplaneClusterConfig),
+	}
+}
+
+func (e *EKSProvider) Create(request *types.ClusterRequest) (*types.ClusterSpec, error) {
+	if cloudproviders.ParseCloudProviderID(request.CloudProvider) != cloudproviders.AWS {
+		return nil, errors.Errorf("cloud provider %q is not supported by the %s provider", request.CloudProvider, api.ClusterProviderAwsEKS)
+	}
+	if err := e.awsConfig.EKS.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "failed to create EKS cluster")
+	}
+
+	client, err := e.newEKSClient(request.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterName := fmt.Sprintf("%s%s", eksClusterNamePrefix, api.NewID())
+	input := &eks.CreateClusterInput{
+		Name:    aws.String(clusterName),
+		RoleArn: aws.String(e.awsConfig.EKS.ClusterRoleARN),
+		ResourcesVpcConfig: &eks.VpcConfigRequest{
+			SubnetIds: aws.StringSlice(e.awsConfig.EKS.SubnetIDs),
+		},
+		Tags: map[string]*string{
+			eksManagedByTagKey: aws.String(eksManagedByTagValue),
+		},
+	}
+	if e.awsConfig.EKS.KubernetesVersion != "" {
+		input.Version = aws.String(e.awsConfig.EKS.KubernetesVersion)
+	}
+
+	createdCluster, err := client.CreateCluster(input)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create EKS cluster")
+	}
+
+	return &types.ClusterSpec{
+		InternalID:    clusterName,
+		ExternalID:    aws.StringValue(createdCluster.Arn),
+		Status:        api.ClusterProvisioning,
+		MultiAZ:       request.MultiAZ,
+		Region:        request.Region,
+		CloudProvider: request.CloudProvider,
+	}, nil
+}
+
+// Delete removes the node groups of the cluster first, as EKS refuses to delete a cluster that still has node groups attached,
+// and then the cluster itself. It returns true once the cluster is no longer found
+func (e *EKSProvider) Delete(spec *types.ClusterSpec) (bool, error) {
+	client, err := e.newEKSClient(spec.Region)
+	if err != nil {
+		return false, err
+	}
+
+	nodegroups, err := client.ListNodegroups(spec.InternalID)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to list node groups of cluster %s", spec.InternalID)
+	}
+
+	if len(nodegroups) > 0 {
+		for _, nodegroupName := range nodegroups {
+			nodegroup, err := client.DescribeNodegroup(spec.InternalID, nodegroupName)
+			if err != nil {
+				return false, errors.Wrapf(err, "failed to get node group %s of cluster %s", nodegroupName, spec.InternalID)
+			}
+			if nodegroup == nil || aws.StringValue(nodegroup.Status) == eks.NodegroupStatusDeleting {
+				continue
+			}
+			glog.V(5).Infof("Deleting node group %s of cluster %s", nodegroupName, spec.InternalID)
+			if err := client.DeleteNodegroup(spec.InternalID, nodegroupName); err != nil {
+				return false, errors.Wrapf(err, "failed to delete node group %s of cluster %s", nodegroupName, spec.InternalID)
+			}
+		}
+		return false, nil
+	}
+
+	cluster, err := client.DescribeCluster(spec.InternalID)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get cluster %s", spec.InternalID)
+	}
+	if cluster == nil {
+		return true, nil
+	}
+
+	if aws.StringValue(cluster.Status) != eks.ClusterStatusDeleting {
+		if err := client.DeleteCluster(spec.InternalID); err != nil {
+			return false, errors.Wrapf(err, "failed to delete cluster %s", spec.InternalID)
+		}
+	}
+
+	return false, nil
+}
+
+// CheckClusterStatus creates the default node group once the EKS control plane is active.
+// The cluster is considered provisioned once the default node group is active too
+func (e *EKSProvider) CheckClusterStatus(spec *types.ClusterSpec) (*types.ClusterSpec, error) {
+	client, err := e.newEKSClient(spec.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterSpec, err := e.getClusterSpec(client, spec.InternalID)
+	if err != nil {
+		return nil, err
+	}
+	clusterSpec.MultiAZ = spec.MultiAZ
+	clusterSpec.AdditionalInfo = spec.AdditionalInfo
+
+	if clusterSpec.Status != api.ClusterProvisioning {
+		return clusterSpec, nil
+	}
+
+	cluster, err := client.DescribeCluster(spec.InternalID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get cluster %s", spec.InternalID)
+	}
+
+	if cluster != nil && aws.StringValue(cluster.Status) == eks.ClusterStatusActive {
+		nodegroup, err := client.DescribeNodegroup(spec.InternalID, eksDefaultNodegroupName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get node group %s of cluster %s", eksDefaultNodegroupName, spec.InternalID)
+		}
+		if nodegroup == nil {
+			glog.V(5).Infof("Creating default node group for cluster %s", spec.InternalID)
+			_, err = client.CreateNodegroup(e.buildDefaultNodegroupInput(spec.InternalID))
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to create default node group for cluster %s", spec.InternalID)
+			}
+		}
+	}
+
+	return clusterSpec, nil
+}
+
+func (e *EKSProvider) buildDefaultNodegroupInput(clusterName string) *eks.CreateNodegroupInput {
+	nodeCount := int64(e.awsConfig.EKS.NodeCount)
+	return &eks.CreateNodegroupInput{
+		ClusterName:   aws.String(clusterName),
+		NodegroupName: aws.String(eksDefaultNodegroupName),
+		NodeRole:      aws.String(e.awsConfig.EKS.NodeRoleARN),
+		Subnets:       aws.StringSlice(e.awsConfig.EKS.SubnetIDs),
+		InstanceTypes: aws.StringSlice([]string{e.awsConfig.EKS.NodeInstanceType}),
+		ScalingConfig: &eks.NodegroupScalingConfig{
+			DesiredSize: aws.Int64(nodeCount),
+			MinSize:     aws.Int64(nodeCount),
+			MaxSize:     aws.Int64(nodeCount),
+		},
+		Tags: map[string]*string{
+			eksManagedByTagKey: aws.String(eksManagedByTagValue),
+		},
+	}
+}
+
+func (e *EKSProvider) GetClusterSpec(clusterID string) (types.ClusterSpec, error) {
+	client, err := e.newEKSClientForCluster(clusterID)
+	if err != nil {
+		return types.ClusterSpec{}, err
+	}
+
+	clusterSpec, err := e.getClusterSpec(client, clusterID)
+	if err != nil {
+		return types.ClusterSpec{}, err
+	}
+
+	return *clusterSpec, nil
+}
+
+// getClusterSpec maps the status of the EKS cluster and its default node group to a cluster spec
+func (e *EKSProvider) getClusterSpec(client awsclient.EKSClient, clusterName string) (*types.ClusterSpec, error) {
+	cluster, err := client.DescribeCluster(clusterName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get cluster %s", clusterName)
+	}
+	if cluster == nil {
+		return nil, errors.Errorf("cluster %s not found", clusterName)
+	}
+
+	clusterSpec := &types.ClusterSpec{
+		InternalID:    clusterName,
+		ExternalID:    aws.StringValue(cluster.Arn),
+		CloudProvider: cloudproviders.AWS.String(),
+		Status:        api.ClusterProvisioning,
+	}
+	if cluster.Arn != nil {
+		// the region is the 4th element of an EKS cluster ARN: arn:aws:eks:<region>:<account>:cluster/<name>
+		if arnParts := strings.Split(aws.StringValue(cluster.Arn), ":"); len(arnParts) > 3 {
+			clusterSpec.Region = arnParts[3]
+		}
+	}
+
+	switch aws.StringValue(cluster.Status) {
+	case eks.ClusterStatusFailed:
+		clusterSpec.Status = api.ClusterFailed
+		clusterSpec.StatusDetails = fmt.Sprintf("EKS cluster %s is in %s state", clusterName, eks.ClusterStatusFailed)
+		return clusterSpec, nil
+	case eks.ClusterStatusActive:
+	default:
+		return clusterSpec, nil
+	}
+
+	nodegroup, err := client.DescribeNodegroup(clusterName, eksDefaultNodegroupName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get node group %s of cluster %s", eksDefaultNodegroupName, clusterName)
+	}
+	if nodegroup == nil {
+		return clusterSpec, nil
+	}
+
+	switch aws.StringValue(nodegroup.Status) {
+	case eks.NodegroupStatusActive:
+		clusterSpec.Status = api.ClusterProvisioned
+	case eks.NodegroupStatusCreateFailed:
+		clusterSpec.Status = api.ClusterFailed
+		clusterSpec.StatusDetails = nodegroupHealthIssues(nodegroup)
+	}
+
+	return clusterSpec, nil
+}
+
+func nodegroupHealthIssues(nodegroup *eks.Nodegroup) string {
+	if nodegroup.Health == nil {
+		return ""
+	}
+	issues := []string{}
+	for _, issue := range nodegroup.Health.Issues {
+		issues = append(issues, fmt.Sprintf("%s: %s", aws.StringValue(issue.Code), aws.StringValue(issue.Message)))
+	}
+	return strings.Join(issues, ", ")
+}
+
+// AddIdentityProvider is a noop. EKS clusters do not have an OpenShift OAuth server to configure
+func (e *EKSProvider) AddIdentityProvider(clusterSpec *types.ClusterSpec, identityProvider types.IdentityProviderInfo) (*types.IdentityProviderInfo, error) {
+	return &identityProvider, nil
+}
+
+func (e *EKSProvider) ApplyResources(clusterSpec *types.ClusterSpec, resources types.ResourceSet) (*types.ResourceSet, error) {
+	client, err := e.newEKSClient(clusterSpec.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	restConfig, err := e.buildRestConfig(client, clusterSpec.InternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = applyResourcesWithRestConfig(restConfig, resources)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to apply resources to cluster %s", clusterSpec.InternalID)
+	}
+
+	return &resources, nil
+}
+
+// buildRestConfig builds the configuration needed to reach the kubernetes API server of the EKS cluster
+func (e *EKSProvider) buildRestConfig(client awsclient.EKSClient, clusterName string) (*rest.Config, error) {
+	cluster, err := client.DescribeCluster(clusterName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get cluster %s", clusterName)
+	}
+	if cluster == nil {
+		return nil, errors.Errorf("cluster %s not found", clusterName)
+	}
+	if cluster.CertificateAuthority == nil || cluster.Endpoint == nil {
+		return nil, errors.Errorf("the API server of cluster %s is not available yet", clusterName)
+	}
+
+	caData, err := base64.StdEncoding.DecodeString(aws.StringValue(cluster.CertificateAuthority.Data))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode certificate authority of cluster %s", clusterName)
+	}
+
+	token, err := client.GetToken(clusterName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get token for cluster %s", clusterName)
+	}
+
+	return &rest.Config{
+		Host:        aws.StringValue(cluster.Endpoint),
+		BearerToken: token,
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData: caData,
+		},
+	}, nil
+}
+
+// RemoveResources is a noop. Resources are applied directly to the cluster so there is no SyncSet to remove
+func (e *EKSProvider) RemoveResources(clusterSpec *types.ClusterSpec, syncSetName string) error {
+	return nil
+}
+
+// GetClusterDNS returns the ingress DNS of the cluster built from the configured ingress base domain.
+// An empty DNS is returned when no base domain is configured, in which case the DNS has to be provided
+// through the data plane cluster configuration
+func (e *EKSProvider) GetClusterDNS(clusterSpec *types.ClusterSpec) (string, error) {
+	if e.awsConfig.EKS.IngressBaseDomain == "" {
+		return "", nil
+	}
+	return fmt.Sprintf("%s.%s", clusterSpec.InternalID, e.awsConfig.EKS.IngressBaseDomain), nil
+}
+
+func (e *EKSProvider) GetCloudProviders() (*types.CloudProviderInfoList, error) {
+	return getCloudProvidersOfProviderType(e.connectionFactory, api.ClusterProviderAwsEKS)
+}
+
+func (e *EKSProvider) GetCloudProviderRegions(providerInf types.CloudProviderInfo) (*types.CloudProviderRegionInfoList, error) {
+	return getCloudProviderRegionsOfProviderType(e.connectionFactory, api.ClusterProviderAwsEKS, providerInf)
+}
+
+func (e *EKSProvider) InstallStrimzi(clusterSpec *types.ClusterSpec) (bool, error) {
+	_, err := e.ApplyResources(clusterSpec, types.ResourceSet{
+		Resources: []interface{}{
+			e.olmResourcesBuilder.buildStrimziOperatorNamespace(),
+			e.olmResourcesBuilder.buildStrimziOperatorCatalogSource(),
+			e.olmResourcesBuilder.buildStrimziOperatorOperatorGroup(),
+			e.olmResourcesBuilder.buildStrimziOperatorSubscription(),
+		},
+	})
+
+	return err == nil, err
+}
+
+// InstallClusterLogging is a noop. Logs of EKS clusters are collected through CloudWatch
+func (e *EKSProvider) InstallClusterLogging(clusterSpec *types.ClusterSpec, params []types.Parameter) (bool, error) {
+	return true, nil
+}
+
+func (e *EKSProvider) InstallKasFleetshard(clusterSpec *types.ClusterSpec, params []types.Parameter) (bool, error) {
+	_, err := e.ApplyResources(clusterSpec, types.ResourceSet{
+		Resources: []interface{}{
+			e.olmResourcesBuilder.buildKASFleetShardOperatorNamespace(),
+			e.olmResourcesBuilder.buildKASFleetShardSyncSecret(params),
+			e.olmResourcesBuilder.buildKASFleetShardOperatorCatalogSource(),
+			e.olmResourcesBuilder.buildKASFleetShardOperatorOperatorGroup(),
+			e.olmResourcesBuilder.buildKASFleetShardOperatorSubscription(),
+		},
+	})
+
+	return err == nil, err
+}
+
+// GetMachinePool returns the EKS node group with the given id. nil is returned if the node group does not exist
+func (e *EKSProvider) GetMachinePool(clusterID string, id string) (*types.MachinePoolInfo, error) {
+	client, err := e.newEKSClientForCluster(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	nodegroup, err := client.DescribeNodegroup(clusterID, id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get node group %s of cluster %s", id, clusterID)
+	}
+	if nodegroup == nil {
+		return nil, nil
+	}
+
+	var nodeTaints []types.ClusterNodeTaint
+	for _, eksTaint := range nodegroup.Taints {
+		nodeTaints = append(nodeTaints, types.ClusterNodeTaint{
+			Effect: eksToK8sTaintEffect(aws.StringValue(eksTaint.Effect)),
+			Key:    aws.StringValue(eksTaint.Key),
+			Value:  aws.StringValue(eksTaint.Value),
+		})
+	}
+
+	res := &types.MachinePoolInfo{
+		ID:         aws.StringValue(nodegroup.NodegroupName),
+		ClusterID:  clusterID,
+		MultiAZ:    len(nodegroup.Subnets) > 1,
+		NodeLabels: aws.StringValueMap(nodegroup.Labels),
+		NodeTaints: nodeTaints,
+	}
+	if len(nodegroup.InstanceTypes) > 0 {
+		res.InstanceSize = aws.StringValue(nodegroup.InstanceTypes[0])
+	}
+	if scalingConfig := nodegroup.ScalingConfig; scalingConfig != nil {
+		minNodes := int(aws.Int64Value(scalingConfig.MinSize))
+		maxNodes := int(aws.Int64Value(scalingConfig.MaxSize))
+		res.Replicas = int(aws.Int64Value(scalingConfig.DesiredSize))
+		res.AutoScalingEnabled = minNodes != maxNodes
+		res.AutoScaling = types.MachinePoolAutoScaling{
+			MinNodes: minNodes,
+			MaxNodes: maxNodes,
+		}
+	}
+
+	return res, nil
+}
+
+// CreateMachinePool creates an EKS node group. EKS node groups have no dedicated autoscaling object: the
+// autoscaling bounds are set as the scaling configuration of the node group, which is what the cluster autoscaler uses
+func (e *EKSProvider) CreateMachinePool(request *types.MachinePoolRequest) (*types.MachinePoolRequest, error) {
+	client, err := e.newEKSClientForCluster(request.ClusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	scalingConfig := &eks.NodegroupScalingConfig{
+		DesiredSize: aws.Int64(int64(request.Replicas)),
+		MinSize:     aws.Int64(int64(request.Replicas)),
+		MaxSize:     aws.Int64(int64(request.Replicas)),
+	}
+	if request.AutoScalingEnabled {
+		if request.AutoScaling.MinNodes > request.AutoScaling.MaxNodes {
+			return nil, fmt.Errorf("error creating MachinePool '%s' for cluster id '%s': minimum number of nodes cannot be more than maximum number of nodes", request.ID, request.ClusterID)
+		}
+		scalingConfig = &eks.NodegroupScalingConfig{
+			DesiredSize: aws.Int64(int64(request.AutoScaling.MinNodes)),
+			MinSize:     aws.Int64(int64(request.AutoScaling.MinNodes)),
+			MaxSize:     aws.Int64(int64(request.AutoScaling.MaxNodes)),
+		}
+	}
+
+	var taints []*eks.Taint
+	for _, nodeTaint := range request.NodeTaints {
+		effect, ok := k8sToEKSTaintEffects[nodeTaint.Effect]
+		if !ok {
+			return nil, fmt.Errorf("error creating MachinePool '%s' for cluster id '%s': unsupported taint effect %q", request.ID, request.ClusterID, nodeTaint.Effect)
+		}
+		taints = append(taints, &eks.Taint{
+			Effect: aws.String(effect),
+			Key:    aws.String(nodeTaint.Key),
+			Value:  aws.String(nodeTaint.Value),
+		})
+	}
+
+	_, err = client.CreateNodegroup(&eks.CreateNodegroupInput{
+		ClusterName:   aws.String(request.ClusterID),
+		NodegroupName: aws.String(request.ID),
+		NodeRole:      aws.String(e.awsConfig.EKS.NodeRoleARN),
+		Subnets:       aws.StringSlice(e.awsConfig.EKS.SubnetIDs),
+		InstanceTypes: aws.StringSlice([]string{request.InstanceSize}),
+		ScalingConfig: scalingConfig,
+		Labels:        aws.StringMap(request.NodeLabels),
+		Taints:        taints,
+		Tags: map[string]*string{
+			eksManagedByTagKey: aws.String(eksManagedByTagValue),
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create node group %s for cluster %s", request.ID, request.ClusterID)
+	}
+
+	return request, nil
+}
+
+// noop method, it will always return a nil slice as EKS clusters are not subject to resource quotas
+func (e *EKSProvider) GetClusterResourceQuotaCosts() ([]types.QuotaCost, error) {
+	var quotaCostList []types.QuotaCost
+	return quotaCostList, nil
+}
+
+func (e *EKSProvider) newEKSClient(region string) (awsclient.EKSClient, error) {
+	credentials := awsclient.Config{
+		AccessKeyID:     e.awsConfig.ConfigForOSDClusterCreation.AccessKey,
+		SecretAccessKey: e.awsConfig.ConfigForOSDClusterCreation.SecretAccessKey,
+	}
+	client, err := e.eksClientFactory.NewEKSClient(credentials, region)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create EKS client for region %s", region)
+	}
+	return client, nil
+}
+
+// newEKSClientForCluster creates an EKS client for the region of the given cluster as stored in the database
+func (e *EKSProvider) newEKSClientForCluster(clusterID string) (awsclient.EKSClient, error) {
+	var cluster api.Cluster
+	if err := e.connectionFactory.New().Where("cluster_id = ?", clusterID).First(&cluster).Error; err != nil {
+		return nil, errors.Wrapf(err, "failed to find cluster %s", clusterID)
+	}
+	return e.newEKSClient(cluster.Region)
+}
+
+func eksToK8sTaintEffect(eksEffect string) string {
+	for k8sEffect, effect := range k8sToEKSTaintEffects {
+		if effect == eksEffect {
+			return k8sEffect
+		}
+	}
+	return eksEffect
+}
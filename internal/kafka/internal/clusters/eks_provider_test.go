@@ -0,0 +1,537 @@
+package clusters
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/clusters/types"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	awsclient "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/aws"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+const (
+	testEKSClusterName = "kas-test-cluster"
+	testEKSClusterArn  = "arn:aws:eks:us-east-1:123456789012:cluster/kas-test-cluster"
+	testEKSRegion      = "us-east-1"
+	testEKSClusterRole = "arn:aws:iam::123456789012:role/kas-eks-cluster"
+	testEKSNodeRole    = "arn:aws:iam::123456789012:role/kas-eks-node"
+)
+
+func newTestEKSProvider(client awsclient.EKSClient) *EKSProvider {
+	awsConfig := config.NewAWSConfig()
+	awsConfig.EKS.ClusterRoleARN = testEKSClusterRole
+	awsConfig.EKS.NodeRoleARN = testEKSNodeRole
+	awsConfig.EKS.SubnetIDs = []string{"subnet-a", "subnet-b"}
+	return newEKSProvider(awsclient.NewMockEKSClientFactory(client), awsConfig, db.NewMockConnectionFactory(nil), config.NewDataplaneClusterConfig())
+}
+
+func TestEKSProvider_Create(t *testing.T) {
+	type args struct {
+		request *types.ClusterRequest
+	}
+
+	tests := []struct {
+		name         string
+		client       *awsclient.EKSClientMock
+		modifyConfig func(awsConfig *config.AWSConfig)
+		args         args
+		want         *types.ClusterSpec
+		wantErr      bool
+	}{
+		{
+			name:   "should return an error when the cloud provider is not aws",
+			client: &awsclient.EKSClientMock{},
+			args: args{
+				request: &types.ClusterRequest{
+					CloudProvider: "gcp",
+					Region:        "us-east1",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "should return an error without creating the cluster when the cluster role is not set",
+			client: &awsclient.EKSClientMock{},
+			modifyConfig: func(awsConfig *config.AWSConfig) {
+				awsConfig.EKS.ClusterRoleARN = ""
+			},
+			args: args{
+				request: &types.ClusterRequest{
+					CloudProvider: "aws",
+					Region:        testEKSRegion,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "should return an error without creating the cluster when the subnets are not set",
+			client: &awsclient.EKSClientMock{},
+			modifyConfig: func(awsConfig *config.AWSConfig) {
+				awsConfig.EKS.SubnetIDs = nil
+			},
+			args: args{
+				request: &types.ClusterRequest{
+					CloudProvider: "aws",
+					Region:        testEKSRegion,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when the cluster creation fails",
+			client: &awsclient.EKSClientMock{
+				CreateClusterFunc: func(input *eks.CreateClusterInput) (*eks.Cluster, error) {
+					return nil, errors.New("create failed")
+				},
+			},
+			args: args{
+				request: &types.ClusterRequest{
+					CloudProvider: "aws",
+					Region:        testEKSRegion,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should create the cluster with the configured roles and subnets",
+			client: &awsclient.EKSClientMock{
+				CreateClusterFunc: func(input *eks.CreateClusterInput) (*eks.Cluster, error) {
+					if aws.StringValue(input.RoleArn) != testEKSClusterRole || len(input.ResourcesVpcConfig.SubnetIds) != 2 {
+						return nil, errors.New("unexpected input")
+					}
+					return &eks.Cluster{
+						Name: input.Name,
+						Arn:  aws.String(testEKSClusterArn),
+					}, nil
+				},
+			},
+			args: args{
+				request: &types.ClusterRequest{
+					CloudProvider: "aws",
+					Region:        testEKSRegion,
+					MultiAZ:       true,
+				},
+			},
+			want: &types.ClusterSpec{
+				ExternalID:    testEKSClusterArn,
+				Status:        api.ClusterProvisioning,
+				MultiAZ:       true,
+				Region:        testEKSRegion,
+				CloudProvider: "aws",
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			provider := newTestEKSProvider(tt.client)
+			if tt.modifyConfig != nil {
+				tt.modifyConfig(provider.awsConfig)
+			}
+			got, err := provider.Create(tt.args.request)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if !tt.wantErr {
+				g.Expect(got.InternalID).To(gomega.HavePrefix(eksClusterNamePrefix))
+				tt.want.InternalID = got.InternalID
+				g.Expect(got).To(gomega.Equal(tt.want))
+			}
+		})
+	}
+}
+
+func TestEKSProvider_CheckClusterStatus(t *testing.T) {
+	activeCluster := func(clusterName string) (*eks.Cluster, error) {
+		return &eks.Cluster{
+			Name:   aws.String(clusterName),
+			Arn:    aws.String(testEKSClusterArn),
+			Status: aws.String(eks.ClusterStatusActive),
+		}, nil
+	}
+
+	tests := []struct {
+		name                      string
+		client                    *awsclient.EKSClientMock
+		wantStatus                api.ClusterStatus
+		wantNodegroupCreateCalled bool
+		wantErr                   bool
+	}{
+		{
+			name: "should return an error when the cluster cannot be found",
+			client: &awsclient.EKSClientMock{
+				DescribeClusterFunc: func(clusterName string) (*eks.Cluster, error) {
+					return nil, nil
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return provisioning while the control plane is being created",
+			client: &awsclient.EKSClientMock{
+				DescribeClusterFunc: func(clusterName string) (*eks.Cluster, error) {
+					return &eks.Cluster{Status: aws.String(eks.ClusterStatusCreating)}, nil
+				},
+			},
+			wantStatus: api.ClusterProvisioning,
+		},
+		{
+			name: "should return failed when the control plane creation failed",
+			client: &awsclient.EKSClientMock{
+				DescribeClusterFunc: func(clusterName string) (*eks.Cluster, error) {
+					return &eks.Cluster{Status: aws.String(eks.ClusterStatusFailed)}, nil
+				},
+			},
+			wantStatus: api.ClusterFailed,
+		},
+		{
+			name: "should create the default node group once the control plane is active",
+			client: &awsclient.EKSClientMock{
+				DescribeClusterFunc: activeCluster,
+				DescribeNodegroupFunc: func(clusterName string, nodegroupName string) (*eks.Nodegroup, error) {
+					return nil, nil
+				},
+				CreateNodegroupFunc: func(input *eks.CreateNodegroupInput) (*eks.Nodegroup, error) {
+					return &eks.Nodegroup{}, nil
+				},
+			},
+			wantStatus:                api.ClusterProvisioning,
+			wantNodegroupCreateCalled: true,
+		},
+		{
+			name: "should return an error when the default node group creation fails",
+			client: &awsclient.EKSClientMock{
+				DescribeClusterFunc: activeCluster,
+				DescribeNodegroupFunc: func(clusterName string, nodegroupName string) (*eks.Nodegroup, error) {
+					return nil, nil
+				},
+				CreateNodegroupFunc: func(input *eks.CreateNodegroupInput) (*eks.Nodegroup, error) {
+					return nil, errors.New("create failed")
+				},
+			},
+			wantNodegroupCreateCalled: true,
+			wantErr:                   true,
+		},
+		{
+			name: "should return provisioned once the default node group is active",
+			client: &awsclient.EKSClientMock{
+				DescribeClusterFunc: activeCluster,
+				DescribeNodegroupFunc: func(clusterName string, nodegroupName string) (*eks.Nodegroup, error) {
+					return &eks.Nodegroup{Status: aws.String(eks.NodegroupStatusActive)}, nil
+				},
+			},
+			wantStatus: api.ClusterProvisioned,
+		},
+		{
+			name: "should return failed when the default node group creation failed",
+			client: &awsclient.EKSClientMock{
+				DescribeClusterFunc: activeCluster,
+				DescribeNodegroupFunc: func(clusterName string, nodegroupName string) (*eks.Nodegroup, error) {
+					return &eks.Nodegroup{Status: aws.String(eks.NodegroupStatusCreateFailed)}, nil
+				},
+			},
+			wantStatus: api.ClusterFailed,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			provider := newTestEKSProvider(tt.client)
+			got, err := provider.CheckClusterStatus(&types.ClusterSpec{
+				InternalID: testEKSClusterName,
+				Region:     testEKSRegion,
+			})
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(len(tt.client.CreateNodegroupCalls()) > 0).To(gomega.Equal(tt.wantNodegroupCreateCalled))
+			if !tt.wantErr {
+				g.Expect(got.Status).To(gomega.Equal(tt.wantStatus))
+			}
+		})
+	}
+}
+
+func TestEKSProvider_Delete(t *testing.T) {
+	tests := []struct {
+		name                     string
+		client                   *awsclient.EKSClientMock
+		want                     bool
+		wantNodegroupDeleteCalls int
+		wantClusterDeleteCalls   int
+		wantErr                  bool
+	}{
+		{
+			name: "should return an error when listing the node groups fails",
+			client: &awsclient.EKSClientMock{
+				ListNodegroupsFunc: func(clusterName string) ([]string, error) {
+					return nil, errors.New("list failed")
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should delete the node groups that are not being deleted before deleting the cluster",
+			client: &awsclient.EKSClientMock{
+				ListNodegroupsFunc: func(clusterName string) ([]string, error) {
+					return []string{"ng-1", "ng-2"}, nil
+				},
+				DescribeNodegroupFunc: func(clusterName string, nodegroupName string) (*eks.Nodegroup, error) {
+					if nodegroupName == "ng-1" {
+						return &eks.Nodegroup{Status: aws.String(eks.NodegroupStatusDeleting)}, nil
+					}
+					return &eks.Nodegroup{Status: aws.String(eks.NodegroupStatusActive)}, nil
+				},
+				DeleteNodegroupFunc: func(clusterName string, nodegroupName string) error {
+					return nil
+				},
+			},
+			want:                     false,
+			wantNodegroupDeleteCalls: 1,
+		},
+		{
+			name: "should delete the cluster once it has no node groups",
+			client: &awsclient.EKSClientMock{
+				ListNodegroupsFunc: func(clusterName string) ([]string, error) {
+					return nil, nil
+				},
+				DescribeClusterFunc: func(clusterName string) (*eks.Cluster, error) {
+					return &eks.Cluster{Status: aws.String(eks.ClusterStatusActive)}, nil
+				},
+				DeleteClusterFunc: func(clusterName string) error {
+					return nil
+				},
+			},
+			want:                   false,
+			wantClusterDeleteCalls: 1,
+		},
+		{
+			name: "should not delete the cluster again while it is being deleted",
+			client: &awsclient.EKSClientMock{
+				ListNodegroupsFunc: func(clusterName string) ([]string, error) {
+					return nil, nil
+				},
+				DescribeClusterFunc: func(clusterName string) (*eks.Cluster, error) {
+					return &eks.Cluster{Status: aws.String(eks.ClusterStatusDeleting)}, nil
+				},
+			},
+			want: false,
+		},
+		{
+			name: "should return true once the cluster is not found",
+			client: &awsclient.EKSClientMock{
+				ListNodegroupsFunc: func(clusterName string) ([]string, error) {
+					return nil, nil
+				},
+				DescribeClusterFunc: func(clusterName string) (*eks.Cluster, error) {
+					return nil, nil
+				},
+			},
+			want: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			provider := newTestEKSProvider(tt.client)
+			got, err := provider.Delete(&types.ClusterSpec{
+				InternalID: testEKSClusterName,
+				Region:     testEKSRegion,
+			})
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+			g.Expect(tt.client.DeleteNodegroupCalls()).To(gomega.HaveLen(tt.wantNodegroupDeleteCalls))
+			g.Expect(tt.client.DeleteClusterCalls()).To(gomega.HaveLen(tt.wantClusterDeleteCalls))
+		})
+	}
+}
+
+func TestEKSProvider_GetMachinePool(t *testing.T) {
+	tests := []struct {
+		name    string
+		client  *awsclient.EKSClientMock
+		setupFn func()
+		want    *types.MachinePoolInfo
+		wantErr bool
+	}{
+		{
+			name:   "should return an error when the cluster is not found in the database",
+			client: &awsclient.EKSClientMock{},
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "clusters"`).WithError(errors.New("some-error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return nil when the node group does not exist",
+			client: &awsclient.EKSClientMock{
+				DescribeNodegroupFunc: func(clusterName string, nodegroupName string) (*eks.Nodegroup, error) {
+					return nil, nil
+				},
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "clusters"`).WithReply([]map[string]interface{}{{"cluster_id": testEKSClusterName, "region": testEKSRegion}})
+			},
+			want: nil,
+		},
+		{
+			name: "should map the node group to a machine pool",
+			client: &awsclient.EKSClientMock{
+				DescribeNodegroupFunc: func(clusterName string, nodegroupName string) (*eks.Nodegroup, error) {
+					return &eks.Nodegroup{
+						NodegroupName: aws.String(nodegroupName),
+						InstanceTypes: aws.StringSlice([]string{"m5.2xlarge"}),
+						Subnets:       aws.StringSlice([]string{"subnet-a", "subnet-b"}),
+						Labels:        aws.StringMap(map[string]string{"label": "value"}),
+						Taints: []*eks.Taint{
+							{Effect: aws.String(eks.TaintEffectNoExecute), Key: aws.String("key"), Value: aws.String("value")},
+						},
+						ScalingConfig: &eks.NodegroupScalingConfig{
+							MinSize:     aws.Int64(3),
+							MaxSize:     aws.Int64(6),
+							DesiredSize: aws.Int64(3),
+						},
+					}, nil
+				},
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "clusters"`).WithReply([]map[string]interface{}{{"cluster_id": testEKSClusterName, "region": testEKSRegion}})
+			},
+			want: &types.MachinePoolInfo{
+				ID:                 "pool",
+				ClusterID:          testEKSClusterName,
+				InstanceSize:       "m5.2xlarge",
+				MultiAZ:            true,
+				AutoScalingEnabled: true,
+				AutoScaling: types.MachinePoolAutoScaling{
+					MinNodes: 3,
+					MaxNodes: 6,
+				},
+				Replicas:   3,
+				NodeLabels: map[string]string{"label": "value"},
+				NodeTaints: []types.ClusterNodeTaint{
+					{Effect: "NoExecute", Key: "key", Value: "value"},
+				},
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			provider := newTestEKSProvider(tt.client)
+			got, err := provider.GetMachinePool(testEKSClusterName, "pool")
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func TestEKSProvider_CreateMachinePool(t *testing.T) {
+	tests := []struct {
+		name    string
+		client  *awsclient.EKSClientMock
+		request *types.MachinePoolRequest
+		wantErr bool
+	}{
+		{
+			name:   "should return an error when the minimum number of nodes is greater than the maximum",
+			client: &awsclient.EKSClientMock{},
+			request: &types.MachinePoolRequest{
+				ID:                 "pool",
+				ClusterID:          testEKSClusterName,
+				AutoScalingEnabled: true,
+				AutoScaling:        types.MachinePoolAutoScaling{MinNodes: 6, MaxNodes: 3},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "should return an error when a taint effect is not supported",
+			client: &awsclient.EKSClientMock{},
+			request: &types.MachinePoolRequest{
+				ID:         "pool",
+				ClusterID:  testEKSClusterName,
+				NodeTaints: []types.ClusterNodeTaint{{Effect: "Unknown", Key: "key"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should create the node group with the autoscaling bounds",
+			client: &awsclient.EKSClientMock{
+				CreateNodegroupFunc: func(input *eks.CreateNodegroupInput) (*eks.Nodegroup, error) {
+					if aws.Int64Value(input.ScalingConfig.MinSize) != 3 || aws.Int64Value(input.ScalingConfig.MaxSize) != 6 ||
+						aws.StringValue(input.Taints[0].Effect) != eks.TaintEffectNoExecute {
+						return nil, errors.New("unexpected input")
+					}
+					return &eks.Nodegroup{}, nil
+				},
+			},
+			request: &types.MachinePoolRequest{
+				ID:                 "pool",
+				ClusterID:          testEKSClusterName,
+				InstanceSize:       "m5.2xlarge",
+				AutoScalingEnabled: true,
+				AutoScaling:        types.MachinePoolAutoScaling{MinNodes: 3, MaxNodes: 6},
+				NodeTaints:         []types.ClusterNodeTaint{{Effect: "NoExecute", Key: "key", Value: "value"}},
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "clusters"`).WithReply([]map[string]interface{}{{"cluster_id": testEKSClusterName, "region": testEKSRegion}})
+			provider := newTestEKSProvider(tt.client)
+			got, err := provider.CreateMachinePool(tt.request)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if !tt.wantErr {
+				g.Expect(got).To(gomega.Equal(tt.request))
+			}
+		})
+	}
+}
+
+func TestEKSProvider_GetClusterDNS(t *testing.T) {
+	tests := []struct {
+		name              string
+		ingressBaseDomain string
+		want              string
+	}{
+		{
+			name: "should return an empty DNS when no ingress base domain is configured",
+			want: "",
+		},
+		{
+			name:              "should build the DNS from the cluster name and the ingress base domain",
+			ingressBaseDomain: "example.com",
+			want:              testEKSClusterName + ".example.com",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			provider := newTestEKSProvider(&awsclient.EKSClientMock{})
+			provider.awsConfig.EKS.IngressBaseDomain = tt.ingressBaseDomain
+			got, err := provider.GetClusterDNS(&types.ClusterSpec{InternalID: testEKSClusterName})
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
@@ -86,6 +86,10 @@ func (o *OCMProvider) GetClusterSpec(clusterID string) (types.ClusterSpec, error
 		InternalID:    clusterID,
 	}
 
+	if subscription, ok := cluster.GetSubscription(); ok {
+		clusterSpec.SubscriptionID = subscription.ID()
+	}
+
 	clusterStatusInOCM := cluster.Status()
 	if clusterStatusInOCM.State() == clustersmgmtv1.ClusterStateReady {
 		clusterSpec.Status = api.ClusterProvisioned
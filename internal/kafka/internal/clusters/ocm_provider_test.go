@@ -183,6 +183,35 @@ func TestOCMProvider_GetClusterSpec(t *testing.T) {
 			wantErr: false,
 			want:    spec,
 		},
+		{
+			name: "should return the subscription id of the OCM cluster",
+			fields: fields{
+				ocmClient: &ocm.ClientMock{
+					GetClusterFunc: func(clusterID string) (*clustersmgmtv1.Cluster, error) {
+						return clustersmgmtv1.NewCluster().ID(internalID).
+							MultiAZ(true).
+							ExternalID(externalID).
+							CloudProvider(clustersmgmtv1.NewCloudProvider().ID(mocks.MockCloudProviderID)).
+							Region(clustersmgmtv1.NewCloudRegion().ID(mocks.MockCloudRegionID)).
+							Subscription(clustersmgmtv1.NewSubscription().ID("test-subscription-id")).
+							Build()
+					},
+				},
+			},
+			args: args{
+				clusterID: internalID,
+			},
+			wantErr: false,
+			want: types.ClusterSpec{
+				MultiAZ:        true,
+				CloudProvider:  mocks.MockCloudProviderID,
+				Region:         mocks.MockCloudRegionID,
+				ExternalID:     externalID,
+				InternalID:     internalID,
+				Status:         "cluster_provisioning",
+				SubscriptionID: "test-subscription-id",
+			},
+		},
 	}
 
 	for _, testcase := range tests {
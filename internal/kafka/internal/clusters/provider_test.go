@@ -5,6 +5,7 @@ import (
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/aws"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/ocm"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	"github.com/onsi/gomega"
@@ -18,6 +19,7 @@ func TestNewDefaultProviderFactory(t *testing.T) {
 		awsConfig              *config.AWSConfig
 		gcpConfig              *config.GCPConfig
 		dataplaneClusterConfig *config.DataplaneClusterConfig
+		eksClientFactory       aws.EKSClientFactory
 	}
 	tests := []struct {
 		name string
@@ -35,6 +37,9 @@ func TestNewDefaultProviderFactory(t *testing.T) {
 							idGenerator: ocm.NewIDGenerator("mk-"),
 						},
 					},
+					api.ClusterProviderAwsEKS: &EKSProvider{
+						olmResourcesBuilder: &StandaloneProvider{},
+					},
 				},
 			},
 		},
@@ -43,7 +48,7 @@ func TestNewDefaultProviderFactory(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			got := NewDefaultProviderFactory(tt.args.ocmClient, tt.args.connectionFactory, tt.args.ocmConfig, tt.args.awsConfig, tt.args.gcpConfig, tt.args.dataplaneClusterConfig)
+			got := NewDefaultProviderFactory(tt.args.ocmClient, tt.args.connectionFactory, tt.args.ocmConfig, tt.args.awsConfig, tt.args.gcpConfig, tt.args.dataplaneClusterConfig, tt.args.eksClientFactory)
 			g.Expect(got).To(gomega.Equal(tt.want))
 		})
 	}
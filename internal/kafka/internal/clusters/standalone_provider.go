@@ -21,6 +21,7 @@ import (
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
 )
@@ -383,15 +384,25 @@ func (s *StandaloneProvider) ApplyResources(clusterSpec *types.ClusterSpec, reso
 		return nil, err
 	}
 
-	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	err = applyResourcesWithRestConfig(restConfig, resources)
 	if err != nil {
 		return nil, err
 	}
 
+	return &resources, nil
+}
+
+// applyResourcesWithRestConfig applies the given resources to the kubernetes cluster reachable with the given rest config
+func applyResourcesWithRestConfig(restConfig *rest.Config, resources types.ResourceSet) error {
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
 	// Create a REST mapper that tracks information about the available resources in the cluster.
 	dc, err := discovery.NewDiscoveryClientForConfig(restConfig)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	discoveryCachedClient := memory.NewMemCacheClient(dc)
@@ -400,21 +411,30 @@ func (s *StandaloneProvider) ApplyResources(clusterSpec *types.ClusterSpec, reso
 	for _, resource := range resources.Resources {
 		_, err = applyResource(dynamicClient, mapper, resource)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	return &resources, nil
+	return nil
 }
 
 func (s *StandaloneProvider) GetCloudProviders() (*types.CloudProviderInfoList, error) {
+	return getCloudProvidersOfProviderType(s.connectionFactory, api.ClusterProviderStandalone)
+}
+
+func (s *StandaloneProvider) GetCloudProviderRegions(providerInf types.CloudProviderInfo) (*types.CloudProviderRegionInfoList, error) {
+	return getCloudProviderRegionsOfProviderType(s.connectionFactory, api.ClusterProviderStandalone, providerInf)
+}
+
+// getCloudProvidersOfProviderType returns the cloud providers of the clusters, not under deletion, of the given provider type stored in the database
+func getCloudProvidersOfProviderType(connectionFactory *db.ConnectionFactory, providerType api.ClusterProviderType) (*types.CloudProviderInfoList, error) {
 	type Cluster struct {
 		CloudProvider string
 	}
-	dbConn := s.connectionFactory.New().
+	dbConn := connectionFactory.New().
 		Model(&Cluster{}).
 		Distinct("cloud_provider").
-		Where("provider_type = ?", api.ClusterProviderStandalone.String()).
+		Where("provider_type = ?", providerType.String()).
 		Where("status NOT IN (?)", api.ClusterDeletionStatuses)
 
 	var results []Cluster
@@ -435,16 +455,18 @@ func (s *StandaloneProvider) GetCloudProviders() (*types.CloudProviderInfoList,
 	return &types.CloudProviderInfoList{Items: items}, nil
 }
 
-func (s *StandaloneProvider) GetCloudProviderRegions(providerInf types.CloudProviderInfo) (*types.CloudProviderRegionInfoList, error) {
+// getCloudProviderRegionsOfProviderType returns the regions of the given cloud provider where clusters, not under deletion,
+// of the given provider type are stored in the database
+func getCloudProviderRegionsOfProviderType(connectionFactory *db.ConnectionFactory, providerType api.ClusterProviderType, providerInf types.CloudProviderInfo) (*types.CloudProviderRegionInfoList, error) {
 	type Cluster struct {
 		Region  string
 		MultiAZ bool
 	}
-	dbConn := s.connectionFactory.New().
+	dbConn := connectionFactory.New().
 		Model(&Cluster{}).
 		Distinct("region", "multi_az").
 		Where("cloud_provider = ?", providerInf.ID).
-		Where("provider_type = ?", api.ClusterProviderStandalone.String()).
+		Where("provider_type = ?", providerType.String()).
 		Where("status NOT IN (?)", api.ClusterDeletionStatuses)
 
 	var results []Cluster
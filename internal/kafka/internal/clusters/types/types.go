@@ -94,6 +94,9 @@ type ClusterSpec struct {
 	CloudProvider string `json:"cloud_provider"`
 	// multi AZ availability flag of the cluster
 	MultiAZ bool `json:"multi_az"`
+	// id of the subscription of the cluster, used to verify which organization owns the cluster.
+	// Only set by the providers that track the ownership of the clusters.
+	SubscriptionID string `json:"subscription_id,omitempty"`
 }
 
 type CloudProviderInfo struct {
@@ -1,7 +1,11 @@
 package config
 
 import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
 )
 
@@ -9,6 +13,7 @@ type AWSConfig struct {
 	Route53                     awsRoute53Config
 	SecretManager               awsSecretManagerConfig
 	ConfigForOSDClusterCreation awsConfigForOSDClusterCreation
+	EKS                         awsEKSConfig
 }
 
 type awsSecretManagerConfig struct {
@@ -29,6 +34,48 @@ type awsConfigForOSDClusterCreation struct {
 	SecretAccessKey         string
 }
 
+// awsEKSConfig holds the configuration used to create AWS EKS data plane clusters.
+// The clusters are created in the same AWS account used for OSD cluster creation
+type awsEKSConfig struct {
+	// ClusterRoleARN is the IAM role assumed by the EKS control plane
+	ClusterRoleARN string
+	// NodeRoleARN is the IAM role assumed by the worker nodes of the EKS node groups
+	NodeRoleARN string
+	// SubnetIDs are the subnets where the EKS control plane and worker nodes are placed
+	SubnetIDs []string
+	// KubernetesVersion is the kubernetes version of the EKS clusters. The EKS default version is used when empty
+	KubernetesVersion string
+	// NodeInstanceType is the EC2 instance type of the default node group
+	NodeInstanceType string
+	// NodeCount is the number of nodes of the default node group
+	NodeCount int
+	// IngressBaseDomain is the base domain used to build the ingress DNS of the EKS clusters
+	IngressBaseDomain string
+}
+
+// eksMinSubnets is the minimum number of subnets EKS requires for the control plane of a cluster
+const eksMinSubnets = 2
+
+// Validate returns an error when the settings required to create EKS clusters are missing or invalid, so that the
+// EKS clusters are not registered only to fail once they are provisioned
+func (c awsEKSConfig) Validate() error {
+	if !arn.IsARN(c.ClusterRoleARN) {
+		return errors.Errorf("the EKS cluster role ARN %q is not a valid ARN, it should be set with --aws-eks-cluster-role-arn", c.ClusterRoleARN)
+	}
+	if !arn.IsARN(c.NodeRoleARN) {
+		return errors.Errorf("the EKS node role ARN %q is not a valid ARN, it should be set with --aws-eks-node-role-arn", c.NodeRoleARN)
+	}
+	if len(c.SubnetIDs) < eksMinSubnets {
+		return errors.Errorf("at least %d EKS subnet IDs should be set with --aws-eks-subnet-ids, got %d", eksMinSubnets, len(c.SubnetIDs))
+	}
+	for _, subnetID := range c.SubnetIDs {
+		if strings.TrimSpace(subnetID) == "" {
+			return errors.Errorf("the EKS subnet IDs %v should not contain empty values", c.SubnetIDs)
+		}
+	}
+	return nil
+}
+
 type awsRoute53Config struct {
 	AccessKey               string
 	SecretAccessKey         string
@@ -53,6 +100,10 @@ func NewAWSConfig() *AWSConfig {
 			Region:                  "us-east-1",
 			SecretPrefix:            "kas-fleet-manager",
 		},
+		EKS: awsEKSConfig{
+			NodeInstanceType: "m5.2xlarge",
+			NodeCount:        3,
+		},
 	}
 }
 
@@ -66,6 +117,13 @@ func (c *AWSConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.SecretManager.secretAccessKeyFilePath, "aws-secret-manager-secret-access-key-file", c.SecretManager.secretAccessKeyFilePath, "File containing AWS secret manager secret access key")
 	fs.StringVar(&c.SecretManager.SecretPrefix, "aws-secret-manager-secret-prefix", c.SecretManager.SecretPrefix, "Prefix to use for all secret names in AWS secret manager")
 	fs.StringVar(&c.SecretManager.Region, "aws-secret-manager-region", c.SecretManager.Region, "The region of the AWS secret manager")
+	fs.StringVar(&c.EKS.ClusterRoleARN, "aws-eks-cluster-role-arn", c.EKS.ClusterRoleARN, "The ARN of the IAM role used by the EKS control plane")
+	fs.StringVar(&c.EKS.NodeRoleARN, "aws-eks-node-role-arn", c.EKS.NodeRoleARN, "The ARN of the IAM role used by the EKS worker nodes")
+	fs.StringSliceVar(&c.EKS.SubnetIDs, "aws-eks-subnet-ids", c.EKS.SubnetIDs, "Comma separated list of subnet IDs where EKS clusters and node groups are created")
+	fs.StringVar(&c.EKS.KubernetesVersion, "aws-eks-kubernetes-version", c.EKS.KubernetesVersion, "The kubernetes version of the EKS clusters")
+	fs.StringVar(&c.EKS.NodeInstanceType, "aws-eks-node-instance-type", c.EKS.NodeInstanceType, "The EC2 instance type of the default EKS node group")
+	fs.IntVar(&c.EKS.NodeCount, "aws-eks-node-count", c.EKS.NodeCount, "The number of nodes of the default EKS node group")
+	fs.StringVar(&c.EKS.IngressBaseDomain, "aws-eks-ingress-base-domain", c.EKS.IngressBaseDomain, "The base domain used to build the ingress DNS of the EKS clusters")
 }
 
 func (c *AWSConfig) ReadFiles() error {
@@ -29,6 +29,10 @@ func Test_NewAwsConfig(t *testing.T) {
 					Region:                  "us-east-1",
 					SecretPrefix:            "kas-fleet-manager",
 				},
+				EKS: awsEKSConfig{
+					NodeInstanceType: "m5.2xlarge",
+					NodeCount:        3,
+				},
 			},
 		},
 	}
@@ -144,3 +148,63 @@ func Test_ReadFilesAWSConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_awsEKSConfig_Validate(t *testing.T) {
+	validConfig := func() awsEKSConfig {
+		return awsEKSConfig{
+			ClusterRoleARN: "arn:aws:iam::123456789012:role/kas-eks-cluster",
+			NodeRoleARN:    "arn:aws:iam::123456789012:role/kas-eks-node",
+			SubnetIDs:      []string{"subnet-a", "subnet-b"},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		modifyFn func(config *awsEKSConfig)
+		wantErr  bool
+	}{
+		{
+			name: "should return no error when the roles and the subnets are set",
+		},
+		{
+			name: "should return an error when the cluster role ARN is not set",
+			modifyFn: func(config *awsEKSConfig) {
+				config.ClusterRoleARN = ""
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when the node role ARN is not an ARN",
+			modifyFn: func(config *awsEKSConfig) {
+				config.NodeRoleARN = "kas-eks-node"
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when less than two subnets are set",
+			modifyFn: func(config *awsEKSConfig) {
+				config.SubnetIDs = []string{"subnet-a"}
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when a subnet is empty",
+			modifyFn: func(config *awsEKSConfig) {
+				config.SubnetIDs = []string{"subnet-a", " "}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			config := validConfig()
+			if tt.modifyFn != nil {
+				tt.modifyFn(&config)
+			}
+			g.Expect(config.Validate() != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
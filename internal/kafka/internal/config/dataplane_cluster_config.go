@@ -55,6 +55,9 @@ type DataplaneClusterConfig struct {
 	ObservabilityOperatorOLMConfig              OperatorInstallationConfig
 	DynamicScalingConfig                        DynamicScalingConfig
 	NodePrewarmingConfig                        NodePrewarmingConfig
+	// ClusterPlacementStrategies holds the strategy used to place the kafkas of each instance type on the data plane
+	// clusters. The instance types that are not listed use the FirstFitClusterPlacementStrategy.
+	ClusterPlacementStrategies map[string]string
 }
 
 type OperatorInstallationConfig struct {
@@ -76,6 +79,29 @@ const (
 	NoScaling string = "none"
 )
 
+const (
+	// FirstFitClusterPlacementStrategy places kafkas on the first data plane cluster that can receive them. The
+	// capacity of the clusters is evaluated according to the DataPlaneClusterScalingType
+	FirstFitClusterPlacementStrategy string = "first_fit"
+	// BestFitClusterPlacementStrategy places kafkas on the data plane cluster with the least streaming units left
+	// once the kafka is placed, packing the kafkas on as few clusters as possible
+	BestFitClusterPlacementStrategy string = "best_fit"
+	// LeastLoadedClusterPlacementStrategy places kafkas on the data plane cluster with the lowest share of consumed
+	// streaming units, spreading the kafkas across the clusters
+	LeastLoadedClusterPlacementStrategy string = "least_loaded"
+	// ZoneAwareClusterPlacementStrategy places kafkas on the least loaded data plane cluster matching their
+	// availability zones: single AZ kafkas are placed on single AZ clusters unless none of them can receive the kafka
+	ZoneAwareClusterPlacementStrategy string = "zone_aware"
+)
+
+// ClusterPlacementStrategies are the strategies that can be used to place kafkas on the data plane clusters
+var ClusterPlacementStrategies = []string{
+	FirstFitClusterPlacementStrategy,
+	BestFitClusterPlacementStrategy,
+	LeastLoadedClusterPlacementStrategy,
+	ZoneAwareClusterPlacementStrategy,
+}
+
 // constants for operators installation through OpenShift Lifecycle Manager (OLM)
 // in `standalone` cluster provider type
 const (
@@ -136,8 +162,9 @@ func NewDataplaneClusterConfig() *DataplaneClusterConfig {
 			IndexImage:              defaultObservabilityOperatorIndexImage,
 			SubscriptionStartingCSV: defaultObservabilityOperatorStartingCSV,
 		},
-		DynamicScalingConfig: NewDynamicScalingConfig(),
-		NodePrewarmingConfig: NewNodePrewarmingConfig(),
+		DynamicScalingConfig:       NewDynamicScalingConfig(),
+		NodePrewarmingConfig:       NewNodePrewarmingConfig(),
+		ClusterPlacementStrategies: map[string]string{},
 	}
 }
 
@@ -250,6 +277,15 @@ func (conf *ClusterConfig) IsNumberOfStreamingUnitsWithinClusterLimit(clusterID
 	return true
 }
 
+// GetClusterKafkaInstanceLimit returns the maximum number of streaming units of the cluster. It returns false when the
+// cluster is not in the manual list.
+func (conf *ClusterConfig) GetClusterKafkaInstanceLimit(clusterID string) (int, bool) {
+	if manualCluster, exist := conf.clusterConfigMap[clusterID]; exist {
+		return manualCluster.KafkaInstanceLimit, true
+	}
+	return 0, false
+}
+
 func (conf *ClusterConfig) IsClusterSchedulable(clusterID string) bool {
 	if clusterConfigMap, exist := conf.clusterConfigMap[clusterID]; exist {
 		return clusterConfigMap.Schedulable
@@ -301,6 +337,15 @@ func (c *DataplaneClusterConfig) IsDataPlaneAutoScalingEnabled() bool {
 	return c.DataPlaneClusterScalingType == AutoScaling
 }
 
+// GetClusterPlacementStrategy returns the strategy used to place the kafkas of the given instance type on the data
+// plane clusters
+func (c *DataplaneClusterConfig) GetClusterPlacementStrategy(instanceType string) string {
+	if strategy, ok := c.ClusterPlacementStrategies[instanceType]; ok {
+		return strategy
+	}
+	return FirstFitClusterPlacementStrategy
+}
+
 func (c *DataplaneClusterConfig) IsReadyDataPlaneClustersReconcileEnabled() bool {
 	return c.EnableReadyDataPlaneClustersReconcile
 }
@@ -341,6 +386,7 @@ func (c *DataplaneClusterConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.ObservabilityOperatorOLMConfig.SubscriptionStartingCSV, "observability-operator-starting-csv", c.ObservabilityOperatorOLMConfig.SubscriptionStartingCSV, "Observability operator subscription starting CSV")
 	fs.StringVar(&c.DynamicScalingConfig.filePath, "dynamic-scaling-config-file", c.DynamicScalingConfig.filePath, "File path to a file containing the dynamic scaling configuration")
 	fs.StringVar(&c.NodePrewarmingConfig.filePath, "node-prewarming-config-file", c.NodePrewarmingConfig.filePath, "File path to a file containing the node prewarming configuration")
+	fs.StringToStringVar(&c.ClusterPlacementStrategies, "cluster-placement-strategies", c.ClusterPlacementStrategies, fmt.Sprintf("Strategy used to place the kafkas of each instance type on the data plane clusters, e.g. 'standard=best_fit,developer=least_loaded'. Its values should be one of %v. Instance types that are not set use '%s'", ClusterPlacementStrategies, FirstFitClusterPlacementStrategy))
 }
 
 func (c *DataplaneClusterConfig) Validate(env *environments.Env) error {
@@ -355,9 +401,50 @@ func (c *DataplaneClusterConfig) Validate(env *environments.Env) error {
 		}
 	}
 
+	if err := c.validateClusterPlacementStrategies(kafkaConfig); err != nil {
+		return err
+	}
+
+	if err := c.validateEKSClusters(env); err != nil {
+		return err
+	}
+
 	return c.NodePrewarmingConfig.validate(kafkaConfig)
 }
 
+// validateEKSClusters makes sure the settings used to create the EKS clusters are set when any EKS cluster is
+// registered, otherwise the clusters would be persisted and only fail once their creation is attempted
+func (c *DataplaneClusterConfig) validateEKSClusters(env *environments.Env) error {
+	if !c.IsDataPlaneManualScalingEnabled() || c.ClusterConfig == nil {
+		return nil
+	}
+	var awsConfig *AWSConfig
+	env.MustResolve(&awsConfig)
+	for _, cluster := range c.ClusterConfig.clusterList {
+		if cluster.ProviderType != api.ClusterProviderAwsEKS {
+			continue
+		}
+		// the settings are shared by all the EKS clusters, they are only validated once
+		if err := awsConfig.EKS.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid configuration of the %s cluster %q", api.ClusterProviderAwsEKS, cluster.ClusterId)
+		}
+		return nil
+	}
+	return nil
+}
+
+func (c *DataplaneClusterConfig) validateClusterPlacementStrategies(kafkaConfig *KafkaConfig) error {
+	for instanceType, strategy := range c.ClusterPlacementStrategies {
+		if _, err := kafkaConfig.SupportedInstanceTypes.Configuration.GetKafkaInstanceTypeByID(instanceType); err != nil {
+			return errors.Wrapf(err, "invalid cluster placement strategy for instance type %q", instanceType)
+		}
+		if !arrays.Contains(ClusterPlacementStrategies, strategy) {
+			return errors.Errorf("invalid cluster placement strategy %q for instance type %q: the strategy should be one of %v", strategy, instanceType, ClusterPlacementStrategies)
+		}
+	}
+	return nil
+}
+
 func (c *DataplaneClusterConfig) ReadFiles() error {
 	if c.ImagePullDockerConfigContent == "" && c.ImagePullDockerConfigFile != "" {
 		err := shared.ReadFileValueString(c.ImagePullDockerConfigFile, &c.ImagePullDockerConfigContent)
@@ -837,6 +837,39 @@ func Test_IsReadyDataPlaneClustersReconcileEnabled(t *testing.T) {
 	}
 }
 
+func Test_GetClusterPlacementStrategy(t *testing.T) {
+	tests := []struct {
+		name                       string
+		clusterPlacementStrategies map[string]string
+		instanceType               string
+		want                       string
+	}{
+		{
+			name:                       "should return the strategy configured for the instance type",
+			clusterPlacementStrategies: map[string]string{"standard": BestFitClusterPlacementStrategy},
+			instanceType:               "standard",
+			want:                       BestFitClusterPlacementStrategy,
+		},
+		{
+			name:                       "should return the first fit strategy if no strategy is configured for the instance type",
+			clusterPlacementStrategies: map[string]string{"standard": BestFitClusterPlacementStrategy},
+			instanceType:               "developer",
+			want:                       FirstFitClusterPlacementStrategy,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			conf := NewDataplaneClusterConfig()
+			conf.ClusterPlacementStrategies = tt.clusterPlacementStrategies
+			g.Expect(conf.GetClusterPlacementStrategy(tt.instanceType)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
 func Test_DataPlaneClusterConfig_DefaultComputeMachinesConfig(t *testing.T) {
 	t.Parallel()
 	type fields struct {
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/dns"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/spf13/pflag"
+)
+
+// DNSConfig configures the DNS providers managing the CNAME records of the routes of the Kafka instances
+type DNSConfig struct {
+	// Provider is the kind of the DNS provider of the Kafka instances e.g. route53
+	Provider string
+	// CloudProviderProviders overrides the DNS provider of the Kafka instances by their cloud provider e.g. to use
+	// Cloud DNS for the Kafka instances on GCP
+	CloudProviderProviders map[string]string
+
+	// CloudDNSProjectID is the Google Cloud project of the Cloud DNS managed zones, it defaults to the project of the
+	// GCP credentials
+	CloudDNSProjectID string
+	// CloudDNSManagedZone is the name of the managed zone of the Kafka domain, it is looked up when empty
+	CloudDNSManagedZone string
+
+	RFC2136Nameserver     string
+	RFC2136TSIGKeyName    string
+	RFC2136TSIGAlgorithm  string
+	RFC2136TSIGSecret     string
+	RFC2136TSIGSecretFile string
+
+	// DriftCheckInterval is how often the CNAME records of the Kafka instances are compared with the records of the
+	// DNS zone, 0 disables the check
+	DriftCheckInterval time.Duration
+	// DriftRepair repairs the drifted CNAME records, the drift is only reported when it is false
+	DriftRepair bool
+	// DriftDeletedKafkasLookback is how long after the deletion of a Kafka instance its leftover CNAME records are
+	// still looked for
+	DriftDeletedKafkasLookback time.Duration
+}
+
+var _ environments.ConfigModule = &DNSConfig{}
+var _ environments.ServiceValidator = &DNSConfig{}
+
+func NewDNSConfig() *DNSConfig {
+	return &DNSConfig{
+		Provider:                   dns.KindRoute53,
+		CloudProviderProviders:     map[string]string{},
+		RFC2136TSIGSecretFile:      "secrets/rfc2136.tsig-secret",
+		DriftCheckInterval:         time.Hour,
+		DriftRepair:                true,
+		DriftDeletedKafkasLookback: 30 * 24 * time.Hour,
+	}
+}
+
+func (c *DNSConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.Provider, "dns-provider", c.Provider, fmt.Sprintf("The DNS provider of the CNAME records of the Kafka instances, one of %v", dns.Kinds))
+	fs.StringToStringVar(&c.CloudProviderProviders, "dns-cloud-provider-providers", c.CloudProviderProviders, "The DNS providers of the Kafka instances of specific cloud providers e.g. gcp=clouddns")
+	fs.StringVar(&c.CloudDNSProjectID, "clouddns-project-id", c.CloudDNSProjectID, "The Google Cloud project of the Cloud DNS managed zones, defaults to the project of the GCP credentials")
+	fs.StringVar(&c.CloudDNSManagedZone, "clouddns-managed-zone", c.CloudDNSManagedZone, "The name of the Cloud DNS managed zone of the Kafka domain, looked up by its domain name when empty")
+	fs.StringVar(&c.RFC2136Nameserver, "rfc2136-nameserver", c.RFC2136Nameserver, "The host:port of the name server accepting the RFC 2136 dynamic updates")
+	fs.StringVar(&c.RFC2136TSIGKeyName, "rfc2136-tsig-key-name", c.RFC2136TSIGKeyName, "The name of the TSIG key signing the RFC 2136 dynamic updates, the updates are not signed when empty")
+	fs.StringVar(&c.RFC2136TSIGAlgorithm, "rfc2136-tsig-algorithm", c.RFC2136TSIGAlgorithm, "The algorithm of the TSIG key e.g. hmac-sha256 (default)")
+	fs.StringVar(&c.RFC2136TSIGSecretFile, "rfc2136-tsig-secret-file", c.RFC2136TSIGSecretFile, "File containing the base64 encoded secret of the TSIG key")
+	fs.DurationVar(&c.DriftCheckInterval, "dns-drift-check-interval", c.DriftCheckInterval, "How often the CNAME records of the Kafka instances are compared with the DNS zone in golang duration format, 0 disables the check")
+	fs.BoolVar(&c.DriftRepair, "dns-drift-repair", c.DriftRepair, "Repair the drifted CNAME records of the Kafka instances, the drift is only reported when disabled")
+	fs.DurationVar(&c.DriftDeletedKafkasLookback, "dns-drift-deleted-kafkas-lookback", c.DriftDeletedKafkasLookback, "How long after the deletion of a Kafka instance its leftover CNAME records are still looked for in golang duration format")
+}
+
+func (c *DNSConfig) ReadFiles() error {
+	if c.RFC2136TSIGKeyName != "" && c.UsesProvider(dns.KindRFC2136) {
+		return shared.ReadFileValueString(c.RFC2136TSIGSecretFile, &c.RFC2136TSIGSecret)
+	}
+	return nil
+}
+
+func (c *DNSConfig) Validate(env *environments.Env) error {
+	for _, kind := range c.providers() {
+		if !arrays.Contains(dns.Kinds, kind) {
+			return fmt.Errorf("unknown DNS provider %q, supported providers are %v", kind, dns.Kinds)
+		}
+	}
+	if c.UsesProvider(dns.KindRFC2136) && c.RFC2136Nameserver == "" {
+		return fmt.Errorf("rfc2136-nameserver is required by the %s DNS provider", dns.KindRFC2136)
+	}
+	return nil
+}
+
+// ProviderFor returns the kind of the DNS provider of the Kafka instances of the cloud provider
+func (c *DNSConfig) ProviderFor(cloudProvider string) string {
+	if kind, ok := c.CloudProviderProviders[cloudProvider]; ok {
+		return kind
+	}
+	return c.Provider
+}
+
+// UsesProvider returns whether the DNS provider of the given kind manages the records of any Kafka instance
+func (c *DNSConfig) UsesProvider(kind string) bool {
+	return arrays.Contains(c.providers(), kind)
+}
+
+func (c *DNSConfig) providers() []string {
+	kinds := []string{c.Provider}
+	for _, kind := range c.CloudProviderProviders {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
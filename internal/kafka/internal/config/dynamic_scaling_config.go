@@ -2,8 +2,11 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/cloudproviders"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 	"github.com/pkg/errors"
 )
 
@@ -15,6 +18,7 @@ type DynamicScalingConfig struct {
 	EnableDynamicScaleUpManagerScaleUpTrigger     bool                                                     `yaml:"enable_dynamic_data_plane_scale_up"`
 	EnableDynamicScaleDownManagerScaleDownTrigger bool                                                     `yaml:"enable_dynamic_data_plane_scale_down"`
 	NewDataPlaneOpenShiftVersion                  string                                                   `yaml:"new_data_plane_openshift_version"`
+	ScaleDownDrainPolicy                          ScaleDownDrainPolicy                                     `yaml:"scale_down_drain_policy"`
 }
 
 func NewDynamicScalingConfig() DynamicScalingConfig {
@@ -27,6 +31,11 @@ func NewDynamicScalingConfig() DynamicScalingConfig {
 		// as the fleetshard operator addon is currently incompatible with 4.12.
 		// To be set back to an empty string once https://issues.redhat.com/browse/MGDSTRM-10450 is resolved.
 		NewDataPlaneOpenShiftVersion: "openshift-v4.11.22",
+		ScaleDownDrainPolicy: ScaleDownDrainPolicy{
+			CordonGracePeriod:           time.Hour,
+			MigratableInstanceTypes:     []string{types.DEVELOPER.String()},
+			MaxMigratableStreamingUnits: 1,
+		},
 	}
 }
 
@@ -54,6 +63,30 @@ func (c *DynamicScalingConfig) validate() error {
 	return nil
 }
 
+// ScaleDownDrainPolicy drives the removal of the data plane clusters picked by the dynamic scale down. A cluster that can
+// be removed is first cordoned: it keeps on serving its kafkas but it no longer receives new ones. Once the grace period
+// elapsed, the kafkas left on the cluster are migrated off it if allowed, then the cluster is deprovisioned.
+type ScaleDownDrainPolicy struct {
+	// CordonGracePeriod is the time a cluster stays cordoned before being drained and deprovisioned
+	CordonGracePeriod time.Duration `yaml:"cordon_grace_period" validate:"gte=0"`
+	// MigrateRemainingKafkas allows removing the clusters that only hold migratable kafkas by migrating them off the
+	// cluster. Without it, only the empty clusters are removed.
+	MigrateRemainingKafkas bool `yaml:"migrate_remaining_kafkas"`
+	// MigratableInstanceTypes are the instance types whose kafkas are migratable whatever their size
+	MigratableInstanceTypes []string `yaml:"migratable_instance_types"`
+	// MaxMigratableStreamingUnits is the maximum number of streaming units of a migratable kafka of the other instance types
+	MaxMigratableStreamingUnits int `yaml:"max_migratable_streaming_units" validate:"gte=0"`
+}
+
+// IsKafkaMigratable returns true when a kafka of the given instance type consuming the given streaming units can be
+// migrated off a cluster being removed
+func (p *ScaleDownDrainPolicy) IsKafkaMigratable(instanceType string, streamingUnits int) bool {
+	if !p.MigrateRemainingKafkas {
+		return false
+	}
+	return arrays.Contains(p.MigratableInstanceTypes, instanceType) || streamingUnits <= p.MaxMigratableStreamingUnits
+}
+
 type ComputeNodesAutoscalingConfig struct {
 	MaxComputeNodes int `yaml:"max_compute_nodes" validate:"gt=0,gtefield=MinComputeNodes"`
 	MinComputeNodes int `yaml:"min_compute_nodes" validate:"gt=0"`
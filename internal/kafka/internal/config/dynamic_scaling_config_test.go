@@ -503,3 +503,64 @@ func TestDynamicScalingConfig_validate(t *testing.T) {
 		})
 	}
 }
+
+func TestScaleDownDrainPolicy_IsKafkaMigratable(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		instanceType   string
+		streamingUnits int
+	}
+	tests := []struct {
+		name   string
+		policy ScaleDownDrainPolicy
+		args   args
+		want   bool
+	}{
+		{
+			name: "return false when the migration of the remaining kafkas is disabled",
+			policy: ScaleDownDrainPolicy{
+				MigratableInstanceTypes:     []string{"developer"},
+				MaxMigratableStreamingUnits: 1,
+			},
+			args: args{instanceType: "developer", streamingUnits: 1},
+			want: false,
+		},
+		{
+			name: "return true for a kafka of a migratable instance type",
+			policy: ScaleDownDrainPolicy{
+				MigrateRemainingKafkas:  true,
+				MigratableInstanceTypes: []string{"developer"},
+			},
+			args: args{instanceType: "developer", streamingUnits: 2},
+			want: true,
+		},
+		{
+			name: "return true for a kafka small enough to be migrated",
+			policy: ScaleDownDrainPolicy{
+				MigrateRemainingKafkas:      true,
+				MigratableInstanceTypes:     []string{"developer"},
+				MaxMigratableStreamingUnits: 1,
+			},
+			args: args{instanceType: "standard", streamingUnits: 1},
+			want: true,
+		},
+		{
+			name: "return false for a kafka too large to be migrated",
+			policy: ScaleDownDrainPolicy{
+				MigrateRemainingKafkas:      true,
+				MigratableInstanceTypes:     []string{"developer"},
+				MaxMigratableStreamingUnits: 1,
+			},
+			args: args{instanceType: "standard", streamingUnits: 2},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			t.Parallel()
+			g.Expect(testcase.policy.IsKafkaMigratable(testcase.args.instanceType, testcase.args.streamingUnits)).To(gomega.Equal(testcase.want))
+		})
+	}
+}
@@ -1,9 +1,9 @@
 package config
 
 import (
-	"fmt"
+	"time"
+
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
-	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 	"github.com/spf13/pflag"
 )
@@ -19,18 +19,30 @@ type KafkaConfig struct {
 	EnableKafkaOwnerConfig bool
 	KafkaOwnerList         []string
 	KafkaOwnerListFile     string
+	// MaintenanceWindowDuration is how long the weekly maintenance windows, in which the upgrades of the Kafka
+	// instances are rolled out, last
+	MaintenanceWindowDuration time.Duration
+	// CanaryServiceAccountRotationInterval is how often the credentials of the canaries of the Kafka instances are
+	// rotated. The rotation is disabled when it is 0.
+	CanaryServiceAccountRotationInterval time.Duration
+	// UpgradeCampaignWaveTimeout is how long the kafkas of a wave of an upgrade campaign have to finish upgrading. The
+	// kafkas still upgrading past it are failed and the campaign is paused. The timeout is disabled when it is 0.
+	UpgradeCampaignWaveTimeout time.Duration
 }
 
 func NewKafkaConfig() *KafkaConfig {
 	return &KafkaConfig{
-		EnableKafkaCNAMERegistration: false,
-		EnableKafkaOwnerConfig:       false,
-		KafkaDomainName:              "kafka.bf2.dev",
-		KafkaLifespan:                NewKafkaLifespanConfig(),
-		Quota:                        NewKafkaQuotaConfig(),
-		SupportedInstanceTypes:       NewKafkaSupportedInstanceTypesConfig(),
-		KafkaOwnerListFile:           "config/kafka-owner-list.yaml",
-		BrowserUrl:                   "http://localhost:8080/",
+		EnableKafkaCNAMERegistration:         false,
+		EnableKafkaOwnerConfig:               false,
+		KafkaDomainName:                      "kafka.bf2.dev",
+		KafkaLifespan:                        NewKafkaLifespanConfig(),
+		Quota:                                NewKafkaQuotaConfig(),
+		SupportedInstanceTypes:               NewKafkaSupportedInstanceTypesConfig(),
+		KafkaOwnerListFile:                   "config/kafka-owner-list.yaml",
+		BrowserUrl:                           "http://localhost:8080/",
+		MaintenanceWindowDuration:            4 * time.Hour,
+		CanaryServiceAccountRotationInterval: 30 * 24 * time.Hour,
+		UpgradeCampaignWaveTimeout:           24 * time.Hour,
 	}
 }
 
@@ -44,6 +56,9 @@ func (c *KafkaConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.BrowserUrl, "browser-url", c.BrowserUrl, "Browser url to kafka admin UI")
 	fs.BoolVar(&c.EnableKafkaOwnerConfig, "enable-kafka-owner-config", c.EnableKafkaOwnerConfig, "Enable configuration for setting kafka owners")
 	fs.StringVar(&c.KafkaOwnerListFile, "kafka-owner-list-file", c.KafkaOwnerListFile, "File containing list of kafka owners")
+	fs.DurationVar(&c.MaintenanceWindowDuration, "kafka-maintenance-window-duration", c.MaintenanceWindowDuration, "How long the weekly maintenance windows, in which the upgrades of the Kafka instances are rolled out, last")
+	fs.DurationVar(&c.CanaryServiceAccountRotationInterval, "canary-service-account-rotation-interval", c.CanaryServiceAccountRotationInterval, "How often the credentials of the canaries of the Kafka instances are rotated, 0 disables the rotation")
+	fs.DurationVar(&c.UpgradeCampaignWaveTimeout, "upgrade-campaign-wave-timeout", c.UpgradeCampaignWaveTimeout, "How long the kafkas of a wave of an upgrade campaign have to finish upgrading before the campaign is paused, 0 disables the timeout")
 	fs.IntVar(&c.Quota.MaxAllowedDeveloperInstances, "max-allowed-developer-instances", c.Quota.MaxAllowedDeveloperInstances, "As a user, one can create up to N defined max developer instances if they do not have quota to create standard instances")
 }
 
@@ -67,17 +82,6 @@ func (c *KafkaConfig) Validate(env *environments.Env) error {
 	return c.SupportedInstanceTypes.Configuration.validate()
 }
 
-func (c *KafkaConfig) GetFirstAvailableSize(instanceType string) (*KafkaInstanceSize, error) {
-	kafkaInstanceType, err := c.SupportedInstanceTypes.Configuration.GetKafkaInstanceTypeByID(instanceType)
-	if err != nil {
-		return nil, err
-	}
-	if len(kafkaInstanceType.Sizes) < 1 || kafkaInstanceType.Sizes[0].Id == "" {
-		return nil, errors.New(errors.ErrorGeneral, fmt.Sprintf("unable to get size for instance type: '%s'", instanceType))
-	}
-	return &kafkaInstanceType.Sizes[0], nil
-}
-
 func (c *KafkaConfig) GetKafkaInstanceSize(instanceType, sizeId string) (*KafkaInstanceSize, error) {
 	kafkaInstanceType, err := c.SupportedInstanceTypes.Configuration.GetKafkaInstanceTypeByID(instanceType)
 	if err != nil {
@@ -82,6 +82,7 @@ func (kp *KafkaInstanceType) HasAnInstanceSizeWithLifespan() bool {
 // - id must be defined and included in the valid instance type id list
 // - display_name must be defined and included in the valid instance type list
 // - sizes cannot be an empty list and each size id must be unique
+// - the billing models the sizes are restricted to must be supported by the instance type
 func (kp *KafkaInstanceType) validate() error {
 	if kp.Id == "" || kp.DisplayName == "" || len(kp.Sizes) == 0 {
 		return fmt.Errorf("kafka instance type '%s' is missing required parameters", kp.Id)
@@ -102,6 +103,12 @@ func (kp *KafkaInstanceType) validate() error {
 		if err := kafkaInstanceSize.validate(kp.Id); err != nil {
 			return err
 		}
+
+		for _, billingModelID := range kafkaInstanceSize.SupportedBillingModels {
+			if _, err := kp.GetKafkaSupportedBillingModelByID(billingModelID); err != nil {
+				return fmt.Errorf("value '%s' in supportedBillingModels for Kafka instance type '%s', size '%s' is invalid: %s", billingModelID, kp.Id, kafkaInstanceSize.Id, err.Error())
+			}
+		}
 	}
 
 	err := validate.Struct(kp)
@@ -156,6 +163,23 @@ type KafkaInstanceSize struct {
 	ReplicationFactor   int            `yaml:"replicationFactor"` // also abbreviated as RF in Kafka terminology
 	LifespanSeconds     *int           `yaml:"lifespanSeconds"`
 	MaturityStatus      MaturityStatus `yaml:"maturityStatus"`
+	// SupportedBillingModels restricts the size to the given kafka billing models of its instance type.
+	// The size is available to all the kafka billing models of its instance type when empty.
+	SupportedBillingModels []string `yaml:"supportedBillingModels"`
+}
+
+// SupportsBillingModel returns true if the size is available to the kafkas of the given kafka billing model
+func (k *KafkaInstanceSize) SupportsBillingModel(kafkaBillingModelID string) bool {
+	return len(k.SupportedBillingModels) == 0 || arrays.AnyMatch(k.SupportedBillingModels, arrays.StringEqualsIgnoreCasePredicate(kafkaBillingModelID))
+}
+
+// SupportsAZMode returns true if the size can be deployed in the given availability zones mode: multi AZ or single AZ
+func (k *KafkaInstanceSize) SupportsAZMode(multiAZ bool) bool {
+	azMode := "single"
+	if multiAZ {
+		azMode = "multi"
+	}
+	return arrays.Contains(k.SupportedAZModes, azMode)
 }
 
 // validates Kafka instance size configuration to ensure the following:
@@ -38,6 +38,48 @@ func TestKafkaSupportedSizesConfig_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Should not return an error when a size is restricted to a supported billing model",
+			configFactoryFunc: func() SupportedKafkaInstanceTypesConfig {
+				testKafkaInstanceSizex1 := buildTestStandardKafkaInstanceSize()
+				testKafkaInstanceSizex1.SupportedBillingModels = []string{"standard"}
+				res := SupportedKafkaInstanceTypesConfig{
+					SupportedKafkaInstanceTypes: []KafkaInstanceType{
+						{
+							Id:          "standard",
+							DisplayName: "Standard",
+							Sizes: []KafkaInstanceSize{
+								testKafkaInstanceSizex1,
+							},
+							SupportedBillingModels: buildTestSupportedBillingModels(),
+						},
+					},
+				}
+				return res
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail because a size is restricted to a billing model not supported by the instance type",
+			configFactoryFunc: func() SupportedKafkaInstanceTypesConfig {
+				testKafkaInstanceSizex1 := buildTestStandardKafkaInstanceSize()
+				testKafkaInstanceSizex1.SupportedBillingModels = []string{"enterprise"}
+				res := SupportedKafkaInstanceTypesConfig{
+					SupportedKafkaInstanceTypes: []KafkaInstanceType{
+						{
+							Id:          "standard",
+							DisplayName: "Standard",
+							Sizes: []KafkaInstanceSize{
+								testKafkaInstanceSizex1,
+							},
+							SupportedBillingModels: buildTestSupportedBillingModels(),
+						},
+					},
+				}
+				return res
+			},
+			wantErr: true,
+		},
 		{
 			name: "Should fail because size was repeated",
 			configFactoryFunc: func() SupportedKafkaInstanceTypesConfig {
@@ -1218,6 +1260,43 @@ func TestKafkaInstanceType_HasAnInstanceSizeWithLifespan(t *testing.T) {
 
 }
 
+func TestKafkaInstanceSize_SupportsBillingModel(t *testing.T) {
+	tests := []struct {
+		name              string
+		kafkaInstanceSize KafkaInstanceSize
+		billingModelID    string
+		want              bool
+	}{
+		{
+			name:              "returns true when the size is not restricted to any billing model",
+			kafkaInstanceSize: KafkaInstanceSize{Id: "x1"},
+			billingModelID:    "enterprise",
+			want:              true,
+		},
+		{
+			name:              "returns true when the size is restricted to the billing model",
+			kafkaInstanceSize: KafkaInstanceSize{Id: "x1", SupportedBillingModels: []string{"standard", "enterprise"}},
+			billingModelID:    "Enterprise",
+			want:              true,
+		},
+		{
+			name:              "returns false when the size is restricted to other billing models",
+			kafkaInstanceSize: KafkaInstanceSize{Id: "x1", SupportedBillingModels: []string{"standard"}},
+			billingModelID:    "enterprise",
+			want:              false,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(tt.kafkaInstanceSize.SupportsBillingModel(tt.billingModelID)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
 func TestKafkaInstanceType_GetBiggestCapacityConsumedSize(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/onsi/gomega"
 )
@@ -14,13 +15,16 @@ func Test_NewKafkaConfig(t *testing.T) {
 		{
 			name: "should return NewKafkaConfig",
 			want: &KafkaConfig{
-				KafkaDomainName:        "kafka.bf2.dev",
-				KafkaLifespan:          NewKafkaLifespanConfig(),
-				Quota:                  NewKafkaQuotaConfig(),
-				BrowserUrl:             "http://localhost:8080/",
-				SupportedInstanceTypes: NewKafkaSupportedInstanceTypesConfig(),
-				EnableKafkaOwnerConfig: false,
-				KafkaOwnerListFile:     "config/kafka-owner-list.yaml",
+				KafkaDomainName:                      "kafka.bf2.dev",
+				KafkaLifespan:                        NewKafkaLifespanConfig(),
+				Quota:                                NewKafkaQuotaConfig(),
+				BrowserUrl:                           "http://localhost:8080/",
+				SupportedInstanceTypes:               NewKafkaSupportedInstanceTypesConfig(),
+				EnableKafkaOwnerConfig:               false,
+				KafkaOwnerListFile:                   "config/kafka-owner-list.yaml",
+				MaintenanceWindowDuration:            4 * time.Hour,
+				CanaryServiceAccountRotationInterval: 30 * 24 * time.Hour,
+				UpgradeCampaignWaveTimeout:           24 * time.Hour,
 			},
 		},
 	}
@@ -17,11 +17,12 @@ const (
 	InMemoryTLSCertStorageType     = "in-memory"
 	SecureTLSCertStorageType       = "secure-storage"
 	FileTLSCertStorageType         = "file"
+	PostgresTLSCertStorageType     = "postgres"
 	ManualCertificateManagement    = "manual"
 	AutomaticCertificateManagement = "automatic"
 )
 
-var validStorageTypes = []string{InMemoryTLSCertStorageType, FileTLSCertStorageType, SecureTLSCertStorageType}
+var validStorageTypes = []string{InMemoryTLSCertStorageType, FileTLSCertStorageType, SecureTLSCertStorageType, PostgresTLSCertStorageType}
 var validCertificateManagementStrategies = []string{ManualCertificateManagement, AutomaticCertificateManagement}
 
 type KafkaTLSCertificateManagementConfig struct {
@@ -47,6 +48,12 @@ type AutomaticCertificateManagementConfig struct {
 	CertificateCacheTTL          time.Duration
 	AcmeIssuerAccountKey         string
 	MustStaple                   bool
+	// InventoryRefreshInterval is how often the inventory of the managed certificates is refreshed from the storage,
+	// 0 disables the inventory and the proactive renewal
+	InventoryRefreshInterval time.Duration
+	// ProactiveRenewalWindow is the time before their expiry the certificates are renewed at, regardless of the renewal
+	// window ratio of the certificate management library. 0 disables the proactive renewal.
+	ProactiveRenewalWindow time.Duration
 }
 
 func NewCertificateManagementConfig() *KafkaTLSCertificateManagementConfig {
@@ -65,13 +72,15 @@ func NewCertificateManagementConfig() *KafkaTLSCertificateManagementConfig {
 			RenewalWindowRatio:           certmagic.Default.RenewalWindowRatio,
 			AcmeIssuerAccountKeyFilePath: "secrets/kafka-tls-certificate-management-acme-issuer-account-key.pem",
 			MustStaple:                   false,
+			InventoryRefreshInterval:     time.Hour,
+			ProactiveRenewalWindow:       21 * 24 * time.Hour,
 		},
 	}
 }
 
 func (c *KafkaTLSCertificateManagementConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.CertificateManagementStrategy, "kafka-tls-certificate-management-strategy", c.CertificateManagementStrategy, "The strategy used to manage tls certificates: Supported values are 'manual', 'automatic'. The default value is 'manual'")
-	fs.StringVar(&c.StorageType, "kafka-tls-certificate-management-storage-type", c.StorageType, "The storage type of the tls certificates: Supported values are 'in-memory', 'secure-storage', 'file'. The default value is 'in-memory'")
+	fs.StringVar(&c.StorageType, "kafka-tls-certificate-management-storage-type", c.StorageType, "The storage type of the tls certificates: Supported values are 'in-memory', 'secure-storage', 'file', 'postgres'. The default value is 'in-memory'")
 	fs.StringVar(&c.ManualCertificateManagementConfig.KafkaTLSCertFilePath, "kafka-tls-cert-file", c.ManualCertificateManagementConfig.KafkaTLSCertFilePath, "File containing kafka certificate")
 	fs.StringVar(&c.ManualCertificateManagementConfig.KafkaTLSKeyFilePath, "kafka-tls-key-file", c.ManualCertificateManagementConfig.KafkaTLSKeyFilePath, "File containing kafka certificate private key")
 	fs.BoolVar(&c.EnableKafkaExternalCertificate, "enable-kafka-external-certificate", c.EnableKafkaExternalCertificate, "Enable custom certificate for Kafka TLS")
@@ -79,6 +88,8 @@ func (c *KafkaTLSCertificateManagementConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.AutomaticCertificateManagementConfig.EmailToSendNotificationTo, "kafka-tls-certificate-management-email", c.AutomaticCertificateManagementConfig.EmailToSendNotificationTo, "The email address that will receive certificate notification. The field is required")
 	fs.StringVar(&c.AutomaticCertificateManagementConfig.AcmeIssuerAccountKeyFilePath, "kafka-tls-certificate-management-acme-issuer-account-key-file-path", c.AutomaticCertificateManagementConfig.AcmeIssuerAccountKeyFilePath, "The file containing the ACME Issuer account key. This is required")
 	fs.Float64Var(&c.AutomaticCertificateManagementConfig.RenewalWindowRatio, "kafka-tls-certificate-management-renewal-window-ratio", c.AutomaticCertificateManagementConfig.RenewalWindowRatio, "How much of a certificate's lifetime becomes the renewal window")
+	fs.DurationVar(&c.AutomaticCertificateManagementConfig.InventoryRefreshInterval, "kafka-tls-certificate-management-inventory-refresh-interval", c.AutomaticCertificateManagementConfig.InventoryRefreshInterval, "How often the inventory of the managed tls certificates is refreshed in golang duration format, 0 disables the inventory and the proactive renewal")
+	fs.DurationVar(&c.AutomaticCertificateManagementConfig.ProactiveRenewalWindow, "kafka-tls-certificate-management-proactive-renewal-window", c.AutomaticCertificateManagementConfig.ProactiveRenewalWindow, "The time before their expiry the managed tls certificates are renewed at in golang duration format, 0 disables the proactive renewal")
 	fs.DurationVar(&c.AutomaticCertificateManagementConfig.CertificateCacheTTL, "kafka-tls-certificate-management-secure-storage-cache-ttl", c.AutomaticCertificateManagementConfig.CertificateCacheTTL, "The cache duration of the certificate when secure-storage is used. Past this duration, the cached certificate will be refreshed from the secure storage on its retrieval")
 }
 
@@ -165,6 +165,21 @@ func TestKafkaTLSCertificateManagementConfig_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "should not return an error when the certificates are stored in postgres",
+			fields: fields{
+				StorageType:                    "postgres",
+				CertificateManagementStrategy:  AutomaticCertificateManagement,
+				RenewalWindowRatio:             0.2,
+				EmailToSendNotificationTo:      "some-email@gmail.com",
+				AcmeIssuerAccountKeyPEMFile:    "some-keyfile.pem",
+				EnableKafkaExternalCertificate: true,
+			},
+			args: args{
+				&environments.Env{},
+			},
+			wantErr: false,
+		},
 		{
 			name: "should not return an error when configuration is invalid for automatic management of certificates but external certificate flag is not enabled",
 			fields: fields{
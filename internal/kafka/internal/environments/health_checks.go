@@ -0,0 +1,75 @@
+package environments
+
+import (
+	"context"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/aws"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/dns"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/ocm"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/server"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/secrets"
+	"github.com/goava/di"
+)
+
+// route53HealthCheckRegion is the region of the Route53 client of the health check, Route53 being a global service
+const route53HealthCheckRegion = "us-east-1"
+
+// HealthCheckProviders provides the health checks of the dependencies of the Kafka service
+func HealthCheckProviders() di.Option {
+	return di.Options(
+		di.Provide(NewOCMHealthCheck),
+		di.Provide(NewAMSHealthCheck),
+		di.Provide(NewRoute53HealthCheck),
+		di.Provide(secrets.NewVaultHealthCheck),
+	)
+}
+
+// NewOCMHealthCheck checks that the clusters can be managed through OCM. It does not fail the readiness probe.
+func NewOCMHealthCheck(clusterManagementClient ocm.ClusterManagementClient) *server.HealthCheck {
+	return &server.HealthCheck{
+		Name: "ocm",
+		Kind: server.DependencyCheck,
+		Check: func(ctx context.Context) error {
+			_, err := clusterManagementClient.GetCloudProvidersContext(ctx)
+			return err
+		},
+	}
+}
+
+// NewAMSHealthCheck checks that the quotas and the subscriptions can be managed through AMS. It does not fail the
+// readiness probe.
+func NewAMSHealthCheck(amsClient ocm.AMSClient) *server.HealthCheck {
+	return &server.HealthCheck{
+		Name: "ams",
+		Kind: server.DependencyCheck,
+		Check: func(ctx context.Context) error {
+			_, err := amsClient.GetCurrentAccountContext(ctx)
+			return err
+		},
+	}
+}
+
+// NewRoute53HealthCheck checks that the hosted zone of the Kafka domain can be read from Route53. There is no check
+// when the CNAME records of the Kafka instances are not registered or not registered with Route53. It does not fail the
+// readiness probe.
+func NewRoute53HealthCheck(kafkaConfig *config.KafkaConfig, dnsConfig *config.DNSConfig, awsConfig *config.AWSConfig, awsClientFactory aws.ClientFactory) *server.HealthCheck {
+	if !kafkaConfig.EnableKafkaCNAMERegistration || !dnsConfig.UsesProvider(dns.KindRoute53) {
+		return nil
+	}
+	return &server.HealthCheck{
+		Name: "route53",
+		Kind: server.DependencyCheck,
+		Check: func(ctx context.Context) error {
+			awsClient, err := awsClientFactory.NewClient(aws.Config{
+				AccessKeyID:     awsConfig.Route53.AccessKey,
+				SecretAccessKey: awsConfig.Route53.SecretAccessKey,
+			}, route53HealthCheckRegion)
+			if err != nil {
+				return err
+			}
+			_, err = awsClient.ListHostedZonesByNameInput(kafkaConfig.KafkaDomainName)
+			return err
+		},
+	}
+}
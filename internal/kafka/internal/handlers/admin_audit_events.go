@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/audit"
+)
+
+type adminAuditEventsHandler struct {
+	auditEvents audit.AuditEventService
+}
+
+func NewAdminAuditEventsHandler(auditEvents audit.AuditEventService) *adminAuditEventsHandler {
+	return &adminAuditEventsHandler{
+		auditEvents: auditEvents,
+	}
+}
+
+func (h adminAuditEventsHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := coreServices.NewListArguments(r.URL.Query())
+			if err := listArgs.Validate(audit.AcceptedOrderByParams); err != nil {
+				return nil, errors.NewWithCause(errors.ErrorMalformedRequest, err, "unable to list audit events: %s", err.Error())
+			}
+
+			events, paging, err := h.auditEvents.List(listArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			eventList := private.AuditEventList{
+				Kind:  "AuditEventList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []private.AuditEvent{},
+			}
+			for _, event := range events {
+				eventList.Items = append(eventList.Items, presenters.PresentAuditEvent(event))
+			}
+
+			return eventList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
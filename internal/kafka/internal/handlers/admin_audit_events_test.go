@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/audit"
+	"github.com/onsi/gomega"
+)
+
+func Test_adminAuditEventsHandler_List(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		auditEvents    audit.AuditEventService
+		wantStatusCode int
+		wantItems      int
+	}{
+		{
+			name:           "should fail if the order by field is unknown",
+			url:            "/audit_events?orderBy=request_body",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should fail if the search query is invalid",
+			url:  "/audit_events?search=password%20%3D%20secret",
+			auditEvents: &audit.AuditEventServiceMock{
+				ListFunc: func(listArgs *coreServices.ListArguments) (api.AuditEventList, *api.PagingMeta, *errors.ServiceError) {
+					return nil, nil, errors.FailedToParseSearch("invalid column name")
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return the audit events",
+			url:  "/audit_events?search=actor%20%3D%20test-user&orderBy=created_at%20asc",
+			auditEvents: &audit.AuditEventServiceMock{
+				ListFunc: func(listArgs *coreServices.ListArguments) (api.AuditEventList, *api.PagingMeta, *errors.ServiceError) {
+					return api.AuditEventList{
+						{ID: "event-1", Actor: "test-user", Method: http.MethodPost, RouteName: "create-kafka", StatusCode: http.StatusAccepted},
+						{ID: "event-2", Actor: "test-user", Method: http.MethodDelete, RouteName: "delete-kafka", TargetId: "kafka-id", StatusCode: http.StatusAccepted},
+					}, &api.PagingMeta{Page: 1, Size: 2, Total: 2}, nil
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantItems:      2,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminAuditEventsHandler(tt.auditEvents)
+			req, rw := GetHandlerParams(http.MethodGet, tt.url, nil, t)
+			h.List(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode == http.StatusOK {
+				var list private.AuditEventList
+				g.Expect(json.NewDecoder(resp.Body).Decode(&list)).To(gomega.Succeed())
+				g.Expect(list.Kind).To(gomega.Equal("AuditEventList"))
+				g.Expect(list.Items).To(gomega.HaveLen(tt.wantItems))
+				g.Expect(list.Items[0].Kind).To(gomega.Equal("AuditEvent"))
+				g.Expect(list.Items[1].TargetId).To(gomega.Equal("kafka-id"))
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/gorilla/mux"
+)
+
+type adminClusterHandler struct {
+	clusterService services.ClusterService
+}
+
+func NewAdminClusterHandler(clusterService services.ClusterService) *adminClusterHandler {
+	return &adminClusterHandler{
+		clusterService: clusterService,
+	}
+}
+
+func (h adminClusterHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := coreServices.NewListArguments(r.URL.Query())
+			if err := listArgs.Validate(services.AcceptedClusterOrderByParams); err != nil {
+				return nil, errors.NewWithCause(errors.ErrorMalformedRequest, err, "unable to list data plane clusters: %s", err.Error())
+			}
+
+			clusters, paging, err := h.clusterService.List(listArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			clusterIDs := make([]string, 0, len(clusters))
+			for _, cluster := range clusters {
+				clusterIDs = append(clusterIDs, cluster.ClusterID)
+			}
+			kafkaInstanceCounts, err := h.findKafkaInstanceCounts(clusterIDs)
+			if err != nil {
+				return nil, err
+			}
+
+			clusterList := private.ClusterList{
+				Kind:  "ClusterList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []private.Cluster{},
+			}
+			for _, cluster := range clusters {
+				clusterList.Items = append(clusterList.Items, presenters.PresentAdminCluster(cluster, kafkaInstanceCounts[cluster.ClusterID]))
+			}
+
+			return clusterList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
+func (h adminClusterHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	cluster, err := h.clusterService.FindClusterByID(id)
+
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			validateGettingClusterFromDatabase(id, cluster, err),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			return h.presentCluster(cluster)
+		},
+	}
+
+	handlers.HandleGet(w, r, cfg)
+}
+
+// Update forces the status of a data plane cluster. It is meant to unblock clusters stuck in a status, the cluster
+// manager carries on reconciling the cluster from the new status.
+func (h adminClusterHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	cluster, err := h.clusterService.FindClusterByID(id)
+
+	var clusterUpdateRequest private.ClusterUpdateRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &clusterUpdateRequest,
+		Validate: []handlers.Validate{
+			validateGettingClusterFromDatabase(id, cluster, err),
+			validateClusterStatus(&clusterUpdateRequest.Status),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			status := api.ClusterStatus(clusterUpdateRequest.Status)
+			if err := h.clusterService.UpdateStatus(*cluster, status); err != nil {
+				return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to update the status of data plane cluster %q", id)
+			}
+			cluster.Status = status
+
+			return h.presentCluster(cluster)
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+// Delete deregisters a data plane cluster. Only the clusters without kafkas can be deregistered.
+func (h adminClusterHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	cluster, err := h.clusterService.FindClusterByID(id)
+
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.ValidateAsyncEnabled(r, "deregistering data plane cluster"),
+			validateGettingClusterFromDatabase(id, cluster, err),
+			validateClusterHasNoKafkas(id, h.clusterService),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			return nil, h.clusterService.DeregisterClusterJob(id)
+		},
+	}
+
+	handlers.HandleDelete(w, r, cfg, http.StatusAccepted)
+}
+
+// Cordon stops placing new kafkas on a data plane cluster until it is uncordoned through the admin API
+func (h adminClusterHandler) Cordon(w http.ResponseWriter, r *http.Request) {
+	h.updateManuallyCordoned(w, r, true)
+}
+
+// Uncordon resumes placing new kafkas on a data plane cluster
+func (h adminClusterHandler) Uncordon(w http.ResponseWriter, r *http.Request) {
+	h.updateManuallyCordoned(w, r, false)
+}
+
+// ReconcileResources requests the resources of a data plane cluster to be reconciled. The reconcile is carried out
+// asynchronously by the cluster manager. Only the resources of the clusters whose status is one of
+// api.ClusterResourcesReconcilableStatuses can be reconciled.
+func (h adminClusterHandler) ReconcileResources(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	cluster, err := h.clusterService.FindClusterByID(id)
+
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			validateGettingClusterFromDatabase(id, cluster, err),
+			validateClusterResourcesReconcilable(cluster),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			requestedAt := time.Now()
+			if err := h.clusterService.UpdateResourcesReconcileRequestedAt(id, &requestedAt); err != nil {
+				return nil, err
+			}
+			cluster.ResourcesReconcileRequestedAt = &requestedAt
+
+			return h.presentCluster(cluster)
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusAccepted)
+}
+
+func (h adminClusterHandler) updateManuallyCordoned(w http.ResponseWriter, r *http.Request, cordoned bool) {
+	id := mux.Vars(r)["id"]
+	cluster, err := h.clusterService.FindClusterByID(id)
+
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			validateGettingClusterFromDatabase(id, cluster, err),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			if err := h.clusterService.UpdateManuallyCordoned(id, cordoned); err != nil {
+				return nil, err
+			}
+
+			// read the cluster again to return the cordoned time set by the database
+			updatedCluster, err := h.clusterService.FindClusterByID(id)
+			if err != nil {
+				return nil, err
+			}
+			if updatedCluster == nil {
+				return nil, errors.NotFound("unable to find data plane cluster with id %q", id)
+			}
+
+			return h.presentCluster(updatedCluster)
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+func (h adminClusterHandler) presentCluster(cluster *api.Cluster) (private.Cluster, *errors.ServiceError) {
+	kafkaInstanceCounts, err := h.findKafkaInstanceCounts([]string{cluster.ClusterID})
+	if err != nil {
+		return private.Cluster{}, err
+	}
+
+	return presenters.PresentAdminCluster(cluster, kafkaInstanceCounts[cluster.ClusterID]), nil
+}
+
+// findKafkaInstanceCounts returns the number of kafkas of each of the given clusters indexed by cluster id
+func (h adminClusterHandler) findKafkaInstanceCounts(clusterIDs []string) (map[string]int, *errors.ServiceError) {
+	kafkaInstanceCounts := map[string]int{}
+	if len(clusterIDs) == 0 {
+		return kafkaInstanceCounts, nil
+	}
+
+	instanceCounts, err := h.clusterService.FindKafkaInstanceCount(clusterIDs)
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to count the kafka instances of the data plane clusters")
+	}
+	for _, instanceCount := range instanceCounts {
+		kafkaInstanceCounts[instanceCount.ClusterID] = instanceCount.Count
+	}
+
+	return kafkaInstanceCounts, nil
+}
+
+func validateGettingClusterFromDatabase(clusterID string, clusterFromDatabase *api.Cluster, err *errors.ServiceError) handlers.Validate {
+	return func() *errors.ServiceError {
+		if err != nil {
+			return err
+		}
+		if clusterFromDatabase == nil {
+			return errors.NotFound("unable to find data plane cluster with id %q", clusterID)
+		}
+		return nil
+	}
+}
+
+func validateClusterResourcesReconcilable(cluster *api.Cluster) handlers.Validate {
+	return func() *errors.ServiceError {
+		if !arrays.Contains(api.ClusterResourcesReconcilableStatuses, cluster.Status) {
+			return errors.Conflict("the resources of data plane cluster %q with status %q cannot be reconciled. Supported statuses are: %v", cluster.ClusterID, cluster.Status, api.ClusterResourcesReconcilableStatuses)
+		}
+		return nil
+	}
+}
+
+func validateClusterStatus(status *string) handlers.Validate {
+	return func() *errors.ServiceError {
+		if !arrays.Contains(api.ClusterStatuses, api.ClusterStatus(*status)) {
+			return errors.BadRequest("invalid data plane cluster status %q. Supported statuses are: %v", *status, api.ClusterStatuses)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+)
+
+type adminClusterPlacementHandler struct {
+	clusterService         services.ClusterService
+	dataplaneClusterConfig *config.DataplaneClusterConfig
+	kafkaConfig            *config.KafkaConfig
+}
+
+func NewAdminClusterPlacementHandler(clusterService services.ClusterService, dataplaneClusterConfig *config.DataplaneClusterConfig, kafkaConfig *config.KafkaConfig) *adminClusterPlacementHandler {
+	return &adminClusterPlacementHandler{
+		clusterService:         clusterService,
+		dataplaneClusterConfig: dataplaneClusterConfig,
+		kafkaConfig:            kafkaConfig,
+	}
+}
+
+// DryRun returns the cluster each cluster placement strategy would place the hypothetical kafka of the request on.
+// Nothing is persisted, so the strategies can be compared against the current state of the data plane.
+func (h adminClusterPlacementHandler) DryRun(w http.ResponseWriter, r *http.Request) {
+	var dryRunRequest private.ClusterPlacementDryRunRequest
+
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &dryRunRequest,
+		Validate: []handlers.Validate{
+			handlers.ValidateLength(&dryRunRequest.CloudProvider, "cloud_provider", handlers.MinRequiredFieldLength, nil),
+			handlers.ValidateLength(&dryRunRequest.Region, "region", handlers.MinRequiredFieldLength, nil),
+			handlers.ValidateLength(&dryRunRequest.InstanceType, "instance_type", handlers.MinRequiredFieldLength, nil),
+			handlers.ValidateLength(&dryRunRequest.SizeId, "size_id", handlers.MinRequiredFieldLength, nil),
+			func() *errors.ServiceError {
+				if _, err := h.kafkaConfig.GetKafkaInstanceSize(dryRunRequest.InstanceType, dryRunRequest.SizeId); err != nil {
+					return errors.BadRequest("kafka size %q of instance type %q is not supported", dryRunRequest.SizeId, dryRunRequest.InstanceType)
+				}
+				return nil
+			},
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			kafka := &dbapi.KafkaRequest{
+				CloudProvider:            dryRunRequest.CloudProvider,
+				Region:                   dryRunRequest.Region,
+				MultiAZ:                  dryRunRequest.MultiAz,
+				InstanceType:             dryRunRequest.InstanceType,
+				SizeId:                   dryRunRequest.SizeId,
+				DesiredKafkaBillingModel: dryRunRequest.BillingModel,
+				ClusterID:                dryRunRequest.ClusterId,
+				OrganisationId:           dryRunRequest.OrganisationId,
+			}
+
+			dryRun := private.ClusterPlacementDryRun{
+				Kind:               "ClusterPlacementDryRun",
+				ConfiguredStrategy: h.dataplaneClusterConfig.GetClusterPlacementStrategy(kafka.InstanceType),
+				Items:              []private.ClusterPlacementDryRunItem{},
+			}
+			for _, name := range config.ClusterPlacementStrategies {
+				item := private.ClusterPlacementDryRunItem{Strategy: name}
+				strategy, err := services.NewClusterPlacementStrategyByName(name, h.clusterService, h.dataplaneClusterConfig, h.kafkaConfig)
+				if err != nil {
+					return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to create cluster placement strategy %q", name)
+				}
+				cluster, err := strategy.FindCluster(kafka)
+				switch {
+				case err != nil:
+					item.Error = err.Error()
+				case cluster != nil:
+					item.ClusterId = cluster.ClusterID
+				}
+				dryRun.Items = append(dryRun.Items, item)
+			}
+
+			return dryRun, nil
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
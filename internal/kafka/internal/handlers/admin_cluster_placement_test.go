@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+func Test_adminClusterPlacementHandler_DryRun(t *testing.T) {
+	kafkaConfig := &config.KafkaConfig{
+		SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
+			Configuration: config.SupportedKafkaInstanceTypesConfig{
+				SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+					{
+						Id: types.STANDARD.String(),
+						Sizes: []config.KafkaInstanceSize{
+							{
+								Id:               "x1",
+								CapacityConsumed: 1,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	dataplaneClusterConfig := config.NewDataplaneClusterConfig()
+	dataplaneClusterConfig.DataPlaneClusterScalingType = config.AutoScaling
+	dataplaneClusterConfig.ClusterPlacementStrategies = map[string]string{types.STANDARD.String(): config.LeastLoadedClusterPlacementStrategy}
+
+	clusters := []*api.Cluster{
+		{
+			ClusterID:           "cluster-1",
+			ClusterType:         api.ManagedDataPlaneClusterType.String(),
+			MultiAZ:             true,
+			DynamicCapacityInfo: api.JSON([]byte(`{"standard":{"max_nodes":1,"max_units":4,"remaining_units":4}}`)),
+		},
+		{
+			ClusterID:           "cluster-2",
+			ClusterType:         api.ManagedDataPlaneClusterType.String(),
+			MultiAZ:             true,
+			DynamicCapacityInfo: api.JSON([]byte(`{"standard":{"max_nodes":1,"max_units":10,"remaining_units":10}}`)),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		body           private.ClusterPlacementDryRunRequest
+		clusterService services.ClusterService
+		wantStatusCode int
+		want           *private.ClusterPlacementDryRun
+	}{
+		{
+			name: "should fail if the cloud provider is missing",
+			body: private.ClusterPlacementDryRunRequest{
+				Region:       "us-east-1",
+				InstanceType: types.STANDARD.String(),
+				SizeId:       "x1",
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should fail if the kafka size is not supported",
+			body: private.ClusterPlacementDryRunRequest{
+				CloudProvider: "aws",
+				Region:        "us-east-1",
+				InstanceType:  types.STANDARD.String(),
+				SizeId:        "x100",
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return the cluster picked by each strategy",
+			body: private.ClusterPlacementDryRunRequest{
+				CloudProvider: "aws",
+				Region:        "us-east-1",
+				MultiAz:       true,
+				InstanceType:  types.STANDARD.String(),
+				SizeId:        "x1",
+			},
+			clusterService: &services.ClusterServiceMock{
+				FindAllClustersFunc: func(criteria services.FindClusterCriteria) ([]*api.Cluster, error) {
+					return clusters, nil
+				},
+				FindStreamingUnitCountByClusterAndInstanceTypeFunc: func() (services.KafkaStreamingUnitCountPerClusterList, error) {
+					return services.KafkaStreamingUnitCountPerClusterList{
+						{ClusterId: "cluster-1", InstanceType: types.STANDARD.String(), Count: 2},
+						{ClusterId: "cluster-2", InstanceType: types.STANDARD.String(), Count: 2},
+					}, nil
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			want: &private.ClusterPlacementDryRun{
+				Kind:               "ClusterPlacementDryRun",
+				ConfiguredStrategy: config.LeastLoadedClusterPlacementStrategy,
+				Items: []private.ClusterPlacementDryRunItem{
+					{Strategy: config.FirstFitClusterPlacementStrategy, ClusterId: "cluster-1"},
+					{Strategy: config.BestFitClusterPlacementStrategy, ClusterId: "cluster-1"},
+					{Strategy: config.LeastLoadedClusterPlacementStrategy, ClusterId: "cluster-2"},
+					{Strategy: config.ZoneAwareClusterPlacementStrategy, ClusterId: "cluster-2"},
+				},
+			},
+		},
+		{
+			name: "should return the errors of the strategies",
+			body: private.ClusterPlacementDryRunRequest{
+				CloudProvider: "aws",
+				Region:        "us-east-1",
+				InstanceType:  types.STANDARD.String(),
+				SizeId:        "x1",
+			},
+			clusterService: &services.ClusterServiceMock{
+				FindAllClustersFunc: func(criteria services.FindClusterCriteria) ([]*api.Cluster, error) {
+					return nil, errors.New("failed to find clusters")
+				},
+			},
+			wantStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminClusterPlacementHandler(tt.clusterService, dataplaneClusterConfig, kafkaConfig)
+			body, err := json.Marshal(tt.body)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			req, rw := GetHandlerParams(http.MethodPost, "/cluster_placement/dry_run", bytes.NewBuffer(body), t)
+			h.DryRun(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			var dryRun private.ClusterPlacementDryRun
+			g.Expect(json.NewDecoder(resp.Body).Decode(&dryRun)).To(gomega.Succeed())
+			g.Expect(dryRun.Items).To(gomega.HaveLen(len(config.ClusterPlacementStrategies)))
+			if tt.want != nil {
+				g.Expect(dryRun).To(gomega.Equal(*tt.want))
+				return
+			}
+			for _, item := range dryRun.Items {
+				g.Expect(item.ClusterId).To(gomega.BeEmpty())
+				g.Expect(item.Error).ToNot(gomega.BeEmpty())
+			}
+		})
+	}
+}
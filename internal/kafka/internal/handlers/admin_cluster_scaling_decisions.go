@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/gorilla/mux"
+)
+
+type adminClusterScalingDecisionsHandler struct {
+	scalingDecisions services.ClusterScalingDecisionService
+}
+
+func NewAdminClusterScalingDecisionsHandler(scalingDecisions services.ClusterScalingDecisionService) *adminClusterScalingDecisionsHandler {
+	return &adminClusterScalingDecisionsHandler{
+		scalingDecisions: scalingDecisions,
+	}
+}
+
+// List returns the decisions taken by the dynamic scale down about a data plane cluster.
+// The decisions are kept once the cluster is deleted so its existence is not checked.
+func (h adminClusterScalingDecisionsHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			listArgs := coreServices.NewListArguments(r.URL.Query())
+			if err := listArgs.Validate([]string{}); err != nil {
+				return nil, errors.NewWithCause(errors.ErrorMalformedRequest, err, "unable to list the scaling decisions of cluster %q: %s", id, err.Error())
+			}
+
+			decisions, paging, err := h.scalingDecisions.ListByClusterId(id, listArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			decisionList := private.ClusterScalingDecisionList{
+				Kind:  "ClusterScalingDecisionList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []private.ClusterScalingDecision{},
+			}
+			for _, decision := range decisions {
+				decisionList.Items = append(decisionList.Items, presenters.PresentClusterScalingDecision(decision))
+			}
+
+			return decisionList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
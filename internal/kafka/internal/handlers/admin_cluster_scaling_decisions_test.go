@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+)
+
+func Test_adminClusterScalingDecisionsHandler_List(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		scalingDecisions services.ClusterScalingDecisionService
+		wantStatusCode   int
+		want             *private.ClusterScalingDecisionList
+	}{
+		{
+			name:             "should return bad request if the list arguments are invalid",
+			query:            "?page=-1",
+			scalingDecisions: &services.ClusterScalingDecisionServiceMock{},
+			wantStatusCode:   http.StatusBadRequest,
+		},
+		{
+			name:  "should return an error if listing the decisions fails",
+			query: "",
+			scalingDecisions: &services.ClusterScalingDecisionServiceMock{
+				ListByClusterIdFunc: func(clusterId string, listArgs *coreServices.ListArguments) (dbapi.ClusterScalingDecisionList, *api.PagingMeta, *errors.ServiceError) {
+					return nil, nil, errors.GeneralError("failed to list the decisions")
+				},
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:  "should return the scaling decisions about the cluster",
+			query: "",
+			scalingDecisions: &services.ClusterScalingDecisionServiceMock{
+				ListByClusterIdFunc: func(clusterId string, listArgs *coreServices.ListArguments) (dbapi.ClusterScalingDecisionList, *api.PagingMeta, *errors.ServiceError) {
+					return dbapi.ClusterScalingDecisionList{
+						{ID: "decision-2", ClusterId: clusterId, Decision: dbapi.ClusterScalingDecisionCordoned, Reason: "the cluster can be removed without triggering a scale up"},
+						{ID: "decision-1", ClusterId: clusterId, Decision: dbapi.ClusterScalingDecisionKept, Reason: "the cluster is not empty", DryRun: true},
+					}, &api.PagingMeta{Page: 1, Size: 2, Total: 2}, nil
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			want: &private.ClusterScalingDecisionList{
+				Kind:  "ClusterScalingDecisionList",
+				Page:  1,
+				Size:  2,
+				Total: 2,
+				Items: []private.ClusterScalingDecision{
+					{Id: "decision-2", Kind: "ClusterScalingDecision", ClusterId: "cluster-id", Decision: "cordoned", Reason: "the cluster can be removed without triggering a scale up"},
+					{Id: "decision-1", Kind: "ClusterScalingDecision", ClusterId: "cluster-id", Decision: "kept", Reason: "the cluster is not empty", DryRun: true},
+				},
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminClusterScalingDecisionsHandler(tt.scalingDecisions)
+			req, rw := GetHandlerParams(http.MethodGet, "/clusters/{id}/scaling_decisions"+tt.query, nil, t)
+			req = mux.SetURLVars(req, map[string]string{"id": "cluster-id"})
+			h.List(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.want != nil {
+				var list private.ClusterScalingDecisionList
+				g.Expect(json.NewDecoder(resp.Body).Decode(&list)).To(gomega.Succeed())
+				g.Expect(list).To(gomega.Equal(*tt.want))
+			}
+		})
+	}
+}
@@ -0,0 +1,446 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+)
+
+const adminClusterID = "cluster-id"
+
+func findAdminCluster(clusterID string) (*api.Cluster, *errors.ServiceError) {
+	return &api.Cluster{ClusterID: clusterID, Status: api.ClusterReady}, nil
+}
+
+func findNoAdminCluster(clusterID string) (*api.Cluster, *errors.ServiceError) {
+	return nil, nil
+}
+
+func countAdminClusterKafkas(clusterIDs []string) ([]services.ResKafkaInstanceCount, error) {
+	counts := []services.ResKafkaInstanceCount{}
+	for _, clusterID := range clusterIDs {
+		counts = append(counts, services.ResKafkaInstanceCount{ClusterID: clusterID, Count: 2})
+	}
+	return counts, nil
+}
+
+func Test_adminClusterHandler_List(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		clusterService services.ClusterService
+		wantStatusCode int
+		want           *private.ClusterList
+	}{
+		{
+			name:           "should return bad request if the order by criteria is not supported",
+			query:          "?orderBy=client_secret",
+			clusterService: &services.ClusterServiceMock{},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return an error if listing the clusters fails",
+			clusterService: &services.ClusterServiceMock{
+				ListFunc: func(listArgs *coreServices.ListArguments) (api.ClusterList, *api.PagingMeta, *errors.ServiceError) {
+					return nil, nil, errors.GeneralError("failed to list the clusters")
+				},
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:  "should return the clusters with their kafka instance count",
+			query: "?search=region%20%3D%20us-east-1&orderBy=region%20asc",
+			clusterService: &services.ClusterServiceMock{
+				ListFunc: func(listArgs *coreServices.ListArguments) (api.ClusterList, *api.PagingMeta, *errors.ServiceError) {
+					return api.ClusterList{
+						{ClusterID: "cluster-1", Region: "us-east-1", Status: api.ClusterReady},
+						{ClusterID: "cluster-2", Region: "us-east-1", Status: api.ClusterFull},
+					}, &api.PagingMeta{Page: 1, Size: 2, Total: 2}, nil
+				},
+				FindKafkaInstanceCountFunc: func(clusterIDs []string) ([]services.ResKafkaInstanceCount, error) {
+					return []services.ResKafkaInstanceCount{{ClusterID: "cluster-2", Count: 3}}, nil
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			want: &private.ClusterList{
+				Kind:  "ClusterList",
+				Page:  1,
+				Size:  2,
+				Total: 2,
+				Items: []private.Cluster{
+					{Id: "cluster-1", Kind: "Cluster", Href: "/api/kafkas_mgmt/v1/clusters/cluster-1", ClusterId: "cluster-1", Region: "us-east-1", Status: "ready"},
+					{Id: "cluster-2", Kind: "Cluster", Href: "/api/kafkas_mgmt/v1/clusters/cluster-2", ClusterId: "cluster-2", Region: "us-east-1", Status: "full", KafkaInstanceCount: 3},
+				},
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminClusterHandler(tt.clusterService)
+			req, rw := GetHandlerParams(http.MethodGet, "/clusters"+tt.query, nil, t)
+			h.List(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.want != nil {
+				var list private.ClusterList
+				g.Expect(json.NewDecoder(resp.Body).Decode(&list)).To(gomega.Succeed())
+				g.Expect(list).To(gomega.Equal(*tt.want))
+			}
+		})
+	}
+}
+
+func Test_adminClusterHandler_Get(t *testing.T) {
+	tests := []struct {
+		name           string
+		clusterService services.ClusterService
+		wantStatusCode int
+	}{
+		{
+			name: "should return not found if the cluster does not exist",
+			clusterService: &services.ClusterServiceMock{
+				FindClusterByIDFunc: findNoAdminCluster,
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "should return an error if finding the cluster fails",
+			clusterService: &services.ClusterServiceMock{
+				FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+					return nil, errors.GeneralError("failed to find the cluster")
+				},
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "should return the cluster",
+			clusterService: &services.ClusterServiceMock{
+				FindClusterByIDFunc:        findAdminCluster,
+				FindKafkaInstanceCountFunc: countAdminClusterKafkas,
+			},
+			wantStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminClusterHandler(tt.clusterService)
+			req, rw := GetHandlerParams(http.MethodGet, "/clusters/{id}", nil, t)
+			req = mux.SetURLVars(req, map[string]string{"id": adminClusterID})
+			h.Get(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode == http.StatusOK {
+				var cluster private.Cluster
+				g.Expect(json.NewDecoder(resp.Body).Decode(&cluster)).To(gomega.Succeed())
+				g.Expect(cluster.ClusterId).To(gomega.Equal(adminClusterID))
+				g.Expect(cluster.KafkaInstanceCount).To(gomega.Equal(int32(2)))
+			}
+		})
+	}
+}
+
+func Test_adminClusterHandler_Update(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           private.ClusterUpdateRequest
+		clusterService *services.ClusterServiceMock
+		wantStatusCode int
+		wantStatus     string
+	}{
+		{
+			name: "should return not found if the cluster does not exist",
+			body: private.ClusterUpdateRequest{Status: api.ClusterFailed.String()},
+			clusterService: &services.ClusterServiceMock{
+				FindClusterByIDFunc: findNoAdminCluster,
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "should return bad request if the status is not supported",
+			body: private.ClusterUpdateRequest{Status: "unknown"},
+			clusterService: &services.ClusterServiceMock{
+				FindClusterByIDFunc: findAdminCluster,
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return an error if updating the status fails",
+			body: private.ClusterUpdateRequest{Status: api.ClusterFailed.String()},
+			clusterService: &services.ClusterServiceMock{
+				FindClusterByIDFunc: findAdminCluster,
+				UpdateStatusFunc: func(cluster api.Cluster, status api.ClusterStatus) error {
+					return errors.GeneralError("failed to update the status")
+				},
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "should force the status of the cluster",
+			body: private.ClusterUpdateRequest{Status: api.ClusterFailed.String()},
+			clusterService: &services.ClusterServiceMock{
+				FindClusterByIDFunc: findAdminCluster,
+				UpdateStatusFunc: func(cluster api.Cluster, status api.ClusterStatus) error {
+					return nil
+				},
+				FindKafkaInstanceCountFunc: countAdminClusterKafkas,
+			},
+			wantStatusCode: http.StatusOK,
+			wantStatus:     api.ClusterFailed.String(),
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminClusterHandler(tt.clusterService)
+			body, err := json.Marshal(tt.body)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			req, rw := GetHandlerParams(http.MethodPatch, "/clusters/{id}", bytes.NewBuffer(body), t)
+			req = mux.SetURLVars(req, map[string]string{"id": adminClusterID})
+			h.Update(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatus != "" {
+				var cluster private.Cluster
+				g.Expect(json.NewDecoder(resp.Body).Decode(&cluster)).To(gomega.Succeed())
+				g.Expect(cluster.Status).To(gomega.Equal(tt.wantStatus))
+				g.Expect(tt.clusterService.UpdateStatusCalls()[0].Status.String()).To(gomega.Equal(tt.wantStatus))
+			}
+		})
+	}
+}
+
+func Test_adminClusterHandler_Delete(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		clusterService *services.ClusterServiceMock
+		wantStatusCode int
+	}{
+		{
+			name:           "should return bad request if the deregistration is not asynchronous",
+			clusterService: &services.ClusterServiceMock{},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "should return not found if the cluster does not exist",
+			query: "?async=true",
+			clusterService: &services.ClusterServiceMock{
+				FindClusterByIDFunc: findNoAdminCluster,
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:  "should refuse to deregister a cluster with kafkas",
+			query: "?async=true",
+			clusterService: &services.ClusterServiceMock{
+				FindClusterByIDFunc:        findAdminCluster,
+				FindKafkaInstanceCountFunc: countAdminClusterKafkas,
+			},
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:  "should deregister an empty cluster",
+			query: "?async=true",
+			clusterService: &services.ClusterServiceMock{
+				FindClusterByIDFunc: findAdminCluster,
+				FindKafkaInstanceCountFunc: func(clusterIDs []string) ([]services.ResKafkaInstanceCount, error) {
+					return nil, nil
+				},
+				DeregisterClusterJobFunc: func(clusterID string) *errors.ServiceError {
+					return nil
+				},
+			},
+			wantStatusCode: http.StatusAccepted,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			if tt.clusterService.FindClusterByIDFunc == nil {
+				tt.clusterService.FindClusterByIDFunc = findAdminCluster
+			}
+			h := NewAdminClusterHandler(tt.clusterService)
+			req, rw := GetHandlerParams(http.MethodDelete, "/clusters/{id}"+tt.query, nil, t)
+			req = mux.SetURLVars(req, map[string]string{"id": adminClusterID})
+			h.Delete(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode == http.StatusAccepted {
+				g.Expect(tt.clusterService.DeregisterClusterJobCalls()).To(gomega.HaveLen(1))
+			} else {
+				g.Expect(tt.clusterService.DeregisterClusterJobCalls()).To(gomega.BeEmpty())
+			}
+		})
+	}
+}
+
+func Test_adminClusterHandler_Cordon(t *testing.T) {
+	cordonedAt := time.Now()
+
+	tests := []struct {
+		name           string
+		cordon         bool
+		clusterService *services.ClusterServiceMock
+		wantStatusCode int
+	}{
+		{
+			name:   "should return not found if the cluster does not exist",
+			cordon: true,
+			clusterService: &services.ClusterServiceMock{
+				FindClusterByIDFunc: findNoAdminCluster,
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:   "should return an error if cordoning the cluster fails",
+			cordon: true,
+			clusterService: &services.ClusterServiceMock{
+				FindClusterByIDFunc: findAdminCluster,
+				UpdateManuallyCordonedFunc: func(clusterID string, cordoned bool) *errors.ServiceError {
+					return errors.GeneralError("failed to cordon the cluster")
+				},
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:   "should cordon the cluster",
+			cordon: true,
+			clusterService: &services.ClusterServiceMock{
+				FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+					return &api.Cluster{ClusterID: clusterID, CordonedAt: &cordonedAt, ManuallyCordoned: true}, nil
+				},
+				UpdateManuallyCordonedFunc: func(clusterID string, cordoned bool) *errors.ServiceError {
+					return nil
+				},
+				FindKafkaInstanceCountFunc: countAdminClusterKafkas,
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "should uncordon the cluster",
+			cordon: false,
+			clusterService: &services.ClusterServiceMock{
+				FindClusterByIDFunc: findAdminCluster,
+				UpdateManuallyCordonedFunc: func(clusterID string, cordoned bool) *errors.ServiceError {
+					return nil
+				},
+				FindKafkaInstanceCountFunc: countAdminClusterKafkas,
+			},
+			wantStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminClusterHandler(tt.clusterService)
+			req, rw := GetHandlerParams(http.MethodPost, "/clusters/{id}/cordon", nil, t)
+			req = mux.SetURLVars(req, map[string]string{"id": adminClusterID})
+			if tt.cordon {
+				h.Cordon(rw, req)
+			} else {
+				h.Uncordon(rw, req)
+			}
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode == http.StatusOK {
+				g.Expect(tt.clusterService.UpdateManuallyCordonedCalls()[0].Cordoned).To(gomega.Equal(tt.cordon))
+				var cluster private.Cluster
+				g.Expect(json.NewDecoder(resp.Body).Decode(&cluster)).To(gomega.Succeed())
+				g.Expect(cluster.ManuallyCordoned).To(gomega.Equal(tt.cordon))
+				g.Expect(cluster.CordonedAt != nil).To(gomega.Equal(tt.cordon))
+			}
+		})
+	}
+}
+
+func Test_adminClusterHandler_ReconcileResources(t *testing.T) {
+	tests := []struct {
+		name           string
+		clusterService *services.ClusterServiceMock
+		wantStatusCode int
+	}{
+		{
+			name: "should return not found if the cluster does not exist",
+			clusterService: &services.ClusterServiceMock{
+				FindClusterByIDFunc: findNoAdminCluster,
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "should return a conflict if the resources of the cluster cannot be reconciled in its status",
+			clusterService: &services.ClusterServiceMock{
+				FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+					return &api.Cluster{ClusterID: clusterID, Status: api.ClusterDeprovisioning}, nil
+				},
+			},
+			wantStatusCode: http.StatusConflict,
+		},
+		{
+			name: "should return an error if the reconcile cannot be requested",
+			clusterService: &services.ClusterServiceMock{
+				FindClusterByIDFunc: findAdminCluster,
+				UpdateResourcesReconcileRequestedAtFunc: func(clusterID string, requestedAt *time.Time) *errors.ServiceError {
+					return errors.GeneralError("failed to request the reconcile")
+				},
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "should request the reconcile of the resources of the cluster",
+			clusterService: &services.ClusterServiceMock{
+				FindClusterByIDFunc: findAdminCluster,
+				UpdateResourcesReconcileRequestedAtFunc: func(clusterID string, requestedAt *time.Time) *errors.ServiceError {
+					return nil
+				},
+				FindKafkaInstanceCountFunc: countAdminClusterKafkas,
+			},
+			wantStatusCode: http.StatusAccepted,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminClusterHandler(tt.clusterService)
+			req, rw := GetHandlerParams(http.MethodPost, "/clusters/{id}/reconcile_resources", nil, t)
+			req = mux.SetURLVars(req, map[string]string{"id": adminClusterID})
+			h.ReconcileResources(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode == http.StatusAccepted {
+				var cluster private.Cluster
+				g.Expect(json.NewDecoder(resp.Body).Decode(&cluster)).To(gomega.Succeed())
+				g.Expect(cluster.ResourcesReconcileRequestedAt).ToNot(gomega.BeNil())
+			}
+		})
+	}
+}
@@ -24,9 +24,10 @@ import (
 )
 
 type adminKafkaHandler struct {
-	kafkaService   services.KafkaService
-	accountService account.AccountService
-	clusterService services.ClusterService
+	kafkaService          services.KafkaService
+	accountService        account.AccountService
+	clusterService        services.ClusterService
+	kafkaMigrationService services.KafkaMigrationService
 
 	providerConfig *config.ProviderConfig
 	kafkaConfig    *config.KafkaConfig
@@ -35,11 +36,12 @@ type adminKafkaHandler struct {
 }
 
 func NewAdminKafkaHandler(kafkaService services.KafkaService, accountService account.AccountService, providerConfig *config.ProviderConfig, clusterService services.ClusterService, kafkaConfig *config.KafkaConfig,
-	kafkaTLSCertificateManagementService kafkatlscertmgmt.KafkaTLSCertificateManagementService) *adminKafkaHandler {
+	kafkaTLSCertificateManagementService kafkatlscertmgmt.KafkaTLSCertificateManagementService, kafkaMigrationService services.KafkaMigrationService) *adminKafkaHandler {
 	return &adminKafkaHandler{
-		kafkaService:   kafkaService,
-		accountService: accountService,
-		clusterService: clusterService,
+		kafkaService:          kafkaService,
+		accountService:        accountService,
+		clusterService:        clusterService,
+		kafkaMigrationService: kafkaMigrationService,
 
 		providerConfig:                       providerConfig,
 		kafkaConfig:                          kafkaConfig,
@@ -191,14 +193,30 @@ func (h *adminKafkaHandler) Update(w http.ResponseWriter, r *http.Request) {
 				return kafka.Status
 			}
 
+			// the quota of the kafkas suspended by a user has been released, it must be reserved again to resume them
+			if kafkaUpdateReq.Suspended != nil && !*kafkaUpdateReq.Suspended && kafkaRequest.IsSuspendedByUser() {
+				if err := h.kafkaService.Resume(ctx, kafkaRequest); err != nil {
+					return nil, err
+				}
+			}
+
 			updateRequired := update(&kafkaRequest.DesiredKafkaVersion, kafkaUpdateReq.KafkaVersion)
 			updateRequired = update(&kafkaRequest.DesiredStrimziVersion, kafkaUpdateReq.StrimziVersion) || updateRequired
 			updateRequired = update(&kafkaRequest.DesiredKafkaIBPVersion, kafkaUpdateReq.KafkaIbpVersion) || updateRequired
+			// the new versions wait for the next maintenance window of the kafka
+			if updateRequired {
+				kafkaRequest.UpgradeReleasedAt = nil
+			}
 			updateRequired = update(&kafkaRequest.MaxDataRetentionSize, kafkaUpdateReq.MaxDataRetentionSize) || updateRequired
 
 			newStatus := getStatusBasedOnSuspendedParam(kafkaUpdateReq.Suspended, kafkaRequest)
 			updateRequired = update(&kafkaRequest.Status, newStatus) || updateRequired
 
+			if kafkaUpdateReq.ForceUpgrade && !kafkaRequest.UpgradeForced {
+				kafkaRequest.UpgradeForced = true
+				updateRequired = true
+			}
+
 			if updateRequired {
 				err := h.kafkaService.VerifyAndUpdateKafkaAdmin(ctx, kafkaRequest)
 				if err != nil {
@@ -240,6 +258,30 @@ func (h *adminKafkaHandler) RevokeCertificateOfAKafka(w http.ResponseWriter, r *
 	handlers.Handle(w, r, cfg, http.StatusNoContent)
 }
 
+// Migrate starts migrating the kafka to another data plane cluster. The migration is carried out asynchronously:
+// its progress is reported in the migration status of the kafka.
+func (h *adminKafkaHandler) Migrate(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	ctx := r.Context()
+	kafkaRequest, err := h.kafkaService.Get(ctx, id)
+
+	var kafkaMigrateRequest private.KafkaMigrateRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &kafkaMigrateRequest,
+		Validate: []handlers.Validate{
+			validateGettingKafkaFromDatabase(id, kafkaRequest, err),
+			validateKafkaCanBeMigrated(kafkaRequest),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			if err := h.kafkaMigrationService.StartMigration(kafkaRequest, kafkaMigrateRequest.ClusterId); err != nil {
+				return nil, err
+			}
+			return presenters.PresentKafkaRequestAdminEndpoint(kafkaRequest, h.accountService)
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusAccepted)
+}
+
 func (h *adminKafkaHandler) validateUpdateKafkaSuspended(kafkaRequest *dbapi.KafkaRequest, kafkaUpdateReq *private.KafkaUpdateRequest) handlers.Validate {
 	return func() *errors.ServiceError {
 		if kafkaUpdateReq.Suspended == nil {
@@ -261,6 +303,9 @@ func (h *adminKafkaHandler) validateUpdateKafkaCanBeSuspended(kafkaRequest *dbap
 		if !isSuspendableState {
 			return errors.New(errors.ErrorValidation, "kafka instance with a status of %q cannot be suspended. Kafka instances can only be suspended in the following states: %s", kafkaRequest.Status, suspendableStates)
 		}
+		if kafkaRequest.MigrationInProgress() {
+			return errors.New(errors.ErrorValidation, "kafka instance %q cannot be suspended while it is being migrated to another data plane cluster", kafkaRequest.ID)
+		}
 		return nil
 	}
 }
@@ -283,9 +328,7 @@ func (h *adminKafkaHandler) validateUpdateKafkaCanBeResumed(kafkaRequest *dbapi.
 		if err != nil {
 			return errors.ToServiceError(err)
 		}
-		gracePeriodDays := kafkaBillingModelConfig.GracePeriodDays
-		durationGracePeriodDays := time.Duration(gracePeriodDays*86400) * time.Second
-		startOfGracePeriod := kafkaRequest.ExpiresAt.Time.Add(-durationGracePeriodDays)
+		startOfGracePeriod, _ := kafkaRequest.GetGracePeriodStart(kafkaBillingModelConfig.GracePeriodDays)
 		isWithinOrAfterGracePeriod := timeNow.After(startOfGracePeriod)
 		if isWithinOrAfterGracePeriod {
 			return errors.New(errors.ErrorValidation, "kafka instance with a status of %q cannot be resumed due to the instance is suspended and it is within its grace period: start of grace period: %s ", kafkaRequest.Status, startOfGracePeriod)
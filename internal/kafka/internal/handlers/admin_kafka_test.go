@@ -84,7 +84,7 @@ func Test_Get(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			h := NewAdminKafkaHandler(tt.fields.kafkaService, tt.fields.accountService, tt.fields.providerConfig, tt.fields.clusterService, tt.fields.kafkaConfig, &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{})
+			h := NewAdminKafkaHandler(tt.fields.kafkaService, tt.fields.accountService, tt.fields.providerConfig, tt.fields.clusterService, tt.fields.kafkaConfig, &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{}, &services.KafkaMigrationServiceMock{})
 			req, rw := GetHandlerParams("GET", "/{id}", nil, t)
 			h.Get(rw, req)
 			resp := rw.Result()
@@ -174,7 +174,7 @@ func Test_List(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			h := NewAdminKafkaHandler(tt.fields.kafkaService, tt.fields.accountService, tt.fields.providerConfig, tt.fields.clusterService, tt.fields.kafkaConfig, &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{})
+			h := NewAdminKafkaHandler(tt.fields.kafkaService, tt.fields.accountService, tt.fields.providerConfig, tt.fields.clusterService, tt.fields.kafkaConfig, &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{}, &services.KafkaMigrationServiceMock{})
 			req, rw := GetHandlerParams("GET", tt.args.url, nil, t)
 			h.List(rw, req)
 			resp := rw.Result()
@@ -230,7 +230,7 @@ func Test_Delete(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			h := NewAdminKafkaHandler(tt.fields.kafkaService, tt.fields.accountService, tt.fields.providerConfig, tt.fields.clusterService, tt.fields.kafkaConfig, &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{})
+			h := NewAdminKafkaHandler(tt.fields.kafkaService, tt.fields.accountService, tt.fields.providerConfig, tt.fields.clusterService, tt.fields.kafkaConfig, &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{}, &services.KafkaMigrationServiceMock{})
 			req, rw := GetHandlerParams("DELETE", tt.args.url, nil, t)
 			h.Delete(rw, req)
 			resp := rw.Result()
@@ -1131,13 +1131,143 @@ func Test_adminKafkaHandler_Update(t *testing.T) {
 			wantStatusCode:  http.StatusOK,
 			wantKafkaStatus: constants.KafkaRequestStatusResuming,
 		},
+		{
+			name: "should resume an instance suspended by a user through the kafka service so that its quota is reserved again",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return &api.Cluster{ClusterID: clusterID}, nil
+					},
+					IsStrimziKafkaVersionAvailableInClusterFunc: func(cluster *api.Cluster, strimziVersion, kafkaVersion, ibpVersion string) (bool, error) {
+						return true, nil
+					},
+					CheckStrimziVersionReadyFunc: func(cluster *api.Cluster, strimziVersion string) (bool, error) {
+						return true, nil
+					},
+				},
+				kafkaService: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return &dbapi.KafkaRequest{
+							Status:                 constants.KafkaRequestStatusSuspended.String(),
+							Meta:                   api.Meta{ID: "id"},
+							SuspendedBy:            "some-user",
+							ClusterID:              "cluster-id",
+							ActualKafkaIBPVersion:  "2.8",
+							DesiredKafkaIBPVersion: "2.8",
+							ActualKafkaVersion:     "2.8",
+							DesiredKafkaVersion:    "2.8",
+							DesiredStrimziVersion:  "2.8",
+							MaxDataRetentionSize:   "100",
+						}, nil
+					},
+					ResumeFunc: func(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+						kafkaRequest.Status = constants.KafkaRequestStatusResuming.String()
+						kafkaRequest.SuspendedBy = ""
+						return nil
+					},
+					VerifyAndUpdateKafkaAdminFunc: nil, // should never be called as the kafka is already updated when resumed
+				},
+				accountService: account.NewMockAccountService(),
+			},
+			args: args{
+				url:  kafkaByIdUrl,
+				body: []byte(`{"suspended": false}`),
+			},
+			wantStatusCode:  http.StatusOK,
+			wantKafkaStatus: constants.KafkaRequestStatusResuming,
+		},
+		{
+			name: "should return the error of the kafka service when it fails to resume an instance suspended by a user",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return &api.Cluster{ClusterID: clusterID}, nil
+					},
+					IsStrimziKafkaVersionAvailableInClusterFunc: func(cluster *api.Cluster, strimziVersion, kafkaVersion, ibpVersion string) (bool, error) {
+						return true, nil
+					},
+					CheckStrimziVersionReadyFunc: func(cluster *api.Cluster, strimziVersion string) (bool, error) {
+						return true, nil
+					},
+				},
+				kafkaService: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return &dbapi.KafkaRequest{
+							Status:                 constants.KafkaRequestStatusSuspended.String(),
+							Meta:                   api.Meta{ID: "id"},
+							SuspendedBy:            "some-user",
+							ClusterID:              "cluster-id",
+							ActualKafkaIBPVersion:  "2.8",
+							DesiredKafkaIBPVersion: "2.8",
+							ActualKafkaVersion:     "2.8",
+							DesiredKafkaVersion:    "2.8",
+							DesiredStrimziVersion:  "2.8",
+							MaxDataRetentionSize:   "100",
+						}, nil
+					},
+					ResumeFunc: func(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+						return errors.New(errors.ErrorInsufficientQuota, "insufficient quota")
+					},
+				},
+				accountService: account.NewMockAccountService(),
+			},
+			args: args{
+				url:  kafkaByIdUrl,
+				body: []byte(`{"suspended": false}`),
+			},
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "should force the pending upgrade of the kafka",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return &api.Cluster{ClusterID: clusterID}, nil
+					},
+					IsStrimziKafkaVersionAvailableInClusterFunc: func(cluster *api.Cluster, strimziVersion, kafkaVersion, ibpVersion string) (bool, error) {
+						return true, nil
+					},
+					CheckStrimziVersionReadyFunc: func(cluster *api.Cluster, strimziVersion string) (bool, error) {
+						return true, nil
+					},
+				},
+				kafkaService: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return &dbapi.KafkaRequest{
+							Status:                 constants.KafkaRequestStatusReady.String(),
+							Meta:                   api.Meta{ID: "id"},
+							ClusterID:              "cluster-id",
+							ActualKafkaIBPVersion:  "2.8",
+							DesiredKafkaIBPVersion: "2.8",
+							ActualKafkaVersion:     "2.8",
+							DesiredKafkaVersion:    "3.0",
+							DesiredStrimziVersion:  "2.8",
+							MaxDataRetentionSize:   "100",
+						}, nil
+					},
+					VerifyAndUpdateKafkaAdminFunc: func(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+						if !kafkaRequest.UpgradeForced {
+							return errors.GeneralError("the upgrade is not forced")
+						}
+						return nil
+					},
+				},
+				accountService: account.NewMockAccountService(),
+			},
+			args: args{
+				url:  kafkaByIdUrl,
+				body: []byte(`{"force_upgrade": true}`),
+			},
+			wantStatusCode:  http.StatusOK,
+			wantKafkaStatus: constants.KafkaRequestStatusReady,
+		},
 	}
 
 	for _, testcase := range tests {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			h := NewAdminKafkaHandler(tt.fields.kafkaService, tt.fields.accountService, tt.fields.providerConfig, tt.fields.clusterService, tt.fields.kafkaConfig, &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{})
+			h := NewAdminKafkaHandler(tt.fields.kafkaService, tt.fields.accountService, tt.fields.providerConfig, tt.fields.clusterService, tt.fields.kafkaConfig, &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{}, &services.KafkaMigrationServiceMock{})
 			req, rw := GetHandlerParams("PATCH", tt.args.url, bytes.NewBuffer(tt.args.body), t)
 			h.Update(rw, req)
 			resp := rw.Result()
@@ -1276,7 +1406,7 @@ func Test_RevokeCertificateOfAKafka(t *testing.T) {
 		t.Run(testcase.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
 			t.Parallel()
-			h := NewAdminKafkaHandler(testcase.fields.kafkaService, account.NewMockAccountService(), &config.ProviderConfig{}, &services.ClusterServiceMock{}, &config.KafkaConfig{}, testcase.fields.kafkaTLSCertificateManagementService)
+			h := NewAdminKafkaHandler(testcase.fields.kafkaService, account.NewMockAccountService(), &config.ProviderConfig{}, &services.ClusterServiceMock{}, &config.KafkaConfig{}, testcase.fields.kafkaTLSCertificateManagementService, &services.KafkaMigrationServiceMock{})
 			req, rw := GetHandlerParams("POST", testcase.args.url, bytes.NewBuffer(testcase.args.body), t)
 			h.RevokeCertificateOfAKafka(rw, req)
 			resp := rw.Result()
@@ -1285,3 +1415,138 @@ func Test_RevokeCertificateOfAKafka(t *testing.T) {
 		})
 	}
 }
+
+func Test_adminKafkaHandler_Migrate(t *testing.T) {
+	migrateKafkaByIdUrl := "/kafkas/{id}/migrate"
+
+	readyKafka := func() *dbapi.KafkaRequest {
+		return &dbapi.KafkaRequest{
+			Meta: api.Meta{
+				ID: "kafka-id",
+			},
+			Status:               constants.KafkaRequestStatusReady.String(),
+			ClusterID:            "source-cluster-id",
+			MaxDataRetentionSize: "100",
+		}
+	}
+
+	type fields struct {
+		kafkaService          services.KafkaService
+		kafkaMigrationService services.KafkaMigrationService
+	}
+
+	tests := []struct {
+		name                    string
+		fields                  fields
+		body                    []byte
+		wantStatusCode          int
+		wantStartMigrationCalls int
+	}{
+		{
+			name: "should return a not found error if Kafka not found",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return nil, nil
+					},
+				},
+				kafkaMigrationService: &services.KafkaMigrationServiceMock{},
+			},
+			body:           []byte(`{}`),
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "should return a bad request error if the kafka is not ready",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						kafka := readyKafka()
+						kafka.Status = constants.KafkaRequestStatusProvisioning.String()
+						return kafka, nil
+					},
+				},
+				kafkaMigrationService: &services.KafkaMigrationServiceMock{},
+			},
+			body:           []byte(`{}`),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return a conflict error if the kafka is already being migrated",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						kafka := readyKafka()
+						kafka.MigrationStatus = dbapi.KafkaMigrationStatusProvisioning
+						kafka.MigrationTargetClusterId = "target-cluster-id"
+						return kafka, nil
+					},
+				},
+				kafkaMigrationService: &services.KafkaMigrationServiceMock{},
+			},
+			body:           []byte(`{}`),
+			wantStatusCode: http.StatusConflict,
+		},
+		{
+			name: "should return the error of the migration service if the migration cannot be started",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return readyKafka(), nil
+					},
+				},
+				kafkaMigrationService: &services.KafkaMigrationServiceMock{
+					StartMigrationFunc: func(kafka *dbapi.KafkaRequest, targetClusterID string) *errors.ServiceError {
+						return errors.BadRequest("no data plane cluster is available")
+					},
+				},
+			},
+			body:                    []byte(`{}`),
+			wantStatusCode:          http.StatusBadRequest,
+			wantStartMigrationCalls: 1,
+		},
+		{
+			name: "should start migrating the kafka to the given cluster",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return readyKafka(), nil
+					},
+				},
+				kafkaMigrationService: &services.KafkaMigrationServiceMock{
+					StartMigrationFunc: func(kafka *dbapi.KafkaRequest, targetClusterID string) *errors.ServiceError {
+						if targetClusterID != "target-cluster-id" {
+							return errors.GeneralError("unexpected target cluster %q", targetClusterID)
+						}
+						kafka.MigrationStatus = dbapi.KafkaMigrationStatusProvisioning
+						kafka.MigrationTargetClusterId = targetClusterID
+						return nil
+					},
+				},
+			},
+			body:                    []byte(`{"cluster_id": "target-cluster-id"}`),
+			wantStatusCode:          http.StatusAccepted,
+			wantStartMigrationCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			t.Parallel()
+			h := NewAdminKafkaHandler(testcase.fields.kafkaService, account.NewMockAccountService(), &config.ProviderConfig{}, &services.ClusterServiceMock{}, &config.KafkaConfig{}, &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{}, testcase.fields.kafkaMigrationService)
+			req, rw := GetHandlerParams("POST", migrateKafkaByIdUrl, bytes.NewBuffer(testcase.body), t)
+			h.Migrate(rw, req)
+			resp := rw.Result()
+			g.Expect(resp.StatusCode).To(gomega.Equal(testcase.wantStatusCode))
+			g.Expect(testcase.fields.kafkaMigrationService.(*services.KafkaMigrationServiceMock).StartMigrationCalls()).To(gomega.HaveLen(testcase.wantStartMigrationCalls))
+			if testcase.wantStatusCode == http.StatusAccepted {
+				var kafka private.Kafka
+				g.Expect(json.NewDecoder(resp.Body).Decode(&kafka)).To(gomega.Succeed())
+				g.Expect(kafka.MigrationStatus).To(gomega.Equal(dbapi.KafkaMigrationStatusProvisioning.String()))
+				g.Expect(kafka.MigrationTargetClusterId).To(gomega.Equal("target-cluster-id"))
+			}
+			resp.Body.Close()
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+)
+
+type adminKafkaTLSCertificatesHandler struct {
+	kafkaTLSCertificateInventory services.KafkaTLSCertificateInventoryService
+}
+
+func NewAdminKafkaTLSCertificatesHandler(kafkaTLSCertificateInventory services.KafkaTLSCertificateInventoryService) *adminKafkaTLSCertificatesHandler {
+	return &adminKafkaTLSCertificatesHandler{
+		kafkaTLSCertificateInventory: kafkaTLSCertificateInventory,
+	}
+}
+
+func (h adminKafkaTLSCertificatesHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := coreServices.NewListArguments(r.URL.Query())
+			if err := listArgs.Validate(services.AcceptedKafkaTLSCertificateOrderByParams); err != nil {
+				return nil, errors.NewWithCause(errors.ErrorMalformedRequest, err, "unable to list certificates: %s", err.Error())
+			}
+
+			certificates, paging, err := h.kafkaTLSCertificateInventory.List(listArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			certificateList := private.KafkaTlsCertificateList{
+				Kind:  "KafkaTLSCertificateList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []private.KafkaTlsCertificate{},
+			}
+			for _, certificate := range certificates {
+				certificateList.Items = append(certificateList.Items, presenters.PresentKafkaTLSCertificate(certificate))
+			}
+
+			return certificateList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/onsi/gomega"
+)
+
+func Test_adminKafkaTLSCertificatesHandler_List(t *testing.T) {
+	notAfter := time.Now().Add(10 * 24 * time.Hour)
+
+	tests := []struct {
+		name           string
+		url            string
+		inventory      services.KafkaTLSCertificateInventoryService
+		wantStatusCode int
+		wantItems      int
+	}{
+		{
+			name:           "should fail if the order by field is unknown",
+			url:            "/certificates?orderBy=tls_key_ref",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should fail if the search query is invalid",
+			url:  "/certificates?search=tls_key_ref%20%3D%20key",
+			inventory: &services.KafkaTLSCertificateInventoryServiceMock{
+				ListFunc: func(listArgs *coreServices.ListArguments) (dbapi.KafkaTLSCertificateList, *api.PagingMeta, *errors.ServiceError) {
+					return nil, nil, errors.FailedToParseSearch("invalid column name")
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return the certificates",
+			url:  "/certificates?search=last_renewal_error%20%3C%3E%20%27%27&orderBy=not_after%20asc",
+			inventory: &services.KafkaTLSCertificateInventoryServiceMock{
+				ListFunc: func(listArgs *coreServices.ListArguments) (dbapi.KafkaTLSCertificateList, *api.PagingMeta, *errors.ServiceError) {
+					return dbapi.KafkaTLSCertificateList{
+						{ID: "certificate-1", Domain: "kafka-1.example.com", NotAfter: &notAfter, LastRenewalError: "rate limited"},
+						{ID: "certificate-2", Domain: "kafka-2.example.com"},
+					}, &api.PagingMeta{Page: 1, Size: 2, Total: 2}, nil
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantItems:      2,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminKafkaTLSCertificatesHandler(tt.inventory)
+			req, rw := GetHandlerParams(http.MethodGet, tt.url, nil, t)
+			h.List(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode == http.StatusOK {
+				var list private.KafkaTlsCertificateList
+				g.Expect(json.NewDecoder(resp.Body).Decode(&list)).To(gomega.Succeed())
+				g.Expect(list.Kind).To(gomega.Equal("KafkaTLSCertificateList"))
+				g.Expect(list.Items).To(gomega.HaveLen(tt.wantItems))
+				g.Expect(list.Items[0].Kind).To(gomega.Equal("KafkaTLSCertificate"))
+				g.Expect(list.Items[0].NotAfter).ToNot(gomega.BeNil())
+				g.Expect(list.Items[0].LastRenewalError).To(gomega.Equal("rate limited"))
+				g.Expect(list.Items[1].NotAfter).To(gomega.BeNil())
+			}
+		})
+	}
+}
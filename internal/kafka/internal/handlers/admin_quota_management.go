@@ -0,0 +1,317 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/gorilla/mux"
+)
+
+type adminQuotaManagementListHandler struct {
+	quotaManagementListEntries services.QuotaManagementListEntriesService
+}
+
+func NewAdminQuotaManagementListHandler(quotaManagementListEntries services.QuotaManagementListEntriesService) *adminQuotaManagementListHandler {
+	return &adminQuotaManagementListHandler{
+		quotaManagementListEntries: quotaManagementListEntries,
+	}
+}
+
+func (h adminQuotaManagementListHandler) ListOrganisations(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := coreServices.NewListArguments(r.URL.Query())
+			if err := listArgs.Validate([]string{}); err != nil {
+				return nil, errors.NewWithCause(errors.ErrorMalformedRequest, err, "unable to list quota management list organisations: %s", err.Error())
+			}
+
+			orgs, paging, err := h.quotaManagementListEntries.ListOrganisations(listArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			orgList := private.QuotaManagementOrganisationList{
+				Kind:  "QuotaManagementOrganisationList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []private.QuotaManagementOrganisation{},
+			}
+			for _, org := range orgs {
+				orgList.Items = append(orgList.Items, presenters.PresentQuotaManagementOrganisation(org))
+			}
+
+			return orgList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
+func (h adminQuotaManagementListHandler) GetOrganisation(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			org, err := h.quotaManagementListEntries.FindOrganisationById(id)
+			if err != nil {
+				return nil, err
+			}
+			if org == nil {
+				return nil, errors.NotFound("organisation %q not found in the quota management list", id)
+			}
+			return presenters.PresentQuotaManagementOrganisation(*org), nil
+		},
+	}
+
+	handlers.HandleGet(w, r, cfg)
+}
+
+func (h adminQuotaManagementListHandler) CreateOrganisation(w http.ResponseWriter, r *http.Request) {
+	var request private.QuotaManagementOrganisationRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &request,
+		Validate: []handlers.Validate{
+			handlers.ValidateLength(&request.Id, "id", 1, nil),
+			func() *errors.ServiceError {
+				return validateQuotaManagementListEntry(request.MaxAllowedInstances, request.RegisteredUsers, request.GrantedQuota)
+			},
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			org, err := presenters.ConvertQuotaManagementOrganisationRequest(request)
+			if err != nil {
+				return nil, err
+			}
+			if err := h.quotaManagementListEntries.CreateOrganisation(&org); err != nil {
+				return nil, err
+			}
+			return presenters.PresentQuotaManagementOrganisation(org), nil
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusCreated)
+}
+
+func (h adminQuotaManagementListHandler) UpdateOrganisation(w http.ResponseWriter, r *http.Request) {
+	var request private.QuotaManagementOrganisationUpdateRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &request,
+		Validate: []handlers.Validate{
+			func() *errors.ServiceError {
+				var maxAllowedInstances int32
+				if request.MaxAllowedInstances != nil {
+					maxAllowedInstances = *request.MaxAllowedInstances
+				}
+				return validateQuotaManagementListEntry(maxAllowedInstances, request.RegisteredUsers, request.GrantedQuota)
+			},
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			org, err := h.quotaManagementListEntries.FindOrganisationById(id)
+			if err != nil {
+				return nil, err
+			}
+			if org == nil {
+				return nil, errors.NotFound("organisation %q not found in the quota management list", id)
+			}
+
+			// only the provided fields are updated
+			if request.AnyUser != nil {
+				org.AnyUser = *request.AnyUser
+			}
+			if request.MaxAllowedInstances != nil {
+				org.MaxAllowedInstances = int(*request.MaxAllowedInstances)
+			}
+			if request.RegisteredUsers != nil {
+				if org.RegisteredUsers, err = presenters.ConvertQuotaManagementAccounts(request.RegisteredUsers); err != nil {
+					return nil, err
+				}
+			}
+			if request.GrantedQuota != nil {
+				if org.GrantedQuota, err = presenters.ConvertQuotaManagementGrantedQuota(request.GrantedQuota); err != nil {
+					return nil, err
+				}
+			}
+
+			if err := h.quotaManagementListEntries.UpdateOrganisation(org); err != nil {
+				return nil, err
+			}
+			return presenters.PresentQuotaManagementOrganisation(*org), nil
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+func (h adminQuotaManagementListHandler) DeleteOrganisation(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			return nil, h.quotaManagementListEntries.DeleteOrganisation(id)
+		},
+	}
+
+	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
+}
+
+func (h adminQuotaManagementListHandler) ListServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := coreServices.NewListArguments(r.URL.Query())
+			if err := listArgs.Validate([]string{}); err != nil {
+				return nil, errors.NewWithCause(errors.ErrorMalformedRequest, err, "unable to list quota management list service accounts: %s", err.Error())
+			}
+
+			accounts, paging, err := h.quotaManagementListEntries.ListServiceAccounts(listArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			accountList := private.QuotaManagementServiceAccountList{
+				Kind:  "QuotaManagementServiceAccountList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []private.QuotaManagementServiceAccount{},
+			}
+			for _, account := range accounts {
+				accountList.Items = append(accountList.Items, presenters.PresentQuotaManagementServiceAccount(account))
+			}
+
+			return accountList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
+func (h adminQuotaManagementListHandler) GetServiceAccount(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			username := mux.Vars(r)["username"]
+			account, err := h.quotaManagementListEntries.FindServiceAccountByUsername(username)
+			if err != nil {
+				return nil, err
+			}
+			if account == nil {
+				return nil, errors.NotFound("service account %q not found in the quota management list", username)
+			}
+			return presenters.PresentQuotaManagementServiceAccount(*account), nil
+		},
+	}
+
+	handlers.HandleGet(w, r, cfg)
+}
+
+func (h adminQuotaManagementListHandler) CreateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	var request private.QuotaManagementServiceAccountRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &request,
+		Validate: []handlers.Validate{
+			handlers.ValidateLength(&request.Username, "username", 1, nil),
+			func() *errors.ServiceError {
+				return validateQuotaManagementListEntry(request.MaxAllowedInstances, nil, request.GrantedQuota)
+			},
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			account, err := presenters.ConvertQuotaManagementServiceAccountRequest(request)
+			if err != nil {
+				return nil, err
+			}
+			if err := h.quotaManagementListEntries.CreateServiceAccount(&account); err != nil {
+				return nil, err
+			}
+			return presenters.PresentQuotaManagementServiceAccount(account), nil
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusCreated)
+}
+
+func (h adminQuotaManagementListHandler) UpdateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	var request private.QuotaManagementServiceAccountUpdateRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &request,
+		Validate: []handlers.Validate{
+			func() *errors.ServiceError {
+				var maxAllowedInstances int32
+				if request.MaxAllowedInstances != nil {
+					maxAllowedInstances = *request.MaxAllowedInstances
+				}
+				return validateQuotaManagementListEntry(maxAllowedInstances, nil, request.GrantedQuota)
+			},
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			username := mux.Vars(r)["username"]
+			account, err := h.quotaManagementListEntries.FindServiceAccountByUsername(username)
+			if err != nil {
+				return nil, err
+			}
+			if account == nil {
+				return nil, errors.NotFound("service account %q not found in the quota management list", username)
+			}
+
+			// only the provided fields are updated
+			if request.MaxAllowedInstances != nil {
+				account.MaxAllowedInstances = int(*request.MaxAllowedInstances)
+			}
+			if request.GrantedQuota != nil {
+				if account.GrantedQuota, err = presenters.ConvertQuotaManagementGrantedQuota(request.GrantedQuota); err != nil {
+					return nil, err
+				}
+			}
+
+			if err := h.quotaManagementListEntries.UpdateServiceAccount(account); err != nil {
+				return nil, err
+			}
+			return presenters.PresentQuotaManagementServiceAccount(*account), nil
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+func (h adminQuotaManagementListHandler) DeleteServiceAccount(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			username := mux.Vars(r)["username"]
+			return nil, h.quotaManagementListEntries.DeleteServiceAccount(username)
+		},
+	}
+
+	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
+}
+
+// validateQuotaManagementListEntry checks that the limits of a quota management list entry are not negative
+// and that its registered users and granted quota are identified
+func validateQuotaManagementListEntry(maxAllowedInstances int32, registeredUsers []private.QuotaManagementAccount, grantedQuota []private.QuotaManagementGrantedQuota) *errors.ServiceError {
+	if maxAllowedInstances < 0 {
+		return errors.Validation("max_allowed_instances must be equal or greater than 0")
+	}
+	for _, user := range registeredUsers {
+		if user.Username == "" {
+			return errors.Validation("registered_users: username is required")
+		}
+		if err := validateQuotaManagementListEntry(user.MaxAllowedInstances, nil, user.GrantedQuota); err != nil {
+			return err
+		}
+	}
+	for _, quota := range grantedQuota {
+		if quota.InstanceTypeId == "" {
+			return errors.Validation("granted_quota: instance_type_id is required")
+		}
+		for _, bm := range quota.KafkaBillingModels {
+			if bm.Id == "" {
+				return errors.Validation("granted_quota: the id of the kafka billing models is required")
+			}
+			if bm.MaxAllowedInstances < 0 {
+				return errors.Validation("granted_quota: max_allowed_instances of billing model %q must be equal or greater than 0", bm.Id)
+			}
+		}
+	}
+	return nil
+}
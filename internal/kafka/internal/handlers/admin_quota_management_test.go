@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/quota_management"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+)
+
+func Test_adminQuotaManagementListHandler_CreateOrganisation(t *testing.T) {
+	tests := []struct {
+		name                       string
+		quotaManagementListEntries services.QuotaManagementListEntriesService
+		body                       string
+		wantStatusCode             int
+	}{
+		{
+			name:           "should fail if the id is missing",
+			body:           `{"max_allowed_instances": 1}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should fail if max_allowed_instances is negative",
+			body:           `{"id": "org-id", "max_allowed_instances": -1}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should fail if the expiration date of a billing model is invalid",
+			body:           `{"id": "org-id", "granted_quota": [{"instance_type_id": "standard", "kafka_billing_models": [{"id": "eval", "expiration_date": "2023-01-01"}]}]}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return conflict if the organisation is already in the quota management list",
+			quotaManagementListEntries: &services.QuotaManagementListEntriesServiceMock{
+				CreateOrganisationFunc: func(org *quota_management.Organisation) *errors.ServiceError {
+					return errors.Conflict("already exists")
+				},
+			},
+			body:           `{"id": "org-id"}`,
+			wantStatusCode: http.StatusConflict,
+		},
+		{
+			name: "should add the organisation to the quota management list",
+			quotaManagementListEntries: &services.QuotaManagementListEntriesServiceMock{
+				CreateOrganisationFunc: func(org *quota_management.Organisation) *errors.ServiceError {
+					return nil
+				},
+			},
+			body:           `{"id": "org-id", "any_user": true, "granted_quota": [{"instance_type_id": "standard", "kafka_billing_models": [{"id": "eval", "expiration_date": "2023-01-01 +00:00"}]}]}`,
+			wantStatusCode: http.StatusCreated,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminQuotaManagementListHandler(tt.quotaManagementListEntries)
+			req, rw := GetHandlerParams(http.MethodPost, "/quota_management/organisations", bytes.NewBufferString(tt.body), t)
+			h.CreateOrganisation(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode == http.StatusCreated {
+				var org private.QuotaManagementOrganisation
+				g.Expect(json.NewDecoder(resp.Body).Decode(&org)).To(gomega.Succeed())
+				g.Expect(org.Id).To(gomega.Equal("org-id"))
+				g.Expect(org.Kind).To(gomega.Equal("QuotaManagementOrganisation"))
+				g.Expect(org.GrantedQuota[0].KafkaBillingModels[0].ExpirationDate).To(gomega.Equal("2023-01-01 +00:00"))
+			}
+		})
+	}
+}
+
+func Test_adminQuotaManagementListHandler_UpdateOrganisation(t *testing.T) {
+	tests := []struct {
+		name                       string
+		quotaManagementListEntries *services.QuotaManagementListEntriesServiceMock
+		body                       string
+		wantStatusCode             int
+		wantUpdated                *quota_management.Organisation
+	}{
+		{
+			name: "should return not found if the organisation is not in the quota management list",
+			quotaManagementListEntries: &services.QuotaManagementListEntriesServiceMock{
+				FindOrganisationByIdFunc: func(orgId string) (*quota_management.Organisation, *errors.ServiceError) {
+					return nil, nil
+				},
+			},
+			body:           `{"any_user": true}`,
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "should return a conflict if the organisation is defined in the configuration file",
+			quotaManagementListEntries: &services.QuotaManagementListEntriesServiceMock{
+				FindOrganisationByIdFunc: func(orgId string) (*quota_management.Organisation, *errors.ServiceError) {
+					return &quota_management.Organisation{Id: orgId}, nil
+				},
+				UpdateOrganisationFunc: func(org *quota_management.Organisation) *errors.ServiceError {
+					return errors.Conflict("defined in the configuration file")
+				},
+			},
+			body:           `{"any_user": true}`,
+			wantStatusCode: http.StatusConflict,
+		},
+		{
+			name: "should only update the provided fields",
+			quotaManagementListEntries: &services.QuotaManagementListEntriesServiceMock{
+				FindOrganisationByIdFunc: func(orgId string) (*quota_management.Organisation, *errors.ServiceError) {
+					return &quota_management.Organisation{
+						Id:                  orgId,
+						AnyUser:             true,
+						MaxAllowedInstances: 5,
+						RegisteredUsers:     quota_management.AccountList{{Username: "user-1"}},
+					}, nil
+				},
+				UpdateOrganisationFunc: func(org *quota_management.Organisation) *errors.ServiceError {
+					return nil
+				},
+			},
+			body:           `{"max_allowed_instances": 10, "granted_quota": []}`,
+			wantStatusCode: http.StatusOK,
+			wantUpdated: &quota_management.Organisation{
+				Id:                  "org-id",
+				AnyUser:             true,
+				MaxAllowedInstances: 10,
+				RegisteredUsers:     quota_management.AccountList{{Username: "user-1"}},
+				GrantedQuota:        quota_management.QuotaList{},
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminQuotaManagementListHandler(tt.quotaManagementListEntries)
+			req, rw := GetHandlerParams(http.MethodPatch, "/quota_management/organisations/{id}", bytes.NewBufferString(tt.body), t)
+			req = mux.SetURLVars(req, map[string]string{"id": "org-id"})
+			h.UpdateOrganisation(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantUpdated != nil {
+				g.Expect(tt.quotaManagementListEntries.UpdateOrganisationCalls()).To(gomega.HaveLen(1))
+				g.Expect(tt.quotaManagementListEntries.UpdateOrganisationCalls()[0].Org).To(gomega.Equal(tt.wantUpdated))
+			}
+		})
+	}
+}
+
+func Test_adminQuotaManagementListHandler_DeleteServiceAccount(t *testing.T) {
+	tests := []struct {
+		name           string
+		deleteErr      *errors.ServiceError
+		wantStatusCode int
+	}{
+		{
+			name:           "should return not found if the service account is not in the quota management list",
+			deleteErr:      errors.NotFound("not found"),
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "should return a conflict if the service account is defined in the configuration file",
+			deleteErr:      errors.Conflict("defined in the configuration file"),
+			wantStatusCode: http.StatusConflict,
+		},
+		{
+			name:           "should remove the service account from the quota management list",
+			wantStatusCode: http.StatusNoContent,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewAdminQuotaManagementListHandler(&services.QuotaManagementListEntriesServiceMock{
+				DeleteServiceAccountFunc: func(username string) *errors.ServiceError {
+					return tt.deleteErr
+				},
+			})
+			req, rw := GetHandlerParams(http.MethodDelete, "/quota_management/service_accounts/{username}", nil, t)
+			req = mux.SetURLVars(req, map[string]string{"username": "account-1"})
+			h.DeleteServiceAccount(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+		})
+	}
+}
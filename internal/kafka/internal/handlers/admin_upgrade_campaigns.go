@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/gorilla/mux"
+)
+
+type adminUpgradeCampaignsHandler struct {
+	upgradeCampaignService services.UpgradeCampaignService
+}
+
+func NewAdminUpgradeCampaignsHandler(upgradeCampaignService services.UpgradeCampaignService) *adminUpgradeCampaignsHandler {
+	return &adminUpgradeCampaignsHandler{
+		upgradeCampaignService: upgradeCampaignService,
+	}
+}
+
+func (h adminUpgradeCampaignsHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := coreServices.NewListArguments(r.URL.Query())
+			if err := listArgs.Validate([]string{}); err != nil {
+				return nil, errors.NewWithCause(errors.ErrorMalformedRequest, err, "unable to list upgrade campaigns: %s", err.Error())
+			}
+
+			campaigns, paging, err := h.upgradeCampaignService.List(listArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			campaignList := private.UpgradeCampaignList{
+				Kind:  "UpgradeCampaignList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []private.UpgradeCampaign{},
+			}
+			for _, campaign := range campaigns {
+				presented, err := h.presentUpgradeCampaign(campaign)
+				if err != nil {
+					return nil, err
+				}
+				campaignList.Items = append(campaignList.Items, presented)
+			}
+
+			return campaignList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
+func (h adminUpgradeCampaignsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			campaign, err := h.getUpgradeCampaign(mux.Vars(r)["id"])
+			if err != nil {
+				return nil, err
+			}
+			return h.presentUpgradeCampaign(campaign)
+		},
+	}
+
+	handlers.HandleGet(w, r, cfg)
+}
+
+func (h adminUpgradeCampaignsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var request private.UpgradeCampaignRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &request,
+		Validate: []handlers.Validate{
+			handlers.ValidateLength(&request.Name, "name", 1, nil),
+			func() *errors.ServiceError {
+				if request.StrimziVersion == "" && request.KafkaVersion == "" && request.KafkaIbpVersion == "" {
+					return errors.Validation("at least one of strimzi_version, kafka_version or kafka_ibp_version must be set")
+				}
+				return nil
+			},
+			func() *errors.ServiceError {
+				if request.BatchSize < 1 {
+					return errors.Validation("batch_size must be greater than 0")
+				}
+				// failure_threshold defaults to 1 when not provided
+				if request.FailureThreshold < 0 {
+					return errors.Validation("failure_threshold must be greater than 0")
+				}
+				return nil
+			},
+			func() *errors.ServiceError {
+				if err := services.ValidateUpgradeCampaignSelectors(request.KafkaSelector, request.ClusterSelector); err != nil {
+					return errors.NewWithCause(errors.ErrorFailedToParseSearch, err, "invalid selector: %s", err.Error())
+				}
+				return nil
+			},
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			campaign := presenters.ConvertUpgradeCampaignRequest(request)
+			if err := h.upgradeCampaignService.Create(campaign); err != nil {
+				return nil, err
+			}
+			return presenters.PresentUpgradeCampaign(campaign, nil), nil
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusCreated)
+}
+
+func (h adminUpgradeCampaignsHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var request private.UpgradeCampaignUpdateRequest
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &request,
+		Validate: []handlers.Validate{
+			func() *errors.ServiceError {
+				if request.Status != nil && !arrays.Contains(dbapi.UpgradeCampaignStatuses, dbapi.UpgradeCampaignStatus(*request.Status)) {
+					return errors.Validation("invalid status %q, the allowed values are %v", *request.Status, dbapi.UpgradeCampaignStatuses)
+				}
+				return nil
+			},
+			func() *errors.ServiceError {
+				if request.BatchSize != nil && *request.BatchSize < 1 {
+					return errors.Validation("batch_size must be greater than 0")
+				}
+				if request.FailureThreshold != nil && *request.FailureThreshold < 1 {
+					return errors.Validation("failure_threshold must be greater than 0")
+				}
+				return nil
+			},
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			campaign, err := h.getUpgradeCampaign(mux.Vars(r)["id"])
+			if err != nil {
+				return nil, err
+			}
+			if campaign.Status.IsFinal() {
+				return nil, errors.BadRequest("upgrade campaign %q is %s and can no longer be updated", campaign.ID, campaign.Status)
+			}
+
+			// only the provided fields are updated
+			values := map[string]interface{}{}
+			if request.Status != nil && dbapi.UpgradeCampaignStatus(*request.Status) != campaign.Status {
+				// the kafkas which failed so far no longer count against the failure threshold of the resumed campaign
+				if campaign.Status == dbapi.UpgradeCampaignStatusPaused && dbapi.UpgradeCampaignStatus(*request.Status) == dbapi.UpgradeCampaignStatusInProgress {
+					kafkaCounts, err := h.upgradeCampaignService.CountKafkasByStatus(campaign.ID)
+					if err != nil {
+						return nil, err
+					}
+					campaign.FailedKafkasAtResume = kafkaCounts[dbapi.UpgradeCampaignKafkaStatusFailed]
+					values["failed_kafkas_at_resume"] = campaign.FailedKafkasAtResume
+				}
+				campaign.Status = dbapi.UpgradeCampaignStatus(*request.Status)
+				campaign.StatusReason = ""
+				values["status"] = campaign.Status
+				values["status_reason"] = campaign.StatusReason
+			}
+			if request.BatchSize != nil {
+				campaign.BatchSize = int(*request.BatchSize)
+				values["batch_size"] = campaign.BatchSize
+			}
+			if request.FailureThreshold != nil {
+				campaign.FailureThreshold = int(*request.FailureThreshold)
+				values["failure_threshold"] = campaign.FailureThreshold
+			}
+
+			if len(values) > 0 {
+				if err := h.upgradeCampaignService.Updates(campaign, values); err != nil {
+					return nil, err
+				}
+			}
+			return h.presentUpgradeCampaign(campaign)
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+func (h adminUpgradeCampaignsHandler) ListKafkas(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := coreServices.NewListArguments(r.URL.Query())
+			if err := listArgs.Validate([]string{}); err != nil {
+				return nil, errors.NewWithCause(errors.ErrorMalformedRequest, err, "unable to list the kafkas of the upgrade campaign: %s", err.Error())
+			}
+
+			campaign, err := h.getUpgradeCampaign(mux.Vars(r)["id"])
+			if err != nil {
+				return nil, err
+			}
+
+			campaignKafkas, paging, err := h.upgradeCampaignService.ListCampaignKafkas(campaign.ID, listArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			campaignKafkaList := private.UpgradeCampaignKafkaList{
+				Kind:  "UpgradeCampaignKafkaList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []private.UpgradeCampaignKafka{},
+			}
+			for _, campaignKafka := range campaignKafkas {
+				campaignKafkaList.Items = append(campaignKafkaList.Items, presenters.PresentUpgradeCampaignKafka(campaignKafka))
+			}
+
+			return campaignKafkaList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
+func (h adminUpgradeCampaignsHandler) getUpgradeCampaign(id string) (*dbapi.UpgradeCampaign, *errors.ServiceError) {
+	campaign, err := h.upgradeCampaignService.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if campaign == nil {
+		return nil, errors.NotFound("upgrade campaign %q not found", id)
+	}
+	return campaign, nil
+}
+
+func (h adminUpgradeCampaignsHandler) presentUpgradeCampaign(campaign *dbapi.UpgradeCampaign) (private.UpgradeCampaign, *errors.ServiceError) {
+	kafkaCounts, err := h.upgradeCampaignService.CountKafkasByStatus(campaign.ID)
+	if err != nil {
+		return private.UpgradeCampaign{}, err
+	}
+	return presenters.PresentUpgradeCampaign(campaign, kafkaCounts), nil
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+)
+
+func Test_adminUpgradeCampaignsHandler_Create(t *testing.T) {
+	tests := []struct {
+		name           string
+		request        private.UpgradeCampaignRequest
+		wantStatusCode int
+		want           *dbapi.UpgradeCampaign
+	}{
+		{
+			name:           "should reject a campaign without target version",
+			request:        private.UpgradeCampaignRequest{Name: "campaign", BatchSize: 10},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should reject a campaign with an invalid batch size",
+			request:        private.UpgradeCampaignRequest{Name: "campaign", KafkaVersion: "3.4.0"},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should reject a campaign with an invalid selector",
+			request:        private.UpgradeCampaignRequest{Name: "campaign", KafkaVersion: "3.4.0", BatchSize: 10, ClusterSelector: "unknown = value"},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should create the campaign with a failure threshold of 1 by default",
+			request:        private.UpgradeCampaignRequest{Name: "campaign", KafkaVersion: "3.4.0", BatchSize: 10, KafkaSelector: "instance_type = standard"},
+			wantStatusCode: http.StatusCreated,
+			want: &dbapi.UpgradeCampaign{
+				Name:             "campaign",
+				KafkaVersion:     "3.4.0",
+				KafkaSelector:    "instance_type = standard",
+				BatchSize:        10,
+				FailureThreshold: 1,
+				Status:           dbapi.UpgradeCampaignStatusInProgress,
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			var created *dbapi.UpgradeCampaign
+			h := NewAdminUpgradeCampaignsHandler(&services.UpgradeCampaignServiceMock{
+				CreateFunc: func(campaign *dbapi.UpgradeCampaign) *errors.ServiceError {
+					created = campaign
+					return nil
+				},
+			})
+
+			body, err := json.Marshal(tt.request)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			req, rw := GetHandlerParams(http.MethodPost, "/upgrade_campaigns", bytes.NewReader(body), t)
+			h.Create(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			g.Expect(created).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_adminUpgradeCampaignsHandler_Update(t *testing.T) {
+	paused := dbapi.UpgradeCampaignStatusPaused.String()
+	inProgress := dbapi.UpgradeCampaignStatusInProgress.String()
+	completed := dbapi.UpgradeCampaignStatusCompleted.String()
+	threshold := int32(3)
+
+	tests := []struct {
+		name           string
+		campaign       *dbapi.UpgradeCampaign
+		request        private.UpgradeCampaignUpdateRequest
+		wantStatusCode int
+		wantValues     map[string]interface{}
+	}{
+		{
+			name:           "should return not found if the campaign does not exist",
+			request:        private.UpgradeCampaignUpdateRequest{Status: &paused},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "should reject a status that cannot be set by an administrator",
+			campaign:       &dbapi.UpgradeCampaign{Meta: api.Meta{ID: "campaign-id"}, Status: dbapi.UpgradeCampaignStatusInProgress},
+			request:        private.UpgradeCampaignUpdateRequest{Status: &completed},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should reject the update of a cancelled campaign",
+			campaign:       &dbapi.UpgradeCampaign{Meta: api.Meta{ID: "campaign-id"}, Status: dbapi.UpgradeCampaignStatusCancelled},
+			request:        private.UpgradeCampaignUpdateRequest{Status: &paused},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should pause the campaign",
+			campaign:       &dbapi.UpgradeCampaign{Meta: api.Meta{ID: "campaign-id"}, Status: dbapi.UpgradeCampaignStatusInProgress},
+			request:        private.UpgradeCampaignUpdateRequest{Status: &paused},
+			wantStatusCode: http.StatusOK,
+			wantValues:     map[string]interface{}{"status": dbapi.UpgradeCampaignStatusPaused, "status_reason": ""},
+		},
+		{
+			name: "should raise the failure threshold of a campaign paused because of failures",
+			campaign: &dbapi.UpgradeCampaign{Meta: api.Meta{ID: "campaign-id"}, Status: dbapi.UpgradeCampaignStatusPaused,
+				StatusReason: "1 kafkas failed to upgrade, reaching the failure threshold of 1"},
+			request:        private.UpgradeCampaignUpdateRequest{FailureThreshold: &threshold},
+			wantStatusCode: http.StatusOK,
+			wantValues:     map[string]interface{}{"failure_threshold": 3},
+		},
+		{
+			name: "should only count the failures following the resume of a campaign against its failure threshold",
+			campaign: &dbapi.UpgradeCampaign{Meta: api.Meta{ID: "campaign-id"}, Status: dbapi.UpgradeCampaignStatusPaused,
+				StatusReason: "2 kafkas failed to upgrade, reaching the failure threshold of 2"},
+			request:        private.UpgradeCampaignUpdateRequest{Status: &inProgress},
+			wantStatusCode: http.StatusOK,
+			wantValues:     map[string]interface{}{"status": dbapi.UpgradeCampaignStatusInProgress, "status_reason": "", "failed_kafkas_at_resume": 2},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			var values map[string]interface{}
+			h := NewAdminUpgradeCampaignsHandler(&services.UpgradeCampaignServiceMock{
+				GetFunc: func(id string) (*dbapi.UpgradeCampaign, *errors.ServiceError) {
+					return tt.campaign, nil
+				},
+				UpdatesFunc: func(campaign *dbapi.UpgradeCampaign, v map[string]interface{}) *errors.ServiceError {
+					values = v
+					return nil
+				},
+				CountKafkasByStatusFunc: func(campaignId string) (map[dbapi.UpgradeCampaignKafkaStatus]int, *errors.ServiceError) {
+					return map[dbapi.UpgradeCampaignKafkaStatus]int{dbapi.UpgradeCampaignKafkaStatusUpgraded: 5, dbapi.UpgradeCampaignKafkaStatusFailed: 2}, nil
+				},
+			})
+
+			body, err := json.Marshal(tt.request)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			req, rw := GetHandlerParams(http.MethodPatch, "/upgrade_campaigns/{id}", bytes.NewReader(body), t)
+			req = mux.SetURLVars(req, map[string]string{"id": "campaign-id"})
+			h.Update(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			g.Expect(values).To(gomega.Equal(tt.wantValues))
+			if tt.wantStatusCode == http.StatusOK {
+				var campaign private.UpgradeCampaign
+				g.Expect(json.NewDecoder(resp.Body).Decode(&campaign)).To(gomega.Succeed())
+				g.Expect(campaign.KafkasUpgraded).To(gomega.Equal(int32(5)))
+			}
+		})
+	}
+}
@@ -2,14 +2,17 @@ package handlers
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/clusters"
+	clusterTypes "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/clusters/types"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/ocm"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
@@ -21,15 +24,17 @@ type clusterHandler struct {
 	clusterService             services.ClusterService
 	providerFactory            clusters.ProviderFactory
 	kafkaConfig                *config.KafkaConfig
+	amsClient                  ocm.AMSClient
 }
 
 func NewClusterHandler(kasFleetshardOperatorAddon services.KasFleetshardOperatorAddon, clusterService services.ClusterService, providerFactory clusters.ProviderFactory,
-	kafkaConfig *config.KafkaConfig) *clusterHandler {
+	kafkaConfig *config.KafkaConfig, amsClient ocm.AMSClient) *clusterHandler {
 	return &clusterHandler{
 		kasFleetshardOperatorAddon: kasFleetshardOperatorAddon,
 		clusterService:             clusterService,
 		providerFactory:            providerFactory,
 		kafkaConfig:                kafkaConfig,
+		amsClient:                  amsClient,
 	}
 }
 
@@ -54,6 +59,8 @@ func (h clusterHandler) RegisterEnterpriseCluster(w http.ResponseWriter, r *http
 			handlers.ValidateDnsName(&clusterPayload.ClusterIngressDnsName, "cluster dns name"),
 
 			validateKafkaMachinePoolNodeCount(&clusterPayload),
+
+			validateEnterpriseClusterInstanceTypes(&clusterPayload, h.kafkaConfig),
 		},
 		Action: func() (interface{}, *errors.ServiceError) {
 
@@ -76,7 +83,6 @@ func (h clusterHandler) RegisterEnterpriseCluster(w http.ResponseWriter, r *http
 				return nil, claimsErr
 			}
 
-			// TODO - validate that the org also owns the cluster.
 			orgId, getOrgIdErr := claims.GetOrgId()
 			if getOrgIdErr != nil {
 				return nil, errors.GeneralError(getOrgIdErr.Error())
@@ -86,7 +92,19 @@ func (h clusterHandler) RegisterEnterpriseCluster(w http.ResponseWriter, r *http
 				return nil, errors.New(errors.ErrorUnauthorized, "non admin user not authorized to perform this action")
 			}
 
-			supportedKafkaInstanceType := api.StandardTypeSupport.String()
+			if svcErr := h.validateClusterIsOwnedByOrganization(clusterPayload.ClusterId, clusterSpec, orgId); svcErr != nil {
+				return nil, svcErr
+			}
+
+			supportedKafkaInstanceTypes := []string{}
+			capacityInfo := map[string]api.DynamicCapacityInfo{}
+			for _, instanceType := range getEnterpriseClusterInstanceTypes(clusterPayload) {
+				supportedKafkaInstanceTypes = append(supportedKafkaInstanceTypes, instanceType.InstanceType)
+				capacityInfo[instanceType.InstanceType] = api.DynamicCapacityInfo{
+					MaxNodes: instanceType.KafkaMachinePoolNodeCount,
+				}
+			}
+
 			clusterRequest := &api.Cluster{
 				ClusterType:                   api.EnterpriseDataPlaneClusterType.String(),
 				ProviderType:                  api.ClusterProviderOCM,
@@ -99,13 +117,7 @@ func (h clusterHandler) RegisterEnterpriseCluster(w http.ResponseWriter, r *http
 				ExternalID:                    clusterSpec.ExternalID,
 				MultiAZ:                       clusterSpec.MultiAZ,
 				AccessKafkasViaPrivateNetwork: clusterPayload.AccessKafkasViaPrivateNetwork,
-				SupportedInstanceType:         supportedKafkaInstanceType,
-			}
-
-			capacityInfo := map[string]api.DynamicCapacityInfo{
-				supportedKafkaInstanceType: {
-					MaxNodes: clusterPayload.KafkaMachinePoolNodeCount,
-				},
+				SupportedInstanceType:         strings.Join(supportedKafkaInstanceTypes, ","),
 			}
 
 			err := clusterRequest.SetDynamicCapacityInfo(capacityInfo)
@@ -120,7 +132,6 @@ func (h clusterHandler) RegisterEnterpriseCluster(w http.ResponseWriter, r *http
 			}
 
 			clusterRequest.ClientID = fsoParams.GetParam(services.KasFleetshardOperatorParamServiceAccountId)
-			clusterRequest.ClientSecret = fsoParams.GetParam(services.KasFleetshardOperatorParamServiceAccountSecret)
 
 			svcErr = h.clusterService.RegisterClusterJob(clusterRequest)
 			if svcErr != nil {
@@ -134,6 +145,47 @@ func (h clusterHandler) RegisterEnterpriseCluster(w http.ResponseWriter, r *http
 	handlers.Handle(w, r, cfg, http.StatusOK)
 }
 
+// validateClusterIsOwnedByOrganization checks that the OCM subscription of the cluster belongs to the organization
+// registering it, so that an organization cannot register a cluster it does not own by knowing its ID
+func (h clusterHandler) validateClusterIsOwnedByOrganization(clusterID string, clusterSpec clusterTypes.ClusterSpec, orgId string) *errors.ServiceError {
+	if clusterSpec.SubscriptionID == "" {
+		return errors.Forbidden("unable to verify that cluster %q is owned by organization %q", clusterID, orgId)
+	}
+
+	subscription, found, err := h.amsClient.GetSubscriptionByID(clusterSpec.SubscriptionID)
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to get the subscription of cluster %q", clusterID)
+	}
+	if !found || subscription == nil {
+		return errors.Forbidden("unable to verify that cluster %q is owned by organization %q", clusterID, orgId)
+	}
+
+	internalOrgId, err := h.amsClient.GetOrganisationIdFromExternalId(orgId)
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to get the internal id of organization %q", orgId)
+	}
+
+	if subscription.OrganizationID() != internalOrgId {
+		return errors.Forbidden("cluster %q is not owned by organization %q", clusterID, orgId)
+	}
+
+	return nil
+}
+
+// getEnterpriseClusterInstanceTypes returns the instance types the cluster is registered for. The cluster is registered
+// for the standard instance type when no instance types are given.
+func getEnterpriseClusterInstanceTypes(clusterPayload public.EnterpriseOsdClusterPayload) []public.EnterpriseOsdClusterInstanceTypePayload {
+	if len(clusterPayload.InstanceTypes) > 0 {
+		return clusterPayload.InstanceTypes
+	}
+	return []public.EnterpriseOsdClusterInstanceTypePayload{
+		{
+			InstanceType:              api.StandardTypeSupport.String(),
+			KafkaMachinePoolNodeCount: clusterPayload.KafkaMachinePoolNodeCount,
+		},
+	}
+}
+
 func (h clusterHandler) validateOCMProviderAvailable(provider clusters.Provider, err error) handlers.Validate {
 	return func() *errors.ServiceError {
 		if err != nil || shared.IsNil(provider) {
@@ -182,7 +234,7 @@ func (h clusterHandler) DeregisterEnterpriseCluster(w http.ResponseWriter, r *ht
 			handlers.ValidateAsyncEnabled(r, "deleting enterprise cluster"),
 			ValidateKafkaClaims(ctx, ValidateOrganisationId()),
 			validateEnterpriseClusterEligibleForDeregistration(ctx, clusterID, h.clusterService),
-			validateEnterpriseClusterHasNoKafkas(clusterID, h.clusterService),
+			validateClusterHasNoKafkas(clusterID, h.clusterService),
 		},
 		Action: func() (i interface{}, serviceError *errors.ServiceError) {
 			return nil, h.clusterService.DeregisterClusterJob(clusterID)
@@ -218,8 +270,7 @@ func (h clusterHandler) Get(w http.ResponseWriter, r *http.Request) {
 				return nil, errors.GeneralError("failed to retrieve cluster %q consumed capacity info", clusterID)
 			}
 
-			standardConsumedCapacity := consumedCapacity[types.STANDARD]
-			presentedCluster, presentationErr := presenters.PresentEnterpriseCluster(*cluster, int32(standardConsumedCapacity), h.kafkaConfig)
+			presentedCluster, presentationErr := presenters.PresentEnterpriseCluster(*cluster, consumedCapacity, h.kafkaConfig)
 			if presentationErr != nil {
 				return nil, errors.GeneralError("failed to present enterprise cluster due to %q", presentationErr.Error())
 			}
@@ -230,6 +281,101 @@ func (h clusterHandler) Get(w http.ResponseWriter, r *http.Request) {
 	handlers.HandleGet(w, r, cfg)
 }
 
+// Update changes the settings of an enterprise cluster once it has been registered. The changes that would strand the
+// kafkas already placed on the cluster are refused.
+func (h clusterHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var clusterUpdatePayload public.EnterpriseClusterUpdatePayload
+	clusterID := mux.Vars(r)["id"]
+	ctx := r.Context()
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &clusterUpdatePayload,
+		Validate: []handlers.Validate{
+			handlers.ValidateNotEmptyClusterId(&clusterID, "cluster id"),
+			ValidateKafkaClaims(ctx, ValidateOrganisationId()),
+			validateKafkaMachinePoolNodeCountUpdate(&clusterUpdatePayload),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			// error checked in the validate, no need to check again
+			claims, _ := getClaims(ctx)
+			orgID, _ := claims.GetOrgId()
+
+			if !claims.IsOrgAdmin() {
+				return nil, errors.New(errors.ErrorUnauthorized, "non admin user not authorized to perform this action")
+			}
+
+			cluster, err := h.clusterService.FindClusterByID(clusterID)
+			if err != nil {
+				return nil, err
+			}
+
+			if cluster == nil || cluster.OrganizationID != orgID || cluster.ClusterType != api.EnterpriseDataPlaneClusterType.String() {
+				return nil, errors.NotFound("enterprise data plane cluster with id='%v' not found within organization: %s", clusterID, orgID)
+			}
+
+			consumedCapacity, consumedCapacityError := h.clusterService.ComputeConsumedStreamingUnitCountPerInstanceType(clusterID)
+			if consumedCapacityError != nil {
+				return nil, errors.GeneralError("failed to retrieve cluster %q consumed capacity info", clusterID)
+			}
+
+			if clusterUpdatePayload.AccessKafkasViaPrivateNetwork != nil && *clusterUpdatePayload.AccessKafkasViaPrivateNetwork != cluster.AccessKafkasViaPrivateNetwork {
+				for _, consumedStreamingUnits := range consumedCapacity {
+					if consumedStreamingUnits > 0 {
+						return nil, errors.Conflict("unable to change the network access of the Kafka instances of cluster %q while Kafka instances are placed on it", clusterID)
+					}
+				}
+				cluster.AccessKafkasViaPrivateNetwork = *clusterUpdatePayload.AccessKafkasViaPrivateNetwork
+			}
+
+			nodeCountPerInstanceType := map[string]int32{}
+			if clusterUpdatePayload.KafkaMachinePoolNodeCount != nil {
+				nodeCountPerInstanceType[api.StandardTypeSupport.String()] = *clusterUpdatePayload.KafkaMachinePoolNodeCount
+			}
+			for _, instanceType := range clusterUpdatePayload.InstanceTypes {
+				nodeCountPerInstanceType[instanceType.InstanceType] = instanceType.KafkaMachinePoolNodeCount
+			}
+
+			if len(nodeCountPerInstanceType) > 0 {
+				capacityInfo := cluster.RetrieveDynamicCapacityInfo()
+				for instanceType, nodeCount := range nodeCountPerInstanceType {
+					instanceTypeCapacityInfo, ok := capacityInfo[instanceType]
+					if !ok {
+						return nil, errors.BadRequest("kafka instance type %q is not supported by cluster %q", instanceType, clusterID)
+					}
+					consumedStreamingUnits := consumedCapacity[types.KafkaInstanceType(instanceType)]
+
+					// the maximum number of streaming units is reported by kas-fleetshard once the machine pool is resized.
+					// Until then, it is estimated from the number of streaming units the current nodes can hold.
+					if instanceTypeCapacityInfo.MaxNodes > 0 {
+						instanceTypeCapacityInfo.MaxUnits = instanceTypeCapacityInfo.MaxUnits * nodeCount / instanceTypeCapacityInfo.MaxNodes
+					}
+					if int64(instanceTypeCapacityInfo.MaxUnits) < consumedStreamingUnits {
+						return nil, errors.Conflict("unable to lower the %q kafka machine pool node count of cluster %q to %d: its Kafka instances consume %d streaming units", instanceType, clusterID, nodeCount, consumedStreamingUnits)
+					}
+					instanceTypeCapacityInfo.MaxNodes = nodeCount
+					instanceTypeCapacityInfo.RemainingUnits = instanceTypeCapacityInfo.MaxUnits - int32(consumedStreamingUnits)
+					capacityInfo[instanceType] = instanceTypeCapacityInfo
+				}
+
+				if err := cluster.SetDynamicCapacityInfo(capacityInfo); err != nil { // this should never occur
+					return nil, errors.GeneralError("invalid node count info")
+				}
+			}
+
+			if svcErr := h.clusterService.UpdateEnterpriseClusterSettings(*cluster); svcErr != nil {
+				return nil, svcErr
+			}
+
+			presentedCluster, presentationErr := presenters.PresentEnterpriseCluster(*cluster, consumedCapacity, h.kafkaConfig)
+			if presentationErr != nil {
+				return nil, errors.GeneralError("failed to present enterprise cluster due to %q", presentationErr.Error())
+			}
+
+			return presentedCluster, nil
+		},
+	}
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
 func (h clusterHandler) GetEnterpriseClusterWithAddonParams(w http.ResponseWriter, r *http.Request) {
 	clusterID := mux.Vars(r)["id"]
 	ctx := r.Context()
@@ -18,10 +18,12 @@ import (
 	mocks "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/kafkas"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/ocm"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/gorilla/mux"
 	"github.com/onsi/gomega"
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 )
 
 const (
@@ -49,12 +51,64 @@ var (
 	entClusterID = "1234abcd1234abcd1234abcd1234abcd"
 )
 
+const (
+	entClusterSubscriptionID = "subscription-id"
+	internalOrganisationId   = "internal-org-id"
+)
+
+// newClusterOwnerAMSClient returns an AMS client mock for which the subscription of the cluster belongs to the given
+// internal organisation
+func newClusterOwnerAMSClient(ownerInternalOrgId string) *ocm.ClientMock {
+	return &ocm.ClientMock{
+		GetSubscriptionByIDFunc: func(subscriptionID string) (*amsv1.Subscription, bool, error) {
+			subscription, err := amsv1.NewSubscription().ID(subscriptionID).OrganizationID(ownerInternalOrgId).Build()
+			return subscription, true, err
+		},
+		GetOrganisationIdFromExternalIdFunc: func(externalId string) (string, error) {
+			return internalOrganisationId, nil
+		},
+	}
+}
+
 func Test_RegisterEnterpriseCluster(t *testing.T) {
 	g := gomega.NewWithT(t)
+	kafkaConfig := &config.KafkaConfig{
+		SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
+			Configuration: config.SupportedKafkaInstanceTypesConfig{
+				SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+					{
+						Id: kafkaTypes.STANDARD.String(),
+						SupportedBillingModels: []config.KafkaBillingModel{
+							{
+								ID: constants.BillingModelEnterprise.String(),
+							},
+						},
+					},
+					{
+						Id: kafkaTypes.DEVELOPER.String(),
+						SupportedBillingModels: []config.KafkaBillingModel{
+							{
+								ID: constants.BillingModelEnterprise.String(),
+							},
+						},
+					},
+					{
+						Id: "non-enterprise",
+						SupportedBillingModels: []config.KafkaBillingModel{
+							{
+								ID: "standard",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
 	type fields struct {
 		kasFleetshardOperatorAddon services.KasFleetshardOperatorAddon
 		clusterService             services.ClusterService
 		providerFactory            clusters.ProviderFactory
+		amsClient                  ocm.AMSClient
 	}
 
 	type args struct {
@@ -227,6 +281,86 @@ func Test_RegisterEnterpriseCluster(t *testing.T) {
 			},
 			wantStatusCode: http.StatusBadRequest,
 		},
+		{
+			name: "should return an error if kafka_machine_pool_node_count is set together with instance_types",
+			args: args{
+				body: []byte(fmt.Sprintf(`{"cluster_id": "%s", "cluster_ingress_dns_name": "%s", "kafka_machine_pool_node_count": 3, "instance_types": [{"instance_type": "standard", "kafka_machine_pool_node_count": 3}]}`, validLengthClusterId, validDnsName)),
+				ctx:  ctxWithClaims,
+			},
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return nil, nil
+					},
+				},
+				providerFactory: &clusters.ProviderFactoryMock{
+					GetProviderFunc: func(providerType api.ClusterProviderType) (clusters.Provider, error) {
+						return &clusters.ProviderMock{}, nil
+					},
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return an error if an instance type does not support the enterprise billing model",
+			args: args{
+				body: []byte(fmt.Sprintf(`{"cluster_id": "%s", "cluster_ingress_dns_name": "%s", "instance_types": [{"instance_type": "non-enterprise", "kafka_machine_pool_node_count": 3}]}`, validLengthClusterId, validDnsName)),
+				ctx:  ctxWithClaims,
+			},
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return nil, nil
+					},
+				},
+				providerFactory: &clusters.ProviderFactoryMock{
+					GetProviderFunc: func(providerType api.ClusterProviderType) (clusters.Provider, error) {
+						return &clusters.ProviderMock{}, nil
+					},
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return an error if an instance type is not supported",
+			args: args{
+				body: []byte(fmt.Sprintf(`{"cluster_id": "%s", "cluster_ingress_dns_name": "%s", "instance_types": [{"instance_type": "unknown", "kafka_machine_pool_node_count": 3}]}`, validLengthClusterId, validDnsName)),
+				ctx:  ctxWithClaims,
+			},
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return nil, nil
+					},
+				},
+				providerFactory: &clusters.ProviderFactoryMock{
+					GetProviderFunc: func(providerType api.ClusterProviderType) (clusters.Provider, error) {
+						return &clusters.ProviderMock{}, nil
+					},
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return an error if the node count of an instance type is not a multiple of 3",
+			args: args{
+				body: []byte(fmt.Sprintf(`{"cluster_id": "%s", "cluster_ingress_dns_name": "%s", "instance_types": [{"instance_type": "standard", "kafka_machine_pool_node_count": 4}]}`, validLengthClusterId, validDnsName)),
+				ctx:  ctxWithClaims,
+			},
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return nil, nil
+					},
+				},
+				providerFactory: &clusters.ProviderFactoryMock{
+					GetProviderFunc: func(providerType api.ClusterProviderType) (clusters.Provider, error) {
+						return &clusters.ProviderMock{}, nil
+					},
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
 		{
 			name: "should return an error if GetProvider fails",
 			args: args{
@@ -329,6 +463,170 @@ func Test_RegisterEnterpriseCluster(t *testing.T) {
 			},
 			wantStatusCode: http.StatusInternalServerError,
 		},
+		{
+			name: "should return an error if the subscription of the cluster is unknown",
+			args: args{
+				body: []byte(fmt.Sprintf(`{"cluster_id": "%s", "access_kafkas_via_private_network": false, "cluster_ingress_dns_name": "%s", "kafka_machine_pool_node_count": 3}`, validLengthClusterId, validDnsName)),
+				ctx:  ctxWithClaims,
+			},
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return nil, nil
+					},
+				},
+				providerFactory: &clusters.ProviderFactoryMock{
+					GetProviderFunc: func(providerType api.ClusterProviderType) (clusters.Provider, error) {
+						return &clusters.ProviderMock{
+							GetClusterSpecFunc: func(clusterID string) (types.ClusterSpec, error) {
+								return types.ClusterSpec{
+									MultiAZ:    true,
+									InternalID: validLengthClusterId,
+									Status:     api.ClusterProvisioned,
+								}, nil
+							},
+						}, nil
+					},
+				},
+			},
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "should return an error if GetSubscriptionByID returns an error",
+			args: args{
+				body: []byte(fmt.Sprintf(`{"cluster_id": "%s", "access_kafkas_via_private_network": false, "cluster_ingress_dns_name": "%s", "kafka_machine_pool_node_count": 3}`, validLengthClusterId, validDnsName)),
+				ctx:  ctxWithClaims,
+			},
+			fields: fields{
+				amsClient: &ocm.ClientMock{
+					GetSubscriptionByIDFunc: func(subscriptionID string) (*amsv1.Subscription, bool, error) {
+						return nil, false, fmt.Errorf("failed to get subscription")
+					},
+				},
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return nil, nil
+					},
+				},
+				providerFactory: &clusters.ProviderFactoryMock{
+					GetProviderFunc: func(providerType api.ClusterProviderType) (clusters.Provider, error) {
+						return &clusters.ProviderMock{
+							GetClusterSpecFunc: func(clusterID string) (types.ClusterSpec, error) {
+								return types.ClusterSpec{
+									MultiAZ:        true,
+									InternalID:     validLengthClusterId,
+									Status:         api.ClusterProvisioned,
+									SubscriptionID: entClusterSubscriptionID,
+								}, nil
+							},
+						}, nil
+					},
+				},
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "should return an error if the subscription of the cluster is not found",
+			args: args{
+				body: []byte(fmt.Sprintf(`{"cluster_id": "%s", "access_kafkas_via_private_network": false, "cluster_ingress_dns_name": "%s", "kafka_machine_pool_node_count": 3}`, validLengthClusterId, validDnsName)),
+				ctx:  ctxWithClaims,
+			},
+			fields: fields{
+				amsClient: &ocm.ClientMock{
+					GetSubscriptionByIDFunc: func(subscriptionID string) (*amsv1.Subscription, bool, error) {
+						return nil, false, nil
+					},
+				},
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return nil, nil
+					},
+				},
+				providerFactory: &clusters.ProviderFactoryMock{
+					GetProviderFunc: func(providerType api.ClusterProviderType) (clusters.Provider, error) {
+						return &clusters.ProviderMock{
+							GetClusterSpecFunc: func(clusterID string) (types.ClusterSpec, error) {
+								return types.ClusterSpec{
+									MultiAZ:        true,
+									InternalID:     validLengthClusterId,
+									Status:         api.ClusterProvisioned,
+									SubscriptionID: entClusterSubscriptionID,
+								}, nil
+							},
+						}, nil
+					},
+				},
+			},
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "should return an error if GetOrganisationIdFromExternalId returns an error",
+			args: args{
+				body: []byte(fmt.Sprintf(`{"cluster_id": "%s", "access_kafkas_via_private_network": false, "cluster_ingress_dns_name": "%s", "kafka_machine_pool_node_count": 3}`, validLengthClusterId, validDnsName)),
+				ctx:  ctxWithClaims,
+			},
+			fields: fields{
+				amsClient: &ocm.ClientMock{
+					GetSubscriptionByIDFunc: func(subscriptionID string) (*amsv1.Subscription, bool, error) {
+						subscription, err := amsv1.NewSubscription().ID(subscriptionID).OrganizationID(internalOrganisationId).Build()
+						return subscription, true, err
+					},
+					GetOrganisationIdFromExternalIdFunc: func(externalId string) (string, error) {
+						return "", fmt.Errorf("failed to get organisation id")
+					},
+				},
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return nil, nil
+					},
+				},
+				providerFactory: &clusters.ProviderFactoryMock{
+					GetProviderFunc: func(providerType api.ClusterProviderType) (clusters.Provider, error) {
+						return &clusters.ProviderMock{
+							GetClusterSpecFunc: func(clusterID string) (types.ClusterSpec, error) {
+								return types.ClusterSpec{
+									MultiAZ:        true,
+									InternalID:     validLengthClusterId,
+									Status:         api.ClusterProvisioned,
+									SubscriptionID: entClusterSubscriptionID,
+								}, nil
+							},
+						}, nil
+					},
+				},
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "should return an error if the cluster is owned by another organisation",
+			args: args{
+				body: []byte(fmt.Sprintf(`{"cluster_id": "%s", "access_kafkas_via_private_network": false, "cluster_ingress_dns_name": "%s", "kafka_machine_pool_node_count": 3}`, validLengthClusterId, validDnsName)),
+				ctx:  ctxWithClaims,
+			},
+			fields: fields{
+				amsClient: newClusterOwnerAMSClient("another-org-id"),
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return nil, nil
+					},
+				},
+				providerFactory: &clusters.ProviderFactoryMock{
+					GetProviderFunc: func(providerType api.ClusterProviderType) (clusters.Provider, error) {
+						return &clusters.ProviderMock{
+							GetClusterSpecFunc: func(clusterID string) (types.ClusterSpec, error) {
+								return types.ClusterSpec{
+									MultiAZ:        true,
+									InternalID:     validLengthClusterId,
+									Status:         api.ClusterProvisioned,
+									SubscriptionID: entClusterSubscriptionID,
+								}, nil
+							},
+						}, nil
+					},
+				},
+			},
+			wantStatusCode: http.StatusForbidden,
+		},
 		{
 			name: "should return an error if GetAddonParams returns an error",
 			args: args{
@@ -336,6 +634,7 @@ func Test_RegisterEnterpriseCluster(t *testing.T) {
 				ctx:  ctxWithClaims,
 			},
 			fields: fields{
+				amsClient: newClusterOwnerAMSClient(internalOrganisationId),
 				clusterService: &services.ClusterServiceMock{
 					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
 						return nil, nil
@@ -351,9 +650,10 @@ func Test_RegisterEnterpriseCluster(t *testing.T) {
 						return &clusters.ProviderMock{
 							GetClusterSpecFunc: func(clusterID string) (types.ClusterSpec, error) {
 								return types.ClusterSpec{
-									MultiAZ:    true,
-									InternalID: validLengthClusterId,
-									Status:     api.ClusterProvisioned,
+									MultiAZ:        true,
+									InternalID:     validLengthClusterId,
+									SubscriptionID: entClusterSubscriptionID,
+									Status:         api.ClusterProvisioned,
 								}, nil
 							},
 						}, nil
@@ -369,6 +669,7 @@ func Test_RegisterEnterpriseCluster(t *testing.T) {
 				ctx:  ctxWithClaims,
 			},
 			fields: fields{
+				amsClient: newClusterOwnerAMSClient(internalOrganisationId),
 				clusterService: &services.ClusterServiceMock{
 					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
 						return nil, nil
@@ -392,9 +693,10 @@ func Test_RegisterEnterpriseCluster(t *testing.T) {
 						return &clusters.ProviderMock{
 							GetClusterSpecFunc: func(clusterID string) (types.ClusterSpec, error) {
 								return types.ClusterSpec{
-									MultiAZ:    true,
-									InternalID: validLengthClusterId,
-									Status:     api.ClusterProvisioned,
+									MultiAZ:        true,
+									InternalID:     validLengthClusterId,
+									SubscriptionID: entClusterSubscriptionID,
+									Status:         api.ClusterProvisioned,
 								}, nil
 							},
 						}, nil
@@ -410,6 +712,7 @@ func Test_RegisterEnterpriseCluster(t *testing.T) {
 				ctx:  ctxWithClaims,
 			},
 			fields: fields{
+				amsClient: newClusterOwnerAMSClient(internalOrganisationId),
 				clusterService: &services.ClusterServiceMock{
 					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
 						return nil, nil
@@ -433,11 +736,12 @@ func Test_RegisterEnterpriseCluster(t *testing.T) {
 						return &clusters.ProviderMock{
 							GetClusterSpecFunc: func(clusterID string) (types.ClusterSpec, error) {
 								return types.ClusterSpec{
-									MultiAZ:       true,
-									InternalID:    validLengthClusterId,
-									Region:        mocks.DefaultKafkaRequestRegion,
-									CloudProvider: "aws",
-									Status:        api.ClusterProvisioned,
+									MultiAZ:        true,
+									InternalID:     validLengthClusterId,
+									Region:         mocks.DefaultKafkaRequestRegion,
+									CloudProvider:  "aws",
+									SubscriptionID: entClusterSubscriptionID,
+									Status:         api.ClusterProvisioned,
 								}, nil
 							},
 						}, nil
@@ -470,6 +774,7 @@ func Test_RegisterEnterpriseCluster(t *testing.T) {
 				ctx:  ctxWithClaims,
 			},
 			fields: fields{
+				amsClient: newClusterOwnerAMSClient(internalOrganisationId),
 				clusterService: &services.ClusterServiceMock{
 					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
 						return nil, errors.GeneralError("failed to find cluster")
@@ -498,12 +803,13 @@ func Test_RegisterEnterpriseCluster(t *testing.T) {
 						return &clusters.ProviderMock{
 							GetClusterSpecFunc: func(clusterID string) (types.ClusterSpec, error) {
 								return types.ClusterSpec{
-									MultiAZ:       true,
-									InternalID:    validLengthClusterId,
-									Region:        mocks.DefaultKafkaRequestRegion,
-									ExternalID:    validFormatExternalClusterId,
-									CloudProvider: mocks.DefaultKafkaRequestProvider,
-									Status:        api.ClusterProvisioned,
+									MultiAZ:        true,
+									InternalID:     validLengthClusterId,
+									Region:         mocks.DefaultKafkaRequestRegion,
+									ExternalID:     validFormatExternalClusterId,
+									CloudProvider:  mocks.DefaultKafkaRequestProvider,
+									SubscriptionID: entClusterSubscriptionID,
+									Status:         api.ClusterProvisioned,
 								}, nil
 							},
 						}, nil
@@ -529,13 +835,68 @@ func Test_RegisterEnterpriseCluster(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "should successfully register enterprise cluster for several instance types",
+			args: args{
+				body: []byte(fmt.Sprintf(`{"cluster_id": "%s", "access_kafkas_via_private_network": false, "cluster_ingress_dns_name": "%s", "instance_types": [{"instance_type": "standard", "kafka_machine_pool_node_count": 6}, {"instance_type": "developer", "kafka_machine_pool_node_count": 3}]}`, validLengthClusterId, validDnsName)),
+				ctx:  ctxWithClaims,
+			},
+			fields: fields{
+				amsClient: newClusterOwnerAMSClient(internalOrganisationId),
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return nil, nil
+					},
+					RegisterClusterJobFunc: func(clusterRequest *api.Cluster) *errors.ServiceError {
+						g.Expect(clusterRequest.GetSupportedInstanceTypes()).To(gomega.Equal([]string{kafkaTypes.STANDARD.String(), kafkaTypes.DEVELOPER.String()}))
+						g.Expect(clusterRequest.RetrieveDynamicCapacityInfo()).To(gomega.Equal(map[string]api.DynamicCapacityInfo{
+							kafkaTypes.STANDARD.String():  {MaxNodes: 6},
+							kafkaTypes.DEVELOPER.String(): {MaxNodes: 3},
+						}))
+						return nil
+					},
+				},
+				kasFleetshardOperatorAddon: &services.KasFleetshardOperatorAddonMock{
+					GetAddonParamsFunc: func(cluster *api.Cluster) (services.ParameterList, *errors.ServiceError) {
+						return services.ParameterList{}, nil
+					},
+				},
+				providerFactory: &clusters.ProviderFactoryMock{
+					GetProviderFunc: func(providerType api.ClusterProviderType) (clusters.Provider, error) {
+						return &clusters.ProviderMock{
+							GetClusterSpecFunc: func(clusterID string) (types.ClusterSpec, error) {
+								return types.ClusterSpec{
+									MultiAZ:        true,
+									InternalID:     validLengthClusterId,
+									Region:         mocks.DefaultKafkaRequestRegion,
+									CloudProvider:  mocks.DefaultKafkaRequestProvider,
+									SubscriptionID: entClusterSubscriptionID,
+									Status:         api.ClusterProvisioned,
+								}, nil
+							},
+						}, nil
+					},
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			want: &public.EnterpriseClusterWithAddonParameters{
+				Status:        api.ClusterAccepted.String(),
+				ClusterId:     validLengthClusterId,
+				Id:            validLengthClusterId,
+				CloudProvider: "aws",
+				Region:        "us-east-1",
+				MultiAz:       true,
+				Kind:          "Cluster",
+				Href:          fmt.Sprintf("/api/kafkas_mgmt/v1/clusters/%s", validLengthClusterId),
+			},
+		},
 	}
 
 	for _, testcase := range tests {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			h := NewClusterHandler(tt.fields.kasFleetshardOperatorAddon, tt.fields.clusterService, tt.fields.providerFactory, &config.KafkaConfig{})
+			h := NewClusterHandler(tt.fields.kasFleetshardOperatorAddon, tt.fields.clusterService, tt.fields.providerFactory, kafkaConfig, tt.fields.amsClient)
 			req, rw := GetHandlerParams("POST", "", bytes.NewBuffer(tt.args.body), t)
 			req = req.WithContext(tt.args.ctx)
 			h.RegisterEnterpriseCluster(rw, req)
@@ -629,7 +990,7 @@ func Test_ListEnterpriseClusters(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			h := NewClusterHandler(nil, tt.fields.clusterService, nil, &config.KafkaConfig{})
+			h := NewClusterHandler(nil, tt.fields.clusterService, nil, &config.KafkaConfig{}, nil)
 			req, rw := GetHandlerParams("GET", "", nil, t)
 			req = req.WithContext(tt.args.ctx)
 			h.List(rw, req)
@@ -872,7 +1233,7 @@ func Test_DeregisterEnterpriseCluster(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			h := NewClusterHandler(nil, tt.fields.clusterService, nil, &config.KafkaConfig{})
+			h := NewClusterHandler(nil, tt.fields.clusterService, nil, &config.KafkaConfig{}, nil)
 			req, rw := GetHandlerParams("DELETE", "/{id}", nil, t)
 			if tt.args.queryParams != nil {
 				q := req.URL.Query()
@@ -1125,6 +1486,15 @@ func Test_GetEnterpriseCluster(t *testing.T) {
 					RemainingKafkaStreamingUnits: 1,
 					ConsumedKafkaStreamingUnits:  3,
 				},
+				InstanceTypesCapacityInformation: []public.EnterpriseClusterInstanceTypeCapacityInformation{
+					{
+						InstanceType:                 kafkaTypes.STANDARD.String(),
+						KafkaMachinePoolNodeCount:    12,
+						MaximumKafkaStreamingUnits:   4,
+						RemainingKafkaStreamingUnits: 1,
+						ConsumedKafkaStreamingUnits:  3,
+					},
+				},
 				SupportedInstanceTypes: public.SupportedKafkaInstanceTypesList{
 					InstanceTypes: []public.SupportedKafkaInstanceType{
 						{
@@ -1166,7 +1536,7 @@ func Test_GetEnterpriseCluster(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			h := NewClusterHandler(nil, tt.fields.clusterService, nil, tt.fields.kafkaConfig)
+			h := NewClusterHandler(nil, tt.fields.clusterService, nil, tt.fields.kafkaConfig, nil)
 			req, rw := GetHandlerParams("GET", "/{id}", nil, t)
 			req = mux.SetURLVars(req, map[string]string{"id": entClusterID})
 			req = req.WithContext(tt.args.ctx)
@@ -1184,6 +1554,291 @@ func Test_GetEnterpriseCluster(t *testing.T) {
 	}
 }
 
+func Test_UpdateEnterpriseCluster(t *testing.T) {
+	kafkaConfig := &config.KafkaConfig{
+		SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
+			Configuration: config.SupportedKafkaInstanceTypesConfig{
+				SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+					{
+						Id: kafkaTypes.STANDARD.String(),
+						Sizes: []config.KafkaInstanceSize{
+							{
+								Id:               "x1",
+								CapacityConsumed: 1,
+							},
+						},
+						SupportedBillingModels: []config.KafkaBillingModel{
+							{
+								ID: constants.BillingModelEnterprise.String(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	newEnterpriseCluster := func() *api.Cluster {
+		return &api.Cluster{
+			OrganizationID:      mocks.DefaultOrganisationId,
+			ClusterType:         api.EnterpriseDataPlaneClusterType.String(),
+			ClusterID:           entClusterID,
+			CloudProvider:       "aws",
+			Region:              "us-east-1",
+			MultiAZ:             true,
+			DynamicCapacityInfo: api.JSON([]byte(`{"standard":{"max_nodes":6,"max_units":4,"remaining_units":1}}`)),
+		}
+	}
+
+	type fields struct {
+		clusterService services.ClusterService
+	}
+
+	type args struct {
+		ctx  context.Context
+		body []byte
+	}
+
+	tests := []struct {
+		name           string
+		fields         fields
+		args           args
+		wantStatusCode int
+		want           public.EnterpriseClusterAllOfCapacityInformation
+	}{
+		{
+			name: "should fail if organization ID is not available within context",
+			args: args{
+				ctx:  context.TODO(),
+				body: []byte(`{"kafka_machine_pool_node_count": 12}`),
+			},
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "should fail if kafka_machine_pool_node_count is not a multiple of 3",
+			args: args{
+				ctx:  ctxWithClaims,
+				body: []byte(`{"kafka_machine_pool_node_count": 7}`),
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should fail if the user is not an org admin",
+			args: args{
+				ctx:  nonAdminCtxWithClaims,
+				body: []byte(`{"kafka_machine_pool_node_count": 12}`),
+			},
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "should fail if the cluster is not an enterprise cluster of the organization",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						cluster := newEnterpriseCluster()
+						cluster.OrganizationID = "98765432"
+						return cluster, nil
+					},
+				},
+			},
+			args: args{
+				ctx:  ctxWithClaims,
+				body: []byte(`{"kafka_machine_pool_node_count": 12}`),
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "should refuse to change the network access while kafkas are placed on the cluster",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return newEnterpriseCluster(), nil
+					},
+					ComputeConsumedStreamingUnitCountPerInstanceTypeFunc: func(clusterID string) (services.StreamingUnitCountPerInstanceType, error) {
+						return services.StreamingUnitCountPerInstanceType{kafkaTypes.STANDARD: 3}, nil
+					},
+				},
+			},
+			args: args{
+				ctx:  ctxWithClaims,
+				body: []byte(`{"access_kafkas_via_private_network": true}`),
+			},
+			wantStatusCode: http.StatusConflict,
+		},
+		{
+			name: "should refuse to lower the node count below the capacity consumed by the kafkas of the cluster",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return newEnterpriseCluster(), nil
+					},
+					ComputeConsumedStreamingUnitCountPerInstanceTypeFunc: func(clusterID string) (services.StreamingUnitCountPerInstanceType, error) {
+						return services.StreamingUnitCountPerInstanceType{kafkaTypes.STANDARD: 3}, nil
+					},
+				},
+			},
+			args: args{
+				ctx:  ctxWithClaims,
+				body: []byte(`{"kafka_machine_pool_node_count": 3}`),
+			},
+			wantStatusCode: http.StatusConflict,
+		},
+		{
+			name: "should return an error if the cluster cannot be updated",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return newEnterpriseCluster(), nil
+					},
+					ComputeConsumedStreamingUnitCountPerInstanceTypeFunc: func(clusterID string) (services.StreamingUnitCountPerInstanceType, error) {
+						return services.StreamingUnitCountPerInstanceType{kafkaTypes.STANDARD: 3}, nil
+					},
+					UpdateEnterpriseClusterSettingsFunc: func(cluster api.Cluster) *errors.ServiceError {
+						return errors.GeneralError("failed to update cluster")
+					},
+				},
+			},
+			args: args{
+				ctx:  ctxWithClaims,
+				body: []byte(`{"kafka_machine_pool_node_count": 12}`),
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "should increase the node count and recompute the capacity of the cluster",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return newEnterpriseCluster(), nil
+					},
+					ComputeConsumedStreamingUnitCountPerInstanceTypeFunc: func(clusterID string) (services.StreamingUnitCountPerInstanceType, error) {
+						return services.StreamingUnitCountPerInstanceType{kafkaTypes.STANDARD: 3}, nil
+					},
+					UpdateEnterpriseClusterSettingsFunc: func(cluster api.Cluster) *errors.ServiceError {
+						return nil
+					},
+				},
+			},
+			args: args{
+				ctx:  ctxWithClaims,
+				body: []byte(`{"kafka_machine_pool_node_count": 12}`),
+			},
+			wantStatusCode: http.StatusOK,
+			want: public.EnterpriseClusterAllOfCapacityInformation{
+				KafkaMachinePoolNodeCount:    12,
+				MaximumKafkaStreamingUnits:   8,
+				RemainingKafkaStreamingUnits: 5,
+				ConsumedKafkaStreamingUnits:  3,
+			},
+		},
+		{
+			name: "should fail if both kafka_machine_pool_node_count and instance_types are set",
+			args: args{
+				ctx:  ctxWithClaims,
+				body: []byte(`{"kafka_machine_pool_node_count": 12, "instance_types": [{"instance_type": "standard", "kafka_machine_pool_node_count": 12}]}`),
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should refuse to update the node count of an instance type not supported by the cluster",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return newEnterpriseCluster(), nil
+					},
+					ComputeConsumedStreamingUnitCountPerInstanceTypeFunc: func(clusterID string) (services.StreamingUnitCountPerInstanceType, error) {
+						return services.StreamingUnitCountPerInstanceType{}, nil
+					},
+				},
+			},
+			args: args{
+				ctx:  ctxWithClaims,
+				body: []byte(`{"instance_types": [{"instance_type": "developer", "kafka_machine_pool_node_count": 3}]}`),
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should update the node count of the instance types of the cluster",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return newEnterpriseCluster(), nil
+					},
+					ComputeConsumedStreamingUnitCountPerInstanceTypeFunc: func(clusterID string) (services.StreamingUnitCountPerInstanceType, error) {
+						return services.StreamingUnitCountPerInstanceType{kafkaTypes.STANDARD: 3}, nil
+					},
+					UpdateEnterpriseClusterSettingsFunc: func(cluster api.Cluster) *errors.ServiceError {
+						return nil
+					},
+				},
+			},
+			args: args{
+				ctx:  ctxWithClaims,
+				body: []byte(`{"instance_types": [{"instance_type": "standard", "kafka_machine_pool_node_count": 12}]}`),
+			},
+			wantStatusCode: http.StatusOK,
+			want: public.EnterpriseClusterAllOfCapacityInformation{
+				KafkaMachinePoolNodeCount:    12,
+				MaximumKafkaStreamingUnits:   8,
+				RemainingKafkaStreamingUnits: 5,
+				ConsumedKafkaStreamingUnits:  3,
+			},
+		},
+		{
+			name: "should change the network access of a cluster without kafkas",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return newEnterpriseCluster(), nil
+					},
+					ComputeConsumedStreamingUnitCountPerInstanceTypeFunc: func(clusterID string) (services.StreamingUnitCountPerInstanceType, error) {
+						return services.StreamingUnitCountPerInstanceType{}, nil
+					},
+					UpdateEnterpriseClusterSettingsFunc: func(cluster api.Cluster) *errors.ServiceError {
+						if !cluster.AccessKafkasViaPrivateNetwork {
+							return errors.GeneralError("unexpected network access")
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				ctx:  ctxWithClaims,
+				body: []byte(`{"access_kafkas_via_private_network": true}`),
+			},
+			wantStatusCode: http.StatusOK,
+			want: public.EnterpriseClusterAllOfCapacityInformation{
+				KafkaMachinePoolNodeCount:    6,
+				MaximumKafkaStreamingUnits:   4,
+				RemainingKafkaStreamingUnits: 4,
+				ConsumedKafkaStreamingUnits:  0,
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			h := NewClusterHandler(nil, tt.fields.clusterService, nil, kafkaConfig, nil)
+			req, rw := GetHandlerParams(http.MethodPatch, "/{id}", bytes.NewBuffer(tt.args.body), t)
+			req = mux.SetURLVars(req, map[string]string{"id": entClusterID})
+			req = req.WithContext(tt.args.ctx)
+			h.Update(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode == http.StatusOK {
+				cluster := public.EnterpriseCluster{}
+				err := json.NewDecoder(resp.Body).Decode(&cluster)
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+				g.Expect(cluster.CapacityInformation).To(gomega.Equal(tt.want))
+			}
+		})
+	}
+}
+
 func Test_GetEnterpriseClusterWithAddonParams(t *testing.T) {
 	type fields struct {
 		clusterService             services.ClusterService
@@ -1354,7 +2009,7 @@ func Test_GetEnterpriseClusterWithAddonParams(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			h := NewClusterHandler(tt.fields.kasFleetshardOperatorAddon, tt.fields.clusterService, nil, &config.KafkaConfig{})
+			h := NewClusterHandler(tt.fields.kasFleetshardOperatorAddon, tt.fields.clusterService, nil, &config.KafkaConfig{}, nil)
 			req, rw := GetHandlerParams("GET", "/{id}/addon_parameters", nil, t)
 			req = mux.SetURLVars(req, map[string]string{"id": entClusterID})
 			req = req.WithContext(tt.args.ctx)
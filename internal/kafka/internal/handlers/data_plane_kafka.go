@@ -1,7 +1,12 @@
 package handlers
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	v1 "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api/managedkafkas.managedkafka.bf2.org/v1"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
@@ -9,7 +14,9 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/private"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/gorilla/mux"
@@ -18,12 +25,14 @@ import (
 type dataPlaneKafkaHandler struct {
 	dataPlaneKafkaService services.DataPlaneKafkaService
 	kafkaService          services.KafkaService
+	bus                   signalbus.SignalBus
 }
 
-func NewDataPlaneKafkaHandler(dataPlaneKafkaService services.DataPlaneKafkaService, kafkaService services.KafkaService) *dataPlaneKafkaHandler {
+func NewDataPlaneKafkaHandler(dataPlaneKafkaService services.DataPlaneKafkaService, kafkaService services.KafkaService, bus signalbus.SignalBus) *dataPlaneKafkaHandler {
 	return &dataPlaneKafkaHandler{
 		dataPlaneKafkaService: dataPlaneKafkaService,
 		kafkaService:          kafkaService,
+		bus:                   bus,
 	}
 }
 
@@ -46,36 +55,147 @@ func (h *dataPlaneKafkaHandler) UpdateKafkaStatuses(w http.ResponseWriter, r *ht
 }
 
 func (h *dataPlaneKafkaHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
 	clusterID := mux.Vars(r)["id"]
 	cfg := &handlers.HandlerConfig{
 		Validate: []handlers.Validate{
 			handlers.ValidateLength(&clusterID, "id", handlers.MinRequiredFieldLength, nil),
+			validateGtVersion(query),
 		},
 		Action: func() (interface{}, *errors.ServiceError) {
-			managedKafkas, err := h.kafkaService.GetManagedKafkaByClusterID(clusterID)
-			if err != nil {
-				return nil, err
+			gtVersion := int64(0)
+			if v := query.Get("gt_version"); v != "" {
+				gtVersion, _ = strconv.ParseInt(v, 10, 64)
 			}
 
-			reservedManagedKafkas, err := h.kafkaService.GenerateReservedManagedKafkasByClusterID(clusterID)
-			if err != nil {
-				return nil, err
+			// reserved managed kafkas are generated on each call and are not versioned,
+			// so they are only returned as part of the full list i.e. when no gt_version is given
+			includeReserved := gtVersion == 0
+
+			getList := func() (list private.ManagedKafkaList, err *errors.ServiceError) {
+				managedKafkas, err := h.kafkaService.GetManagedKafkaByClusterID(clusterID, gtVersion)
+				if err != nil {
+					return
+				}
+
+				if includeReserved {
+					var reservedManagedKafkas []v1.ManagedKafka
+					reservedManagedKafkas, err = h.kafkaService.GenerateReservedManagedKafkasByClusterID(clusterID)
+					if err != nil {
+						return
+					}
+					managedKafkas = append(managedKafkas, reservedManagedKafkas...)
+				}
+
+				list = private.ManagedKafkaList{
+					Kind:  "ManagedKafkaList",
+					Items: []private.ManagedKafka{},
+				}
+
+				list.Items = append(
+					list.Items,
+					arrays.Map(managedKafkas, func(mk v1.ManagedKafka) private.ManagedKafka { return presenters.PresentManagedKafka(&mk) })...,
+				)
+
+				return
 			}
-			managedKafkas = append(managedKafkas, reservedManagedKafkas...)
 
-			managedKafkaList := private.ManagedKafkaList{
-				Kind:  "ManagedKafkaList",
-				Items: []private.ManagedKafka{},
+			if v := query.Get("watch"); v != "true" {
+				return getList()
 			}
 
-			managedKafkaList.Items = append(
-				managedKafkaList.Items,
-				arrays.Map(managedKafkas, func(mk v1.ManagedKafka) private.ManagedKafka { return presenters.PresentManagedKafka(&mk) })...,
-			)
+			idx := 0
+			list, err := getList()
+			includeReserved = false
+			bookmarkSent := false
+
+			sub := h.bus.Subscribe(fmt.Sprintf("/agent-clusters/%s/kafkas", clusterID))
+			return handlers.EventStream{
+				ContentType: "application/json;stream=watch",
+				Close:       sub.Close,
+				GetNextEvent: func() (interface{}, *errors.ServiceError) {
+					for { // This function blocks until there is an event to return...
+						if err != nil {
+							return nil, err
+						}
+						if idx < len(list.Items) {
+							result := list.Items[idx]
+							if result.Metadata.ResourceVersion > gtVersion {
+								gtVersion = result.Metadata.ResourceVersion
+							}
+							idx++
+							return private.ManagedKafkaWatchEvent{
+								Type:   "CHANGE",
+								Object: result,
+							}, nil
+						}
+
+						// get the next list..
+						list, err = getList()
+						if err != nil {
+							return nil, err
+						}
+						idx = 0
+
+						// did we run out of items to send?
+						if len(list.Items) > 0 {
+							continue
+						}
+
+						// bookmark idea taken from: https://kubernetes.io/docs/reference/using-api/api-concepts/#watch-bookmarks
+						if !bookmarkSent {
+							bookmarkSent = true
+							return private.ManagedKafkaWatchEvent{
+								Type: "BOOKMARK",
+							}, nil
+						}
 
-			return managedKafkaList, nil
+						// release the DB connection so that we don't tie those up while we wait for changes..
+						if err := db.Resolve(ctx); err != nil {
+							return nil, errors.GeneralError("internal error")
+						}
+
+						if waitForCancelOrTimeoutOrNotification(ctx, 30*time.Second, sub) {
+							// ctx was canceled... likely due to the http connection being closed by
+							// the client. Signal the event stream is done.
+							return nil, nil
+						}
+
+						// get a new DB connection...
+						if err := db.Begin(ctx); err != nil {
+							return nil, errors.GeneralError("internal error")
+						}
+					}
+				},
+			}, nil
 		},
 	}
 
-	handlers.HandleGet(w, r, cfg)
+	handlers.HandleList(w, r, cfg)
+}
+
+// validateGtVersion validates the optional gt_version query parameter: a version which cannot be parsed would
+// otherwise silently return the full list of the managed kafkas.
+func validateGtVersion(query url.Values) handlers.Validate {
+	return func() *errors.ServiceError {
+		if query.Get("gt_version") == "" {
+			return nil
+		}
+		return handlers.ValidateQueryParam(query, "gt_version")()
+	}
+}
+
+// waitForCancelOrTimeoutOrNotification returns true if the context has been canceled or false after the timeout or sub signal
+func waitForCancelOrTimeoutOrNotification(ctx context.Context, timeout time.Duration, sub *signalbus.Subscription) bool {
+	tc, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	select {
+	case <-tc.Done():
+		return false
+	case <-sub.Signal():
+		return false
+	case <-ctx.Done():
+		return true
+	}
 }
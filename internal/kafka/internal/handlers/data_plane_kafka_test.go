@@ -11,8 +11,11 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/private"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
 	v1 "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api/managedkafkas.managedkafka.bf2.org/v1"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
 	"github.com/gorilla/mux"
+	mocket "github.com/selvatico/go-mocket"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/onsi/gomega"
@@ -73,7 +76,7 @@ func Test_UpdateUpdateKafkaStatuses(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			h := NewDataPlaneKafkaHandler(tt.fields.dataplaneKafkaService, tt.fields.kafkaService)
+			h := NewDataPlaneKafkaHandler(tt.fields.dataplaneKafkaService, tt.fields.kafkaService, nil)
 
 			req, rw := GetHandlerParams("GET", "/{id}", bytes.NewBuffer(tt.args.body), t)
 			req = mux.SetURLVars(req, map[string]string{"id": testId})
@@ -94,6 +97,7 @@ func Test_GetAll(t *testing.T) {
 
 	type args struct {
 		clusterId string
+		url       string
 	}
 
 	tests := []struct {
@@ -114,7 +118,7 @@ func Test_GetAll(t *testing.T) {
 			},
 			fields: fields{
 				kafkaService: &services.KafkaServiceMock{
-					GetManagedKafkaByClusterIDFunc: func(clusterID string) ([]v1.ManagedKafka, *errors.ServiceError) {
+					GetManagedKafkaByClusterIDFunc: func(clusterID string, gtVersion int64) ([]v1.ManagedKafka, *errors.ServiceError) {
 						return nil, errors.GeneralError("failed to get kafka by cluster id")
 					},
 				},
@@ -128,7 +132,7 @@ func Test_GetAll(t *testing.T) {
 			},
 			fields: fields{
 				kafkaService: &services.KafkaServiceMock{
-					GetManagedKafkaByClusterIDFunc: func(clusterID string) ([]v1.ManagedKafka, *errors.ServiceError) {
+					GetManagedKafkaByClusterIDFunc: func(clusterID string, gtVersion int64) ([]v1.ManagedKafka, *errors.ServiceError) {
 						return []v1.ManagedKafka{{Id: testId}}, nil
 					},
 					GenerateReservedManagedKafkasByClusterIDFunc: func(clusterID string) ([]v1.ManagedKafka, *errors.ServiceError) {
@@ -145,7 +149,7 @@ func Test_GetAll(t *testing.T) {
 			},
 			fields: fields{
 				kafkaService: &services.KafkaServiceMock{
-					GetManagedKafkaByClusterIDFunc: func(clusterID string) ([]v1.ManagedKafka, *errors.ServiceError) {
+					GetManagedKafkaByClusterIDFunc: func(clusterID string, gtVersion int64) ([]v1.ManagedKafka, *errors.ServiceError) {
 						return []v1.ManagedKafka{
 							{
 								Id: testId,
@@ -174,6 +178,49 @@ func Test_GetAll(t *testing.T) {
 			wantStatusCode: http.StatusOK,
 			wantKafkaIDs:   []string{testId, "reserved-kafka-test-1"},
 		},
+		{
+			name: "should only return the ManagedKafkas with a greater version and no reserved ones when gt_version is given",
+			args: args{
+				clusterId: testId,
+				url:       "/{id}?gt_version=5",
+			},
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					GetManagedKafkaByClusterIDFunc: func(clusterID string, gtVersion int64) ([]v1.ManagedKafka, *errors.ServiceError) {
+						if gtVersion != 5 {
+							return nil, errors.GeneralError("unexpected gt_version %d", gtVersion)
+						}
+						return []v1.ManagedKafka{
+							{
+								Id: testId,
+								ObjectMeta: metav1.ObjectMeta{
+									ResourceVersion: "6",
+									Annotations: map[string]string{
+										"bf2.org/id": testId,
+									},
+								},
+							},
+						}, nil
+					},
+					GenerateReservedManagedKafkasByClusterIDFunc: nil, // should never be called
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantKafkaIDs:   []string{testId},
+		},
+		{
+			name: "should fail validation when gt_version cannot be parsed",
+			args: args{
+				clusterId: testId,
+				url:       "/{id}?gt_version=latest",
+			},
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					GetManagedKafkaByClusterIDFunc: nil, // should never be called
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
 	}
 
 	for _, testcase := range tests {
@@ -181,9 +228,13 @@ func Test_GetAll(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			h := NewDataPlaneKafkaHandler(tt.fields.dataplaneKafkaService, tt.fields.kafkaService)
+			h := NewDataPlaneKafkaHandler(tt.fields.dataplaneKafkaService, tt.fields.kafkaService, nil)
 
-			req, rw := GetHandlerParams("GET", "/{id}", nil, t)
+			url := tt.args.url
+			if url == "" {
+				url = "/{id}"
+			}
+			req, rw := GetHandlerParams("GET", url, nil, t)
 			req = mux.SetURLVars(req, map[string]string{"id": tt.args.clusterId})
 
 			h.GetAll(rw, req)
@@ -201,3 +252,77 @@ func Test_GetAll(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetAll_Watch(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	mocket.Catcher.Reset().NewMock().WithQuery("select txid_current()").WithReply([]map[string]interface{}{{"txid_current": 1}})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx, err := db.NewMockConnectionFactory(nil).NewContext(ctx)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	var gtVersions []int64
+	kafkaService := &services.KafkaServiceMock{
+		GetManagedKafkaByClusterIDFunc: func(clusterID string, gtVersion int64) ([]v1.ManagedKafka, *errors.ServiceError) {
+			gtVersions = append(gtVersions, gtVersion)
+			if gtVersion > 0 {
+				// no more changes: simulate the client closing the connection while waiting for new ones
+				cancel()
+				return nil, nil
+			}
+			return []v1.ManagedKafka{
+				{
+					Id: testId,
+					ObjectMeta: metav1.ObjectMeta{
+						ResourceVersion: "7",
+						Annotations: map[string]string{
+							"bf2.org/id": testId,
+						},
+					},
+				},
+			}, nil
+		},
+		GenerateReservedManagedKafkasByClusterIDFunc: func(clusterID string) ([]v1.ManagedKafka, *errors.ServiceError) {
+			return []v1.ManagedKafka{
+				{
+					Id: "reserved-kafka-test-1",
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"bf2.org/id": "reserved-kafka-test-1",
+						},
+					},
+				},
+			}, nil
+		},
+	}
+	h := NewDataPlaneKafkaHandler(nil, kafkaService, signalbus.NewSignalBus())
+
+	req, rw := GetHandlerParams("GET", "/{id}?watch=true", nil, t)
+	req = mux.SetURLVars(req.WithContext(ctx), map[string]string{"id": testId})
+
+	h.GetAll(rw, req)
+	resp := rw.Result()
+	defer resp.Body.Close()
+	g.Expect(resp.StatusCode).To(gomega.Equal(http.StatusOK))
+	g.Expect(resp.Header.Get("Content-Type")).To(gomega.Equal("application/json;stream=watch"))
+
+	var events []private.ManagedKafkaWatchEvent
+	decoder := json.NewDecoder(resp.Body)
+	for decoder.More() {
+		var event private.ManagedKafkaWatchEvent
+		g.Expect(decoder.Decode(&event)).To(gomega.Succeed())
+		events = append(events, event)
+	}
+
+	g.Expect(events).To(gomega.HaveLen(3))
+	g.Expect(events[0].Type).To(gomega.Equal("CHANGE"))
+	g.Expect(events[0].Object.Id).To(gomega.Equal(testId))
+	g.Expect(events[0].Object.Metadata.ResourceVersion).To(gomega.Equal(int64(7)))
+	g.Expect(events[1].Type).To(gomega.Equal("CHANGE"))
+	g.Expect(events[1].Object.Id).To(gomega.Equal("reserved-kafka-test-1"))
+	g.Expect(events[2].Type).To(gomega.Equal("BOOKMARK"))
+	// reserved kafkas are only part of the initial list and the following lists start from the last seen version
+	g.Expect(gtVersions).To(gomega.Equal([]int64{0, 7, 7}))
+	g.Expect(kafkaService.GenerateReservedManagedKafkasByClusterIDCalls()).To(gomega.HaveLen(1))
+}
@@ -2,13 +2,16 @@ package handlers
 
 import (
 	"net/http"
+	"strings"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
 	config "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/authorization"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 
 	"github.com/gorilla/mux"
 
@@ -17,22 +20,24 @@ import (
 )
 
 type kafkaHandler struct {
-	service        services.KafkaService
-	providerConfig *config.ProviderConfig
-	authService    authorization.Authorization
-	kafkaConfig    *config.KafkaConfig
+	service                  services.KafkaService
+	providerConfig           *config.ProviderConfig
+	authService              authorization.Authorization
+	kafkaConfig              *config.KafkaConfig
+	maintenanceWindowService services.MaintenanceWindowService
 }
 
 func GetAcceptedOrderByParams() []string {
 	return []string{"bootstrap_server_host", "cloud_provider", "cluster_id", "created_at", "href", "id", "instance_type", "multi_az", "name", "organisation_id", "owner", "reauthentication_enabled", "region", "status", "updated_at", "version"}
 }
 
-func NewKafkaHandler(service services.KafkaService, providerConfig *config.ProviderConfig, authService authorization.Authorization, kafkaConfig *config.KafkaConfig) *kafkaHandler {
+func NewKafkaHandler(service services.KafkaService, providerConfig *config.ProviderConfig, authService authorization.Authorization, kafkaConfig *config.KafkaConfig, maintenanceWindowService services.MaintenanceWindowService) *kafkaHandler {
 	return &kafkaHandler{
-		service:        service,
-		providerConfig: providerConfig,
-		authService:    authService,
-		kafkaConfig:    kafkaConfig,
+		service:                  service,
+		providerConfig:           providerConfig,
+		authService:              authService,
+		kafkaConfig:              kafkaConfig,
+		maintenanceWindowService: maintenanceWindowService,
 	}
 }
 
@@ -70,7 +75,7 @@ func (h kafkaHandler) Create(w http.ResponseWriter, r *http.Request) {
 			if svcErr != nil {
 				return nil, svcErr
 			}
-			return presenters.PresentKafkaRequest(convKafka, h.kafkaConfig)
+			return h.presentKafkaRequest(convKafka)
 		},
 	}
 
@@ -87,7 +92,7 @@ func (h kafkaHandler) Get(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				return nil, err
 			}
-			return presenters.PresentKafkaRequest(kafkaRequest, h.kafkaConfig)
+			return h.presentKafkaRequest(kafkaRequest)
 		},
 	}
 	handlers.HandleGet(w, r, cfg)
@@ -134,8 +139,23 @@ func (h kafkaHandler) List(w http.ResponseWriter, r *http.Request) {
 				Items: []public.KafkaRequest{},
 			}
 
+			organisationIds := make([]string, 0, len(kafkaRequests))
 			for _, kafkaRequest := range kafkaRequests {
-				converted, err := presenters.PresentKafkaRequest(kafkaRequest, h.kafkaConfig)
+				if kafkaRequest.OwnMaintenanceWindow() == nil && !arrays.Contains(organisationIds, kafkaRequest.OrganisationId) {
+					organisationIds = append(organisationIds, kafkaRequest.OrganisationId)
+				}
+			}
+			organisationMaintenanceWindows, err := h.maintenanceWindowService.ListByOrganisationIds(organisationIds)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, kafkaRequest := range kafkaRequests {
+				maintenanceWindow := kafkaRequest.OwnMaintenanceWindow()
+				if organisationMaintenanceWindow, ok := organisationMaintenanceWindows[kafkaRequest.OrganisationId]; ok && maintenanceWindow == nil {
+					maintenanceWindow = organisationMaintenanceWindow.Schedule()
+				}
+				converted, err := presenters.PresentKafkaRequest(kafkaRequest, h.kafkaConfig, maintenanceWindow)
 				if err != nil {
 					return public.KafkaRequestList{}, err
 				}
@@ -165,6 +185,8 @@ func (h kafkaHandler) Update(w http.ResponseWriter, r *http.Request) {
 		Validate: []handlers.Validate{
 			validateKafkaFound(),
 			ValidateKafkaUserFacingUpdateFields(ctx, h.authService, kafkaRequest, &kafkaUpdateReq),
+			validateKafkaResizePlan(kafkaRequest, &kafkaUpdateReq, h.kafkaConfig),
+			validateKafkaMaintenanceWindow(&kafkaUpdateReq),
 		},
 		Action: func() (i interface{}, serviceError *errors.ServiceError) {
 			updatedNeeded := false
@@ -178,19 +200,64 @@ func (h kafkaHandler) Update(w http.ResponseWriter, r *http.Request) {
 				updatedNeeded = true
 			}
 
+			updates := map[string]interface{}{
+				"reauthentication_enabled": kafkaRequest.ReauthenticationEnabled,
+				"owner":                    kafkaRequest.Owner,
+			}
+
+			// the kafka request is resized asynchronously by the resize worker: the size is only recorded here
+			if kafkaUpdateReq.Plan != nil {
+				desiredSizeId, _ := config.Plan(*kafkaUpdateReq.Plan).GetSizeID()
+				if desiredSizeId != kafkaRequest.SizeId {
+					kafkaRequest.DesiredSizeId = desiredSizeId
+					kafkaRequest.ResizeStatus = dbapi.KafkaResizeStatusResizing
+					kafkaRequest.ResizeDetails = ""
+					// the quota of the new size is reserved by the resize worker, not reused from a previous resize
+					kafkaRequest.ResizeSubscriptionId = ""
+					updates["desired_size_id"] = kafkaRequest.DesiredSizeId
+					updates["resize_status"] = kafkaRequest.ResizeStatus
+					updates["resize_details"] = kafkaRequest.ResizeDetails
+					updates["resize_subscription_id"] = kafkaRequest.ResizeSubscriptionId
+					updatedNeeded = true
+				}
+			}
+
+			// an empty day removes the maintenance window of the kafka: the one of its organisation applies instead
+			if kafkaUpdateReq.MaintenanceWindowDay != nil {
+				maintenanceWindowDay := strings.ToLower(*kafkaUpdateReq.MaintenanceWindowDay)
+				maintenanceWindowStartHour := 0
+				if maintenanceWindowDay != "" && kafkaUpdateReq.MaintenanceWindowStartHour != nil {
+					maintenanceWindowStartHour = int(*kafkaUpdateReq.MaintenanceWindowStartHour)
+				}
+				if maintenanceWindowDay != kafkaRequest.MaintenanceWindowDay || maintenanceWindowStartHour != kafkaRequest.MaintenanceWindowStartHour {
+					kafkaRequest.MaintenanceWindowDay = maintenanceWindowDay
+					kafkaRequest.MaintenanceWindowStartHour = maintenanceWindowStartHour
+					updates["maintenance_window_day"] = kafkaRequest.MaintenanceWindowDay
+					updates["maintenance_window_start_hour"] = kafkaRequest.MaintenanceWindowStartHour
+					updatedNeeded = true
+				}
+			}
+
 			if updatedNeeded {
-				updateErr := h.service.Updates(kafkaRequest, map[string]interface{}{
-					"reauthentication_enabled": kafkaRequest.ReauthenticationEnabled,
-					"owner":                    kafkaRequest.Owner,
-				})
+				updateErr := h.service.Updates(kafkaRequest, updates)
 
 				if updateErr != nil {
 					return nil, updateErr
 				}
 			}
 
-			return presenters.PresentKafkaRequest(kafkaRequest, h.kafkaConfig)
+			return h.presentKafkaRequest(kafkaRequest)
 		},
 	}
 	handlers.Handle(w, r, cfg, http.StatusOK)
 }
+
+// presentKafkaRequest presents the kafka request along with the maintenance window applying to it
+func (h kafkaHandler) presentKafkaRequest(kafkaRequest *dbapi.KafkaRequest) (public.KafkaRequest, *errors.ServiceError) {
+	maintenanceWindow, err := h.maintenanceWindowService.GetKafkaSchedule(kafkaRequest)
+	if err != nil {
+		return public.KafkaRequest{}, err
+	}
+
+	return presenters.PresentKafkaRequest(kafkaRequest, h.kafkaConfig, maintenanceWindow)
+}
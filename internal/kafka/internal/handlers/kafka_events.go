@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/gorilla/mux"
+)
+
+type kafkaEventsHandler struct {
+	kafkaService services.KafkaService
+	kafkaEvents  services.KafkaEventService
+}
+
+func NewKafkaEventsHandler(kafkaService services.KafkaService, kafkaEvents services.KafkaEventService) *kafkaEventsHandler {
+	return &kafkaEventsHandler{
+		kafkaService: kafkaService,
+		kafkaEvents:  kafkaEvents,
+	}
+}
+
+// List returns the lifecycle history of a Kafka instance to the users allowed to access it
+func (h kafkaEventsHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			listArgs := coreServices.NewListArguments(r.URL.Query())
+			if err := listArgs.Validate([]string{}); err != nil {
+				return nil, errors.NewWithCause(errors.ErrorMalformedRequest, err, "unable to list the events of kafka %q: %s", id, err.Error())
+			}
+
+			// the kafka is only found if the user is allowed to access it
+			if _, err := h.kafkaService.Get(r.Context(), id); err != nil {
+				return nil, err
+			}
+
+			events, paging, err := h.kafkaEvents.ListByKafkaId(id, listArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			eventList := public.KafkaEventList{
+				Kind:  "KafkaEventList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []public.KafkaEvent{},
+			}
+			for _, event := range events {
+				eventList.Items = append(eventList.Items, presenters.PresentKafkaEvent(event))
+			}
+
+			return eventList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
+// AdminList returns the lifecycle history of a Kafka instance with the details of the events.
+// The history is kept once the Kafka instance is deleted so its existence is not checked.
+func (h kafkaEventsHandler) AdminList(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			listArgs := coreServices.NewListArguments(r.URL.Query())
+			if err := listArgs.Validate([]string{}); err != nil {
+				return nil, errors.NewWithCause(errors.ErrorMalformedRequest, err, "unable to list the events of kafka %q: %s", id, err.Error())
+			}
+
+			events, paging, err := h.kafkaEvents.ListByKafkaId(id, listArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			eventList := private.KafkaEventList{
+				Kind:  "KafkaEventList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []private.KafkaEvent{},
+			}
+			for _, event := range events {
+				eventList.Items = append(eventList.Items, presenters.PresentAdminKafkaEvent(event))
+			}
+
+			return eventList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+)
+
+var kafkaEventsMock = &services.KafkaEventServiceMock{
+	ListByKafkaIdFunc: func(kafkaId string, listArgs *coreServices.ListArguments) (dbapi.KafkaEventList, *api.PagingMeta, *errors.ServiceError) {
+		return dbapi.KafkaEventList{
+			{ID: "event-1", KafkaId: kafkaId, Status: "accepted"},
+			{ID: "event-2", KafkaId: kafkaId, PreviousStatus: "provisioning", Status: "failed", FailedReason: "Kafka reported as failed from the data plane", Details: "error reported by the data plane: broker crashed"},
+		}, &api.PagingMeta{Page: 1, Size: 2, Total: 2}, nil
+	},
+}
+
+func Test_kafkaEventsHandler_List(t *testing.T) {
+	tests := []struct {
+		name           string
+		kafkaService   services.KafkaService
+		wantStatusCode int
+	}{
+		{
+			name: "should return not found if the user can not access the kafka",
+			kafkaService: &services.KafkaServiceMock{
+				GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					return nil, errors.NotFound("kafka %q not found", id)
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "should return the events of the kafka without their details",
+			kafkaService: &services.KafkaServiceMock{
+				GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					return &dbapi.KafkaRequest{Meta: api.Meta{ID: id}}, nil
+				},
+			},
+			wantStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewKafkaEventsHandler(tt.kafkaService, kafkaEventsMock)
+			req, rw := GetHandlerParams(http.MethodGet, "/{id}/events", nil, t)
+			req = mux.SetURLVars(req, map[string]string{"id": "kafka-id"})
+			h.List(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode == http.StatusOK {
+				var list public.KafkaEventList
+				g.Expect(json.NewDecoder(resp.Body).Decode(&list)).To(gomega.Succeed())
+				g.Expect(list.Kind).To(gomega.Equal("KafkaEventList"))
+				g.Expect(list.Total).To(gomega.Equal(int32(2)))
+				g.Expect(list.Items[1]).To(gomega.Equal(public.KafkaEvent{
+					Id:             "event-2",
+					Kind:           "KafkaEvent",
+					KafkaId:        "kafka-id",
+					PreviousStatus: "provisioning",
+					Status:         "failed",
+					FailedReason:   "Kafka reported as failed from the data plane",
+				}))
+			}
+		})
+	}
+}
+
+func Test_kafkaEventsHandler_AdminList(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	h := NewKafkaEventsHandler(&services.KafkaServiceMock{}, kafkaEventsMock)
+	req, rw := GetHandlerParams(http.MethodGet, "/kafkas/{id}/events", nil, t)
+	req = mux.SetURLVars(req, map[string]string{"id": "kafka-id"})
+	h.AdminList(rw, req)
+	resp := rw.Result()
+	defer resp.Body.Close()
+	g.Expect(resp.StatusCode).To(gomega.Equal(http.StatusOK))
+
+	var list private.KafkaEventList
+	g.Expect(json.NewDecoder(resp.Body).Decode(&list)).To(gomega.Succeed())
+	g.Expect(list.Items).To(gomega.HaveLen(2))
+	g.Expect(list.Items[1].Details).To(gomega.Equal("error reported by the data plane: broker crashed"))
+}
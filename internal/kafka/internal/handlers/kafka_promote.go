@@ -49,6 +49,8 @@ func (h kafkaPromoteHandler) Promote(w http.ResponseWriter, r *http.Request) {
 			validateKafkaRequestToPromoteHasAPromotableStatus(kafkaRequest),
 			validateRequestedKafkaPromotionHasDifferentKafkaBillingModel(&kafkaPromoteRequest, kafkaRequest),
 			validateNoKafkaPromotionInProgress(kafkaRequest),
+			validateNoKafkaResizeInProgress(kafkaRequest),
+			validateNoKafkaMigrationInProgress(kafkaRequest),
 			validateKafkaPromoteRequestFields(&kafkaPromoteRequest, kafkaRequest, h.service, h.kafkaConfig, h.kafkaPromoteValidatorFactory),
 		},
 		Action: func() (i interface{}, serviceError *errors.ServiceError) {
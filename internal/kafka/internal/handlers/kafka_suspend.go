@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	"github.com/gorilla/mux"
+)
+
+func (h kafkaHandler) Suspend(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	ctx := r.Context()
+	kafkaRequest, kafkaGetError := h.service.Get(ctx, id)
+	validateKafkaFound := func() handlers.Validate {
+		return func() *errors.ServiceError {
+			return kafkaGetError
+		}
+	}
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.ValidateAsyncEnabled(r, "suspend a kafka instance"),
+			validateKafkaFound(),
+			validateUserIsKafkaOwnerOrOrgAdmin(ctx, kafkaRequest),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			return nil, h.service.Suspend(ctx, kafkaRequest)
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusAccepted)
+}
+
+func (h kafkaHandler) Resume(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	ctx := r.Context()
+	kafkaRequest, kafkaGetError := h.service.Get(ctx, id)
+	validateKafkaFound := func() handlers.Validate {
+		return func() *errors.ServiceError {
+			return kafkaGetError
+		}
+	}
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			handlers.ValidateAsyncEnabled(r, "resume a kafka instance"),
+			validateKafkaFound(),
+			validateUserIsKafkaOwnerOrOrgAdmin(ctx, kafkaRequest),
+		},
+		Action: func() (i interface{}, serviceError *errors.ServiceError) {
+			return nil, h.service.Resume(ctx, kafkaRequest)
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusAccepted)
+}
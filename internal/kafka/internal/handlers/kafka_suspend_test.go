@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	mocks "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/kafkas"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+)
+
+var nonOwnerCtx = auth.SetTokenInContext(context.TODO(), &jwt.Token{
+	Claims: jwt.MapClaims{
+		"username":     "another-user",
+		"org_id":       mocks.DefaultOrganisationId,
+		"is_org_admin": false,
+	},
+})
+
+func Test_KafkaHandler_Suspend(t *testing.T) {
+	type args struct {
+		url string
+		ctx context.Context
+	}
+
+	tests := []struct {
+		name           string
+		service        *services.KafkaServiceMock
+		args           args
+		wantStatusCode int
+		wantSuspended  bool
+	}{
+		{
+			name: "should return 400 if async is not enabled",
+			service: &services.KafkaServiceMock{
+				GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
+				},
+			},
+			args: args{
+				url: "/kafkas/test-id/suspend",
+				ctx: ctx,
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return 404 if the kafka is not found",
+			service: &services.KafkaServiceMock{
+				GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					return nil, errors.NotFound("not found")
+				},
+			},
+			args: args{
+				url: "/kafkas/test-id/suspend?async=true",
+				ctx: ctx,
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "should return 403 if the user is neither the owner nor an org admin",
+			service: &services.KafkaServiceMock{
+				GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
+				},
+			},
+			args: args{
+				url: "/kafkas/test-id/suspend?async=true",
+				ctx: nonOwnerCtx,
+			},
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "should return 400 if the kafka cannot be suspended",
+			service: &services.KafkaServiceMock{
+				GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
+				},
+				SuspendFunc: func(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+					return errors.New(errors.ErrorValidation, "kafka cannot be suspended")
+				},
+			},
+			args: args{
+				url: "/kafkas/test-id/suspend?async=true",
+				ctx: ctx,
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantSuspended:  true,
+		},
+		{
+			name: "should return 202 when the kafka is suspended",
+			service: &services.KafkaServiceMock{
+				GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					return mocks.BuildKafkaRequest(
+						mocks.WithPredefinedTestValues(),
+						mocks.With(mocks.STATUS, constants.KafkaRequestStatusReady.String()),
+					), nil
+				},
+				SuspendFunc: func(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+					return nil
+				},
+			},
+			args: args{
+				url: "/kafkas/test-id/suspend?async=true",
+				ctx: ctx,
+			},
+			wantStatusCode: http.StatusAccepted,
+			wantSuspended:  true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			h := NewKafkaHandler(tt.service, nil, nil, nil, nil)
+			req, rw := GetHandlerParams(http.MethodPost, tt.args.url, nil, t)
+			req = mux.SetURLVars(req.WithContext(tt.args.ctx), map[string]string{"id": id})
+			h.Suspend(rw, req)
+			resp := rw.Result()
+			resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			g.Expect(len(tt.service.SuspendCalls()) == 1).To(gomega.Equal(tt.wantSuspended))
+		})
+	}
+}
+
+func Test_KafkaHandler_Resume(t *testing.T) {
+	type args struct {
+		url string
+		ctx context.Context
+	}
+
+	tests := []struct {
+		name           string
+		service        *services.KafkaServiceMock
+		args           args
+		wantStatusCode int
+		wantResumed    bool
+	}{
+		{
+			name: "should return 400 if async is not enabled",
+			service: &services.KafkaServiceMock{
+				GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
+				},
+			},
+			args: args{
+				url: "/kafkas/test-id/resume",
+				ctx: ctx,
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "should return 403 if the user is neither the owner nor an org admin",
+			service: &services.KafkaServiceMock{
+				GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
+				},
+			},
+			args: args{
+				url: "/kafkas/test-id/resume?async=true",
+				ctx: nonOwnerCtx,
+			},
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "should return 403 if there is not enough quota to resume the kafka",
+			service: &services.KafkaServiceMock{
+				GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
+				},
+				ResumeFunc: func(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+					return errors.InsufficientQuotaError("insufficient quota")
+				},
+			},
+			args: args{
+				url: "/kafkas/test-id/resume?async=true",
+				ctx: ctx,
+			},
+			wantStatusCode: http.StatusForbidden,
+			wantResumed:    true,
+		},
+		{
+			name: "should return 202 when the kafka is resumed",
+			service: &services.KafkaServiceMock{
+				GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					return mocks.BuildKafkaRequest(
+						mocks.WithPredefinedTestValues(),
+						mocks.With(mocks.STATUS, constants.KafkaRequestStatusSuspended.String()),
+					), nil
+				},
+				ResumeFunc: func(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+					return nil
+				},
+			},
+			args: args{
+				url: "/kafkas/test-id/resume?async=true",
+				ctx: ctx,
+			},
+			wantStatusCode: http.StatusAccepted,
+			wantResumed:    true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			h := NewKafkaHandler(tt.service, nil, nil, nil, nil)
+			req, rw := GetHandlerParams(http.MethodPost, tt.args.url, nil, t)
+			req = mux.SetURLVars(req.WithContext(tt.args.ctx), map[string]string{"id": id})
+			h.Resume(rw, req)
+			resp := rw.Result()
+			resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			g.Expect(len(tt.service.ResumeCalls()) == 1).To(gomega.Equal(tt.wantResumed))
+		})
+	}
+}
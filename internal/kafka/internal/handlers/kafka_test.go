@@ -34,6 +34,18 @@ var (
 	limit = 2
 )
 
+// noMaintenanceWindowService returns a maintenance window service for which no maintenance window has been chosen
+func noMaintenanceWindowService() services.MaintenanceWindowService {
+	return &services.MaintenanceWindowServiceMock{
+		GetKafkaScheduleFunc: func(kafka *dbapi.KafkaRequest) (*dbapi.MaintenanceWindowSchedule, *errors.ServiceError) {
+			return nil, nil
+		},
+		ListByOrganisationIdsFunc: func(organisationIds []string) (map[string]*dbapi.MaintenanceWindow, *errors.ServiceError) {
+			return map[string]*dbapi.MaintenanceWindow{}, nil
+		},
+	}
+}
+
 func Test_KafkaHandler_Get(t *testing.T) {
 	type fields struct {
 		service        services.KafkaService
@@ -77,7 +89,7 @@ func Test_KafkaHandler_Get(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig)
+			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig, noMaintenanceWindowService())
 			req, rw := GetHandlerParams("GET", "/{id}", nil, t)
 			req = mux.SetURLVars(req, map[string]string{"id": id})
 			h.Get(rw, req)
@@ -145,7 +157,7 @@ func Test_KafkaHandler_Delete(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig)
+			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig, noMaintenanceWindowService())
 			req, rw := GetHandlerParams("DELETE", tt.args.url, nil, t)
 			h.Delete(rw, req)
 			resp := rw.Result()
@@ -238,7 +250,7 @@ func Test_KafkaHandler_List(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig)
+			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig, noMaintenanceWindowService())
 			req, rw := GetHandlerParams("GET", tt.args.url, nil, t)
 			h.List(rw, req)
 			resp := rw.Result()
@@ -249,6 +261,25 @@ func Test_KafkaHandler_List(t *testing.T) {
 }
 
 func Test_KafkaHandler_Update(t *testing.T) {
+	// the standard instance type of the resizable kafka config has a second size the kafkas can be resized to
+	standardSize := fullKafkaConfig.SupportedInstanceTypes.Configuration.SupportedKafkaInstanceTypes[0].Sizes[0]
+	largerStandardSize := standardSize
+	largerStandardSize.Id = "x2"
+	largerStandardSize.SupportedAZModes = []string{"single", "multi"}
+	resizableKafkaConfig := config.KafkaConfig{
+		SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
+			Configuration: config.SupportedKafkaInstanceTypesConfig{
+				SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+					{
+						Id:          "standard",
+						DisplayName: "Standard",
+						Sizes:       []config.KafkaInstanceSize{standardSize, largerStandardSize},
+					},
+				},
+			},
+		},
+	}
+
 	type fields struct {
 		service        services.KafkaService
 		providerConfig *config.ProviderConfig
@@ -317,6 +348,110 @@ func Test_KafkaHandler_Update(t *testing.T) {
 			},
 			wantStatusCode: http.StatusOK,
 		},
+		{
+			name: "succeeds if the maintenance window is set",
+			fields: fields{
+				service: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
+					},
+					UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *errors.ServiceError {
+						if values["maintenance_window_day"] != "tuesday" || values["maintenance_window_start_hour"] != 2 {
+							return errors.GeneralError("unexpected maintenance window: %v", values)
+						}
+						return nil
+					},
+				},
+				kafkaConfig: &fullKafkaConfig,
+			},
+			args: args{
+				body: []byte(`{"maintenance_window_day": "Tuesday", "maintenance_window_start_hour": 2}`),
+				ctx:  ctx,
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "succeeds if the maintenance window is removed",
+			fields: fields{
+				service: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues(), func(kafkaRequest *dbapi.KafkaRequest) {
+							kafkaRequest.MaintenanceWindowDay = "tuesday"
+							kafkaRequest.MaintenanceWindowStartHour = 2
+						}), nil
+					},
+					UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *errors.ServiceError {
+						if values["maintenance_window_day"] != "" || values["maintenance_window_start_hour"] != 0 {
+							return errors.GeneralError("unexpected maintenance window: %v", values)
+						}
+						return nil
+					},
+				},
+				kafkaConfig: &fullKafkaConfig,
+			},
+			args: args{
+				body: []byte(`{"maintenance_window_day": ""}`),
+				ctx:  ctx,
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "succeeds if the plan is changed and clears the subscription of a previous resize",
+			fields: fields{
+				service: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues(), func(kafkaRequest *dbapi.KafkaRequest) {
+							kafkaRequest.ResizeStatus = dbapi.KafkaResizeStatusFailed
+							kafkaRequest.ResizeSubscriptionId = "previous-resize-subscription-id"
+						}), nil
+					},
+					UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *errors.ServiceError {
+						if values["desired_size_id"] != "x2" || values["resize_status"] != dbapi.KafkaResizeStatusResizing || values["resize_subscription_id"] != "" {
+							return errors.GeneralError("unexpected resize: %v", values)
+						}
+						return nil
+					},
+				},
+				kafkaConfig: &resizableKafkaConfig,
+			},
+			args: args{
+				body: []byte(`{"plan": "standard.x2"}`),
+				ctx:  ctx,
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "fails if the day of the maintenance window is invalid",
+			fields: fields{
+				service: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
+					},
+				},
+				kafkaConfig: &fullKafkaConfig,
+			},
+			args: args{
+				body: []byte(`{"maintenance_window_day": "someday", "maintenance_window_start_hour": 2}`),
+				ctx:  ctx,
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "fails if the start hour of the maintenance window is provided without its day",
+			fields: fields{
+				service: &services.KafkaServiceMock{
+					GetFunc: func(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return mocks.BuildKafkaRequest(mocks.WithPredefinedTestValues()), nil
+					},
+				},
+				kafkaConfig: &fullKafkaConfig,
+			},
+			args: args{
+				body: []byte(`{"maintenance_window_start_hour": 24}`),
+				ctx:  ctx,
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
 		{
 			name: "fails if Updates in the kafka service returns an error",
 			fields: fields{
@@ -351,7 +486,7 @@ func Test_KafkaHandler_Update(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig)
+			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig, noMaintenanceWindowService())
 			req, rw := GetHandlerParams("PATCH", tt.args.url, bytes.NewBuffer(tt.args.body), t)
 			req = req.WithContext(tt.args.ctx)
 			h.Update(rw, req)
@@ -604,7 +739,7 @@ func Test_KafkaHandler_Create(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig)
+			h := NewKafkaHandler(tt.fields.service, tt.fields.providerConfig, tt.fields.authService, tt.fields.kafkaConfig, noMaintenanceWindowService())
 			req, rw := GetHandlerParams("CREATE", tt.args.url, bytes.NewBuffer(tt.args.body), t)
 			req = req.WithContext(tt.args.ctx)
 			h.Create(rw, req)
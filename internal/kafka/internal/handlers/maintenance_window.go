@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+)
+
+type maintenanceWindowHandler struct {
+	maintenanceWindowService services.MaintenanceWindowService
+}
+
+func NewMaintenanceWindowHandler(maintenanceWindowService services.MaintenanceWindowService) *maintenanceWindowHandler {
+	return &maintenanceWindowHandler{
+		maintenanceWindowService: maintenanceWindowService,
+	}
+}
+
+// Get returns the maintenance window of the organisation of the user
+func (h maintenanceWindowHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			ValidateKafkaClaims(ctx, ValidateOrganisationId()),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			claims, _ := getClaims(ctx)
+			orgId, _ := claims.GetOrgId()
+
+			maintenanceWindow, err := h.maintenanceWindowService.GetByOrganisationId(orgId)
+			if err != nil {
+				return nil, err
+			}
+			if maintenanceWindow == nil {
+				return nil, errors.NotFound("no maintenance window has been chosen for organisation %q", orgId)
+			}
+			return presenters.PresentMaintenanceWindow(maintenanceWindow), nil
+		},
+	}
+
+	handlers.HandleGet(w, r, cfg)
+}
+
+// Update sets the maintenance window of the organisation of the user. It applies to the Kafka instances of the
+// organisation for which no maintenance window has been chosen. Only organisation administrators can update it.
+func (h maintenanceWindowHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var maintenanceWindowRequest public.MaintenanceWindow
+	ctx := r.Context()
+
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &maintenanceWindowRequest,
+		Validate: []handlers.Validate{
+			ValidateKafkaClaims(ctx, ValidateOrganisationId()),
+			validateUserIsOrgAdmin(ctx),
+			validateMaintenanceWindow(&maintenanceWindowRequest),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			claims, _ := getClaims(ctx)
+
+			maintenanceWindow := presenters.ConvertMaintenanceWindow(maintenanceWindowRequest)
+			maintenanceWindow.OrganisationId, _ = claims.GetOrgId()
+			if err := h.maintenanceWindowService.Upsert(maintenanceWindow); err != nil {
+				return nil, err
+			}
+			return presenters.PresentMaintenanceWindow(maintenanceWindow), nil
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+// Delete removes the maintenance window of the organisation of the user. The upgrades of the Kafka instances of the
+// organisation without a maintenance window of their own are then rolled out straight away.
+func (h maintenanceWindowHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			ValidateKafkaClaims(ctx, ValidateOrganisationId()),
+			validateUserIsOrgAdmin(ctx),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			claims, _ := getClaims(ctx)
+			orgId, _ := claims.GetOrgId()
+
+			return nil, h.maintenanceWindowService.DeleteByOrganisationId(orgId)
+		},
+	}
+
+	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	mocks "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/kafkas"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+)
+
+func Test_maintenanceWindowHandler_Get(t *testing.T) {
+	tests := []struct {
+		name              string
+		maintenanceWindow *dbapi.MaintenanceWindow
+		wantStatusCode    int
+	}{
+		{
+			name:           "should return not found if the organisation has not chosen a maintenance window",
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:              "should return the maintenance window of the organisation",
+			maintenanceWindow: &dbapi.MaintenanceWindow{OrganisationId: mocks.DefaultOrganisationId, DayOfWeek: "tuesday", StartHour: 2},
+			wantStatusCode:    http.StatusOK,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			maintenanceWindowService := &services.MaintenanceWindowServiceMock{
+				GetByOrganisationIdFunc: func(organisationId string) (*dbapi.MaintenanceWindow, *errors.ServiceError) {
+					return tt.maintenanceWindow, nil
+				},
+			}
+			h := NewMaintenanceWindowHandler(maintenanceWindowService)
+
+			req, rw := GetHandlerParams(http.MethodGet, "/", nil, t)
+			req = req.WithContext(ctxWithClaims)
+			h.Get(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			g.Expect(maintenanceWindowService.GetByOrganisationIdCalls()[0].OrganisationId).To(gomega.Equal(mocks.DefaultOrganisationId))
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			var maintenanceWindow public.MaintenanceWindow
+			g.Expect(json.NewDecoder(resp.Body).Decode(&maintenanceWindow)).To(gomega.Succeed())
+			g.Expect(maintenanceWindow).To(gomega.Equal(public.MaintenanceWindow{DayOfWeek: "tuesday", StartHour: 2}))
+		})
+	}
+}
+
+func Test_maintenanceWindowHandler_Update(t *testing.T) {
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		request        public.MaintenanceWindow
+		wantStatusCode int
+	}{
+		{
+			name:           "should return forbidden if the user is not an organisation administrator",
+			ctx:            nonAdminCtxWithClaims,
+			request:        public.MaintenanceWindow{DayOfWeek: "tuesday", StartHour: 2},
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "should return bad request if the day is not a day of the week",
+			ctx:            ctxWithClaims,
+			request:        public.MaintenanceWindow{DayOfWeek: "someday", StartHour: 2},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should return bad request if the start hour is out of range",
+			ctx:            ctxWithClaims,
+			request:        public.MaintenanceWindow{DayOfWeek: "tuesday", StartHour: 24},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should save the maintenance window of the organisation",
+			ctx:            ctxWithClaims,
+			request:        public.MaintenanceWindow{DayOfWeek: "Tuesday", StartHour: 2},
+			wantStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			maintenanceWindowService := &services.MaintenanceWindowServiceMock{
+				UpsertFunc: func(window *dbapi.MaintenanceWindow) *errors.ServiceError {
+					return nil
+				},
+			}
+			h := NewMaintenanceWindowHandler(maintenanceWindowService)
+
+			body, err := json.Marshal(tt.request)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			req, rw := GetHandlerParams(http.MethodPut, "/", bytes.NewBuffer(body), t)
+			req = req.WithContext(tt.ctx)
+			h.Update(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode != http.StatusOK {
+				g.Expect(maintenanceWindowService.UpsertCalls()).To(gomega.BeEmpty())
+				return
+			}
+
+			g.Expect(maintenanceWindowService.UpsertCalls()).To(gomega.HaveLen(1))
+			g.Expect(maintenanceWindowService.UpsertCalls()[0].Window).To(gomega.Equal(&dbapi.MaintenanceWindow{
+				OrganisationId: mocks.DefaultOrganisationId,
+				DayOfWeek:      "tuesday",
+				StartHour:      2,
+			}))
+		})
+	}
+}
+
+func Test_maintenanceWindowHandler_Delete(t *testing.T) {
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		wantStatusCode int
+		wantDeleted    bool
+	}{
+		{
+			name:           "should return forbidden if the user is not an organisation administrator",
+			ctx:            nonAdminCtxWithClaims,
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "should delete the maintenance window of the organisation",
+			ctx:            ctxWithClaims,
+			wantStatusCode: http.StatusNoContent,
+			wantDeleted:    true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			maintenanceWindowService := &services.MaintenanceWindowServiceMock{
+				DeleteByOrganisationIdFunc: func(organisationId string) *errors.ServiceError {
+					return nil
+				},
+			}
+			h := NewMaintenanceWindowHandler(maintenanceWindowService)
+
+			req, rw := GetHandlerParams(http.MethodDelete, "/", nil, t)
+			req = req.WithContext(tt.ctx)
+			h.Delete(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			g.Expect(len(maintenanceWindowService.DeleteByOrganisationIdCalls()) == 1).To(gomega.Equal(tt.wantDeleted))
+		})
+	}
+}
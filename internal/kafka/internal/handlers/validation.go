@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"regexp"
 	"strings"
 
@@ -24,6 +25,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
 	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/authorization"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
 	resource "k8s.io/apimachinery/pkg/api/resource"
 )
 
@@ -33,6 +35,8 @@ var MaxKafkaNameLength = 32
 
 var ClusterIdLength = 32
 
+const WebhookSubscriptionSecretMinLength = 16
+
 const minimunNumberOfNodesForTheKafkaMachinePool = 3
 
 func validateKafkaBillingModel(ctx context.Context, kafkaService services.KafkaService, kafkaConfig *config.KafkaConfig, kafkaRequestPayload *public.KafkaRequestPayload) handlers.Validate {
@@ -153,49 +157,7 @@ func validateVersionsCompatibility(h *adminKafkaHandler, kafkaRequest *dbapi.Kaf
 		desiredKafkaVersion := arrays.FirstNonEmptyOrDefault(kafkaRequest.DesiredKafkaVersion, kafkaUpdateReq.KafkaVersion)
 		desiredKafkaIBPVersion := arrays.FirstNonEmptyOrDefault(kafkaRequest.DesiredKafkaIBPVersion, kafkaUpdateReq.KafkaIbpVersion)
 
-		cluster, err := h.clusterService.FindClusterByID(kafkaRequest.ClusterID)
-		if err != nil {
-			return errors.NewWithCause(errors.ErrorGeneral, err, "unable to find cluster associated with kafka request: %s", kafkaRequest.ID)
-		}
-		if cluster == nil {
-			return errors.New(errors.ErrorValidation, fmt.Sprintf("unable to get cluster for kafka %s", kafkaRequest.ID))
-		}
-
-		if kafkaVersionAvailable, err := h.clusterService.IsStrimziKafkaVersionAvailableInCluster(cluster, desiredStrimziVersion, desiredKafkaVersion, desiredKafkaIBPVersion); err != nil {
-			return errors.Validation(err.Error())
-		} else if !kafkaVersionAvailable {
-			return errors.New(errors.ErrorValidation, fmt.Sprintf("unable to update kafka: %s with kafka version: %s", kafkaRequest.ID, desiredKafkaVersion))
-		}
-
-		if strimziVersionReady, err := h.clusterService.CheckStrimziVersionReady(cluster, desiredStrimziVersion); err != nil {
-			return errors.Validation(err.Error())
-		} else if !strimziVersionReady {
-			return errors.New(errors.ErrorValidation, fmt.Sprintf("unable to update kafka: %s with strimzi version: %s", kafkaRequest.ID, desiredStrimziVersion))
-		}
-
-		currentIBPVersion, _ := arrays.FirstNonEmpty(kafkaRequest.ActualKafkaIBPVersion, desiredKafkaIBPVersion)
-
-		if vCompOldNewIbp, err := api.CompareBuildAwareSemanticVersions(currentIBPVersion, desiredKafkaIBPVersion); err != nil {
-			return errors.New(errors.ErrorValidation, fmt.Sprintf("unable to compare actual ibp version: %s with desired ibp version: %s", currentIBPVersion, desiredKafkaIBPVersion))
-		} else if vCompOldNewIbp > 0 {
-			return errors.New(errors.ErrorValidation, fmt.Sprintf("unable to downgrade kafka: %s ibp version: %s to a lower version: %s", kafkaRequest.ID, desiredKafkaIBPVersion, currentIBPVersion))
-		}
-
-		if vCompIbpKafka, err := api.CompareBuildAwareSemanticVersions(desiredKafkaIBPVersion, desiredKafkaVersion); err != nil {
-			return errors.New(errors.ErrorValidation, fmt.Sprintf("unable to compare kafka ibp version: %s with kafka version: %s", desiredKafkaIBPVersion, desiredKafkaVersion))
-		} else if vCompIbpKafka > 0 {
-			return errors.New(errors.ErrorValidation, fmt.Sprintf("unable to update kafka: %s ibp version: %s with kafka version: %s", kafkaRequest.ID, desiredKafkaIBPVersion, desiredKafkaVersion))
-		}
-
-		currentKafkaVersion, _ := arrays.FirstNonEmpty(kafkaRequest.ActualKafkaVersion, desiredKafkaVersion)
-
-		if vCompKafka, err := api.CompareSemanticVersionsMajorAndMinor(currentKafkaVersion, desiredKafkaVersion); err != nil {
-			return errors.New(errors.ErrorValidation, fmt.Sprintf("unable to compare desired kafka version: %s with actual kafka version: %s", desiredKafkaVersion, currentKafkaVersion))
-		} else if vCompKafka > 0 {
-			return errors.New(errors.ErrorValidation, fmt.Sprintf("unable to downgrade kafka: %s version: %s to the following kafka version: %s", kafkaRequest.ID, currentKafkaVersion, desiredKafkaVersion))
-		}
-
-		return nil
+		return services.ValidateKafkaVersionsCompatibility(h.clusterService, kafkaRequest, desiredStrimziVersion, desiredKafkaVersion, desiredKafkaIBPVersion)
 	}
 }
 
@@ -287,19 +249,42 @@ func getInstanceTypeAndSize(ctx context.Context, kafkaService services.KafkaServ
 		if err != nil {
 			return "", "", errors.New(errors.ErrorBadRequest, fmt.Sprintf("unable to detect instance size in plan provided: %q", kafkaRequestPayload.Plan))
 		}
-		_, err = kafkaConfig.GetKafkaInstanceSize(instTypeFromPlan, size)
+		kafkaInstanceSize, err := kafkaConfig.GetKafkaInstanceSize(instTypeFromPlan, size)
 
 		if err != nil {
 			return "", "", errors.InstancePlanNotSupported("unsupported plan provided: %q", kafkaRequestPayload.Plan)
 		}
+		if !sizeSupportsRequestedBillingModel(kafkaInstanceSize, kafkaRequestPayload) {
+			return "", "", errors.InstancePlanNotSupported("plan %q is not available for the requested billing model", kafkaRequestPayload.Plan)
+		}
 		return instanceType.String(), size, nil
 	} else {
-		rSize, err := kafkaConfig.GetFirstAvailableSize(instanceType.String())
+		kafkaInstanceType, err := kafkaConfig.SupportedInstanceTypes.Configuration.GetKafkaInstanceTypeByID(instanceType.String())
 		if err != nil {
 			return "", "", errors.InstanceTypeNotSupported("unsupported kafka instance type: %q provided", instanceType.String())
 		}
-		return instanceType.String(), rSize.Id, nil
+		// the first size of the instance type available for the requested billing model is the default one
+		for i := range kafkaInstanceType.Sizes {
+			if sizeSupportsRequestedBillingModel(&kafkaInstanceType.Sizes[i], kafkaRequestPayload) {
+				return instanceType.String(), kafkaInstanceType.Sizes[i].Id, nil
+			}
+		}
+		return "", "", errors.InstanceTypeNotSupported("kafka instance type: %q has no size available for the requested billing model", instanceType.String())
+	}
+}
+
+// sizeSupportsRequestedBillingModel returns true if the size is available to the billing model of the kafka request.
+// The billing model defaults to enterprise when a cluster is given, otherwise the billing model is resolved among
+// the standard and marketplace ones when the kafka quota is reserved.
+func sizeSupportsRequestedBillingModel(kafkaInstanceSize *config.KafkaInstanceSize, kafkaRequestPayload *public.KafkaRequestPayload) bool {
+	billingModel := shared.SafeString(kafkaRequestPayload.BillingModel)
+	if shared.StringEmpty(billingModel) && !shared.StringEmpty(kafkaRequestPayload.ClusterId) {
+		billingModel = constants.BillingModelEnterprise.String()
 	}
+	if shared.StringEmpty(billingModel) {
+		return kafkaInstanceSize.SupportsBillingModel("standard") || kafkaInstanceSize.SupportsBillingModel("marketplace")
+	}
+	return kafkaInstanceSize.SupportsBillingModel(billingModel)
 }
 
 // ValidateKafkaPlan - validate the requested Kafka Plan
@@ -316,8 +301,9 @@ func ValidateKafkaUpdateFields(kafkaUpdateRequest *private.KafkaUpdateRequest) h
 			stringSet(&kafkaUpdateRequest.KafkaVersion) ||
 			stringSet(&kafkaUpdateRequest.KafkaIbpVersion) ||
 			stringSet(&kafkaUpdateRequest.MaxDataRetentionSize) ||
-			shared.IsNotNil(kafkaUpdateRequest.Suspended)) {
-			return errors.FieldValidationError("failed to update Kafka Request. Expecting at least one of the following fields: strimzi_version, kafka_version, kafka_ibp_version, max_data_retention_size or suspended to be provided")
+			shared.IsNotNil(kafkaUpdateRequest.Suspended) ||
+			kafkaUpdateRequest.ForceUpgrade) {
+			return errors.FieldValidationError("failed to update Kafka Request. Expecting at least one of the following fields: strimzi_version, kafka_version, kafka_ibp_version, max_data_retention_size, suspended or force_upgrade to be provided")
 		}
 		return nil
 	}
@@ -346,6 +332,20 @@ func validateUserIsKafkaOwnerOrOrgAdmin(ctx context.Context, kafkaRequest *dbapi
 	}
 }
 
+func validateUserIsOrgAdmin(ctx context.Context) handlers.Validate {
+	return func() *errors.ServiceError {
+		claims, claimsErr := getClaims(ctx)
+		if claimsErr != nil {
+			return claimsErr
+		}
+
+		if !claims.IsOrgAdmin() {
+			return errors.Forbidden("only organisation administrators are allowed to perform this action")
+		}
+		return nil
+	}
+}
+
 func ValidateKafkaUserFacingUpdateFields(ctx context.Context, authService authorization.Authorization, kafkaRequest *dbapi.KafkaRequest, kafkaUpdateReq *public.KafkaUpdateRequest) handlers.Validate {
 	return func() *errors.ServiceError {
 		claims, claimsErr := getClaims(ctx)
@@ -443,19 +443,90 @@ func ValidateMaxDataRetentionSize(kafkaRequest *dbapi.KafkaRequest, kafkaUpdateR
 
 func validateKafkaMachinePoolNodeCount(clusterPayload *public.EnterpriseOsdClusterPayload) handlers.Validate {
 	return func() *errors.ServiceError {
-		if clusterPayload.KafkaMachinePoolNodeCount < minimunNumberOfNodesForTheKafkaMachinePool {
-			return errors.FieldValidationError("failed to register cluster. Kafka machine pool node count: %d should be greater or equal to %d", clusterPayload.KafkaMachinePoolNodeCount, minimunNumberOfNodesForTheKafkaMachinePool)
+		// the node counts of the instance types are validated by validateEnterpriseClusterInstanceTypes
+		if len(clusterPayload.InstanceTypes) > 0 {
+			return nil
+		}
+		return validateKafkaMachinePoolNodeCountValue("failed to register cluster", clusterPayload.KafkaMachinePoolNodeCount)
+	}
+}
+
+// validateEnterpriseClusterInstanceTypes validates the instance types an enterprise cluster is registered for.
+// Each instance type has to be supported and to support the enterprise billing model.
+func validateEnterpriseClusterInstanceTypes(clusterPayload *public.EnterpriseOsdClusterPayload, kafkaConfig *config.KafkaConfig) handlers.Validate {
+	return func() *errors.ServiceError {
+		if len(clusterPayload.InstanceTypes) == 0 {
+			return nil
+		}
+
+		if clusterPayload.KafkaMachinePoolNodeCount != 0 {
+			return errors.FieldValidationError("failed to register cluster. kafka_machine_pool_node_count cannot be set together with instance_types")
+		}
+
+		if err := validateEnterpriseClusterInstanceTypesNodeCount("failed to register cluster", clusterPayload.InstanceTypes); err != nil {
+			return err
 		}
 
-		remainder := clusterPayload.KafkaMachinePoolNodeCount % 3
-		if remainder != 0 {
-			return errors.FieldValidationError("failed to register cluster. Kafka machine pool node count: %d should be in multiple of 3", clusterPayload.KafkaMachinePoolNodeCount)
+		for _, instanceType := range clusterPayload.InstanceTypes {
+			instanceTypeConfig, err := kafkaConfig.SupportedInstanceTypes.Configuration.GetKafkaInstanceTypeByID(instanceType.InstanceType)
+			if err != nil {
+				return errors.FieldValidationError("failed to register cluster. Kafka instance type %q is not supported", instanceType.InstanceType)
+			}
+			if _, err := instanceTypeConfig.GetKafkaSupportedBillingModelByID(constants.BillingModelEnterprise.String()); err != nil {
+				return errors.FieldValidationError("failed to register cluster. Kafka instance type %q does not support the %q billing model", instanceType.InstanceType, constants.BillingModelEnterprise.String())
+			}
 		}
 
 		return nil
 	}
 }
 
+func validateKafkaMachinePoolNodeCountUpdate(clusterUpdatePayload *public.EnterpriseClusterUpdatePayload) handlers.Validate {
+	return func() *errors.ServiceError {
+		if len(clusterUpdatePayload.InstanceTypes) > 0 {
+			if clusterUpdatePayload.KafkaMachinePoolNodeCount != nil {
+				return errors.FieldValidationError("failed to update cluster. kafka_machine_pool_node_count cannot be set together with instance_types")
+			}
+			return validateEnterpriseClusterInstanceTypesNodeCount("failed to update cluster", clusterUpdatePayload.InstanceTypes)
+		}
+		if clusterUpdatePayload.KafkaMachinePoolNodeCount == nil {
+			return nil
+		}
+		return validateKafkaMachinePoolNodeCountValue("failed to update cluster", *clusterUpdatePayload.KafkaMachinePoolNodeCount)
+	}
+}
+
+func validateEnterpriseClusterInstanceTypesNodeCount(action string, instanceTypes []public.EnterpriseOsdClusterInstanceTypePayload) *errors.ServiceError {
+	seenInstanceTypes := map[string]bool{}
+	for _, instanceType := range instanceTypes {
+		if shared.StringEmpty(instanceType.InstanceType) {
+			return errors.FieldValidationError("%s. Kafka instance type cannot be empty", action)
+		}
+		if seenInstanceTypes[instanceType.InstanceType] {
+			return errors.FieldValidationError("%s. Kafka instance type %q is listed more than once", action, instanceType.InstanceType)
+		}
+		seenInstanceTypes[instanceType.InstanceType] = true
+
+		if err := validateKafkaMachinePoolNodeCountValue(action, instanceType.KafkaMachinePoolNodeCount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateKafkaMachinePoolNodeCountValue(action string, nodeCount int32) *errors.ServiceError {
+	if nodeCount < minimunNumberOfNodesForTheKafkaMachinePool {
+		return errors.FieldValidationError("%s. Kafka machine pool node count: %d should be greater or equal to %d", action, nodeCount, minimunNumberOfNodesForTheKafkaMachinePool)
+	}
+
+	remainder := nodeCount % 3
+	if remainder != 0 {
+		return errors.FieldValidationError("%s. Kafka machine pool node count: %d should be in multiple of 3", action, nodeCount)
+	}
+
+	return nil
+}
+
 func validateEnterpriseClusterEligibleForDeregistration(ctx context.Context, clusterID string, clusterService services.ClusterService) handlers.Validate {
 	return func() *errors.ServiceError {
 		claims, claimsErr := getClaims(ctx)
@@ -492,8 +563,8 @@ func validateEnterpriseClusterEligibleForDeregistration(ctx context.Context, clu
 	}
 }
 
-// validateEnterpriseClusterHasNoKafkas requires a cluster to be empty, thus having no kafka instances
-func validateEnterpriseClusterHasNoKafkas(clusterID string, clusterService services.ClusterService) handlers.Validate {
+// validateClusterHasNoKafkas requires a cluster to be empty, thus having no kafka instances
+func validateClusterHasNoKafkas(clusterID string, clusterService services.ClusterService) handlers.Validate {
 	return func() *errors.ServiceError {
 		instanceCounts, err := clusterService.FindKafkaInstanceCount([]string{clusterID})
 		if err != nil {
@@ -606,3 +677,154 @@ func validateKafkaPromoteRequestFields(kafkaPromoteRequest *public.KafkaPromoteR
 		return nil
 	}
 }
+
+func validateNoKafkaResizeInProgress(kafkaRequest *dbapi.KafkaRequest) handlers.Validate {
+	return func() *errors.ServiceError {
+		if kafkaRequest.ResizeStatus == dbapi.KafkaResizeStatusResizing {
+			return errors.GeneralError("resize already in progress. kafka request %q is being resized from size %q to %q", kafkaRequest.ID, kafkaRequest.SizeId, kafkaRequest.DesiredSizeId)
+		}
+
+		return nil
+	}
+}
+
+func validateNoKafkaMigrationInProgress(kafkaRequest *dbapi.KafkaRequest) handlers.Validate {
+	return func() *errors.ServiceError {
+		if kafkaRequest.MigrationInProgress() {
+			return errors.Conflict("migration already in progress. kafka request %q is being migrated to another data plane cluster", kafkaRequest.ID)
+		}
+
+		return nil
+	}
+}
+
+// validateKafkaCanBeMigrated verifies that the kafka request is ready and that it is not being promoted, resized or
+// migrated already
+func validateKafkaCanBeMigrated(kafkaRequest *dbapi.KafkaRequest) handlers.Validate {
+	return func() *errors.ServiceError {
+		return services.ValidateKafkaCanBeMigrated(kafkaRequest)
+	}
+}
+
+// validateKafkaResizePlan verifies that the kafka request can be resized to the plan of the update request, if any.
+// Only the sizes of the instance type of the kafka request are supported and the kafka request has to be ready.
+func validateKafkaResizePlan(kafkaRequest *dbapi.KafkaRequest, kafkaUpdateReq *public.KafkaUpdateRequest, kafkaConfig *config.KafkaConfig) handlers.Validate {
+	return func() *errors.ServiceError {
+		if kafkaUpdateReq.Plan == nil {
+			return nil
+		}
+
+		plan := config.Plan(*kafkaUpdateReq.Plan)
+		instanceType, err := plan.GetInstanceType()
+		if err != nil {
+			return errors.BadRequest("unable to detect instance type in plan provided: %q", plan)
+		}
+		sizeId, err := plan.GetSizeID()
+		if err != nil {
+			return errors.BadRequest("unable to detect instance size in plan provided: %q", plan)
+		}
+
+		if instanceType != kafkaRequest.InstanceType {
+			return errors.BadRequest("kafka request %q of instance type %q cannot be resized to a size of instance type %q", kafkaRequest.ID, kafkaRequest.InstanceType, instanceType)
+		}
+		kafkaInstanceSize, err := kafkaConfig.GetKafkaInstanceSize(instanceType, sizeId)
+		if err != nil {
+			return errors.InstancePlanNotSupported("unsupported plan provided: %q", plan)
+		}
+		if sizeId == kafkaRequest.SizeId {
+			// nothing to resize
+			return nil
+		}
+		if !kafkaInstanceSize.SupportsBillingModel(kafkaRequest.ActualKafkaBillingModel) {
+			return errors.InstancePlanNotSupported("plan %q is not available for the billing model %q of kafka request %q", plan, kafkaRequest.ActualKafkaBillingModel, kafkaRequest.ID)
+		}
+		if !kafkaInstanceSize.SupportsAZMode(kafkaRequest.MultiAZ) {
+			return errors.InstancePlanNotSupported("plan %q is not available for the availability zones of kafka request %q", plan, kafkaRequest.ID)
+		}
+
+		if kafkaRequest.Status != constants.KafkaRequestStatusReady.String() {
+			return errors.BadRequest("kafka request %q with status %q cannot be resized: only ready kafka requests can be resized", kafkaRequest.ID, kafkaRequest.Status)
+		}
+		if svcErr := validateNoKafkaPromotionInProgress(kafkaRequest)(); svcErr != nil {
+			return svcErr
+		}
+
+		if svcErr := validateNoKafkaResizeInProgress(kafkaRequest)(); svcErr != nil {
+			return svcErr
+		}
+
+		return validateNoKafkaMigrationInProgress(kafkaRequest)()
+	}
+}
+
+// validateKafkaMaintenanceWindow verifies the maintenance window of the update request, if any. The start hour can only
+// be set along with the day of the maintenance window, an empty day removes the maintenance window of the kafka.
+func validateKafkaMaintenanceWindow(kafkaUpdateReq *public.KafkaUpdateRequest) handlers.Validate {
+	return func() *errors.ServiceError {
+		if kafkaUpdateReq.MaintenanceWindowDay == nil {
+			if kafkaUpdateReq.MaintenanceWindowStartHour != nil {
+				return errors.FieldValidationError("maintenance_window_start_hour can only be provided along with maintenance_window_day")
+			}
+			return nil
+		}
+		if *kafkaUpdateReq.MaintenanceWindowDay == "" {
+			return nil
+		}
+
+		startHour := 0
+		if kafkaUpdateReq.MaintenanceWindowStartHour != nil {
+			startHour = int(*kafkaUpdateReq.MaintenanceWindowStartHour)
+		}
+		if _, err := dbapi.NewMaintenanceWindowSchedule(*kafkaUpdateReq.MaintenanceWindowDay, startHour); err != nil {
+			return errors.FieldValidationError("invalid maintenance window: %s", err.Error())
+		}
+		return nil
+	}
+}
+
+// validateMaintenanceWindow verifies the day and the start hour of the maintenance window of an organisation
+func validateMaintenanceWindow(maintenanceWindow *public.MaintenanceWindow) handlers.Validate {
+	return func() *errors.ServiceError {
+		if _, err := dbapi.NewMaintenanceWindowSchedule(maintenanceWindow.DayOfWeek, int(maintenanceWindow.StartHour)); err != nil {
+			return errors.FieldValidationError("invalid maintenance window: %s", err.Error())
+		}
+		return nil
+	}
+}
+
+// validateWebhookSubscriptionUrl verifies that the notifications are POSTed to an absolute HTTPS url of a public host
+func validateWebhookSubscriptionUrl(ctx context.Context, webhookConfig *webhooks.Config, webhookSubscriptionRequest *public.WebhookSubscriptionRequest) handlers.Validate {
+	return func() *errors.ServiceError {
+		endpoint, err := url.Parse(webhookSubscriptionRequest.Url)
+		if err != nil || endpoint.Host == "" {
+			return errors.FieldValidationError("url %q is not a valid url", webhookSubscriptionRequest.Url)
+		}
+		if endpoint.Scheme != "https" {
+			return errors.FieldValidationError("url %q must use the https scheme", webhookSubscriptionRequest.Url)
+		}
+		if err := webhookConfig.ValidateEndpoint(ctx, endpoint); err != nil {
+			return errors.FieldValidationError("url %q is not a public endpoint: %s", webhookSubscriptionRequest.Url, err.Error())
+		}
+		return nil
+	}
+}
+
+func validateWebhookSubscriptionEventTypes(webhookSubscriptionRequest *public.WebhookSubscriptionRequest) handlers.Validate {
+	return func() *errors.ServiceError {
+		for _, eventType := range webhookSubscriptionRequest.EventTypes {
+			if !arrays.Contains(webhooks.EventTypes, eventType) {
+				return errors.FieldValidationError("event type %q is not supported. Supported event types are: %v", eventType, webhooks.EventTypes)
+			}
+		}
+		return nil
+	}
+}
+
+func validateWebhookSubscriptionSecret(webhookSubscriptionRequest *public.WebhookSubscriptionRequest) handlers.Validate {
+	return func() *errors.ServiceError {
+		if webhookSubscriptionRequest.Secret != "" && len(webhookSubscriptionRequest.Secret) < WebhookSubscriptionSecretMinLength {
+			return errors.FieldValidationError("secret must be at least %d characters long", WebhookSubscriptionSecretMinLength)
+		}
+		return nil
+	}
+}
@@ -958,6 +958,166 @@ func TestValidateKafkaPlan(t *testing.T) {
 			},
 			want: errors.InstancePlanNotSupported(fmt.Sprintf("unsupported plan provided: %q", "developer.invalidPlan")),
 		},
+		{
+			name: "should not return an error if the plan provided is available for the requested billing model",
+			args: args{
+				ctx: context.Background(),
+				kafkaService: &services.KafkaServiceMock{
+					AssignInstanceTypeFunc: func(owner, organisationID string) (types.KafkaInstanceType, *errors.ServiceError) {
+						return types.DEVELOPER, nil
+					},
+				},
+				kafkaRequestPayload: &public.KafkaRequestPayload{
+					Plan:      "developer.x1-enterprise",
+					ClusterId: &[]string{"cluster-id"}[0],
+				},
+				kafkaConfig: &config.KafkaConfig{
+					SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
+						Configuration: config.SupportedKafkaInstanceTypesConfig{
+							SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+								{
+									Id:          "developer",
+									DisplayName: "Trial",
+									Sizes: []config.KafkaInstanceSize{
+										{
+											Id:                     "x1",
+											DisplayName:            "1",
+											SupportedBillingModels: []string{"standard"},
+										},
+										{
+											Id:                     "x1-enterprise",
+											DisplayName:            "1",
+											SupportedBillingModels: []string{"enterprise"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "should return an error if the plan provided is not available for the requested billing model",
+			args: args{
+				ctx: context.Background(),
+				kafkaService: &services.KafkaServiceMock{
+					AssignInstanceTypeFunc: func(owner, organisationID string) (types.KafkaInstanceType, *errors.ServiceError) {
+						return types.DEVELOPER, nil
+					},
+				},
+				kafkaRequestPayload: &public.KafkaRequestPayload{
+					Plan:         "developer.x1-enterprise",
+					BillingModel: &[]string{"standard"}[0],
+				},
+				kafkaConfig: &config.KafkaConfig{
+					SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
+						Configuration: config.SupportedKafkaInstanceTypesConfig{
+							SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+								{
+									Id:          "developer",
+									DisplayName: "Trial",
+									Sizes: []config.KafkaInstanceSize{
+										{
+											Id:                     "x1",
+											DisplayName:            "1",
+											SupportedBillingModels: []string{"standard"},
+										},
+										{
+											Id:                     "x1-enterprise",
+											DisplayName:            "1",
+											SupportedBillingModels: []string{"enterprise"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: errors.InstancePlanNotSupported(fmt.Sprintf("plan %q is not available for the requested billing model", "developer.x1-enterprise")),
+		},
+		{
+			name: "should return an error if the plan provided is not available for the enterprise billing model defaulted from the cluster",
+			args: args{
+				ctx: context.Background(),
+				kafkaService: &services.KafkaServiceMock{
+					AssignInstanceTypeFunc: func(owner, organisationID string) (types.KafkaInstanceType, *errors.ServiceError) {
+						return types.DEVELOPER, nil
+					},
+				},
+				kafkaRequestPayload: &public.KafkaRequestPayload{
+					Plan:      "developer.x1",
+					ClusterId: &[]string{"cluster-id"}[0],
+				},
+				kafkaConfig: &config.KafkaConfig{
+					SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
+						Configuration: config.SupportedKafkaInstanceTypesConfig{
+							SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+								{
+									Id:          "developer",
+									DisplayName: "Trial",
+									Sizes: []config.KafkaInstanceSize{
+										{
+											Id:                     "x1",
+											DisplayName:            "1",
+											SupportedBillingModels: []string{"standard"},
+										},
+										{
+											Id:                     "x1-enterprise",
+											DisplayName:            "1",
+											SupportedBillingModels: []string{"enterprise"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: errors.InstancePlanNotSupported(fmt.Sprintf("plan %q is not available for the requested billing model", "developer.x1")),
+		},
+		{
+			name: "should not return an error if KafkaRequestPayload.plan in not set and a size is available for the requested billing model",
+			args: args{
+				ctx: context.Background(),
+				kafkaService: &services.KafkaServiceMock{
+					AssignInstanceTypeFunc: func(owner, organisationID string) (types.KafkaInstanceType, *errors.ServiceError) {
+						return types.DEVELOPER, nil
+					},
+				},
+				kafkaRequestPayload: &public.KafkaRequestPayload{
+					BillingModel: &[]string{"enterprise"}[0],
+					ClusterId:    &[]string{"cluster-id"}[0],
+				},
+				kafkaConfig: &config.KafkaConfig{
+					SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
+						Configuration: config.SupportedKafkaInstanceTypesConfig{
+							SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+								{
+									Id:          "developer",
+									DisplayName: "Trial",
+									Sizes: []config.KafkaInstanceSize{
+										{
+											Id:                     "x1",
+											DisplayName:            "1",
+											SupportedBillingModels: []string{"standard"},
+										},
+										{
+											Id:                     "x1-enterprise",
+											DisplayName:            "1",
+											SupportedBillingModels: []string{"enterprise"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
 	}
 
 	for _, testcase := range tests {
@@ -993,6 +1153,15 @@ func TestValidateKafkaUpdateFields(t *testing.T) {
 			},
 			want: nil,
 		},
+		{
+			name: "should return nil if only the upgrade is forced",
+			args: args{
+				kafkaUpdateRequest: &private.KafkaUpdateRequest{
+					ForceUpgrade: true,
+				},
+			},
+			want: nil,
+		},
 		{
 			name: "should return error if all fields are empty",
 			args: args{
@@ -1003,7 +1172,7 @@ func TestValidateKafkaUpdateFields(t *testing.T) {
 					MaxDataRetentionSize: "",
 				},
 			},
-			want: errors.FieldValidationError("failed to update Kafka Request. Expecting at least one of the following fields: strimzi_version, kafka_version, kafka_ibp_version, max_data_retention_size or suspended to be provided"),
+			want: errors.FieldValidationError("failed to update Kafka Request. Expecting at least one of the following fields: strimzi_version, kafka_version, kafka_ibp_version, max_data_retention_size, suspended or force_upgrade to be provided"),
 		},
 	}
 	for _, testcase := range tests {
@@ -2039,7 +2208,7 @@ func Test_validateEnterpriseClusterEligibleForDeregistration(t *testing.T) {
 	}
 }
 
-func Test_validateEnterpriseClusterHasNoKafkas(t *testing.T) {
+func Test_validateClusterHasNoKafkas(t *testing.T) {
 	clusterID := "1234abcd1234abcd1234abcd1234abcd"
 	type args struct {
 		ctx            context.Context
@@ -2121,9 +2290,184 @@ func Test_validateEnterpriseClusterHasNoKafkas(t *testing.T) {
 		t.Run(testcase.name, func(t *testing.T) {
 			t.Parallel()
 			g := gomega.NewWithT(t)
-			validateFn := validateEnterpriseClusterHasNoKafkas(testcase.args.clusterID, testcase.args.clusterService)
+			validateFn := validateClusterHasNoKafkas(testcase.args.clusterID, testcase.args.clusterService)
 			err := validateFn()
 			g.Expect(err).To(gomega.Equal(testcase.want))
 		})
 	}
 }
+
+func Test_validateKafkaResizePlan(t *testing.T) {
+	kafkaConfig := &config.KafkaConfig{
+		SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
+			Configuration: config.SupportedKafkaInstanceTypesConfig{
+				SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+					{
+						Id:          types.STANDARD.String(),
+						DisplayName: "Standard",
+						Sizes: []config.KafkaInstanceSize{
+							{Id: "x1", DisplayName: "1", SupportedAZModes: []string{"multi"}},
+							{Id: "x2", DisplayName: "2", SupportedAZModes: []string{"multi"}},
+							{Id: "x2-enterprise", DisplayName: "2", SupportedAZModes: []string{"multi"}, SupportedBillingModels: []string{"enterprise"}},
+							{Id: "x2-single", DisplayName: "2", SupportedAZModes: []string{"single"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	readyKafka := func() *dbapi.KafkaRequest {
+		return &dbapi.KafkaRequest{
+			Meta:         api.Meta{ID: "kafka-id"},
+			InstanceType: types.STANDARD.String(),
+			SizeId:       "x1",
+			Status:       constants.KafkaRequestStatusReady.String(),
+			MultiAZ:      true,
+			// the billing model of the kafka is checked against the billing models the sizes are restricted to
+			ActualKafkaBillingModel: "standard",
+		}
+	}
+	plan := func(p string) *string {
+		return &p
+	}
+
+	type args struct {
+		kafkaRequest   *dbapi.KafkaRequest
+		kafkaUpdateReq *public.KafkaUpdateRequest
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "should succeed if no plan is requested",
+			args: args{
+				kafkaRequest:   readyKafka(),
+				kafkaUpdateReq: &public.KafkaUpdateRequest{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should succeed if the plan is a size of the instance type of the kafka",
+			args: args{
+				kafkaRequest:   readyKafka(),
+				kafkaUpdateReq: &public.KafkaUpdateRequest{Plan: plan("standard.x2")},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should succeed if the plan is the actual size of the kafka",
+			args: args{
+				kafkaRequest: func() *dbapi.KafkaRequest {
+					kafka := readyKafka()
+					kafka.Status = constants.KafkaRequestStatusSuspended.String()
+					return kafka
+				}(),
+				kafkaUpdateReq: &public.KafkaUpdateRequest{Plan: plan("standard.x1")},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should fail if the plan is malformed",
+			args: args{
+				kafkaRequest:   readyKafka(),
+				kafkaUpdateReq: &public.KafkaUpdateRequest{Plan: plan("x2")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should fail if the plan is of another instance type",
+			args: args{
+				kafkaRequest:   readyKafka(),
+				kafkaUpdateReq: &public.KafkaUpdateRequest{Plan: plan("developer.x1")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should fail if the size is not supported",
+			args: args{
+				kafkaRequest:   readyKafka(),
+				kafkaUpdateReq: &public.KafkaUpdateRequest{Plan: plan("standard.x3")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should fail if the size is not available for the billing model of the kafka",
+			args: args{
+				kafkaRequest:   readyKafka(),
+				kafkaUpdateReq: &public.KafkaUpdateRequest{Plan: plan("standard.x2-enterprise")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should succeed if the size is available for the billing model of the kafka",
+			args: args{
+				kafkaRequest: func() *dbapi.KafkaRequest {
+					kafka := readyKafka()
+					kafka.ActualKafkaBillingModel = "enterprise"
+					return kafka
+				}(),
+				kafkaUpdateReq: &public.KafkaUpdateRequest{Plan: plan("standard.x2-enterprise")},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should fail if the size is not available for the availability zones of the kafka",
+			args: args{
+				kafkaRequest:   readyKafka(),
+				kafkaUpdateReq: &public.KafkaUpdateRequest{Plan: plan("standard.x2-single")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should fail if the kafka is not ready",
+			args: args{
+				kafkaRequest: func() *dbapi.KafkaRequest {
+					kafka := readyKafka()
+					kafka.Status = constants.KafkaRequestStatusSuspended.String()
+					return kafka
+				}(),
+				kafkaUpdateReq: &public.KafkaUpdateRequest{Plan: plan("standard.x2")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should fail if a promotion is in progress",
+			args: args{
+				kafkaRequest: func() *dbapi.KafkaRequest {
+					kafka := readyKafka()
+					kafka.PromotionStatus = dbapi.KafkaPromotionStatusPromoting
+					return kafka
+				}(),
+				kafkaUpdateReq: &public.KafkaUpdateRequest{Plan: plan("standard.x2")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should fail if a resize is in progress",
+			args: args{
+				kafkaRequest: func() *dbapi.KafkaRequest {
+					kafka := readyKafka()
+					kafka.ResizeStatus = dbapi.KafkaResizeStatusResizing
+					kafka.DesiredSizeId = "x2"
+					return kafka
+				}(),
+				kafkaUpdateReq: &public.KafkaUpdateRequest{Plan: plan("standard.x2")},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			validateFn := validateKafkaResizePlan(testcase.args.kafkaRequest, testcase.args.kafkaUpdateReq, kafkaConfig)
+			err := validateFn()
+			g.Expect(err != nil).To(gomega.Equal(testcase.wantErr))
+		})
+	}
+}
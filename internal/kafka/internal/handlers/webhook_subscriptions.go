@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/presenters"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
+	"github.com/gorilla/mux"
+)
+
+type webhookSubscriptionsHandler struct {
+	webhookService webhooks.WebhookService
+	webhookConfig  *webhooks.Config
+}
+
+func NewWebhookSubscriptionsHandler(webhookService webhooks.WebhookService, webhookConfig *webhooks.Config) *webhookSubscriptionsHandler {
+	return &webhookSubscriptionsHandler{
+		webhookService: webhookService,
+		webhookConfig:  webhookConfig,
+	}
+}
+
+// Create subscribes an endpoint to the events of the organisation of the user. The secret of the subscription is
+// only returned in the response of this request.
+func (h webhookSubscriptionsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var webhookSubscriptionRequest public.WebhookSubscriptionRequest
+	ctx := r.Context()
+
+	cfg := &handlers.HandlerConfig{
+		MarshalInto: &webhookSubscriptionRequest,
+		Validate: []handlers.Validate{
+			ValidateKafkaClaims(ctx, ValidateUsername(), ValidateOrganisationId()),
+			validateWebhookSubscriptionUrl(ctx, h.webhookConfig, &webhookSubscriptionRequest),
+			validateWebhookSubscriptionEventTypes(&webhookSubscriptionRequest),
+			validateWebhookSubscriptionSecret(&webhookSubscriptionRequest),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			if webhookSubscriptionRequest.Secret == "" {
+				secret, err := webhooks.GenerateSecret()
+				if err != nil {
+					return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to generate the secret of the webhook subscription")
+				}
+				webhookSubscriptionRequest.Secret = secret
+			}
+
+			subscription, svcErr := presenters.ConvertWebhookSubscriptionRequest(webhookSubscriptionRequest)
+			if svcErr != nil {
+				return nil, svcErr
+			}
+			claims, _ := getClaims(ctx)
+			subscription.Owner, _ = claims.GetUsername()
+			subscription.OrgId, _ = claims.GetOrgId()
+
+			if svcErr := h.webhookService.CreateSubscription(subscription); svcErr != nil {
+				return nil, svcErr
+			}
+
+			presented, svcErr := presenters.PresentWebhookSubscription(subscription)
+			if svcErr != nil {
+				return nil, svcErr
+			}
+			presented.Secret = subscription.Secret.String()
+			return presented, nil
+		},
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusCreated)
+}
+
+func (h webhookSubscriptionsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			ValidateKafkaClaims(ctx, ValidateOrganisationId()),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			claims, _ := getClaims(ctx)
+			orgId, _ := claims.GetOrgId()
+
+			subscription, err := h.webhookService.GetSubscription(orgId, mux.Vars(r)["id"])
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentWebhookSubscription(subscription)
+		},
+	}
+
+	handlers.HandleGet(w, r, cfg)
+}
+
+func (h webhookSubscriptionsHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			ValidateKafkaClaims(ctx, ValidateOrganisationId()),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := coreServices.NewListArguments(r.URL.Query())
+			if err := listArgs.Validate([]string{}); err != nil {
+				return nil, errors.NewWithCause(errors.ErrorMalformedRequest, err, "unable to list webhook subscriptions: %s", err.Error())
+			}
+			claims, _ := getClaims(ctx)
+			orgId, _ := claims.GetOrgId()
+
+			subscriptions, paging, svcErr := h.webhookService.ListSubscriptions(orgId, listArgs)
+			if svcErr != nil {
+				return nil, svcErr
+			}
+
+			subscriptionList := public.WebhookSubscriptionList{
+				Kind:  "WebhookSubscriptionList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []public.WebhookSubscription{},
+			}
+			for _, subscription := range subscriptions {
+				presented, svcErr := presenters.PresentWebhookSubscription(subscription)
+				if svcErr != nil {
+					return nil, svcErr
+				}
+				subscriptionList.Items = append(subscriptionList.Items, presented)
+			}
+
+			return subscriptionList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
+// Delete unsubscribes the endpoint. The notifications that are not delivered yet are given up.
+func (h webhookSubscriptionsHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	cfg := &handlers.HandlerConfig{
+		Validate: []handlers.Validate{
+			ValidateKafkaClaims(ctx, ValidateOrganisationId()),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			claims, _ := getClaims(ctx)
+			orgId, _ := claims.GetOrgId()
+
+			return nil, h.webhookService.DeleteSubscription(orgId, mux.Vars(r)["id"])
+		},
+	}
+
+	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
+	mocks "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/kafkas"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
+	"github.com/gorilla/mux"
+	"github.com/onsi/gomega"
+)
+
+func Test_webhookSubscriptionsHandler_Create(t *testing.T) {
+	tests := []struct {
+		name           string
+		request        public.WebhookSubscriptionRequest
+		wantStatusCode int
+		wantSecret     string
+	}{
+		{
+			name:           "should return bad request if the url does not use https",
+			request:        public.WebhookSubscriptionRequest{Url: "http://example.com/webhook"},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should return bad request if the url is not absolute",
+			request:        public.WebhookSubscriptionRequest{Url: "/webhook"},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should return bad request if the host is a loopback address",
+			request:        public.WebhookSubscriptionRequest{Url: "https://127.0.0.1/webhook"},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should return bad request if the host resolves to a loopback address",
+			request:        public.WebhookSubscriptionRequest{Url: "https://localhost/webhook"},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should return bad request if the host is a private address",
+			request:        public.WebhookSubscriptionRequest{Url: "https://10.0.0.1/webhook"},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should return bad request if the host is a link-local address",
+			request:        public.WebhookSubscriptionRequest{Url: "https://169.254.169.254/latest/meta-data"},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should return bad request if an event type is not supported",
+			request:        public.WebhookSubscriptionRequest{Url: "https://203.0.113.10/webhook", EventTypes: []string{"kafka.deleted"}},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should return bad request if the secret is too short",
+			request:        public.WebhookSubscriptionRequest{Url: "https://203.0.113.10/webhook", Secret: "short"},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "should create the subscription with the given secret",
+			request:        public.WebhookSubscriptionRequest{Url: "https://203.0.113.10/webhook", EventTypes: []string{webhooks.KafkaPhaseChanged.String()}, Secret: "a-secret-of-16-chars"},
+			wantStatusCode: http.StatusCreated,
+			wantSecret:     "a-secret-of-16-chars",
+		},
+		{
+			name:           "should create the subscription with a generated secret",
+			request:        public.WebhookSubscriptionRequest{Url: "https://203.0.113.10/webhook"},
+			wantStatusCode: http.StatusCreated,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			webhookService := &webhooks.WebhookServiceMock{
+				CreateSubscriptionFunc: func(subscription *api.WebhookSubscription) *errors.ServiceError {
+					subscription.ID = "subscription-id"
+					return nil
+				},
+			}
+			h := NewWebhookSubscriptionsHandler(webhookService, webhooks.NewConfig())
+
+			body, err := json.Marshal(tt.request)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			req, rw := GetHandlerParams(http.MethodPost, "/", bytes.NewBuffer(body), t)
+			req = req.WithContext(ctxWithClaims)
+			h.Create(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode != http.StatusCreated {
+				g.Expect(webhookService.CreateSubscriptionCalls()).To(gomega.BeEmpty())
+				return
+			}
+
+			g.Expect(webhookService.CreateSubscriptionCalls()).To(gomega.HaveLen(1))
+			created := webhookService.CreateSubscriptionCalls()[0].Subscription
+			g.Expect(created.OrgId).To(gomega.Equal(mocks.DefaultOrganisationId))
+			g.Expect(created.Owner).To(gomega.Equal("test-user"))
+
+			var subscription public.WebhookSubscription
+			g.Expect(json.NewDecoder(resp.Body).Decode(&subscription)).To(gomega.Succeed())
+			g.Expect(subscription.Kind).To(gomega.Equal("WebhookSubscription"))
+			g.Expect(subscription.Href).To(gomega.Equal("/api/kafkas_mgmt/v1/webhook_subscriptions/subscription-id"))
+			g.Expect(subscription.Secret).To(gomega.Equal(created.Secret.String()))
+			if tt.wantSecret != "" {
+				g.Expect(subscription.Secret).To(gomega.Equal(tt.wantSecret))
+			} else {
+				g.Expect(len(subscription.Secret)).To(gomega.BeNumerically(">=", WebhookSubscriptionSecretMinLength))
+			}
+		})
+	}
+}
+
+func Test_webhookSubscriptionsHandler_Get(t *testing.T) {
+	tests := []struct {
+		name           string
+		webhookService webhooks.WebhookService
+		wantStatusCode int
+	}{
+		{
+			name: "should return not found if the organisation has no subscription with the id",
+			webhookService: &webhooks.WebhookServiceMock{
+				GetSubscriptionFunc: func(orgId, id string) (*api.WebhookSubscription, *errors.ServiceError) {
+					return nil, errors.NotFound("webhook subscription with id='%s' not found", id)
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "should return the subscription without its secret",
+			webhookService: &webhooks.WebhookServiceMock{
+				GetSubscriptionFunc: func(orgId, id string) (*api.WebhookSubscription, *errors.ServiceError) {
+					return &api.WebhookSubscription{
+						Meta:       api.Meta{ID: id},
+						OrgId:      orgId,
+						Url:        "https://example.com/webhook",
+						Secret:     "a-secret-of-16-chars",
+						EventTypes: api.JSON(`["kafka.phase_changed"]`),
+					}, nil
+				},
+			},
+			wantStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			h := NewWebhookSubscriptionsHandler(tt.webhookService, webhooks.NewConfig())
+			req, rw := GetHandlerParams(http.MethodGet, "/{id}", nil, t)
+			req = mux.SetURLVars(req.WithContext(ctxWithClaims), map[string]string{"id": "subscription-id"})
+			h.Get(rw, req)
+			resp := rw.Result()
+			defer resp.Body.Close()
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.wantStatusCode))
+			if tt.wantStatusCode == http.StatusOK {
+				var subscription public.WebhookSubscription
+				g.Expect(json.NewDecoder(resp.Body).Decode(&subscription)).To(gomega.Succeed())
+				g.Expect(subscription.Secret).To(gomega.BeEmpty())
+				g.Expect(subscription.EventTypes).To(gomega.Equal([]string{"kafka.phase_changed"}))
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addKafkaSuspensionInfoInKafkaRequestsTable() *gormigrate.Migration {
+	type KafkaRequest struct {
+		SuspendedBy string
+		SuspendedAt sql.NullTime
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230301120000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&KafkaRequest{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, column := range []string{"suspended_by", "suspended_at"} {
+				if tx.Migrator().HasColumn(&KafkaRequest{}, column) {
+					if err := tx.Migrator().DropColumn(&KafkaRequest{}, column); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
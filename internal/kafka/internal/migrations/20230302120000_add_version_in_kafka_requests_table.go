@@ -0,0 +1,46 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addVersionInKafkaRequestsTable() *gormigrate.Migration {
+	type KafkaRequest struct {
+		Version int64 `gorm:"type:bigserial;index"`
+	}
+
+	return db.CreateMigrationFromActions("20230302120000",
+		db.AddTableColumnsAction(&KafkaRequest{}),
+
+		// bump the version on every write and notify the watchers of the data plane cluster(s) the kafka belongs to.
+		// notifications sent with pg_notify are only delivered once the surrounding transaction commits.
+		db.ExecAction(`
+			CREATE OR REPLACE FUNCTION kafka_requests_version_trigger() RETURNS TRIGGER LANGUAGE plpgsql AS '
+			BEGIN
+			NEW.version := nextval(''kafka_requests_version_seq'');
+			IF NEW.cluster_id <> '''' THEN
+				PERFORM pg_notify(''signalbus'', ''/agent-clusters/'' || NEW.cluster_id || ''/kafkas'');
+			END IF;
+			IF TG_OP = ''UPDATE'' AND OLD.cluster_id <> '''' AND OLD.cluster_id IS DISTINCT FROM NEW.cluster_id THEN
+				PERFORM pg_notify(''signalbus'', ''/agent-clusters/'' || OLD.cluster_id || ''/kafkas'');
+			END IF;
+			RETURN NEW;
+			END;'
+		`, `
+			DROP FUNCTION IF EXISTS kafka_requests_version_trigger
+		`),
+		db.ExecAction(`DROP TRIGGER IF EXISTS kafka_requests_version_trigger ON kafka_requests`, ``),
+		db.ExecAction(`
+			CREATE TRIGGER kafka_requests_version_trigger BEFORE INSERT OR UPDATE ON kafka_requests
+			FOR EACH ROW EXECUTE PROCEDURE kafka_requests_version_trigger();
+		`, `
+			DROP TRIGGER IF EXISTS kafka_requests_version_trigger ON kafka_requests
+		`),
+	)
+}
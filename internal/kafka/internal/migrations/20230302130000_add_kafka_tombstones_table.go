@@ -0,0 +1,62 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addKafkaTombstonesTable() *gormigrate.Migration {
+	type KafkaTombstone struct {
+		KafkaId   string `gorm:"primaryKey"`
+		ClusterId string `gorm:"primaryKey;index:idx_kafka_tombstones_cluster_id_version"`
+		Version   int64  `gorm:"primaryKey;autoIncrement:false;index:idx_kafka_tombstones_cluster_id_version"`
+		Name      string
+		Namespace string
+		CreatedAt time.Time
+	}
+
+	return db.CreateMigrationFromActions("20230302130000",
+		db.CreateTableAction(&KafkaTombstone{}),
+
+		// a kafka leaves a data plane cluster when it is (soft) deleted, when it is assigned to another cluster or when
+		// it is no longer in one of the statuses of the kafkas managed by the kas-fleetshard agent. A tombstone is then
+		// written for the cluster, with the version of the update, and the watchers of the cluster are notified.
+		db.ExecAction(`
+			CREATE OR REPLACE FUNCTION kafka_requests_tombstone_trigger() RETURNS TRIGGER LANGUAGE plpgsql AS '
+			DECLARE
+			managed_statuses text[] := ARRAY[''provisioning'', ''deprovision'', ''ready'', ''failed'', ''suspended'', ''suspending'', ''resuming''];
+			BEGIN
+			IF OLD.deleted_at IS NOT NULL OR OLD.bootstrap_server_host = '''' OR NOT (OLD.status = ANY(managed_statuses))
+				OR OLD.cluster_id IS NULL OR OLD.cluster_id = '''' THEN
+				RETURN NULL;
+			END IF;
+			IF NEW.deleted_at IS NULL AND NEW.bootstrap_server_host <> '''' AND NEW.status = ANY(managed_statuses)
+				AND NEW.cluster_id = OLD.cluster_id THEN
+				RETURN NULL;
+			END IF;
+			INSERT INTO kafka_tombstones (kafka_id, cluster_id, version, name, namespace, created_at)
+				VALUES (NEW.id, OLD.cluster_id, NEW.version, NEW.name, NEW.namespace, now())
+				ON CONFLICT DO NOTHING;
+			PERFORM pg_notify(''signalbus'', ''/agent-clusters/'' || OLD.cluster_id || ''/kafkas'');
+			RETURN NULL;
+			END;'
+		`, `
+			DROP FUNCTION IF EXISTS kafka_requests_tombstone_trigger
+		`),
+		db.ExecAction(`DROP TRIGGER IF EXISTS kafka_requests_tombstone_trigger ON kafka_requests`, ``),
+		// the trigger runs after the update so that the tombstone has the version set by kafka_requests_version_trigger
+		db.ExecAction(`
+			CREATE TRIGGER kafka_requests_tombstone_trigger AFTER UPDATE ON kafka_requests
+			FOR EACH ROW EXECUTE PROCEDURE kafka_requests_tombstone_trigger();
+		`, `
+			DROP TRIGGER IF EXISTS kafka_requests_tombstone_trigger ON kafka_requests
+		`),
+	)
+}
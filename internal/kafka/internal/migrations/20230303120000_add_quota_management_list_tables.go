@@ -0,0 +1,45 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addQuotaManagementListTables() *gormigrate.Migration {
+	type QuotaManagementListOrganisation struct {
+		OrganisationId         string `gorm:"primaryKey"`
+		AnyUser                bool
+		MaxAllowedInstances    int
+		RegisteredUsers        string `gorm:"type:jsonb"`
+		GrantedQuota           string `gorm:"type:jsonb"`
+		ManagedByConfiguration bool
+		CreatedAt              time.Time
+		UpdatedAt              time.Time
+	}
+
+	type QuotaManagementListServiceAccount struct {
+		Username               string `gorm:"primaryKey"`
+		MaxAllowedInstances    int
+		GrantedQuota           string `gorm:"type:jsonb"`
+		ManagedByConfiguration bool
+		CreatedAt              time.Time
+		UpdatedAt              time.Time
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230303120000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&QuotaManagementListOrganisation{}, &QuotaManagementListServiceAccount{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&QuotaManagementListOrganisation{}, &QuotaManagementListServiceAccount{})
+		},
+	}
+}
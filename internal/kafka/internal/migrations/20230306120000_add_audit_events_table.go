@@ -0,0 +1,38 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addAuditEventsTable() *gormigrate.Migration {
+	type AuditEvent struct {
+		ID          string    `gorm:"primaryKey"`
+		CreatedAt   time.Time `gorm:"index"`
+		Actor       string    `gorm:"index"`
+		OrgId       string    `gorm:"index"`
+		Method      string
+		RouteName   string
+		Path        string
+		TargetId    string `gorm:"index"`
+		StatusCode  int
+		RequestBody string
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230306120000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&AuditEvent{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&AuditEvent{})
+		},
+	}
+}
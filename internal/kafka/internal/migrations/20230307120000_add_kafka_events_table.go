@@ -0,0 +1,37 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addKafkaEventsTable() *gormigrate.Migration {
+	type KafkaEvent struct {
+		ID              string    `gorm:"primaryKey"`
+		KafkaId         string    `gorm:"index"`
+		CreatedAt       time.Time `gorm:"index"`
+		Type            string
+		PreviousStatus  string
+		Status          string
+		FailedReason    string
+		PromotionStatus string
+		Details         string
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230307120000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&KafkaEvent{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&KafkaEvent{})
+		},
+	}
+}
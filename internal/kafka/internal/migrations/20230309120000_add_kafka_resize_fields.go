@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addKafkaResizeFields() *gormigrate.Migration {
+	type KafkaRequest struct {
+		DesiredSizeId        string
+		ResizeStatus         string
+		ResizeDetails        string
+		ResizeSubscriptionId string
+	}
+	leaderLeaseType := "resizing_kafka"
+
+	return &gormigrate.Migration{
+		ID: "20230309120000",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&KafkaRequest{}); err != nil {
+				return err
+			}
+			return tx.Create(&api.LeaderLease{Expires: &db.KafkaAdditionalLeasesExpireTime, LeaseType: leaderLeaseType, Leader: api.NewID()}).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Unscoped().Where("lease_type = ?", leaderLeaseType).Delete(&api.LeaderLease{}).Error; err != nil {
+				return err
+			}
+			for _, column := range []string{"desired_size_id", "resize_status", "resize_details", "resize_subscription_id"} {
+				if tx.Migrator().HasColumn(&KafkaRequest{}, column) {
+					if err := tx.Migrator().DropColumn(&KafkaRequest{}, column); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
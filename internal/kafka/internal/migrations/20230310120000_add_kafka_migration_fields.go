@@ -0,0 +1,137 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addKafkaMigrationFields() *gormigrate.Migration {
+	type KafkaRequest struct {
+		MigrationStatus          string
+		MigrationSourceClusterId string `gorm:"index"`
+		MigrationTargetClusterId string `gorm:"index"`
+		MigrationRoutes          string `gorm:"type:jsonb"`
+		MigrationRoutesChangeId  string
+		MigrationDetails         string
+	}
+	leaderLeaseType := "migrating_kafka"
+
+	// the data plane clusters a kafka is migrated from and to are notified of the changes of the kafka as well
+	versionTriggerFunction := `
+		CREATE OR REPLACE FUNCTION kafka_requests_version_trigger() RETURNS TRIGGER LANGUAGE plpgsql AS '
+		BEGIN
+		NEW.version := nextval(''kafka_requests_version_seq'');
+		IF NEW.cluster_id <> '''' THEN
+			PERFORM pg_notify(''signalbus'', ''/agent-clusters/'' || NEW.cluster_id || ''/kafkas'');
+		END IF;
+		IF TG_OP = ''UPDATE'' AND OLD.cluster_id <> '''' AND OLD.cluster_id IS DISTINCT FROM NEW.cluster_id THEN
+			PERFORM pg_notify(''signalbus'', ''/agent-clusters/'' || OLD.cluster_id || ''/kafkas'');
+		END IF;
+		IF NEW.migration_source_cluster_id <> '''' THEN
+			PERFORM pg_notify(''signalbus'', ''/agent-clusters/'' || NEW.migration_source_cluster_id || ''/kafkas'');
+		END IF;
+		IF NEW.migration_target_cluster_id <> '''' THEN
+			PERFORM pg_notify(''signalbus'', ''/agent-clusters/'' || NEW.migration_target_cluster_id || ''/kafkas'');
+		END IF;
+		RETURN NEW;
+		END;'
+	`
+	previousVersionTriggerFunction := `
+		CREATE OR REPLACE FUNCTION kafka_requests_version_trigger() RETURNS TRIGGER LANGUAGE plpgsql AS '
+		BEGIN
+		NEW.version := nextval(''kafka_requests_version_seq'');
+		IF NEW.cluster_id <> '''' THEN
+			PERFORM pg_notify(''signalbus'', ''/agent-clusters/'' || NEW.cluster_id || ''/kafkas'');
+		END IF;
+		IF TG_OP = ''UPDATE'' AND OLD.cluster_id <> '''' AND OLD.cluster_id IS DISTINCT FROM NEW.cluster_id THEN
+			PERFORM pg_notify(''signalbus'', ''/agent-clusters/'' || OLD.cluster_id || ''/kafkas'');
+		END IF;
+		RETURN NEW;
+		END;'
+	`
+
+	// a kafka leaves the data plane clusters it is migrated from and to as well once it is no longer deployed on them
+	tombstoneTriggerFunction := `
+		CREATE OR REPLACE FUNCTION kafka_requests_tombstone_trigger() RETURNS TRIGGER LANGUAGE plpgsql AS '
+		DECLARE
+		managed_statuses text[] := ARRAY[''provisioning'', ''deprovision'', ''ready'', ''failed'', ''suspended'', ''suspending'', ''resuming''];
+		old_cluster_id text;
+		BEGIN
+		IF OLD.deleted_at IS NOT NULL OR OLD.bootstrap_server_host = '''' OR NOT (OLD.status = ANY(managed_statuses)) THEN
+			RETURN NULL;
+		END IF;
+		FOREACH old_cluster_id IN ARRAY ARRAY[OLD.cluster_id, OLD.migration_source_cluster_id, OLD.migration_target_cluster_id] LOOP
+			CONTINUE WHEN old_cluster_id IS NULL OR old_cluster_id = '''';
+			CONTINUE WHEN NEW.deleted_at IS NULL AND NEW.bootstrap_server_host <> '''' AND NEW.status = ANY(managed_statuses)
+				AND old_cluster_id IN (NEW.cluster_id, NEW.migration_source_cluster_id, NEW.migration_target_cluster_id);
+			INSERT INTO kafka_tombstones (kafka_id, cluster_id, version, name, namespace, created_at)
+				VALUES (NEW.id, old_cluster_id, NEW.version, NEW.name, NEW.namespace, now())
+				ON CONFLICT DO NOTHING;
+			PERFORM pg_notify(''signalbus'', ''/agent-clusters/'' || old_cluster_id || ''/kafkas'');
+		END LOOP;
+		RETURN NULL;
+		END;'
+	`
+	previousTombstoneTriggerFunction := `
+		CREATE OR REPLACE FUNCTION kafka_requests_tombstone_trigger() RETURNS TRIGGER LANGUAGE plpgsql AS '
+		DECLARE
+		managed_statuses text[] := ARRAY[''provisioning'', ''deprovision'', ''ready'', ''failed'', ''suspended'', ''suspending'', ''resuming''];
+		BEGIN
+		IF OLD.deleted_at IS NOT NULL OR OLD.bootstrap_server_host = '''' OR NOT (OLD.status = ANY(managed_statuses))
+			OR OLD.cluster_id IS NULL OR OLD.cluster_id = '''' THEN
+			RETURN NULL;
+		END IF;
+		IF NEW.deleted_at IS NULL AND NEW.bootstrap_server_host <> '''' AND NEW.status = ANY(managed_statuses)
+			AND NEW.cluster_id = OLD.cluster_id THEN
+			RETURN NULL;
+		END IF;
+		INSERT INTO kafka_tombstones (kafka_id, cluster_id, version, name, namespace, created_at)
+			VALUES (NEW.id, OLD.cluster_id, NEW.version, NEW.name, NEW.namespace, now())
+			ON CONFLICT DO NOTHING;
+		PERFORM pg_notify(''signalbus'', ''/agent-clusters/'' || OLD.cluster_id || ''/kafkas'');
+		RETURN NULL;
+		END;'
+	`
+
+	return &gormigrate.Migration{
+		ID: "20230310120000",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&KafkaRequest{}); err != nil {
+				return err
+			}
+			if err := tx.Exec(versionTriggerFunction).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(tombstoneTriggerFunction).Error; err != nil {
+				return err
+			}
+			return tx.Create(&api.LeaderLease{Expires: &db.KafkaAdditionalLeasesExpireTime, LeaseType: leaderLeaseType, Leader: api.NewID()}).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Unscoped().Where("lease_type = ?", leaderLeaseType).Delete(&api.LeaderLease{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(previousVersionTriggerFunction).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(previousTombstoneTriggerFunction).Error; err != nil {
+				return err
+			}
+			for _, column := range []string{"migration_status", "migration_source_cluster_id", "migration_target_cluster_id", "migration_routes", "migration_routes_change_id", "migration_details"} {
+				if tx.Migrator().HasColumn(&KafkaRequest{}, column) {
+					if err := tx.Migrator().DropColumn(&KafkaRequest{}, column); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
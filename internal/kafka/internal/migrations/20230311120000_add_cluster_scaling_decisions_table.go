@@ -0,0 +1,44 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addClusterScalingDecisionsTable() *gormigrate.Migration {
+	type Cluster struct {
+		CordonedAt *time.Time
+	}
+
+	type ClusterScalingDecision struct {
+		ID        string    `gorm:"primaryKey"`
+		ClusterId string    `gorm:"index"`
+		CreatedAt time.Time `gorm:"index"`
+		Decision  string
+		Reason    string
+		DryRun    bool
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230311120000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Cluster{}, &ClusterScalingDecision{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable(&ClusterScalingDecision{}); err != nil {
+				return err
+			}
+			if tx.Migrator().HasColumn(&Cluster{}, "cordoned_at") {
+				return tx.Migrator().DropColumn(&Cluster{}, "cordoned_at")
+			}
+			return nil
+		},
+	}
+}
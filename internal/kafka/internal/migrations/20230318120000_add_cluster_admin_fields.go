@@ -0,0 +1,37 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addClusterAdminFields() *gormigrate.Migration {
+	type Cluster struct {
+		ManuallyCordoned              bool `gorm:"default:false"`
+		ResourcesReconcileRequestedAt *time.Time
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230318120000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Cluster{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, column := range []string{"manually_cordoned", "resources_reconcile_requested_at"} {
+				if tx.Migrator().HasColumn(&Cluster{}, column) {
+					if err := tx.Migrator().DropColumn(&Cluster{}, column); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,60 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addMaintenanceWindowFields() *gormigrate.Migration {
+	type KafkaRequest struct {
+		MaintenanceWindowDay       string
+		MaintenanceWindowStartHour int  `gorm:"default:0"`
+		UpgradeForced              bool `gorm:"default:false"`
+		UpgradeReleasedAt          *time.Time
+	}
+
+	type MaintenanceWindow struct {
+		ID             string `gorm:"primaryKey"`
+		OrganisationId string `gorm:"uniqueIndex"`
+		DayOfWeek      string
+		StartHour      int
+		CreatedAt      time.Time
+		UpdatedAt      time.Time
+	}
+	leaderLeaseType := "kafka_maintenance_window"
+
+	return &gormigrate.Migration{
+		ID: "20230320120000",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&KafkaRequest{}, &MaintenanceWindow{}); err != nil {
+				return err
+			}
+			return tx.Create(&api.LeaderLease{Expires: &db.KafkaAdditionalLeasesExpireTime, LeaseType: leaderLeaseType, Leader: api.NewID()}).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Unscoped().Where("lease_type = ?", leaderLeaseType).Delete(&api.LeaderLease{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Migrator().DropTable(&MaintenanceWindow{}); err != nil {
+				return err
+			}
+			for _, column := range []string{"maintenance_window_day", "maintenance_window_start_hour", "upgrade_forced", "upgrade_released_at"} {
+				if tx.Migrator().HasColumn(&KafkaRequest{}, column) {
+					if err := tx.Migrator().DropColumn(&KafkaRequest{}, column); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,64 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addUpgradeCampaignsTables() *gormigrate.Migration {
+	type UpgradeCampaign struct {
+		ID               string `gorm:"primaryKey"`
+		CreatedAt        time.Time
+		UpdatedAt        time.Time
+		DeletedAt        gorm.DeletedAt `gorm:"index"`
+		Name             string
+		StrimziVersion   string
+		KafkaVersion     string
+		KafkaIBPVersion  string
+		KafkaSelector    string
+		ClusterSelector  string
+		BatchSize        int
+		FailureThreshold int
+		Status           string `gorm:"index"`
+		StatusReason     string
+		CurrentWave      int `gorm:"default:0"`
+	}
+
+	type UpgradeCampaignKafka struct {
+		ID         string `gorm:"primaryKey"`
+		CampaignId string `gorm:"uniqueIndex:idx_upgrade_campaign_kafkas_campaign_id_kafka_id"`
+		KafkaId    string `gorm:"uniqueIndex:idx_upgrade_campaign_kafkas_campaign_id_kafka_id"`
+		ClusterId  string
+		Wave       int
+		Status     string
+		Reason     string
+		CreatedAt  time.Time
+		UpdatedAt  time.Time
+	}
+	leaderLeaseType := "upgrade_campaign"
+
+	return &gormigrate.Migration{
+		ID: "20230322120000",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&UpgradeCampaign{}, &UpgradeCampaignKafka{}); err != nil {
+				return err
+			}
+			return tx.Create(&api.LeaderLease{Expires: &db.KafkaAdditionalLeasesExpireTime, LeaseType: leaderLeaseType, Leader: api.NewID()}).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Unscoped().Where("lease_type = ?", leaderLeaseType).Delete(&api.LeaderLease{}).Error; err != nil {
+				return err
+			}
+			return tx.Migrator().DropTable(&UpgradeCampaignKafka{}, &UpgradeCampaign{})
+		},
+	}
+}
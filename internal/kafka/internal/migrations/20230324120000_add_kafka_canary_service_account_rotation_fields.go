@@ -0,0 +1,47 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addKafkaCanaryServiceAccountRotationFields() *gormigrate.Migration {
+	type KafkaRequest struct {
+		PendingCanaryServiceAccountClientID string
+		PendingCanaryServiceAccountReady    bool `gorm:"default:false"`
+		CanaryServiceAccountRotatedAt       *time.Time
+	}
+	leaderLeaseType := "canary_service_account_rotation"
+
+	return &gormigrate.Migration{
+		ID: "20230324120000",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&KafkaRequest{}); err != nil {
+				return err
+			}
+			return tx.Create(&api.LeaderLease{Expires: &db.KafkaAdditionalLeasesExpireTime, LeaseType: leaderLeaseType, Leader: api.NewID()}).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Unscoped().Where("lease_type = ?", leaderLeaseType).Delete(&api.LeaderLease{}).Error; err != nil {
+				return err
+			}
+			for _, column := range []string{"pending_canary_service_account_client_id", "pending_canary_service_account_ready", "canary_service_account_rotated_at"} {
+				if tx.Migrator().HasColumn(&KafkaRequest{}, column) {
+					if err := tx.Migrator().DropColumn(&KafkaRequest{}, column); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,33 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addTLSCertificateStorageItemsTable() *gormigrate.Migration {
+	type TLSCertificateStorageItem struct {
+		ID        string `gorm:"primaryKey"`
+		CreatedAt time.Time
+		UpdatedAt time.Time
+		Key       string `gorm:"uniqueIndex;not null"`
+		Value     string
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230331120000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&TLSCertificateStorageItem{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&TLSCertificateStorageItem{})
+		},
+	}
+}
@@ -0,0 +1,41 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addKafkaTLSCertificatesTable() *gormigrate.Migration {
+	type KafkaTLSCertificate struct {
+		ID                   string `gorm:"primaryKey"`
+		CreatedAt            time.Time
+		UpdatedAt            time.Time
+		Domain               string `gorm:"uniqueIndex;not null"`
+		TLSCertRef           string
+		TLSKeyRef            string
+		Subject              string
+		Issuer               string
+		SerialNumber         string
+		NotBefore            *time.Time
+		NotAfter             *time.Time `gorm:"index"`
+		LastRenewalAttemptAt *time.Time
+		LastRenewalError     string
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230401120000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&KafkaTLSCertificate{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&KafkaTLSCertificate{})
+		},
+	}
+}
@@ -0,0 +1,30 @@
+package migrations
+
+// Migrations should NEVER use types from other packages. Types can change
+// and then migrations run on a _new_ database will fail or behave unexpectedly.
+// Instead of importing types, always re-create the type in the migration, as
+// is done here, even though the same type is defined in pkg/api
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func addUpgradeCampaignFailedKafkasAtResumeField() *gormigrate.Migration {
+	type UpgradeCampaign struct {
+		FailedKafkasAtResume int `gorm:"default:0"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "20230402120000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&UpgradeCampaign{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&UpgradeCampaign{}, "failed_kafkas_at_resume") {
+				return tx.Migrator().DropColumn(&UpgradeCampaign{}, "failed_kafkas_at_resume")
+			}
+			return nil
+		},
+	}
+}
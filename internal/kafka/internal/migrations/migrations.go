@@ -98,13 +98,45 @@ var migrations = []*gormigrate.Migration{
 	updateExpiresAtZeroValueFromKafkaRequests(),
 	renameKafkaStorageSizeColumn(),
 	addKafkaDomainCertificateManagementInfoInKafkaRequestsTable(),
+	addKafkaSuspensionInfoInKafkaRequestsTable(),
+	addVersionInKafkaRequestsTable(),
+	addKafkaTombstonesTable(),
+	addQuotaManagementListTables(),
+	addAuditEventsTable(),
+	addKafkaEventsTable(),
+	addWebhookTables(),
+	addKafkaResizeFields(),
+	addKafkaMigrationFields(),
+	addClusterScalingDecisionsTable(),
+	addClusterAdminFields(),
+	addMaintenanceWindowFields(),
+	addUpgradeCampaignsTables(),
+	addKafkaCanaryServiceAccountRotationFields(),
+	addKafkaDNSDriftWorkerInLeaderLeases(),
+	addTLSCertificateStorageItemsTable(),
+	addKafkaTLSCertificatesTable(),
+	addKafkaTLSCertificateRenewalWorkerInLeaderLeases(),
+	addUpgradeCampaignFailedKafkasAtResumeField(),
+}
+
+// encryptedColumns are the columns holding secrets, they are mapped to encryption.EncryptedString fields
+var encryptedColumns = []db.EncryptedColumn{
+	{Table: "clusters", Column: "client_secret"},
+	{Table: "kafka_requests", Column: "canary_service_account_client_secret"},
+	{Table: "webhook_subscriptions", Column: "secret"},
+	{Table: "tls_certificate_storage_items", Column: "value"},
 }
 
 func New(dbConfig *db.DatabaseConfig) (*db.Migration, func(), error) {
-	return db.NewMigration(dbConfig, &gormigrate.Options{
+	migration, cleanup, err := db.NewMigration(dbConfig, &gormigrate.Options{
 		TableName:      "migrations",
 		IDColumnName:   "id",
 		IDColumnSize:   255,
 		UseTransaction: false,
 	}, migrations)
+	if err != nil {
+		return nil, nil, err
+	}
+	migration.EncryptedColumns = encryptedColumns
+	return migration, cleanup, nil
 }
@@ -0,0 +1,43 @@
+package presenters
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+)
+
+func PresentAdminCluster(cluster *api.Cluster, kafkaInstanceCount int) private.Cluster {
+	reference := PresentReference(cluster.ClusterID, cluster)
+
+	dynamicCapacityInfo := map[string]private.ClusterDynamicCapacityInfo{}
+	for instanceType, capacityInfo := range cluster.RetrieveDynamicCapacityInfo() {
+		dynamicCapacityInfo[instanceType] = private.ClusterDynamicCapacityInfo{
+			MaxNodes:       capacityInfo.MaxNodes,
+			MaxUnits:       capacityInfo.MaxUnits,
+			RemainingUnits: capacityInfo.RemainingUnits,
+		}
+	}
+
+	return private.Cluster{
+		Id:                            reference.Id,
+		Kind:                          reference.Kind,
+		Href:                          reference.Href,
+		ClusterId:                     cluster.ClusterID,
+		ExternalId:                    cluster.ExternalID,
+		CloudProvider:                 cluster.CloudProvider,
+		Region:                        cluster.Region,
+		MultiAz:                       cluster.MultiAZ,
+		Status:                        cluster.Status.String(),
+		ClusterType:                   cluster.ClusterType,
+		ProviderType:                  cluster.ProviderType.String(),
+		OrganizationId:                cluster.OrganizationID,
+		SupportedInstanceType:         cluster.SupportedInstanceType,
+		ClusterDns:                    cluster.ClusterDNS,
+		DynamicCapacityInfo:           dynamicCapacityInfo,
+		KafkaInstanceCount:            int32(kafkaInstanceCount),
+		CordonedAt:                    cluster.CordonedAt,
+		ManuallyCordoned:              cluster.ManuallyCordoned,
+		ResourcesReconcileRequestedAt: cluster.ResourcesReconcileRequestedAt,
+		CreatedAt:                     cluster.CreatedAt,
+		UpdatedAt:                     cluster.UpdatedAt,
+	}
+}
@@ -0,0 +1,79 @@
+package presenters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/onsi/gomega"
+)
+
+func Test_PresentAdminCluster(t *testing.T) {
+	cordonedAt := time.Now()
+
+	tests := []struct {
+		name               string
+		cluster            *api.Cluster
+		kafkaInstanceCount int
+		want               private.Cluster
+	}{
+		{
+			name: "should present a data plane cluster without capacity information",
+			cluster: &api.Cluster{
+				ClusterID:     clusterId,
+				Status:        api.ClusterProvisioning,
+				CloudProvider: "aws",
+				Region:        "us-east-1",
+				ProviderType:  api.ClusterProviderOCM,
+				ClusterType:   api.ManagedDataPlaneClusterType.String(),
+			},
+			want: private.Cluster{
+				Id:                  clusterId,
+				Kind:                KindCluster,
+				Href:                "/api/kafkas_mgmt/v1/clusters/" + clusterId,
+				ClusterId:           clusterId,
+				Status:              api.ClusterProvisioning.String(),
+				CloudProvider:       "aws",
+				Region:              "us-east-1",
+				ProviderType:        api.ClusterProviderOCM.String(),
+				ClusterType:         api.ManagedDataPlaneClusterType.String(),
+				DynamicCapacityInfo: map[string]private.ClusterDynamicCapacityInfo{},
+			},
+		},
+		{
+			name: "should present a cordoned data plane cluster with its kafkas and its capacity",
+			cluster: &api.Cluster{
+				ClusterID:           clusterId,
+				Status:              api.ClusterReady,
+				MultiAZ:             true,
+				DynamicCapacityInfo: api.JSON([]byte(`{"standard":{"max_nodes":3,"max_units":5,"remaining_units":2}}`)),
+				CordonedAt:          &cordonedAt,
+				ManuallyCordoned:    true,
+			},
+			kafkaInstanceCount: 3,
+			want: private.Cluster{
+				Id:        clusterId,
+				Kind:      KindCluster,
+				Href:      "/api/kafkas_mgmt/v1/clusters/" + clusterId,
+				ClusterId: clusterId,
+				Status:    api.ClusterReady.String(),
+				MultiAz:   true,
+				DynamicCapacityInfo: map[string]private.ClusterDynamicCapacityInfo{
+					"standard": {MaxNodes: 3, MaxUnits: 5, RemainingUnits: 2},
+				},
+				KafkaInstanceCount: 3,
+				CordonedAt:         &cordonedAt,
+				ManuallyCordoned:   true,
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(PresentAdminCluster(tt.cluster, tt.kafkaInstanceCount)).To(gomega.Equal(tt.want))
+		})
+	}
+}
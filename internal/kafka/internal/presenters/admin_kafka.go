@@ -67,6 +67,10 @@ func PresentKafkaRequestAdminEndpoint(kafkaRequest *dbapi.KafkaRequest, accountS
 		MaxDataRetentionSize: private.SupportedKafkaSizeBytesValueItem{
 			Bytes: maxDataRetentionSizeBytes,
 		},
+		MigrationStatus:          kafkaRequest.MigrationStatus.String(),
+		MigrationSourceClusterId: kafkaRequest.MigrationSourceClusterId,
+		MigrationTargetClusterId: kafkaRequest.MigrationTargetClusterId,
+		MigrationDetails:         kafkaRequest.MigrationDetails,
 	}, nil
 }
 
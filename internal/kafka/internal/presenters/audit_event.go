@@ -0,0 +1,25 @@
+package presenters
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+)
+
+func PresentAuditEvent(event *api.AuditEvent) private.AuditEvent {
+	reference := PresentReference(event.ID, event)
+
+	return private.AuditEvent{
+		Id:          reference.Id,
+		Kind:        reference.Kind,
+		Href:        reference.Href,
+		CreatedAt:   event.CreatedAt,
+		Actor:       event.Actor,
+		OrgId:       event.OrgId,
+		Method:      event.Method,
+		RouteName:   event.RouteName,
+		Path:        event.Path,
+		TargetId:    event.TargetId,
+		StatusCode:  int32(event.StatusCode),
+		RequestBody: event.RequestBody,
+	}
+}
@@ -55,36 +55,57 @@ func PresentEnterpriseClusterWithAddonParams(cluster api.Cluster, fleetShardPara
 	return c, nil
 }
 
-func PresentEnterpriseCluster(cluster api.Cluster, consumedStreamingUnitsInTheCluster int32, kafkaConfig *config.KafkaConfig) (public.EnterpriseCluster, error) {
+func PresentEnterpriseCluster(cluster api.Cluster, consumedStreamingUnits services.StreamingUnitCountPerInstanceType, kafkaConfig *config.KafkaConfig) (public.EnterpriseCluster, error) {
 	reference := PresentReference(cluster.ClusterID, cluster)
 	presentedCluster := public.EnterpriseCluster{
-		Id:                            cluster.ClusterID,
-		Status:                        cluster.Status.String(),
-		ClusterId:                     cluster.ClusterID,
-		Kind:                          reference.Kind,
-		Href:                          reference.Href,
-		CloudProvider:                 cluster.CloudProvider,
-		Region:                        cluster.Region,
-		MultiAz:                       cluster.MultiAZ,
-		AccessKafkasViaPrivateNetwork: cluster.AccessKafkasViaPrivateNetwork,
-		SupportedInstanceTypes:        public.SupportedKafkaInstanceTypesList{},
+		Id:                               cluster.ClusterID,
+		Status:                           cluster.Status.String(),
+		ClusterId:                        cluster.ClusterID,
+		Kind:                             reference.Kind,
+		Href:                             reference.Href,
+		CloudProvider:                    cluster.CloudProvider,
+		Region:                           cluster.Region,
+		MultiAz:                          cluster.MultiAZ,
+		AccessKafkasViaPrivateNetwork:    cluster.AccessKafkasViaPrivateNetwork,
+		SupportedInstanceTypes:           public.SupportedKafkaInstanceTypesList{},
+		InstanceTypesCapacityInformation: []public.EnterpriseClusterInstanceTypeCapacityInformation{},
 	}
 
-	// enterprise clusters only supports standard instance type for now. It is safe to hardcode this.
-	storedCapacityInfo, ok := cluster.RetrieveDynamicCapacityInfo()[types.STANDARD.String()]
-	if ok {
-		presentedCluster.CapacityInformation = presentEnterpriseClusterCapacityInfo(consumedStreamingUnitsInTheCluster, storedCapacityInfo)
-		supportedInstanceTypes, err := presentEnterpriseClusterSupportedInstanceTypes(kafkaConfig)
-		if err != nil {
+	supportedInstanceTypes := cluster.GetSupportedInstanceTypes()
+	// enterprise clusters registered before the instance types could be chosen only support the standard instance type
+	if len(supportedInstanceTypes) == 0 {
+		supportedInstanceTypes = []string{types.STANDARD.String()}
+	}
+
+	dynamicCapacityInfo := cluster.RetrieveDynamicCapacityInfo()
+	for _, instanceType := range supportedInstanceTypes {
+		storedCapacityInfo, ok := dynamicCapacityInfo[instanceType]
+		if !ok { // this should never happen, let's log an error in case it happens
+			err := fmt.Errorf("cluster %q is missing capacity information of instance type %q", cluster.ID, instanceType)
+			logger.Logger.Error(err)
 			return public.EnterpriseCluster{}, err
 		}
 
-		presentedCluster.SupportedInstanceTypes = supportedInstanceTypes
-	} else { // this should never happen, let's log an error in case it happens
-		err := fmt.Errorf("cluster %q is missing capacity information", cluster.ID)
-		logger.Logger.Error(err)
+		consumedStreamingUnitsOfInstanceType := int32(consumedStreamingUnits[types.KafkaInstanceType(instanceType)])
+		capacityInfo := presentEnterpriseClusterCapacityInfo(consumedStreamingUnitsOfInstanceType, storedCapacityInfo)
+		presentedCluster.InstanceTypesCapacityInformation = append(presentedCluster.InstanceTypesCapacityInformation, public.EnterpriseClusterInstanceTypeCapacityInformation{
+			InstanceType:                 instanceType,
+			KafkaMachinePoolNodeCount:    capacityInfo.KafkaMachinePoolNodeCount,
+			MaximumKafkaStreamingUnits:   capacityInfo.MaximumKafkaStreamingUnits,
+			RemainingKafkaStreamingUnits: capacityInfo.RemainingKafkaStreamingUnits,
+			ConsumedKafkaStreamingUnits:  capacityInfo.ConsumedKafkaStreamingUnits,
+		})
+
+		if instanceType == types.STANDARD.String() {
+			presentedCluster.CapacityInformation = capacityInfo
+		}
+	}
+
+	presentedSupportedInstanceTypes, err := presentEnterpriseClusterSupportedInstanceTypes(supportedInstanceTypes, kafkaConfig)
+	if err != nil {
 		return public.EnterpriseCluster{}, err
 	}
+	presentedCluster.SupportedInstanceTypes = presentedSupportedInstanceTypes
 
 	return presentedCluster, nil
 }
@@ -98,37 +119,45 @@ func presentEnterpriseClusterCapacityInfo(consumedStreamingUnitsInTheCluster int
 	}
 }
 
-func presentEnterpriseClusterSupportedInstanceTypes(kafkaConfig *config.KafkaConfig) (public.SupportedKafkaInstanceTypesList, error) {
-	// enterprise clusters only supports standard instance type for now. It is safe to hardcode this.
-	standardInstanceType, err := kafkaConfig.SupportedInstanceTypes.Configuration.GetKafkaInstanceTypeByID(types.STANDARD.String())
-	if err != nil { // this should never happen, lets log an error in case it happens.
-		logger.Logger.Errorf("failed to find standard instance type from supported instance type config due to %q.", err.Error())
-		return public.SupportedKafkaInstanceTypesList{
-			InstanceTypes: []public.SupportedKafkaInstanceType{},
-		}, err
-	}
+func presentEnterpriseClusterSupportedInstanceTypes(supportedInstanceTypes []string, kafkaConfig *config.KafkaConfig) (public.SupportedKafkaInstanceTypesList, error) {
+	presentedInstanceTypes := []public.SupportedKafkaInstanceType{}
+	for _, supportedInstanceType := range supportedInstanceTypes {
+		instanceType, err := kafkaConfig.SupportedInstanceTypes.Configuration.GetKafkaInstanceTypeByID(supportedInstanceType)
+		if err != nil { // this should never happen, lets log an error in case it happens.
+			logger.Logger.Errorf("failed to find %s instance type from supported instance type config due to %q.", supportedInstanceType, err.Error())
+			return public.SupportedKafkaInstanceTypesList{
+				InstanceTypes: []public.SupportedKafkaInstanceType{},
+			}, err
+		}
 
-	// only enlist enterprise billing model as the supported billing model
-	enterpriseBillingModel, err := standardInstanceType.GetKafkaSupportedBillingModelByID(constants.BillingModelEnterprise.String())
-	if err != nil { // this should never happen, lets log an error in case it happens.
-		logger.Logger.Errorf("failed to find enterprise billing model for standard instance due to %q.", err.Error())
-		return public.SupportedKafkaInstanceTypesList{
-			InstanceTypes: []public.SupportedKafkaInstanceType{},
-		}, err
-	}
+		// only enlist enterprise billing model as the supported billing model
+		enterpriseBillingModel, err := instanceType.GetKafkaSupportedBillingModelByID(constants.BillingModelEnterprise.String())
+		if err != nil { // this should never happen, lets log an error in case it happens.
+			logger.Logger.Errorf("failed to find enterprise billing model for %s instance due to %q.", supportedInstanceType, err.Error())
+			return public.SupportedKafkaInstanceTypesList{
+				InstanceTypes: []public.SupportedKafkaInstanceType{},
+			}, err
+		}
+
+		// only enlist the sizes available to the enterprise billing model
+		enterpriseSizes := []config.KafkaInstanceSize{}
+		for _, size := range instanceType.Sizes {
+			if size.SupportsBillingModel(enterpriseBillingModel.ID) {
+				enterpriseSizes = append(enterpriseSizes, size)
+			}
+		}
 
-	presentedSizes := GetSupportedSizes(&config.KafkaInstanceType{Sizes: standardInstanceType.Sizes})
+		presentedInstanceTypes = append(presentedInstanceTypes, public.SupportedKafkaInstanceType{
+			Id:          instanceType.Id,
+			DisplayName: instanceType.DisplayName,
+			Sizes:       GetSupportedSizes(&config.KafkaInstanceType{Sizes: enterpriseSizes}),
+			SupportedBillingModels: GetSupportedBillingModels(&config.KafkaInstanceType{
+				SupportedBillingModels: []config.KafkaBillingModel{*enterpriseBillingModel},
+			}),
+		})
+	}
 
 	return public.SupportedKafkaInstanceTypesList{
-		InstanceTypes: []public.SupportedKafkaInstanceType{
-			{
-				Id:          standardInstanceType.Id,
-				DisplayName: standardInstanceType.DisplayName,
-				Sizes:       presentedSizes,
-				SupportedBillingModels: GetSupportedBillingModels(&config.KafkaInstanceType{
-					SupportedBillingModels: []config.KafkaBillingModel{*enterpriseBillingModel},
-				}),
-			},
-		},
+		InstanceTypes: presentedInstanceTypes,
 	}, nil
 }
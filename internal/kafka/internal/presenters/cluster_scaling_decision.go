@@ -0,0 +1,20 @@
+package presenters
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+)
+
+func PresentClusterScalingDecision(decision *dbapi.ClusterScalingDecision) private.ClusterScalingDecision {
+	reference := PresentReference(decision.ID, decision)
+
+	return private.ClusterScalingDecision{
+		Id:        reference.Id,
+		Kind:      reference.Kind,
+		ClusterId: decision.ClusterId,
+		CreatedAt: decision.CreatedAt,
+		Decision:  decision.Decision.String(),
+		Reason:    decision.Reason,
+		DryRun:    decision.DryRun,
+	}
+}
@@ -132,6 +132,12 @@ func Test_PresentEnterpriseCluster(t *testing.T) {
 								Id:               "x1",
 								CapacityConsumed: 1,
 							},
+							{
+								// not presented as it is not available to the enterprise billing model
+								Id:                     "x1-other",
+								CapacityConsumed:       1,
+								SupportedBillingModels: []string{"some-other-billing-model"},
+							},
 						},
 						SupportedBillingModels: []config.KafkaBillingModel{
 							{
@@ -236,6 +242,15 @@ func Test_PresentEnterpriseCluster(t *testing.T) {
 					RemainingKafkaStreamingUnits: 3,
 					ConsumedKafkaStreamingUnits:  2,
 				},
+				InstanceTypesCapacityInformation: []public.EnterpriseClusterInstanceTypeCapacityInformation{
+					{
+						InstanceType:                 types.STANDARD.String(),
+						KafkaMachinePoolNodeCount:    15,
+						MaximumKafkaStreamingUnits:   5,
+						RemainingKafkaStreamingUnits: 3,
+						ConsumedKafkaStreamingUnits:  2,
+					},
+				},
 				AccessKafkasViaPrivateNetwork: false,
 				SupportedInstanceTypes: public.SupportedKafkaInstanceTypesList{
 					InstanceTypes: []public.SupportedKafkaInstanceType{
@@ -302,6 +317,15 @@ func Test_PresentEnterpriseCluster(t *testing.T) {
 					RemainingKafkaStreamingUnits: 0,
 					ConsumedKafkaStreamingUnits:  2,
 				},
+				InstanceTypesCapacityInformation: []public.EnterpriseClusterInstanceTypeCapacityInformation{
+					{
+						InstanceType:                 types.STANDARD.String(),
+						KafkaMachinePoolNodeCount:    6,
+						MaximumKafkaStreamingUnits:   2,
+						RemainingKafkaStreamingUnits: 0,
+						ConsumedKafkaStreamingUnits:  2,
+					},
+				},
 				AccessKafkasViaPrivateNetwork: false,
 				SupportedInstanceTypes: public.SupportedKafkaInstanceTypesList{
 					InstanceTypes: []public.SupportedKafkaInstanceType{
@@ -340,6 +364,115 @@ func Test_PresentEnterpriseCluster(t *testing.T) {
 				Href: fmt.Sprintf("/api/kafkas_mgmt/v1/clusters/%s", clusterId),
 			},
 		},
+		{
+			name: "should present the capacity information and supported types of each instance type supported by the cluster",
+			args: args{
+				cluster: api.Cluster{
+					ClusterID:             clusterId,
+					Status:                status,
+					CloudProvider:         "azure",
+					Region:                "af-east",
+					MultiAZ:               true,
+					SupportedInstanceType: api.AllInstanceTypeSupport.String(),
+					DynamicCapacityInfo:   api.JSON([]byte(`{"standard":{"max_nodes":6,"max_units":2,"remaining_units":0},"developer":{"max_nodes":3,"max_units":6,"remaining_units":6}}`)),
+				},
+				kafkaConfig: validKafkaConfig,
+			},
+			want: public.EnterpriseCluster{
+				Id:            clusterId,
+				ClusterId:     clusterId,
+				Status:        status.String(),
+				Kind:          "Cluster",
+				CloudProvider: "azure",
+				Region:        "af-east",
+				MultiAz:       true,
+				CapacityInformation: public.EnterpriseClusterAllOfCapacityInformation{
+					KafkaMachinePoolNodeCount:    6,
+					MaximumKafkaStreamingUnits:   2,
+					RemainingKafkaStreamingUnits: 0,
+					ConsumedKafkaStreamingUnits:  2,
+				},
+				InstanceTypesCapacityInformation: []public.EnterpriseClusterInstanceTypeCapacityInformation{
+					{
+						InstanceType:                 types.STANDARD.String(),
+						KafkaMachinePoolNodeCount:    6,
+						MaximumKafkaStreamingUnits:   2,
+						RemainingKafkaStreamingUnits: 0,
+						ConsumedKafkaStreamingUnits:  2,
+					},
+					{
+						InstanceType:                 types.DEVELOPER.String(),
+						KafkaMachinePoolNodeCount:    3,
+						MaximumKafkaStreamingUnits:   6,
+						RemainingKafkaStreamingUnits: 6,
+						ConsumedKafkaStreamingUnits:  0,
+					},
+				},
+				SupportedInstanceTypes: public.SupportedKafkaInstanceTypesList{
+					InstanceTypes: []public.SupportedKafkaInstanceType{
+						{
+							Id: types.STANDARD.String(),
+							Sizes: []public.SupportedKafkaSize{
+								{
+									Id:               "x1",
+									CapacityConsumed: 1,
+								},
+								{
+									Id:               "x2",
+									CapacityConsumed: 2,
+								},
+								{
+									Id:               "x3",
+									CapacityConsumed: 3,
+								},
+								{
+									Id:               "x4",
+									CapacityConsumed: 4,
+								},
+								{
+									Id:               "x5",
+									CapacityConsumed: 5,
+								},
+							},
+							SupportedBillingModels: []public.SupportedKafkaBillingModel{
+								{
+									Id: constants.BillingModelEnterprise.String(),
+								},
+							},
+						},
+						{
+							Id: types.DEVELOPER.String(),
+							Sizes: []public.SupportedKafkaSize{
+								{
+									Id:               "x1",
+									CapacityConsumed: 1,
+								},
+							},
+							SupportedBillingModels: []public.SupportedKafkaBillingModel{
+								{
+									Id: constants.BillingModelEnterprise.String(),
+								},
+							},
+						},
+					},
+				},
+				Href: fmt.Sprintf("/api/kafkas_mgmt/v1/clusters/%s", clusterId),
+			},
+		},
+		{
+			name: "should return an error when the cluster is missing the capacity information of one of its instance types",
+			args: args{
+				cluster: api.Cluster{
+					ClusterID:             clusterId,
+					Status:                status,
+					SupportedInstanceType: api.AllInstanceTypeSupport.String(),
+					DynamicCapacityInfo:   api.JSON([]byte(`{"standard":{"max_nodes":6,"max_units":2,"remaining_units":0}}`)),
+				},
+				kafkaConfig: validKafkaConfig,
+			},
+			want:    public.EnterpriseCluster{},
+			wantErr: true,
+		},
 		{
 			name: "should return an error when Kafka config is missing standard instance type",
 			args: args{
@@ -397,7 +530,7 @@ func Test_PresentEnterpriseCluster(t *testing.T) {
 
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			presentedCluster, err := PresentEnterpriseCluster(tt.args.cluster, 2, tt.args.kafkaConfig)
+			presentedCluster, err := PresentEnterpriseCluster(tt.args.cluster, services.StreamingUnitCountPerInstanceType{types.STANDARD: 2}, tt.args.kafkaConfig)
 			g.Expect(presentedCluster).To(gomega.Equal(tt.want))
 			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
 		})
@@ -35,6 +35,8 @@ func ConvertDataPlaneKafkaStatus(status map[string]private.DataPlaneKafkaStatus)
 			StrimziVersion:  v.Versions.Strimzi,
 			KafkaIBPVersion: v.Versions.KafkaIbp,
 			AdminServerURI:  v.AdminServerURI,
+			CanaryPrincipal: v.Canary.Principal,
+			CanaryReady:     v.Canary.Ready,
 		})
 	}
 
@@ -50,8 +50,9 @@ func ConvertKafkaRequest(kafkaRequestPayload public.KafkaRequestPayload, dbKafka
 	return kafka
 }
 
-// PresentKafkaRequest - create KafkaRequest in an appropriate format ready to be returned by the API
-func PresentKafkaRequest(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.KafkaConfig) (public.KafkaRequest, *errors.ServiceError) {
+// PresentKafkaRequest - create KafkaRequest in an appropriate format ready to be returned by the API. The maintenance
+// window is the one applying to the kafka, nil if upgrades are rolled out as soon as they are available.
+func PresentKafkaRequest(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.KafkaConfig, maintenanceWindow *dbapi.MaintenanceWindowSchedule) (public.KafkaRequest, *errors.ServiceError) {
 	reference := PresentReference(kafkaRequest.ID, kafkaRequest)
 
 	var ingressThroughputPerSec, egressThroughputPerSec, maxDataRetentionPeriod string
@@ -87,6 +88,26 @@ func PresentKafkaRequest(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.K
 		return public.KafkaRequest{}, errors.NewWithCause(errors.ErrorGeneral, conversionErr, "failed to get bytes value for max_data_retention_size")
 	}
 
+	var maintenanceWindowDay string
+	var maintenanceWindowStartHour *int32
+	if maintenanceWindow != nil {
+		maintenanceWindowDay = maintenanceWindow.DayOfWeekName()
+		startHour := int32(maintenanceWindow.StartHour)
+		maintenanceWindowStartHour = &startHour
+	}
+
+	// the next maintenance window is only shown when an upgrade is waiting for it
+	upgradePending := kafkaRequest.HasPendingUpgrade()
+	var pendingVersion string
+	if upgradePending && kafkaRequest.ActualKafkaVersion != "" && kafkaRequest.DesiredKafkaVersion != kafkaRequest.ActualKafkaVersion {
+		pendingVersion = kafkaRequest.DesiredKafkaVersion
+	}
+	var nextMaintenanceWindowStart *time.Time
+	if upgradePending && !kafkaRequest.UpgradeForced && kafkaRequest.UpgradeReleasedAt == nil && maintenanceWindow != nil && kafkaConfig != nil {
+		nextStart := maintenanceWindow.NextStart(time.Now(), kafkaConfig.MaintenanceWindowDuration)
+		nextMaintenanceWindowStart = &nextStart
+	}
+
 	return public.KafkaRequest{
 		Id:                      reference.Id,
 		Kind:                    reference.Kind,
@@ -123,7 +144,14 @@ func PresentKafkaRequest(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.K
 		BillingModel:                          kafkaRequest.ActualKafkaBillingModel,
 		PromotionStatus:                       kafkaRequest.PromotionStatus.String(),
 		PromotionDetails:                      kafkaRequest.PromotionDetails,
+		ResizeStatus:                          kafkaRequest.ResizeStatus.String(),
+		ResizeDetails:                         kafkaRequest.ResizeDetails,
 		ClusterId:                             getClusterID(kafkaRequest),
+		MaintenanceWindowDay:                  maintenanceWindowDay,
+		MaintenanceWindowStartHour:            maintenanceWindowStartHour,
+		NextMaintenanceWindowStart:            nextMaintenanceWindowStart,
+		UpgradePending:                        upgradePending,
+		PendingVersion:                        pendingVersion,
 	}, nil
 }
 
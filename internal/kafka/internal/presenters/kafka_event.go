@@ -0,0 +1,42 @@
+package presenters
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
+)
+
+// PresentKafkaEvent presents the event to the owners of the Kafka instance. The details of the event are left out
+// as they are only intended for administrators.
+func PresentKafkaEvent(event *dbapi.KafkaEvent) public.KafkaEvent {
+	reference := PresentReference(event.ID, event)
+
+	return public.KafkaEvent{
+		Id:              reference.Id,
+		Kind:            reference.Kind,
+		KafkaId:         event.KafkaId,
+		CreatedAt:       event.CreatedAt,
+		Type:            event.Type.String(),
+		PreviousStatus:  event.PreviousStatus,
+		Status:          event.Status,
+		FailedReason:    event.FailedReason,
+		PromotionStatus: event.PromotionStatus,
+	}
+}
+
+func PresentAdminKafkaEvent(event *dbapi.KafkaEvent) private.KafkaEvent {
+	reference := PresentReference(event.ID, event)
+
+	return private.KafkaEvent{
+		Id:              reference.Id,
+		Kind:            reference.Kind,
+		KafkaId:         event.KafkaId,
+		CreatedAt:       event.CreatedAt,
+		Type:            event.Type.String(),
+		PreviousStatus:  event.PreviousStatus,
+		Status:          event.Status,
+		FailedReason:    event.FailedReason,
+		PromotionStatus: event.PromotionStatus,
+		Details:         event.Details,
+	}
+}
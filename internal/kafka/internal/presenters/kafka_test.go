@@ -275,7 +275,107 @@ func TestPresentKafkaRequest(t *testing.T) {
 
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			g.Expect(PresentKafkaRequest(tt.args.dbKafkaRequest, &tt.config)).To(gomega.Equal(tt.want))
+			g.Expect(PresentKafkaRequest(tt.args.dbKafkaRequest, &tt.config, nil)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func TestPresentKafkaRequest_MaintenanceWindow(t *testing.T) {
+	startHour := int32(2)
+	maintenanceWindow := &dbapi.MaintenanceWindowSchedule{DayOfWeek: time.Tuesday, StartHour: 2}
+	kafkaConfig := &config.KafkaConfig{
+		MaintenanceWindowDuration: time.Hour,
+		SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
+			Configuration: config.SupportedKafkaInstanceTypesConfig{
+				SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+					{
+						Id:    mocks.DefaultInstanceType,
+						Sizes: []config.KafkaInstanceSize{*mocksupportedinstancetypes.BuildKafkaInstanceSize()},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name                  string
+		kafkaRequest          *dbapi.KafkaRequest
+		maintenanceWindow     *dbapi.MaintenanceWindowSchedule
+		wantDay               string
+		wantStartHour         *int32
+		wantUpgradePending    bool
+		wantPendingVersion    string
+		wantNextWindowPresent bool
+	}{
+		{
+			name: "should not show a maintenance window when none applies to the kafka",
+			kafkaRequest: mocks.BuildKafkaRequest(
+				mocks.WithPredefinedTestValues(),
+				mocks.With(mocks.ACTUAL_KAFKA_VERSION, "2.8.0"),
+				mocks.With(mocks.DESIRED_KAFKA_VERSION, "2.8.0"),
+			),
+		},
+		{
+			name: "should show the maintenance window without the next window when no upgrade is pending",
+			kafkaRequest: mocks.BuildKafkaRequest(
+				mocks.WithPredefinedTestValues(),
+				mocks.With(mocks.ACTUAL_KAFKA_VERSION, "2.8.0"),
+				mocks.With(mocks.DESIRED_KAFKA_VERSION, "2.8.0"),
+			),
+			maintenanceWindow: maintenanceWindow,
+			wantDay:           "tuesday",
+			wantStartHour:     &startHour,
+		},
+		{
+			name: "should show the pending upgrade and the next maintenance window",
+			kafkaRequest: mocks.BuildKafkaRequest(
+				mocks.WithPredefinedTestValues(),
+				mocks.With(mocks.ACTUAL_KAFKA_VERSION, "2.8.0"),
+				mocks.With(mocks.DESIRED_KAFKA_VERSION, "3.0.0"),
+			),
+			maintenanceWindow:     maintenanceWindow,
+			wantDay:               "tuesday",
+			wantStartHour:         &startHour,
+			wantUpgradePending:    true,
+			wantPendingVersion:    "3.0.0",
+			wantNextWindowPresent: true,
+		},
+		{
+			name: "should not show the next maintenance window when the upgrade is forced",
+			kafkaRequest: mocks.BuildKafkaRequest(
+				mocks.WithPredefinedTestValues(),
+				mocks.With(mocks.ACTUAL_KAFKA_VERSION, "2.8.0"),
+				mocks.With(mocks.DESIRED_KAFKA_VERSION, "3.0.0"),
+				func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.UpgradeForced = true
+				},
+			),
+			maintenanceWindow:  maintenanceWindow,
+			wantDay:            "tuesday",
+			wantStartHour:      &startHour,
+			wantUpgradePending: true,
+			wantPendingVersion: "3.0.0",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			kafkaRequest, err := PresentKafkaRequest(tt.kafkaRequest, kafkaConfig, tt.maintenanceWindow)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(kafkaRequest.MaintenanceWindowDay).To(gomega.Equal(tt.wantDay))
+			g.Expect(kafkaRequest.MaintenanceWindowStartHour).To(gomega.Equal(tt.wantStartHour))
+			g.Expect(kafkaRequest.UpgradePending).To(gomega.Equal(tt.wantUpgradePending))
+			g.Expect(kafkaRequest.PendingVersion).To(gomega.Equal(tt.wantPendingVersion))
+			if tt.wantNextWindowPresent {
+				g.Expect(kafkaRequest.NextMaintenanceWindowStart).ToNot(gomega.BeNil())
+				g.Expect(kafkaRequest.NextMaintenanceWindowStart.Weekday()).To(gomega.Equal(time.Tuesday))
+				g.Expect(kafkaRequest.NextMaintenanceWindowStart.Hour()).To(gomega.Equal(2))
+			} else {
+				g.Expect(kafkaRequest.NextMaintenanceWindowStart).To(gomega.BeNil())
+			}
 		})
 	}
 }
@@ -470,7 +570,7 @@ func TestCapacityLimitReports(t *testing.T) {
 		test := testcase
 		t.Run(test.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			kafkaRequest, err := PresentKafkaRequest(&test.request, &test.config)
+			kafkaRequest, err := PresentKafkaRequest(&test.request, &test.config, nil)
 			if !test.errExpected {
 				if !test.negative {
 					g.Expect(kafkaRequest.DeprecatedIngressThroughputPerSec).ToNot(gomega.BeNil())
@@ -0,0 +1,25 @@
+package presenters
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+)
+
+func PresentKafkaTLSCertificate(certificate *dbapi.KafkaTLSCertificate) private.KafkaTlsCertificate {
+	reference := PresentReference(certificate.ID, certificate)
+
+	return private.KafkaTlsCertificate{
+		Id:                   reference.Id,
+		Kind:                 reference.Kind,
+		Href:                 reference.Href,
+		Domain:               certificate.Domain,
+		Subject:              certificate.Subject,
+		Issuer:               certificate.Issuer,
+		SerialNumber:         certificate.SerialNumber,
+		NotBefore:            certificate.NotBefore,
+		NotAfter:             certificate.NotAfter,
+		LastRenewalAttemptAt: certificate.LastRenewalAttemptAt,
+		LastRenewalError:     certificate.LastRenewalError,
+		UpdatedAt:            certificate.UpdatedAt,
+	}
+}
@@ -0,0 +1,22 @@
+package presenters
+
+import (
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
+)
+
+func ConvertMaintenanceWindow(maintenanceWindow public.MaintenanceWindow) *dbapi.MaintenanceWindow {
+	return &dbapi.MaintenanceWindow{
+		DayOfWeek: strings.ToLower(maintenanceWindow.DayOfWeek),
+		StartHour: int(maintenanceWindow.StartHour),
+	}
+}
+
+func PresentMaintenanceWindow(maintenanceWindow *dbapi.MaintenanceWindow) public.MaintenanceWindow {
+	return public.MaintenanceWindow{
+		DayOfWeek: maintenanceWindow.DayOfWeek,
+		StartHour: int32(maintenanceWindow.StartHour),
+	}
+}
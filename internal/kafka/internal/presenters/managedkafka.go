@@ -1,17 +1,23 @@
 package presenters
 
 import (
+	"strconv"
+
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/private"
 	v1 "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api/managedkafkas.managedkafka.bf2.org/v1"
 )
 
 func PresentManagedKafka(from *v1.ManagedKafka) private.ManagedKafka {
+	// reserved managed kafkas are not stored in the database and have no resource version
+	resourceVersion, _ := strconv.ParseInt(from.ResourceVersion, 10, 64)
+
 	res := private.ManagedKafka{
 		Id:   from.Annotations["bf2.org/id"],
 		Kind: from.Kind,
 		Metadata: private.ManagedKafkaAllOfMetadata{
-			Name:      from.Name,
-			Namespace: from.Namespace,
+			Name:            from.Name,
+			Namespace:       from.Namespace,
+			ResourceVersion: resourceVersion,
 			Annotations: private.ManagedKafkaAllOfMetadataAnnotations{
 				Bf2OrgId:          from.Annotations["bf2.org/id"],
 				Bf2OrgPlacementId: from.Annotations["bf2.org/placementId"],
@@ -8,6 +8,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/quota_management"
 )
 
 const (
@@ -24,6 +25,25 @@ const (
 
 	KindCluster = "Cluster"
 
+	// KindQuotaManagementOrganisation is a string identifier for the type quota_management.Organisation
+	KindQuotaManagementOrganisation = "QuotaManagementOrganisation"
+	// KindQuotaManagementServiceAccount is a string identifier for the type quota_management.Account
+	KindQuotaManagementServiceAccount = "QuotaManagementServiceAccount"
+	// KindAuditEvent is a string identifier for the type api.AuditEvent
+	KindAuditEvent = "AuditEvent"
+	// KindKafkaEvent is a string identifier for the type dbapi.KafkaEvent
+	KindKafkaEvent = "KafkaEvent"
+	// KindClusterScalingDecision is a string identifier for the type dbapi.ClusterScalingDecision
+	KindClusterScalingDecision = "ClusterScalingDecision"
+	// KindWebhookSubscription is a string identifier for the type api.WebhookSubscription
+	KindWebhookSubscription = "WebhookSubscription"
+	// KindUpgradeCampaign is a string identifier for the type dbapi.UpgradeCampaign
+	KindUpgradeCampaign = "UpgradeCampaign"
+	// KindUpgradeCampaignKafka is a string identifier for the type dbapi.UpgradeCampaignKafka
+	KindUpgradeCampaignKafka = "UpgradeCampaignKafka"
+	// KindKafkaTLSCertificate is a string identifier for the type dbapi.KafkaTLSCertificate
+	KindKafkaTLSCertificate = "KafkaTLSCertificate"
+
 	BasePath = "/api/kafkas_mgmt/v1"
 )
 
@@ -45,6 +65,24 @@ func objectKind(i interface{}) string {
 		return KindServiceAccount
 	case api.Cluster, *api.Cluster:
 		return KindCluster
+	case quota_management.Organisation, *quota_management.Organisation:
+		return KindQuotaManagementOrganisation
+	case quota_management.Account, *quota_management.Account:
+		return KindQuotaManagementServiceAccount
+	case api.AuditEvent, *api.AuditEvent:
+		return KindAuditEvent
+	case dbapi.KafkaEvent, *dbapi.KafkaEvent:
+		return KindKafkaEvent
+	case dbapi.ClusterScalingDecision, *dbapi.ClusterScalingDecision:
+		return KindClusterScalingDecision
+	case api.WebhookSubscription, *api.WebhookSubscription:
+		return KindWebhookSubscription
+	case dbapi.UpgradeCampaign, *dbapi.UpgradeCampaign:
+		return KindUpgradeCampaign
+	case dbapi.UpgradeCampaignKafka, *dbapi.UpgradeCampaignKafka:
+		return KindUpgradeCampaignKafka
+	case dbapi.KafkaTLSCertificate, *dbapi.KafkaTLSCertificate:
+		return KindKafkaTLSCertificate
 	default:
 		return ""
 	}
@@ -60,6 +98,14 @@ func objectPath(id string, obj interface{}) string {
 		return fmt.Sprintf("%s/clusters/%s", BasePath, id)
 	case api.ServiceAccount, *api.ServiceAccount:
 		return fmt.Sprintf("%s/service_accounts/%s", BasePath, id)
+	case quota_management.Organisation, *quota_management.Organisation:
+		return fmt.Sprintf("%s/admin/quota_management/organisations/%s", BasePath, id)
+	case quota_management.Account, *quota_management.Account:
+		return fmt.Sprintf("%s/admin/quota_management/service_accounts/%s", BasePath, id)
+	case api.WebhookSubscription, *api.WebhookSubscription:
+		return fmt.Sprintf("%s/webhook_subscriptions/%s", BasePath, id)
+	case dbapi.UpgradeCampaign, *dbapi.UpgradeCampaign:
+		return fmt.Sprintf("%s/admin/upgrade_campaigns/%s", BasePath, id)
 	default:
 		return ""
 	}
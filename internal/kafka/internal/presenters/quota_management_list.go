@@ -0,0 +1,148 @@
+package presenters
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/quota_management"
+)
+
+func ConvertQuotaManagementOrganisationRequest(request private.QuotaManagementOrganisationRequest) (quota_management.Organisation, *errors.ServiceError) {
+	registeredUsers, err := ConvertQuotaManagementAccounts(request.RegisteredUsers)
+	if err != nil {
+		return quota_management.Organisation{}, err
+	}
+	grantedQuota, err := ConvertQuotaManagementGrantedQuota(request.GrantedQuota)
+	if err != nil {
+		return quota_management.Organisation{}, err
+	}
+
+	return quota_management.Organisation{
+		Id:                  request.Id,
+		AnyUser:             request.AnyUser,
+		MaxAllowedInstances: int(request.MaxAllowedInstances),
+		RegisteredUsers:     registeredUsers,
+		GrantedQuota:        grantedQuota,
+	}, nil
+}
+
+func ConvertQuotaManagementServiceAccountRequest(request private.QuotaManagementServiceAccountRequest) (quota_management.Account, *errors.ServiceError) {
+	return ConvertQuotaManagementAccount(private.QuotaManagementAccount{
+		Username:            request.Username,
+		MaxAllowedInstances: request.MaxAllowedInstances,
+		GrantedQuota:        request.GrantedQuota,
+	})
+}
+
+func ConvertQuotaManagementAccounts(accounts []private.QuotaManagementAccount) (quota_management.AccountList, *errors.ServiceError) {
+	res := quota_management.AccountList{}
+	for _, account := range accounts {
+		converted, err := ConvertQuotaManagementAccount(account)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, converted)
+	}
+	return res, nil
+}
+
+func ConvertQuotaManagementAccount(account private.QuotaManagementAccount) (quota_management.Account, *errors.ServiceError) {
+	grantedQuota, err := ConvertQuotaManagementGrantedQuota(account.GrantedQuota)
+	if err != nil {
+		return quota_management.Account{}, err
+	}
+
+	return quota_management.Account{
+		Username:            account.Username,
+		MaxAllowedInstances: int(account.MaxAllowedInstances),
+		GrantedQuota:        grantedQuota,
+	}, nil
+}
+
+// ConvertQuotaManagementGrantedQuota converts the granted quota. A validation error is returned if an expiration date is not in the expected format
+func ConvertQuotaManagementGrantedQuota(grantedQuota []private.QuotaManagementGrantedQuota) (quota_management.QuotaList, *errors.ServiceError) {
+	res := quota_management.QuotaList{}
+	for _, quota := range grantedQuota {
+		billingModels := quota_management.BillingModelList{}
+		for _, bm := range quota.KafkaBillingModels {
+			billingModel := quota_management.BillingModel{
+				Id:                  bm.Id,
+				MaxAllowedInstances: int(bm.MaxAllowedInstances),
+			}
+			if bm.ExpirationDate != "" {
+				expirationDate, err := quota_management.ParseExpirationDate(bm.ExpirationDate)
+				if err != nil {
+					return nil, errors.Validation("invalid expiration_date %q for billing model %q: the expected format is 'YYYY-MM-DD {+,-}HH:MM'", bm.ExpirationDate, bm.Id)
+				}
+				billingModel.ExpirationDate = &expirationDate
+			}
+			billingModels = append(billingModels, billingModel)
+		}
+		res = append(res, quota_management.Quota{
+			InstanceTypeID:     quota.InstanceTypeId,
+			KafkaBillingModels: billingModels,
+		})
+	}
+	return res, nil
+}
+
+func PresentQuotaManagementOrganisation(org quota_management.Organisation) private.QuotaManagementOrganisation {
+	reference := PresentReference(org.Id, org)
+
+	registeredUsers := []private.QuotaManagementAccount{}
+	for _, account := range org.RegisteredUsers {
+		registeredUsers = append(registeredUsers, PresentQuotaManagementAccount(account))
+	}
+
+	return private.QuotaManagementOrganisation{
+		Id:                  reference.Id,
+		Kind:                reference.Kind,
+		Href:                reference.Href,
+		AnyUser:             org.AnyUser,
+		MaxAllowedInstances: int32(org.MaxAllowedInstances),
+		RegisteredUsers:     registeredUsers,
+		GrantedQuota:        PresentQuotaManagementGrantedQuota(org.GrantedQuota),
+	}
+}
+
+func PresentQuotaManagementServiceAccount(account quota_management.Account) private.QuotaManagementServiceAccount {
+	reference := PresentReference(account.Username, account)
+
+	return private.QuotaManagementServiceAccount{
+		Id:                  reference.Id,
+		Kind:                reference.Kind,
+		Href:                reference.Href,
+		Username:            account.Username,
+		MaxAllowedInstances: int32(account.MaxAllowedInstances),
+		GrantedQuota:        PresentQuotaManagementGrantedQuota(account.GrantedQuota),
+	}
+}
+
+func PresentQuotaManagementAccount(account quota_management.Account) private.QuotaManagementAccount {
+	return private.QuotaManagementAccount{
+		Username:            account.Username,
+		MaxAllowedInstances: int32(account.MaxAllowedInstances),
+		GrantedQuota:        PresentQuotaManagementGrantedQuota(account.GrantedQuota),
+	}
+}
+
+func PresentQuotaManagementGrantedQuota(grantedQuota quota_management.QuotaList) []private.QuotaManagementGrantedQuota {
+	res := []private.QuotaManagementGrantedQuota{}
+	for _, quota := range grantedQuota {
+		billingModels := []private.QuotaManagementBillingModel{}
+		for _, bm := range quota.KafkaBillingModels {
+			billingModel := private.QuotaManagementBillingModel{
+				Id:                  bm.Id,
+				MaxAllowedInstances: int32(bm.MaxAllowedInstances),
+			}
+			if bm.ExpirationDate != nil {
+				billingModel.ExpirationDate = bm.ExpirationDate.String()
+			}
+			billingModels = append(billingModels, billingModel)
+		}
+		res = append(res, private.QuotaManagementGrantedQuota{
+			InstanceTypeId:     quota.InstanceTypeID,
+			KafkaBillingModels: billingModels,
+		})
+	}
+	return res
+}
@@ -0,0 +1,69 @@
+package presenters
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/admin/private"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+)
+
+func ConvertUpgradeCampaignRequest(request private.UpgradeCampaignRequest) *dbapi.UpgradeCampaign {
+	failureThreshold := int(request.FailureThreshold)
+	if failureThreshold == 0 {
+		failureThreshold = 1
+	}
+
+	return &dbapi.UpgradeCampaign{
+		Name:             request.Name,
+		StrimziVersion:   request.StrimziVersion,
+		KafkaVersion:     request.KafkaVersion,
+		KafkaIBPVersion:  request.KafkaIbpVersion,
+		KafkaSelector:    request.KafkaSelector,
+		ClusterSelector:  request.ClusterSelector,
+		BatchSize:        int(request.BatchSize),
+		FailureThreshold: failureThreshold,
+		Status:           dbapi.UpgradeCampaignStatusInProgress,
+	}
+}
+
+// PresentUpgradeCampaign presents the campaign along with the number of kafkas it processed indexed by status
+func PresentUpgradeCampaign(campaign *dbapi.UpgradeCampaign, kafkaCounts map[dbapi.UpgradeCampaignKafkaStatus]int) private.UpgradeCampaign {
+	reference := PresentReference(campaign.ID, campaign)
+
+	return private.UpgradeCampaign{
+		Id:               reference.Id,
+		Kind:             reference.Kind,
+		Href:             reference.Href,
+		Name:             campaign.Name,
+		StrimziVersion:   campaign.StrimziVersion,
+		KafkaVersion:     campaign.KafkaVersion,
+		KafkaIbpVersion:  campaign.KafkaIBPVersion,
+		KafkaSelector:    campaign.KafkaSelector,
+		ClusterSelector:  campaign.ClusterSelector,
+		BatchSize:        int32(campaign.BatchSize),
+		FailureThreshold: int32(campaign.FailureThreshold),
+		Status:           campaign.Status.String(),
+		StatusReason:     campaign.StatusReason,
+		CurrentWave:      int32(campaign.CurrentWave),
+		KafkasUpgrading:  int32(kafkaCounts[dbapi.UpgradeCampaignKafkaStatusUpgrading]),
+		KafkasUpgraded:   int32(kafkaCounts[dbapi.UpgradeCampaignKafkaStatusUpgraded]),
+		KafkasFailed:     int32(kafkaCounts[dbapi.UpgradeCampaignKafkaStatusFailed]),
+		KafkasSkipped:    int32(kafkaCounts[dbapi.UpgradeCampaignKafkaStatusSkipped]),
+		CreatedAt:        campaign.CreatedAt,
+		UpdatedAt:        campaign.UpdatedAt,
+	}
+}
+
+func PresentUpgradeCampaignKafka(campaignKafka *dbapi.UpgradeCampaignKafka) private.UpgradeCampaignKafka {
+	reference := PresentReference(campaignKafka.ID, campaignKafka)
+
+	return private.UpgradeCampaignKafka{
+		Id:        reference.Id,
+		Kind:      reference.Kind,
+		KafkaId:   campaignKafka.KafkaId,
+		ClusterId: campaignKafka.ClusterId,
+		Wave:      int32(campaignKafka.Wave),
+		Status:    campaignKafka.Status.String(),
+		Reason:    campaignKafka.Reason,
+		CreatedAt: campaignKafka.CreatedAt,
+		UpdatedAt: campaignKafka.UpdatedAt,
+	}
+}
@@ -0,0 +1,47 @@
+package presenters
+
+import (
+	"encoding/json"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/public"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/encryption"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+)
+
+func ConvertWebhookSubscriptionRequest(request public.WebhookSubscriptionRequest) (*api.WebhookSubscription, *errors.ServiceError) {
+	eventTypes := request.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	eventTypesJSON, err := json.Marshal(eventTypes)
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to marshal the event types of the webhook subscription")
+	}
+
+	return &api.WebhookSubscription{
+		Url:        request.Url,
+		Secret:     encryption.EncryptedString(request.Secret),
+		EventTypes: eventTypesJSON,
+	}, nil
+}
+
+// PresentWebhookSubscription presents the subscription without its secret: the secret is only returned when the
+// subscription is created.
+func PresentWebhookSubscription(subscription *api.WebhookSubscription) (public.WebhookSubscription, *errors.ServiceError) {
+	eventTypes, err := subscription.GetEventTypes()
+	if err != nil {
+		return public.WebhookSubscription{}, errors.NewWithCause(errors.ErrorGeneral, err, "unable to read the event types of webhook subscription %q", subscription.ID)
+	}
+	reference := PresentReference(subscription.ID, subscription)
+
+	return public.WebhookSubscription{
+		Id:         reference.Id,
+		Kind:       reference.Kind,
+		Href:       reference.Href,
+		Url:        subscription.Url,
+		EventTypes: eventTypes,
+		Owner:      subscription.Owner,
+		CreatedAt:  subscription.CreatedAt,
+	}, nil
+}
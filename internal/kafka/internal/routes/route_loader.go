@@ -7,8 +7,10 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/account"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/audit"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/authorization"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/clusters"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
@@ -28,6 +30,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	coreHandlers "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/handlers"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/server"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/signalbus"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 
 	"github.com/goava/di"
@@ -38,10 +41,12 @@ import (
 
 type options struct {
 	di.Inject
-	ServerConfig   *server.ServerConfig
-	OCMConfig      *ocm.OCMConfig
-	ProviderConfig *config.ProviderConfig
-	KafkaConfig    *config.KafkaConfig
+	ServerConfig           *server.ServerConfig
+	OCMConfig              *ocm.OCMConfig
+	ProviderConfig         *config.ProviderConfig
+	KafkaConfig            *config.KafkaConfig
+	DataplaneClusterConfig *config.DataplaneClusterConfig
+	WebhookConfig          *webhooks.Config
 
 	AMSClient                                 ocm.AMSClient
 	Kafka                                     services.KafkaService
@@ -63,6 +68,16 @@ type options struct {
 	AdminRoleAuthZConfig                      *auth.AdminRoleAuthZConfig
 	KasFleetshardOperatorAddon                services.KasFleetshardOperatorAddon
 	KafkaTLSCertificateManagementService      kafkatlscertmgmt.KafkaTLSCertificateManagementService
+	SignalBus                                 signalbus.SignalBus
+	QuotaManagementListEntries                services.QuotaManagementListEntriesService
+	AuditEvents                               audit.AuditEventService
+	KafkaEvents                               services.KafkaEventService
+	KafkaMigrationService                     services.KafkaMigrationService
+	ClusterScalingDecisions                   services.ClusterScalingDecisionService
+	WebhookService                            webhooks.WebhookService
+	MaintenanceWindowService                  services.MaintenanceWindowService
+	UpgradeCampaigns                          services.UpgradeCampaignService
+	KafkaTLSCertificateInventory              services.KafkaTLSCertificateInventoryService
 }
 
 func NewRouteLoader(s options) environments.RouteLoader {
@@ -85,9 +100,10 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 		return pkgerrors.Wrapf(err, "can't load OpenAPI specification")
 	}
 
-	kafkaHandler := handlers.NewKafkaHandler(s.Kafka, s.ProviderConfig, s.AuthService, s.KafkaConfig)
+	kafkaHandler := handlers.NewKafkaHandler(s.Kafka, s.ProviderConfig, s.AuthService, s.KafkaConfig, s.MaintenanceWindowService)
 	kafkaPromoteValidatorFactory := handlers.NewDefaultKafkaPromoteValidatorFactory(s.KafkaConfig)
 	kafkaPromoteHandler := handlers.NewKafkaPromoteHandler(s.Kafka, s.KafkaConfig, kafkaPromoteValidatorFactory)
+	kafkaEventsHandler := handlers.NewKafkaEventsHandler(s.Kafka, s.KafkaEvents)
 	cloudProvidersHandler := handlers.NewCloudProviderHandler(s.CloudProviders, s.ProviderConfig, s.Kafka, s.ClusterPlacementStrategy, s.KafkaConfig)
 	errorsHandler := coreHandlers.NewErrorsHandler()
 	serviceAccountsHandler := handlers.NewServiceAccountHandler(s.Keycloak)
@@ -98,6 +114,8 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 	requireOrgID := auth.NewRequireOrgIDMiddleware().RequireOrgID(errors.ErrorUnauthenticated)
 	requireIssuer := auth.NewRequireIssuerMiddleware().RequireIssuer([]string{s.ServerConfig.TokenIssuerURL}, errors.ErrorUnauthenticated)
 	requireTermsAcceptance := auth.NewRequireTermsAcceptanceMiddleware().RequireTermsAcceptance(s.ServerConfig.EnableTermsAcceptance, s.AMSClient, errors.ErrorTermsNotAccepted)
+	auditLogMiddleware := auth.NewAuditLogMiddleware(s.AuditEvents)
+	auditMutatingRequests := auditLogMiddleware.AuditLogMutatingRequests(errors.ErrorUnauthenticated)
 
 	// base path. Could be /api/kafkas_mgmt
 	apiRouter := mainRouter.PathPrefix(basePath).Subrouter()
@@ -137,11 +155,14 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 		Name(logger.NewLogEvent("list-kafka", "list all kafkas").ToString()).
 		Methods(http.MethodGet)
 	apiV1KafkasRouter.Use(requireIssuer)
+	apiV1KafkasRouter.Use(auditMutatingRequests)
 	apiV1KafkasRouter.Use(requireOrgID)
 	apiV1KafkasRouter.Use(authorizeMiddleware)
 
 	apiV1KafkasCreateRouter := apiV1KafkasRouter.NewRoute().Subrouter()
-	apiV1KafkasCreateRouter.HandleFunc("", kafkaHandler.Create).Methods(http.MethodPost)
+	apiV1KafkasCreateRouter.HandleFunc("", kafkaHandler.Create).
+		Name(logger.NewLogEvent("create-kafka", "create a kafka instance").ToString()).
+		Methods(http.MethodPost)
 	apiV1KafkasCreateRouter.Use(requireTermsAcceptance)
 
 	// /kafkas/{id}/promote
@@ -150,6 +171,23 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 		Name(logger.NewLogEvent("promote-kafka", "promote a kafka instance").ToString()).
 		Methods(http.MethodPost)
 
+	// /kafkas/{id}/suspend
+	apiV1KafkasSuspendRouter := apiV1KafkasRouter.PathPrefix("/{id}/suspend").Subrouter()
+	apiV1KafkasSuspendRouter.HandleFunc("", kafkaHandler.Suspend).
+		Name(logger.NewLogEvent("suspend-kafka", "suspend a kafka instance").ToString()).
+		Methods(http.MethodPost)
+
+	// /kafkas/{id}/resume
+	apiV1KafkasResumeRouter := apiV1KafkasRouter.PathPrefix("/{id}/resume").Subrouter()
+	apiV1KafkasResumeRouter.HandleFunc("", kafkaHandler.Resume).
+		Name(logger.NewLogEvent("resume-kafka", "resume a suspended kafka instance").ToString()).
+		Methods(http.MethodPost)
+
+	// /kafkas/{id}/events
+	apiV1KafkasRouter.HandleFunc("/{id}/events", kafkaEventsHandler.List).
+		Name(logger.NewLogEvent("list-kafka-events", "list the lifecycle events of a kafka instance").ToString()).
+		Methods(http.MethodGet)
+
 	//  /kafkas/{id}/metrics
 	apiV1MetricsRouter := apiV1KafkasRouter.PathPrefix("/{id}/metrics").Subrouter()
 	apiV1MetricsRouter.HandleFunc("/query_range", metricsHandler.GetMetricsByRangeQuery).
@@ -200,6 +238,7 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 		Methods(http.MethodGet)
 
 	apiV1ServiceAccountsRouter.Use(requireIssuer)
+	apiV1ServiceAccountsRouter.Use(auditMutatingRequests)
 	apiV1ServiceAccountsRouter.Use(requireOrgID)
 	apiV1ServiceAccountsRouter.Use(authorizeMiddleware)
 
@@ -230,7 +269,7 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 		ID:   "clusters",
 		Kind: "EnterpriseClusterList",
 	})
-	clusterHandler := handlers.NewClusterHandler(s.KasFleetshardOperatorAddon, s.ClusterService, s.ProviderFactory, s.KafkaConfig)
+	clusterHandler := handlers.NewClusterHandler(s.KasFleetshardOperatorAddon, s.ClusterService, s.ProviderFactory, s.KafkaConfig, s.AMSClient)
 	clusterRouter := apiV1Router.PathPrefix("/clusters").Subrouter()
 	clusterRouter.Use(s.EnterpriseClustersAccessControlMiddleware.Authorize)
 	clusterRouter.HandleFunc("", clusterHandler.RegisterEnterpriseCluster).
@@ -245,13 +284,59 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 	clusterRouter.HandleFunc("/{id}", clusterHandler.Get).
 		Name(logger.NewLogEvent("get-enterprise-cluster", "get an enterprise data plane cluster by ID").ToString()).
 		Methods(http.MethodGet)
+	clusterRouter.HandleFunc("/{id}", clusterHandler.Update).
+		Name(logger.NewLogEvent("update-enterprise-cluster", "update an enterprise data plane cluster by ID").ToString()).
+		Methods(http.MethodPatch)
 	clusterRouter.HandleFunc("/{id}/addon_parameters", clusterHandler.GetEnterpriseClusterWithAddonParams).
 		Name(logger.NewLogEvent("get-enterprise-cluster-addon-parameters", "get addon parameters of an enterprise data plane cluster by ID").ToString()).
 		Methods(http.MethodGet)
 
+	// /api/kafkas_mgmt/v1/webhook_subscriptions
+	v1Collections = append(v1Collections, api.CollectionMetadata{
+		ID:   "webhook_subscriptions",
+		Kind: "WebhookSubscriptionList",
+	})
+	webhookSubscriptionsHandler := handlers.NewWebhookSubscriptionsHandler(s.WebhookService, s.WebhookConfig)
+	apiV1WebhookSubscriptionsRouter := apiV1Router.PathPrefix("/webhook_subscriptions").Subrouter()
+	apiV1WebhookSubscriptionsRouter.HandleFunc("", webhookSubscriptionsHandler.Create).
+		Name(logger.NewLogEvent("create-webhook-subscription", "create a webhook subscription").ToString()).
+		Methods(http.MethodPost)
+	apiV1WebhookSubscriptionsRouter.HandleFunc("", webhookSubscriptionsHandler.List).
+		Name(logger.NewLogEvent("list-webhook-subscriptions", "list all webhook subscriptions").ToString()).
+		Methods(http.MethodGet)
+	apiV1WebhookSubscriptionsRouter.HandleFunc("/{id}", webhookSubscriptionsHandler.Get).
+		Name(logger.NewLogEvent("get-webhook-subscription", "get a webhook subscription by id").ToString()).
+		Methods(http.MethodGet)
+	apiV1WebhookSubscriptionsRouter.HandleFunc("/{id}", webhookSubscriptionsHandler.Delete).
+		Name(logger.NewLogEvent("delete-webhook-subscription", "delete a webhook subscription by id").ToString()).
+		Methods(http.MethodDelete)
+
+	apiV1WebhookSubscriptionsRouter.Use(requireIssuer)
+	apiV1WebhookSubscriptionsRouter.Use(auditMutatingRequests)
+	apiV1WebhookSubscriptionsRouter.Use(requireOrgID)
+	apiV1WebhookSubscriptionsRouter.Use(authorizeMiddleware)
+
+	// /api/kafkas_mgmt/v1/maintenance_window
+	maintenanceWindowHandler := handlers.NewMaintenanceWindowHandler(s.MaintenanceWindowService)
+	apiV1MaintenanceWindowRouter := apiV1Router.PathPrefix("/maintenance_window").Subrouter()
+	apiV1MaintenanceWindowRouter.HandleFunc("", maintenanceWindowHandler.Get).
+		Name(logger.NewLogEvent("get-maintenance-window", "get the maintenance window of the organisation").ToString()).
+		Methods(http.MethodGet)
+	apiV1MaintenanceWindowRouter.HandleFunc("", maintenanceWindowHandler.Update).
+		Name(logger.NewLogEvent("update-maintenance-window", "update the maintenance window of the organisation").ToString()).
+		Methods(http.MethodPut)
+	apiV1MaintenanceWindowRouter.HandleFunc("", maintenanceWindowHandler.Delete).
+		Name(logger.NewLogEvent("delete-maintenance-window", "delete the maintenance window of the organisation").ToString()).
+		Methods(http.MethodDelete)
+
+	apiV1MaintenanceWindowRouter.Use(requireIssuer)
+	apiV1MaintenanceWindowRouter.Use(auditMutatingRequests)
+	apiV1MaintenanceWindowRouter.Use(requireOrgID)
+	apiV1MaintenanceWindowRouter.Use(authorizeMiddleware)
+
 	// /agent-clusters/{id}
 	dataPlaneClusterHandler := handlers.NewDataPlaneClusterHandler(s.DataPlaneCluster)
-	dataPlaneKafkaHandler := handlers.NewDataPlaneKafkaHandler(s.DataPlaneKafkaService, s.Kafka)
+	dataPlaneKafkaHandler := handlers.NewDataPlaneKafkaHandler(s.DataPlaneKafkaService, s.Kafka, s.SignalBus)
 	apiV1DataPlaneRequestsRouter := apiV1Router.PathPrefix("/agent-clusters").Subrouter()
 	apiV1DataPlaneRequestsRouter.HandleFunc("/{id}", dataPlaneClusterHandler.GetDataPlaneClusterConfig).
 		Name(logger.NewLogEvent("get-dataplane-cluster-config", "get dataplane cluster config by id").ToString()).
@@ -277,11 +362,11 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 	observatoriumProxyRouter.Use(auth.NewRequireIssuerMiddleware().RequireIssuer([]string{s.Keycloak.GetRealmConfig().ValidIssuerURI}, errors.ErrorNotFound))
 
 	// /api/kafkas_mgmt/v1/admin/kafkas
-	adminKafkaHandler := handlers.NewAdminKafkaHandler(s.Kafka, s.AccountService, s.ProviderConfig, s.ClusterService, s.KafkaConfig, s.KafkaTLSCertificateManagementService)
+	adminKafkaHandler := handlers.NewAdminKafkaHandler(s.Kafka, s.AccountService, s.ProviderConfig, s.ClusterService, s.KafkaConfig, s.KafkaTLSCertificateManagementService, s.KafkaMigrationService)
 	adminRouter := apiV1Router.PathPrefix("/admin").Subrouter()
 	adminRouter.Use(auth.NewRequireIssuerMiddleware().RequireIssuer([]string{s.Keycloak.GetConfig().AdminAPISSORealm.ValidIssuerURI}, errors.ErrorNotFound))
 	adminRouter.Use(auth.NewRolesAuthzMiddleware(s.AdminRoleAuthZConfig).RequireRolesForMethods(errors.ErrorNotFound))
-	adminRouter.Use(auth.NewAuditLogMiddleware().AuditLog(errors.ErrorNotFound))
+	adminRouter.Use(auditLogMiddleware.AuditLog(errors.ErrorNotFound))
 	adminRouter.HandleFunc("/kafkas", adminKafkaHandler.List).
 		Name(logger.NewLogEvent("admin-list-kafkas", "[admin] list all kafkas").ToString()).
 		Methods(http.MethodGet)
@@ -294,9 +379,112 @@ func (s *options) buildApiBaseRouter(mainRouter *mux.Router, basePath string) er
 	adminRouter.HandleFunc("/kafkas/{id}", adminKafkaHandler.Update).
 		Name(logger.NewLogEvent("admin-update-kafka", "[admin] update kafka by id").ToString()).
 		Methods(http.MethodPatch)
+	adminRouter.HandleFunc("/kafkas/{id}/events", kafkaEventsHandler.AdminList).
+		Name(logger.NewLogEvent("admin-list-kafka-events", "[admin] list the lifecycle events of a kafka by id").ToString()).
+		Methods(http.MethodGet)
 	adminRouter.HandleFunc("/kafkas/{id}/revoke_tls_certificate", adminKafkaHandler.RevokeCertificateOfAKafka).
 		Name(logger.NewLogEvent("admin-kafka-tls-certificate-revocation", "[admin] revoke the TLS certificate of a kafka by id").ToString()).
 		Methods(http.MethodPost)
+	adminRouter.HandleFunc("/kafkas/{id}/migrate", adminKafkaHandler.Migrate).
+		Name(logger.NewLogEvent("admin-migrate-kafka", "[admin] migrate a kafka by id to another data plane cluster").ToString()).
+		Methods(http.MethodPost)
+
+	// /api/kafkas_mgmt/v1/admin/cluster_placement
+	adminClusterPlacementHandler := handlers.NewAdminClusterPlacementHandler(s.ClusterService, s.DataplaneClusterConfig, s.KafkaConfig)
+	adminRouter.HandleFunc("/cluster_placement/dry_run", adminClusterPlacementHandler.DryRun).
+		Name(logger.NewLogEvent("admin-cluster-placement-dry-run", "[admin] find the cluster each placement strategy would place a kafka on").ToString()).
+		Methods(http.MethodPost)
+
+	// /api/kafkas_mgmt/v1/admin/clusters
+	adminClusterHandler := handlers.NewAdminClusterHandler(s.ClusterService)
+	adminRouter.HandleFunc("/clusters", adminClusterHandler.List).
+		Name(logger.NewLogEvent("admin-list-clusters", "[admin] list all data plane clusters").ToString()).
+		Methods(http.MethodGet)
+	adminRouter.HandleFunc("/clusters/{id}", adminClusterHandler.Get).
+		Name(logger.NewLogEvent("admin-get-cluster", "[admin] get data plane cluster by id").ToString()).
+		Methods(http.MethodGet)
+	adminRouter.HandleFunc("/clusters/{id}", adminClusterHandler.Update).
+		Name(logger.NewLogEvent("admin-update-cluster", "[admin] force the status of a data plane cluster by id").ToString()).
+		Methods(http.MethodPatch)
+	adminRouter.HandleFunc("/clusters/{id}", adminClusterHandler.Delete).
+		Name(logger.NewLogEvent("admin-delete-cluster", "[admin] deregister data plane cluster by id").ToString()).
+		Methods(http.MethodDelete)
+	adminRouter.HandleFunc("/clusters/{id}/cordon", adminClusterHandler.Cordon).
+		Name(logger.NewLogEvent("admin-cordon-cluster", "[admin] stop placing kafkas on a data plane cluster by id").ToString()).
+		Methods(http.MethodPost)
+	adminRouter.HandleFunc("/clusters/{id}/uncordon", adminClusterHandler.Uncordon).
+		Name(logger.NewLogEvent("admin-uncordon-cluster", "[admin] resume placing kafkas on a data plane cluster by id").ToString()).
+		Methods(http.MethodPost)
+	adminRouter.HandleFunc("/clusters/{id}/reconcile_resources", adminClusterHandler.ReconcileResources).
+		Name(logger.NewLogEvent("admin-reconcile-cluster-resources", "[admin] reconcile the resources of a data plane cluster by id").ToString()).
+		Methods(http.MethodPost)
+	adminClusterScalingDecisionsHandler := handlers.NewAdminClusterScalingDecisionsHandler(s.ClusterScalingDecisions)
+	adminRouter.HandleFunc("/clusters/{id}/scaling_decisions", adminClusterScalingDecisionsHandler.List).
+		Name(logger.NewLogEvent("admin-list-cluster-scaling-decisions", "[admin] list the scaling decisions about a data plane cluster by id").ToString()).
+		Methods(http.MethodGet)
+
+	// /api/kafkas_mgmt/v1/admin/audit_events
+	adminAuditEventsHandler := handlers.NewAdminAuditEventsHandler(s.AuditEvents)
+	adminRouter.HandleFunc("/audit_events", adminAuditEventsHandler.List).
+		Name(logger.NewLogEvent("admin-list-audit-events", "[admin] list audit events").ToString()).
+		Methods(http.MethodGet)
+
+	// /api/kafkas_mgmt/v1/admin/certificates
+	adminKafkaTLSCertificatesHandler := handlers.NewAdminKafkaTLSCertificatesHandler(s.KafkaTLSCertificateInventory)
+	adminRouter.HandleFunc("/certificates", adminKafkaTLSCertificatesHandler.List).
+		Name(logger.NewLogEvent("admin-list-kafka-tls-certificates", "[admin] list the tls certificates of the kafka base domains").ToString()).
+		Methods(http.MethodGet)
+
+	// /api/kafkas_mgmt/v1/admin/quota_management
+	adminQuotaManagementListHandler := handlers.NewAdminQuotaManagementListHandler(s.QuotaManagementListEntries)
+	adminRouter.HandleFunc("/quota_management/organisations", adminQuotaManagementListHandler.ListOrganisations).
+		Name(logger.NewLogEvent("admin-list-quota-management-organisations", "[admin] list the organisations of the quota management list").ToString()).
+		Methods(http.MethodGet)
+	adminRouter.HandleFunc("/quota_management/organisations", adminQuotaManagementListHandler.CreateOrganisation).
+		Name(logger.NewLogEvent("admin-create-quota-management-organisation", "[admin] add an organisation to the quota management list").ToString()).
+		Methods(http.MethodPost)
+	adminRouter.HandleFunc("/quota_management/organisations/{id}", adminQuotaManagementListHandler.GetOrganisation).
+		Name(logger.NewLogEvent("admin-get-quota-management-organisation", "[admin] get quota management list organisation by id").ToString()).
+		Methods(http.MethodGet)
+	adminRouter.HandleFunc("/quota_management/organisations/{id}", adminQuotaManagementListHandler.UpdateOrganisation).
+		Name(logger.NewLogEvent("admin-update-quota-management-organisation", "[admin] update quota management list organisation by id").ToString()).
+		Methods(http.MethodPatch)
+	adminRouter.HandleFunc("/quota_management/organisations/{id}", adminQuotaManagementListHandler.DeleteOrganisation).
+		Name(logger.NewLogEvent("admin-delete-quota-management-organisation", "[admin] delete quota management list organisation by id").ToString()).
+		Methods(http.MethodDelete)
+	adminRouter.HandleFunc("/quota_management/service_accounts", adminQuotaManagementListHandler.ListServiceAccounts).
+		Name(logger.NewLogEvent("admin-list-quota-management-service-accounts", "[admin] list the service accounts of the quota management list").ToString()).
+		Methods(http.MethodGet)
+	adminRouter.HandleFunc("/quota_management/service_accounts", adminQuotaManagementListHandler.CreateServiceAccount).
+		Name(logger.NewLogEvent("admin-create-quota-management-service-account", "[admin] add a service account to the quota management list").ToString()).
+		Methods(http.MethodPost)
+	adminRouter.HandleFunc("/quota_management/service_accounts/{username}", adminQuotaManagementListHandler.GetServiceAccount).
+		Name(logger.NewLogEvent("admin-get-quota-management-service-account", "[admin] get quota management list service account by username").ToString()).
+		Methods(http.MethodGet)
+	adminRouter.HandleFunc("/quota_management/service_accounts/{username}", adminQuotaManagementListHandler.UpdateServiceAccount).
+		Name(logger.NewLogEvent("admin-update-quota-management-service-account", "[admin] update quota management list service account by username").ToString()).
+		Methods(http.MethodPatch)
+	adminRouter.HandleFunc("/quota_management/service_accounts/{username}", adminQuotaManagementListHandler.DeleteServiceAccount).
+		Name(logger.NewLogEvent("admin-delete-quota-management-service-account", "[admin] delete quota management list service account by username").ToString()).
+		Methods(http.MethodDelete)
+
+	// /api/kafkas_mgmt/v1/admin/upgrade_campaigns
+	adminUpgradeCampaignsHandler := handlers.NewAdminUpgradeCampaignsHandler(s.UpgradeCampaigns)
+	adminRouter.HandleFunc("/upgrade_campaigns", adminUpgradeCampaignsHandler.List).
+		Name(logger.NewLogEvent("admin-list-upgrade-campaigns", "[admin] list upgrade campaigns").ToString()).
+		Methods(http.MethodGet)
+	adminRouter.HandleFunc("/upgrade_campaigns", adminUpgradeCampaignsHandler.Create).
+		Name(logger.NewLogEvent("admin-create-upgrade-campaign", "[admin] create an upgrade campaign").ToString()).
+		Methods(http.MethodPost)
+	adminRouter.HandleFunc("/upgrade_campaigns/{id}", adminUpgradeCampaignsHandler.Get).
+		Name(logger.NewLogEvent("admin-get-upgrade-campaign", "[admin] get upgrade campaign by id").ToString()).
+		Methods(http.MethodGet)
+	adminRouter.HandleFunc("/upgrade_campaigns/{id}", adminUpgradeCampaignsHandler.Update).
+		Name(logger.NewLogEvent("admin-update-upgrade-campaign", "[admin] update upgrade campaign by id").ToString()).
+		Methods(http.MethodPatch)
+	adminRouter.HandleFunc("/upgrade_campaigns/{id}/kafkas", adminUpgradeCampaignsHandler.ListKafkas).
+		Name(logger.NewLogEvent("admin-list-upgrade-campaign-kafkas", "[admin] list the kafkas processed by an upgrade campaign").ToString()).
+		Methods(http.MethodGet)
 
 	// /api/kafkas_mgmt/v1
 	v1Metadata := api.VersionMetadata{
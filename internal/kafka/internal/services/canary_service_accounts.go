@@ -0,0 +1,139 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/secrets"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+)
+
+const CanaryServiceAccountPrefix = "canary"
+
+// CanaryServiceAccountService manages the service accounts the canaries of the Kafka instances authenticate with.
+// Only the client ids of the service accounts are stored with the Kafka requests, their client secrets are stored in
+// the vault.
+//
+//go:generate moq -out canary_service_accounts_moq.go . CanaryServiceAccountService
+type CanaryServiceAccountService interface {
+	// Create creates a new canary service account for the given kafka and stores its client secret in the vault.
+	// It returns the client id of the service account.
+	Create(kafka *dbapi.KafkaRequest) (string, *errors.ServiceError)
+	// GetClientSecret returns the client secret of the given canary service account of the kafka
+	GetClientSecret(kafka *dbapi.KafkaRequest, clientId string) (string, *errors.ServiceError)
+	// StoreClientSecret stores the client secret of the given canary service account of the kafka in the vault
+	StoreClientSecret(kafka *dbapi.KafkaRequest, clientId string, clientSecret string) *errors.ServiceError
+	// Delete deletes the given canary service account of the kafka and its client secret. Service accounts and
+	// secrets which do not exist are ignored.
+	Delete(kafka *dbapi.KafkaRequest, clientId string) *errors.ServiceError
+	// ListClientIds returns the client ids of the canary service accounts of the kafka whose client secret is stored
+	// in the vault, including the service accounts left over by the rotations which did not complete
+	ListClientIds(kafka *dbapi.KafkaRequest) ([]string, *errors.ServiceError)
+}
+
+var _ CanaryServiceAccountService = &canaryServiceAccountService{}
+
+type canaryServiceAccountService struct {
+	keycloakService sso.KeycloakService
+	vaultService    secrets.VaultService
+}
+
+func NewCanaryServiceAccountService(keycloakService sso.KafkaKeycloakService, vaultService secrets.VaultService) CanaryServiceAccountService {
+	return &canaryServiceAccountService{
+		keycloakService: keycloakService,
+		vaultService:    vaultService,
+	}
+}
+
+func (s *canaryServiceAccountService) Create(kafka *dbapi.KafkaRequest) (string, *errors.ServiceError) {
+	clientId := strings.ToLower(fmt.Sprintf("%s-%s", CanaryServiceAccountPrefix, kafka.ID))
+	// the service accounts the canary is rotated to need a different client id than the service account in use
+	if kafka.CanaryServiceAccountClientID != "" {
+		clientId = fmt.Sprintf("%s-%s", clientId, strconv.FormatInt(time.Now().Unix(), 10))
+	}
+
+	serviceAccount, err := s.keycloakService.CreateServiceAccountInternal(sso.CompleteServiceAccountRequest{
+		Owner:          kafka.Owner,
+		OwnerAccountId: kafka.OwnerAccountId,
+		ClientId:       clientId,
+		OrgId:          kafka.OrganisationId,
+		Name:           fmt.Sprintf("canary-service-account-for-kafka %s", kafka.ID),
+		Description:    fmt.Sprintf("canary service account for kafka %s", kafka.ID),
+	})
+	if err != nil {
+		return "", errors.FailedToCreateSSOClient("failed to  create canary service account %s:%v", kafka.ID, err)
+	}
+
+	if err := s.StoreClientSecret(kafka, serviceAccount.ClientID, serviceAccount.ClientSecret); err != nil {
+		// the service account is useless without its client secret
+		if deleteErr := s.keycloakService.DeleteServiceAccountInternal(serviceAccount.ClientID); deleteErr != nil {
+			logger.Logger.Errorf("failed to delete canary service account %q of kafka %q: %v", serviceAccount.ClientID, kafka.ID, deleteErr)
+		}
+		return "", err
+	}
+
+	return serviceAccount.ClientID, nil
+}
+
+func (s *canaryServiceAccountService) GetClientSecret(kafka *dbapi.KafkaRequest, clientId string) (string, *errors.ServiceError) {
+	// the client secrets of the kafkas created before the secrets were moved to the vault are stored in the database
+	// until they are migrated
+	if clientId == kafka.CanaryServiceAccountClientID && kafka.CanaryServiceAccountClientSecret != "" {
+		return kafka.CanaryServiceAccountClientSecret.String(), nil
+	}
+
+	clientSecret, err := s.vaultService.GetSecretString(canaryServiceAccountSecretName(clientId))
+	if err != nil {
+		return "", errors.NewWithCause(errors.ErrorGeneral, err, "failed to get the client secret of canary service account %q of kafka %q", clientId, kafka.ID)
+	}
+	return clientSecret, nil
+}
+
+func (s *canaryServiceAccountService) StoreClientSecret(kafka *dbapi.KafkaRequest, clientId string, clientSecret string) *errors.ServiceError {
+	if err := s.vaultService.SetSecretString(canaryServiceAccountSecretName(clientId), clientSecret, kafka.ID); err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to store the client secret of canary service account %q of kafka %q", clientId, kafka.ID)
+	}
+	return nil
+}
+
+func (s *canaryServiceAccountService) Delete(kafka *dbapi.KafkaRequest, clientId string) *errors.ServiceError {
+	if err := s.keycloakService.DeleteServiceAccountInternal(clientId); err != nil {
+		// Log the info for not found and proceed - not an error if service account is not found
+		if err.Code != errors.ErrorServiceAccountNotFound {
+			return errors.NewWithCause(errors.ErrorGeneral, err, "error deleting canary service account %q of kafka %q", clientId, kafka.ID)
+		}
+		logger.Logger.V(10).Infof("Service account with ID '%s' not found. Skipping deletion", clientId)
+	}
+
+	if err := s.vaultService.DeleteSecretString(canaryServiceAccountSecretName(clientId)); err != nil && !secrets.IsNotFound(err) {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to delete the client secret of canary service account %q of kafka %q", clientId, kafka.ID)
+	}
+	return nil
+}
+
+func (s *canaryServiceAccountService) ListClientIds(kafka *dbapi.KafkaRequest) ([]string, *errors.ServiceError) {
+	names, err := s.vaultService.ListSecretsByOwner(kafka.ID)
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list the secrets of kafka %q", kafka.ID)
+	}
+
+	var clientIds []string
+	for _, name := range names {
+		// the kafka may own secrets other than the client secrets of its canary service accounts
+		if strings.HasPrefix(name, canaryServiceAccountSecretPrefix) {
+			clientIds = append(clientIds, strings.TrimPrefix(name, canaryServiceAccountSecretPrefix))
+		}
+	}
+	return clientIds, nil
+}
+
+const canaryServiceAccountSecretPrefix = "canary-service-accounts/"
+
+func canaryServiceAccountSecretName(clientId string) string {
+	return canaryServiceAccountSecretPrefix + clientId
+}
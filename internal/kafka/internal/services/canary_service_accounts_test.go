@@ -0,0 +1,177 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/secrets"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
+	"github.com/onsi/gomega"
+)
+
+// newTmpVaultCanaryServiceAccountService returns a canary service account service storing the client secrets in memory
+func newTmpVaultCanaryServiceAccountService(keycloakService sso.KeycloakService) CanaryServiceAccountService {
+	vaultService, _ := secrets.NewTmpVaultService()
+	return NewCanaryServiceAccountService(keycloakService, vaultService)
+}
+
+func Test_canaryServiceAccountService_Create(t *testing.T) {
+	tests := []struct {
+		name             string
+		kafka            *dbapi.KafkaRequest
+		createErr        *errors.ServiceError
+		setErr           error
+		wantErr          bool
+		wantClientId     string
+		wantClientPrefix string
+		wantDeleteCount  int
+	}{
+		{
+			name:         "should create the first canary service account of the kafka",
+			kafka:        &dbapi.KafkaRequest{Meta: api.Meta{ID: "KAFKA-ID"}},
+			wantClientId: "canary-kafka-id",
+		},
+		{
+			name:             "should create a service account with a new client id when the canary is rotated",
+			kafka:            &dbapi.KafkaRequest{Meta: api.Meta{ID: "KAFKA-ID"}, CanaryServiceAccountClientID: "canary-kafka-id"},
+			wantClientPrefix: "canary-kafka-id-",
+		},
+		{
+			name:      "should return an error when the service account cannot be created",
+			kafka:     &dbapi.KafkaRequest{Meta: api.Meta{ID: "KAFKA-ID"}},
+			createErr: errors.GeneralError("test"),
+			wantErr:   true,
+		},
+		{
+			name:            "should delete the service account when its client secret cannot be stored",
+			kafka:           &dbapi.KafkaRequest{Meta: api.Meta{ID: "KAFKA-ID"}},
+			setErr:          secrets.NotFound,
+			wantErr:         true,
+			wantDeleteCount: 1,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			keycloakService := &sso.KeycloakServiceMock{
+				CreateServiceAccountInternalFunc: func(request sso.CompleteServiceAccountRequest) (*api.ServiceAccount, *errors.ServiceError) {
+					if tt.createErr != nil {
+						return nil, tt.createErr
+					}
+					return &api.ServiceAccount{ClientID: request.ClientId, ClientSecret: "secret"}, nil
+				},
+				DeleteServiceAccountInternalFunc: func(clientId string) *errors.ServiceError {
+					return nil
+				},
+			}
+			vaultService := &secrets.VaultServiceMock{
+				SetSecretStringFunc: func(name string, value string, owningResource string) error {
+					return tt.setErr
+				},
+			}
+
+			clientId, err := NewCanaryServiceAccountService(keycloakService, vaultService).Create(tt.kafka)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(keycloakService.DeleteServiceAccountInternalCalls()).To(gomega.HaveLen(tt.wantDeleteCount))
+			if tt.wantErr {
+				return
+			}
+			if tt.wantClientId != "" {
+				g.Expect(clientId).To(gomega.Equal(tt.wantClientId))
+			} else {
+				g.Expect(clientId).To(gomega.HavePrefix(tt.wantClientPrefix))
+			}
+			g.Expect(vaultService.SetSecretStringCalls()).To(gomega.HaveLen(1))
+			g.Expect(vaultService.SetSecretStringCalls()[0].Name).To(gomega.Equal("canary-service-accounts/" + clientId))
+			g.Expect(vaultService.SetSecretStringCalls()[0].Value).To(gomega.Equal("secret"))
+			g.Expect(vaultService.SetSecretStringCalls()[0].OwningResource).To(gomega.Equal("KAFKA-ID"))
+		})
+	}
+}
+
+func Test_canaryServiceAccountService_GetClientSecret(t *testing.T) {
+	g := gomega.NewWithT(t)
+	s := newTmpVaultCanaryServiceAccountService(&sso.KeycloakServiceMock{})
+	kafka := &dbapi.KafkaRequest{
+		Meta:                             api.Meta{ID: "kafka-id"},
+		CanaryServiceAccountClientID:     "canary-kafka-id",
+		CanaryServiceAccountClientSecret: "legacy-secret",
+	}
+
+	// the client secrets which have not been moved to the vault yet are read from the kafka
+	secret, err := s.GetClientSecret(kafka, "canary-kafka-id")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(secret).To(gomega.Equal("legacy-secret"))
+
+	_, err = s.GetClientSecret(kafka, "canary-kafka-id-1679616000")
+	g.Expect(err).ToNot(gomega.BeNil())
+
+	g.Expect(s.StoreClientSecret(kafka, "canary-kafka-id-1679616000", "secret")).To(gomega.BeNil())
+	secret, err = s.GetClientSecret(kafka, "canary-kafka-id-1679616000")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(secret).To(gomega.Equal("secret"))
+}
+
+func Test_canaryServiceAccountService_Delete(t *testing.T) {
+	tests := []struct {
+		name      string
+		deleteErr *errors.ServiceError
+		wantErr   bool
+	}{
+		{
+			name: "should delete the service account and its client secret",
+		},
+		{
+			name:      "should ignore the service accounts which do not exist",
+			deleteErr: errors.New(errors.ErrorServiceAccountNotFound, "not found"),
+		},
+		{
+			name:      "should return an error when the service account cannot be deleted",
+			deleteErr: errors.GeneralError("test"),
+			wantErr:   true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			kafka := &dbapi.KafkaRequest{Meta: api.Meta{ID: "kafka-id"}}
+			s := newTmpVaultCanaryServiceAccountService(&sso.KeycloakServiceMock{
+				DeleteServiceAccountInternalFunc: func(clientId string) *errors.ServiceError {
+					return tt.deleteErr
+				},
+			})
+			g.Expect(s.StoreClientSecret(kafka, "canary-kafka-id", "secret")).To(gomega.BeNil())
+
+			g.Expect(s.Delete(kafka, "canary-kafka-id") != nil).To(gomega.Equal(tt.wantErr))
+			if !tt.wantErr {
+				_, err := s.GetClientSecret(kafka, "canary-kafka-id")
+				g.Expect(err).ToNot(gomega.BeNil())
+				// deleting again does not fail
+				g.Expect(s.Delete(kafka, "canary-kafka-id")).To(gomega.BeNil())
+			}
+		})
+	}
+}
+
+func Test_canaryServiceAccountService_ListClientIds(t *testing.T) {
+	g := gomega.NewWithT(t)
+	vaultService, _ := secrets.NewTmpVaultService()
+	s := NewCanaryServiceAccountService(&sso.KeycloakServiceMock{}, vaultService)
+	kafka := &dbapi.KafkaRequest{Meta: api.Meta{ID: "kafka-id"}}
+
+	g.Expect(s.StoreClientSecret(kafka, "canary-kafka-id", "secret")).To(gomega.BeNil())
+	g.Expect(s.StoreClientSecret(kafka, "canary-kafka-id-1679616000", "secret")).To(gomega.BeNil())
+	g.Expect(s.StoreClientSecret(&dbapi.KafkaRequest{Meta: api.Meta{ID: "other-kafka-id"}}, "canary-other-kafka-id", "secret")).To(gomega.BeNil())
+	// the secrets of the kafka other than the client secrets of its canary service accounts are ignored
+	g.Expect(vaultService.SetSecretString("other-secret", "value", "kafka-id")).To(gomega.Succeed())
+
+	clientIds, err := s.ListClientIds(kafka)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(clientIds).To(gomega.ConsistOf("canary-kafka-id", "canary-kafka-id-1679616000"))
+}
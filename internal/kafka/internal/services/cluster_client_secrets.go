@@ -0,0 +1,89 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/secrets"
+)
+
+// ClusterClientSecretService manages the client secrets of the service accounts the kas-fleetshard operators of the
+// data plane clusters authenticate with. Only the client ids of the service accounts are stored with the clusters,
+// their client secrets are stored in the vault.
+//
+//go:generate moq -out cluster_client_secrets_moq.go . ClusterClientSecretService
+type ClusterClientSecretService interface {
+	// GetClientSecret returns the client secret of the cluster, or an empty string if the cluster has none yet
+	GetClientSecret(cluster *api.Cluster) (string, *errors.ServiceError)
+	// StoreClientSecret stores the client secret of the cluster in the vault
+	StoreClientSecret(cluster *api.Cluster, clientSecret string) *errors.ServiceError
+	// MigrateClientSecret moves the client secret of a cluster created before the secrets were moved to the vault
+	// from the database to the vault. Clusters without such secret are ignored.
+	MigrateClientSecret(cluster *api.Cluster) *errors.ServiceError
+	// DeleteClientSecret deletes the client secret of the cluster. Secrets which do not exist are ignored.
+	DeleteClientSecret(cluster *api.Cluster) *errors.ServiceError
+}
+
+var _ ClusterClientSecretService = &clusterClientSecretService{}
+
+type clusterClientSecretService struct {
+	clusterService ClusterService
+	vaultService   secrets.VaultService
+}
+
+func NewClusterClientSecretService(clusterService ClusterService, vaultService secrets.VaultService) ClusterClientSecretService {
+	return &clusterClientSecretService{
+		clusterService: clusterService,
+		vaultService:   vaultService,
+	}
+}
+
+func (s *clusterClientSecretService) GetClientSecret(cluster *api.Cluster) (string, *errors.ServiceError) {
+	// the client secrets of the clusters created before the secrets were moved to the vault are stored in the
+	// database until they are migrated
+	if cluster.ClientSecret != "" {
+		return cluster.ClientSecret.String(), nil
+	}
+
+	clientSecret, err := s.vaultService.GetSecretString(clusterClientSecretName(cluster.ClusterID))
+	if secrets.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.NewWithCause(errors.ErrorGeneral, err, "failed to get the client secret of cluster %q", cluster.ClusterID)
+	}
+	return clientSecret, nil
+}
+
+func (s *clusterClientSecretService) StoreClientSecret(cluster *api.Cluster, clientSecret string) *errors.ServiceError {
+	if err := s.vaultService.SetSecretString(clusterClientSecretName(cluster.ClusterID), clientSecret, cluster.ClusterID); err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to store the client secret of cluster %q", cluster.ClusterID)
+	}
+	return nil
+}
+
+func (s *clusterClientSecretService) MigrateClientSecret(cluster *api.Cluster) *errors.ServiceError {
+	if cluster.ClientSecret == "" {
+		return nil
+	}
+	if err := s.StoreClientSecret(cluster, cluster.ClientSecret.String()); err != nil {
+		return err
+	}
+	if err := s.clusterService.ClearClientSecret(cluster.ClusterID); err != nil {
+		return err
+	}
+	cluster.ClientSecret = ""
+	return nil
+}
+
+func (s *clusterClientSecretService) DeleteClientSecret(cluster *api.Cluster) *errors.ServiceError {
+	if err := s.vaultService.DeleteSecretString(clusterClientSecretName(cluster.ClusterID)); err != nil && !secrets.IsNotFound(err) {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to delete the client secret of cluster %q", cluster.ClusterID)
+	}
+	return nil
+}
+
+func clusterClientSecretName(clusterID string) string {
+	return fmt.Sprintf("cluster-client-secrets/%s", clusterID)
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/secrets"
+	"github.com/onsi/gomega"
+)
+
+func Test_clusterClientSecretService_GetClientSecret(t *testing.T) {
+	g := gomega.NewWithT(t)
+	vaultService, _ := secrets.NewTmpVaultService()
+	s := NewClusterClientSecretService(&ClusterServiceMock{}, vaultService)
+
+	// the clusters have no client secret until their service account is created
+	secret, err := s.GetClientSecret(&api.Cluster{ClusterID: "cluster-id"})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(secret).To(gomega.BeEmpty())
+
+	// the client secrets which have not been moved to the vault yet are read from the cluster
+	secret, err = s.GetClientSecret(&api.Cluster{ClusterID: "cluster-id", ClientSecret: "legacy-secret"})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(secret).To(gomega.Equal("legacy-secret"))
+
+	g.Expect(s.StoreClientSecret(&api.Cluster{ClusterID: "cluster-id"}, "secret")).To(gomega.BeNil())
+	secret, err = s.GetClientSecret(&api.Cluster{ClusterID: "cluster-id"})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(secret).To(gomega.Equal("secret"))
+
+	names, listErr := vaultService.ListSecretsByOwner("cluster-id")
+	g.Expect(listErr).ToNot(gomega.HaveOccurred())
+	g.Expect(names).To(gomega.Equal([]string{"cluster-client-secrets/cluster-id"}))
+
+	g.Expect(s.DeleteClientSecret(&api.Cluster{ClusterID: "cluster-id"})).To(gomega.BeNil())
+	secret, err = s.GetClientSecret(&api.Cluster{ClusterID: "cluster-id"})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(secret).To(gomega.BeEmpty())
+	// deleting again does not fail
+	g.Expect(s.DeleteClientSecret(&api.Cluster{ClusterID: "cluster-id"})).To(gomega.BeNil())
+}
+
+func Test_clusterClientSecretService_MigrateClientSecret(t *testing.T) {
+	tests := []struct {
+		name             string
+		cluster          api.Cluster
+		clearErr         *errors.ServiceError
+		wantErr          bool
+		wantClearCount   int
+		wantClientSecret string
+	}{
+		{
+			name:    "should ignore the clusters whose client secret is in the vault",
+			cluster: api.Cluster{ClusterID: "cluster-id"},
+		},
+		{
+			name:             "should move the client secret of the cluster to the vault",
+			cluster:          api.Cluster{ClusterID: "cluster-id", ClientSecret: "legacy-secret"},
+			wantClearCount:   1,
+			wantClientSecret: "legacy-secret",
+		},
+		{
+			name:             "should return an error when the client secret of the cluster cannot be cleared",
+			cluster:          api.Cluster{ClusterID: "cluster-id", ClientSecret: "legacy-secret"},
+			clearErr:         errors.GeneralError("test"),
+			wantErr:          true,
+			wantClearCount:   1,
+			wantClientSecret: "legacy-secret",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			vaultService, _ := secrets.NewTmpVaultService()
+			clusterService := &ClusterServiceMock{
+				ClearClientSecretFunc: func(clusterID string) *errors.ServiceError {
+					return tt.clearErr
+				},
+			}
+			s := NewClusterClientSecretService(clusterService, vaultService)
+
+			g.Expect(s.MigrateClientSecret(&tt.cluster) != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(clusterService.ClearClientSecretCalls()).To(gomega.HaveLen(tt.wantClearCount))
+			if tt.wantClientSecret != "" {
+				secret, err := vaultService.GetSecretString("cluster-client-secrets/cluster-id")
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+				g.Expect(secret).To(gomega.Equal(tt.wantClientSecret))
+			}
+			if !tt.wantErr {
+				g.Expect(tt.cluster.ClientSecret.String()).To(gomega.BeEmpty())
+			}
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package services
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/pkg/errors"
+)
+
+// unlimitedStreamingUnits is the maximum number of streaming units of the clusters whose capacity is not limited
+const unlimitedStreamingUnits = -1
+
+// clusterCapacity holds the streaming units of an instance type used by the kafkas of a cluster and the maximum number
+// of streaming units of that instance type the cluster can hold
+type clusterCapacity struct {
+	cluster   *api.Cluster
+	usedUnits int
+	maxUnits  int
+}
+
+// remainingUnitsAfterPlacement returns the streaming units left in the cluster once a kafka consuming the given
+// streaming units is placed on it. It returns -1 when the capacity of the cluster is unlimited.
+func (c clusterCapacity) remainingUnitsAfterPlacement(units int) int {
+	if c.maxUnits == unlimitedStreamingUnits {
+		return unlimitedStreamingUnits
+	}
+	return c.maxUnits - c.usedUnits - units
+}
+
+// loadAfterPlacement returns the share of the capacity of the cluster consumed once a kafka consuming the given
+// streaming units is placed on it. Clusters with an unlimited capacity are never loaded.
+func (c clusterCapacity) loadAfterPlacement(units int) float64 {
+	if c.maxUnits == unlimitedStreamingUnits {
+		return 0
+	}
+	return float64(c.usedUnits+units) / float64(c.maxUnits)
+}
+
+// clusterCapacityFinder returns the managed clusters that have enough capacity to receive a kafka along with their
+// capacity. The capacity of the clusters is evaluated according to the DataPlaneClusterScalingType.
+type clusterCapacityFinder struct {
+	clusterService         ClusterService
+	dataplaneClusterConfig *config.DataplaneClusterConfig
+	kafkaConfig            *config.KafkaConfig
+}
+
+func (f *clusterCapacityFinder) findClustersWithCapacity(kafka *dbapi.KafkaRequest) ([]clusterCapacity, int, error) {
+	criteria := FindClusterCriteria{
+		Provider:              kafka.CloudProvider,
+		Region:                kafka.Region,
+		Status:                api.ClusterReady,
+		SupportedInstanceType: kafka.InstanceType,
+		ExcludeCordoned:       true,
+	}
+
+	instanceSize, err := f.kafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, kafka.SizeId)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "failed to get kafka instance size for cluster with criteria '%v'", criteria)
+	}
+
+	clusters, err := f.clusterService.FindAllClusters(criteria)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "failed to find all clusters with criteria '%v'", criteria)
+	}
+
+	var capacities []clusterCapacity
+	if f.dataplaneClusterConfig.IsDataPlaneManualScalingEnabled() {
+		capacities, err = f.findManualClustersCapacity(clusters)
+	} else {
+		capacities, err = f.findDynamicClustersCapacity(clusters, kafka.InstanceType)
+	}
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "failed to find the capacity of the clusters with criteria '%v'", criteria)
+	}
+
+	var clustersWithCapacity []clusterCapacity
+	for _, capacity := range capacities {
+		if capacity.maxUnits == unlimitedStreamingUnits || capacity.remainingUnitsAfterPlacement(instanceSize.CapacityConsumed) >= 0 {
+			clustersWithCapacity = append(clustersWithCapacity, capacity)
+		}
+	}
+
+	return clustersWithCapacity, instanceSize.CapacityConsumed, nil
+}
+
+// findManualClustersCapacity returns the capacity of the schedulable managed clusters, their limit being the one of
+// the manual cluster configuration
+func (f *clusterCapacityFinder) findManualClustersCapacity(clusters []*api.Cluster) ([]clusterCapacity, error) {
+	var schedulableClusters []*api.Cluster
+	var clusterIDs []string
+	for _, cluster := range clusters {
+		if cluster.ClusterType != api.ManagedDataPlaneClusterType.String() || !f.dataplaneClusterConfig.ClusterConfig.IsClusterSchedulable(cluster.ClusterID) {
+			continue
+		}
+		schedulableClusters = append(schedulableClusters, cluster)
+		clusterIDs = append(clusterIDs, cluster.ClusterID)
+	}
+	if len(clusterIDs) == 0 {
+		return nil, nil
+	}
+
+	instanceCounts, err := f.clusterService.FindKafkaInstanceCount(clusterIDs)
+	if err != nil {
+		return nil, err
+	}
+	usedUnitsPerClusterID := map[string]int{}
+	for _, instanceCount := range instanceCounts {
+		usedUnitsPerClusterID[instanceCount.ClusterID] = instanceCount.Count
+	}
+
+	capacities := make([]clusterCapacity, 0, len(schedulableClusters))
+	for _, cluster := range schedulableClusters {
+		maxUnits, _ := f.dataplaneClusterConfig.ClusterConfig.GetClusterKafkaInstanceLimit(cluster.ClusterID)
+		capacities = append(capacities, clusterCapacity{
+			cluster:   cluster,
+			usedUnits: usedUnitsPerClusterID[cluster.ClusterID],
+			maxUnits:  maxUnits,
+		})
+	}
+	return capacities, nil
+}
+
+// findDynamicClustersCapacity returns the capacity of the managed clusters, their limit being the maximum number of
+// streaming units stored in their dynamic capacity information. Without dynamic scaling, the clusters missing this
+// information are not limited.
+func (f *clusterCapacityFinder) findDynamicClustersCapacity(clusters []*api.Cluster, instanceType string) ([]clusterCapacity, error) {
+	var managedClusters []*api.Cluster
+	for _, cluster := range clusters {
+		if cluster.ClusterType == api.ManagedDataPlaneClusterType.String() {
+			managedClusters = append(managedClusters, cluster)
+		}
+	}
+	if len(managedClusters) == 0 {
+		return nil, nil
+	}
+
+	streamingUnitCounts, err := f.clusterService.FindStreamingUnitCountByClusterAndInstanceType()
+	if err != nil {
+		return nil, err
+	}
+
+	capacities := make([]clusterCapacity, 0, len(managedClusters))
+	for _, cluster := range managedClusters {
+		maxUnits := unlimitedStreamingUnits
+		if capacityInfo, ok := cluster.RetrieveDynamicCapacityInfo()[instanceType]; ok {
+			maxUnits = int(capacityInfo.MaxUnits)
+		} else if f.dataplaneClusterConfig.IsDataPlaneAutoScalingEnabled() {
+			maxUnits = 0
+		}
+		capacities = append(capacities, clusterCapacity{
+			cluster:   cluster,
+			usedUnits: streamingUnitCounts.GetStreamingUnitCountForClusterAndInstanceType(cluster.ClusterID, instanceType),
+			maxUnits:  maxUnits,
+		})
+	}
+	return capacities, nil
+}
+
+// leastLoadedCluster returns the cluster with the lowest share of consumed capacity once the kafka is placed on it.
+// Ties are broken by picking the cluster with the fewest used streaming units, then the oldest cluster.
+func leastLoadedCluster(capacities []clusterCapacity, units int) *api.Cluster {
+	var selected *clusterCapacity
+	for i := range capacities {
+		capacity := &capacities[i]
+		if selected == nil {
+			selected = capacity
+			continue
+		}
+		load, selectedLoad := capacity.loadAfterPlacement(units), selected.loadAfterPlacement(units)
+		if load < selectedLoad || load == selectedLoad && capacity.usedUnits < selected.usedUnits {
+			selected = capacity
+		}
+	}
+	if selected == nil {
+		return nil
+	}
+	return selected.cluster
+}
+
+func filterClusterCapacitiesByMultiAZ(capacities []clusterCapacity, multiAZ bool) []clusterCapacity {
+	var filtered []clusterCapacity
+	for _, capacity := range capacities {
+		if capacity.cluster.MultiAZ == multiAZ {
+			filtered = append(filtered, capacity)
+		}
+	}
+	return filtered
+}
+
+// BestFit finds and returns the ready cluster with the least remaining capacity once the kafka is placed on it,
+// packing the kafkas on as few clusters as possible. Clusters with an unlimited capacity are only picked when no
+// other cluster can receive the kafka.
+type BestFit struct {
+	clusterCapacityFinder
+}
+
+func (f *BestFit) FindCluster(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+	if kafka.DesiredBillingModelIsEnterprise() {
+		enterpriseKafkaPlacementStrategy := findDataPlaneClusterByIdIfItHasCapacityAvailable{
+			clusterService: f.clusterService,
+			kafkaConfig:    f.kafkaConfig,
+		}
+		return enterpriseKafkaPlacementStrategy.FindCluster(kafka)
+	}
+
+	capacities, units, err := f.findClustersWithCapacity(kafka)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected *clusterCapacity
+	for i, capacity := range filterClusterCapacitiesByMultiAZ(capacities, kafka.MultiAZ) {
+		capacity := capacity
+		if i == 0 {
+			selected = &capacity
+			continue
+		}
+		if capacity.maxUnits == unlimitedStreamingUnits {
+			continue
+		}
+		if selected.maxUnits == unlimitedStreamingUnits || capacity.remainingUnitsAfterPlacement(units) < selected.remainingUnitsAfterPlacement(units) {
+			selected = &capacity
+		}
+	}
+	if selected == nil {
+		return nil, nil
+	}
+	return selected.cluster, nil
+}
+
+// LeastLoaded finds and returns the ready cluster with the lowest share of consumed capacity once the kafka is placed
+// on it, spreading the kafkas across the clusters
+type LeastLoaded struct {
+	clusterCapacityFinder
+}
+
+func (f *LeastLoaded) FindCluster(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+	if kafka.DesiredBillingModelIsEnterprise() {
+		enterpriseKafkaPlacementStrategy := findDataPlaneClusterByIdIfItHasCapacityAvailable{
+			clusterService: f.clusterService,
+			kafkaConfig:    f.kafkaConfig,
+		}
+		return enterpriseKafkaPlacementStrategy.FindCluster(kafka)
+	}
+
+	capacities, units, err := f.findClustersWithCapacity(kafka)
+	if err != nil {
+		return nil, err
+	}
+
+	return leastLoadedCluster(filterClusterCapacitiesByMultiAZ(capacities, kafka.MultiAZ), units), nil
+}
+
+// ZoneAware finds and returns the least loaded ready cluster matching the availability zones of the kafka.
+// Multi AZ kafkas are only placed on multi AZ clusters. Single AZ kafkas are placed on single AZ clusters and fall
+// back to multi AZ clusters when none of the single AZ clusters can receive them.
+type ZoneAware struct {
+	clusterCapacityFinder
+}
+
+func (f *ZoneAware) FindCluster(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+	if kafka.DesiredBillingModelIsEnterprise() {
+		enterpriseKafkaPlacementStrategy := findDataPlaneClusterByIdIfItHasCapacityAvailable{
+			clusterService: f.clusterService,
+			kafkaConfig:    f.kafkaConfig,
+		}
+		return enterpriseKafkaPlacementStrategy.FindCluster(kafka)
+	}
+
+	capacities, units, err := f.findClustersWithCapacity(kafka)
+	if err != nil {
+		return nil, err
+	}
+
+	if cluster := leastLoadedCluster(filterClusterCapacitiesByMultiAZ(capacities, kafka.MultiAZ), units); cluster != nil || kafka.MultiAZ {
+		return cluster, nil
+	}
+	return leastLoadedCluster(filterClusterCapacitiesByMultiAZ(capacities, true), units), nil
+}
+
+// instanceTypeClusterPlacementStrategy places the kafkas with the strategy configured for their instance type
+type instanceTypeClusterPlacementStrategy struct {
+	strategies      map[string]ClusterPlacementStrategy
+	defaultStrategy ClusterPlacementStrategy
+}
+
+func (s *instanceTypeClusterPlacementStrategy) FindCluster(kafka *dbapi.KafkaRequest) (*api.Cluster, error) {
+	if strategy, ok := s.strategies[kafka.InstanceType]; ok {
+		return strategy.FindCluster(kafka)
+	}
+	return s.defaultStrategy.FindCluster(kafka)
+}
@@ -18,6 +18,49 @@ type ClusterPlacementStrategy interface {
 
 // NewClusterPlacementStrategy return a concrete strategy impl. depends on the placement configuration
 func NewClusterPlacementStrategy(clusterService ClusterService, dataplaneClusterConfig *config.DataplaneClusterConfig, kafkaConfig *config.KafkaConfig) ClusterPlacementStrategy {
+	defaultStrategy := newFirstFitClusterPlacementStrategy(clusterService, dataplaneClusterConfig, kafkaConfig)
+	if len(dataplaneClusterConfig.ClusterPlacementStrategies) == 0 {
+		return defaultStrategy
+	}
+
+	// the strategies are validated along with the data plane cluster configuration
+	strategies := map[string]ClusterPlacementStrategy{}
+	for instanceType, name := range dataplaneClusterConfig.ClusterPlacementStrategies {
+		strategy, err := NewClusterPlacementStrategyByName(name, clusterService, dataplaneClusterConfig, kafkaConfig)
+		if err != nil {
+			strategy = defaultStrategy
+		}
+		strategies[instanceType] = strategy
+	}
+	return &instanceTypeClusterPlacementStrategy{
+		strategies:      strategies,
+		defaultStrategy: defaultStrategy,
+	}
+}
+
+// NewClusterPlacementStrategyByName returns the strategy with the given name, one of config.ClusterPlacementStrategies
+func NewClusterPlacementStrategyByName(name string, clusterService ClusterService, dataplaneClusterConfig *config.DataplaneClusterConfig, kafkaConfig *config.KafkaConfig) (ClusterPlacementStrategy, error) {
+	finder := clusterCapacityFinder{
+		clusterService:         clusterService,
+		dataplaneClusterConfig: dataplaneClusterConfig,
+		kafkaConfig:            kafkaConfig,
+	}
+	switch name {
+	case config.FirstFitClusterPlacementStrategy:
+		return newFirstFitClusterPlacementStrategy(clusterService, dataplaneClusterConfig, kafkaConfig), nil
+	case config.BestFitClusterPlacementStrategy:
+		return &BestFit{finder}, nil
+	case config.LeastLoadedClusterPlacementStrategy:
+		return &LeastLoaded{finder}, nil
+	case config.ZoneAwareClusterPlacementStrategy:
+		return &ZoneAware{finder}, nil
+	default:
+		return nil, errors.Errorf("unknown cluster placement strategy %q, it should be one of %v", name, config.ClusterPlacementStrategies)
+	}
+}
+
+// newFirstFitClusterPlacementStrategy returns the first fit strategy matching the scaling type of the data plane
+func newFirstFitClusterPlacementStrategy(clusterService ClusterService, dataplaneClusterConfig *config.DataplaneClusterConfig, kafkaConfig *config.KafkaConfig) ClusterPlacementStrategy {
 	var clusterSelection ClusterPlacementStrategy
 	switch {
 	case dataplaneClusterConfig.IsDataPlaneManualScalingEnabled():
@@ -104,6 +147,7 @@ func (f *FirstReadyCluster) FindCluster(kafka *dbapi.KafkaRequest) (*api.Cluster
 		MultiAZ:               kafka.MultiAZ,
 		Status:                api.ClusterReady,
 		SupportedInstanceType: kafka.InstanceType,
+		ExcludeCordoned:       true,
 	}
 
 	cluster, err := f.ClusterService.FindCluster(criteria)
@@ -137,6 +181,7 @@ func (f *FirstSchedulableWithinLimit) FindCluster(kafka *dbapi.KafkaRequest) (*a
 		MultiAZ:               kafka.MultiAZ,
 		Status:                api.ClusterReady,
 		SupportedInstanceType: kafka.InstanceType,
+		ExcludeCordoned:       true,
 	}
 
 	kafkaInstanceSize, e := f.kafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, kafka.SizeId)
@@ -237,6 +282,7 @@ func (f *FirstReadyWithCapacity) FindCluster(kafka *dbapi.KafkaRequest) (*api.Cl
 		MultiAZ:               kafka.MultiAZ,
 		Status:                api.ClusterReady,
 		SupportedInstanceType: kafka.InstanceType,
+		ExcludeCordoned:       true,
 	}
 
 	clusters, findAllClusterErr := f.clusterService.FindAllClusters(criteria)
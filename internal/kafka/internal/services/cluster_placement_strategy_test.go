@@ -309,8 +309,9 @@ func TestFirstReadyWithCapacity_FindCluster(t *testing.T) {
 			},
 			want: nil,
 			wantErr: errors.Wrapf(errors.New("failed to find clusters"), fmt.Sprintf("failed to find all clusters with criteria '%v'", FindClusterCriteria{
-				MultiAZ: mockkafkas.BuildKafkaRequest().MultiAZ,
-				Status:  api.ClusterReady,
+				MultiAZ:         mockkafkas.BuildKafkaRequest().MultiAZ,
+				Status:          api.ClusterReady,
+				ExcludeCordoned: true,
 			})),
 		},
 		{
@@ -335,8 +336,9 @@ func TestFirstReadyWithCapacity_FindCluster(t *testing.T) {
 			},
 			want: nil,
 			wantErr: errors.Wrapf(errors.New("failed to retrieve streaming unit count per region and instance type"), fmt.Sprintf("failed to get count of streaming units by cluster and instance type for criteria '%v'", FindClusterCriteria{
-				MultiAZ: mockkafkas.BuildKafkaRequest().MultiAZ,
-				Status:  api.ClusterReady,
+				MultiAZ:         mockkafkas.BuildKafkaRequest().MultiAZ,
+				Status:          api.ClusterReady,
+				ExcludeCordoned: true,
 			})),
 		},
 		{
@@ -375,6 +377,7 @@ func TestFirstReadyWithCapacity_FindCluster(t *testing.T) {
 				MultiAZ:               mockkafkas.BuildKafkaRequest().MultiAZ,
 				Status:                api.ClusterReady,
 				SupportedInstanceType: "unsupported",
+				ExcludeCordoned:       true,
 			})),
 		},
 		{
@@ -804,3 +807,277 @@ func Test_findDesiredDataPlaneClusterIfItHasCapacityAvailable_FindCluster(t *tes
 		})
 	}
 }
+
+func buildClusterPlacementTestKafkaConfig() *config.KafkaConfig {
+	return &config.KafkaConfig{
+		SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
+			Configuration: config.SupportedKafkaInstanceTypesConfig{
+				SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+					{
+						Id: types.STANDARD.String(),
+						Sizes: []config.KafkaInstanceSize{
+							{
+								Id:               "x1",
+								CapacityConsumed: 1,
+							},
+							{
+								Id:               "x2",
+								CapacityConsumed: 2,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func buildClusterPlacementTestClusterService(clusters []*api.Cluster, streamingUnitCounts KafkaStreamingUnitCountPerClusterList) *ClusterServiceMock {
+	return &ClusterServiceMock{
+		FindAllClustersFunc: func(criteria FindClusterCriteria) ([]*api.Cluster, error) {
+			return clusters, nil
+		},
+		FindStreamingUnitCountByClusterAndInstanceTypeFunc: func() (KafkaStreamingUnitCountPerClusterList, error) {
+			return streamingUnitCounts, nil
+		},
+		FindKafkaInstanceCountFunc: func(clusterIDs []string) ([]ResKafkaInstanceCount, error) {
+			var counts []ResKafkaInstanceCount
+			for _, count := range streamingUnitCounts {
+				counts = append(counts, ResKafkaInstanceCount{ClusterID: count.ClusterId, Count: int(count.Count)})
+			}
+			return counts, nil
+		},
+	}
+}
+
+func buildClusterPlacementTestCluster(clusterID string, multiAZ bool, maxUnits int) *api.Cluster {
+	return &api.Cluster{
+		ClusterID:           clusterID,
+		ClusterType:         api.ManagedDataPlaneClusterType.String(),
+		MultiAZ:             multiAZ,
+		DynamicCapacityInfo: api.JSON([]byte(fmt.Sprintf(`{"standard":{"max_nodes":1,"max_units":%d,"remaining_units":%d}}`, maxUnits, maxUnits))),
+	}
+}
+
+func TestClusterPlacementStrategies_FindCluster(t *testing.T) {
+	autoScalingConfig := config.NewDataplaneClusterConfig()
+	autoScalingConfig.DataPlaneClusterScalingType = config.AutoScaling
+
+	manualScalingConfig := config.NewDataplaneClusterConfig()
+	manualScalingConfig.DataPlaneClusterScalingType = config.ManualScaling
+	manualScalingConfig.ClusterConfig = config.NewClusterConfig(config.ClusterList{
+		{ClusterId: "cluster-1", Schedulable: true, KafkaInstanceLimit: 4},
+		{ClusterId: "cluster-2", Schedulable: true, KafkaInstanceLimit: 10},
+		{ClusterId: "cluster-3", Schedulable: false, KafkaInstanceLimit: 10},
+	})
+
+	singleAZKafka := mockkafkas.BuildKafkaRequest(
+		mockkafkas.With(mockkafkas.INSTANCE_TYPE, types.STANDARD.String()),
+		mockkafkas.With(mockkafkas.SIZE_ID, "x2"),
+		mockkafkas.WithMultiAZ(false),
+	)
+	multiAZKafka := mockkafkas.BuildKafkaRequest(
+		mockkafkas.With(mockkafkas.INSTANCE_TYPE, types.STANDARD.String()),
+		mockkafkas.With(mockkafkas.SIZE_ID, "x2"),
+		mockkafkas.WithMultiAZ(true),
+	)
+
+	// cluster-1 has 2 units left, cluster-2 has 6 units left, cluster-3 has 1 unit left
+	clusters := []*api.Cluster{
+		buildClusterPlacementTestCluster("cluster-1", true, 4),
+		buildClusterPlacementTestCluster("cluster-2", true, 10),
+		buildClusterPlacementTestCluster("cluster-3", true, 10),
+	}
+	streamingUnitCounts := KafkaStreamingUnitCountPerClusterList{
+		{ClusterId: "cluster-1", InstanceType: types.STANDARD.String(), Count: 2},
+		{ClusterId: "cluster-2", InstanceType: types.STANDARD.String(), Count: 4},
+		{ClusterId: "cluster-3", InstanceType: types.STANDARD.String(), Count: 9},
+	}
+
+	tests := []struct {
+		name                   string
+		strategy               string
+		dataplaneClusterConfig *config.DataplaneClusterConfig
+		clusterService         ClusterService
+		kafka                  *dbapi.KafkaRequest
+		wantClusterID          string
+		wantErr                bool
+	}{
+		{
+			name:                   "best fit should pick the cluster with the least remaining capacity",
+			strategy:               config.BestFitClusterPlacementStrategy,
+			dataplaneClusterConfig: autoScalingConfig,
+			clusterService:         buildClusterPlacementTestClusterService(clusters, streamingUnitCounts),
+			kafka:                  multiAZKafka,
+			wantClusterID:          "cluster-1",
+		},
+		{
+			name:                   "least loaded should pick the cluster with the lowest share of consumed capacity",
+			strategy:               config.LeastLoadedClusterPlacementStrategy,
+			dataplaneClusterConfig: autoScalingConfig,
+			clusterService:         buildClusterPlacementTestClusterService(clusters, streamingUnitCounts),
+			kafka:                  multiAZKafka,
+			wantClusterID:          "cluster-2",
+		},
+		{
+			name:                   "best fit should only consider the schedulable clusters within their limit when manual scaling is enabled",
+			strategy:               config.BestFitClusterPlacementStrategy,
+			dataplaneClusterConfig: manualScalingConfig,
+			clusterService:         buildClusterPlacementTestClusterService(clusters, streamingUnitCounts),
+			kafka:                  multiAZKafka,
+			wantClusterID:          "cluster-1",
+		},
+		{
+			name:                   "least loaded should only consider the schedulable clusters within their limit when manual scaling is enabled",
+			strategy:               config.LeastLoadedClusterPlacementStrategy,
+			dataplaneClusterConfig: manualScalingConfig,
+			clusterService:         buildClusterPlacementTestClusterService(clusters, streamingUnitCounts),
+			kafka:                  multiAZKafka,
+			wantClusterID:          "cluster-2",
+		},
+		{
+			name:                   "best fit should not pick a cluster with a different availability zones setting",
+			strategy:               config.BestFitClusterPlacementStrategy,
+			dataplaneClusterConfig: autoScalingConfig,
+			clusterService:         buildClusterPlacementTestClusterService(clusters, streamingUnitCounts),
+			kafka:                  singleAZKafka,
+			wantClusterID:          "",
+		},
+		{
+			name:                   "zone aware should fall back to multi AZ clusters for single AZ kafkas",
+			strategy:               config.ZoneAwareClusterPlacementStrategy,
+			dataplaneClusterConfig: autoScalingConfig,
+			clusterService:         buildClusterPlacementTestClusterService(clusters, streamingUnitCounts),
+			kafka:                  singleAZKafka,
+			wantClusterID:          "cluster-2",
+		},
+		{
+			name:                   "zone aware should prefer single AZ clusters for single AZ kafkas",
+			strategy:               config.ZoneAwareClusterPlacementStrategy,
+			dataplaneClusterConfig: autoScalingConfig,
+			clusterService: buildClusterPlacementTestClusterService(
+				append([]*api.Cluster{buildClusterPlacementTestCluster("cluster-4", false, 4)}, clusters...),
+				append(KafkaStreamingUnitCountPerClusterList{{ClusterId: "cluster-4", InstanceType: types.STANDARD.String(), Count: 2}}, streamingUnitCounts...),
+			),
+			kafka:         singleAZKafka,
+			wantClusterID: "cluster-4",
+		},
+		{
+			name:                   "zone aware should not place multi AZ kafkas on single AZ clusters",
+			strategy:               config.ZoneAwareClusterPlacementStrategy,
+			dataplaneClusterConfig: autoScalingConfig,
+			clusterService: buildClusterPlacementTestClusterService(
+				[]*api.Cluster{buildClusterPlacementTestCluster("cluster-4", false, 4)},
+				KafkaStreamingUnitCountPerClusterList{},
+			),
+			kafka:         multiAZKafka,
+			wantClusterID: "",
+		},
+		{
+			name:                   "should return nil if no cluster has enough capacity",
+			strategy:               config.LeastLoadedClusterPlacementStrategy,
+			dataplaneClusterConfig: autoScalingConfig,
+			clusterService: buildClusterPlacementTestClusterService(
+				[]*api.Cluster{buildClusterPlacementTestCluster("cluster-3", true, 10)},
+				KafkaStreamingUnitCountPerClusterList{{ClusterId: "cluster-3", InstanceType: types.STANDARD.String(), Count: 9}},
+			),
+			kafka:         multiAZKafka,
+			wantClusterID: "",
+		},
+		{
+			name:                   "should return an error if finding the clusters fails",
+			strategy:               config.BestFitClusterPlacementStrategy,
+			dataplaneClusterConfig: autoScalingConfig,
+			clusterService: &ClusterServiceMock{
+				FindAllClustersFunc: func(criteria FindClusterCriteria) ([]*api.Cluster, error) {
+					return nil, errors.New("failed to find clusters")
+				},
+			},
+			kafka:   multiAZKafka,
+			wantErr: true,
+		},
+		{
+			name:                   "should return an error if the size of the kafka is not supported",
+			strategy:               config.ZoneAwareClusterPlacementStrategy,
+			dataplaneClusterConfig: autoScalingConfig,
+			clusterService:         buildClusterPlacementTestClusterService(clusters, streamingUnitCounts),
+			kafka: mockkafkas.BuildKafkaRequest(
+				mockkafkas.With(mockkafkas.INSTANCE_TYPE, types.STANDARD.String()),
+				mockkafkas.With(mockkafkas.SIZE_ID, "unsupported"),
+			),
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			strategy, err := NewClusterPlacementStrategyByName(tt.strategy, tt.clusterService, tt.dataplaneClusterConfig, buildClusterPlacementTestKafkaConfig())
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+
+			cluster, err := strategy.FindCluster(tt.kafka)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantClusterID == "" {
+				g.Expect(cluster).To(gomega.BeNil())
+			} else {
+				g.Expect(cluster).ToNot(gomega.BeNil())
+				g.Expect(cluster.ClusterID).To(gomega.Equal(tt.wantClusterID))
+			}
+		})
+	}
+}
+
+func TestNewClusterPlacementStrategy(t *testing.T) {
+	clusterService := &ClusterServiceMock{}
+	kafkaConfig := buildClusterPlacementTestKafkaConfig()
+
+	tests := []struct {
+		name                       string
+		clusterPlacementStrategies map[string]string
+		instanceType               string
+		want                       ClusterPlacementStrategy
+	}{
+		{
+			name:         "should return the first fit strategy if no strategy is configured",
+			instanceType: types.STANDARD.String(),
+			want:         &FirstSchedulableWithinLimit{},
+		},
+		{
+			name:                       "should use the strategy configured for the instance type of the kafka",
+			clusterPlacementStrategies: map[string]string{types.STANDARD.String(): config.BestFitClusterPlacementStrategy},
+			instanceType:               types.STANDARD.String(),
+			want:                       &BestFit{},
+		},
+		{
+			name:                       "should use the first fit strategy for the instance types without configured strategy",
+			clusterPlacementStrategies: map[string]string{types.STANDARD.String(): config.BestFitClusterPlacementStrategy},
+			instanceType:               types.DEVELOPER.String(),
+			want:                       &FirstSchedulableWithinLimit{},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			dataplaneClusterConfig := config.NewDataplaneClusterConfig()
+			dataplaneClusterConfig.ClusterPlacementStrategies = tt.clusterPlacementStrategies
+
+			strategy := NewClusterPlacementStrategy(clusterService, dataplaneClusterConfig, kafkaConfig)
+			if instanceTypeStrategy, ok := strategy.(*instanceTypeClusterPlacementStrategy); ok {
+				strategy = instanceTypeStrategy.defaultStrategy
+				if s, ok := instanceTypeStrategy.strategies[tt.instanceType]; ok {
+					strategy = s
+				}
+			}
+			g.Expect(strategy).To(gomega.BeAssignableToTypeOf(tt.want))
+		})
+	}
+
+	t.Run("should return an error for an unknown strategy", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		_, err := NewClusterPlacementStrategyByName("unknown", clusterService, config.NewDataplaneClusterConfig(), kafkaConfig)
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+)
+
+// ClusterScalingDecisionService stores the decisions taken by the dynamic scale down about the data plane clusters so
+// that administrators can find out why a cluster was or was not removed.
+//
+//go:generate moq -out cluster_scaling_decisions_moq.go . ClusterScalingDecisionService
+type ClusterScalingDecisionService interface {
+	// Record appends the decision to the history of its cluster. As the scale down evaluates the clusters on every
+	// reconcile, the decision is not stored when it has the same outcome as the latest decision about the cluster.
+	Record(decision *dbapi.ClusterScalingDecision) *errors.ServiceError
+	// ListByClusterId returns the decisions about the given cluster, the most recent first
+	ListByClusterId(clusterId string, listArgs *coreServices.ListArguments) (dbapi.ClusterScalingDecisionList, *api.PagingMeta, *errors.ServiceError)
+}
+
+var _ ClusterScalingDecisionService = &clusterScalingDecisionService{}
+
+type clusterScalingDecisionService struct {
+	connectionFactory *db.ConnectionFactory
+}
+
+func NewClusterScalingDecisionService(connectionFactory *db.ConnectionFactory) ClusterScalingDecisionService {
+	return &clusterScalingDecisionService{
+		connectionFactory: connectionFactory,
+	}
+}
+
+func (s *clusterScalingDecisionService) Record(decision *dbapi.ClusterScalingDecision) *errors.ServiceError {
+	dbConn := s.connectionFactory.New()
+
+	var latest dbapi.ClusterScalingDecisionList
+	if err := dbConn.Where("cluster_id = ?", decision.ClusterId).Order("created_at desc").Limit(1).Find(&latest).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to find the latest scaling decision of cluster %q", decision.ClusterId)
+	}
+
+	if len(latest) > 0 && decision.HasSameOutcomeAs(latest[0]) {
+		return nil
+	}
+
+	if err := dbConn.Create(decision).Error; err != nil {
+		return coreServices.HandleCreateError("cluster scaling decision", err)
+	}
+
+	return nil
+}
+
+func (s *clusterScalingDecisionService) ListByClusterId(clusterId string, listArgs *coreServices.ListArguments) (dbapi.ClusterScalingDecisionList, *api.PagingMeta, *errors.ServiceError) {
+	var decisions dbapi.ClusterScalingDecisionList
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+
+	dbConn := s.connectionFactory.New().Model(&dbapi.ClusterScalingDecision{}).Where("cluster_id = ?", clusterId)
+
+	var total int64
+	if err := dbConn.Count(&total).Error; err != nil {
+		return nil, nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to count the scaling decisions of cluster %q", clusterId)
+	}
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+
+	if err := dbConn.Order("created_at desc").Offset((pagingMeta.Page - 1) * listArgs.Size).Limit(listArgs.Size).Find(&decisions).Error; err != nil {
+		return nil, nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list the scaling decisions of cluster %q", clusterId)
+	}
+
+	return decisions, pagingMeta, nil
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_clusterScalingDecisionService_Record(t *testing.T) {
+	latestDecisionQuery := `SELECT * FROM "cluster_scaling_decisions" WHERE cluster_id = $1 ORDER BY created_at desc LIMIT 1`
+	latestDecision := []map[string]interface{}{
+		{"id": "decision-1", "cluster_id": "cluster-id", "decision": "kept", "reason": "the cluster is not empty", "dry_run": false},
+	}
+
+	tests := []struct {
+		name           string
+		decision       *dbapi.ClusterScalingDecision
+		latestDecision []map[string]interface{}
+		wantInserted   bool
+	}{
+		{
+			name:         "should record the first decision about a cluster",
+			decision:     &dbapi.ClusterScalingDecision{ClusterId: "cluster-id", Decision: dbapi.ClusterScalingDecisionKept, Reason: "the cluster is not empty"},
+			wantInserted: true,
+		},
+		{
+			name:           "should record a new decision",
+			decision:       &dbapi.ClusterScalingDecision{ClusterId: "cluster-id", Decision: dbapi.ClusterScalingDecisionCordoned, Reason: "the cluster is empty"},
+			latestDecision: latestDecision,
+			wantInserted:   true,
+		},
+		{
+			name:           "should record the same decision for another reason",
+			decision:       &dbapi.ClusterScalingDecision{ClusterId: "cluster-id", Decision: dbapi.ClusterScalingDecisionKept, Reason: "removing the cluster would trigger a scale up"},
+			latestDecision: latestDecision,
+			wantInserted:   true,
+		},
+		{
+			name:           "should not record a decision with the same outcome as the latest decision",
+			decision:       &dbapi.ClusterScalingDecision{ClusterId: "cluster-id", Decision: dbapi.ClusterScalingDecisionKept, Reason: "the cluster is not empty"},
+			latestDecision: latestDecision,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(latestDecisionQuery).WithReply(tt.latestDecision)
+			insert := mocket.Catcher.NewMock().WithQuery(`INSERT INTO "cluster_scaling_decisions"`)
+
+			err := NewClusterScalingDecisionService(db.NewMockConnectionFactory(nil)).Record(tt.decision)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(insert.Triggered).To(gomega.Equal(tt.wantInserted))
+		})
+	}
+}
+
+func Test_clusterScalingDecisionService_ListByClusterId(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	mocket.Catcher.Reset()
+	mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "cluster_scaling_decisions" WHERE cluster_id = $1`).WithArgs("cluster-id").WithReply([]map[string]interface{}{{"count": 2}})
+	mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "cluster_scaling_decisions" WHERE cluster_id = $1 ORDER BY created_at desc`).WithArgs("cluster-id").WithReply([]map[string]interface{}{
+		{"id": "decision-2", "cluster_id": "cluster-id", "decision": "cordoned", "reason": "the cluster is empty"},
+		{"id": "decision-1", "cluster_id": "cluster-id", "decision": "kept", "reason": "the cluster is not empty"},
+	})
+
+	decisions, paging, err := NewClusterScalingDecisionService(db.NewMockConnectionFactory(nil)).ListByClusterId("cluster-id", &coreServices.ListArguments{Page: 1, Size: 100})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(paging.Total).To(gomega.Equal(2))
+	g.Expect(paging.Size).To(gomega.Equal(2))
+	g.Expect(decisions).To(gomega.Equal(dbapi.ClusterScalingDecisionList{
+		{ID: "decision-2", ClusterId: "cluster-id", Decision: dbapi.ClusterScalingDecisionCordoned, Reason: "the cluster is empty"},
+		{ID: "decision-1", ClusterId: "cluster-id", Decision: dbapi.ClusterScalingDecisionKept, Reason: "the cluster is not empty"},
+	}))
+}
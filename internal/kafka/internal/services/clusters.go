@@ -5,9 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/queryparser"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
@@ -30,6 +34,12 @@ import (
 
 var kafkaStatusesThatNoLongerConsumeResourcesInTheDataPlane = []string{constants.KafkaRequestStatusDeleting.String()}
 
+// ValidClusterColumns are the columns of the clusters that can be used in the search query of the admin API
+var ValidClusterColumns = []string{"cluster_id", "external_id", "cloud_provider", "region", "status", "cluster_type", "provider_type", "organization_id", "supported_instance_type"}
+
+// AcceptedClusterOrderByParams are the accepted values of the orderBy parameter when listing the clusters
+var AcceptedClusterOrderByParams = append([]string{"created_at", "updated_at"}, ValidClusterColumns...)
+
 //go:generate moq -out clusterservice_moq.go . ClusterService
 type ClusterService interface {
 	Create(cluster *api.Cluster) (*api.Cluster, *apiErrors.ServiceError)
@@ -42,6 +52,24 @@ type ClusterService interface {
 	// Update updates a Cluster. Only fields whose value is different than the
 	// zero-value of their corresponding type will be updated
 	Update(cluster api.Cluster) *apiErrors.ServiceError
+	// UpdateEnterpriseClusterSettings updates the settings the owner of an enterprise cluster can change after its
+	// registration: the network access of its kafkas and its dynamic capacity information. Unlike Update, zero values
+	// are updated as well.
+	UpdateEnterpriseClusterSettings(cluster api.Cluster) *apiErrors.ServiceError
+	// UpdateCordonedAt cordons the cluster when cordonedAt is set and uncordons it otherwise. The cordoned clusters are
+	// left out of the placement of new kafkas.
+	UpdateCordonedAt(clusterID string, cordonedAt *time.Time) *apiErrors.ServiceError
+	// UpdateManuallyCordoned cordons the cluster on behalf of an administrator when cordoned is true and uncordons it
+	// otherwise. A manually cordoned cluster is never uncordoned by the dynamic scale down.
+	UpdateManuallyCordoned(clusterID string, cordoned bool) *apiErrors.ServiceError
+	// UpdateResourcesReconcileRequestedAt requests the resources of the cluster to be reconciled when requestedAt is set
+	// and withdraws the request otherwise
+	UpdateResourcesReconcileRequestedAt(clusterID string, requestedAt *time.Time) *apiErrors.ServiceError
+	// WithdrawResourcesReconcileRequest withdraws the request to reconcile the resources of the cluster made at
+	// requestedAt. A newer request is kept.
+	WithdrawResourcesReconcileRequest(clusterID string, requestedAt time.Time) *apiErrors.ServiceError
+	// ClearClientSecret removes the client secret stored with the cluster once it has been moved to the vault
+	ClearClientSecret(clusterID string) *apiErrors.ServiceError
 	FindCluster(criteria FindClusterCriteria) (*api.Cluster, error)
 	// FindClusterByID returns the cluster corresponding to the provided clusterID.
 	// If the cluster has not been found nil is returned. If there has been an issue
@@ -49,6 +77,8 @@ type ClusterService interface {
 	FindClusterByID(clusterID string) (*api.Cluster, *apiErrors.ServiceError)
 	GetClientID(clusterID string) (string, error)
 	ListGroupByProviderAndRegion(providers []string, regions []string, status []string) ([]*ResGroupCPRegion, *apiErrors.ServiceError)
+	// RegisterClusterJob registers a new job in the cluster table. The webhook subscriptions of the organisation of an
+	// enterprise cluster are notified of its registration, as well as of its later changes of status.
 	RegisterClusterJob(clusterRequest *api.Cluster) *apiErrors.ServiceError
 	DeregisterClusterJob(clusterID string) *apiErrors.ServiceError
 	// DeleteByClusterID will delete the cluster from the database
@@ -64,6 +94,9 @@ type ClusterService interface {
 	ListNonEnterpriseClusterIDs() ([]api.Cluster, *apiErrors.ServiceError)
 	// FindAllClusters return all the valid clusters in array
 	FindAllClusters(criteria FindClusterCriteria) ([]*api.Cluster, error)
+	// List returns the clusters matching the search query of the list arguments. The clusters are ordered by creation
+	// date unless a different order is requested.
+	List(listArgs *coreServices.ListArguments) (api.ClusterList, *api.PagingMeta, *apiErrors.ServiceError)
 	// FindKafkaInstanceCount returns the kafka instance counts associated with the list of clusters. If the list is empty, it will list all clusterIDs that have Kafka instances assigned.
 	// Kafkas that are in deleting state won't be included in the count as they no longer consume resources in the data plane cluster.
 	FindKafkaInstanceCount(clusterIDs []string) ([]ResKafkaInstanceCount, error)
@@ -101,14 +134,16 @@ type clusterService struct {
 	connectionFactory *db.ConnectionFactory
 	providerFactory   clusters.ProviderFactory
 	kafkaConfig       *config.KafkaConfig
+	webhookService    webhooks.WebhookService
 }
 
 // NewClusterService creates a new client for the OSD Cluster Service
-func NewClusterService(connectionFactory *db.ConnectionFactory, providerFactory clusters.ProviderFactory, kafkaConfig *config.KafkaConfig) ClusterService {
+func NewClusterService(connectionFactory *db.ConnectionFactory, providerFactory clusters.ProviderFactory, kafkaConfig *config.KafkaConfig, webhookService webhooks.WebhookService) ClusterService {
 	return &clusterService{
 		connectionFactory: connectionFactory,
 		providerFactory:   providerFactory,
 		kafkaConfig:       kafkaConfig,
+		webhookService:    webhookService,
 	}
 }
 
@@ -118,6 +153,9 @@ func (c clusterService) RegisterClusterJob(clusterRequest *api.Cluster) *apiErro
 	if err := dbConn.Save(clusterRequest).Error; err != nil {
 		return apiErrors.NewWithCause(apiErrors.ErrorGeneral, err, "failed to register cluster job")
 	}
+	if clusterRequest.ClusterType == api.EnterpriseDataPlaneClusterType.String() {
+		c.notifyEnterpriseClusterStatusChange(&api.Cluster{ClusterID: clusterRequest.ClusterID, OrganizationID: clusterRequest.OrganizationID}, clusterRequest.Status)
+	}
 	return nil
 }
 
@@ -247,6 +285,11 @@ func (c clusterService) Update(cluster api.Cluster) *apiErrors.ServiceError {
 		return apiErrors.Validation("id is undefined")
 	}
 
+	var enterpriseCluster *api.Cluster
+	if cluster.Status != "" {
+		enterpriseCluster = c.findEnterpriseCluster("id = ?", cluster.ID)
+	}
+
 	// by specifying the Model with a non-empty primary key we ensure
 	// only the record with that primary key is updated
 	dbConn := c.connectionFactory.New().Model(cluster)
@@ -255,6 +298,8 @@ func (c clusterService) Update(cluster api.Cluster) *apiErrors.ServiceError {
 		return apiErrors.NewWithCause(apiErrors.ErrorGeneral, err, "failed to update cluster")
 	}
 
+	c.notifyEnterpriseClusterStatusChange(enterpriseCluster, cluster.Status)
+
 	return nil
 }
 
@@ -280,6 +325,8 @@ func (c clusterService) UpdateStatus(cluster api.Cluster, status api.ClusterStat
 		query, arg = "cluster_id = ?", cluster.ClusterID
 	}
 
+	enterpriseCluster := c.findEnterpriseCluster(query, arg)
+
 	if err := dbConn.Model(&api.Cluster{}).Where(query, arg).Updates(map[string]interface{}{"status": status}).Error; err != nil {
 		return apiErrors.NewWithCause(apiErrors.ErrorGeneral, err, "failed to update cluster status")
 	}
@@ -288,9 +335,54 @@ func (c clusterService) UpdateStatus(cluster api.Cluster, status api.ClusterStat
 		metrics.IncreaseClusterSuccessOperationsCountMetric(constants.ClusterOperationCreate)
 	}
 
+	c.notifyEnterpriseClusterStatusChange(enterpriseCluster, status)
+
 	return nil
 }
 
+// findEnterpriseCluster returns the id, organization and current status of the enterprise cluster matching the query.
+// Nil is returned if no enterprise cluster matches the query.
+func (c clusterService) findEnterpriseCluster(query string, arg string) *api.Cluster {
+	var enterpriseClusters []*api.Cluster
+	if err := c.connectionFactory.New().
+		Select("cluster_id", "organization_id", "status").
+		Where(query, arg).
+		Where("cluster_type = ?", api.EnterpriseDataPlaneClusterType.String()).
+		Limit(1).
+		Find(&enterpriseClusters).Error; err != nil {
+		logger.Logger.Errorf("failed to find enterprise cluster with %s %q: %v", query, arg, err)
+		return nil
+	}
+	if len(enterpriseClusters) == 0 {
+		return nil
+	}
+	return enterpriseClusters[0]
+}
+
+// notifyEnterpriseClusterStatusChange notifies the webhook subscriptions of the organisation of the given enterprise
+// cluster of its new status, if it differs from the status of the cluster. Failures are only logged as the status
+// has been updated already.
+func (c clusterService) notifyEnterpriseClusterStatusChange(enterpriseCluster *api.Cluster, status api.ClusterStatus) {
+	if enterpriseCluster == nil || enterpriseCluster.Status == status {
+		return
+	}
+
+	event := &webhooks.Event{
+		Type:           webhooks.EnterpriseClusterPhaseChanged,
+		OrganisationId: enterpriseCluster.OrganizationID,
+		Resource: webhooks.Resource{
+			Id:   enterpriseCluster.ClusterID,
+			Kind: "Cluster",
+			Href: fmt.Sprintf("/api/kafkas_mgmt/v1/clusters/%s", enterpriseCluster.ClusterID),
+		},
+		PreviousPhase: enterpriseCluster.Status.String(),
+		Phase:         status.String(),
+	}
+	if err := c.webhookService.Notify(event); err != nil {
+		logger.Logger.Errorf("failed to notify the webhook subscriptions of the status %q of enterprise cluster %q: %v", status, enterpriseCluster.ClusterID, err)
+	}
+}
+
 type ResGroupCPRegion struct {
 	Provider string
 	Region   string
@@ -325,6 +417,140 @@ type FindClusterCriteria struct {
 	Status                api.ClusterStatus
 	SupportedInstanceType string
 	ExternalID            string
+	// ExcludeCordoned leaves out the cordoned clusters as they must not receive new kafkas
+	ExcludeCordoned bool
+	// ResourcesReconcileRequested only keeps the clusters whose resources an administrator requested to reconcile
+	ResourcesReconcileRequested bool
+}
+
+func (c clusterService) UpdateEnterpriseClusterSettings(cluster api.Cluster) *apiErrors.ServiceError {
+	if cluster.ID == "" {
+		return apiErrors.Validation("id is undefined")
+	}
+
+	dbConn := c.connectionFactory.New().Model(&cluster).Select("access_kafkas_via_private_network", "dynamic_capacity_info")
+	if err := dbConn.Updates(cluster).Error; err != nil {
+		return apiErrors.NewWithCause(apiErrors.ErrorGeneral, err, "failed to update settings of enterprise cluster %q", cluster.ClusterID)
+	}
+
+	return nil
+}
+
+func (c clusterService) UpdateCordonedAt(clusterID string, cordonedAt *time.Time) *apiErrors.ServiceError {
+	if clusterID == "" {
+		return apiErrors.Validation("clusterID is undefined")
+	}
+
+	// cordoned_at is updated even when it is nil in order to uncordon the cluster
+	dbConn := c.connectionFactory.New().Model(&api.Cluster{}).Where("cluster_id = ?", clusterID)
+	if err := dbConn.Update("cordoned_at", cordonedAt).Error; err != nil {
+		return apiErrors.NewWithCause(apiErrors.ErrorGeneral, err, "failed to update the cordoned time of cluster %q", clusterID)
+	}
+
+	return nil
+}
+
+func (c clusterService) ClearClientSecret(clusterID string) *apiErrors.ServiceError {
+	if clusterID == "" {
+		return apiErrors.Validation("clusterID is undefined")
+	}
+
+	dbConn := c.connectionFactory.New().Model(&api.Cluster{}).Where("cluster_id = ?", clusterID)
+	if err := dbConn.Update("client_secret", "").Error; err != nil {
+		return apiErrors.NewWithCause(apiErrors.ErrorGeneral, err, "failed to clear the client secret of cluster %q", clusterID)
+	}
+
+	return nil
+}
+
+func (c clusterService) UpdateManuallyCordoned(clusterID string, cordoned bool) *apiErrors.ServiceError {
+	if clusterID == "" {
+		return apiErrors.Validation("clusterID is undefined")
+	}
+
+	updates := map[string]interface{}{"manually_cordoned": cordoned}
+	if cordoned {
+		// a cluster already cordoned by the dynamic scale down keeps its original cordoned time
+		updates["cordoned_at"] = gorm.Expr("COALESCE(cordoned_at, ?)", time.Now())
+	} else {
+		updates["cordoned_at"] = nil
+	}
+
+	dbConn := c.connectionFactory.New().Model(&api.Cluster{}).Where("cluster_id = ?", clusterID)
+	if err := dbConn.Updates(updates).Error; err != nil {
+		return apiErrors.NewWithCause(apiErrors.ErrorGeneral, err, "failed to update the cordon of cluster %q", clusterID)
+	}
+
+	return nil
+}
+
+func (c clusterService) UpdateResourcesReconcileRequestedAt(clusterID string, requestedAt *time.Time) *apiErrors.ServiceError {
+	if clusterID == "" {
+		return apiErrors.Validation("clusterID is undefined")
+	}
+
+	// resources_reconcile_requested_at is updated even when it is nil in order to withdraw the request
+	dbConn := c.connectionFactory.New().Model(&api.Cluster{}).Where("cluster_id = ?", clusterID)
+	if err := dbConn.Update("resources_reconcile_requested_at", requestedAt).Error; err != nil {
+		return apiErrors.NewWithCause(apiErrors.ErrorGeneral, err, "failed to request the reconcile of the resources of cluster %q", clusterID)
+	}
+
+	return nil
+}
+
+func (c clusterService) WithdrawResourcesReconcileRequest(clusterID string, requestedAt time.Time) *apiErrors.ServiceError {
+	if clusterID == "" {
+		return apiErrors.Validation("clusterID is undefined")
+	}
+
+	dbConn := c.connectionFactory.New().Model(&api.Cluster{}).
+		Where("cluster_id = ?", clusterID).
+		Where("resources_reconcile_requested_at = ?", requestedAt)
+	if err := dbConn.Update("resources_reconcile_requested_at", nil).Error; err != nil {
+		return apiErrors.NewWithCause(apiErrors.ErrorGeneral, err, "failed to withdraw the request to reconcile the resources of cluster %q", clusterID)
+	}
+
+	return nil
+}
+
+func (c clusterService) List(listArgs *coreServices.ListArguments) (api.ClusterList, *api.PagingMeta, *apiErrors.ServiceError) {
+	var clusters api.ClusterList
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+
+	dbConn := c.connectionFactory.New().Model(&api.Cluster{})
+
+	if len(listArgs.Search) > 0 {
+		searchDbQuery, err := queryparser.NewQueryParser(ValidClusterColumns...).Parse(listArgs.Search)
+		if err != nil {
+			return nil, nil, apiErrors.NewWithCause(apiErrors.ErrorFailedToParseSearch, err, "unable to list clusters: %s", err.Error())
+		}
+		dbConn = dbConn.Where(searchDbQuery.Query, searchDbQuery.Values...)
+	}
+
+	var total int64
+	if err := dbConn.Count(&total).Error; err != nil {
+		return nil, nil, apiErrors.NewWithCause(apiErrors.ErrorGeneral, err, "unable to count clusters")
+	}
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+
+	if len(listArgs.OrderBy) == 0 {
+		dbConn = dbConn.Order("created_at asc")
+	}
+	for _, orderByArg := range listArgs.OrderBy {
+		dbConn = dbConn.Order(orderByArg)
+	}
+
+	if err := dbConn.Offset((pagingMeta.Page - 1) * listArgs.Size).Limit(listArgs.Size).Find(&clusters).Error; err != nil {
+		return nil, nil, apiErrors.NewWithCause(apiErrors.ErrorGeneral, err, "unable to list clusters")
+	}
+
+	return clusters, pagingMeta, nil
 }
 
 func (c clusterService) FindCluster(criteria FindClusterCriteria) (*api.Cluster, error) {
@@ -344,6 +570,12 @@ func (c clusterService) FindCluster(criteria FindClusterCriteria) (*api.Cluster,
 	if criteria.SupportedInstanceType != "" {
 		dbConn = dbConn.Where("supported_instance_type like ?", fmt.Sprintf("%%%s%%", criteria.SupportedInstanceType))
 	}
+	if criteria.ExcludeCordoned {
+		dbConn = dbConn.Where("cordoned_at IS NULL")
+	}
+	if criteria.ResourcesReconcileRequested {
+		dbConn = dbConn.Where("resources_reconcile_requested_at IS NOT NULL")
+	}
 
 	// we order them by "created_at" field instead of the default "id" field.
 	// They are mostly the same as the library we use (xid) does take the generation timestamp into consideration,
@@ -538,6 +770,12 @@ func (c clusterService) FindAllClusters(criteria FindClusterCriteria) ([]*api.Cl
 	if criteria.SupportedInstanceType != "" {
 		dbConn.Where("supported_instance_type like ?", fmt.Sprintf("%%%s%%", criteria.SupportedInstanceType))
 	}
+	if criteria.ExcludeCordoned {
+		dbConn.Where("cordoned_at IS NULL")
+	}
+	if criteria.ResourcesReconcileRequested {
+		dbConn.Where("resources_reconcile_requested_at IS NOT NULL")
+	}
 	// we order them by "created_at" field instead of the default "id" field.
 	// They are mostly the same as the library we use (xid) does take the generation timestamp into consideration,
 	// However, it only down to the level of seconds. This means that if a few records are created at almost the same time,
@@ -777,6 +1015,10 @@ type KafkaStreamingUnitCountPerCluster struct {
 	MaxUnits      int32
 	Status        string
 	ClusterType   string
+	// CordonedAt is the time the cluster has been cordoned, if any
+	CordonedAt *time.Time
+	// ManuallyCordoned indicates that the cluster has been cordoned by an administrator
+	ManuallyCordoned bool
 }
 
 func (k KafkaStreamingUnitCountPerCluster) isSame(kafkaPerRegionFromDB *KafkaPerClusterCount) bool {
@@ -829,6 +1071,8 @@ type ClusterSelection struct {
 	DynamicCapacityInfo   api.JSON
 	Status                string
 	ClusterType           string
+	CordonedAt            *time.Time
+	ManuallyCordoned      bool
 }
 
 func (c *clusterService) FindStreamingUnitCountByClusterAndInstanceType() (KafkaStreamingUnitCountPerClusterList, error) {
@@ -865,15 +1109,17 @@ func (c *clusterService) FindStreamingUnitCountByClusterAndInstanceType() (Kafka
 			}
 
 			streamingUnitsCountPerCluster = append(streamingUnitsCountPerCluster, KafkaStreamingUnitCountPerCluster{
-				CloudProvider: clusterSelection.CloudProvider,
-				ID:            clusterSelection.ID,
-				ClusterId:     clusterSelection.ClusterID,
-				InstanceType:  instanceType,
-				Region:        clusterSelection.Region,
-				Count:         0,
-				MaxUnits:      maxUnits,
-				Status:        clusterSelection.Status,
-				ClusterType:   clusterSelection.ClusterType,
+				CloudProvider:    clusterSelection.CloudProvider,
+				ID:               clusterSelection.ID,
+				ClusterId:        clusterSelection.ClusterID,
+				InstanceType:     instanceType,
+				Region:           clusterSelection.Region,
+				Count:            0,
+				MaxUnits:         maxUnits,
+				Status:           clusterSelection.Status,
+				ClusterType:      clusterSelection.ClusterType,
+				CordonedAt:       clusterSelection.CordonedAt,
+				ManuallyCordoned: clusterSelection.ManuallyCordoned,
 			})
 		}
 	}
@@ -918,7 +1164,8 @@ func (c *clusterService) ComputeConsumedStreamingUnitCountPerInstanceType(cluste
 	if err := dbConn.Model(&dbapi.KafkaRequest{}).
 		Select("size_id, instance_type, count(1) as Count").
 		Group("size_id, instance_type").
-		Where("cluster_id = ?", clusterID).
+		// the kafkas being migrated to the cluster consume its capacity as soon as they are provisioned on it
+		Where("cluster_id = ? OR migration_target_cluster_id = ?", clusterID, clusterID).
 		Where("status not in (?)", kafkaStatusesThatNoLongerConsumeResourcesInTheDataPlane).
 		Scan(&sizeCountsPerInstanceType).Error; err != nil {
 		return nil, apiErrors.NewWithCause(apiErrors.ErrorGeneral, err, "failed to get count of sizes of a cluster")
@@ -17,6 +17,8 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	apiErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
 	"github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	mocket "github.com/selvatico/go-mocket"
@@ -686,6 +688,53 @@ func Test_clusterService_Update(t *testing.T) {
 	}
 }
 
+func Test_clusterService_UpdateEnterpriseClusterSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		cluster api.Cluster
+		wantErr bool
+		setupFn func()
+	}{
+		{
+			name:    "error when id is undefined",
+			cluster: api.Cluster{},
+			wantErr: true,
+		},
+		{
+			name:    "error when database update returns an error",
+			cluster: api.Cluster{Meta: api.Meta{ID: testID}},
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery("UPDATE").WithExecException()
+			},
+		},
+		{
+			name:    "should update the settings even when they are set to their zero value",
+			cluster: api.Cluster{Meta: api.Meta{ID: testID}, AccessKafkasViaPrivateNetwork: false, DynamicCapacityInfo: api.JSON(`{}`)},
+			wantErr: false,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "clusters" SET "updated_at"=$1,"dynamic_capacity_info"=$2,"access_kafkas_via_private_network"=$3 WHERE "id" = $4`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			if tt.setupFn != nil {
+				tt.setupFn()
+			}
+			k := &clusterService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			err := k.UpdateEnterpriseClusterSettings(tt.cluster)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
 func Test_UpdateStatus(t *testing.T) {
 	type fields struct {
 		connectionFactory *db.ConnectionFactory
@@ -779,6 +828,65 @@ func Test_UpdateStatus(t *testing.T) {
 	}
 }
 
+func Test_clusterService_UpdateStatus_NotifiesEnterpriseClusterStatusChanges(t *testing.T) {
+	enterpriseClusterQuery := `SELECT "cluster_id","organization_id","status" FROM "clusters" WHERE cluster_id = $1 AND cluster_type = $2`
+
+	tests := []struct {
+		name              string
+		enterpriseCluster []map[string]interface{}
+		status            api.ClusterStatus
+		wantNotified      bool
+	}{
+		{
+			name:              "should notify the change of status of an enterprise cluster",
+			enterpriseCluster: []map[string]interface{}{{"cluster_id": "cluster-id", "organization_id": "org-id", "status": api.ClusterWaitingForKasFleetShardOperator.String()}},
+			status:            api.ClusterReady,
+			wantNotified:      true,
+		},
+		{
+			name:              "should not notify when the status of the enterprise cluster does not change",
+			enterpriseCluster: []map[string]interface{}{{"cluster_id": "cluster-id", "organization_id": "org-id", "status": api.ClusterReady.String()}},
+			status:            api.ClusterReady,
+		},
+		{
+			name:   "should not notify the change of status of a cluster that is not an enterprise cluster",
+			status: api.ClusterReady,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(enterpriseClusterQuery).WithReply(tt.enterpriseCluster)
+			mocket.Catcher.NewMock().WithQuery(`UPDATE "clusters" SET "status"=$1`)
+			webhookService := &webhooks.WebhookServiceMock{
+				NotifyFunc: func(event *webhooks.Event) *apiErrors.ServiceError {
+					return nil
+				},
+			}
+			k := &clusterService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				webhookService:    webhookService,
+			}
+
+			g.Expect(k.UpdateStatus(api.Cluster{ClusterID: "cluster-id"}, tt.status)).To(gomega.Succeed())
+			if !tt.wantNotified {
+				g.Expect(webhookService.NotifyCalls()).To(gomega.BeEmpty())
+				return
+			}
+			g.Expect(webhookService.NotifyCalls()).To(gomega.HaveLen(1))
+			event := webhookService.NotifyCalls()[0].Event
+			g.Expect(event.Type).To(gomega.Equal(webhooks.EnterpriseClusterPhaseChanged))
+			g.Expect(event.OrganisationId).To(gomega.Equal("org-id"))
+			g.Expect(event.Resource.Id).To(gomega.Equal("cluster-id"))
+			g.Expect(event.PreviousPhase).To(gomega.Equal(api.ClusterWaitingForKasFleetShardOperator.String()))
+			g.Expect(event.Phase).To(gomega.Equal(tt.status.String()))
+		})
+	}
+}
+
 func Test_RegisterClusterJob(t *testing.T) {
 	type fields struct {
 		connectionFactory *db.ConnectionFactory
@@ -1398,6 +1506,20 @@ func Test_clusterService_FindAllClusters(t *testing.T) {
 			want:    clusters,
 			wantErr: false,
 		},
+		{
+			name: "should leave out the cordoned clusters when required",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			args: args{
+				criteria: FindClusterCriteria{Status: api.ClusterReady, ExcludeCordoned: true},
+			},
+			want: []*api.Cluster{mocks.BuildCluster(nil)},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "clusters" WHERE (cordoned_at IS NULL) AND "clusters"."status" = $1`).WithReply(converters.ConvertClusters([]*api.Cluster{mocks.BuildCluster(nil)}))
+				mocket.Catcher.NewMock().WithQueryException().WithExecException()
+			},
+		},
 		{
 			name: "successful retrieval of all clusters when passing external ID to the find criteria",
 			fields: fields{
@@ -1442,6 +1564,331 @@ func Test_clusterService_FindAllClusters(t *testing.T) {
 	}
 }
 
+func Test_clusterService_UpdateCordonedAt(t *testing.T) {
+	cordonedAt := time.Now()
+
+	tests := []struct {
+		name       string
+		clusterID  string
+		cordonedAt *time.Time
+		setupFn    func()
+		wantErr    bool
+	}{
+		{
+			name:       "should return an error when the cluster id is undefined",
+			cordonedAt: &cordonedAt,
+			wantErr:    true,
+		},
+		{
+			name:       "should return an error when the database returns an error",
+			clusterID:  testClusterID,
+			cordonedAt: &cordonedAt,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "clusters" SET "cordoned_at"`).WithExecException()
+			},
+			wantErr: true,
+		},
+		{
+			name:       "should cordon the cluster",
+			clusterID:  testClusterID,
+			cordonedAt: &cordonedAt,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "clusters" SET "cordoned_at"=$1,"updated_at"=$2 WHERE cluster_id = $3`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name:      "should uncordon the cluster",
+			clusterID: testClusterID,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "clusters" SET "cordoned_at"=$1,"updated_at"=$2 WHERE cluster_id = $3`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			if tt.setupFn != nil {
+				tt.setupFn()
+			}
+			c := clusterService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			err := c.UpdateCordonedAt(tt.clusterID, tt.cordonedAt)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func Test_clusterService_ClearClientSecret(t *testing.T) {
+	tests := []struct {
+		name      string
+		clusterID string
+		setupFn   func()
+		wantErr   bool
+	}{
+		{
+			name:    "should return an error when the cluster id is undefined",
+			wantErr: true,
+		},
+		{
+			name:      "should return an error when the database returns an error",
+			clusterID: testClusterID,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "clusters" SET "client_secret"`).WithExecException()
+			},
+			wantErr: true,
+		},
+		{
+			name:      "should clear the client secret of the cluster",
+			clusterID: testClusterID,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "clusters" SET "client_secret"=$1,"updated_at"=$2 WHERE cluster_id = $3`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			if tt.setupFn != nil {
+				tt.setupFn()
+			}
+			c := clusterService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			err := c.ClearClientSecret(tt.clusterID)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func Test_clusterService_UpdateManuallyCordoned(t *testing.T) {
+	tests := []struct {
+		name      string
+		clusterID string
+		cordoned  bool
+		setupFn   func()
+		wantErr   bool
+	}{
+		{
+			name:     "should return an error when the cluster id is undefined",
+			cordoned: true,
+			wantErr:  true,
+		},
+		{
+			name:      "should return an error when the database returns an error",
+			clusterID: testClusterID,
+			cordoned:  true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "clusters" SET`).WithExecException()
+			},
+			wantErr: true,
+		},
+		{
+			name:      "should cordon the cluster keeping its cordoned time if it is already cordoned",
+			clusterID: testClusterID,
+			cordoned:  true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "clusters" SET "cordoned_at"=COALESCE(cordoned_at, $1),"manually_cordoned"=$2,"updated_at"=$3 WHERE cluster_id = $4`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name:      "should uncordon the cluster",
+			clusterID: testClusterID,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "clusters" SET "cordoned_at"=$1,"manually_cordoned"=$2,"updated_at"=$3 WHERE cluster_id = $4`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			if tt.setupFn != nil {
+				tt.setupFn()
+			}
+			c := clusterService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			err := c.UpdateManuallyCordoned(tt.clusterID, tt.cordoned)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func Test_clusterService_UpdateResourcesReconcileRequestedAt(t *testing.T) {
+	requestedAt := time.Now()
+
+	tests := []struct {
+		name        string
+		clusterID   string
+		requestedAt *time.Time
+		setupFn     func()
+		wantErr     bool
+	}{
+		{
+			name:        "should return an error when the cluster id is undefined",
+			requestedAt: &requestedAt,
+			wantErr:     true,
+		},
+		{
+			name:        "should return an error when the database returns an error",
+			clusterID:   testClusterID,
+			requestedAt: &requestedAt,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "clusters" SET "resources_reconcile_requested_at"`).WithExecException()
+			},
+			wantErr: true,
+		},
+		{
+			name:        "should request the reconcile of the resources of the cluster",
+			clusterID:   testClusterID,
+			requestedAt: &requestedAt,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "clusters" SET "resources_reconcile_requested_at"=$1,"updated_at"=$2 WHERE cluster_id = $3`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name:      "should withdraw the request",
+			clusterID: testClusterID,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "clusters" SET "resources_reconcile_requested_at"=$1,"updated_at"=$2 WHERE cluster_id = $3`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			if tt.setupFn != nil {
+				tt.setupFn()
+			}
+			c := clusterService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			err := c.UpdateResourcesReconcileRequestedAt(tt.clusterID, tt.requestedAt)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func Test_clusterService_WithdrawResourcesReconcileRequest(t *testing.T) {
+	requestedAt := time.Now()
+
+	tests := []struct {
+		name      string
+		clusterID string
+		setupFn   func()
+		wantErr   bool
+	}{
+		{
+			name:    "should return an error when the cluster id is undefined",
+			wantErr: true,
+		},
+		{
+			name:      "should return an error when the database returns an error",
+			clusterID: testClusterID,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "clusters" SET "resources_reconcile_requested_at"`).WithExecException()
+			},
+			wantErr: true,
+		},
+		{
+			name:      "should only withdraw the request made at the given time",
+			clusterID: testClusterID,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "clusters" SET "resources_reconcile_requested_at"=$1,"updated_at"=$2 WHERE cluster_id = $3 AND resources_reconcile_requested_at = $4`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			if tt.setupFn != nil {
+				tt.setupFn()
+			}
+			c := clusterService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			err := c.WithdrawResourcesReconcileRequest(tt.clusterID, requestedAt)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func Test_clusterService_List(t *testing.T) {
+	tests := []struct {
+		name       string
+		listArgs   *coreServices.ListArguments
+		setupFn    func()
+		wantErr    bool
+		wantTotal  int
+		wantLength int
+	}{
+		{
+			name:     "should return an error when the search query is invalid",
+			listArgs: &coreServices.ListArguments{Page: 1, Size: 100, Search: "client_secret = secret"},
+			wantErr:  true,
+		},
+		{
+			name:     "should return an error when counting the clusters fails",
+			listArgs: &coreServices.ListArguments{Page: 1, Size: 100},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT count(1) FROM "clusters"`).WithQueryException()
+			},
+			wantErr: true,
+		},
+		{
+			name:     "should list the clusters matching the search query",
+			listArgs: &coreServices.ListArguments{Page: 1, Size: 100, Search: "region = us-east-1"},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT count(1) FROM "clusters" WHERE region = $1`).WithArgs("us-east-1").WithReply([]map[string]interface{}{{"count": 2}})
+				mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "clusters" WHERE region = $1 AND "clusters"."deleted_at" IS NULL ORDER BY created_at asc LIMIT 100`).WithArgs("us-east-1").WithReply([]map[string]interface{}{
+					{"cluster_id": "cluster-1", "region": "us-east-1"},
+					{"cluster_id": "cluster-2", "region": "us-east-1"},
+				})
+			},
+			wantTotal:  2,
+			wantLength: 2,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			if tt.setupFn != nil {
+				tt.setupFn()
+			}
+			c := clusterService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			clusters, paging, err := c.List(tt.listArgs)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if !tt.wantErr {
+				g.Expect(paging.Total).To(gomega.Equal(tt.wantTotal))
+				g.Expect(clusters).To(gomega.HaveLen(tt.wantLength))
+			}
+		})
+	}
+}
+
 func Test_clusterService_UpdateMultiClusterStatus(t *testing.T) {
 	type fields struct {
 		connectionFactory *db.ConnectionFactory
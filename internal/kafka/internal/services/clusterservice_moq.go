@@ -9,7 +9,9 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/ocm"
 	apiErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	"sync"
+	"time"
 )
 
 // Ensure, that ClusterServiceMock does implement ClusterService.
@@ -31,6 +33,9 @@ var _ ClusterService = &ClusterServiceMock{}
 //			CheckStrimziVersionReadyFunc: func(cluster *api.Cluster, strimziVersion string) (bool, error) {
 //				panic("mock out the CheckStrimziVersionReady method")
 //			},
+//			ClearClientSecretFunc: func(clusterID string) *apiErrors.ServiceError {
+//				panic("mock out the ClearClientSecret method")
+//			},
 //			ComputeConsumedStreamingUnitCountPerInstanceTypeFunc: func(clusterID string) (StreamingUnitCountPerInstanceType, error) {
 //				panic("mock out the ComputeConsumedStreamingUnitCountPerInstanceType method")
 //			},
@@ -91,6 +96,9 @@ var _ ClusterService = &ClusterServiceMock{}
 //			IsStrimziKafkaVersionAvailableInClusterFunc: func(cluster *api.Cluster, strimziVersion string, kafkaVersion string, ibpVersion string) (bool, error) {
 //				panic("mock out the IsStrimziKafkaVersionAvailableInCluster method")
 //			},
+//			ListFunc: func(listArgs *coreServices.ListArguments) (api.ClusterList, *api.PagingMeta, *apiErrors.ServiceError) {
+//				panic("mock out the List method")
+//			},
 //			ListByStatusFunc: func(state api.ClusterStatus) ([]api.Cluster, *apiErrors.ServiceError) {
 //				panic("mock out the ListByStatus method")
 //			},
@@ -112,12 +120,27 @@ var _ ClusterService = &ClusterServiceMock{}
 //			UpdateFunc: func(cluster api.Cluster) *apiErrors.ServiceError {
 //				panic("mock out the Update method")
 //			},
+//			UpdateCordonedAtFunc: func(clusterID string, cordonedAt *time.Time) *apiErrors.ServiceError {
+//				panic("mock out the UpdateCordonedAt method")
+//			},
+//			UpdateEnterpriseClusterSettingsFunc: func(cluster api.Cluster) *apiErrors.ServiceError {
+//				panic("mock out the UpdateEnterpriseClusterSettings method")
+//			},
+//			UpdateManuallyCordonedFunc: func(clusterID string, cordoned bool) *apiErrors.ServiceError {
+//				panic("mock out the UpdateManuallyCordoned method")
+//			},
 //			UpdateMultiClusterStatusFunc: func(clusterIDs []string, status api.ClusterStatus) *apiErrors.ServiceError {
 //				panic("mock out the UpdateMultiClusterStatus method")
 //			},
+//			UpdateResourcesReconcileRequestedAtFunc: func(clusterID string, requestedAt *time.Time) *apiErrors.ServiceError {
+//				panic("mock out the UpdateResourcesReconcileRequestedAt method")
+//			},
 //			UpdateStatusFunc: func(cluster api.Cluster, status api.ClusterStatus) error {
 //				panic("mock out the UpdateStatus method")
 //			},
+//			WithdrawResourcesReconcileRequestFunc: func(clusterID string, requestedAt time.Time) *apiErrors.ServiceError {
+//				panic("mock out the WithdrawResourcesReconcileRequest method")
+//			},
 //		}
 //
 //		// use mockedClusterService in code that requires ClusterService
@@ -134,6 +157,9 @@ type ClusterServiceMock struct {
 	// CheckStrimziVersionReadyFunc mocks the CheckStrimziVersionReady method.
 	CheckStrimziVersionReadyFunc func(cluster *api.Cluster, strimziVersion string) (bool, error)
 
+	// ClearClientSecretFunc mocks the ClearClientSecret method.
+	ClearClientSecretFunc func(clusterID string) *apiErrors.ServiceError
+
 	// ComputeConsumedStreamingUnitCountPerInstanceTypeFunc mocks the ComputeConsumedStreamingUnitCountPerInstanceType method.
 	ComputeConsumedStreamingUnitCountPerInstanceTypeFunc func(clusterID string) (StreamingUnitCountPerInstanceType, error)
 
@@ -194,6 +220,9 @@ type ClusterServiceMock struct {
 	// IsStrimziKafkaVersionAvailableInClusterFunc mocks the IsStrimziKafkaVersionAvailableInCluster method.
 	IsStrimziKafkaVersionAvailableInClusterFunc func(cluster *api.Cluster, strimziVersion string, kafkaVersion string, ibpVersion string) (bool, error)
 
+	// ListFunc mocks the List method.
+	ListFunc func(listArgs *coreServices.ListArguments) (api.ClusterList, *api.PagingMeta, *apiErrors.ServiceError)
+
 	// ListByStatusFunc mocks the ListByStatus method.
 	ListByStatusFunc func(state api.ClusterStatus) ([]api.Cluster, *apiErrors.ServiceError)
 
@@ -215,12 +244,27 @@ type ClusterServiceMock struct {
 	// UpdateFunc mocks the Update method.
 	UpdateFunc func(cluster api.Cluster) *apiErrors.ServiceError
 
+	// UpdateCordonedAtFunc mocks the UpdateCordonedAt method.
+	UpdateCordonedAtFunc func(clusterID string, cordonedAt *time.Time) *apiErrors.ServiceError
+
+	// UpdateEnterpriseClusterSettingsFunc mocks the UpdateEnterpriseClusterSettings method.
+	UpdateEnterpriseClusterSettingsFunc func(cluster api.Cluster) *apiErrors.ServiceError
+
+	// UpdateManuallyCordonedFunc mocks the UpdateManuallyCordoned method.
+	UpdateManuallyCordonedFunc func(clusterID string, cordoned bool) *apiErrors.ServiceError
+
 	// UpdateMultiClusterStatusFunc mocks the UpdateMultiClusterStatus method.
 	UpdateMultiClusterStatusFunc func(clusterIDs []string, status api.ClusterStatus) *apiErrors.ServiceError
 
+	// UpdateResourcesReconcileRequestedAtFunc mocks the UpdateResourcesReconcileRequestedAt method.
+	UpdateResourcesReconcileRequestedAtFunc func(clusterID string, requestedAt *time.Time) *apiErrors.ServiceError
+
 	// UpdateStatusFunc mocks the UpdateStatus method.
 	UpdateStatusFunc func(cluster api.Cluster, status api.ClusterStatus) error
 
+	// WithdrawResourcesReconcileRequestFunc mocks the WithdrawResourcesReconcileRequest method.
+	WithdrawResourcesReconcileRequestFunc func(clusterID string, requestedAt time.Time) *apiErrors.ServiceError
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// ApplyResources holds details about calls to the ApplyResources method.
@@ -242,6 +286,11 @@ type ClusterServiceMock struct {
 			// StrimziVersion is the strimziVersion argument value.
 			StrimziVersion string
 		}
+		// ClearClientSecret holds details about calls to the ClearClientSecret method.
+		ClearClientSecret []struct {
+			// ClusterID is the clusterID argument value.
+			ClusterID string
+		}
 		// ComputeConsumedStreamingUnitCountPerInstanceType holds details about calls to the ComputeConsumedStreamingUnitCountPerInstanceType method.
 		ComputeConsumedStreamingUnitCountPerInstanceType []struct {
 			// ClusterID is the clusterID argument value.
@@ -350,6 +399,11 @@ type ClusterServiceMock struct {
 			// IbpVersion is the ibpVersion argument value.
 			IbpVersion string
 		}
+		// List holds details about calls to the List method.
+		List []struct {
+			// ListArgs is the listArgs argument value.
+			ListArgs *coreServices.ListArguments
+		}
 		// ListByStatus holds details about calls to the ListByStatus method.
 		ListByStatus []struct {
 			// State is the state argument value.
@@ -389,6 +443,25 @@ type ClusterServiceMock struct {
 			// Cluster is the cluster argument value.
 			Cluster api.Cluster
 		}
+		// UpdateCordonedAt holds details about calls to the UpdateCordonedAt method.
+		UpdateCordonedAt []struct {
+			// ClusterID is the clusterID argument value.
+			ClusterID string
+			// CordonedAt is the cordonedAt argument value.
+			CordonedAt *time.Time
+		}
+		// UpdateEnterpriseClusterSettings holds details about calls to the UpdateEnterpriseClusterSettings method.
+		UpdateEnterpriseClusterSettings []struct {
+			// Cluster is the cluster argument value.
+			Cluster api.Cluster
+		}
+		// UpdateManuallyCordoned holds details about calls to the UpdateManuallyCordoned method.
+		UpdateManuallyCordoned []struct {
+			// ClusterID is the clusterID argument value.
+			ClusterID string
+			// Cordoned is the cordoned argument value.
+			Cordoned bool
+		}
 		// UpdateMultiClusterStatus holds details about calls to the UpdateMultiClusterStatus method.
 		UpdateMultiClusterStatus []struct {
 			// ClusterIDs is the clusterIDs argument value.
@@ -396,6 +469,13 @@ type ClusterServiceMock struct {
 			// Status is the status argument value.
 			Status api.ClusterStatus
 		}
+		// UpdateResourcesReconcileRequestedAt holds details about calls to the UpdateResourcesReconcileRequestedAt method.
+		UpdateResourcesReconcileRequestedAt []struct {
+			// ClusterID is the clusterID argument value.
+			ClusterID string
+			// RequestedAt is the requestedAt argument value.
+			RequestedAt *time.Time
+		}
 		// UpdateStatus holds details about calls to the UpdateStatus method.
 		UpdateStatus []struct {
 			// Cluster is the cluster argument value.
@@ -403,10 +483,18 @@ type ClusterServiceMock struct {
 			// Status is the status argument value.
 			Status api.ClusterStatus
 		}
+		// WithdrawResourcesReconcileRequest holds details about calls to the WithdrawResourcesReconcileRequest method.
+		WithdrawResourcesReconcileRequest []struct {
+			// ClusterID is the clusterID argument value.
+			ClusterID string
+			// RequestedAt is the requestedAt argument value.
+			RequestedAt time.Time
+		}
 	}
 	lockApplyResources                                   sync.RWMutex
 	lockCheckClusterStatus                               sync.RWMutex
 	lockCheckStrimziVersionReady                         sync.RWMutex
+	lockClearClientSecret                                sync.RWMutex
 	lockComputeConsumedStreamingUnitCountPerInstanceType sync.RWMutex
 	lockConfigureAndSaveIdentityProvider                 sync.RWMutex
 	lockCountByStatus                                    sync.RWMutex
@@ -427,6 +515,7 @@ type ClusterServiceMock struct {
 	lockInstallClusterLogging                            sync.RWMutex
 	lockInstallStrimzi                                   sync.RWMutex
 	lockIsStrimziKafkaVersionAvailableInCluster          sync.RWMutex
+	lockList                                             sync.RWMutex
 	lockListByStatus                                     sync.RWMutex
 	lockListEnterpriseClustersOfAnOrganization           sync.RWMutex
 	lockListGroupByProviderAndRegion                     sync.RWMutex
@@ -434,8 +523,13 @@ type ClusterServiceMock struct {
 	lockRegisterClusterJob                               sync.RWMutex
 	lockRemoveResources                                  sync.RWMutex
 	lockUpdate                                           sync.RWMutex
+	lockUpdateCordonedAt                                 sync.RWMutex
+	lockUpdateEnterpriseClusterSettings                  sync.RWMutex
+	lockUpdateManuallyCordoned                           sync.RWMutex
 	lockUpdateMultiClusterStatus                         sync.RWMutex
+	lockUpdateResourcesReconcileRequestedAt              sync.RWMutex
 	lockUpdateStatus                                     sync.RWMutex
+	lockWithdrawResourcesReconcileRequest                sync.RWMutex
 }
 
 // ApplyResources calls ApplyResourcesFunc.
@@ -542,6 +636,38 @@ func (mock *ClusterServiceMock) CheckStrimziVersionReadyCalls() []struct {
 	return calls
 }
 
+// ClearClientSecret calls ClearClientSecretFunc.
+func (mock *ClusterServiceMock) ClearClientSecret(clusterID string) *apiErrors.ServiceError {
+	if mock.ClearClientSecretFunc == nil {
+		panic("ClusterServiceMock.ClearClientSecretFunc: method is nil but ClusterService.ClearClientSecret was just called")
+	}
+	callInfo := struct {
+		ClusterID string
+	}{
+		ClusterID: clusterID,
+	}
+	mock.lockClearClientSecret.Lock()
+	mock.calls.ClearClientSecret = append(mock.calls.ClearClientSecret, callInfo)
+	mock.lockClearClientSecret.Unlock()
+	return mock.ClearClientSecretFunc(clusterID)
+}
+
+// ClearClientSecretCalls gets all the calls that were made to ClearClientSecret.
+// Check the length with:
+//
+//	len(mockedClusterService.ClearClientSecretCalls())
+func (mock *ClusterServiceMock) ClearClientSecretCalls() []struct {
+	ClusterID string
+} {
+	var calls []struct {
+		ClusterID string
+	}
+	mock.lockClearClientSecret.RLock()
+	calls = mock.calls.ClearClientSecret
+	mock.lockClearClientSecret.RUnlock()
+	return calls
+}
+
 // ComputeConsumedStreamingUnitCountPerInstanceType calls ComputeConsumedStreamingUnitCountPerInstanceTypeFunc.
 func (mock *ClusterServiceMock) ComputeConsumedStreamingUnitCountPerInstanceType(clusterID string) (StreamingUnitCountPerInstanceType, error) {
 	if mock.ComputeConsumedStreamingUnitCountPerInstanceTypeFunc == nil {
@@ -1197,6 +1323,38 @@ func (mock *ClusterServiceMock) IsStrimziKafkaVersionAvailableInClusterCalls() [
 	return calls
 }
 
+// List calls ListFunc.
+func (mock *ClusterServiceMock) List(listArgs *coreServices.ListArguments) (api.ClusterList, *api.PagingMeta, *apiErrors.ServiceError) {
+	if mock.ListFunc == nil {
+		panic("ClusterServiceMock.ListFunc: method is nil but ClusterService.List was just called")
+	}
+	callInfo := struct {
+		ListArgs *coreServices.ListArguments
+	}{
+		ListArgs: listArgs,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc(listArgs)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedClusterService.ListCalls())
+func (mock *ClusterServiceMock) ListCalls() []struct {
+	ListArgs *coreServices.ListArguments
+} {
+	var calls []struct {
+		ListArgs *coreServices.ListArguments
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
 // ListByStatus calls ListByStatusFunc.
 func (mock *ClusterServiceMock) ListByStatus(state api.ClusterStatus) ([]api.Cluster, *apiErrors.ServiceError) {
 	if mock.ListByStatusFunc == nil {
@@ -1428,6 +1586,110 @@ func (mock *ClusterServiceMock) UpdateCalls() []struct {
 	return calls
 }
 
+// UpdateCordonedAt calls UpdateCordonedAtFunc.
+func (mock *ClusterServiceMock) UpdateCordonedAt(clusterID string, cordonedAt *time.Time) *apiErrors.ServiceError {
+	if mock.UpdateCordonedAtFunc == nil {
+		panic("ClusterServiceMock.UpdateCordonedAtFunc: method is nil but ClusterService.UpdateCordonedAt was just called")
+	}
+	callInfo := struct {
+		ClusterID  string
+		CordonedAt *time.Time
+	}{
+		ClusterID:  clusterID,
+		CordonedAt: cordonedAt,
+	}
+	mock.lockUpdateCordonedAt.Lock()
+	mock.calls.UpdateCordonedAt = append(mock.calls.UpdateCordonedAt, callInfo)
+	mock.lockUpdateCordonedAt.Unlock()
+	return mock.UpdateCordonedAtFunc(clusterID, cordonedAt)
+}
+
+// UpdateCordonedAtCalls gets all the calls that were made to UpdateCordonedAt.
+// Check the length with:
+//
+//	len(mockedClusterService.UpdateCordonedAtCalls())
+func (mock *ClusterServiceMock) UpdateCordonedAtCalls() []struct {
+	ClusterID  string
+	CordonedAt *time.Time
+} {
+	var calls []struct {
+		ClusterID  string
+		CordonedAt *time.Time
+	}
+	mock.lockUpdateCordonedAt.RLock()
+	calls = mock.calls.UpdateCordonedAt
+	mock.lockUpdateCordonedAt.RUnlock()
+	return calls
+}
+
+// UpdateEnterpriseClusterSettings calls UpdateEnterpriseClusterSettingsFunc.
+func (mock *ClusterServiceMock) UpdateEnterpriseClusterSettings(cluster api.Cluster) *apiErrors.ServiceError {
+	if mock.UpdateEnterpriseClusterSettingsFunc == nil {
+		panic("ClusterServiceMock.UpdateEnterpriseClusterSettingsFunc: method is nil but ClusterService.UpdateEnterpriseClusterSettings was just called")
+	}
+	callInfo := struct {
+		Cluster api.Cluster
+	}{
+		Cluster: cluster,
+	}
+	mock.lockUpdateEnterpriseClusterSettings.Lock()
+	mock.calls.UpdateEnterpriseClusterSettings = append(mock.calls.UpdateEnterpriseClusterSettings, callInfo)
+	mock.lockUpdateEnterpriseClusterSettings.Unlock()
+	return mock.UpdateEnterpriseClusterSettingsFunc(cluster)
+}
+
+// UpdateEnterpriseClusterSettingsCalls gets all the calls that were made to UpdateEnterpriseClusterSettings.
+// Check the length with:
+//
+//	len(mockedClusterService.UpdateEnterpriseClusterSettingsCalls())
+func (mock *ClusterServiceMock) UpdateEnterpriseClusterSettingsCalls() []struct {
+	Cluster api.Cluster
+} {
+	var calls []struct {
+		Cluster api.Cluster
+	}
+	mock.lockUpdateEnterpriseClusterSettings.RLock()
+	calls = mock.calls.UpdateEnterpriseClusterSettings
+	mock.lockUpdateEnterpriseClusterSettings.RUnlock()
+	return calls
+}
+
+// UpdateManuallyCordoned calls UpdateManuallyCordonedFunc.
+func (mock *ClusterServiceMock) UpdateManuallyCordoned(clusterID string, cordoned bool) *apiErrors.ServiceError {
+	if mock.UpdateManuallyCordonedFunc == nil {
+		panic("ClusterServiceMock.UpdateManuallyCordonedFunc: method is nil but ClusterService.UpdateManuallyCordoned was just called")
+	}
+	callInfo := struct {
+		ClusterID string
+		Cordoned  bool
+	}{
+		ClusterID: clusterID,
+		Cordoned:  cordoned,
+	}
+	mock.lockUpdateManuallyCordoned.Lock()
+	mock.calls.UpdateManuallyCordoned = append(mock.calls.UpdateManuallyCordoned, callInfo)
+	mock.lockUpdateManuallyCordoned.Unlock()
+	return mock.UpdateManuallyCordonedFunc(clusterID, cordoned)
+}
+
+// UpdateManuallyCordonedCalls gets all the calls that were made to UpdateManuallyCordoned.
+// Check the length with:
+//
+//	len(mockedClusterService.UpdateManuallyCordonedCalls())
+func (mock *ClusterServiceMock) UpdateManuallyCordonedCalls() []struct {
+	ClusterID string
+	Cordoned  bool
+} {
+	var calls []struct {
+		ClusterID string
+		Cordoned  bool
+	}
+	mock.lockUpdateManuallyCordoned.RLock()
+	calls = mock.calls.UpdateManuallyCordoned
+	mock.lockUpdateManuallyCordoned.RUnlock()
+	return calls
+}
+
 // UpdateMultiClusterStatus calls UpdateMultiClusterStatusFunc.
 func (mock *ClusterServiceMock) UpdateMultiClusterStatus(clusterIDs []string, status api.ClusterStatus) *apiErrors.ServiceError {
 	if mock.UpdateMultiClusterStatusFunc == nil {
@@ -1464,6 +1726,42 @@ func (mock *ClusterServiceMock) UpdateMultiClusterStatusCalls() []struct {
 	return calls
 }
 
+// UpdateResourcesReconcileRequestedAt calls UpdateResourcesReconcileRequestedAtFunc.
+func (mock *ClusterServiceMock) UpdateResourcesReconcileRequestedAt(clusterID string, requestedAt *time.Time) *apiErrors.ServiceError {
+	if mock.UpdateResourcesReconcileRequestedAtFunc == nil {
+		panic("ClusterServiceMock.UpdateResourcesReconcileRequestedAtFunc: method is nil but ClusterService.UpdateResourcesReconcileRequestedAt was just called")
+	}
+	callInfo := struct {
+		ClusterID   string
+		RequestedAt *time.Time
+	}{
+		ClusterID:   clusterID,
+		RequestedAt: requestedAt,
+	}
+	mock.lockUpdateResourcesReconcileRequestedAt.Lock()
+	mock.calls.UpdateResourcesReconcileRequestedAt = append(mock.calls.UpdateResourcesReconcileRequestedAt, callInfo)
+	mock.lockUpdateResourcesReconcileRequestedAt.Unlock()
+	return mock.UpdateResourcesReconcileRequestedAtFunc(clusterID, requestedAt)
+}
+
+// UpdateResourcesReconcileRequestedAtCalls gets all the calls that were made to UpdateResourcesReconcileRequestedAt.
+// Check the length with:
+//
+//	len(mockedClusterService.UpdateResourcesReconcileRequestedAtCalls())
+func (mock *ClusterServiceMock) UpdateResourcesReconcileRequestedAtCalls() []struct {
+	ClusterID   string
+	RequestedAt *time.Time
+} {
+	var calls []struct {
+		ClusterID   string
+		RequestedAt *time.Time
+	}
+	mock.lockUpdateResourcesReconcileRequestedAt.RLock()
+	calls = mock.calls.UpdateResourcesReconcileRequestedAt
+	mock.lockUpdateResourcesReconcileRequestedAt.RUnlock()
+	return calls
+}
+
 // UpdateStatus calls UpdateStatusFunc.
 func (mock *ClusterServiceMock) UpdateStatus(cluster api.Cluster, status api.ClusterStatus) error {
 	if mock.UpdateStatusFunc == nil {
@@ -1499,3 +1797,39 @@ func (mock *ClusterServiceMock) UpdateStatusCalls() []struct {
 	mock.lockUpdateStatus.RUnlock()
 	return calls
 }
+
+// WithdrawResourcesReconcileRequest calls WithdrawResourcesReconcileRequestFunc.
+func (mock *ClusterServiceMock) WithdrawResourcesReconcileRequest(clusterID string, requestedAt time.Time) *apiErrors.ServiceError {
+	if mock.WithdrawResourcesReconcileRequestFunc == nil {
+		panic("ClusterServiceMock.WithdrawResourcesReconcileRequestFunc: method is nil but ClusterService.WithdrawResourcesReconcileRequest was just called")
+	}
+	callInfo := struct {
+		ClusterID   string
+		RequestedAt time.Time
+	}{
+		ClusterID:   clusterID,
+		RequestedAt: requestedAt,
+	}
+	mock.lockWithdrawResourcesReconcileRequest.Lock()
+	mock.calls.WithdrawResourcesReconcileRequest = append(mock.calls.WithdrawResourcesReconcileRequest, callInfo)
+	mock.lockWithdrawResourcesReconcileRequest.Unlock()
+	return mock.WithdrawResourcesReconcileRequestFunc(clusterID, requestedAt)
+}
+
+// WithdrawResourcesReconcileRequestCalls gets all the calls that were made to WithdrawResourcesReconcileRequest.
+// Check the length with:
+//
+//	len(mockedClusterService.WithdrawResourcesReconcileRequestCalls())
+func (mock *ClusterServiceMock) WithdrawResourcesReconcileRequestCalls() []struct {
+	ClusterID   string
+	RequestedAt time.Time
+} {
+	var calls []struct {
+		ClusterID   string
+		RequestedAt time.Time
+	}
+	mock.lockWithdrawResourcesReconcileRequest.RLock()
+	calls = mock.calls.WithdrawResourcesReconcileRequest
+	mock.lockWithdrawResourcesReconcileRequest.RUnlock()
+	return calls
+}
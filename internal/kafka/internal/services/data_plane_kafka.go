@@ -58,13 +58,15 @@ type dataPlaneKafkaService struct {
 	kafkaService   KafkaService
 	clusterService ClusterService
 	kafkaConfig    *config.KafkaConfig
+	kafkaEvents    KafkaEventService
 }
 
-func NewDataPlaneKafkaService(kafkaSrv KafkaService, clusterSrv ClusterService, kafkaConfig *config.KafkaConfig) *dataPlaneKafkaService {
+func NewDataPlaneKafkaService(kafkaSrv KafkaService, clusterSrv ClusterService, kafkaConfig *config.KafkaConfig, kafkaEvents KafkaEventService) *dataPlaneKafkaService {
 	return &dataPlaneKafkaService{
 		kafkaService:   kafkaSrv,
 		clusterService: clusterSrv,
 		kafkaConfig:    kafkaConfig,
+		kafkaEvents:    kafkaEvents,
 	}
 }
 
@@ -147,6 +149,13 @@ func (d *dataPlaneKafkaService) processRealKafkaDeployment(ks *dbapi.DataPlaneKa
 		glog.Error(errors.Wrapf(getErr, "failed to get kafka request by kafka ID %q", ks.KafkaClusterId))
 		return
 	}
+	if kafka.MigrationInProgress() && kafka.ClusterID != cluster.ClusterID {
+		// the status is reported by the other cluster of the migration of the kafka
+		if e := d.processKafkaMigrationStatus(kafka, ks, cluster); e != nil {
+			log.Error(errors.Wrapf(e, "Error updating kafka %q migration status", ks.KafkaClusterId))
+		}
+		return
+	}
 	if kafka.ClusterID != cluster.ClusterID {
 		log.Warningf("kafka with ID %q does not match cluster's ClusterID. kafka ClusterID = %q, cluster's ClusterID = %q", kafka.ID, kafka.ClusterID, cluster.ClusterID)
 		return
@@ -192,6 +201,7 @@ func (d *dataPlaneKafkaService) processRealKafkaDeployment(ks *dbapi.DataPlaneKa
 			e = d.setKafkaClusterFailed(kafka, readyCondition.Message)
 		} else {
 			log.Errorf("kafka %q with status %q received errors from data plane: %q", kafka.ID, kafka.Status, readyCondition.Message)
+			recordKafkaEvent(d.kafkaEvents, kafka, dbapi.KafkaEventTypeStatus, fmt.Sprintf("error reported by the data plane: %s", readyCondition.Message))
 		}
 	case statusDeleted:
 		e = d.setKafkaClusterDeleting(kafka)
@@ -217,6 +227,29 @@ func (d *dataPlaneKafkaService) processRealKafkaDeployment(ks *dbapi.DataPlaneKa
 	if e != nil {
 		log.Error(errors.Wrapf(e, "Error updating kafka '%q' version fields", ks.KafkaClusterId))
 	}
+
+	e = d.setPendingCanaryServiceAccountReady(kafka, ks)
+	if e != nil {
+		log.Error(errors.Wrapf(e, "Error updating kafka %q canary service account status", ks.KafkaClusterId))
+	}
+}
+
+// setPendingCanaryServiceAccountReady records that the canary of the kafka is ready with the service account it is
+// being rotated to, so that the previous service account can be deleted
+func (d *dataPlaneKafkaService) setPendingCanaryServiceAccountReady(kafka *dbapi.KafkaRequest, status *dbapi.DataPlaneKafkaStatus) *serviceError.ServiceError {
+	if !kafka.CanaryServiceAccountRotationInProgress() || kafka.PendingCanaryServiceAccountReady {
+		return nil
+	}
+	if !status.CanaryReady || status.CanaryPrincipal != kafka.PendingCanaryServiceAccountClientID {
+		return nil
+	}
+
+	logger.Logger.Infof("canary of kafka %q is ready with service account %q", kafka.ID, kafka.PendingCanaryServiceAccountClientID)
+	kafka.PendingCanaryServiceAccountReady = true
+	if err := d.kafkaService.Updates(kafka, map[string]interface{}{"pending_canary_service_account_ready": true}); err != nil {
+		return serviceError.NewWithCause(err.Code, err, "failed to update the canary service account status of kafka %q", kafka.ID)
+	}
+	return nil
 }
 
 func (d *dataPlaneKafkaService) setKafkaClusterReady(kafka *dbapi.KafkaRequest) *serviceError.ServiceError {
@@ -250,6 +283,7 @@ func (d *dataPlaneKafkaService) setKafkaClusterReady(kafka *dbapi.KafkaRequest)
 
 func (d *dataPlaneKafkaService) setKafkaRequestVersionFields(kafka *dbapi.KafkaRequest, status *dbapi.DataPlaneKafkaStatus) *serviceError.ServiceError {
 	needsUpdate := false
+	wasUpgrading := kafka.IsUpgrading()
 	prevActualKafkaVersion := kafka.ActualKafkaVersion
 	if status.KafkaVersion != "" && status.KafkaVersion != kafka.ActualKafkaVersion {
 		logger.Logger.Infof("Updating Kafka version for Kafka ID %q from %q to %q", kafka.ID, prevActualKafkaVersion, status.KafkaVersion)
@@ -325,10 +359,25 @@ func (d *dataPlaneKafkaService) setKafkaRequestVersionFields(kafka *dbapi.KafkaR
 			"kafka_upgrading":          kafka.KafkaUpgrading,
 			"kafka_ibp_upgrading":      kafka.KafkaIBPUpgrading,
 		}
+		// a forced upgrade only applies to the pending upgrade, the next upgrades wait for the maintenance window again
+		if kafka.UpgradeForced && !kafka.HasPendingUpgrade() && !kafka.IsUpgrading() {
+			logger.Logger.Infof("Forced upgrade of Kafka ID %q completed", kafka.ID)
+			kafka.UpgradeForced = false
+			versionFields["upgrade_forced"] = false
+		}
 
 		if err := d.kafkaService.Updates(kafka, versionFields); err != nil {
 			return serviceError.NewWithCause(err.Code, err, "failed to update actual version fields for kafka %q", kafka.ID)
 		}
+
+		if !wasUpgrading && kafka.IsUpgrading() {
+			recordKafkaEvent(d.kafkaEvents, kafka, dbapi.KafkaEventTypeUpgradeStarted, fmt.Sprintf("upgrade to strimzi version %q, kafka version %q and kafka ibp version %q started",
+				kafka.DesiredStrimziVersion, kafka.DesiredKafkaVersion, kafka.DesiredKafkaIBPVersion))
+		}
+		if wasUpgrading && !kafka.IsUpgrading() {
+			recordKafkaEvent(d.kafkaEvents, kafka, dbapi.KafkaEventTypeUpgraded, fmt.Sprintf("upgraded to strimzi version %q, kafka version %q and kafka ibp version %q",
+				kafka.ActualStrimziVersion, kafka.ActualKafkaVersion, kafka.ActualKafkaIBPVersion))
+		}
 	}
 
 	return nil
@@ -350,6 +399,9 @@ func (d *dataPlaneKafkaService) setKafkaClusterFailed(kafka *dbapi.KafkaRequest,
 
 	kafka.Status = string(constants.KafkaRequestStatusFailed)
 	kafka.FailedReason = "Kafka reported as failed from the data plane"
+	// the event is recorded before the update so that the history keeps the error reported by the data plane:
+	// the same transition recorded without details by the update is then ignored
+	recordKafkaEvent(d.kafkaEvents, kafka, dbapi.KafkaEventTypeStatus, fmt.Sprintf("error reported by the data plane: %s", errMessage))
 	err = d.kafkaService.Update(kafka)
 	if err != nil {
 		return serviceError.NewWithCause(err.Code, err, "failed to update kafka cluster to %q status for kafka %q", constants.KafkaRequestStatusFailed, kafka.ID)
@@ -384,6 +436,7 @@ func (d *dataPlaneKafkaService) reassignKafkaCluster(kafka *dbapi.KafkaRequest)
 		if err := d.kafkaService.Update(kafka); err != nil {
 			return err
 		}
+		recordKafkaEvent(d.kafkaEvents, kafka, dbapi.KafkaEventTypeStatus, fmt.Sprintf("rejected by data plane cluster %q: placement retried with id %q", kafka.ClusterID, kafka.PlacementId))
 		metrics.UpdateKafkaRequestsStatusSinceCreatedMetric(constants.KafkaRequestStatusProvisioning, kafka.ID, kafka.ClusterID, time.Since(kafka.CreatedAt))
 	} else {
 		logger.Logger.Infof("kafka %q is rejected and current status is %q", kafka.ID, kafka.Status)
@@ -406,6 +459,7 @@ func (d *dataPlaneKafkaService) unassignKafkaFromDataplaneCluster(kafka *dbapi.K
 		}); err != nil {
 			return serviceError.NewWithCause(err.Code, err, "failed to reset fields for kafka %q", kafka.ID)
 		}
+		recordKafkaEvent(d.kafkaEvents, kafka, dbapi.KafkaEventTypeStatus, fmt.Sprintf("rejected by data plane cluster %q as it is full: unassigned from the cluster", kafka.ClusterID))
 
 		metrics.UpdateKafkaRequestsStatusSinceCreatedMetric(constants.KafkaRequestStatusProvisioning, kafka.ID, kafka.ClusterID, time.Since(kafka.CreatedAt))
 	} else {
@@ -463,6 +517,91 @@ func (d *dataPlaneKafkaService) persistKafkaRoutes(kafka *dbapi.KafkaRequest, ka
 	return nil
 }
 
+// processKafkaMigrationStatus handles the status of a kafka reported by the data plane cluster the kafka is migrated
+// to or from:
+//   - once the kafka is ready on the target cluster, the routes of the target cluster are stored and the kafka is
+//     cut over to the target cluster by the migration worker.
+//   - the migration fails when the target cluster cannot run the kafka. The kafka is then deprovisioned from the
+//     target cluster.
+//   - the migration completes once the kafka is deprovisioned from the source cluster.
+func (d *dataPlaneKafkaService) processKafkaMigrationStatus(kafka *dbapi.KafkaRequest, ks *dbapi.DataPlaneKafkaStatus, cluster *api.Cluster) *serviceError.ServiceError {
+	status := d.getManagedKafkaStatus(ks)
+
+	if cluster.ClusterID == kafka.MigrationTargetClusterId {
+		switch {
+		case kafka.MigrationStatus == dbapi.KafkaMigrationStatusProvisioning && status == statusReady:
+			return d.persistKafkaMigrationRoutes(kafka, ks, cluster)
+		case kafka.MigrationStatus == dbapi.KafkaMigrationStatusProvisioning && status == statusError:
+			readyCondition, _ := ks.GetReadyCondition()
+			return d.setKafkaMigrationFailed(kafka, fmt.Sprintf("error reported by target cluster %q: %s", cluster.ClusterID, readyCondition.Message))
+		case kafka.MigrationStatus == dbapi.KafkaMigrationStatusProvisioning && (status == statusRejected || status == statusRejectedClusterFull):
+			return d.setKafkaMigrationFailed(kafka, fmt.Sprintf("rejected by target cluster %q", cluster.ClusterID))
+		case kafka.MigrationStatus == dbapi.KafkaMigrationStatusFailed && status == statusDeleted:
+			logger.Logger.Infof("kafka %q is deprovisioned from the target cluster %q of its failed migration", kafka.ID, cluster.ClusterID)
+			kafka.MigrationTargetClusterId = ""
+			return d.kafkaService.Updates(kafka, map[string]interface{}{"migration_target_cluster_id": kafka.MigrationTargetClusterId})
+		}
+		return nil
+	}
+
+	if cluster.ClusterID == kafka.MigrationSourceClusterId && status == statusDeleted {
+		logger.Logger.Infof("kafka %q is deprovisioned from the source cluster %q of its migration", kafka.ID, cluster.ClusterID)
+		kafka.MigrationSourceClusterId = ""
+		kafka.MigrationStatus = dbapi.KafkaMigrationStatusNoMigration
+		if err := d.kafkaService.Updates(kafka, map[string]interface{}{
+			"migration_source_cluster_id": kafka.MigrationSourceClusterId,
+			"migration_status":            kafka.MigrationStatus,
+		}); err != nil {
+			return err
+		}
+		recordKafkaEvent(d.kafkaEvents, kafka, dbapi.KafkaEventTypeStatus, fmt.Sprintf("migrated from data plane cluster %q to data plane cluster %q", cluster.ClusterID, kafka.ClusterID))
+	}
+
+	return nil
+}
+
+func (d *dataPlaneKafkaService) persistKafkaMigrationRoutes(kafka *dbapi.KafkaRequest, kafkaStatus *dbapi.DataPlaneKafkaStatus, cluster *api.Cluster) *serviceError.ServiceError {
+	if len(kafkaStatus.Routes) < 1 {
+		logger.Logger.V(10).Infof("skip persisting migration routes for Kafka %q as they are not available", kafka.ID)
+		return nil
+	}
+
+	clusterDNS, err := d.clusterService.GetClusterDNS(cluster.ClusterID)
+	if err != nil {
+		return serviceError.NewWithCause(err.Code, err, "failed to get DNS entry for ClusterID %q", cluster.ClusterID)
+	}
+
+	baseClusterDomain := strings.TrimPrefix(clusterDNS, fmt.Sprintf("%s.", constants.DefaultIngressDnsNamePrefix))
+	routes, routesErr := d.buildKafkaRoutes(kafkaStatus.Routes, kafka, baseClusterDomain)
+	if routesErr != nil {
+		return serviceError.NewWithCause(serviceError.ErrorBadRequest, routesErr, "routes are not valid")
+	}
+	if err := kafka.SetMigrationRoutes(routes); err != nil {
+		return serviceError.NewWithCause(serviceError.ErrorGeneral, err, "failed to set migration routes for kafka %q", kafka.ID)
+	}
+
+	logger.Logger.Infof("kafka %q is ready on the target cluster %q of its migration", kafka.ID, cluster.ClusterID)
+	kafka.MigrationStatus = dbapi.KafkaMigrationStatusCuttingOver
+	return d.kafkaService.Updates(kafka, map[string]interface{}{
+		"migration_routes": kafka.MigrationRoutes,
+		"migration_status": kafka.MigrationStatus,
+	})
+}
+
+func (d *dataPlaneKafkaService) setKafkaMigrationFailed(kafka *dbapi.KafkaRequest, details string) *serviceError.ServiceError {
+	logger.Logger.Warningf("migration of kafka %q failed: %s", kafka.ID, details)
+	kafka.MigrationStatus = dbapi.KafkaMigrationStatusFailed
+	kafka.MigrationDetails = details
+	if err := d.kafkaService.Updates(kafka, map[string]interface{}{
+		"migration_status":  kafka.MigrationStatus,
+		"migration_details": kafka.MigrationDetails,
+	}); err != nil {
+		return err
+	}
+	recordKafkaEvent(d.kafkaEvents, kafka, dbapi.KafkaEventTypeStatus, fmt.Sprintf("migration failed: %s", details))
+	return nil
+}
+
 func (d *dataPlaneKafkaService) getManagedKafkaStatus(status *dbapi.DataPlaneKafkaStatus) managedKafkaStatus {
 	for _, c := range status.Conditions {
 		if strings.EqualFold(c.Type, "Ready") {
@@ -723,7 +723,11 @@ func Test_dataPlaneKafkaService_UpdateDataPlaneKafkaService(t *testing.T) {
 				"rejected":  0,
 				"suspended": 0,
 			}
-			s := NewDataPlaneKafkaService(tt.fields.kafkaService(counter), tt.fields.clusterService, &config.KafkaConfig{})
+			s := NewDataPlaneKafkaService(tt.fields.kafkaService(counter), tt.fields.clusterService, &config.KafkaConfig{}, &KafkaEventServiceMock{
+				RecordFunc: func(event *dbapi.KafkaEvent) *errors.ServiceError {
+					return nil
+				},
+			})
 			err := s.UpdateDataPlaneKafkaService(context.TODO(), tt.args.clusterId, tt.args.status)
 			g.Expect(err).To(gomega.Equal(tt.want))
 			g.Expect(counter).To(gomega.Equal(tt.expectCounters))
@@ -731,6 +735,297 @@ func Test_dataPlaneKafkaService_UpdateDataPlaneKafkaService(t *testing.T) {
 	}
 }
 
+func Test_dataPlaneKafkaService_RecordsKafkaEvents(t *testing.T) {
+	tests := []struct {
+		name        string
+		condition   dbapi.DataPlaneKafkaStatusCondition
+		kafkaStatus string
+		upgrading   bool
+		wantEvents  []dbapi.KafkaEvent
+	}{
+		{
+			name:        "should record the error reported by the data plane when the kafka fails",
+			condition:   dbapi.DataPlaneKafkaStatusCondition{Type: "Ready", Status: "False", Reason: "Error", Message: "broker crashed"},
+			kafkaStatus: constants.KafkaRequestStatusProvisioning.String(),
+			wantEvents: []dbapi.KafkaEvent{
+				{
+					KafkaId:        "kafka-id",
+					Type:           dbapi.KafkaEventTypeStatus,
+					PreviousStatus: constants.KafkaRequestStatusProvisioning.String(),
+					Status:         constants.KafkaRequestStatusFailed.String(),
+					FailedReason:   "Kafka reported as failed from the data plane",
+					Details:        "error reported by the data plane: broker crashed",
+				},
+			},
+		},
+		{
+			name:        "should record the error reported by the data plane when the kafka is suspended without failing it",
+			condition:   dbapi.DataPlaneKafkaStatusCondition{Type: "Ready", Status: "False", Reason: "Error", Message: "broker crashed"},
+			kafkaStatus: constants.KafkaRequestStatusSuspending.String(),
+			wantEvents: []dbapi.KafkaEvent{
+				{
+					KafkaId:        "kafka-id",
+					Type:           dbapi.KafkaEventTypeStatus,
+					PreviousStatus: constants.KafkaRequestStatusSuspending.String(),
+					Status:         constants.KafkaRequestStatusSuspending.String(),
+					Details:        "error reported by the data plane: broker crashed",
+				},
+			},
+		},
+		{
+			name:        "should record the rejection of the kafka by a full data plane cluster",
+			condition:   dbapi.DataPlaneKafkaStatusCondition{Type: "Ready", Status: "False", Reason: "Rejected", Message: "Cluster has insufficient resources"},
+			kafkaStatus: constants.KafkaRequestStatusProvisioning.String(),
+			wantEvents: []dbapi.KafkaEvent{
+				{
+					KafkaId:        "kafka-id",
+					Type:           dbapi.KafkaEventTypeStatus,
+					PreviousStatus: constants.KafkaRequestStatusProvisioning.String(),
+					Status:         constants.KafkaRequestStatusProvisioning.String(),
+					Details:        `rejected by data plane cluster "test-cluster-id" as it is full: unassigned from the cluster`,
+				},
+			},
+		},
+		{
+			name:        "should record the start of the upgrade of the kafka",
+			condition:   dbapi.DataPlaneKafkaStatusCondition{Type: "Ready", Status: "True", Reason: "StrimziUpdating"},
+			kafkaStatus: constants.KafkaRequestStatusReady.String(),
+			wantEvents: []dbapi.KafkaEvent{
+				{
+					KafkaId:        "kafka-id",
+					Type:           dbapi.KafkaEventTypeUpgradeStarted,
+					PreviousStatus: constants.KafkaRequestStatusReady.String(),
+					Status:         constants.KafkaRequestStatusReady.String(),
+					Details:        `upgrade to strimzi version "strimzi-cluster-operator.v0.24.0-0", kafka version "2.8.0" and kafka ibp version "2.8" started`,
+				},
+			},
+		},
+		{
+			name:        "should record the end of the upgrade of the kafka",
+			condition:   dbapi.DataPlaneKafkaStatusCondition{Type: "Ready", Status: "True"},
+			kafkaStatus: constants.KafkaRequestStatusReady.String(),
+			upgrading:   true,
+			wantEvents: []dbapi.KafkaEvent{
+				{
+					KafkaId:        "kafka-id",
+					Type:           dbapi.KafkaEventTypeUpgraded,
+					PreviousStatus: constants.KafkaRequestStatusReady.String(),
+					Status:         constants.KafkaRequestStatusReady.String(),
+					Details:        `upgraded to strimzi version "strimzi-cluster-operator.v0.23.0-0", kafka version "2.7.0" and kafka ibp version "2.7"`,
+				},
+			},
+		},
+		{
+			name:        "should not record any event when the kafka is installing",
+			condition:   dbapi.DataPlaneKafkaStatusCondition{Type: "Ready", Status: "False", Reason: "Installing"},
+			kafkaStatus: constants.KafkaRequestStatusProvisioning.String(),
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			kafkaEvents := &KafkaEventServiceMock{
+				RecordFunc: func(event *dbapi.KafkaEvent) *errors.ServiceError {
+					return nil
+				},
+			}
+			s := NewDataPlaneKafkaService(&KafkaServiceMock{
+				GetByIDFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					kafka := &dbapi.KafkaRequest{
+						Meta:                   api.Meta{ID: id},
+						ClusterID:              "test-cluster-id",
+						Status:                 tt.kafkaStatus,
+						ActualStrimziVersion:   "strimzi-cluster-operator.v0.23.0-0",
+						DesiredStrimziVersion:  "strimzi-cluster-operator.v0.24.0-0",
+						ActualKafkaVersion:     "2.7.0",
+						DesiredKafkaVersion:    "2.8.0",
+						ActualKafkaIBPVersion:  "2.7",
+						DesiredKafkaIBPVersion: "2.8",
+						StrimziUpgrading:       tt.upgrading,
+					}
+					kafka.SetStoredStatus(tt.kafkaStatus)
+					return kafka, nil
+				},
+				UpdateFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+					return nil
+				},
+				UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *errors.ServiceError {
+					return nil
+				},
+			}, &ClusterServiceMock{
+				FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+					return &api.Cluster{ClusterID: clusterID}, nil
+				},
+			}, &config.KafkaConfig{}, kafkaEvents)
+
+			err := s.UpdateDataPlaneKafkaService(context.TODO(), "test-cluster-id", []*dbapi.DataPlaneKafkaStatus{
+				{
+					KafkaClusterId: "kafka-id",
+					Conditions:     []dbapi.DataPlaneKafkaStatusCondition{tt.condition},
+				},
+			})
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+
+			var events []dbapi.KafkaEvent
+			for _, call := range kafkaEvents.RecordCalls() {
+				events = append(events, *call.Event)
+			}
+			g.Expect(events).To(gomega.Equal(tt.wantEvents))
+		})
+	}
+}
+
+func Test_dataPlaneKafkaService_processKafkaMigrationStatus(t *testing.T) {
+	readyCondition := dbapi.DataPlaneKafkaStatusCondition{Type: "Ready", Status: "True"}
+	routes := []dbapi.DataPlaneKafkaRouteRequest{
+		{
+			Name:   "bootstrap",
+			Router: "router.target.example.com",
+		},
+	}
+
+	tests := []struct {
+		name                 string
+		reportingClusterID   string
+		kafka                dbapi.KafkaRequest
+		condition            dbapi.DataPlaneKafkaStatusCondition
+		routes               []dbapi.DataPlaneKafkaRouteRequest
+		wantUpdatesCalls     int
+		wantMigrationStatus  dbapi.KafkaMigrationStatus
+		wantMigrationRoutes  []dbapi.DataPlaneKafkaRoute
+		wantSourceClusterID  string
+		wantTargetClusterID  string
+		wantMigrationDetails string
+	}{
+		{
+			name:               "should cut over the kafka once it is ready on the target cluster",
+			reportingClusterID: "target-cluster-id",
+			kafka: dbapi.KafkaRequest{
+				MigrationStatus:          dbapi.KafkaMigrationStatusProvisioning,
+				MigrationTargetClusterId: "target-cluster-id",
+			},
+			condition:           readyCondition,
+			routes:              routes,
+			wantUpdatesCalls:    1,
+			wantMigrationStatus: dbapi.KafkaMigrationStatusCuttingOver,
+			wantMigrationRoutes: []dbapi.DataPlaneKafkaRoute{{Domain: "kafka.example.com", Router: "router.target.example.com"}},
+			wantTargetClusterID: "target-cluster-id",
+		},
+		{
+			name:               "should wait for the routes of the target cluster",
+			reportingClusterID: "target-cluster-id",
+			kafka: dbapi.KafkaRequest{
+				MigrationStatus:          dbapi.KafkaMigrationStatusProvisioning,
+				MigrationTargetClusterId: "target-cluster-id",
+			},
+			condition:           readyCondition,
+			wantMigrationStatus: dbapi.KafkaMigrationStatusProvisioning,
+			wantTargetClusterID: "target-cluster-id",
+		},
+		{
+			name:               "should fail the migration when the target cluster rejects the kafka",
+			reportingClusterID: "target-cluster-id",
+			kafka: dbapi.KafkaRequest{
+				MigrationStatus:          dbapi.KafkaMigrationStatusProvisioning,
+				MigrationTargetClusterId: "target-cluster-id",
+			},
+			condition:            dbapi.DataPlaneKafkaStatusCondition{Type: "Ready", Status: "False", Reason: "Rejected"},
+			wantUpdatesCalls:     1,
+			wantMigrationStatus:  dbapi.KafkaMigrationStatusFailed,
+			wantTargetClusterID:  "target-cluster-id",
+			wantMigrationDetails: `rejected by target cluster "target-cluster-id"`,
+		},
+		{
+			name:               "should forget the target cluster of a failed migration once the kafka is deleted from it",
+			reportingClusterID: "target-cluster-id",
+			kafka: dbapi.KafkaRequest{
+				MigrationStatus:          dbapi.KafkaMigrationStatusFailed,
+				MigrationTargetClusterId: "target-cluster-id",
+				MigrationDetails:         "rejected",
+			},
+			condition:            dbapi.DataPlaneKafkaStatusCondition{Type: "Ready", Status: "False", Reason: "Deleted"},
+			wantUpdatesCalls:     1,
+			wantMigrationStatus:  dbapi.KafkaMigrationStatusFailed,
+			wantMigrationDetails: "rejected",
+		},
+		{
+			name:               "should complete the migration once the kafka is deleted from the source cluster",
+			reportingClusterID: "source-cluster-id",
+			kafka: dbapi.KafkaRequest{
+				MigrationStatus:          dbapi.KafkaMigrationStatusDeprovisioning,
+				MigrationSourceClusterId: "source-cluster-id",
+			},
+			condition:           dbapi.DataPlaneKafkaStatusCondition{Type: "Ready", Status: "False", Reason: "Deleted"},
+			wantUpdatesCalls:    1,
+			wantMigrationStatus: dbapi.KafkaMigrationStatusNoMigration,
+		},
+		{
+			name:               "should ignore the status of the kafka on the source cluster until it is deleted",
+			reportingClusterID: "source-cluster-id",
+			kafka: dbapi.KafkaRequest{
+				MigrationStatus:          dbapi.KafkaMigrationStatusDeprovisioning,
+				MigrationSourceClusterId: "source-cluster-id",
+			},
+			condition:           readyCondition,
+			wantMigrationStatus: dbapi.KafkaMigrationStatusDeprovisioning,
+			wantSourceClusterID: "source-cluster-id",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			kafka := tt.kafka
+			kafka.ID = "kafka-id"
+			kafka.ClusterID = "current-cluster-id"
+			kafka.Status = constants.KafkaRequestStatusReady.String()
+			kafka.BootstrapServerHost = "kafka.example.com"
+
+			kafkaService := &KafkaServiceMock{
+				GetByIDFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					return &kafka, nil
+				},
+				UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *errors.ServiceError {
+					return nil
+				},
+			}
+			s := NewDataPlaneKafkaService(kafkaService, &ClusterServiceMock{
+				FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+					return &api.Cluster{ClusterID: clusterID}, nil
+				},
+				GetClusterDNSFunc: func(clusterID string) (string, *errors.ServiceError) {
+					return "apps.target.example.com", nil
+				},
+			}, &config.KafkaConfig{}, &KafkaEventServiceMock{
+				RecordFunc: func(event *dbapi.KafkaEvent) *errors.ServiceError {
+					return nil
+				},
+			})
+
+			err := s.UpdateDataPlaneKafkaService(context.TODO(), tt.reportingClusterID, []*dbapi.DataPlaneKafkaStatus{
+				{
+					KafkaClusterId: "kafka-id",
+					Conditions:     []dbapi.DataPlaneKafkaStatusCondition{tt.condition},
+					Routes:         tt.routes,
+				},
+			})
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(kafkaService.UpdatesCalls()).To(gomega.HaveLen(tt.wantUpdatesCalls))
+			g.Expect(kafka.ClusterID).To(gomega.Equal("current-cluster-id"))
+			g.Expect(kafka.Status).To(gomega.Equal(constants.KafkaRequestStatusReady.String()))
+			g.Expect(kafka.MigrationStatus).To(gomega.Equal(tt.wantMigrationStatus))
+			g.Expect(kafka.MigrationSourceClusterId).To(gomega.Equal(tt.wantSourceClusterID))
+			g.Expect(kafka.MigrationTargetClusterId).To(gomega.Equal(tt.wantTargetClusterID))
+			g.Expect(kafka.MigrationDetails).To(gomega.Equal(tt.wantMigrationDetails))
+			migrationRoutes, _ := kafka.GetMigrationRoutes()
+			g.Expect(migrationRoutes).To(gomega.Equal(tt.wantMigrationRoutes))
+		})
+	}
+}
+
 func TestDataPlaneKafkaService_UpdateVersions(t *testing.T) {
 	type versions struct {
 		actualKafkaVersion    string
@@ -739,6 +1034,7 @@ func TestDataPlaneKafkaService_UpdateVersions(t *testing.T) {
 		strimziUpgrading      bool
 		kafkaUpgrading        bool
 		kafkaIBPUpgrading     bool
+		upgradeForced         bool
 	}
 
 	tests := []struct {
@@ -994,6 +1290,131 @@ func TestDataPlaneKafkaService_UpdateVersions(t *testing.T) {
 				kafkaIBPUpgrading:     true,
 			},
 		},
+		{
+			name: "should reset the forced upgrade once the desired versions are running",
+			clusterService: &ClusterServiceMock{
+				FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+					return &api.Cluster{ClusterID: "test-cluster-id"}, nil
+				},
+			},
+			kafkaService: func(v *versions) KafkaService {
+				return &KafkaServiceMock{
+					GetByIDFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return &dbapi.KafkaRequest{
+							ClusterID:              "test-cluster-id",
+							Status:                 constants.KafkaRequestStatusReady.String(),
+							Routes:                 []byte("[{'domain':'test.example.com', 'router':'test.example.com'}]"),
+							RoutesCreated:          true,
+							DesiredKafkaVersion:    "kafka-2",
+							DesiredKafkaIBPVersion: "kafka-ibp-3",
+							DesiredStrimziVersion:  "strimzi-1",
+							ActualKafkaVersion:     "kafka-1",
+							ActualKafkaIBPVersion:  "kafka-ibp-3",
+							ActualStrimziVersion:   "strimzi-1",
+							KafkaUpgrading:         true,
+							UpgradeForced:          true,
+						}, nil
+					},
+					UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, fields map[string]interface{}) *errors.ServiceError {
+						v.actualKafkaVersion = kafkaRequest.ActualKafkaVersion
+						v.actualKafkaIBPVersion = kafkaRequest.ActualKafkaIBPVersion
+						v.actualStrimziVersion = kafkaRequest.ActualStrimziVersion
+						v.strimziUpgrading = kafkaRequest.StrimziUpgrading
+						v.kafkaUpgrading = kafkaRequest.KafkaUpgrading
+						v.kafkaIBPUpgrading = kafkaRequest.KafkaIBPUpgrading
+						v.upgradeForced = kafkaRequest.UpgradeForced
+						return nil
+					},
+					UpdateStatusFunc: func(id string, status constants.KafkaStatus) (bool, *errors.ServiceError) {
+						return true, nil
+					},
+				}
+			},
+			clusterId: "test-cluster-id",
+			status: []*dbapi.DataPlaneKafkaStatus{
+				{
+					Conditions: []dbapi.DataPlaneKafkaStatusCondition{
+						{
+							Type:   "Ready",
+							Status: "True",
+						},
+					},
+					KafkaVersion:    "kafka-2",
+					StrimziVersion:  "strimzi-1",
+					KafkaIBPVersion: "kafka-ibp-3",
+				},
+			},
+			wantErr: false,
+			expectedVersions: versions{
+				actualKafkaVersion:    "kafka-2",
+				actualStrimziVersion:  "strimzi-1",
+				actualKafkaIBPVersion: "kafka-ibp-3",
+				upgradeForced:         false,
+			},
+		},
+		{
+			name: "should keep the forced upgrade while it is in progress",
+			clusterService: &ClusterServiceMock{
+				FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+					return &api.Cluster{ClusterID: "test-cluster-id"}, nil
+				},
+			},
+			kafkaService: func(v *versions) KafkaService {
+				return &KafkaServiceMock{
+					GetByIDFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+						return &dbapi.KafkaRequest{
+							ClusterID:              "test-cluster-id",
+							Status:                 constants.KafkaRequestStatusReady.String(),
+							Routes:                 []byte("[{'domain':'test.example.com', 'router':'test.example.com'}]"),
+							RoutesCreated:          true,
+							DesiredKafkaVersion:    "kafka-2",
+							DesiredKafkaIBPVersion: "kafka-ibp-3",
+							DesiredStrimziVersion:  "strimzi-1",
+							ActualKafkaVersion:     "kafka-1",
+							ActualKafkaIBPVersion:  "kafka-ibp-3",
+							ActualStrimziVersion:   "strimzi-1",
+							UpgradeForced:          true,
+						}, nil
+					},
+					UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, fields map[string]interface{}) *errors.ServiceError {
+						v.actualKafkaVersion = kafkaRequest.ActualKafkaVersion
+						v.actualKafkaIBPVersion = kafkaRequest.ActualKafkaIBPVersion
+						v.actualStrimziVersion = kafkaRequest.ActualStrimziVersion
+						v.strimziUpgrading = kafkaRequest.StrimziUpgrading
+						v.kafkaUpgrading = kafkaRequest.KafkaUpgrading
+						v.kafkaIBPUpgrading = kafkaRequest.KafkaIBPUpgrading
+						v.upgradeForced = kafkaRequest.UpgradeForced
+						return nil
+					},
+					UpdateStatusFunc: func(id string, status constants.KafkaStatus) (bool, *errors.ServiceError) {
+						return true, nil
+					},
+				}
+			},
+			clusterId: "test-cluster-id",
+			status: []*dbapi.DataPlaneKafkaStatus{
+				{
+					Conditions: []dbapi.DataPlaneKafkaStatusCondition{
+						{
+							Type:   "Ready",
+							Status: "True",
+							Reason: "KafkaUpdating",
+						},
+					},
+					KafkaVersion:    "kafka-1",
+					StrimziVersion:  "strimzi-1",
+					KafkaIBPVersion: "kafka-ibp-3",
+				},
+			},
+			wantErr: false,
+			expectedVersions: versions{
+				actualKafkaVersion:    "kafka-1",
+				actualStrimziVersion:  "strimzi-1",
+				actualKafkaIBPVersion: "kafka-ibp-3",
+				kafkaUpgrading:        true,
+				upgradeForced:         true,
+			},
+		},
 	}
 
 	for _, testcase := range tests {
@@ -1002,7 +1423,11 @@ func TestDataPlaneKafkaService_UpdateVersions(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
 			v := versions{}
-			s := NewDataPlaneKafkaService(tt.kafkaService(&v), tt.clusterService, &config.KafkaConfig{})
+			s := NewDataPlaneKafkaService(tt.kafkaService(&v), tt.clusterService, &config.KafkaConfig{}, &KafkaEventServiceMock{
+				RecordFunc: func(event *dbapi.KafkaEvent) *errors.ServiceError {
+					return nil
+				},
+			})
 			err := s.UpdateDataPlaneKafkaService(context.TODO(), tt.clusterId, tt.status)
 			if err != nil && !tt.wantErr {
 				t.Errorf("unexpected error %v", err)
@@ -1229,6 +1654,11 @@ func Test_dataPlaneKafkaService_unassignKafkaFromDataplaneCluster(t *testing.T)
 			g := gomega.NewWithT(t)
 			d := &dataPlaneKafkaService{
 				kafkaService: tt.fields.kafkaService,
+				kafkaEvents: &KafkaEventServiceMock{
+					RecordFunc: func(event *dbapi.KafkaEvent) *errors.ServiceError {
+						return nil
+					},
+				},
 			}
 			got := d.unassignKafkaFromDataplaneCluster(tt.args.kafka)
 			g.Expect(got).To(gomega.Equal(tt.want))
@@ -1736,3 +2166,56 @@ func Test_dataPlaneKafkaService_processReservedKafkaDeployment(t *testing.T) {
 		})
 	}
 }
+
+func TestDataPlaneKafkaService_UpdatePendingCanaryServiceAccountReady(t *testing.T) {
+	tests := []struct {
+		name      string
+		kafka     *dbapi.KafkaRequest
+		status    *dbapi.DataPlaneKafkaStatus
+		wantReady bool
+	}{
+		{
+			name:   "should not update a kafka whose canary credentials are not being rotated",
+			kafka:  &dbapi.KafkaRequest{CanaryServiceAccountClientID: "canary-kafka-id"},
+			status: &dbapi.DataPlaneKafkaStatus{CanaryPrincipal: "canary-kafka-id", CanaryReady: true},
+		},
+		{
+			name:   "should not update the kafka while the canary is authenticated with the previous service account",
+			kafka:  &dbapi.KafkaRequest{CanaryServiceAccountClientID: "canary-kafka-id", PendingCanaryServiceAccountClientID: "canary-kafka-id-1679616000"},
+			status: &dbapi.DataPlaneKafkaStatus{CanaryPrincipal: "canary-kafka-id", CanaryReady: true},
+		},
+		{
+			name:   "should not update the kafka while the canary is not ready",
+			kafka:  &dbapi.KafkaRequest{CanaryServiceAccountClientID: "canary-kafka-id", PendingCanaryServiceAccountClientID: "canary-kafka-id-1679616000"},
+			status: &dbapi.DataPlaneKafkaStatus{CanaryPrincipal: "canary-kafka-id-1679616000"},
+		},
+		{
+			name:      "should mark the pending service account as ready once the canary is ready with it",
+			kafka:     &dbapi.KafkaRequest{CanaryServiceAccountClientID: "canary-kafka-id", PendingCanaryServiceAccountClientID: "canary-kafka-id-1679616000"},
+			status:    &dbapi.DataPlaneKafkaStatus{CanaryPrincipal: "canary-kafka-id-1679616000", CanaryReady: true},
+			wantReady: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			kafkaService := &KafkaServiceMock{
+				UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *errors.ServiceError {
+					return nil
+				},
+			}
+			s := NewDataPlaneKafkaService(kafkaService, &ClusterServiceMock{}, &config.KafkaConfig{}, &KafkaEventServiceMock{})
+			g.Expect(s.setPendingCanaryServiceAccountReady(tt.kafka, tt.status)).To(gomega.BeNil())
+			g.Expect(tt.kafka.PendingCanaryServiceAccountReady).To(gomega.Equal(tt.wantReady))
+			if tt.wantReady {
+				g.Expect(kafkaService.UpdatesCalls()).To(gomega.HaveLen(1))
+				g.Expect(kafkaService.UpdatesCalls()[0].Values).To(gomega.Equal(map[string]interface{}{"pending_canary_service_account_ready": true}))
+			} else {
+				g.Expect(kafkaService.UpdatesCalls()).To(gomega.BeEmpty())
+			}
+		})
+	}
+}
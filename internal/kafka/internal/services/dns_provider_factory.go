@@ -0,0 +1,133 @@
+package services
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/cloudproviders"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/aws"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/dns"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+)
+
+const rfc2136Timeout = 10 * time.Second
+
+// DNSProviderFactory returns the DNS providers managing the CNAME records of the routes of the Kafka instances
+//
+//go:generate moq -out dns_provider_factory_moq.go . DNSProviderFactory
+type DNSProviderFactory interface {
+	// GetProvider returns the DNS provider configured for the Kafka instances of the cloud provider
+	GetProvider(cloudProvider string) (dns.Provider, *errors.ServiceError)
+	// GetProviderByKind returns the DNS provider of the given kind for the Kafka instances of the cloud provider. It is
+	// used to track the changes made by a provider which is no longer the configured one.
+	GetProviderByKind(kind string, cloudProvider string) (dns.Provider, *errors.ServiceError)
+}
+
+var _ DNSProviderFactory = &defaultDNSProviderFactory{}
+
+type defaultDNSProviderFactory struct {
+	dnsConfig        *config.DNSConfig
+	awsConfig        *config.AWSConfig
+	gcpConfig        *config.GCPConfig
+	awsClientFactory aws.ClientFactory
+
+	mu               sync.Mutex
+	cloudDNSProvider dns.Provider
+}
+
+func NewDNSProviderFactory(dnsConfig *config.DNSConfig, awsConfig *config.AWSConfig, gcpConfig *config.GCPConfig, awsClientFactory aws.ClientFactory) DNSProviderFactory {
+	return &defaultDNSProviderFactory{
+		dnsConfig:        dnsConfig,
+		awsConfig:        awsConfig,
+		gcpConfig:        gcpConfig,
+		awsClientFactory: awsClientFactory,
+	}
+}
+
+func (f *defaultDNSProviderFactory) GetProvider(cloudProvider string) (dns.Provider, *errors.ServiceError) {
+	return f.GetProviderByKind(f.dnsConfig.ProviderFor(cloudProvider), cloudProvider)
+}
+
+func (f *defaultDNSProviderFactory) GetProviderByKind(kind string, cloudProvider string) (dns.Provider, *errors.ServiceError) {
+	switch kind {
+	case dns.KindRoute53:
+		return f.newRoute53Provider(cloudProvider)
+	case dns.KindCloudDNS:
+		return f.getCloudDNSProvider(), nil
+	case dns.KindRFC2136:
+		return dns.NewRFC2136Provider(dns.RFC2136Config{
+			Nameserver:    f.dnsConfig.RFC2136Nameserver,
+			TSIGKeyName:   f.dnsConfig.RFC2136TSIGKeyName,
+			TSIGSecret:    f.dnsConfig.RFC2136TSIGSecret,
+			TSIGAlgorithm: f.dnsConfig.RFC2136TSIGAlgorithm,
+			Timeout:       rfc2136Timeout,
+		}), nil
+	default:
+		return nil, errors.GeneralError("unknown DNS provider: %q", kind)
+	}
+}
+
+func (f *defaultDNSProviderFactory) newRoute53Provider(cloudProvider string) (dns.Provider, *errors.ServiceError) {
+	route53Region, err := getRoute53Region(cloudProvider)
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "error getting route 53 region from cloud provider")
+	}
+
+	awsClient, err := f.awsClientFactory.NewClient(aws.Config{
+		AccessKeyID:     f.awsConfig.Route53.AccessKey,
+		SecretAccessKey: f.awsConfig.Route53.SecretAccessKey,
+	}, route53Region)
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to create aws client")
+	}
+
+	return dns.NewRoute53Provider(awsClient), nil
+}
+
+// getCloudDNSProvider returns the Cloud DNS provider, it is shared so that the access tokens of its client are reused
+func (f *defaultDNSProviderFactory) getCloudDNSProvider() dns.Provider {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.cloudDNSProvider == nil {
+		credentials := f.gcpConfig.GCPCredentials
+		projectID := f.dnsConfig.CloudDNSProjectID
+		if projectID == "" {
+			projectID = credentials.ProjectID
+		}
+		client := dns.NewCloudDNSHTTPClient(dns.GoogleServiceAccount{
+			ClientEmail:  credentials.ClientEmail,
+			PrivateKeyID: credentials.PrivateKeyID,
+			PrivateKey:   credentials.PrivateKey,
+			TokenURI:     credentials.TokenURI,
+		})
+		f.cloudDNSProvider = dns.NewCloudDNSProvider(dns.CloudDNSConfig{
+			ProjectID:   projectID,
+			ManagedZone: f.dnsConfig.CloudDNSManagedZone,
+		}, client)
+	}
+	return f.cloudDNSProvider
+}
+
+// getRoute53Region calculates the AWS region to be used for Route53 from the
+// cloud provider of the Kafka instance.
+// Route53 is a global service which means that in most of the cases
+// the region specified is only used to access a regional endpoint in AWS.
+// There are some parts of the Route53 functionality that are regional.
+// For what we perform which is create hosted zones and entries in them
+// that is a global functionality.
+// See: https://docs.aws.amazon.com/Route53/latest/DeveloperGuide/disaster-recovery-resiliency.html
+// If at some point we end up needing Route53 regional functionalities this
+// mechanism should be reevaluated
+func getRoute53Region(cloudProvider string) (string, error) {
+	switch cloudProvider {
+	case cloudproviders.AWS.String():
+		return aws.DefaultAWSRoute53Region, nil
+	case cloudproviders.GCP.String():
+		return aws.DefaultGCPRoute53Region, nil
+	default:
+		return "", fmt.Errorf("unknown cloud provider: %q", cloudProvider)
+	}
+}
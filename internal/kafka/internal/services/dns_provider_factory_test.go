@@ -0,0 +1,139 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/cloudproviders"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/aws"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/dns"
+	"github.com/onsi/gomega"
+)
+
+func Test_defaultDNSProviderFactory_GetProvider(t *testing.T) {
+	type args struct {
+		cloudProvider string
+	}
+
+	tests := []struct {
+		name      string
+		dnsConfig *config.DNSConfig
+		args      args
+		wantKind  string
+		wantErr   bool
+	}{
+		{
+			name:      "should return the Route53 provider by default",
+			dnsConfig: config.NewDNSConfig(),
+			args: args{
+				cloudProvider: cloudproviders.AWS.String(),
+			},
+			wantKind: dns.KindRoute53,
+		},
+		{
+			name: "should return the provider of the cloud provider when it is overridden",
+			dnsConfig: func() *config.DNSConfig {
+				c := config.NewDNSConfig()
+				c.CloudProviderProviders = map[string]string{cloudproviders.GCP.String(): dns.KindCloudDNS}
+				return c
+			}(),
+			args: args{
+				cloudProvider: cloudproviders.GCP.String(),
+			},
+			wantKind: dns.KindCloudDNS,
+		},
+		{
+			name: "should return the RFC 2136 provider",
+			dnsConfig: func() *config.DNSConfig {
+				c := config.NewDNSConfig()
+				c.Provider = dns.KindRFC2136
+				c.RFC2136Nameserver = "127.0.0.1:53"
+				return c
+			}(),
+			args: args{
+				cloudProvider: cloudproviders.AWS.String(),
+			},
+			wantKind: dns.KindRFC2136,
+		},
+		{
+			name:      "should return an error when the Route53 region of the cloud provider is unknown",
+			dnsConfig: config.NewDNSConfig(),
+			args: args{
+				cloudProvider: "anunknowncloudprovider",
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when the provider is unknown",
+			dnsConfig: func() *config.DNSConfig {
+				c := config.NewDNSConfig()
+				c.Provider = "unknown"
+				return c
+			}(),
+			args: args{
+				cloudProvider: cloudproviders.AWS.String(),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			f := NewDNSProviderFactory(tt.dnsConfig, config.NewAWSConfig(), config.NewGCPConfig(), aws.NewMockClientFactory(&aws.AWSClientMock{}))
+			provider, err := f.GetProvider(tt.args.cloudProvider)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if !tt.wantErr {
+				g.Expect(provider.Kind()).To(gomega.Equal(tt.wantKind))
+			}
+		})
+	}
+}
+
+func Test_getRoute53Region(t *testing.T) {
+	type args struct {
+		cloudProvider string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Route53 region is correctly returned for Kafka instances in AWS",
+			args: args{
+				cloudProvider: cloudproviders.AWS.String(),
+			},
+			want:    aws.DefaultAWSRoute53Region,
+			wantErr: false,
+		},
+		{
+			name: "Route53 region is correctly returned for Kafka instances in GCP",
+			args: args{
+				cloudProvider: cloudproviders.GCP.String(),
+			},
+			want:    aws.DefaultGCPRoute53Region,
+			wantErr: false,
+		},
+		{
+			name: "An error is returned if the Kafka instance has an unknown cloud provider",
+			args: args{
+				cloudProvider: "anunknowncloudprovider",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			res, err := getRoute53Region(tt.args.cloudProvider)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(res).To(gomega.Equal(tt.want))
+		})
+	}
+}
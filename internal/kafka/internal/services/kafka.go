@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 
@@ -13,7 +14,6 @@ import (
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
-	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/cloudproviders"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services/kafkatlscertmgmt"
@@ -31,10 +31,9 @@ import (
 	managedkafka "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api/managedkafkas.managedkafka.bf2.org/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
-	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/aws"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/dns"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/metrics"
@@ -60,15 +59,9 @@ func (a KafkaRoutesAction) String() string {
 const (
 	KafkaRoutesActionCreate KafkaRoutesAction = "CREATE"
 	KafkaRoutesActionDelete KafkaRoutesAction = "DELETE"
+	KafkaRoutesActionUpsert KafkaRoutesAction = "UPSERT"
 )
 
-const CanaryServiceAccountPrefix = "canary"
-
-type CNameRecordStatus struct {
-	Id     *string
-	Status *string
-}
-
 //go:generate moq -out kafkaservice_moq.go . KafkaService
 type KafkaService interface {
 	// PrepareKafkaRequest sets any required information (i.e. bootstrap server host, sso client id and secret)
@@ -88,7 +81,22 @@ type KafkaService interface {
 	// Lists all kafkas. As this returns all Kafka requests without need for authentication, this should only be used for internal purposes
 	ListAll() (dbapi.KafkaList, *errors.ServiceError)
 	ListKafkasToBePromoted() ([]*dbapi.KafkaRequest, *errors.ServiceError)
-	GetManagedKafkaByClusterID(clusterID string) ([]managedkafka.ManagedKafka, *errors.ServiceError)
+	// ListKafkasToBeResized returns the kafkas whose resize to another size of their instance type is in progress,
+	// except the kafkas being deleted. Only the ready kafkas can be resized: the resize of the other ones is to be aborted
+	ListKafkasToBeResized() ([]*dbapi.KafkaRequest, *errors.ServiceError)
+	// AbortResize releases the quota reserved for the resize in progress of the given kafka, if any, clears its desired
+	// size and marks its resize as failed with the given reason
+	AbortResize(kafkaRequest *dbapi.KafkaRequest, reason string) *errors.ServiceError
+	// ListKafkasWithPendingUpgrade returns the kafkas deployed on the data plane whose desired Strimzi, Kafka or Kafka IBP
+	// version differs from the version running on the data plane
+	ListKafkasWithPendingUpgrade() ([]*dbapi.KafkaRequest, *errors.ServiceError)
+	// ListKafkasByClusterID returns the kafkas consuming resources on the data plane cluster with the given clusterID:
+	// the kafkas assigned to it and the kafkas still being migrated from it
+	ListKafkasByClusterID(clusterID string) ([]*dbapi.KafkaRequest, *errors.ServiceError)
+	// GetManagedKafkaByClusterID returns the managed kafkas to be reconciled by the data plane cluster with the given clusterID,
+	// ordered by their version. When gtVersion is greater than 0, only the kafkas with a version greater than gtVersion are returned,
+	// along with the kafkas which left the cluster since gtVersion, returned as deleted.
+	GetManagedKafkaByClusterID(clusterID string, gtVersion int64) ([]managedkafka.ManagedKafka, *errors.ServiceError)
 	// GenerateReservedManagedKafkasByClusterID returns a list of reserved managed
 	// kafkas for a given clusterID. The number of generated reserved managed
 	// kafkas in the cluster is the sum of the specified number of reserved
@@ -112,8 +120,20 @@ type KafkaService interface {
 	// Use this only when you want to update the multiple columns that may contain zero-fields, otherwise use the `KafkaService.Update()` method.
 	// See https://gorm.io/docs/update.html#Updates-multiple-columns for more info
 	Updates(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *errors.ServiceError
-	ChangeKafkaCNAMErecords(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*route53.ChangeResourceRecordSetsOutput, *errors.ServiceError)
-	GetCNAMERecordStatus(kafkaRequest *dbapi.KafkaRequest) (*CNameRecordStatus, error)
+	// Suspend marks the given kafka instance to be suspended on behalf of the user in the given context.
+	// The user performing the request and the time of the request are recorded in the kafka request.
+	// Only kafka instances in 'ready' status can be suspended.
+	Suspend(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError
+	// Resume marks the given suspended kafka instance to be resumed.
+	// Kafka instances that are within their grace period cannot be resumed. The quota of the instance is
+	// reserved again before resuming it, as instances suspended by a user do not consume quota.
+	Resume(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError
+	// ChangeKafkaCNAMErecords applies the action to the CNAME records of the routes of the Kafka instance with the DNS
+	// provider of its cloud provider. The id of the returned change is qualified by the kind of the DNS provider.
+	ChangeKafkaCNAMErecords(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*dns.Change, *errors.ServiceError)
+	// GetCNAMERecordStatus returns the status of the change of the CNAME records identified by the RoutesCreationId
+	// of the Kafka instance, with the DNS provider which made the change
+	GetCNAMERecordStatus(kafkaRequest *dbapi.KafkaRequest) (*dns.Change, error)
 	AssignInstanceType(owner string, organisationID string) (types.KafkaInstanceType, *errors.ServiceError)
 	RegisterKafkaDeprovisionJob(ctx context.Context, id string) *errors.ServiceError
 	// DeprovisionKafkaForUsers registers all kafkas for deprovisioning given the list of owners
@@ -121,6 +141,8 @@ type KafkaService interface {
 	DeprovisionExpiredKafkas() *errors.ServiceError
 	CountByStatus(status []constants.KafkaStatus) ([]KafkaStatusCount, error)
 	ListKafkasWithRoutesNotCreated() ([]*dbapi.KafkaRequest, *errors.ServiceError)
+	// ListDeletedKafkasWithRoutes lists the kafkas deleted since the given time whose CNAME records had been created
+	ListDeletedKafkasWithRoutes(deletedSince time.Time) ([]*dbapi.KafkaRequest, *errors.ServiceError)
 	VerifyAndUpdateKafkaAdmin(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError
 	ListComponentVersions() ([]KafkaComponentVersions, error)
 	HasAvailableCapacityInRegion(kafkaRequest *dbapi.KafkaRequest) (bool, *errors.ServiceError)
@@ -146,36 +168,45 @@ type kafkaService struct {
 	clusterService                       ClusterService
 	keycloakService                      sso.KeycloakService
 	kafkaConfig                          *config.KafkaConfig
-	awsConfig                            *config.AWSConfig
 	quotaServiceFactory                  QuotaServiceFactory
 	mu                                   sync.Mutex
-	awsClientFactory                     aws.ClientFactory
+	dnsProviderFactory                   DNSProviderFactory
 	authService                          authorization.Authorization
 	dataplaneClusterConfig               *config.DataplaneClusterConfig
 	providerConfig                       *config.ProviderConfig
 	clusterPlacementStrategy             ClusterPlacementStrategy
 	kafkaTLSCertificateManagementService kafkatlscertmgmt.KafkaTLSCertificateManagementService
+	kafkaTLSCertificateInventoryService  KafkaTLSCertificateInventoryService
+	canaryServiceAccountService          CanaryServiceAccountService
+	kafkaEvents                          KafkaEventService
+	maintenanceWindowService             MaintenanceWindowService
 }
 
 func NewKafkaService(
 	connectionFactory *db.ConnectionFactory, clusterService ClusterService, keycloakService sso.KafkaKeycloakService,
-	kafkaConfig *config.KafkaConfig, dataplaneClusterConfig *config.DataplaneClusterConfig, awsConfig *config.AWSConfig,
-	quotaServiceFactory QuotaServiceFactory, awsClientFactory aws.ClientFactory, authorizationService authorization.Authorization,
+	kafkaConfig *config.KafkaConfig, dataplaneClusterConfig *config.DataplaneClusterConfig,
+	quotaServiceFactory QuotaServiceFactory, dnsProviderFactory DNSProviderFactory, authorizationService authorization.Authorization,
 	providerConfig *config.ProviderConfig, clusterPlacementStrategy ClusterPlacementStrategy,
-	kafkaTLSCertificateManagementService kafkatlscertmgmt.KafkaTLSCertificateManagementService) *kafkaService {
+	kafkaTLSCertificateManagementService kafkatlscertmgmt.KafkaTLSCertificateManagementService,
+	kafkaTLSCertificateInventoryService KafkaTLSCertificateInventoryService,
+	canaryServiceAccountService CanaryServiceAccountService, kafkaEvents KafkaEventService,
+	maintenanceWindowService MaintenanceWindowService) *kafkaService {
 	return &kafkaService{
 		connectionFactory:                    connectionFactory,
 		clusterService:                       clusterService,
 		keycloakService:                      keycloakService,
 		kafkaConfig:                          kafkaConfig,
-		awsConfig:                            awsConfig,
 		quotaServiceFactory:                  quotaServiceFactory,
-		awsClientFactory:                     awsClientFactory,
+		dnsProviderFactory:                   dnsProviderFactory,
 		authService:                          authorizationService,
 		dataplaneClusterConfig:               dataplaneClusterConfig,
 		providerConfig:                       providerConfig,
 		clusterPlacementStrategy:             clusterPlacementStrategy,
 		kafkaTLSCertificateManagementService: kafkaTLSCertificateManagementService,
+		kafkaTLSCertificateInventoryService:  kafkaTLSCertificateInventoryService,
+		canaryServiceAccountService:          canaryServiceAccountService,
+		kafkaEvents:                          kafkaEvents,
+		maintenanceWindowService:             maintenanceWindowService,
 	}
 }
 
@@ -330,7 +361,8 @@ func (k *kafkaService) AssignInstanceType(owner string, organisationId string) (
 
 // reserveQuota - reserves quota for the given kafka request. If a RHOSAK quota has been assigned, it will try to reserve RHOSAK quota, otherwise it will try with RHOSAKTrial
 func (k *kafkaService) reserveQuota(kafkaRequest *dbapi.KafkaRequest) (subscriptionId string, err *errors.ServiceError) {
-	if kafkaRequest.InstanceType == types.DEVELOPER.String() {
+	// the enterprise kafkas consume the quota of the enterprise clusters instead of the developer instances allowance
+	if kafkaRequest.InstanceType == types.DEVELOPER.String() && !kafkaRequest.DesiredBillingModelIsEnterprise() {
 		instType, err := k.kafkaConfig.SupportedInstanceTypes.Configuration.GetKafkaInstanceTypeByID(kafkaRequest.InstanceType)
 
 		if err != nil {
@@ -346,6 +378,7 @@ func (k *kafkaService) reserveQuota(kafkaRequest *dbapi.KafkaRequest) (subscript
 		var count int64
 		if err := dbConn.Model(&dbapi.KafkaRequest{}).
 			Where("instance_type = ?", types.DEVELOPER).
+			Where("desired_kafka_billing_model IS NULL OR desired_kafka_billing_model <> ?", constants.BillingModelEnterprise.String()).
 			Where("owner = ?", kafkaRequest.Owner).
 			Where("organisation_id = ?", kafkaRequest.OrganisationId).
 			Count(&count).
@@ -468,6 +501,7 @@ func (k *kafkaService) RegisterKafkaJob(kafkaRequest *dbapi.KafkaRequest) *error
 	if err := dbConn.Create(kafkaRequest).Error; err != nil {
 		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to create kafka request") //hide the db error to http caller
 	}
+	recordKafkaEvent(k.kafkaEvents, kafkaRequest, dbapi.KafkaEventTypeStatus, "")
 
 	metrics.UpdateKafkaRequestsStatusSinceCreatedMetric(constants.KafkaRequestStatusAccepted, kafkaRequest.ID, kafkaRequest.ClusterID, time.Since(kafkaRequest.CreatedAt))
 
@@ -508,24 +542,11 @@ func (k *kafkaService) PrepareKafkaRequest(kafkaRequest *dbapi.KafkaRequest) *er
 	}
 
 	if k.keycloakService.GetConfig().EnableAuthenticationOnKafka {
-		clientId := strings.ToLower(fmt.Sprintf("%s-%s", CanaryServiceAccountPrefix, kafkaRequest.ID))
-		serviceAccountRequest := sso.CompleteServiceAccountRequest{
-			Owner:          kafkaRequest.Owner,
-			OwnerAccountId: kafkaRequest.OwnerAccountId,
-			ClientId:       clientId,
-			OrgId:          kafkaRequest.OrganisationId,
-			Name:           fmt.Sprintf("canary-service-account-for-kafka %s", kafkaRequest.ID),
-			Description:    fmt.Sprintf("canary service account for kafka %s", kafkaRequest.ID),
-		}
-
-		canaryServiceAccount, err := k.keycloakService.CreateServiceAccountInternal(serviceAccountRequest)
-
+		clientId, err := k.canaryServiceAccountService.Create(kafkaRequest)
 		if err != nil {
-			return errors.FailedToCreateSSOClient("failed to  create canary service account %s:%v", kafkaRequest.ID, err)
+			return err
 		}
-
-		kafkaRequest.CanaryServiceAccountClientID = canaryServiceAccount.ClientID
-		kafkaRequest.CanaryServiceAccountClientSecret = canaryServiceAccount.ClientSecret
+		kafkaRequest.CanaryServiceAccountClientID = clientId
 	}
 
 	// Update the Kafka Request record in the database
@@ -534,12 +555,11 @@ func (k *kafkaService) PrepareKafkaRequest(kafkaRequest *dbapi.KafkaRequest) *er
 		Meta: api.Meta{
 			ID: kafkaRequest.ID,
 		},
-		BootstrapServerHost:              kafkaRequest.BootstrapServerHost,
-		CanaryServiceAccountClientID:     kafkaRequest.CanaryServiceAccountClientID,
-		CanaryServiceAccountClientSecret: kafkaRequest.CanaryServiceAccountClientSecret,
-		PlacementId:                      api.NewID(),
-		Status:                           constants.KafkaRequestStatusProvisioning.String(),
-		Namespace:                        kafkaRequest.Namespace,
+		BootstrapServerHost:          kafkaRequest.BootstrapServerHost,
+		CanaryServiceAccountClientID: kafkaRequest.CanaryServiceAccountClientID,
+		PlacementId:                  api.NewID(),
+		Status:                       constants.KafkaRequestStatusProvisioning.String(),
+		Namespace:                    kafkaRequest.Namespace,
 	}
 	if err := k.Update(updatedKafkaRequest); err != nil {
 		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update kafka request")
@@ -584,6 +604,87 @@ func (k *kafkaService) ListKafkasToBePromoted() ([]*dbapi.KafkaRequest, *errors.
 	return kafkas, nil
 }
 
+func (k *kafkaService) ListKafkasToBeResized() ([]*dbapi.KafkaRequest, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New()
+
+	var kafkas []*dbapi.KafkaRequest
+
+	if err := dbConn.Model(&dbapi.KafkaRequest{}).
+		Where("resize_status = ?", dbapi.KafkaResizeStatusResizing).
+		Where("desired_size_id <> ''").
+		Where("status NOT IN (?)", kafkaDeletionStatuses).
+		Scan(&kafkas).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list kafkas to be resized")
+	}
+
+	return kafkas, nil
+}
+
+func (k *kafkaService) AbortResize(kafkaRequest *dbapi.KafkaRequest, reason string) *errors.ServiceError {
+	if kafkaRequest.ResizeSubscriptionId != "" && kafkaRequest.ResizeSubscriptionId != kafkaRequest.SubscriptionId {
+		quotaService, factoryErr := k.quotaServiceFactory.GetQuotaService(api.QuotaType(k.kafkaConfig.Quota.Type))
+		if factoryErr != nil {
+			return errors.NewWithCause(errors.ErrorGeneral, factoryErr, "unable to release the quota reserved for the resize of kafka %q", kafkaRequest.ID)
+		}
+		if err := quotaService.DeleteQuota(kafkaRequest.ResizeSubscriptionId); err != nil {
+			return errors.NewWithCause(errors.ErrorGeneral, err, "unable to release the quota reserved for the resize of kafka %q", kafkaRequest.ID)
+		}
+	}
+
+	if err := k.connectionFactory.New().
+		Model(&dbapi.KafkaRequest{}).
+		Where("id = ?", kafkaRequest.ID).
+		Updates(map[string]interface{}{
+			"desired_size_id":        "",
+			"resize_status":          dbapi.KafkaResizeStatusFailed,
+			"resize_details":         reason,
+			"resize_subscription_id": "",
+		}).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to abort the resize of kafka %q", kafkaRequest.ID)
+	}
+
+	kafkaRequest.DesiredSizeId = ""
+	kafkaRequest.ResizeStatus = dbapi.KafkaResizeStatusFailed
+	kafkaRequest.ResizeDetails = reason
+	kafkaRequest.ResizeSubscriptionId = ""
+
+	recordKafkaEvent(k.kafkaEvents, kafkaRequest, dbapi.KafkaEventTypeResizeFailed, reason)
+
+	return nil
+}
+
+func (k *kafkaService) ListKafkasWithPendingUpgrade() ([]*dbapi.KafkaRequest, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New()
+
+	var kafkas []*dbapi.KafkaRequest
+
+	if err := dbConn.Model(&dbapi.KafkaRequest{}).
+		Where("status IN (?)", kafkaManagedCRStatuses).
+		Where("(actual_strimzi_version <> '' AND desired_strimzi_version <> '' AND desired_strimzi_version <> actual_strimzi_version) OR " +
+			"(actual_kafka_version <> '' AND desired_kafka_version <> '' AND desired_kafka_version <> actual_kafka_version) OR " +
+			"(actual_kafka_ibp_version <> '' AND desired_kafka_ibp_version <> '' AND desired_kafka_ibp_version <> actual_kafka_ibp_version)").
+		Scan(&kafkas).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list kafkas with a pending upgrade")
+	}
+
+	return kafkas, nil
+}
+
+func (k *kafkaService) ListKafkasByClusterID(clusterID string) ([]*dbapi.KafkaRequest, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New()
+
+	var kafkas []*dbapi.KafkaRequest
+
+	if err := dbConn.Model(&dbapi.KafkaRequest{}).
+		Where("cluster_id = ? OR migration_source_cluster_id = ?", clusterID, clusterID).
+		Where("status not in (?)", kafkaStatusesThatNoLongerConsumeResourcesInTheDataPlane).
+		Scan(&kafkas).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list the kafkas of cluster %q", clusterID)
+	}
+
+	return kafkas, nil
+}
+
 func (k *kafkaService) Get(ctx context.Context, id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
 	if id == "" {
 		return nil, errors.Validation("id is undefined")
@@ -766,18 +867,22 @@ func (k *kafkaService) Delete(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceE
 
 	// if the we don't have the clusterID we can only delete the row from the database
 	if kafkaRequest.ClusterID != "" {
-		// delete the kafka client in mas sso
+		// delete the canary service accounts of the kafka in mas sso and their client secrets in the vault. The service
+		// account of a rotation in progress may not have been recorded as the pending one yet: the client secrets owned
+		// by the kafka in the vault are used to find it.
 		if k.keycloakService.GetConfig().EnableAuthenticationOnKafka {
-			if kafkaRequest.CanaryServiceAccountClientID != "" {
-				keycloakErr := k.keycloakService.DeleteServiceAccountInternal(kafkaRequest.CanaryServiceAccountClientID)
-
-				if keycloakErr != nil {
-					// Log the info for not found and proceed - not an error if service account is not found
-					if keycloakErr.Code == errors.ErrorServiceAccountNotFound {
-						glog.V(10).Infof("Service account with ID '%s' not found. Skipping deletion", kafkaRequest.CanaryServiceAccountClientID)
-					} else {
-						return errors.NewWithCause(errors.ErrorGeneral, keycloakErr, "error deleting canary service account")
-					}
+			clientIds, err := k.canaryServiceAccountService.ListClientIds(kafkaRequest)
+			if err != nil {
+				return err
+			}
+			for _, clientId := range []string{kafkaRequest.CanaryServiceAccountClientID, kafkaRequest.PendingCanaryServiceAccountClientID} {
+				if clientId != "" && !arrays.Contains(clientIds, clientId) {
+					clientIds = append(clientIds, clientId)
+				}
+			}
+			for _, clientId := range clientIds {
+				if err := k.canaryServiceAccountService.Delete(kafkaRequest, clientId); err != nil {
+					return err
 				}
 			}
 		}
@@ -894,12 +999,18 @@ func (k *kafkaService) List(ctx context.Context, listArgs *services.ListArgument
 	return kafkaRequestList, pagingMeta, nil
 }
 
-func (k *kafkaService) GetManagedKafkaByClusterID(clusterID string) ([]managedkafka.ManagedKafka, *errors.ServiceError) {
+func (k *kafkaService) GetManagedKafkaByClusterID(clusterID string, gtVersion int64) ([]managedkafka.ManagedKafka, *errors.ServiceError) {
+	// the kafkas being migrated are deployed on both the source and the target cluster of their migration
 	dbConn := k.connectionFactory.New().
-		Where("cluster_id = ?", clusterID).
+		Where("cluster_id = ? OR migration_target_cluster_id = ? OR migration_source_cluster_id = ?", clusterID, clusterID, clusterID).
 		Where("status IN (?)", kafkaManagedCRStatuses).
 		Where("bootstrap_server_host != ''")
 
+	if gtVersion > 0 {
+		dbConn = dbConn.Where("version > ?", gtVersion)
+	}
+	dbConn = dbConn.Order("version")
+
 	var kafkaRequestList dbapi.KafkaList
 	if err := dbConn.Find(&kafkaRequestList).Error; err != nil {
 		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list kafka requests")
@@ -907,6 +1018,12 @@ func (k *kafkaService) GetManagedKafkaByClusterID(clusterID string) ([]managedka
 
 	enableKafkaExternalCertificate := k.kafkaTLSCertificateManagementService.IsKafkaExternalCertificateEnabled()
 
+	organisationMaintenanceWindows, svcErr := k.findOrganisationMaintenanceWindowsOfPendingUpgrades(kafkaRequestList)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+	now := time.Now()
+
 	var res []managedkafka.ManagedKafka
 	// convert kafka requests to managed kafka
 	for _, kafkaRequest := range kafkaRequestList {
@@ -927,17 +1044,148 @@ func (k *kafkaService) GetManagedKafkaByClusterID(clusterID string) ([]managedka
 
 		}
 
-		mk, err := buildManagedKafkaCR(kafkaRequest, k.kafkaConfig, k.keycloakService, certificate, enableKafkaExternalCertificate)
+		var canaryClientSecret string
+		if clientId := kafkaRequest.PublishedCanaryServiceAccountClientID(); clientId != "" && k.keycloakService.GetConfig().EnableAuthenticationOnKafka {
+			var err *errors.ServiceError
+			canaryClientSecret, err = k.canaryServiceAccountService.GetClientSecret(kafkaRequest, clientId)
+			// TODO - gracefully handle errors so that we do not block reconciliation of others Kafkas
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		mk, err := buildManagedKafkaCR(kafkaRequest, k.kafkaConfig, k.keycloakService, certificate, enableKafkaExternalCertificate, canaryClientSecret)
 		if err != nil {
 			return nil, err
 		}
+		if kafkaRequest.MigrationDeprovisionedFrom(clusterID) {
+			mk.Spec.Deleted = true
+		}
+
+		schedule := kafkaRequest.OwnMaintenanceWindow()
+		if window, ok := organisationMaintenanceWindows[kafkaRequest.OrganisationId]; ok && schedule == nil {
+			schedule = window.Schedule()
+		}
+		holdUpgradeUntilMaintenanceWindow(mk, kafkaRequest, schedule, now, k.kafkaConfig.MaintenanceWindowDuration)
 
 		res = append(res, *mk)
 	}
 
+	// the kafkas which left the cluster since the given version are no longer listed, they are returned as deleted
+	// so that the agent removes them
+	if gtVersion > 0 {
+		k.pruneAcknowledgedKafkaTombstones(clusterID, gtVersion)
+		tombstones, svcErr := k.findManagedKafkaTombstonesByClusterID(clusterID, gtVersion, res)
+		if svcErr != nil {
+			return nil, svcErr
+		}
+		if len(tombstones) > 0 {
+			res = append(res, tombstones...)
+			sort.SliceStable(res, func(i, j int) bool {
+				return managedKafkaVersion(res[i]) < managedKafkaVersion(res[j])
+			})
+		}
+	}
+
+	return res, nil
+}
+
+// findManagedKafkaTombstonesByClusterID returns the deleted managed kafkas of the kafkas which left the given cluster
+// since the given version, except for the kafkas still listed for the cluster whose current state supersedes their
+// departure.
+func (k *kafkaService) findManagedKafkaTombstonesByClusterID(clusterID string, gtVersion int64, listed []managedkafka.ManagedKafka) ([]managedkafka.ManagedKafka, *errors.ServiceError) {
+	var tombstones dbapi.KafkaTombstoneList
+	if err := k.connectionFactory.New().
+		Where("cluster_id = ?", clusterID).
+		Where("version > ?", gtVersion).
+		Order("version").
+		Find(&tombstones).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list kafka tombstones")
+	}
+
+	listedIds := arrays.Map(listed, func(mk managedkafka.ManagedKafka) string { return mk.Id })
+	// only the latest departure of a kafka is returned
+	latest := map[string]*dbapi.KafkaTombstone{}
+	var kafkaIds []string
+	for _, tombstone := range tombstones {
+		if arrays.Contains(listedIds, tombstone.KafkaId) {
+			continue
+		}
+		if _, ok := latest[tombstone.KafkaId]; !ok {
+			kafkaIds = append(kafkaIds, tombstone.KafkaId)
+		}
+		latest[tombstone.KafkaId] = tombstone
+	}
+
+	res := make([]managedkafka.ManagedKafka, 0, len(kafkaIds))
+	for _, kafkaId := range kafkaIds {
+		res = append(res, *buildDeletedManagedKafkaCR(latest[kafkaId]))
+	}
+
 	return res, nil
 }
 
+// pruneAcknowledgedKafkaTombstones deletes the tombstones of the given cluster up to the given version: the agent asking
+// for the changes since that version has already received them. Failures are only logged as the tombstones are pruned
+// again by the next requests of the agent.
+func (k *kafkaService) pruneAcknowledgedKafkaTombstones(clusterID string, gtVersion int64) {
+	result := k.connectionFactory.New().
+		Where("cluster_id = ?", clusterID).
+		Where("version <= ?", gtVersion).
+		Delete(&dbapi.KafkaTombstone{})
+	if result.Error != nil {
+		logger.Logger.Warningf("unable to prune the kafka tombstones of cluster %q acknowledged up to version %d: %v", clusterID, gtVersion, result.Error)
+		return
+	}
+	if result.RowsAffected > 0 {
+		glog.V(10).Infof("pruned %d kafka tombstone(s) of cluster %q acknowledged up to version %d", result.RowsAffected, clusterID, gtVersion)
+	}
+}
+
+func managedKafkaVersion(mk managedkafka.ManagedKafka) int64 {
+	version, _ := strconv.ParseInt(mk.ResourceVersion, 10, 64)
+	return version
+}
+
+// findOrganisationMaintenanceWindowsOfPendingUpgrades returns the maintenance windows of the organisations of the
+// given kafkas having a pending upgrade that is governed by the maintenance window of their organisation
+func (k *kafkaService) findOrganisationMaintenanceWindowsOfPendingUpgrades(kafkaRequestList dbapi.KafkaList) (map[string]*dbapi.MaintenanceWindow, *errors.ServiceError) {
+	var organisationIds []string
+	for _, kafkaRequest := range kafkaRequestList {
+		if kafkaRequest.HasPendingUpgrade() && !kafkaRequest.UpgradeForced && kafkaRequest.OwnMaintenanceWindow() == nil &&
+			kafkaRequest.OrganisationId != "" && !arrays.Contains(organisationIds, kafkaRequest.OrganisationId) {
+			organisationIds = append(organisationIds, kafkaRequest.OrganisationId)
+		}
+	}
+
+	return k.maintenanceWindowService.ListByOrganisationIds(organisationIds)
+}
+
+// holdUpgradeUntilMaintenanceWindow keeps the versions running on the data plane in the managed kafka for the
+// components whose upgrade is waiting for the maintenance window of the kafka. The desired versions are kept when no
+// maintenance window applies to the kafka, when the window is open, when an administrator forced the upgrade, once the
+// upgrade has been released in a window and for the components whose upgrade is already in progress.
+// A released upgrade is not held back when its window closes, the data plane may not have started rolling it out yet.
+// The release is withdrawn whenever the desired versions change.
+func holdUpgradeUntilMaintenanceWindow(mk *managedkafka.ManagedKafka, kafkaRequest *dbapi.KafkaRequest, schedule *dbapi.MaintenanceWindowSchedule, now time.Time, windowDuration time.Duration) {
+	if schedule == nil || kafkaRequest.UpgradeForced || kafkaRequest.UpgradeReleasedAt != nil || schedule.IsOpen(now, windowDuration) {
+		return
+	}
+
+	held := func(actual string, upgrading bool) bool {
+		return actual != "" && !upgrading
+	}
+	if held(kafkaRequest.ActualStrimziVersion, kafkaRequest.StrimziUpgrading) {
+		mk.Spec.Versions.Strimzi = kafkaRequest.ActualStrimziVersion
+	}
+	if held(kafkaRequest.ActualKafkaVersion, kafkaRequest.KafkaUpgrading) {
+		mk.Spec.Versions.Kafka = kafkaRequest.ActualKafkaVersion
+	}
+	if held(kafkaRequest.ActualKafkaIBPVersion, kafkaRequest.KafkaIBPUpgrading) {
+		mk.Spec.Versions.KafkaIBP = kafkaRequest.ActualKafkaIBPVersion
+	}
+}
+
 func (k *kafkaService) GenerateReservedManagedKafkasByClusterID(clusterID string) ([]managedkafka.ManagedKafka, *errors.ServiceError) {
 	reservedKafkas := []managedkafka.ManagedKafka{}
 	cluster, svcErr := k.clusterService.FindClusterByID(clusterID)
@@ -986,24 +1234,194 @@ func (k *kafkaService) GenerateReservedManagedKafkasByClusterID(clusterID string
 func (k *kafkaService) Update(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
 	dbConn := k.connectionFactory.New().
 		Model(kafkaRequest).
-		Where("status not IN (?)", kafkaDeletionStatuses) // ignore updates of kafka under deletion
+		Where("status not IN (?)", kafkaDeletionStatuses). // ignore updates of kafka under deletion
+		Updates(kafkaRequest)
 
-	if err := dbConn.Updates(kafkaRequest).Error; err != nil {
+	if err := dbConn.Error; err != nil {
 		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update kafka")
 	}
 
+	// zero values are not updated: the status is only part of the update when it is set
+	if dbConn.RowsAffected > 0 && kafkaRequest.Status != "" {
+		recordKafkaStatusTransition(k.kafkaEvents, kafkaRequest, "")
+	}
+
 	return nil
 }
 
 func (k *kafkaService) Updates(kafkaRequest *dbapi.KafkaRequest, fields map[string]interface{}) *errors.ServiceError {
 	dbConn := k.connectionFactory.New().
 		Model(kafkaRequest).
-		Where("status not IN (?)", kafkaDeletionStatuses) // ignore updates of kafka under deletion
+		Where("status not IN (?)", kafkaDeletionStatuses). // ignore updates of kafka under deletion
+		Updates(fields)
+
+	if err := dbConn.Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update kafka")
+	}
+
+	if dbConn.RowsAffected > 0 {
+		k.recordEventOfUpdatedFields(kafkaRequest, fields, dbapi.KafkaEventTypeStatus, "")
+	}
+
+	return nil
+}
+
+func (k *kafkaService) Suspend(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+	claims, err := auth.GetClaimsFromContext(ctx)
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorUnauthenticated, err, "user not authenticated")
+	}
+
+	username, _ := claims.GetUsername()
+	if username == "" {
+		return errors.Unauthenticated("user not authenticated")
+	}
+
+	suspendableStatuses := []string{constants.KafkaRequestStatusReady.String()}
+	if !arrays.Contains(suspendableStatuses, kafkaRequest.Status) {
+		return errors.New(errors.ErrorValidation, "kafka instance with a status of %q cannot be suspended. Kafka instances can only be suspended in the following states: %s", kafkaRequest.Status, suspendableStatuses)
+	}
+
+	// the migration would otherwise stall as a suspended kafka cannot be cut over to the target cluster
+	if kafkaRequest.MigrationInProgress() {
+		return errors.Conflict("kafka instance %q cannot be suspended while it is being migrated to another data plane cluster", kafkaRequest.ID)
+	}
+
+	suspendedAt := sql.NullTime{Time: time.Now(), Valid: true}
+
+	if err := k.updateStatusFrom(kafkaRequest, map[string]interface{}{
+		"status":       constants.KafkaRequestStatusSuspending.String(),
+		"suspended_by": username,
+		"suspended_at": suspendedAt,
+	}, dbapi.KafkaEventTypeSuspendRequested, fmt.Sprintf("suspension requested by %q", username)); err != nil {
+		return err
+	}
+
+	kafkaRequest.Status = constants.KafkaRequestStatusSuspending.String()
+	kafkaRequest.SuspendedBy = username
+	kafkaRequest.SuspendedAt = suspendedAt
+
+	k.releaseQuotaOfSuspendedKafka(kafkaRequest)
+
+	// a suspended kafka cannot be resized: the resize worker aborts the resize later on if it cannot be aborted now
+	if kafkaRequest.ResizeStatus == dbapi.KafkaResizeStatusResizing {
+		if err := k.AbortResize(kafkaRequest, "the resize has been aborted as the kafka instance has been suspended"); err != nil {
+			logger.Logger.Warningf("unable to abort the resize of suspended kafka %q: %v", kafkaRequest.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// releaseQuotaOfSuspendedKafka releases the quota consumed by the kafka suspended by a user so that, whatever the quota
+// type, the suspended kafkas do not consume quota. The quota management list computes the consumed quota from the
+// kafkas themselves and excludes the ones suspended by a user, its quota release is therefore a no-op, whereas the AMS
+// subscription of the kafka is deleted. The quota is reserved again when the kafka is resumed.
+// A failure to release the quota does not fail the suspension: the subscription id is kept so that the subscription
+// is either reused when the kafka is resumed or deleted along with the kafka.
+func (k *kafkaService) releaseQuotaOfSuspendedKafka(kafkaRequest *dbapi.KafkaRequest) {
+	if kafkaRequest.SubscriptionId == "" {
+		return
+	}
+
+	quotaService, factoryErr := k.quotaServiceFactory.GetQuotaService(api.QuotaType(k.kafkaConfig.Quota.Type))
+	if factoryErr != nil {
+		logger.Logger.Warningf("unable to release the quota of suspended kafka %q: %v", kafkaRequest.ID, factoryErr)
+		return
+	}
+
+	if err := quotaService.DeleteQuota(kafkaRequest.SubscriptionId); err != nil {
+		logger.Logger.Warningf("unable to release the quota of suspended kafka %q: %v", kafkaRequest.ID, err)
+		return
+	}
+
+	if err := k.connectionFactory.New().
+		Model(&dbapi.KafkaRequest{}).
+		Where("id = ?", kafkaRequest.ID).
+		Update("subscription_id", "").Error; err != nil {
+		logger.Logger.Warningf("unable to clear the subscription id of suspended kafka %q: %v", kafkaRequest.ID, err)
+		return
+	}
+
+	kafkaRequest.SubscriptionId = ""
+}
 
-	if err := dbConn.Updates(fields).Error; err != nil {
+func (k *kafkaService) Resume(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+	resumableStatuses := []string{constants.KafkaRequestStatusSuspended.String()}
+	if !arrays.Contains(resumableStatuses, kafkaRequest.Status) {
+		return errors.New(errors.ErrorValidation, "kafka instance with a status of %q cannot be resumed. Kafka instances can only be resumed in the following states: %s", kafkaRequest.Status, resumableStatuses)
+	}
+
+	// the kafkas suspended otherwise, e.g. by an admin, cannot be resumed by their users
+	if !kafkaRequest.IsSuspendedByUser() {
+		return errors.New(errors.ErrorValidation, "kafka instance %q has not been suspended by a user and cannot be resumed", kafkaRequest.ID)
+	}
+
+	billingModel, err := k.kafkaConfig.GetBillingModelByID(kafkaRequest.InstanceType, kafkaRequest.ActualKafkaBillingModel)
+	if err != nil {
+		return errors.ToServiceError(err)
+	}
+
+	if startOfGracePeriod, ok := kafkaRequest.GetGracePeriodStart(billingModel.GracePeriodDays); ok && time.Now().After(startOfGracePeriod) {
+		return errors.New(errors.ErrorValidation, "kafka instance with a status of %q cannot be resumed due to the instance is suspended and it is within its grace period: start of grace period: %s ", kafkaRequest.Status, startOfGracePeriod)
+	}
+
+	quotaService, factoryErr := k.quotaServiceFactory.GetQuotaService(api.QuotaType(k.kafkaConfig.Quota.Type))
+	if factoryErr != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, factoryErr, "unable to check quota")
+	}
+
+	// the quota service may change the billing model values of the given kafka so we use a copy of it
+	kafkaCopy := *kafkaRequest
+	kafkaCopy.DesiredKafkaBillingModel = kafkaRequest.ActualKafkaBillingModel
+	subscriptionId, quotaErr := quotaService.ReserveQuotaIfNotAlreadyReserved(&kafkaCopy)
+	if quotaErr != nil {
+		return quotaErr
+	}
+
+	if err := k.updateStatusFrom(kafkaRequest, map[string]interface{}{
+		"status":          constants.KafkaRequestStatusResuming.String(),
+		"suspended_by":    "",
+		"suspended_at":    sql.NullTime{},
+		"subscription_id": subscriptionId,
+	}, dbapi.KafkaEventTypeResumeRequested, "resume requested"); err != nil {
+		// the quota reserved for the resume would otherwise be leaked as its subscription id is not stored
+		if subscriptionId != "" && subscriptionId != kafkaRequest.SubscriptionId {
+			if deleteErr := quotaService.DeleteQuota(subscriptionId); deleteErr != nil {
+				logger.Logger.Errorf("unable to release the quota %q reserved to resume kafka %q: %v", subscriptionId, kafkaRequest.ID, deleteErr)
+			}
+		}
+		return err
+	}
+
+	kafkaRequest.Status = constants.KafkaRequestStatusResuming.String()
+	kafkaRequest.SubscriptionId = subscriptionId
+	kafkaRequest.SuspendedBy = ""
+	kafkaRequest.SuspendedAt = sql.NullTime{}
+
+	return nil
+}
+
+// updateStatusFrom updates the given fields of the kafka only if its status has not changed since it was read.
+// This prevents status transitions from overriding a concurrent change e.g. the kafka being deleted.
+// The transition is recorded in the history of the kafka as an event of the given type with the given details.
+func (k *kafkaService) updateStatusFrom(kafkaRequest *dbapi.KafkaRequest, fields map[string]interface{}, eventType dbapi.KafkaEventType, details string) *errors.ServiceError {
+	dbConn := k.connectionFactory.New().
+		Model(&dbapi.KafkaRequest{}).
+		Where("id = ?", kafkaRequest.ID).
+		Where("status = ?", kafkaRequest.Status).
+		Updates(fields)
+
+	if err := dbConn.Error; err != nil {
 		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update kafka")
 	}
 
+	if dbConn.RowsAffected == 0 {
+		return errors.New(errors.ErrorConflict, "kafka instance %q status has changed, please retry the request", kafkaRequest.ID)
+	}
+
+	k.recordEventOfUpdatedFields(kafkaRequest, fields, eventType, details)
+
 	return nil
 }
 
@@ -1019,6 +1437,8 @@ func (k *kafkaService) VerifyAndUpdateKafkaAdmin(ctx context.Context, kafkaReque
 		"desired_kafka_version":     kafkaRequest.DesiredKafkaVersion,
 		"desired_kafka_ibp_version": kafkaRequest.DesiredKafkaIBPVersion,
 		"status":                    kafkaRequest.Status,
+		"upgrade_forced":            kafkaRequest.UpgradeForced,
+		"upgrade_released_at":       kafkaRequest.UpgradeReleasedAt,
 	}
 
 	dbConn := k.connectionFactory.New().
@@ -1028,89 +1448,106 @@ func (k *kafkaService) VerifyAndUpdateKafkaAdmin(ctx context.Context, kafkaReque
 		return errors.NewWithCause(errors.ErrorGeneral, err, "failed to update kafka")
 	}
 
+	k.recordEventOfUpdatedFields(kafkaRequest, updatableFields, dbapi.KafkaEventTypeStatus, "updated by an administrator")
+
 	return nil
 }
 
 func (k *kafkaService) UpdateStatus(id string, status constants.KafkaStatus) (bool, *errors.ServiceError) {
 	dbConn := k.connectionFactory.New()
 
-	if kafka, err := k.GetByID(id); err != nil {
+	kafka, err := k.GetByID(id)
+	if err != nil {
 		return true, errors.NewWithCause(errors.ErrorGeneral, err, "failed to update status")
-	} else {
-		// only allow to change the status to "deleting" if the cluster is already in "deprovision" status
-		if kafka.Status == constants.KafkaRequestStatusDeprovision.String() && status != constants.KafkaRequestStatusDeleting {
-			return false, errors.GeneralError("failed to update status: cluster is deprovisioning")
-		}
+	}
 
-		if kafka.Status == status.String() {
-			// no update needed
-			return false, errors.GeneralError("failed to update status: the cluster %s is already in %s state", id, status.String())
-		}
+	// only allow to change the status to "deleting" if the cluster is already in "deprovision" status
+	if kafka.Status == constants.KafkaRequestStatusDeprovision.String() && status != constants.KafkaRequestStatusDeleting {
+		return false, errors.GeneralError("failed to update status: cluster is deprovisioning")
+	}
+
+	if kafka.Status == status.String() {
+		// no update needed
+		return false, errors.GeneralError("failed to update status: the cluster %s is already in %s state", id, status.String())
 	}
 
 	if err := dbConn.Model(&dbapi.KafkaRequest{Meta: api.Meta{ID: id}}).Update("status", status).Error; err != nil {
 		return true, errors.NewWithCause(errors.ErrorGeneral, err, "failed to update kafka status")
 	}
 
+	kafka.Status = status.String()
+	recordKafkaStatusTransition(k.kafkaEvents, kafka, "")
+
 	return true, nil
 }
 
-func (k *kafkaService) ChangeKafkaCNAMErecords(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*route53.ChangeResourceRecordSetsOutput, *errors.ServiceError) {
-	routes, err := kafkaRequest.GetRoutes()
-	if routes == nil || err != nil {
-		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to get routes")
+// recordEventOfUpdatedFields records the state of the given kafka once the given fields are updated. The status events
+// are only recorded when the status of the kafka is changed by the update, unless the update is a step of the resize
+// of the kafka. The events of the other types are always recorded.
+func (k *kafkaService) recordEventOfUpdatedFields(kafkaRequest *dbapi.KafkaRequest, fields map[string]interface{}, eventType dbapi.KafkaEventType, details string) {
+	if eventType == dbapi.KafkaEventTypeStatus {
+		if resizeEventType, resizeDetails, ok := resizeEventOfUpdatedFields(kafkaRequest, fields); ok {
+			eventType, details = resizeEventType, resizeDetails
+		}
 	}
 
-	domainRecordBatch := buildKafkaClusterCNAMESRecordBatch(routes, action)
-
-	awsConfig := aws.Config{
-		AccessKeyID:     k.awsConfig.Route53.AccessKey,
-		SecretAccessKey: k.awsConfig.Route53.SecretAccessKey,
+	status, ok := fields["status"]
+	if !ok && eventType == dbapi.KafkaEventTypeStatus {
+		return
 	}
 
-	route53Region, err := k.getRoute53RegionFromKafkaRequest(kafkaRequest)
-	if err != nil {
-		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "error getting route 53 region from kafka request")
+	updated := *kafkaRequest
+	if ok {
+		updated.Status = fmt.Sprint(status)
 	}
-
-	awsClient, err := k.awsClientFactory.NewClient(awsConfig, route53Region)
-	if err != nil {
-		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to create aws client")
+	if failedReason, ok := fields["failed_reason"]; ok {
+		updated.FailedReason = fmt.Sprint(failedReason)
 	}
-
-	changeRecordsOutput, err := awsClient.ChangeResourceRecordSets(k.kafkaConfig.KafkaDomainName, domainRecordBatch)
-	if err != nil {
-		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to create domain record sets")
+	if promotionStatus, ok := fields["promotion_status"]; ok {
+		updated.PromotionStatus = dbapi.KafkaPromotionStatus(fmt.Sprint(promotionStatus))
 	}
 
-	return changeRecordsOutput, nil
+	if eventType == dbapi.KafkaEventTypeStatus {
+		recordKafkaStatusTransition(k.kafkaEvents, &updated, details)
+	} else {
+		recordKafkaEvent(k.kafkaEvents, &updated, eventType, details)
+	}
+	kafkaRequest.SetStoredStatus(updated.StoredStatus())
 }
 
-func (k *kafkaService) GetCNAMERecordStatus(kafkaRequest *dbapi.KafkaRequest) (*CNameRecordStatus, error) {
-	awsConfig := aws.Config{
-		AccessKeyID:     k.awsConfig.Route53.AccessKey,
-		SecretAccessKey: k.awsConfig.Route53.SecretAccessKey,
+func (k *kafkaService) ChangeKafkaCNAMErecords(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*dns.Change, *errors.ServiceError) {
+	routes, err := kafkaRequest.GetRoutes()
+	if routes == nil || err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to get routes")
 	}
 
-	route53Region, err := k.getRoute53RegionFromKafkaRequest(kafkaRequest)
-	if err != nil {
-		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "error getting route 53 region from kafka request")
+	provider, svcErr := k.dnsProviderFactory.GetProvider(kafkaRequest.CloudProvider)
+	if svcErr != nil {
+		return nil, svcErr
 	}
 
-	awsClient, err := k.awsClientFactory.NewClient(awsConfig, route53Region)
+	change, err := provider.ChangeRecordSets(k.kafkaConfig.KafkaDomainName, dns.ChangeAction(action), BuildKafkaClusterCNAMERecordSets(routes))
 	if err != nil {
-		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to create aws client")
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to create domain record sets")
+	}
+
+	return change, nil
+}
+
+func (k *kafkaService) GetCNAMERecordStatus(kafkaRequest *dbapi.KafkaRequest) (*dns.Change, error) {
+	// the change is tracked with the provider which made it, which may no longer be the configured one
+	kind, _ := dns.ParseChangeID(kafkaRequest.RoutesCreationId)
+	provider, svcErr := k.dnsProviderFactory.GetProviderByKind(kind, kafkaRequest.CloudProvider)
+	if svcErr != nil {
+		return nil, svcErr
 	}
 
-	changeOutput, err := awsClient.GetChange(kafkaRequest.RoutesCreationId)
+	change, err := provider.GetChange(kafkaRequest.RoutesCreationId)
 	if err != nil {
-		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to get status of Route53 change batch request with ID %q", kafkaRequest.RoutesCreationId)
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to get status of DNS change with ID %q", kafkaRequest.RoutesCreationId)
 	}
 
-	return &CNameRecordStatus{
-		Id:     changeOutput.ChangeInfo.Id,
-		Status: changeOutput.ChangeInfo.Status,
-	}, nil
+	return change, nil
 }
 
 type KafkaStatusCount struct {
@@ -1174,9 +1611,46 @@ func (k *kafkaService) ListKafkasWithRoutesNotCreated() ([]*dbapi.KafkaRequest,
 	return results, nil
 }
 
+func (k *kafkaService) ListDeletedKafkasWithRoutes(deletedSince time.Time) ([]*dbapi.KafkaRequest, *errors.ServiceError) {
+	dbConn := k.connectionFactory.New()
+	var results []*dbapi.KafkaRequest
+	if err := dbConn.Unscoped().
+		Select("id", "cluster_id", "cloud_provider", "routes", "deleted_at").
+		Where("deleted_at > ?", deletedSince).
+		Where("routes IS NOT NULL").
+		Where("routes_created = ?", true).
+		Find(&results).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list deleted kafka requests")
+	}
+	return results, nil
+}
+
+// buildDeletedManagedKafkaCR builds the managed kafka, marked as deleted, of the kafka which left a data plane cluster.
+// Only the fields identifying the managed kafka on the cluster are set.
+func buildDeletedManagedKafkaCR(tombstone *dbapi.KafkaTombstone) *managedkafka.ManagedKafka {
+	return &managedkafka.ManagedKafka{
+		Id: tombstone.KafkaId,
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ManagedKafka",
+			APIVersion: "managedkafka.bf2.org/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            tombstone.Name,
+			Namespace:       tombstone.Namespace,
+			ResourceVersion: strconv.FormatInt(tombstone.Version, 10),
+			Annotations: map[string]string{
+				"bf2.org/id": tombstone.KafkaId,
+			},
+		},
+		Spec: managedkafka.ManagedKafkaSpec{
+			Deleted: true,
+		},
+	}
+}
+
 func buildManagedKafkaCR(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.KafkaConfig, keycloakService sso.KeycloakService,
 	certificates kafkatlscertmgmt.Certificate,
-	enableKafkaExternalCertificate bool) (*managedkafka.ManagedKafka, *errors.ServiceError) {
+	enableKafkaExternalCertificate bool, canaryClientSecret string) (*managedkafka.ManagedKafka, *errors.ServiceError) {
 	k, err := kafkaConfig.GetKafkaInstanceSize(kafkaRequest.InstanceType, kafkaRequest.SizeId)
 	if err != nil {
 		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list kafka request")
@@ -1195,8 +1669,9 @@ func buildManagedKafkaCR(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.K
 			APIVersion: "managedkafka.bf2.org/v1alpha1",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      kafkaRequest.Name,
-			Namespace: kafkaRequest.Namespace,
+			Name:            kafkaRequest.Name,
+			Namespace:       kafkaRequest.Namespace,
+			ResourceVersion: strconv.FormatInt(kafkaRequest.Version, 10),
 			Annotations: map[string]string{
 				"bf2.org/id":          kafkaRequest.ID,
 				"bf2.org/placementId": kafkaRequest.PlacementId,
@@ -1252,8 +1727,8 @@ func buildManagedKafkaCR(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.K
 		serviceAccounts := []managedkafka.ServiceAccount{}
 		serviceAccounts = append(serviceAccounts, managedkafka.ServiceAccount{
 			Name:      "canary",
-			Principal: kafkaRequest.CanaryServiceAccountClientID,
-			Password:  kafkaRequest.CanaryServiceAccountClientSecret,
+			Principal: kafkaRequest.PublishedCanaryServiceAccountClientID(),
+			Password:  canaryClientSecret,
 		})
 		managedKafkaCR.Spec.ServiceAccounts = serviceAccounts
 	}
@@ -1341,39 +1816,19 @@ func buildKafkaOwner(kafkaRequest *dbapi.KafkaRequest, kafkaConfig *config.Kafka
 	}
 }
 
-func buildKafkaClusterCNAMESRecordBatch(routes []dbapi.DataPlaneKafkaRoute, action KafkaRoutesAction) *route53.ChangeBatch {
-	var changes []*route53.Change
+// BuildKafkaClusterCNAMERecordSets returns the CNAME record sets pointing the routes of a kafka to their router
+func BuildKafkaClusterCNAMERecordSets(routes []dbapi.DataPlaneKafkaRoute) []dns.RecordSet {
+	recordSets := make([]dns.RecordSet, 0, len(routes))
 	for _, r := range routes {
-		c := buildResourceRecordChange(r.Domain, r.Router, action)
-		changes = append(changes, c)
-	}
-	recordChangeBatch := &route53.ChangeBatch{
-		Changes: changes,
-	}
-
-	return recordChangeBatch
-}
-
-func buildResourceRecordChange(recordName string, clusterIngress string, action KafkaRoutesAction) *route53.Change {
-	recordType := "CNAME"
-	recordTTL := int64(300)
-
-	actionStr := action.String()
-	resourceRecordChange := &route53.Change{
-		Action: &actionStr,
-		ResourceRecordSet: &route53.ResourceRecordSet{
-			Name: &recordName,
-			Type: &recordType,
-			TTL:  &recordTTL,
-			ResourceRecords: []*route53.ResourceRecord{
-				{
-					Value: &clusterIngress,
-				},
-			},
-		},
+		recordSets = append(recordSets, dns.RecordSet{
+			Name:   r.Domain,
+			Type:   dns.RecordTypeCNAME,
+			TTL:    300,
+			Values: []string{r.Router},
+		})
 	}
 
-	return resourceRecordChange
+	return recordSets
 }
 
 func (k *kafkaService) AssignBootstrapServerHost(kafkaRequest *dbapi.KafkaRequest) error {
@@ -1424,6 +1879,12 @@ func (k *kafkaService) ManagedKafkasRoutesTLSCertificate(kafkaRequest *dbapi.Kaf
 	logger.Logger.Infof("starting management of tls certificate for the domain %q of kafka with id %q", kafkaRequest.KafkasRoutesBaseDomainName, kafkaRequest.ID)
 
 	certManagementOutput, err := k.kafkaTLSCertificateManagementService.ManageCertificate(context.Background(), kafkaRequest.KafkasRoutesBaseDomainName)
+	// the certificate is renewed on demand when it is due, its inventory must not report it as healthy when this fails
+	if k.kafkaTLSCertificateManagementService.IsAutomaticCertificateManagementEnabled() {
+		if recordErr := k.kafkaTLSCertificateInventoryService.RecordDomainRenewal(kafkaRequest.KafkasRoutesBaseDomainName, err); recordErr != nil {
+			logger.Logger.Warningf("unable to record the management of the tls certificate of domain %q in the inventory: %v", kafkaRequest.KafkasRoutesBaseDomainName, recordErr)
+		}
+	}
 	if err != nil {
 		return err
 	}
@@ -1443,28 +1904,6 @@ func (k *kafkaService) ManagedKafkasRoutesTLSCertificate(kafkaRequest *dbapi.Kaf
 	return nil
 }
 
-// getRoute53RegionFromKafkaRequest calculates the AWS region to be used for
-// Route53 from the kafka request. It calculates its value using the
-// cloud provider specified in the kafka request.
-// Route53 is a global service which means that in most of the cases
-// the region specified is only used to access a regional endpoint in AWS.
-// There are some parts of the Route53 functionality that are regional.
-// For what we perform which is create hosted zones and entries in them
-// that is a global functionality.
-// See: https://docs.aws.amazon.com/Route53/latest/DeveloperGuide/disaster-recovery-resiliency.html
-// If at some point we end up needing Route53 regional functionalities this
-// mechanism should be reevaluated
-func (k *kafkaService) getRoute53RegionFromKafkaRequest(kafkaRequest *dbapi.KafkaRequest) (string, error) {
-	switch kafkaRequest.CloudProvider {
-	case cloudproviders.AWS.String():
-		return aws.DefaultAWSRoute53Region, nil
-	case cloudproviders.GCP.String():
-		return aws.DefaultGCPRoute53Region, nil
-	default:
-		return "", errors.GeneralError("unknown cloud provider: %q", kafkaRequest.CloudProvider)
-	}
-}
-
 func (k *kafkaService) IsQuotaEntitlementActive(kafkaRequest *dbapi.KafkaRequest) (bool, error) {
 	quotaService, factoryErr := k.quotaServiceFactory.GetQuotaService(api.QuotaType(k.kafkaConfig.Quota.Type))
 	if factoryErr != nil {
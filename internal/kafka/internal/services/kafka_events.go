@@ -0,0 +1,150 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
+)
+
+// KafkaEventService stores the lifecycle history of the Kafka instances.
+//
+//go:generate moq -out kafka_events_moq.go . KafkaEventService
+type KafkaEventService interface {
+	// Record appends the event to the history of its Kafka instance. The webhook subscriptions of the organisation of
+	// the Kafka instance are notified when the status of the Kafka instance is changed by the event.
+	Record(event *dbapi.KafkaEvent) *errors.ServiceError
+	// ListByKafkaId returns the events of the given Kafka instance in chronological order
+	ListByKafkaId(kafkaId string, listArgs *coreServices.ListArguments) (dbapi.KafkaEventList, *api.PagingMeta, *errors.ServiceError)
+}
+
+var _ KafkaEventService = &kafkaEventService{}
+
+type kafkaEventService struct {
+	connectionFactory *db.ConnectionFactory
+	webhookService    webhooks.WebhookService
+}
+
+func NewKafkaEventService(connectionFactory *db.ConnectionFactory, webhookService webhooks.WebhookService) KafkaEventService {
+	return &kafkaEventService{
+		connectionFactory: connectionFactory,
+		webhookService:    webhookService,
+	}
+}
+
+func (s *kafkaEventService) Record(event *dbapi.KafkaEvent) *errors.ServiceError {
+	// events are stored outside of any transaction so that they are kept even when the caller fails afterwards
+	if err := s.connectionFactory.New().Create(event).Error; err != nil {
+		return coreServices.HandleCreateError("kafka event", err)
+	}
+
+	if event.Status != event.PreviousStatus {
+		s.notifyStatusChange(event)
+	}
+
+	return nil
+}
+
+// notifyStatusChange notifies the webhook subscriptions of the organisation of the Kafka instance of its new status.
+// Failures are only logged as the event has been recorded already.
+func (s *kafkaEventService) notifyStatusChange(event *dbapi.KafkaEvent) {
+	webhookEvent := &webhooks.Event{
+		Type:           webhooks.KafkaPhaseChanged,
+		OccurredAt:     event.CreatedAt,
+		OrganisationId: event.OrganisationId,
+		Resource: webhooks.Resource{
+			Id:   event.KafkaId,
+			Kind: "Kafka",
+			Href: fmt.Sprintf("/api/kafkas_mgmt/v1/kafkas/%s", event.KafkaId),
+		},
+		PreviousPhase: event.PreviousStatus,
+		Phase:         event.Status,
+	}
+	if err := s.webhookService.Notify(webhookEvent); err != nil {
+		logger.Logger.Errorf("failed to notify the webhook subscriptions of the status %q of kafka %q: %v", event.Status, event.KafkaId, err)
+	}
+}
+
+func (s *kafkaEventService) ListByKafkaId(kafkaId string, listArgs *coreServices.ListArguments) (dbapi.KafkaEventList, *api.PagingMeta, *errors.ServiceError) {
+	var events dbapi.KafkaEventList
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+
+	dbConn := s.connectionFactory.New().Model(&dbapi.KafkaEvent{}).Where("kafka_id = ?", kafkaId)
+
+	var total int64
+	if err := dbConn.Count(&total).Error; err != nil {
+		return nil, nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to count the events of kafka %q", kafkaId)
+	}
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+
+	if err := dbConn.Order("created_at").Offset((pagingMeta.Page - 1) * listArgs.Size).Limit(listArgs.Size).Find(&events).Error; err != nil {
+		return nil, nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list the events of kafka %q", kafkaId)
+	}
+
+	return events, pagingMeta, nil
+}
+
+// recordKafkaEvent records the event of the given type of the given kafka with its current state, the transition from
+// its stored status to its current status. The current status becomes the stored status of the kafka so that the same
+// transition is not recorded twice. Failures are only logged as the history of a Kafka instance must never prevent its
+// lifecycle from progressing.
+func recordKafkaEvent(kafkaEvents KafkaEventService, kafkaRequest *dbapi.KafkaRequest, eventType dbapi.KafkaEventType, details string) {
+	event := &dbapi.KafkaEvent{
+		KafkaId:         kafkaRequest.ID,
+		Type:            eventType,
+		PreviousStatus:  kafkaRequest.StoredStatus(),
+		Status:          kafkaRequest.Status,
+		FailedReason:    kafkaRequest.FailedReason,
+		PromotionStatus: kafkaRequest.PromotionStatus.String(),
+		Details:         details,
+		OrganisationId:  kafkaRequest.OrganisationId,
+	}
+	if err := kafkaEvents.Record(event); err != nil {
+		logger.Logger.Errorf("failed to record %q event with status %q for kafka %q: %v", event.Type, event.Status, event.KafkaId, err)
+	}
+	kafkaRequest.SetStoredStatus(kafkaRequest.Status)
+}
+
+// recordKafkaStatusTransition records the status event of the given kafka only when its status has changed since it
+// was stored
+func recordKafkaStatusTransition(kafkaEvents KafkaEventService, kafkaRequest *dbapi.KafkaRequest, details string) {
+	if kafkaRequest.Status != kafkaRequest.StoredStatus() {
+		recordKafkaEvent(kafkaEvents, kafkaRequest, dbapi.KafkaEventTypeStatus, details)
+	}
+}
+
+// resizeEventOfUpdatedFields returns the type and the details of the resize event recorded when the given fields of the
+// given kafka are updated, false if the update is not a step of the resize of the kafka
+func resizeEventOfUpdatedFields(kafkaRequest *dbapi.KafkaRequest, fields map[string]interface{}) (dbapi.KafkaEventType, string, bool) {
+	resizeStatus, ok := fields["resize_status"]
+	if !ok {
+		return "", "", false
+	}
+
+	switch dbapi.KafkaResizeStatus(fmt.Sprint(resizeStatus)) {
+	case dbapi.KafkaResizeStatusResizing:
+		// the failed attempts of the resize worker keep on resizing without changing the desired size
+		if desiredSizeId, ok := fields["desired_size_id"]; ok {
+			return dbapi.KafkaEventTypeResizeRequested, fmt.Sprintf("resize from size %q to size %q requested", kafkaRequest.SizeId, desiredSizeId), true
+		}
+	case dbapi.KafkaResizeStatusFailed:
+		return dbapi.KafkaEventTypeResizeFailed, fmt.Sprint(fields["resize_details"]), true
+	case dbapi.KafkaResizeStatusNoResize:
+		if sizeId, ok := fields["size_id"]; ok {
+			return dbapi.KafkaEventTypeResized, fmt.Sprintf("resized from size %q to size %q", kafkaRequest.SizeId, sizeId), true
+		}
+	}
+
+	return "", "", false
+}
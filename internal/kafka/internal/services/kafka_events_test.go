@@ -0,0 +1,145 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/webhooks"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_kafkaEventService_Record(t *testing.T) {
+	tests := []struct {
+		name         string
+		event        *dbapi.KafkaEvent
+		wantNotified bool
+	}{
+		{
+			name:         "should record the first event of a kafka",
+			event:        &dbapi.KafkaEvent{KafkaId: "kafka-id", Status: "accepted", OrganisationId: "org-id"},
+			wantNotified: true,
+		},
+		{
+			name:         "should record a change of status",
+			event:        &dbapi.KafkaEvent{KafkaId: "kafka-id", PreviousStatus: "provisioning", Status: "ready", OrganisationId: "org-id"},
+			wantNotified: true,
+		},
+		{
+			name:  "should record an event without any change of status",
+			event: &dbapi.KafkaEvent{KafkaId: "kafka-id", PreviousStatus: "provisioning", Status: "provisioning", Details: "new details", OrganisationId: "org-id"},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			insert := mocket.Catcher.NewMock().WithQuery(`INSERT INTO "kafka_events"`)
+			query := mocket.Catcher.NewMock().WithQuery(`SELECT`)
+			webhookService := &webhooks.WebhookServiceMock{
+				NotifyFunc: func(event *webhooks.Event) *errors.ServiceError {
+					return nil
+				},
+			}
+
+			err := NewKafkaEventService(db.NewMockConnectionFactory(nil), webhookService).Record(tt.event)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(insert.Triggered).To(gomega.BeTrue())
+			g.Expect(query.Triggered).To(gomega.BeFalse())
+			if !tt.wantNotified {
+				g.Expect(webhookService.NotifyCalls()).To(gomega.BeEmpty())
+				return
+			}
+			g.Expect(webhookService.NotifyCalls()).To(gomega.HaveLen(1))
+			notified := webhookService.NotifyCalls()[0].Event
+			g.Expect(notified.Type).To(gomega.Equal(webhooks.KafkaPhaseChanged))
+			g.Expect(notified.OrganisationId).To(gomega.Equal("org-id"))
+			g.Expect(notified.Resource.Id).To(gomega.Equal("kafka-id"))
+			g.Expect(notified.PreviousPhase).To(gomega.Equal(tt.event.PreviousStatus))
+			g.Expect(notified.Phase).To(gomega.Equal(tt.event.Status))
+		})
+	}
+}
+
+func Test_recordKafkaStatusTransition(t *testing.T) {
+	tests := []struct {
+		name               string
+		storedStatus       string
+		status             string
+		wantRecorded       bool
+		wantPreviousStatus string
+	}{
+		{
+			name:         "should record the status of a new kafka",
+			status:       "accepted",
+			wantRecorded: true,
+		},
+		{
+			name:               "should record a change of the status loaded from the database",
+			storedStatus:       "provisioning",
+			status:             "ready",
+			wantRecorded:       true,
+			wantPreviousStatus: "provisioning",
+		},
+		{
+			name:         "should not record a kafka whose status has not changed",
+			storedStatus: "provisioning",
+			status:       "provisioning",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			kafkaEvents := &KafkaEventServiceMock{
+				RecordFunc: func(event *dbapi.KafkaEvent) *errors.ServiceError {
+					return nil
+				},
+			}
+			kafkaRequest := &dbapi.KafkaRequest{Status: tt.status, OrganisationId: "org-id"}
+			kafkaRequest.SetStoredStatus(tt.storedStatus)
+
+			recordKafkaStatusTransition(kafkaEvents, kafkaRequest, "")
+			// recording the same status again never records a second event
+			recordKafkaStatusTransition(kafkaEvents, kafkaRequest, "")
+
+			g.Expect(kafkaRequest.StoredStatus()).To(gomega.Equal(tt.status))
+			if !tt.wantRecorded {
+				g.Expect(kafkaEvents.RecordCalls()).To(gomega.BeEmpty())
+				return
+			}
+			g.Expect(kafkaEvents.RecordCalls()).To(gomega.HaveLen(1))
+			recorded := kafkaEvents.RecordCalls()[0].Event
+			g.Expect(recorded.Type).To(gomega.Equal(dbapi.KafkaEventTypeStatus))
+			g.Expect(recorded.PreviousStatus).To(gomega.Equal(tt.wantPreviousStatus))
+			g.Expect(recorded.Status).To(gomega.Equal(tt.status))
+			g.Expect(recorded.OrganisationId).To(gomega.Equal("org-id"))
+		})
+	}
+}
+
+func Test_kafkaEventService_ListByKafkaId(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	mocket.Catcher.Reset()
+	mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "kafka_events" WHERE kafka_id = $1`).WithArgs("kafka-id").WithReply([]map[string]interface{}{{"count": 2}})
+	mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "kafka_events" WHERE kafka_id = $1 ORDER BY created_at`).WithArgs("kafka-id").WithReply([]map[string]interface{}{
+		{"id": "event-1", "kafka_id": "kafka-id", "previous_status": "", "status": "accepted"},
+		{"id": "event-2", "kafka_id": "kafka-id", "previous_status": "accepted", "status": "preparing"},
+	})
+
+	events, paging, err := NewKafkaEventService(db.NewMockConnectionFactory(nil), &webhooks.WebhookServiceMock{}).ListByKafkaId("kafka-id", &coreServices.ListArguments{Page: 1, Size: 100})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(paging.Total).To(gomega.Equal(2))
+	g.Expect(paging.Size).To(gomega.Equal(2))
+	g.Expect(events).To(gomega.Equal(dbapi.KafkaEventList{
+		{ID: "event-1", KafkaId: "kafka-id", Status: "accepted"},
+		{ID: "event-2", KafkaId: "kafka-id", PreviousStatus: "accepted", Status: "preparing"},
+	}))
+}
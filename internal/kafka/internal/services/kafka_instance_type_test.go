@@ -50,6 +50,61 @@ func Test_KafkaInstanceTypes_GetSupportedKafkaInstanceTypesByRegion(t *testing.T
 				},
 			},
 		},
+		{
+			name: "does not list the sizes and the billing models only available to the enterprise clusters",
+			fields: fields{
+				providerConfig: buildProviderConfiguration(testKafkaRequestRegion, MaxClusterCapacity, MaxClusterCapacity, false),
+				kafkaConfig: &config.KafkaConfig{
+					SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
+						Configuration: config.SupportedKafkaInstanceTypesConfig{
+							SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+								{
+									Id:          "developer",
+									DisplayName: "Trial",
+									SupportedBillingModels: []config.KafkaBillingModel{
+										{ID: "standard", AMSResource: "rhosak", AMSProduct: "RHOSAKTrial", AMSBillingModels: []string{"standard"}},
+										{ID: "enterprise", AMSResource: "rhosak", AMSProduct: "RHOSAKCC", AMSBillingModels: []string{"standard"}},
+									},
+									Sizes: []config.KafkaInstanceSize{
+										{Id: "x1", DisplayName: "1", SupportedBillingModels: []string{"standard"}},
+										{Id: "x1-enterprise", DisplayName: "1", SupportedBillingModels: []string{"enterprise"}},
+									},
+								},
+								{
+									Id:                     "standard",
+									DisplayName:            "Standard",
+									SupportedBillingModels: testSupportedKafkaBillingModelsStandard,
+									Sizes:                  supportedKafkaSizeStandard,
+								},
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				cloudProvider: "aws",
+				cloudRegion:   "us-east-1",
+			},
+			wantErr: false,
+			want: []config.KafkaInstanceType{
+				{
+					Id:          "developer",
+					DisplayName: "Trial",
+					SupportedBillingModels: []config.KafkaBillingModel{
+						{ID: "standard", AMSResource: "rhosak", AMSProduct: "RHOSAKTrial", AMSBillingModels: []string{"standard"}},
+					},
+					Sizes: []config.KafkaInstanceSize{
+						{Id: "x1", DisplayName: "1", SupportedBillingModels: []string{"standard"}},
+					},
+				},
+				{
+					Id:                     "standard",
+					DisplayName:            "Standard",
+					SupportedBillingModels: testSupportedKafkaBillingModelsStandard,
+					Sizes:                  supportedKafkaSizeStandard,
+				},
+			},
+		},
 		{
 			name: "fail when cloud region not supported",
 			fields: fields{
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 )
@@ -43,11 +44,12 @@ func (t *supportedKafkaInstanceTypesService) GetSupportedKafkaInstanceTypesByReg
 			return nil, errors.InstanceTypeNotSupported(fmt.Sprintf("instance type '%s' is unsupported", k))
 		}
 
+		sizes := getRegionalKafkaInstanceSizes(instanceType)
 		instanceTypeList = append(instanceTypeList, config.KafkaInstanceType{
 			Id:                     k,
 			DisplayName:            instanceType.DisplayName,
-			SupportedBillingModels: instanceType.SupportedBillingModels,
-			Sizes:                  instanceType.Sizes,
+			SupportedBillingModels: getKafkaBillingModelsSupportedBySizes(instanceType, sizes),
+			Sizes:                  sizes,
 		})
 	}
 	sort.Slice(instanceTypeList, func(i, j int) bool {
@@ -56,3 +58,33 @@ func (t *supportedKafkaInstanceTypesService) GetSupportedKafkaInstanceTypesByReg
 
 	return instanceTypeList, nil
 }
+
+// getRegionalKafkaInstanceSizes returns the sizes of the instance type available to the kafkas which are not deployed on
+// an enterprise cluster. The sizes reserved to the enterprise billing model are presented along with the enterprise
+// clusters instead.
+func getRegionalKafkaInstanceSizes(instanceType *config.KafkaInstanceType) []config.KafkaInstanceSize {
+	sizes := []config.KafkaInstanceSize{}
+	for _, size := range instanceType.Sizes {
+		for _, billingModel := range instanceType.SupportedBillingModels {
+			if billingModel.ID != constants.BillingModelEnterprise.String() && size.SupportsBillingModel(billingModel.ID) {
+				sizes = append(sizes, size)
+				break
+			}
+		}
+	}
+	return sizes
+}
+
+// getKafkaBillingModelsSupportedBySizes returns the billing models of the instance type available to at least one of the given sizes
+func getKafkaBillingModelsSupportedBySizes(instanceType *config.KafkaInstanceType, sizes []config.KafkaInstanceSize) []config.KafkaBillingModel {
+	billingModels := []config.KafkaBillingModel{}
+	for _, billingModel := range instanceType.SupportedBillingModels {
+		for i := range sizes {
+			if sizes[i].SupportsBillingModel(billingModel.ID) {
+				billingModels = append(billingModels, billingModel)
+				break
+			}
+		}
+	}
+	return billingModels
+}
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/dns"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+)
+
+// KafkaMigrationService migrates kafkas from a data plane cluster to another one. A kafka is migrated as follows:
+//  1. the kafka is provisioned on the target cluster. The source cluster keeps on serving it.
+//  2. once the target cluster reports the kafka as ready, the DNS records of the routes of the kafka are pointed to the
+//     target cluster and the kafka is assigned to it.
+//  3. the kafka is deprovisioned from the source cluster.
+//
+// The progress of the migration is stored in the kafka request so that it is resumed after a restart.
+//
+//go:generate moq -out kafka_migration_moq.go . KafkaMigrationService
+type KafkaMigrationService interface {
+	// StartMigration starts migrating the kafka to the data plane cluster with the given ID. When no cluster ID is
+	// given, the kafka is migrated to the first ready cluster of its cloud provider and region that can receive it.
+	StartMigration(kafka *dbapi.KafkaRequest, targetClusterID string) *errors.ServiceError
+	// ListKafkasToBeCutOver returns the kafkas that are ready on the target cluster of their migration
+	ListKafkasToBeCutOver() ([]*dbapi.KafkaRequest, *errors.ServiceError)
+	// CutOver points the DNS records of the routes of the kafka to the target cluster of its migration and, once the
+	// change is propagated, assigns the kafka to the target cluster. It is meant to be called until the kafka is cut over.
+	CutOver(kafka *dbapi.KafkaRequest) *errors.ServiceError
+}
+
+var _ KafkaMigrationService = &kafkaMigrationService{}
+
+type kafkaMigrationService struct {
+	connectionFactory *db.ConnectionFactory
+	kafkaService      KafkaService
+	clusterService    ClusterService
+	kafkaConfig       *config.KafkaConfig
+}
+
+func NewKafkaMigrationService(connectionFactory *db.ConnectionFactory, kafkaService KafkaService, clusterService ClusterService, kafkaConfig *config.KafkaConfig) KafkaMigrationService {
+	return &kafkaMigrationService{
+		connectionFactory: connectionFactory,
+		kafkaService:      kafkaService,
+		clusterService:    clusterService,
+		kafkaConfig:       kafkaConfig,
+	}
+}
+
+func (m *kafkaMigrationService) StartMigration(kafka *dbapi.KafkaRequest, targetClusterID string) *errors.ServiceError {
+	targetCluster, err := m.findTargetCluster(kafka, targetClusterID)
+	if err != nil {
+		return err
+	}
+
+	logger.Logger.Infof("migrating kafka %q from cluster %q to cluster %q", kafka.ID, kafka.ClusterID, targetCluster.ClusterID)
+	kafka.MigrationStatus = dbapi.KafkaMigrationStatusProvisioning
+	kafka.MigrationTargetClusterId = targetCluster.ClusterID
+	kafka.MigrationSourceClusterId = ""
+	kafka.MigrationRoutes = nil
+	kafka.MigrationRoutesChangeId = ""
+	kafka.MigrationDetails = ""
+
+	// the kafka is provisioned on the target cluster as soon as the target cluster is set
+	return m.kafkaService.Updates(kafka, map[string]interface{}{
+		"migration_status":            kafka.MigrationStatus,
+		"migration_target_cluster_id": kafka.MigrationTargetClusterId,
+		"migration_source_cluster_id": kafka.MigrationSourceClusterId,
+		"migration_routes":            kafka.MigrationRoutes,
+		"migration_routes_change_id":  kafka.MigrationRoutesChangeId,
+		"migration_details":           kafka.MigrationDetails,
+	})
+}
+
+// ValidateKafkaCanBeMigrated verifies that the kafka is ready and that it is not being promoted, resized or migrated
+// already. A kafka whose migration failed can be migrated again once it is deprovisioned from the target cluster of
+// the failed migration.
+func ValidateKafkaCanBeMigrated(kafka *dbapi.KafkaRequest) *errors.ServiceError {
+	if kafka.Status != constants.KafkaRequestStatusReady.String() {
+		return errors.BadRequest("kafka request %q with status %q cannot be migrated: only ready kafka requests can be migrated", kafka.ID, kafka.Status)
+	}
+	if kafka.PromotionStatus == dbapi.KafkaPromotionStatusPromoting {
+		return errors.GeneralError("promotion already in progress. kafka request %q is being promoted from kafka billing %q to %q", kafka.ID, kafka.ActualKafkaBillingModel, kafka.DesiredKafkaBillingModel)
+	}
+	if kafka.ResizeStatus == dbapi.KafkaResizeStatusResizing {
+		return errors.GeneralError("resize already in progress. kafka request %q is being resized from size %q to %q", kafka.ID, kafka.SizeId, kafka.DesiredSizeId)
+	}
+	if kafka.MigrationInProgress() {
+		return errors.Conflict("migration already in progress. kafka request %q is being migrated to another data plane cluster", kafka.ID)
+	}
+
+	return nil
+}
+
+// findTargetCluster returns the cluster with the given ID if the kafka can be migrated to it. When no cluster ID is
+// given, it returns the first cluster the kafka can be migrated to.
+func (m *kafkaMigrationService) findTargetCluster(kafka *dbapi.KafkaRequest, targetClusterID string) (*api.Cluster, *errors.ServiceError) {
+	if targetClusterID != "" {
+		cluster, err := m.clusterService.FindClusterByID(targetClusterID)
+		if err != nil {
+			return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to find data plane cluster %q", targetClusterID)
+		}
+		if cluster == nil {
+			return nil, errors.BadRequest("data plane cluster %q not found", targetClusterID)
+		}
+		if err := m.validateTargetCluster(kafka, cluster); err != nil {
+			return nil, err
+		}
+		return cluster, nil
+	}
+
+	// enterprise kafkas can only be deployed on the clusters of their organisation: the target has to be chosen explicitly
+	if kafka.DesiredBillingModelIsEnterprise() {
+		return nil, errors.BadRequest("the target data plane cluster is required to migrate enterprise kafka %q", kafka.ID)
+	}
+
+	clusters, findErr := m.clusterService.FindAllClusters(FindClusterCriteria{
+		Provider:              kafka.CloudProvider,
+		Region:                kafka.Region,
+		MultiAZ:               kafka.MultiAZ,
+		Status:                api.ClusterReady,
+		SupportedInstanceType: kafka.InstanceType,
+		ExcludeCordoned:       true,
+	})
+	if findErr != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, findErr, "failed to find a data plane cluster to migrate kafka %q to", kafka.ID)
+	}
+	for _, cluster := range clusters {
+		if m.validateTargetCluster(kafka, cluster) == nil {
+			return cluster, nil
+		}
+	}
+
+	return nil, errors.BadRequest("no data plane cluster is available to migrate kafka %q to", kafka.ID)
+}
+
+// validateTargetCluster verifies that the kafka can be migrated to the cluster: the cluster has to be a ready and
+// uncordoned cluster of the same kind, cloud provider and region as the current cluster of the kafka, it has to support
+// the instance type and the strimzi version of the kafka and it must have enough capacity left to receive the kafka.
+func (m *kafkaMigrationService) validateTargetCluster(kafka *dbapi.KafkaRequest, cluster *api.Cluster) *errors.ServiceError {
+	if cluster.ClusterID == kafka.ClusterID {
+		return errors.BadRequest("kafka %q is already deployed on data plane cluster %q", kafka.ID, cluster.ClusterID)
+	}
+	if cluster.Status != api.ClusterReady || cluster.IsCordoned() {
+		return errors.BadRequest("data plane cluster %q is not ready to accept kafkas", cluster.ClusterID)
+	}
+	if cluster.CloudProvider != kafka.CloudProvider || cluster.Region != kafka.Region {
+		return errors.BadRequest("data plane cluster %q is not in the cloud provider and region of kafka %q", cluster.ClusterID, kafka.ID)
+	}
+	// like when they are placed, the single AZ kafkas can be deployed on multi AZ clusters: the developer kafkas of the
+	// enterprise clusters, which are all multi AZ, are single AZ kafkas
+	if kafka.MultiAZ && !cluster.MultiAZ {
+		return errors.BadRequest("multi AZ kafka %q cannot be migrated to single AZ data plane cluster %q", kafka.ID, cluster.ClusterID)
+	}
+
+	if kafka.DesiredBillingModelIsEnterprise() {
+		if cluster.ClusterType != api.EnterpriseDataPlaneClusterType.String() || cluster.OrganizationID != kafka.OrganisationId {
+			return errors.BadRequest("enterprise kafka %q can only be migrated to the enterprise data plane clusters of its organisation", kafka.ID)
+		}
+	} else if cluster.ClusterType == api.EnterpriseDataPlaneClusterType.String() {
+		return errors.BadRequest("kafka %q cannot be migrated to enterprise data plane cluster %q", kafka.ID, cluster.ClusterID)
+	}
+
+	if !arrays.Contains(cluster.GetSupportedInstanceTypes(), kafka.InstanceType) {
+		return errors.BadRequest("instance type %q is not supported by data plane cluster %q", kafka.InstanceType, cluster.ClusterID)
+	}
+
+	strimziVersions, versionsErr := cluster.GetAvailableAndReadyStrimziVersions()
+	if versionsErr != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, versionsErr, "failed to get the strimzi versions of data plane cluster %q", cluster.ClusterID)
+	}
+	if !arrays.AnyMatch(strimziVersions, func(v api.StrimziVersion) bool { return v.Version == kafka.DesiredStrimziVersion }) {
+		return errors.BadRequest("strimzi version %q of kafka %q is not available on data plane cluster %q", kafka.DesiredStrimziVersion, kafka.ID, cluster.ClusterID)
+	}
+
+	// clusters without dynamic capacity information do not limit the number of kafkas they receive
+	capacityInfo, ok := cluster.RetrieveDynamicCapacityInfo()[kafka.InstanceType]
+	if !ok {
+		return nil
+	}
+	kafkaSize, sizeErr := m.kafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, kafka.SizeId)
+	if sizeErr != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, sizeErr, "failed to get the size of kafka %q", kafka.ID)
+	}
+	streamingUnitCounts, countErr := m.clusterService.ComputeConsumedStreamingUnitCountPerInstanceType(cluster.ClusterID)
+	if countErr != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, countErr, "failed to compute the consumed capacity of data plane cluster %q", cluster.ClusterID)
+	}
+	usedCapacity := streamingUnitCounts[types.KafkaInstanceType(kafka.InstanceType)]
+	if usedCapacity+int64(kafkaSize.CapacityConsumed) > int64(capacityInfo.MaxUnits) {
+		return errors.BadRequest("data plane cluster %q does not have enough capacity to receive kafka %q", cluster.ClusterID, kafka.ID)
+	}
+
+	return nil
+}
+
+func (m *kafkaMigrationService) ListKafkasToBeCutOver() ([]*dbapi.KafkaRequest, *errors.ServiceError) {
+	dbConn := m.connectionFactory.New()
+
+	var kafkas []*dbapi.KafkaRequest
+
+	if err := dbConn.Model(&dbapi.KafkaRequest{}).
+		Where("migration_status = ?", dbapi.KafkaMigrationStatusCuttingOver).
+		Where("migration_target_cluster_id <> ''").
+		Where("status = ?", constants.KafkaRequestStatusReady.String()).
+		Scan(&kafkas).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "failed to list kafkas to be cut over")
+	}
+
+	return kafkas, nil
+}
+
+func (m *kafkaMigrationService) CutOver(kafka *dbapi.KafkaRequest) *errors.ServiceError {
+	if m.kafkaConfig.EnableKafkaCNAMERegistration {
+		propagated, err := m.changeRoutesToTargetCluster(kafka)
+		if err != nil || !propagated {
+			return err
+		}
+	}
+
+	logger.Logger.Infof("cutting over kafka %q from cluster %q to cluster %q", kafka.ID, kafka.ClusterID, kafka.MigrationTargetClusterId)
+	kafka.MigrationSourceClusterId = kafka.ClusterID
+	kafka.ClusterID = kafka.MigrationTargetClusterId
+	kafka.Routes = kafka.MigrationRoutes
+	kafka.MigrationTargetClusterId = ""
+	kafka.MigrationRoutes = nil
+	kafka.MigrationRoutesChangeId = ""
+	kafka.MigrationStatus = dbapi.KafkaMigrationStatusDeprovisioning
+
+	// from now on the source cluster is sent the kafka as deleted
+	return m.kafkaService.Updates(kafka, map[string]interface{}{
+		"cluster_id":                  kafka.ClusterID,
+		"routes":                      kafka.Routes,
+		"migration_source_cluster_id": kafka.MigrationSourceClusterId,
+		"migration_target_cluster_id": kafka.MigrationTargetClusterId,
+		"migration_routes":            kafka.MigrationRoutes,
+		"migration_routes_change_id":  kafka.MigrationRoutesChangeId,
+		"migration_status":            kafka.MigrationStatus,
+	})
+}
+
+// changeRoutesToTargetCluster points the DNS records of the routes of the kafka to the target cluster of its migration.
+// It returns whether the change has been propagated: the source cluster keeps on serving the clients that resolved
+// the routes before the change until then.
+func (m *kafkaMigrationService) changeRoutesToTargetCluster(kafka *dbapi.KafkaRequest) (bool, *errors.ServiceError) {
+	// the DNS records are changed with a copy of the kafka holding the routes of the target cluster
+	target := *kafka
+	target.Routes = kafka.MigrationRoutes
+	target.RoutesCreationId = kafka.MigrationRoutesChangeId
+
+	if kafka.MigrationRoutesChangeId == "" {
+		logger.Logger.Infof("pointing the CNAME records of kafka %q to cluster %q", kafka.ID, kafka.MigrationTargetClusterId)
+		change, err := m.kafkaService.ChangeKafkaCNAMErecords(&target, KafkaRoutesActionUpsert)
+		if err != nil {
+			return false, err
+		}
+		kafka.MigrationRoutesChangeId = change.ID
+		if err := m.kafkaService.Updates(kafka, map[string]interface{}{"migration_routes_change_id": kafka.MigrationRoutesChangeId}); err != nil {
+			return false, err
+		}
+		return change.Status == dns.ChangeStatusInSync, nil
+	}
+
+	change, err := m.kafkaService.GetCNAMERecordStatus(&target)
+	if err != nil {
+		return false, errors.ToServiceError(err)
+	}
+	return change.Status == dns.ChangeStatusInSync, nil
+}
@@ -0,0 +1,449 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/dns"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+)
+
+func buildMigrationTargetCluster(modifyFn func(cluster *api.Cluster)) *api.Cluster {
+	cluster := &api.Cluster{
+		ClusterID:             "target-cluster-id",
+		Status:                api.ClusterReady,
+		CloudProvider:         "aws",
+		Region:                "us-east-1",
+		MultiAZ:               true,
+		ClusterType:           api.ManagedDataPlaneClusterType.String(),
+		SupportedInstanceType: "standard",
+	}
+	_ = cluster.SetAvailableStrimziVersions([]api.StrimziVersion{{Version: "strimzi-cluster-operator.v0.23.0-0", Ready: true}})
+	if modifyFn != nil {
+		modifyFn(cluster)
+	}
+	return cluster
+}
+
+func buildMigratedKafka(modifyFn func(kafka *dbapi.KafkaRequest)) *dbapi.KafkaRequest {
+	kafka := &dbapi.KafkaRequest{
+		Meta: api.Meta{
+			ID: "kafka-id",
+		},
+		ClusterID:             "source-cluster-id",
+		CloudProvider:         "aws",
+		Region:                "us-east-1",
+		MultiAZ:               true,
+		InstanceType:          "standard",
+		SizeId:                "x1",
+		DesiredStrimziVersion: "strimzi-cluster-operator.v0.23.0-0",
+	}
+	if modifyFn != nil {
+		modifyFn(kafka)
+	}
+	return kafka
+}
+
+func Test_ValidateKafkaCanBeMigrated(t *testing.T) {
+	tests := []struct {
+		name    string
+		kafka   *dbapi.KafkaRequest
+		wantErr bool
+	}{
+		{
+			name: "should accept a ready kafka",
+			kafka: buildMigratedKafka(func(kafka *dbapi.KafkaRequest) {
+				kafka.Status = constants.KafkaRequestStatusReady.String()
+			}),
+		},
+		{
+			name: "should accept a ready kafka deprovisioned from the target cluster of its failed migration",
+			kafka: buildMigratedKafka(func(kafka *dbapi.KafkaRequest) {
+				kafka.Status = constants.KafkaRequestStatusReady.String()
+				kafka.MigrationStatus = dbapi.KafkaMigrationStatusFailed
+			}),
+		},
+		{
+			name: "should reject a kafka that is not ready",
+			kafka: buildMigratedKafka(func(kafka *dbapi.KafkaRequest) {
+				kafka.Status = constants.KafkaRequestStatusSuspended.String()
+			}),
+			wantErr: true,
+		},
+		{
+			name: "should reject a kafka being promoted",
+			kafka: buildMigratedKafka(func(kafka *dbapi.KafkaRequest) {
+				kafka.Status = constants.KafkaRequestStatusReady.String()
+				kafka.PromotionStatus = dbapi.KafkaPromotionStatusPromoting
+			}),
+			wantErr: true,
+		},
+		{
+			name: "should reject a kafka being resized",
+			kafka: buildMigratedKafka(func(kafka *dbapi.KafkaRequest) {
+				kafka.Status = constants.KafkaRequestStatusReady.String()
+				kafka.ResizeStatus = dbapi.KafkaResizeStatusResizing
+			}),
+			wantErr: true,
+		},
+		{
+			name: "should reject a kafka still being deprovisioned from the target cluster of its failed migration",
+			kafka: buildMigratedKafka(func(kafka *dbapi.KafkaRequest) {
+				kafka.Status = constants.KafkaRequestStatusReady.String()
+				kafka.MigrationStatus = dbapi.KafkaMigrationStatusFailed
+				kafka.MigrationTargetClusterId = "target-cluster-id"
+			}),
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			g.Expect(ValidateKafkaCanBeMigrated(tt.kafka) != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func Test_kafkaMigrationService_StartMigration(t *testing.T) {
+	type fields struct {
+		clusterService ClusterService
+	}
+	type args struct {
+		kafka           *dbapi.KafkaRequest
+		targetClusterID string
+	}
+
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		wantErr    bool
+		wantTarget string
+	}{
+		{
+			name: "should return an error when the target cluster does not exist",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return nil, nil
+					},
+				},
+			},
+			args: args{
+				kafka:           buildMigratedKafka(nil),
+				targetClusterID: "target-cluster-id",
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when the target cluster is the current cluster of the kafka",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return buildMigrationTargetCluster(func(cluster *api.Cluster) {
+							cluster.ClusterID = "source-cluster-id"
+						}), nil
+					},
+				},
+			},
+			args: args{
+				kafka:           buildMigratedKafka(nil),
+				targetClusterID: "source-cluster-id",
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when the target cluster is in another region",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return buildMigrationTargetCluster(func(cluster *api.Cluster) {
+							cluster.Region = "eu-west-1"
+						}), nil
+					},
+				},
+			},
+			args: args{
+				kafka:           buildMigratedKafka(nil),
+				targetClusterID: "target-cluster-id",
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when a multi AZ kafka is migrated to a single AZ cluster",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return buildMigrationTargetCluster(func(cluster *api.Cluster) {
+							cluster.MultiAZ = false
+						}), nil
+					},
+				},
+			},
+			args: args{
+				kafka:           buildMigratedKafka(nil),
+				targetClusterID: "target-cluster-id",
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when the strimzi version of the kafka is not available on the target cluster",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return buildMigrationTargetCluster(func(cluster *api.Cluster) {
+							_ = cluster.SetAvailableStrimziVersions([]api.StrimziVersion{{Version: "strimzi-cluster-operator.v0.24.0-0", Ready: true}})
+						}), nil
+					},
+				},
+			},
+			args: args{
+				kafka:           buildMigratedKafka(nil),
+				targetClusterID: "target-cluster-id",
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when the target cluster does not have enough capacity",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return buildMigrationTargetCluster(func(cluster *api.Cluster) {
+							_ = cluster.SetDynamicCapacityInfo(map[string]api.DynamicCapacityInfo{"standard": {MaxUnits: 2}})
+						}), nil
+					},
+					ComputeConsumedStreamingUnitCountPerInstanceTypeFunc: func(clusterID string) (StreamingUnitCountPerInstanceType, error) {
+						return StreamingUnitCountPerInstanceType{"standard": 2}, nil
+					},
+				},
+			},
+			args: args{
+				kafka:           buildMigratedKafka(nil),
+				targetClusterID: "target-cluster-id",
+			},
+			wantErr: true,
+		},
+		{
+			name: "should require the target cluster of enterprise kafkas",
+			fields: fields{
+				clusterService: &ClusterServiceMock{},
+			},
+			args: args{
+				kafka: buildMigratedKafka(func(kafka *dbapi.KafkaRequest) {
+					kafka.DesiredKafkaBillingModel = "enterprise"
+				}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "should migrate the kafka to the given cluster",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return buildMigrationTargetCluster(nil), nil
+					},
+				},
+			},
+			args: args{
+				kafka:           buildMigratedKafka(nil),
+				targetClusterID: "target-cluster-id",
+			},
+			wantTarget: "target-cluster-id",
+		},
+		{
+			name: "should migrate a single AZ kafka to a multi AZ cluster",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+						return buildMigrationTargetCluster(nil), nil
+					},
+				},
+			},
+			args: args{
+				kafka: buildMigratedKafka(func(kafka *dbapi.KafkaRequest) {
+					kafka.MultiAZ = false
+				}),
+				targetClusterID: "target-cluster-id",
+			},
+			wantTarget: "target-cluster-id",
+		},
+		{
+			name: "should migrate the kafka to the first cluster it can be migrated to",
+			fields: fields{
+				clusterService: &ClusterServiceMock{
+					FindAllClustersFunc: func(criteria FindClusterCriteria) ([]*api.Cluster, error) {
+						return []*api.Cluster{
+							buildMigrationTargetCluster(func(cluster *api.Cluster) {
+								cluster.ClusterID = "source-cluster-id"
+							}),
+							buildMigrationTargetCluster(func(cluster *api.Cluster) {
+								cluster.ClusterID = "enterprise-cluster-id"
+								cluster.ClusterType = api.EnterpriseDataPlaneClusterType.String()
+							}),
+							buildMigrationTargetCluster(nil),
+						}, nil
+					},
+				},
+			},
+			args: args{
+				kafka: buildMigratedKafka(nil),
+			},
+			wantTarget: "target-cluster-id",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			kafkaService := &KafkaServiceMock{
+				UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *errors.ServiceError {
+					return nil
+				},
+			}
+			m := NewKafkaMigrationService(nil, kafkaService, tt.fields.clusterService, &config.KafkaConfig{
+				SupportedInstanceTypes: &kafkaSupportedInstanceTypesConfig,
+			})
+
+			err := m.StartMigration(tt.args.kafka, tt.args.targetClusterID)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantErr {
+				g.Expect(kafkaService.UpdatesCalls()).To(gomega.BeEmpty())
+				return
+			}
+			g.Expect(kafkaService.UpdatesCalls()).To(gomega.HaveLen(1))
+			g.Expect(tt.args.kafka.MigrationStatus).To(gomega.Equal(dbapi.KafkaMigrationStatusProvisioning))
+			g.Expect(tt.args.kafka.MigrationTargetClusterId).To(gomega.Equal(tt.wantTarget))
+			g.Expect(tt.args.kafka.ClusterID).To(gomega.Equal("source-cluster-id"))
+		})
+	}
+}
+
+func Test_kafkaMigrationService_CutOver(t *testing.T) {
+	changeId := dns.QualifyChangeID(dns.KindRoute53, "change-id")
+
+	cuttingOverKafka := func(changeId string) *dbapi.KafkaRequest {
+		return buildMigratedKafka(func(kafka *dbapi.KafkaRequest) {
+			kafka.MigrationStatus = dbapi.KafkaMigrationStatusCuttingOver
+			kafka.MigrationTargetClusterId = "target-cluster-id"
+			kafka.MigrationRoutesChangeId = changeId
+			_ = kafka.SetMigrationRoutes([]dbapi.DataPlaneKafkaRoute{{Domain: "bootstrap.kafka.example.com", Router: "router.target.example.com"}})
+		})
+	}
+
+	tests := []struct {
+		name                string
+		kafka               *dbapi.KafkaRequest
+		enableCNAME         bool
+		kafkaService        *KafkaServiceMock
+		wantErr             bool
+		wantMigrationStatus dbapi.KafkaMigrationStatus
+		wantClusterID       string
+		wantChangeId        string
+	}{
+		{
+			name:        "should point the CNAME records to the target cluster and wait for the change to be propagated",
+			kafka:       cuttingOverKafka(""),
+			enableCNAME: true,
+			kafkaService: &KafkaServiceMock{
+				ChangeKafkaCNAMErecordsFunc: func(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*dns.Change, *errors.ServiceError) {
+					routes, _ := kafkaRequest.GetRoutes()
+					if action != KafkaRoutesActionUpsert || len(routes) != 1 || routes[0].Router != "router.target.example.com" {
+						return nil, errors.GeneralError("unexpected change of the CNAME records")
+					}
+					return &dns.Change{ID: changeId, Status: dns.ChangeStatusPending}, nil
+				},
+				UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *errors.ServiceError {
+					return nil
+				},
+			},
+			wantMigrationStatus: dbapi.KafkaMigrationStatusCuttingOver,
+			wantClusterID:       "source-cluster-id",
+			wantChangeId:        changeId,
+		},
+		{
+			name:        "should return an error when the CNAME records cannot be changed",
+			kafka:       cuttingOverKafka(""),
+			enableCNAME: true,
+			kafkaService: &KafkaServiceMock{
+				ChangeKafkaCNAMErecordsFunc: func(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*dns.Change, *errors.ServiceError) {
+					return nil, errors.GeneralError("test")
+				},
+			},
+			wantErr:             true,
+			wantMigrationStatus: dbapi.KafkaMigrationStatusCuttingOver,
+			wantClusterID:       "source-cluster-id",
+		},
+		{
+			name:        "should not cut over the kafka while the change of the CNAME records is not propagated",
+			kafka:       cuttingOverKafka(changeId),
+			enableCNAME: true,
+			kafkaService: &KafkaServiceMock{
+				GetCNAMERecordStatusFunc: func(kafkaRequest *dbapi.KafkaRequest) (*dns.Change, error) {
+					return &dns.Change{ID: changeId, Status: dns.ChangeStatusPending}, nil
+				},
+			},
+			wantMigrationStatus: dbapi.KafkaMigrationStatusCuttingOver,
+			wantClusterID:       "source-cluster-id",
+			wantChangeId:        changeId,
+		},
+		{
+			name:        "should cut over the kafka once the change of the CNAME records is propagated",
+			kafka:       cuttingOverKafka(changeId),
+			enableCNAME: true,
+			kafkaService: &KafkaServiceMock{
+				GetCNAMERecordStatusFunc: func(kafkaRequest *dbapi.KafkaRequest) (*dns.Change, error) {
+					return &dns.Change{ID: changeId, Status: dns.ChangeStatusInSync}, nil
+				},
+				UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *errors.ServiceError {
+					return nil
+				},
+			},
+			wantMigrationStatus: dbapi.KafkaMigrationStatusDeprovisioning,
+			wantClusterID:       "target-cluster-id",
+		},
+		{
+			name:  "should cut over the kafka straight away when the CNAME registration is disabled",
+			kafka: cuttingOverKafka(""),
+			kafkaService: &KafkaServiceMock{
+				UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *errors.ServiceError {
+					return nil
+				},
+			},
+			wantMigrationStatus: dbapi.KafkaMigrationStatusDeprovisioning,
+			wantClusterID:       "target-cluster-id",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			m := NewKafkaMigrationService(nil, tt.kafkaService, &ClusterServiceMock{}, &config.KafkaConfig{
+				EnableKafkaCNAMERegistration: tt.enableCNAME,
+			})
+
+			err := m.CutOver(tt.kafka)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(tt.kafka.MigrationStatus).To(gomega.Equal(tt.wantMigrationStatus))
+			g.Expect(tt.kafka.ClusterID).To(gomega.Equal(tt.wantClusterID))
+			g.Expect(tt.kafka.MigrationRoutesChangeId).To(gomega.Equal(tt.wantChangeId))
+			if tt.wantMigrationStatus == dbapi.KafkaMigrationStatusDeprovisioning {
+				g.Expect(tt.kafka.MigrationSourceClusterId).To(gomega.Equal("source-cluster-id"))
+				g.Expect(tt.kafka.MigrationTargetClusterId).To(gomega.BeEmpty())
+				routes, _ := tt.kafka.GetRoutes()
+				g.Expect(routes).To(gomega.HaveLen(1))
+				g.Expect(routes[0].Router).To(gomega.Equal("router.target.example.com"))
+			}
+		})
+	}
+}
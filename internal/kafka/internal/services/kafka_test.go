@@ -11,7 +11,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/cloudproviders"
@@ -23,7 +22,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	managedkafka "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api/managedkafkas.managedkafka.bf2.org/v1"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/auth"
-	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/aws"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/dns"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
@@ -74,6 +73,15 @@ func buildKafkaRequest(modifyFn func(kafkaRequest *dbapi.KafkaRequest)) *dbapi.K
 	return kafkaRequest
 }
 
+// buildKafkaEventService returns a kafka event service recording any event successfully
+func buildKafkaEventService() *KafkaEventServiceMock {
+	return &KafkaEventServiceMock{
+		RecordFunc: func(event *dbapi.KafkaEvent) *errors.ServiceError {
+			return nil
+		},
+	}
+}
+
 func buildDataplaneClusterConfig(clusters []config.ManualCluster) *config.DataplaneClusterConfig {
 	dataplane := config.NewDataplaneClusterConfig()
 	dataplane.ClusterConfig = config.NewClusterConfig(clusters)
@@ -683,8 +691,8 @@ func Test_kafkaService_PrepareKafkaRequest(t *testing.T) {
 				clusterService:                       tt.fields.clusterService,
 				keycloakService:                      tt.fields.keycloakService,
 				kafkaConfig:                          tt.fields.kafkaConfig,
-				awsConfig:                            config.NewAWSConfig(),
 				kafkaTLSCertificateManagementService: tt.fields.kafkaTLSCertificateManagementService,
+				canaryServiceAccountService:          newTmpVaultCanaryServiceAccountService(tt.fields.keycloakService),
 			}
 
 			if err := k.PrepareKafkaRequest(tt.args.kafkaRequest); (err != nil) != tt.wantErr {
@@ -779,7 +787,6 @@ func Test_kafkaService_RegisterKafkaDeprovisionJob(t *testing.T) {
 			k := &kafkaService{
 				connectionFactory: tt.fields.connectionFactory,
 				kafkaConfig:       config.NewKafkaConfig(),
-				awsConfig:         config.NewAWSConfig(),
 			}
 			err := k.RegisterKafkaDeprovisionJob(context.TODO(), tt.args.kafkaRequest.ID)
 			if (err != nil) != tt.wantErr {
@@ -802,16 +809,19 @@ func Test_kafkaService_Delete(t *testing.T) {
 		keycloakService                      sso.KeycloakService
 		kafkaConfig                          *config.KafkaConfig
 		kafkaTLSCertificateManagementService kafkatlscertmgmt.KafkaTLSCertificateManagementService
+		// canaryServiceAccountService defaults to a service backed by a temporary vault
+		canaryServiceAccountService CanaryServiceAccountService
 	}
 	type args struct {
 		kafkaRequest *dbapi.KafkaRequest
 	}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
-		setupFn func()
+		name                       string
+		fields                     fields
+		args                       args
+		wantErr                    bool
+		wantDeletedCanaryClientIds []string
+		setupFn                    func()
 	}{
 		{
 			name: "successfully deletes a Kafka request when it has not been assigned to an OSD cluster",
@@ -942,6 +952,66 @@ func Test_kafkaService_Delete(t *testing.T) {
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
+		{
+			name: "deletes the canary service accounts of a rotation in progress, recorded as pending or not",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				keycloakService: &sso.KeycloakServiceMock{
+					GetConfigFunc: func() *keycloak.KeycloakConfig {
+						return &keycloak.KeycloakConfig{
+							EnableAuthenticationOnKafka: true,
+						}
+					},
+				},
+				kafkaConfig: &config.KafkaConfig{},
+				canaryServiceAccountService: &CanaryServiceAccountServiceMock{
+					ListClientIdsFunc: func(kafka *dbapi.KafkaRequest) ([]string, *errors.ServiceError) {
+						return []string{"canary-id", "canary-id-rotating"}, nil
+					},
+					DeleteFunc: func(kafka *dbapi.KafkaRequest, clientId string) *errors.ServiceError {
+						return nil
+					},
+				},
+			},
+			args: args{
+				kafkaRequest: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.ID = testID
+					kafkaRequest.CanaryServiceAccountClientID = "canary-id"
+					kafkaRequest.PendingCanaryServiceAccountClientID = "canary-id-pending"
+				}),
+			},
+			wantDeletedCanaryClientIds: []string{"canary-id", "canary-id-rotating", "canary-id-pending"},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET "deleted_at"`)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "fail to delete kafka request: error when listing the canary service accounts",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				keycloakService: &sso.KeycloakServiceMock{
+					GetConfigFunc: func() *keycloak.KeycloakConfig {
+						return &keycloak.KeycloakConfig{
+							EnableAuthenticationOnKafka: true,
+						}
+					},
+				},
+				kafkaConfig: &config.KafkaConfig{},
+				canaryServiceAccountService: &CanaryServiceAccountServiceMock{
+					ListClientIdsFunc: func(kafka *dbapi.KafkaRequest) ([]string, *errors.ServiceError) {
+						return nil, errors.GeneralError("failed to list the secrets")
+					},
+				},
+			},
+			args: args{
+				kafkaRequest: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.ID = testID
+					kafkaRequest.CanaryServiceAccountClientID = "canary-id"
+				}),
+			},
+			wantErr: true,
+		},
 		{
 			name: "successfully deletes a Kafka request without revoking the shared certificate", // a certificate is considerd shared if kafkaConfig.KafkaDomainName == kafkaRequest.KafkasRoutesBaseDomainName
 			fields: fields{
@@ -1062,19 +1132,30 @@ func Test_kafkaService_Delete(t *testing.T) {
 			if tt.setupFn != nil {
 				tt.setupFn()
 			}
+			canaryServiceAccountService := tt.fields.canaryServiceAccountService
+			if canaryServiceAccountService == nil {
+				canaryServiceAccountService = newTmpVaultCanaryServiceAccountService(tt.fields.keycloakService)
+			}
 			k := &kafkaService{
 				connectionFactory:                    tt.fields.connectionFactory,
 				clusterService:                       tt.fields.clusterService,
 				keycloakService:                      tt.fields.keycloakService,
 				kafkaConfig:                          tt.fields.kafkaConfig,
-				awsConfig:                            config.NewAWSConfig(),
 				kafkaTLSCertificateManagementService: tt.fields.kafkaTLSCertificateManagementService,
+				canaryServiceAccountService:          canaryServiceAccountService,
 			}
 			err := k.Delete(tt.args.kafkaRequest)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Delete() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if mock, ok := canaryServiceAccountService.(*CanaryServiceAccountServiceMock); ok && tt.wantDeletedCanaryClientIds != nil {
+				var deleted []string
+				for _, call := range mock.DeleteCalls() {
+					deleted = append(deleted, call.ClientId)
+				}
+				gomega.NewWithT(t).Expect(deleted).To(gomega.Equal(tt.wantDeletedCanaryClientIds))
+			}
 		})
 	}
 }
@@ -1441,8 +1522,8 @@ func Test_kafkaService_RegisterKafkaJob(t *testing.T) {
 				totalCountResponse := []map[string]interface{}{{"count": 0}}
 
 				mocket.Catcher.Reset()
-				mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "kafka_requests" WHERE instance_type = $1 AND owner = $2 AND (organisation_id = $3) AND "kafka_requests"."deleted_at" IS NULL`).
-					WithArgs(types.DEVELOPER.String(), testUser, "org-id").
+				mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "kafka_requests" WHERE instance_type = $1 AND (desired_kafka_billing_model IS NULL OR desired_kafka_billing_model <> $2) AND owner = $3 AND (organisation_id = $4) AND "kafka_requests"."deleted_at" IS NULL`).
+					WithArgs(types.DEVELOPER.String(), constants.BillingModelEnterprise.String(), testUser, "org-id").
 					WithReply(totalCountResponse)
 				mocket.Catcher.NewMock().
 					WithQuery(`SELECT * FROM "kafka_requests" WHERE region = $1 AND cloud_provider = $2 AND instance_type = $3 AND "kafka_requests"."deleted_at" IS NULL`).
@@ -1517,8 +1598,8 @@ func Test_kafkaService_RegisterKafkaJob(t *testing.T) {
 
 				totalCountResponse := []map[string]interface{}{{"count": 2}}
 
-				mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "kafka_requests" WHERE instance_type = $1 AND owner = $2 AND (organisation_id = $3) AND "kafka_requests"."deleted_at" IS NULL`).
-					WithArgs(types.DEVELOPER.String(), testUser, "org-id").
+				mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "kafka_requests" WHERE instance_type = $1 AND (desired_kafka_billing_model IS NULL OR desired_kafka_billing_model <> $2) AND owner = $3 AND (organisation_id = $4) AND "kafka_requests"."deleted_at" IS NULL`).
+					WithArgs(types.DEVELOPER.String(), constants.BillingModelEnterprise.String(), testUser, "org-id").
 					WithReply(totalCountResponse)
 				mocket.Catcher.NewMock().WithQueryException().WithExecException()
 			},
@@ -1838,10 +1919,10 @@ func Test_kafkaService_RegisterKafkaJob(t *testing.T) {
 			}
 
 			k := &kafkaService{
+				kafkaEvents:              buildKafkaEventService(),
 				connectionFactory:        tt.fields.connectionFactory,
 				clusterService:           tt.fields.clusterService,
 				kafkaConfig:              &tt.fields.kafkaConfig,
-				awsConfig:                config.NewAWSConfig(),
 				providerConfig:           tt.fields.providerConfig,
 				clusterPlacementStrategy: tt.fields.clusterPlmtStrategy,
 				dataplaneClusterConfig:   tt.fields.dataplaneClusterConfig,
@@ -2279,7 +2360,6 @@ func Test_kafkaService_List(t *testing.T) {
 			k := &kafkaService{
 				connectionFactory: tt.fields.connectionFactory,
 				kafkaConfig:       config.NewKafkaConfig(),
-				awsConfig:         config.NewAWSConfig(),
 			}
 
 			result, pagingMeta, err := k.List(tt.args.ctx, tt.args.listArgs)
@@ -2299,6 +2379,8 @@ func Test_kafkaService_List(t *testing.T) {
 			}
 
 			for i, got := range result {
+				// the kafkas loaded from the database keep track of their stored status
+				tt.want.kafkaList[i].SetStoredStatus(tt.want.kafkaList[i].Status)
 				g.Expect(got).To(gomega.Equal(tt.want.kafkaList[i]))
 			}
 		})
@@ -2384,7 +2466,6 @@ func Test_kafkaService_ListAll(t *testing.T) {
 			k := &kafkaService{
 				connectionFactory: tt.fields.connectionFactory,
 				kafkaConfig:       config.NewKafkaConfig(),
-				awsConfig:         config.NewAWSConfig(),
 			}
 
 			result, err := k.ListAll()
@@ -2394,6 +2475,8 @@ func Test_kafkaService_ListAll(t *testing.T) {
 			g.Expect(len(result)).To(gomega.Equal(len(tt.want)))
 
 			for i, got := range result {
+				// the kafkas loaded from the database keep track of their stored status
+				tt.want[i].SetStoredStatus(tt.want[i].Status)
 				g.Expect(got).To(gomega.Equal(tt.want[i]))
 			}
 		})
@@ -2453,7 +2536,6 @@ func Test_kafkaService_ListByStatus(t *testing.T) {
 				connectionFactory: tt.fields.connectionFactory,
 				clusterService:    tt.fields.clusterService,
 				kafkaConfig:       config.NewKafkaConfig(),
-				awsConfig:         config.NewAWSConfig(),
 			}
 			got, err := k.ListByStatus(tt.args.status)
 			if (err != nil) != tt.wantErr {
@@ -2563,10 +2645,10 @@ func Test_kafkaService_UpdateStatus(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupFn()
 			k := kafkaService{
+				kafkaEvents:       buildKafkaEventService(),
 				connectionFactory: tt.fields.connectionFactory,
 				clusterService:    tt.fields.clusterService,
 				kafkaConfig:       config.NewKafkaConfig(),
-				awsConfig:         config.NewAWSConfig(),
 			}
 			executed, err := k.UpdateStatus(tt.args.id, tt.args.status)
 			if executed != tt.wantExecuted {
@@ -2633,7 +2715,6 @@ func Test_kafkaService_Update(t *testing.T) {
 				connectionFactory: tt.fields.connectionFactory,
 				clusterService:    tt.fields.clusterService,
 				kafkaConfig:       config.NewKafkaConfig(),
-				awsConfig:         config.NewAWSConfig(),
 			}
 			err := k.Update(tt.args.kafkaRequest)
 			if (err != nil) != tt.wantErr {
@@ -2696,7 +2777,6 @@ func Test_kafkaService_Updates(t *testing.T) {
 				connectionFactory: tt.fields.connectionFactory,
 				clusterService:    tt.fields.clusterService,
 				kafkaConfig:       config.NewKafkaConfig(),
-				awsConfig:         config.NewAWSConfig(),
 			}
 			err := k.Updates(tt.args.kafkaRequest, map[string]interface{}{
 				"id":    "idsds",
@@ -2710,6 +2790,127 @@ func Test_kafkaService_Updates(t *testing.T) {
 	}
 }
 
+func Test_kafkaService_Updates_RecordsKafkaEvents(t *testing.T) {
+	tests := []struct {
+		name               string
+		storedStatus       string
+		fields             map[string]interface{}
+		rowsAffected       int64
+		wantRecorded       bool
+		wantType           dbapi.KafkaEventType
+		wantPreviousStatus string
+	}{
+		{
+			name:               "should record an event when the status is updated",
+			storedStatus:       constants.KafkaRequestStatusProvisioning.String(),
+			fields:             map[string]interface{}{"status": constants.KafkaRequestStatusReady.String(), "failed_reason": ""},
+			rowsAffected:       1,
+			wantRecorded:       true,
+			wantType:           dbapi.KafkaEventTypeStatus,
+			wantPreviousStatus: constants.KafkaRequestStatusProvisioning.String(),
+		},
+		{
+			name:         "should record an event when a resize is requested",
+			storedStatus: constants.KafkaRequestStatusReady.String(),
+			fields: map[string]interface{}{
+				"owner":           "owner",
+				"desired_size_id": "x2",
+				"resize_status":   dbapi.KafkaResizeStatusResizing,
+				"resize_details":  "",
+			},
+			rowsAffected:       1,
+			wantRecorded:       true,
+			wantType:           dbapi.KafkaEventTypeResizeRequested,
+			wantPreviousStatus: constants.KafkaRequestStatusReady.String(),
+		},
+		{
+			name:         "should not record an event when a failed attempt to resize is retried",
+			storedStatus: constants.KafkaRequestStatusReady.String(),
+			fields: map[string]interface{}{
+				"resize_status":  dbapi.KafkaResizeStatusResizing,
+				"resize_details": "failed to reserve the quota",
+			},
+			rowsAffected: 1,
+		},
+		{
+			name:         "should record an event when the kafka is resized",
+			storedStatus: constants.KafkaRequestStatusReady.String(),
+			fields: map[string]interface{}{
+				"size_id":         "x2",
+				"desired_size_id": "",
+				"resize_status":   "",
+				"resize_details":  "",
+			},
+			rowsAffected:       1,
+			wantRecorded:       true,
+			wantType:           dbapi.KafkaEventTypeResized,
+			wantPreviousStatus: constants.KafkaRequestStatusReady.String(),
+		},
+		{
+			name:         "should record an event when the resize fails",
+			storedStatus: constants.KafkaRequestStatusReady.String(),
+			fields: map[string]interface{}{
+				"resize_status":  dbapi.KafkaResizeStatusFailed,
+				"resize_details": "insufficient capacity",
+			},
+			rowsAffected:       1,
+			wantRecorded:       true,
+			wantType:           dbapi.KafkaEventTypeResizeFailed,
+			wantPreviousStatus: constants.KafkaRequestStatusReady.String(),
+		},
+		{
+			name:         "should not record an event when the status is updated to the loaded status",
+			storedStatus: constants.KafkaRequestStatusReady.String(),
+			fields:       map[string]interface{}{"status": constants.KafkaRequestStatusReady.String()},
+			rowsAffected: 1,
+		},
+		{
+			name:         "should not record an event when none of the updated fields are part of the lifecycle history",
+			storedStatus: constants.KafkaRequestStatusProvisioning.String(),
+			fields:       map[string]interface{}{"owner": "new-owner"},
+			rowsAffected: 1,
+		},
+		{
+			name:         "should not record an event when the kafka is not updated",
+			storedStatus: constants.KafkaRequestStatusProvisioning.String(),
+			fields:       map[string]interface{}{"status": constants.KafkaRequestStatusReady.String()},
+			rowsAffected: 0,
+		},
+	}
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests"`).WithRowsNum(tt.rowsAffected)
+			kafkaEvents := buildKafkaEventService()
+			k := kafkaService{
+				kafkaEvents:       kafkaEvents,
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			}
+			kafkaRequest := buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+				kafkaRequest.Status = tt.storedStatus
+				kafkaRequest.SetStoredStatus(tt.storedStatus)
+			})
+			err := k.Updates(kafkaRequest, tt.fields)
+			g.Expect(err).To(gomega.BeNil())
+			if !tt.wantRecorded {
+				g.Expect(kafkaEvents.RecordCalls()).To(gomega.BeEmpty())
+				return
+			}
+			wantStatus, ok := tt.fields["status"]
+			if !ok {
+				wantStatus = tt.storedStatus
+			}
+			g.Expect(kafkaEvents.RecordCalls()).To(gomega.HaveLen(1))
+			g.Expect(kafkaEvents.RecordCalls()[0].Event.Type).To(gomega.Equal(tt.wantType))
+			g.Expect(kafkaEvents.RecordCalls()[0].Event.PreviousStatus).To(gomega.Equal(tt.wantPreviousStatus))
+			g.Expect(kafkaEvents.RecordCalls()[0].Event.Status).To(gomega.Equal(wantStatus))
+		})
+	}
+}
+
 func Test_kafkaService_DeprovisionKafkaForUsers(t *testing.T) {
 	type fields struct {
 		connectionFactory *db.ConnectionFactory
@@ -2979,7 +3180,8 @@ func Test_KafkaService_CountByStatus(t *testing.T) {
 
 func Test_KafkaService_ChangeKafkaCNAMErecords(t *testing.T) {
 	type fields struct {
-		awsClient aws.AWSClient
+		dnsProvider dns.Provider
+		factoryErr  *errors.ServiceError
 	}
 
 	type args struct {
@@ -2996,18 +3198,15 @@ func Test_KafkaService_ChangeKafkaCNAMErecords(t *testing.T) {
 		{
 			name: "should create CNAMEs for kafka",
 			fields: fields{
-				awsClient: &aws.AWSClientMock{
-					ChangeResourceRecordSetsFunc: func(dnsName string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
-						if len(recordChangeBatch.Changes) != 1 {
-							return nil, goerrors.Errorf("number of record changes should be 1")
+				dnsProvider: &dns.ProviderMock{
+					ChangeRecordSetsFunc: func(zone string, action dns.ChangeAction, recordSets []dns.RecordSet) (*dns.Change, error) {
+						if len(recordSets) != 1 {
+							return nil, goerrors.Errorf("number of record sets should be 1")
 						}
-						if *recordChangeBatch.Changes[0].Action != "CREATE" {
+						if action != dns.ChangeActionCreate {
 							return nil, goerrors.Errorf("the action of the record change is not CREATE")
 						}
-						return nil, nil
-					},
-					ListHostedZonesByNameInputFunc: func(dnsName string) (*route53.ListHostedZonesByNameOutput, error) {
-						return nil, nil
+						return &dns.Change{ID: dns.QualifyChangeID(dns.KindRoute53, "test"), Status: dns.ChangeStatusPending}, nil
 					},
 				},
 			},
@@ -3027,18 +3226,15 @@ func Test_KafkaService_ChangeKafkaCNAMErecords(t *testing.T) {
 		{
 			name: "should delete CNAMEs for kafka",
 			fields: fields{
-				awsClient: &aws.AWSClientMock{
-					ChangeResourceRecordSetsFunc: func(dnsName string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
-						if len(recordChangeBatch.Changes) != 1 {
-							return nil, goerrors.Errorf("number of record changes should be 1")
+				dnsProvider: &dns.ProviderMock{
+					ChangeRecordSetsFunc: func(zone string, action dns.ChangeAction, recordSets []dns.RecordSet) (*dns.Change, error) {
+						if len(recordSets) != 1 {
+							return nil, goerrors.Errorf("number of record sets should be 1")
 						}
-						if *recordChangeBatch.Changes[0].Action != "DELETE" {
+						if action != dns.ChangeActionDelete {
 							return nil, goerrors.Errorf("the action of the record change is not DELETE")
 						}
-						return nil, nil
-					},
-					ListHostedZonesByNameInputFunc: func(dnsName string) (*route53.ListHostedZonesByNameOutput, error) {
-						return nil, nil
+						return &dns.Change{ID: dns.QualifyChangeID(dns.KindRoute53, "test"), Status: dns.ChangeStatusPending}, nil
 					},
 				},
 			},
@@ -3058,18 +3254,15 @@ func Test_KafkaService_ChangeKafkaCNAMErecords(t *testing.T) {
 		{
 			name: "should return error if it fails to get routes",
 			fields: fields{
-				awsClient: &aws.AWSClientMock{
-					ChangeResourceRecordSetsFunc: func(dnsName string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
-						if len(recordChangeBatch.Changes) != 1 {
-							return nil, goerrors.Errorf("number of record changes should be 1")
+				dnsProvider: &dns.ProviderMock{
+					ChangeRecordSetsFunc: func(zone string, action dns.ChangeAction, recordSets []dns.RecordSet) (*dns.Change, error) {
+						if len(recordSets) != 1 {
+							return nil, goerrors.Errorf("number of record sets should be 1")
 						}
-						if *recordChangeBatch.Changes[0].Action != "CREATE" {
+						if action != dns.ChangeActionCreate {
 							return nil, goerrors.Errorf("the action of the record change is not CREATE")
 						}
-						return nil, nil
-					},
-					ListHostedZonesByNameInputFunc: func(dnsName string) (*route53.ListHostedZonesByNameOutput, error) {
-						return nil, nil
+						return &dns.Change{ID: dns.QualifyChangeID(dns.KindRoute53, "test"), Status: dns.ChangeStatusPending}, nil
 					},
 				},
 			},
@@ -3085,26 +3278,44 @@ func Test_KafkaService_ChangeKafkaCNAMErecords(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "should return error if it fails to get the DNS provider",
+			fields: fields{
+				factoryErr: errors.GeneralError("test"),
+			},
+			args: args{
+				kafkaRequest: &dbapi.KafkaRequest{
+					Meta: api.Meta{
+						ID: "test-kafka-id",
+					},
+					Name:          "test-kafka-cname",
+					Routes:        []byte("[{\"domain\": \"test-kafka-id.example.com\", \"router\": \"test-kafka-id.rhcloud.com\"}]"),
+					Region:        testKafkaRequestRegion,
+					CloudProvider: cloudproviders.AWS.String(),
+				},
+				action: KafkaRoutesActionCreate,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, testcase := range tests {
 		tt := testcase
 
 		t.Run(tt.name, func(t *testing.T) {
-			awsConfig := &config.AWSConfig{}
-			awsConfig.Route53.AccessKey = "test-route-53-key"
-			awsConfig.Route53.SecretAccessKey = "test-route-53-secret-key"
-
 			kafkaService := &kafkaService{
-				awsClientFactory: aws.NewMockClientFactory(tt.fields.awsClient),
-				awsConfig:        awsConfig,
+				dnsProviderFactory: &DNSProviderFactoryMock{
+					GetProviderFunc: func(cloudProvider string) (dns.Provider, *errors.ServiceError) {
+						return tt.fields.dnsProvider, tt.fields.factoryErr
+					},
+				},
 				kafkaConfig: &config.KafkaConfig{
 					KafkaDomainName: "rhcloud.com",
 				},
 			}
 
 			_, err := kafkaService.ChangeKafkaCNAMErecords(tt.args.kafkaRequest, tt.args.action)
-			if err != nil && !tt.wantErr {
+			if (err != nil) != tt.wantErr {
 				t.Errorf("unexpected error for ChangeKafkaCNAMErecords %v", err)
 			}
 		})
@@ -3526,6 +3737,7 @@ func Test_kafkaService_GetManagedKafkaByClusterID(t *testing.T) {
 	}
 	type args struct {
 		clusterID string
+		gtVersion int64
 	}
 	kafkaRequestList := dbapi.KafkaList{
 		&dbapi.KafkaRequest{
@@ -3553,7 +3765,30 @@ func Test_kafkaService_GetManagedKafkaByClusterID(t *testing.T) {
 			GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
 				return &keycloak.KeycloakRealmConfig{}
 			},
-		}, kafkatlscertmgmt.Certificate{}, false)
+		}, kafkatlscertmgmt.Certificate{}, false, "")
+
+	kafkaRequestWithVersion := &dbapi.KafkaRequest{
+		ClusterID:    testClusterID,
+		InstanceType: "developer",
+		SizeId:       "x1",
+		Version:      6,
+	}
+	managedkafkaCRWithVersion, _ := buildManagedKafkaCR(
+		kafkaRequestWithVersion,
+		&config.KafkaConfig{
+			EnableKafkaCNAMERegistration: true,
+			SupportedInstanceTypes:       &kafkaSupportedInstanceTypesConfig,
+		},
+		&sso.KeycloakServiceMock{
+			GetConfigFunc: func() *keycloak.KeycloakConfig {
+				return &keycloak.KeycloakConfig{
+					EnableAuthenticationOnKafka: true,
+				}
+			},
+			GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
+				return &keycloak.KeycloakRealmConfig{}
+			},
+		}, kafkatlscertmgmt.Certificate{}, false, "")
 
 	managedkafkaCRWithCert, _ := buildManagedKafkaCR(
 		&dbapi.KafkaRequest{
@@ -3574,7 +3809,7 @@ func Test_kafkaService_GetManagedKafkaByClusterID(t *testing.T) {
 			GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
 				return &keycloak.KeycloakRealmConfig{}
 			},
-		}, kafkatlscertmgmt.Certificate{TLSCert: "crt-cert", TLSKey: "key-cert"}, true)
+		}, kafkatlscertmgmt.Certificate{TLSCert: "crt-cert", TLSKey: "key-cert"}, true, "")
 
 	tests := []struct {
 		name    string
@@ -3673,6 +3908,93 @@ func Test_kafkaService_GetManagedKafkaByClusterID(t *testing.T) {
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
 		},
+		{
+			name: "should only return the kafkas with a version greater than gtVersion",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaTLSCertificateManagementService: &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{
+					IsKafkaExternalCertificateEnabledFunc: func() bool {
+						return false
+					},
+				},
+				keycloakService: &sso.KeycloakServiceMock{
+					GetConfigFunc: func() *keycloak.KeycloakConfig {
+						return &keycloak.KeycloakConfig{
+							EnableAuthenticationOnKafka: true,
+						}
+					},
+					GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
+						return &keycloak.KeycloakRealmConfig{}
+					},
+				},
+				kafkaConfig: &config.KafkaConfig{
+					EnableKafkaCNAMERegistration: true,
+					SupportedInstanceTypes:       &kafkaSupportedInstanceTypesConfig,
+				},
+			},
+			args: args{
+				clusterID: testClusterID,
+				gtVersion: 5,
+			},
+			wantErr: false,
+			want:    []managedkafka.ManagedKafka{*managedkafkaCRWithVersion},
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				query := fmt.Sprintf(`SELECT * FROM "%s" WHERE (cluster_id = $1 OR migration_target_cluster_id = $2 OR migration_source_cluster_id = $3) AND status IN ($4,$5,$6,$7,$8,$9,$10) AND bootstrap_server_host != '' AND version > $11 AND "%s"."deleted_at" IS NULL ORDER BY version`, kafkaRequestTableName, kafkaRequestTableName)
+				response := converters.ConvertKafkaRequestList(dbapi.KafkaList{kafkaRequestWithVersion})
+				mocket.Catcher.NewMock().WithQuery(query).WithReply(response)
+				mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "kafka_tombstones" WHERE cluster_id = $1 AND version > $2 ORDER BY version`).WithReply([]map[string]interface{}{})
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "should return the kafkas which left the cluster since gtVersion as deleted",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaTLSCertificateManagementService: &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{
+					IsKafkaExternalCertificateEnabledFunc: func() bool {
+						return false
+					},
+				},
+				keycloakService: &sso.KeycloakServiceMock{
+					GetConfigFunc: func() *keycloak.KeycloakConfig {
+						return &keycloak.KeycloakConfig{
+							EnableAuthenticationOnKafka: true,
+						}
+					},
+					GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
+						return &keycloak.KeycloakRealmConfig{}
+					},
+				},
+				kafkaConfig: &config.KafkaConfig{
+					EnableKafkaCNAMERegistration: true,
+					SupportedInstanceTypes:       &kafkaSupportedInstanceTypesConfig,
+				},
+			},
+			args: args{
+				clusterID: testClusterID,
+				gtVersion: 3,
+			},
+			wantErr: false,
+			want: []managedkafka.ManagedKafka{
+				*buildDeletedManagedKafkaCR(&dbapi.KafkaTombstone{KafkaId: "migrated-kafka", ClusterId: testClusterID, Version: 5, Name: "migrated", Namespace: "kafka-migrated-kafka"}),
+				*managedkafkaCRWithVersion,
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				query := fmt.Sprintf(`SELECT * FROM "%s" WHERE (cluster_id = $1 OR migration_target_cluster_id = $2 OR migration_source_cluster_id = $3)`, kafkaRequestTableName)
+				response := converters.ConvertKafkaRequestList(dbapi.KafkaList{kafkaRequestWithVersion})
+				mocket.Catcher.NewMock().WithQuery(query).WithReply(response)
+				// the kafka listed with version 6 left the cluster before coming back, its tombstone is not returned.
+				// only the latest departure of the migrated kafka is returned.
+				mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "kafka_tombstones"`).WithReply([]map[string]interface{}{
+					{"kafka_id": "", "cluster_id": testClusterID, "version": 4, "name": "", "namespace": ""},
+					{"kafka_id": "migrated-kafka", "cluster_id": testClusterID, "version": 4, "name": "migrated", "namespace": "kafka-migrated-kafka"},
+					{"kafka_id": "migrated-kafka", "cluster_id": testClusterID, "version": 5, "name": "migrated", "namespace": "kafka-migrated-kafka"},
+				})
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
 		{
 			name: "should return an error when fetching certificates fails",
 			fields: fields{
@@ -3731,57 +4053,288 @@ func Test_kafkaService_GetManagedKafkaByClusterID(t *testing.T) {
 				kafkaConfig:                          tt.fields.kafkaConfig,
 				kafkaTLSCertificateManagementService: tt.fields.kafkaTLSCertificateManagementService,
 				clusterService:                       tt.fields.clusterService,
+				maintenanceWindowService:             NewMaintenanceWindowService(tt.fields.connectionFactory),
 			}
-			got, err := k.GetManagedKafkaByClusterID(tt.args.clusterID)
+			got, err := k.GetManagedKafkaByClusterID(tt.args.clusterID, tt.args.gtVersion)
 			g.Expect(got).To(gomega.Equal(tt.want))
 			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
 		})
 	}
 }
 
-func Test_kafkaService_GenerateReservedManagedKafkasByClusterID(t *testing.T) {
-	type fields struct {
-		connectionFactory      *db.ConnectionFactory
-		kafkaConfig            *config.KafkaConfig
-		clusterService         ClusterService
-		dataplaneClusterConfig *config.DataplaneClusterConfig
-	}
-	type args struct {
-		clusterID string
-	}
+func Test_kafkaService_GetManagedKafkaByClusterID_PrunesAcknowledgedTombstones(t *testing.T) {
+	g := gomega.NewWithT(t)
 
-	strimziOperatorVersion := "strimzi-cluster-operator.from-cluster"
+	mocket.Catcher.Reset()
+	prune := mocket.Catcher.NewMock().WithQuery(`DELETE FROM "kafka_tombstones" WHERE cluster_id = $1 AND version <= $2`).WithArgs(testClusterID, int64(5)).WithRowsNum(2)
+	mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "kafka_tombstones" WHERE cluster_id = $1 AND version > $2 ORDER BY version`).WithReply([]map[string]interface{}{})
 
-	testDeveloperInstanceType, err := kafkaSupportedInstanceTypesConfig.Configuration.GetKafkaInstanceTypeByID("developer")
-	if err != nil {
-		panic("unexpected test error")
-	}
-	testDeveloperX1InstanceSize, err := testDeveloperInstanceType.GetKafkaInstanceSizeByID("x1")
-	if err != nil {
-		panic("unexpected test error")
-	}
-	testStandardInstanceType, err := kafkaSupportedInstanceTypesConfig.Configuration.GetKafkaInstanceTypeByID("standard")
-	if err != nil {
-		panic("unexpected test error")
+	k := &kafkaService{
+		connectionFactory:        db.NewMockConnectionFactory(nil),
+		kafkaConfig:              &config.KafkaConfig{},
+		maintenanceWindowService: NewMaintenanceWindowService(db.NewMockConnectionFactory(nil)),
+		kafkaTLSCertificateManagementService: &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{
+			IsKafkaExternalCertificateEnabledFunc: func() bool {
+				return false
+			},
+		},
 	}
-	testStandardX1InstanceSize, err := testStandardInstanceType.GetKafkaInstanceSizeByID("x1")
-	if err != nil {
-		panic("unexpected test error")
+	got, err := k.GetManagedKafkaByClusterID(testClusterID, 5)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(got).To(gomega.BeEmpty())
+	g.Expect(prune.Triggered).To(gomega.BeTrue())
+}
+
+func Test_kafkaService_GetManagedKafkaByClusterID_MaintenanceWindows(t *testing.T) {
+	pendingUpgrade := map[string]interface{}{
+		"id":                        "kafka-id",
+		"cluster_id":                testClusterID,
+		"organisation_id":           "org-id",
+		"instance_type":             "developer",
+		"size_id":                   "x1",
+		"desired_strimzi_version":   "strimzi-cluster-operator.v0.32.0-1",
+		"actual_strimzi_version":    "strimzi-cluster-operator.v0.31.0-1",
+		"desired_kafka_version":     "3.4.0",
+		"actual_kafka_version":      "3.3.1",
+		"desired_kafka_ibp_version": "3.4",
+		"actual_kafka_ibp_version":  "3.3",
+	}
+	withFields := func(fields map[string]interface{}) map[string]interface{} {
+		kafka := map[string]interface{}{}
+		for k, v := range pendingUpgrade {
+			kafka[k] = v
+		}
+		for k, v := range fields {
+			kafka[k] = v
+		}
+		return kafka
 	}
+	desiredVersions := managedkafka.VersionsSpec{Strimzi: "strimzi-cluster-operator.v0.32.0-1", Kafka: "3.4.0", KafkaIBP: "3.4"}
+	actualVersions := managedkafka.VersionsSpec{Strimzi: "strimzi-cluster-operator.v0.31.0-1", Kafka: "3.3.1", KafkaIBP: "3.3"}
+	organisationWindowQuery := `SELECT * FROM "maintenance_windows" WHERE organisation_id IN ($1)`
+	organisationWindow := []map[string]interface{}{{"id": "window-id", "organisation_id": "org-id", "day_of_week": "sunday", "start_hour": 2}}
 
-	testAvailableStrimziVersions := []api.StrimziVersion{
+	tests := []struct {
+		name               string
+		kafka              map[string]interface{}
+		organisationWindow []map[string]interface{}
+		windowDuration     time.Duration
+		want               managedkafka.VersionsSpec
+	}{
 		{
-			Version: strimziOperatorVersion,
-			Ready:   true,
-			KafkaVersions: []api.KafkaVersion{
-				{
-					Version: "2.7.0",
-				},
-			},
-			KafkaIBPVersions: []api.KafkaIBPVersion{
-				{
-					Version: "2.7",
-				},
+			name:  "should roll out the upgrade when no maintenance window has been chosen",
+			kafka: pendingUpgrade,
+			want:  desiredVersions,
+		},
+		{
+			name:               "should hold the upgrade until the maintenance window of the organisation opens",
+			kafka:              pendingUpgrade,
+			organisationWindow: organisationWindow,
+			want:               actualVersions,
+		},
+		{
+			name:  "should hold the upgrade until the maintenance window of the kafka opens",
+			kafka: withFields(map[string]interface{}{"maintenance_window_day": "monday", "maintenance_window_start_hour": 4}),
+			want:  actualVersions,
+		},
+		{
+			name:           "should roll out the upgrade while the maintenance window is open",
+			kafka:          withFields(map[string]interface{}{"maintenance_window_day": "monday"}),
+			windowDuration: 7 * 24 * time.Hour,
+			want:           desiredVersions,
+		},
+		{
+			name:  "should roll out the upgrade forced by an administrator",
+			kafka: withFields(map[string]interface{}{"maintenance_window_day": "monday", "upgrade_forced": true}),
+			want:  desiredVersions,
+		},
+		{
+			name:  "should keep rolling out the upgrade released in a maintenance window which has closed since",
+			kafka: withFields(map[string]interface{}{"maintenance_window_day": "monday", "maintenance_window_start_hour": 4, "upgrade_released_at": time.Now().Add(-7 * 24 * time.Hour)}),
+			want:  desiredVersions,
+		},
+		{
+			name:               "should not interrupt the upgrades in progress",
+			kafka:              withFields(map[string]interface{}{"strimzi_upgrading": true}),
+			organisationWindow: organisationWindow,
+			want:               managedkafka.VersionsSpec{Strimzi: "strimzi-cluster-operator.v0.32.0-1", Kafka: "3.3.1", KafkaIBP: "3.3"},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(fmt.Sprintf(`SELECT * FROM "%s"`, kafkaRequestTableName)).WithReply([]map[string]interface{}{tt.kafka})
+			mocket.Catcher.NewMock().WithQuery(organisationWindowQuery).WithArgs("org-id").WithReply(tt.organisationWindow)
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory:        db.NewMockConnectionFactory(nil),
+				maintenanceWindowService: NewMaintenanceWindowService(db.NewMockConnectionFactory(nil)),
+				keycloakService: &sso.KeycloakServiceMock{
+					GetConfigFunc: func() *keycloak.KeycloakConfig {
+						return &keycloak.KeycloakConfig{}
+					},
+					GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
+						return &keycloak.KeycloakRealmConfig{}
+					},
+				},
+				kafkaConfig: &config.KafkaConfig{
+					SupportedInstanceTypes:    &kafkaSupportedInstanceTypesConfig,
+					MaintenanceWindowDuration: tt.windowDuration,
+				},
+				kafkaTLSCertificateManagementService: &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{
+					IsKafkaExternalCertificateEnabledFunc: func() bool {
+						return false
+					},
+				},
+			}
+			got, err := k.GetManagedKafkaByClusterID(testClusterID, 0)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(got).To(gomega.HaveLen(1))
+			g.Expect(got[0].Spec.Versions).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_kafkaService_GetManagedKafkaByClusterID_CanaryServiceAccount(t *testing.T) {
+	canaryKafka := map[string]interface{}{
+		"id":                               "kafka-id",
+		"cluster_id":                       testClusterID,
+		"organisation_id":                  "org-id",
+		"instance_type":                    "developer",
+		"size_id":                          "x1",
+		"canary_service_account_client_id": "canary-kafka-id",
+	}
+	rotatingKafka := map[string]interface{}{"pending_canary_service_account_client_id": "canary-kafka-id-1679616000"}
+	for k, v := range canaryKafka {
+		rotatingKafka[k] = v
+	}
+
+	tests := []struct {
+		name                 string
+		kafka                map[string]interface{}
+		wantServiceAccount   managedkafka.ServiceAccount
+		wantCustomClaimCheck string
+	}{
+		{
+			name:                 "should publish the canary service account of the kafka",
+			kafka:                canaryKafka,
+			wantServiceAccount:   managedkafka.ServiceAccount{Name: "canary", Principal: "canary-kafka-id", Password: "canary-kafka-id-secret"},
+			wantCustomClaimCheck: "@.rh-org-id == 'org-id'|| @.org_id == 'org-id' || @.clientId == 'canary-kafka-id'",
+		},
+		{
+			name:               "should publish the service account the canary is rotated to and accept both service accounts",
+			kafka:              rotatingKafka,
+			wantServiceAccount: managedkafka.ServiceAccount{Name: "canary", Principal: "canary-kafka-id-1679616000", Password: "canary-kafka-id-1679616000-secret"},
+			wantCustomClaimCheck: "@.rh-org-id == 'org-id'|| @.org_id == 'org-id' || @.clientId == 'canary-kafka-id' || " +
+				"@.clientId == 'canary-kafka-id-1679616000'",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(fmt.Sprintf(`SELECT * FROM "%s"`, kafkaRequestTableName)).WithReply([]map[string]interface{}{tt.kafka})
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			k := &kafkaService{
+				connectionFactory:        db.NewMockConnectionFactory(nil),
+				maintenanceWindowService: NewMaintenanceWindowService(db.NewMockConnectionFactory(nil)),
+				keycloakService: &sso.KeycloakServiceMock{
+					GetConfigFunc: func() *keycloak.KeycloakConfig {
+						return &keycloak.KeycloakConfig{EnableAuthenticationOnKafka: true, SelectSSOProvider: keycloak.REDHAT_SSO}
+					},
+					GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
+						return &keycloak.KeycloakRealmConfig{}
+					},
+				},
+				kafkaConfig: &config.KafkaConfig{
+					SupportedInstanceTypes: &kafkaSupportedInstanceTypesConfig,
+				},
+				kafkaTLSCertificateManagementService: &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{
+					IsKafkaExternalCertificateEnabledFunc: func() bool {
+						return false
+					},
+				},
+				canaryServiceAccountService: &CanaryServiceAccountServiceMock{
+					GetClientSecretFunc: func(kafka *dbapi.KafkaRequest, clientId string) (string, *errors.ServiceError) {
+						return clientId + "-secret", nil
+					},
+				},
+			}
+			got, err := k.GetManagedKafkaByClusterID(testClusterID, 0)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(got).To(gomega.HaveLen(1))
+			g.Expect(got[0].Spec.ServiceAccounts).To(gomega.Equal([]managedkafka.ServiceAccount{tt.wantServiceAccount}))
+			g.Expect(got[0].Spec.OAuth.CustomClaimCheck).To(gomega.Equal(tt.wantCustomClaimCheck))
+		})
+	}
+}
+
+func Test_kafkaService_ListKafkasWithPendingUpgrade(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	mocket.Catcher.Reset()
+	query := mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "kafka_requests" WHERE status IN ($1,$2,$3,$4,$5,$6,$7) AND ((actual_strimzi_version <> '' AND desired_strimzi_version <> '' AND desired_strimzi_version <> actual_strimzi_version)`).
+		WithReply([]map[string]interface{}{{"id": "kafka-id"}})
+	mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+	k := &kafkaService{connectionFactory: db.NewMockConnectionFactory(nil)}
+	kafkas, err := k.ListKafkasWithPendingUpgrade()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(query.Triggered).To(gomega.BeTrue())
+	g.Expect(kafkas).To(gomega.HaveLen(1))
+	g.Expect(kafkas[0].ID).To(gomega.Equal("kafka-id"))
+}
+
+func Test_kafkaService_GenerateReservedManagedKafkasByClusterID(t *testing.T) {
+	type fields struct {
+		connectionFactory      *db.ConnectionFactory
+		kafkaConfig            *config.KafkaConfig
+		clusterService         ClusterService
+		dataplaneClusterConfig *config.DataplaneClusterConfig
+	}
+	type args struct {
+		clusterID string
+	}
+
+	strimziOperatorVersion := "strimzi-cluster-operator.from-cluster"
+
+	testDeveloperInstanceType, err := kafkaSupportedInstanceTypesConfig.Configuration.GetKafkaInstanceTypeByID("developer")
+	if err != nil {
+		panic("unexpected test error")
+	}
+	testDeveloperX1InstanceSize, err := testDeveloperInstanceType.GetKafkaInstanceSizeByID("x1")
+	if err != nil {
+		panic("unexpected test error")
+	}
+	testStandardInstanceType, err := kafkaSupportedInstanceTypesConfig.Configuration.GetKafkaInstanceTypeByID("standard")
+	if err != nil {
+		panic("unexpected test error")
+	}
+	testStandardX1InstanceSize, err := testStandardInstanceType.GetKafkaInstanceSizeByID("x1")
+	if err != nil {
+		panic("unexpected test error")
+	}
+
+	testAvailableStrimziVersions := []api.StrimziVersion{
+		{
+			Version: strimziOperatorVersion,
+			Ready:   true,
+			KafkaVersions: []api.KafkaVersion{
+				{
+					Version: "2.7.0",
+				},
+			},
+			KafkaIBPVersions: []api.KafkaIBPVersion{
+				{
+					Version: "2.7",
+				},
 			},
 		},
 	}
@@ -4322,16 +4875,30 @@ func Test_kafkaService_VerifyAndUpdateKafkaAdmin(t *testing.T) {
 
 func Test_kafkaService_GetCNAMERecordStatus(t *testing.T) {
 	type fields struct {
-		awsConfig        *config.AWSConfig
-		awsClientFactory aws.ClientFactory
+		dnsProviderFactory DNSProviderFactory
 	}
 
-	CNAME_Id := "CNAME_Id"
-	CNAME_Status := "CNAME_Status"
-
-	awsConfig := &config.AWSConfig{}
-	awsConfig.Route53.AccessKey = "Route53AccessKey"
-	awsConfig.Route53.SecretAccessKey = "Route53SecretAccessKey"
+	// the change is looked up with the provider of the kind which qualifies its id
+	providerFactory := func(provider dns.Provider) DNSProviderFactory {
+		return &DNSProviderFactoryMock{
+			GetProviderByKindFunc: func(kind string, cloudProvider string) (dns.Provider, *errors.ServiceError) {
+				if kind != provider.Kind() {
+					return nil, errors.GeneralError("unexpected DNS provider %q", kind)
+				}
+				return provider, nil
+			},
+		}
+	}
+	inSyncProvider := func(kind string) dns.Provider {
+		return &dns.ProviderMock{
+			KindFunc: func() string {
+				return kind
+			},
+			GetChangeFunc: func(changeID string) (*dns.Change, error) {
+				return &dns.Change{ID: changeID, Status: dns.ChangeStatusInSync}, nil
+			},
+		}
+	}
 
 	type args struct {
 		kafkaRequest *dbapi.KafkaRequest
@@ -4340,49 +4907,74 @@ func Test_kafkaService_GetCNAMERecordStatus(t *testing.T) {
 		name    string
 		fields  fields
 		args    args
-		want    *CNameRecordStatus
+		want    *dns.Change
 		wantErr bool
 	}{
 		{
 			name: "should get the CNAME record Status",
 			fields: fields{
-				awsConfig: awsConfig,
-				awsClientFactory: aws.NewMockClientFactory(&aws.AWSClientMock{
-					GetChangeFunc: func(changeId string) (*route53.GetChangeOutput, error) {
-						return &route53.GetChangeOutput{
-							ChangeInfo: &route53.ChangeInfo{
-								Id:     &CNAME_Id,
-								Status: &CNAME_Status,
-							},
-						}, nil
-					},
-				}),
+				dnsProviderFactory: providerFactory(inSyncProvider(dns.KindCloudDNS)),
 			},
 			args: args{
 				kafkaRequest: &dbapi.KafkaRequest{
-					Region:        "us-east-1",
-					CloudProvider: cloudproviders.AWS.String(),
+					Region:           "us-east-1",
+					CloudProvider:    cloudproviders.GCP.String(),
+					RoutesCreationId: "clouddns:zone/CNAME_Id",
+				},
+			},
+			want: &dns.Change{
+				ID:     "clouddns:zone/CNAME_Id",
+				Status: dns.ChangeStatusInSync,
+			},
+			wantErr: false,
+		},
+		{
+			name: "should get the CNAME record Status of a change made before the DNS providers were pluggable with Route53",
+			fields: fields{
+				dnsProviderFactory: providerFactory(inSyncProvider(dns.KindRoute53)),
+			},
+			args: args{
+				kafkaRequest: &dbapi.KafkaRequest{
+					Region:           "us-east-1",
+					CloudProvider:    cloudproviders.AWS.String(),
+					RoutesCreationId: "CNAME_Id",
 				},
 			},
-			want: &CNameRecordStatus{
-				Id:     &CNAME_Id,
-				Status: &CNAME_Status,
+			want: &dns.Change{
+				ID:     "CNAME_Id",
+				Status: dns.ChangeStatusInSync,
 			},
 			wantErr: false,
 		},
 		{
 			name: "should return error when it fails to get CNAME status",
 			fields: fields{
-				awsConfig: awsConfig,
-				awsClientFactory: aws.NewMockClientFactory(&aws.AWSClientMock{
-					GetChangeFunc: func(changeId string) (*route53.GetChangeOutput, error) {
+				dnsProviderFactory: providerFactory(&dns.ProviderMock{
+					KindFunc: func() string {
+						return dns.KindRoute53
+					},
+					GetChangeFunc: func(changeID string) (*dns.Change, error) {
 						return nil, errors.GeneralError("unable to CNAME record status")
 					},
 				}),
 			},
 			args: args{
 				kafkaRequest: &dbapi.KafkaRequest{
-					Region: "us-east-1",
+					Region:           "us-east-1",
+					RoutesCreationId: "route53:CNAME_Id",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return error when it fails to get the DNS provider",
+			fields: fields{
+				dnsProviderFactory: providerFactory(inSyncProvider(dns.KindRoute53)),
+			},
+			args: args{
+				kafkaRequest: &dbapi.KafkaRequest{
+					Region:           "us-east-1",
+					RoutesCreationId: "rfc2136:CNAME_Id",
 				},
 			},
 			wantErr: true,
@@ -4394,8 +4986,7 @@ func Test_kafkaService_GetCNAMERecordStatus(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
 			k := &kafkaService{
-				awsConfig:        tt.fields.awsConfig,
-				awsClientFactory: tt.fields.awsClientFactory,
+				dnsProviderFactory: tt.fields.dnsProviderFactory,
 			}
 			got, err := k.GetCNAMERecordStatus(tt.args.kafkaRequest)
 			g.Expect(got).To(gomega.Equal(tt.want))
@@ -4411,13 +5002,16 @@ func Test_NewKafkaService(t *testing.T) {
 		keycloakService                      sso.KafkaKeycloakService
 		kafkaConfig                          *config.KafkaConfig
 		dataplaneClusterConfig               *config.DataplaneClusterConfig
-		awsConfig                            *config.AWSConfig
 		quotaServiceFactory                  QuotaServiceFactory
-		awsClientFactory                     aws.ClientFactory
+		dnsProviderFactory                   DNSProviderFactory
 		authorizationService                 authorization.Authorization
 		providerConfig                       *config.ProviderConfig
 		clusterPlacementStrategy             ClusterPlacementStrategy
 		kafkaTLSCertificateManagementService kafkatlscertmgmt.KafkaTLSCertificateManagementService
+		kafkaTLSCertificateInventoryService  KafkaTLSCertificateInventoryService
+		canaryServiceAccountService          CanaryServiceAccountService
+		kafkaEvents                          KafkaEventService
+		maintenanceWindowService             MaintenanceWindowService
 	}
 	tests := []struct {
 		name string
@@ -4432,12 +5026,15 @@ func Test_NewKafkaService(t *testing.T) {
 				keycloakService:                      &sso.KeycloakServiceMock{},
 				kafkaConfig:                          &config.KafkaConfig{},
 				dataplaneClusterConfig:               &config.DataplaneClusterConfig{},
-				awsConfig:                            &config.AWSConfig{},
 				quotaServiceFactory:                  &QuotaServiceFactoryMock{},
-				awsClientFactory:                     &aws.MockClientFactory{},
+				dnsProviderFactory:                   &DNSProviderFactoryMock{},
 				providerConfig:                       &config.ProviderConfig{},
 				clusterPlacementStrategy:             &ClusterPlacementStrategyMock{},
 				kafkaTLSCertificateManagementService: &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{},
+				kafkaTLSCertificateInventoryService:  &KafkaTLSCertificateInventoryServiceMock{},
+				canaryServiceAccountService:          &CanaryServiceAccountServiceMock{},
+				kafkaEvents:                          &KafkaEventServiceMock{},
+				maintenanceWindowService:             &MaintenanceWindowServiceMock{},
 			},
 			want: &kafkaService{
 				connectionFactory:                    &db.ConnectionFactory{},
@@ -4445,12 +5042,15 @@ func Test_NewKafkaService(t *testing.T) {
 				keycloakService:                      &sso.KeycloakServiceMock{},
 				kafkaConfig:                          &config.KafkaConfig{},
 				dataplaneClusterConfig:               &config.DataplaneClusterConfig{},
-				awsConfig:                            &config.AWSConfig{},
 				quotaServiceFactory:                  &QuotaServiceFactoryMock{},
-				awsClientFactory:                     &aws.MockClientFactory{},
+				dnsProviderFactory:                   &DNSProviderFactoryMock{},
 				providerConfig:                       &config.ProviderConfig{},
 				clusterPlacementStrategy:             &ClusterPlacementStrategyMock{},
 				kafkaTLSCertificateManagementService: &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{},
+				kafkaTLSCertificateInventoryService:  &KafkaTLSCertificateInventoryServiceMock{},
+				canaryServiceAccountService:          &CanaryServiceAccountServiceMock{},
+				kafkaEvents:                          &KafkaEventServiceMock{},
+				maintenanceWindowService:             &MaintenanceWindowServiceMock{},
 			},
 		},
 	}
@@ -4464,13 +5064,16 @@ func Test_NewKafkaService(t *testing.T) {
 			tt.args.keycloakService,
 			tt.args.kafkaConfig,
 			tt.args.dataplaneClusterConfig,
-			tt.args.awsConfig,
 			tt.args.quotaServiceFactory,
-			tt.args.awsClientFactory,
+			tt.args.dnsProviderFactory,
 			tt.args.authorizationService,
 			tt.args.providerConfig,
 			tt.args.clusterPlacementStrategy,
-			tt.args.kafkaTLSCertificateManagementService)).To(gomega.Equal(tt.want))
+			tt.args.kafkaTLSCertificateManagementService,
+			tt.args.kafkaTLSCertificateInventoryService,
+			tt.args.canaryServiceAccountService,
+			tt.args.kafkaEvents,
+			tt.args.maintenanceWindowService)).To(gomega.Equal(tt.want))
 	}
 }
 
@@ -4514,6 +5117,58 @@ func Test_kafkaService_ListKafkasWithRoutesNotCreated(t *testing.T) {
 	}
 }
 
+func Test_kafkaService_ListDeletedKafkasWithRoutes(t *testing.T) {
+	type fields struct {
+		connectionFactory *db.ConnectionFactory
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		want    []*dbapi.KafkaRequest
+		wantErr bool
+		setupFn func()
+	}{
+		{
+			name: "should return the deleted kafkas whose CNAME records were created",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			want: []*dbapi.KafkaRequest{buildKafkaRequest(nil)},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().
+					WithQuery(`SELECT "id","cluster_id","cloud_provider","routes","deleted_at" FROM "kafka_requests" WHERE deleted_at > $1 AND routes IS NOT NULL AND routes_created = $2`).
+					WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(nil)))
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+		},
+		{
+			name: "should return an error when the deleted kafkas cannot be listed",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			wantErr: true,
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQueryException()
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		tt.setupFn()
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			k := &kafkaService{
+				connectionFactory: tt.fields.connectionFactory,
+			}
+			got, err := k.ListDeletedKafkasWithRoutes(time.Now().Add(-time.Hour))
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
 func Test_kafkaService_AssignBootstrapServerHost(t *testing.T) {
 	type fields struct {
 		clusterService ClusterService
@@ -4612,70 +5267,13 @@ func Test_kafkaService_AssignBootstrapServerHost(t *testing.T) {
 	}
 }
 
-func Test_kafkaService_getRoute53RegionFromKafkaRequest(t *testing.T) {
-
-	type args struct {
-		kafkaRequest *dbapi.KafkaRequest
-	}
-
-	tests := []struct {
-		name    string
-		args    args
-		want    string
-		wantErr bool
-	}{
-		{
-			name: "Route53 region is correctly returned for Kafka instances in AWS",
-			args: args{
-				kafkaRequest: &dbapi.KafkaRequest{
-					Region:        "anotherregion",
-					CloudProvider: cloudproviders.AWS.String(),
-				},
-			},
-			want:    aws.DefaultAWSRoute53Region,
-			wantErr: false,
-		},
-		{
-			name: "Route53 region is correctly returned for Kafka instances in GCP",
-			args: args{
-				kafkaRequest: &dbapi.KafkaRequest{
-					Region:        "anotherregiontwo",
-					CloudProvider: cloudproviders.GCP.String(),
-				},
-			},
-			want:    aws.DefaultGCPRoute53Region,
-			wantErr: false,
-		},
-		{
-			name: "An error is returned if the Kafka instance has an unknown cloud provider",
-			args: args{
-				kafkaRequest: &dbapi.KafkaRequest{
-					Region:        "us-east-1",
-					CloudProvider: "anunknowncloudprovider",
-				},
-			},
-			wantErr: true,
-		},
-	}
-
-	for _, testcase := range tests {
-		tt := testcase
-		t.Run(tt.name, func(t *testing.T) {
-			g := gomega.NewWithT(t)
-			k := &kafkaService{}
-			res, err := k.getRoute53RegionFromKafkaRequest(tt.args.kafkaRequest)
-			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
-			g.Expect(res).To(gomega.Equal(tt.want))
-		})
-	}
-}
-
 func Test_kafkaService_ManagedKafkasRoutesTLSCertificate(t *testing.T) {
 	g := gomega.NewWithT(t)
 	type fields struct {
 		kafkaConfig                          *config.KafkaConfig
 		connectionFactory                    *db.ConnectionFactory
 		kafkaTLSCertificateManagementService kafkatlscertmgmt.KafkaTLSCertificateManagementService
+		kafkaTLSCertificateInventoryService  KafkaTLSCertificateInventoryService
 	}
 	type args struct {
 		kafkaRequest *dbapi.KafkaRequest
@@ -4703,6 +5301,9 @@ func Test_kafkaService_ManagedKafkasRoutesTLSCertificate(t *testing.T) {
 						return kafkatlscertmgmt.CertificateManagementOutput{}, nil
 					},
 				},
+				kafkaTLSCertificateInventoryService: &KafkaTLSCertificateInventoryServiceMock{
+					RecordDomainRenewalFunc: nil, // should never be called
+				},
 			},
 			args: args{
 				kafkaRequest: &dbapi.KafkaRequest{
@@ -4734,6 +5335,12 @@ func Test_kafkaService_ManagedKafkasRoutesTLSCertificate(t *testing.T) {
 						return kafkatlscertmgmt.CertificateManagementOutput{}, nil
 					},
 				},
+				kafkaTLSCertificateInventoryService: &KafkaTLSCertificateInventoryServiceMock{
+					RecordDomainRenewalFunc: func(domain string, renewalErr error) *errors.ServiceError {
+						g.Expect(renewalErr).ToNot(gomega.HaveOccurred())
+						return nil
+					},
+				},
 			},
 			args: args{
 				kafkaRequest: &dbapi.KafkaRequest{
@@ -4766,6 +5373,12 @@ func Test_kafkaService_ManagedKafkasRoutesTLSCertificate(t *testing.T) {
 						return kafkatlscertmgmt.CertificateManagementOutput{}, nil
 					},
 				},
+				kafkaTLSCertificateInventoryService: &KafkaTLSCertificateInventoryServiceMock{
+					RecordDomainRenewalFunc: func(domain string, renewalErr error) *errors.ServiceError {
+						g.Expect(renewalErr).ToNot(gomega.HaveOccurred())
+						return nil
+					},
+				},
 			},
 			args: args{
 				kafkaRequest: &dbapi.KafkaRequest{
@@ -4799,6 +5412,12 @@ func Test_kafkaService_ManagedKafkasRoutesTLSCertificate(t *testing.T) {
 						return kafkatlscertmgmt.CertificateManagementOutput{}, nil
 					},
 				},
+				kafkaTLSCertificateInventoryService: &KafkaTLSCertificateInventoryServiceMock{
+					RecordDomainRenewalFunc: func(domain string, renewalErr error) *errors.ServiceError {
+						g.Expect(renewalErr).ToNot(gomega.HaveOccurred())
+						return nil
+					},
+				},
 			},
 			args: args{
 				kafkaRequest: &dbapi.KafkaRequest{
@@ -4831,6 +5450,13 @@ func Test_kafkaService_ManagedKafkasRoutesTLSCertificate(t *testing.T) {
 						return kafkatlscertmgmt.CertificateManagementOutput{}, fmt.Errorf("some error")
 					},
 				},
+				kafkaTLSCertificateInventoryService: &KafkaTLSCertificateInventoryServiceMock{
+					RecordDomainRenewalFunc: func(domain string, renewalErr error) *errors.ServiceError {
+						g.Expect(domain).To(gomega.Equal("456.some-kafka-domain.bf2.dev"))
+						g.Expect(renewalErr).To(gomega.MatchError("some error"))
+						return nil
+					},
+				},
 			},
 			args: args{
 				kafkaRequest: &dbapi.KafkaRequest{
@@ -4857,9 +5483,579 @@ func Test_kafkaService_ManagedKafkasRoutesTLSCertificate(t *testing.T) {
 				kafkaConfig:                          testcase.fields.kafkaConfig,
 				connectionFactory:                    testcase.fields.connectionFactory,
 				kafkaTLSCertificateManagementService: testcase.fields.kafkaTLSCertificateManagementService,
+				kafkaTLSCertificateInventoryService:  testcase.fields.kafkaTLSCertificateInventoryService,
 			}
 			err := k.ManagedKafkasRoutesTLSCertificate(testcase.args.kafkaRequest)
 			g.Expect(err != nil).To(gomega.Equal(testcase.wantErr))
 		})
 	}
 }
+
+func Test_kafkaService_Suspend(t *testing.T) {
+	type fields struct {
+		connectionFactory *db.ConnectionFactory
+		quotaService      QuotaService
+	}
+	type args struct {
+		ctx          context.Context
+		kafkaRequest *dbapi.KafkaRequest
+	}
+
+	authHelper, err := auth.NewAuthHelper(JwtKeyFile, JwtCAFile, "")
+	if err != nil {
+		t.Fatalf("failed to create auth helper: %s", err.Error())
+	}
+	account, err := authHelper.NewAccount(testUser, "", "", "")
+	if err != nil {
+		t.Fatal("failed to build a new account")
+	}
+	jwt, err := authHelper.CreateJWTWithClaims(account, nil)
+	if err != nil {
+		t.Fatalf("failed to create jwt: %s", err.Error())
+	}
+	authenticatedCtx := auth.SetTokenInContext(context.TODO(), jwt)
+
+	tests := []struct {
+		name               string
+		fields             fields
+		args               args
+		wantErr            *errors.ServiceError
+		wantDeletedQuota   bool
+		wantSubscriptionId string
+		wantResizeStatus   dbapi.KafkaResizeStatus
+		setupFn            func()
+	}{
+		{
+			name: "should return an error if the user is not authenticated",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			args: args{
+				ctx: context.TODO(),
+				kafkaRequest: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.Status = constants.KafkaRequestStatusReady.String()
+				}),
+			},
+			wantErr: errors.Unauthenticated("user not authenticated"),
+		},
+		{
+			name: "should return an error if the kafka is not in a suspendable status",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			args: args{
+				ctx: authenticatedCtx,
+				kafkaRequest: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.Status = constants.KafkaRequestStatusProvisioning.String()
+				}),
+			},
+			wantErr: errors.New(errors.ErrorValidation, "kafka instance with a status of %q cannot be suspended. Kafka instances can only be suspended in the following states: %s", constants.KafkaRequestStatusProvisioning.String(), []string{constants.KafkaRequestStatusReady.String()}),
+		},
+		{
+			name: "should return a conflict if the kafka is being migrated",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			args: args{
+				ctx: authenticatedCtx,
+				kafkaRequest: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.Status = constants.KafkaRequestStatusReady.String()
+					kafkaRequest.MigrationTargetClusterId = "target-cluster-id"
+					kafkaRequest.MigrationSourceClusterId = kafkaRequest.ClusterID
+				}),
+			},
+			wantErr: errors.Conflict("kafka instance %q cannot be suspended while it is being migrated to another data plane cluster", testID),
+		},
+		{
+			name: "should return an error if the kafka status changed while suspending it",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			args: args{
+				ctx: authenticatedCtx,
+				kafkaRequest: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.Status = constants.KafkaRequestStatusReady.String()
+				}),
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET`).WithRowsNum(0)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			wantErr: errors.New(errors.ErrorConflict, "kafka instance %q status has changed, please retry the request", testID),
+		},
+		{
+			name: "should return an error if the update fails",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			args: args{
+				ctx: authenticatedCtx,
+				kafkaRequest: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.Status = constants.KafkaRequestStatusReady.String()
+				}),
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET`).WithExecException()
+			},
+			wantErr: errors.GeneralError("failed to update kafka"),
+		},
+		{
+			name: "should mark the kafka as suspending and record who suspended it",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+			},
+			args: args{
+				ctx: authenticatedCtx,
+				kafkaRequest: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.Status = constants.KafkaRequestStatusReady.String()
+				}),
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET`).WithRowsNum(1)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			wantErr: nil,
+		},
+		{
+			name: "should release the quota of the suspended kafka",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				quotaService: &QuotaServiceMock{
+					DeleteQuotaFunc: func(subscriptionId string) *errors.ServiceError {
+						return nil
+					},
+				},
+			},
+			args: args{
+				ctx: authenticatedCtx,
+				kafkaRequest: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.Status = constants.KafkaRequestStatusReady.String()
+					kafkaRequest.SubscriptionId = "subscription-id"
+				}),
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET`).WithRowsNum(1)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			wantDeletedQuota: true,
+		},
+		{
+			name: "should keep the subscription of the suspended kafka if its quota cannot be released",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				quotaService: &QuotaServiceMock{
+					DeleteQuotaFunc: func(subscriptionId string) *errors.ServiceError {
+						return errors.GeneralError("failed to delete the quota")
+					},
+				},
+			},
+			args: args{
+				ctx: authenticatedCtx,
+				kafkaRequest: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.Status = constants.KafkaRequestStatusReady.String()
+					kafkaRequest.SubscriptionId = "subscription-id"
+				}),
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET`).WithRowsNum(1)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			wantDeletedQuota:   true,
+			wantSubscriptionId: "subscription-id",
+		},
+		{
+			name: "should abort the resize in progress of the suspended kafka",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				quotaService: &QuotaServiceMock{
+					DeleteQuotaFunc: func(subscriptionId string) *errors.ServiceError {
+						return nil
+					},
+				},
+			},
+			args: args{
+				ctx: authenticatedCtx,
+				kafkaRequest: buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.Status = constants.KafkaRequestStatusReady.String()
+					kafkaRequest.DesiredSizeId = "x2"
+					kafkaRequest.ResizeStatus = dbapi.KafkaResizeStatusResizing
+					kafkaRequest.ResizeSubscriptionId = "resize-subscription-id"
+				}),
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET`).WithRowsNum(1)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			wantDeletedQuota: true,
+			wantResizeStatus: dbapi.KafkaResizeStatusFailed,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			if tt.setupFn != nil {
+				tt.setupFn()
+			}
+			quotaService := tt.fields.quotaService
+			if quotaService == nil {
+				quotaService = &QuotaServiceMock{}
+			}
+			kafkaEvents := buildKafkaEventService()
+			k := &kafkaService{
+				kafkaEvents:       kafkaEvents,
+				connectionFactory: tt.fields.connectionFactory,
+				kafkaConfig:       &defaultKafkaConf,
+				quotaServiceFactory: &QuotaServiceFactoryMock{
+					GetQuotaServiceFunc: func(quotaType api.QuotaType) (QuotaService, *errors.ServiceError) {
+						return quotaService, nil
+					},
+				},
+			}
+
+			err := k.Suspend(tt.args.ctx, tt.args.kafkaRequest)
+			if tt.wantErr != nil {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErr.Code))
+				g.Expect(err.Reason).To(gomega.Equal(tt.wantErr.Reason))
+				return
+			}
+
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(tt.args.kafkaRequest.Status).To(gomega.Equal(constants.KafkaRequestStatusSuspending.String()))
+			g.Expect(tt.args.kafkaRequest.SuspendedBy).To(gomega.Equal(testUser))
+			g.Expect(tt.args.kafkaRequest.SuspendedAt.Valid).To(gomega.BeTrue())
+			g.Expect(tt.args.kafkaRequest.IsSuspendedByUser()).To(gomega.BeTrue())
+
+			wantEventTypes := []dbapi.KafkaEventType{dbapi.KafkaEventTypeSuspendRequested}
+			if tt.wantResizeStatus == dbapi.KafkaResizeStatusFailed {
+				wantEventTypes = append(wantEventTypes, dbapi.KafkaEventTypeResizeFailed)
+			}
+			var eventTypes []dbapi.KafkaEventType
+			for _, call := range kafkaEvents.RecordCalls() {
+				eventTypes = append(eventTypes, call.Event.Type)
+			}
+			g.Expect(eventTypes).To(gomega.Equal(wantEventTypes))
+			if mock, ok := quotaService.(*QuotaServiceMock); ok {
+				g.Expect(len(mock.DeleteQuotaCalls()) > 0).To(gomega.Equal(tt.wantDeletedQuota))
+			}
+			g.Expect(tt.args.kafkaRequest.SubscriptionId).To(gomega.Equal(tt.wantSubscriptionId))
+			g.Expect(tt.args.kafkaRequest.ResizeStatus).To(gomega.Equal(tt.wantResizeStatus))
+		})
+	}
+}
+
+func Test_kafkaService_AbortResize(t *testing.T) {
+	resizingKafka := func(resizeSubscriptionId string) *dbapi.KafkaRequest {
+		return buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.Status = constants.KafkaRequestStatusSuspended.String()
+			kafkaRequest.SubscriptionId = "subscription-id"
+			kafkaRequest.DesiredSizeId = "x2"
+			kafkaRequest.ResizeStatus = dbapi.KafkaResizeStatusResizing
+			kafkaRequest.ResizeSubscriptionId = resizeSubscriptionId
+		})
+	}
+
+	tests := []struct {
+		name                     string
+		kafkaRequest             *dbapi.KafkaRequest
+		deleteQuotaErr           *errors.ServiceError
+		setupFn                  func()
+		wantErr                  bool
+		wantDeletedQuota         []string
+		wantResizeStatus         dbapi.KafkaResizeStatus
+		wantResizeSubscriptionId string
+	}{
+		{
+			name:             "should release the quota reserved for the resize and mark the resize as failed",
+			kafkaRequest:     resizingKafka("resize-subscription-id"),
+			setupFn:          func() { mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET`).WithRowsNum(1) },
+			wantDeletedQuota: []string{"resize-subscription-id"},
+			wantResizeStatus: dbapi.KafkaResizeStatusFailed,
+		},
+		{
+			name:             "should mark the resize as failed when no quota has been reserved for it",
+			kafkaRequest:     resizingKafka(""),
+			setupFn:          func() { mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET`).WithRowsNum(1) },
+			wantResizeStatus: dbapi.KafkaResizeStatusFailed,
+		},
+		{
+			name:             "should not release the quota of the kafka when the quota of the resize is reserved in the same subscription",
+			kafkaRequest:     resizingKafka("subscription-id"),
+			setupFn:          func() { mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET`).WithRowsNum(1) },
+			wantResizeStatus: dbapi.KafkaResizeStatusFailed,
+		},
+		{
+			name:                     "should return an error and keep the resize in progress when the quota cannot be released",
+			kafkaRequest:             resizingKafka("resize-subscription-id"),
+			deleteQuotaErr:           errors.GeneralError("failed to delete the quota"),
+			setupFn:                  func() { mocket.Catcher.Reset() },
+			wantErr:                  true,
+			wantDeletedQuota:         []string{"resize-subscription-id"},
+			wantResizeStatus:         dbapi.KafkaResizeStatusResizing,
+			wantResizeSubscriptionId: "resize-subscription-id",
+		},
+		{
+			name:                     "should return an error when the resize state cannot be cleared",
+			kafkaRequest:             resizingKafka(""),
+			setupFn:                  func() { mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET`).WithExecException() },
+			wantErr:                  true,
+			wantResizeStatus:         dbapi.KafkaResizeStatusResizing,
+			wantResizeSubscriptionId: "",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			quotaService := &QuotaServiceMock{
+				DeleteQuotaFunc: func(subscriptionId string) *errors.ServiceError {
+					return tt.deleteQuotaErr
+				},
+			}
+			kafkaEvents := buildKafkaEventService()
+			k := &kafkaService{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				kafkaConfig:       &defaultKafkaConf,
+				kafkaEvents:       kafkaEvents,
+				quotaServiceFactory: &QuotaServiceFactoryMock{
+					GetQuotaServiceFunc: func(quotaType api.QuotaType) (QuotaService, *errors.ServiceError) {
+						return quotaService, nil
+					},
+				},
+			}
+
+			err := k.AbortResize(tt.kafkaRequest, "aborted")
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+
+			var deletedQuota []string
+			for _, call := range quotaService.DeleteQuotaCalls() {
+				deletedQuota = append(deletedQuota, call.SubscriptionId)
+			}
+			g.Expect(deletedQuota).To(gomega.Equal(tt.wantDeletedQuota))
+			g.Expect(tt.kafkaRequest.ResizeStatus).To(gomega.Equal(tt.wantResizeStatus))
+			g.Expect(tt.kafkaRequest.ResizeSubscriptionId).To(gomega.Equal(tt.wantResizeSubscriptionId))
+			if !tt.wantErr {
+				g.Expect(tt.kafkaRequest.DesiredSizeId).To(gomega.BeEmpty())
+				g.Expect(tt.kafkaRequest.ResizeDetails).To(gomega.Equal("aborted"))
+				g.Expect(kafkaEvents.RecordCalls()).To(gomega.HaveLen(1))
+				g.Expect(kafkaEvents.RecordCalls()[0].Event.Type).To(gomega.Equal(dbapi.KafkaEventTypeResizeFailed))
+				g.Expect(kafkaEvents.RecordCalls()[0].Event.Details).To(gomega.Equal("aborted"))
+			} else {
+				g.Expect(kafkaEvents.RecordCalls()).To(gomega.BeEmpty())
+			}
+		})
+	}
+}
+
+func Test_kafkaService_Resume(t *testing.T) {
+	type fields struct {
+		connectionFactory *db.ConnectionFactory
+		quotaService      QuotaService
+	}
+	type args struct {
+		kafkaRequest *dbapi.KafkaRequest
+	}
+
+	suspendedKafka := func(modifyFn func(kafkaRequest *dbapi.KafkaRequest)) *dbapi.KafkaRequest {
+		return buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.Status = constants.KafkaRequestStatusSuspended.String()
+			kafkaRequest.InstanceType = types.STANDARD.String()
+			kafkaRequest.ActualKafkaBillingModel = "standard"
+			kafkaRequest.DesiredKafkaBillingModel = "standard"
+			kafkaRequest.SuspendedBy = testUser
+			kafkaRequest.SuspendedAt = sql.NullTime{Time: time.Now(), Valid: true}
+			if modifyFn != nil {
+				modifyFn(kafkaRequest)
+			}
+		})
+	}
+
+	quotaServiceReserving := func() *QuotaServiceMock {
+		return &QuotaServiceMock{
+			ReserveQuotaIfNotAlreadyReservedFunc: func(kafka *dbapi.KafkaRequest) (string, *errors.ServiceError) {
+				return "subscription-id", nil
+			},
+			DeleteQuotaFunc: func(subscriptionId string) *errors.ServiceError {
+				return nil
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr *errors.ServiceError
+		// wantDeletedQuota is the subscription of the quota released when the resume fails
+		wantDeletedQuota string
+		setupFn          func()
+	}{
+		{
+			name: "should return an error if the kafka is not suspended",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				quotaService:      quotaServiceReserving(),
+			},
+			args: args{
+				kafkaRequest: suspendedKafka(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.Status = constants.KafkaRequestStatusSuspending.String()
+				}),
+			},
+			wantErr: errors.New(errors.ErrorValidation, "kafka instance with a status of %q cannot be resumed. Kafka instances can only be resumed in the following states: %s", constants.KafkaRequestStatusSuspending.String(), []string{constants.KafkaRequestStatusSuspended.String()}),
+		},
+		{
+			name: "should return an error if the kafka has not been suspended by a user",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				quotaService:      quotaServiceReserving(),
+			},
+			args: args{
+				kafkaRequest: suspendedKafka(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.SuspendedBy = ""
+				}),
+			},
+			wantErr: errors.New(errors.ErrorValidation, "kafka instance %q has not been suspended by a user and cannot be resumed", testID),
+		},
+		{
+			name: "should return an error if the kafka is within its grace period",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				quotaService:      quotaServiceReserving(),
+			},
+			args: args{
+				kafkaRequest: suspendedKafka(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.ExpiresAt = sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true}
+				}),
+			},
+			wantErr: &errors.ServiceError{Code: errors.ErrorValidation},
+		},
+		{
+			name: "should return an error if quota cannot be reserved",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				quotaService: &QuotaServiceMock{
+					ReserveQuotaIfNotAlreadyReservedFunc: func(kafka *dbapi.KafkaRequest) (string, *errors.ServiceError) {
+						return "", errors.InsufficientQuotaError("insufficient quota")
+					},
+				},
+			},
+			args: args{
+				kafkaRequest: suspendedKafka(nil),
+			},
+			wantErr: errors.InsufficientQuotaError("insufficient quota"),
+		},
+		{
+			name: "should return an error if the kafka status changed while resuming it",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				quotaService:      quotaServiceReserving(),
+			},
+			args: args{
+				kafkaRequest: suspendedKafka(nil),
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET`).WithRowsNum(0)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			wantErr:          errors.New(errors.ErrorConflict, "kafka instance %q status has changed, please retry the request", testID),
+			wantDeletedQuota: "subscription-id",
+		},
+		{
+			name: "should release the quota reserved to resume the kafka when it cannot be updated",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				quotaService:      quotaServiceReserving(),
+			},
+			args: args{
+				kafkaRequest: suspendedKafka(nil),
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET`).WithExecException()
+			},
+			wantErr:          &errors.ServiceError{Code: errors.ErrorGeneral},
+			wantDeletedQuota: "subscription-id",
+		},
+		{
+			name: "should keep the subscription still stored with the kafka when it cannot be updated",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				quotaService:      quotaServiceReserving(),
+			},
+			args: args{
+				kafkaRequest: suspendedKafka(func(kafkaRequest *dbapi.KafkaRequest) {
+					kafkaRequest.SubscriptionId = "subscription-id"
+				}),
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET`).WithRowsNum(0)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			wantErr: errors.New(errors.ErrorConflict, "kafka instance %q status has changed, please retry the request", testID),
+		},
+		{
+			name: "should mark the kafka as resuming and clear the suspension info",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				quotaService:      quotaServiceReserving(),
+			},
+			args: args{
+				kafkaRequest: suspendedKafka(nil),
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "kafka_requests" SET`).WithRowsNum(1)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			if tt.setupFn != nil {
+				tt.setupFn()
+			}
+			kafkaEvents := buildKafkaEventService()
+			k := &kafkaService{
+				kafkaEvents:       kafkaEvents,
+				connectionFactory: tt.fields.connectionFactory,
+				kafkaConfig:       &defaultKafkaConf,
+				quotaServiceFactory: &QuotaServiceFactoryMock{
+					GetQuotaServiceFunc: func(quotaType api.QuotaType) (QuotaService, *errors.ServiceError) {
+						return tt.fields.quotaService, nil
+					},
+				},
+			}
+
+			err := k.Resume(context.TODO(), tt.args.kafkaRequest)
+			var deletedQuota string
+			if quotaService, ok := tt.fields.quotaService.(*QuotaServiceMock); ok && quotaService.DeleteQuotaFunc != nil {
+				for _, call := range quotaService.DeleteQuotaCalls() {
+					deletedQuota = call.SubscriptionId
+				}
+			}
+			g.Expect(deletedQuota).To(gomega.Equal(tt.wantDeletedQuota))
+			if tt.wantErr != nil {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErr.Code))
+				if tt.wantErr.Reason != "" {
+					g.Expect(err.Reason).To(gomega.Equal(tt.wantErr.Reason))
+				}
+				return
+			}
+
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(tt.args.kafkaRequest.Status).To(gomega.Equal(constants.KafkaRequestStatusResuming.String()))
+			g.Expect(tt.args.kafkaRequest.SuspendedBy).To(gomega.BeEmpty())
+			g.Expect(tt.args.kafkaRequest.SuspendedAt.Valid).To(gomega.BeFalse())
+			g.Expect(tt.args.kafkaRequest.SubscriptionId).To(gomega.Equal("subscription-id"))
+			g.Expect(kafkaEvents.RecordCalls()).To(gomega.HaveLen(1))
+			g.Expect(kafkaEvents.RecordCalls()[0].Event.Type).To(gomega.Equal(dbapi.KafkaEventTypeResumeRequested))
+		})
+	}
+}
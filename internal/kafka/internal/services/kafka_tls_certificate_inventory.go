@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services/kafkatlscertmgmt"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/queryparser"
+)
+
+// ValidKafkaTLSCertificateColumns are the columns of the certificate inventory that can be used in the search query
+var ValidKafkaTLSCertificateColumns = []string{"domain", "subject", "issuer", "serial_number", "last_renewal_error"}
+
+// AcceptedKafkaTLSCertificateOrderByParams are the accepted values of the orderBy parameter when listing the certificate
+// inventory
+var AcceptedKafkaTLSCertificateOrderByParams = []string{"domain", "not_before", "not_after", "last_renewal_attempt_at", "updated_at"}
+
+// KafkaTLSCertificateInventoryService keeps the inventory of the wildcard tls certificates automatically managed for
+// the base domains of the kafkas
+//
+//go:generate moq -out kafka_tls_certificate_inventory_moq.go . KafkaTLSCertificateInventoryService
+type KafkaTLSCertificateInventoryService interface {
+	// Refresh reads the certificates of the base domains of the kafkas from the certificate storage and updates the
+	// inventory accordingly. The entries of the domains no longer used by any kafka are removed. It returns the
+	// refreshed inventory along with the errors encountered reading the certificates.
+	Refresh() (dbapi.KafkaTLSCertificateList, []error)
+	// RecordRenewal records a renewal attempt of the certificate with its error, if any. The certificate is read again
+	// from the certificate storage when the renewal succeeded.
+	RecordRenewal(certificate *dbapi.KafkaTLSCertificate, renewalErr error) *errors.ServiceError
+	// RecordDomainRenewal records the outcome of the on-demand management of the certificate of the given base domain,
+	// which renews the certificate when it is due. As the certificates are managed on every reconciliation of the
+	// kafkas, the outcome is only recorded when it differs from the error of the previous attempt, i.e. when the
+	// management starts failing, fails with a different error or succeeds again. Nothing is recorded when the domain
+	// is not in the inventory yet: it is added by the next refresh.
+	RecordDomainRenewal(domain string, renewalErr error) *errors.ServiceError
+	// List returns the inventory, the certificates expiring first are returned first unless a different order is
+	// requested
+	List(listArgs *coreServices.ListArguments) (dbapi.KafkaTLSCertificateList, *api.PagingMeta, *errors.ServiceError)
+}
+
+var _ KafkaTLSCertificateInventoryService = &kafkaTLSCertificateInventoryService{}
+
+type kafkaTLSCertificateInventoryService struct {
+	connectionFactory                    *db.ConnectionFactory
+	kafkaTLSCertificateManagementService kafkatlscertmgmt.KafkaTLSCertificateManagementService
+}
+
+func NewKafkaTLSCertificateInventoryService(connectionFactory *db.ConnectionFactory, kafkaTLSCertificateManagementService kafkatlscertmgmt.KafkaTLSCertificateManagementService) KafkaTLSCertificateInventoryService {
+	return &kafkaTLSCertificateInventoryService{
+		connectionFactory:                    connectionFactory,
+		kafkaTLSCertificateManagementService: kafkaTLSCertificateManagementService,
+	}
+}
+
+func (s *kafkaTLSCertificateInventoryService) Refresh() (dbapi.KafkaTLSCertificateList, []error) {
+	type domainCertificateRefs struct {
+		KafkasRoutesBaseDomainName      string
+		KafkasRoutesBaseDomainTLSCrtRef string
+		KafkasRoutesBaseDomainTLSKeyRef string
+	}
+
+	var refs []domainCertificateRefs
+	dbConn := s.connectionFactory.New()
+	err := dbConn.Model(&dbapi.KafkaRequest{}).
+		Distinct("kafkas_routes_base_domain_name", "kafkas_routes_base_domain_tls_crt_ref", "kafkas_routes_base_domain_tls_key_ref").
+		Where("kafkas_routes_base_domain_tls_crt_ref <> '' AND kafkas_routes_base_domain_tls_key_ref <> ''").
+		Order("kafkas_routes_base_domain_name").
+		Scan(&refs).Error
+	if err != nil {
+		return nil, []error{errors.NewWithCause(errors.ErrorGeneral, err, "unable to list the certificates of the kafkas")}
+	}
+
+	var existing dbapi.KafkaTLSCertificateList
+	if err := dbConn.Find(&existing).Error; err != nil {
+		return nil, []error{errors.NewWithCause(errors.ErrorGeneral, err, "unable to list the certificate inventory")}
+	}
+	byDomain := make(map[string]*dbapi.KafkaTLSCertificate, len(existing))
+	for _, certificate := range existing {
+		byDomain[certificate.Domain] = certificate
+	}
+
+	var errs []error
+	var certificates dbapi.KafkaTLSCertificateList
+	refreshed := map[string]bool{}
+	for _, ref := range refs {
+		// the kafkas of a domain share its certificate
+		if refreshed[ref.KafkasRoutesBaseDomainName] {
+			continue
+		}
+		refreshed[ref.KafkasRoutesBaseDomainName] = true
+
+		certificate, ok := byDomain[ref.KafkasRoutesBaseDomainName]
+		if !ok {
+			certificate = &dbapi.KafkaTLSCertificate{
+				ID:     api.NewID(),
+				Domain: ref.KafkasRoutesBaseDomainName,
+			}
+		}
+		certificate.TLSCertRef = ref.KafkasRoutesBaseDomainTLSCrtRef
+		certificate.TLSKeyRef = ref.KafkasRoutesBaseDomainTLSKeyRef
+		if err := s.readCertificate(certificate); err != nil {
+			// the entry is still saved so that the domain appears in the inventory
+			errs = append(errs, err)
+		}
+		if err := dbConn.Save(certificate).Error; err != nil {
+			errs = append(errs, errors.NewWithCause(errors.ErrorGeneral, err, "unable to save the certificate of domain %q in the inventory", certificate.Domain))
+			continue
+		}
+		certificates = append(certificates, certificate)
+	}
+
+	for _, certificate := range existing {
+		if refreshed[certificate.Domain] {
+			continue
+		}
+		if err := dbConn.Delete(certificate).Error; err != nil {
+			errs = append(errs, errors.NewWithCause(errors.ErrorGeneral, err, "unable to remove the certificate of domain %q from the inventory", certificate.Domain))
+		}
+	}
+
+	return certificates, errs
+}
+
+func (s *kafkaTLSCertificateInventoryService) RecordRenewal(certificate *dbapi.KafkaTLSCertificate, renewalErr error) *errors.ServiceError {
+	now := time.Now()
+	certificate.LastRenewalAttemptAt = &now
+	certificate.LastRenewalError = ""
+	var readErr *errors.ServiceError
+	if renewalErr != nil {
+		certificate.LastRenewalError = renewalErr.Error()
+	} else {
+		readErr = s.readCertificate(certificate)
+	}
+
+	if err := s.connectionFactory.New().Save(certificate).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to save the certificate of domain %q in the inventory", certificate.Domain)
+	}
+	return readErr
+}
+
+func (s *kafkaTLSCertificateInventoryService) RecordDomainRenewal(domain string, renewalErr error) *errors.ServiceError {
+	renewalError := ""
+	if renewalErr != nil {
+		renewalError = renewalErr.Error()
+	}
+
+	// only the renewal columns are updated so that a concurrent refresh of the entry is not overwritten
+	err := s.connectionFactory.New().
+		Model(&dbapi.KafkaTLSCertificate{}).
+		Where("domain = ? AND last_renewal_error <> ?", domain, renewalError).
+		UpdateColumns(map[string]interface{}{
+			"last_renewal_attempt_at": time.Now(),
+			"last_renewal_error":      renewalError,
+		}).Error
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to record the renewal of the certificate of domain %q in the inventory", domain)
+	}
+	return nil
+}
+
+func (s *kafkaTLSCertificateInventoryService) List(listArgs *coreServices.ListArguments) (dbapi.KafkaTLSCertificateList, *api.PagingMeta, *errors.ServiceError) {
+	var certificates dbapi.KafkaTLSCertificateList
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+
+	dbConn := s.connectionFactory.New().Model(&dbapi.KafkaTLSCertificate{})
+
+	if len(listArgs.Search) > 0 {
+		searchDbQuery, err := queryparser.NewQueryParser(ValidKafkaTLSCertificateColumns...).Parse(listArgs.Search)
+		if err != nil {
+			return nil, nil, errors.NewWithCause(errors.ErrorFailedToParseSearch, err, "unable to list certificates: %s", err.Error())
+		}
+		dbConn = dbConn.Where(searchDbQuery.Query, searchDbQuery.Values...)
+	}
+
+	var total int64
+	if err := dbConn.Count(&total).Error; err != nil {
+		return nil, nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to count certificates")
+	}
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+
+	if len(listArgs.OrderBy) == 0 {
+		// the certificates expiring first are returned first by default
+		dbConn = dbConn.Order("not_after")
+	}
+	for _, orderByArg := range listArgs.OrderBy {
+		dbConn = dbConn.Order(orderByArg)
+	}
+
+	if err := dbConn.Offset((pagingMeta.Page - 1) * listArgs.Size).Limit(listArgs.Size).Find(&certificates).Error; err != nil {
+		return nil, nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list certificates")
+	}
+
+	return certificates, pagingMeta, nil
+}
+
+// readCertificate updates the certificate from the leaf certificate read from the certificate storage
+func (s *kafkaTLSCertificateInventoryService) readCertificate(certificate *dbapi.KafkaTLSCertificate) *errors.ServiceError {
+	content, err := s.kafkaTLSCertificateManagementService.GetCertificate(context.Background(), kafkatlscertmgmt.GetCertificateRequest{
+		TLSCertRef: certificate.TLSCertRef,
+		TLSKeyRef:  certificate.TLSKeyRef,
+	})
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to read the certificate of domain %q", certificate.Domain)
+	}
+	cert, err := parseCertificate(content.TLSCert)
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to parse the certificate of domain %q", certificate.Domain)
+	}
+
+	certificate.Subject = cert.Subject.String()
+	certificate.Issuer = cert.Issuer.String()
+	certificate.SerialNumber = cert.SerialNumber.Text(16)
+	certificate.NotBefore = &cert.NotBefore
+	certificate.NotAfter = &cert.NotAfter
+	return nil
+}
+
+// parseCertificate parses the first certificate of the PEM encoded certificate chain, which is the leaf certificate
+func parseCertificate(chain string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(chain))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
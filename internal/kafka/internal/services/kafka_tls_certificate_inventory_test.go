@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"database/sql/driver"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services/kafkatlscertmgmt"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+// newTestCertificatePEM returns a PEM encoded self signed wildcard certificate of the domain
+func newTestCertificatePEM(t *testing.T, domain string, notBefore time.Time, notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(255),
+		Subject:      pkix.Name{CommonName: "*." + domain},
+		DNSNames:     []string{"*." + domain},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func Test_kafkaTLSCertificateInventoryService_Refresh(t *testing.T) {
+	g := gomega.NewWithT(t)
+	notBefore := time.Now().Add(-24 * time.Hour).Truncate(time.Second).UTC()
+	notAfter := notBefore.Add(90 * 24 * time.Hour)
+	certPEM := newTestCertificatePEM(t, "kafka-1.kafka.example.com", notBefore, notAfter)
+
+	mocket.Catcher.Reset()
+	mocket.Catcher.NewMock().WithQuery(`SELECT DISTINCT "kafkas_routes_base_domain_name","kafkas_routes_base_domain_tls_crt_ref","kafkas_routes_base_domain_tls_key_ref" FROM "kafka_requests"`).
+		WithReply([]map[string]interface{}{
+			{"kafkas_routes_base_domain_name": "kafka-1.kafka.example.com", "kafkas_routes_base_domain_tls_crt_ref": "kafka-1.crt", "kafkas_routes_base_domain_tls_key_ref": "kafka-1.key"},
+			{"kafkas_routes_base_domain_name": "kafka-2.kafka.example.com", "kafkas_routes_base_domain_tls_crt_ref": "kafka-2.crt", "kafkas_routes_base_domain_tls_key_ref": "kafka-2.key"},
+		})
+	mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "kafka_tls_certificates"`).
+		WithReply([]map[string]interface{}{
+			{"id": "kafka-2-certificate", "domain": "kafka-2.kafka.example.com", "subject": "CN=*.kafka-2.kafka.example.com"},
+			{"id": "deleted-kafka-certificate", "domain": "deleted-kafka.kafka.example.com"},
+		})
+	var deleted []driver.NamedValue
+	mocket.Catcher.NewMock().WithQuery(`DELETE FROM "kafka_tls_certificates" WHERE "kafka_tls_certificates"."id" = $1`).WithRowsNum(1).
+		WithCallback(func(query string, args []driver.NamedValue) {
+			deleted = append(deleted, args...)
+		})
+
+	certificateManagementService := &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{
+		GetCertificateFunc: func(ctx context.Context, request kafkatlscertmgmt.GetCertificateRequest) (kafkatlscertmgmt.Certificate, error) {
+			if request.TLSCertRef != "kafka-1.crt" {
+				return kafkatlscertmgmt.Certificate{}, fmt.Errorf("not found")
+			}
+			return kafkatlscertmgmt.Certificate{TLSCert: certPEM}, nil
+		},
+	}
+
+	certificates, errs := NewKafkaTLSCertificateInventoryService(db.NewMockConnectionFactory(nil), certificateManagementService).Refresh()
+	// the certificate which cannot be read is kept in the inventory as it was
+	g.Expect(errs).To(gomega.HaveLen(1))
+	g.Expect(certificates).To(gomega.HaveLen(2))
+
+	g.Expect(certificates[0].ID).ToNot(gomega.BeEmpty())
+	g.Expect(certificates[0].Domain).To(gomega.Equal("kafka-1.kafka.example.com"))
+	g.Expect(certificates[0].TLSCertRef).To(gomega.Equal("kafka-1.crt"))
+	g.Expect(certificates[0].Subject).To(gomega.Equal("CN=*.kafka-1.kafka.example.com"))
+	g.Expect(certificates[0].Issuer).To(gomega.Equal("CN=*.kafka-1.kafka.example.com"))
+	g.Expect(certificates[0].SerialNumber).To(gomega.Equal("ff"))
+	g.Expect(*certificates[0].NotBefore).To(gomega.BeTemporally("==", notBefore))
+	g.Expect(*certificates[0].NotAfter).To(gomega.BeTemporally("==", notAfter))
+
+	g.Expect(certificates[1].ID).To(gomega.Equal("kafka-2-certificate"))
+	g.Expect(certificates[1].Subject).To(gomega.Equal("CN=*.kafka-2.kafka.example.com"))
+
+	// the certificate of the domain no longer used by any kafka is removed from the inventory
+	g.Expect(deleted).To(gomega.HaveLen(1))
+	g.Expect(deleted[0].Value).To(gomega.Equal("deleted-kafka-certificate"))
+}
+
+func Test_kafkaTLSCertificateInventoryService_RecordRenewal(t *testing.T) {
+	notBefore := time.Now().Truncate(time.Second).UTC()
+	certPEM := newTestCertificatePEM(t, "kafka.example.com", notBefore, notBefore.Add(90*24*time.Hour))
+
+	tests := []struct {
+		name             string
+		renewalErr       error
+		wantRenewalError string
+		wantReads        int
+	}{
+		{
+			name:             "should record the error of the failed renewal",
+			renewalErr:       fmt.Errorf("rate limited"),
+			wantRenewalError: "rate limited",
+		},
+		{
+			name:      "should read the renewed certificate from the storage",
+			wantReads: 1,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			save := mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_tls_certificates"`).WithRowsNum(1)
+
+			certificateManagementService := &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{
+				GetCertificateFunc: func(ctx context.Context, request kafkatlscertmgmt.GetCertificateRequest) (kafkatlscertmgmt.Certificate, error) {
+					return kafkatlscertmgmt.Certificate{TLSCert: certPEM}, nil
+				},
+			}
+			certificate := &dbapi.KafkaTLSCertificate{ID: "certificate-id", Domain: "kafka.example.com", LastRenewalError: "previous error"}
+
+			err := NewKafkaTLSCertificateInventoryService(db.NewMockConnectionFactory(nil), certificateManagementService).RecordRenewal(certificate, tt.renewalErr)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(save.Triggered).To(gomega.BeTrue())
+			g.Expect(certificate.LastRenewalAttemptAt).ToNot(gomega.BeNil())
+			g.Expect(certificate.LastRenewalError).To(gomega.Equal(tt.wantRenewalError))
+			g.Expect(certificateManagementService.GetCertificateCalls()).To(gomega.HaveLen(tt.wantReads))
+			if tt.wantReads > 0 {
+				g.Expect(*certificate.NotBefore).To(gomega.BeTemporally("==", notBefore))
+			}
+		})
+	}
+}
+
+func Test_kafkaTLSCertificateInventoryService_RecordDomainRenewal(t *testing.T) {
+	tests := []struct {
+		name             string
+		renewalErr       error
+		wantRenewalError string
+	}{
+		{
+			name:             "should record the error of the failed renewal when it differs from the previous one",
+			renewalErr:       fmt.Errorf("rate limited"),
+			wantRenewalError: "rate limited",
+		},
+		{
+			name: "should clear the error of the previous renewal",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			var updateArgs []driver.NamedValue
+			update := mocket.Catcher.NewMock().
+				WithQuery(`UPDATE "kafka_tls_certificates" SET "last_renewal_attempt_at"=$1,"last_renewal_error"=$2 WHERE domain = $3 AND last_renewal_error <> $4`).
+				WithCallback(func(query string, args []driver.NamedValue) {
+					updateArgs = args
+				}).
+				WithRowsNum(1)
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			err := NewKafkaTLSCertificateInventoryService(db.NewMockConnectionFactory(nil), &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{}).RecordDomainRenewal("kafka.example.com", tt.renewalErr)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(update.Triggered).To(gomega.BeTrue())
+			g.Expect(updateArgs).To(gomega.HaveLen(4))
+			g.Expect(updateArgs[1].Value).To(gomega.Equal(tt.wantRenewalError))
+			g.Expect(updateArgs[2].Value).To(gomega.Equal("kafka.example.com"))
+			g.Expect(updateArgs[3].Value).To(gomega.Equal(tt.wantRenewalError))
+		})
+	}
+}
+
+func Test_kafkaTLSCertificateInventoryService_List(t *testing.T) {
+	tests := []struct {
+		name      string
+		listArgs  *coreServices.ListArguments
+		wantQuery string
+		wantErr   *errors.ServiceError
+	}{
+		{
+			name:      "should return the certificates expiring first by default",
+			listArgs:  &coreServices.ListArguments{Page: 1, Size: 10},
+			wantQuery: `SELECT * FROM "kafka_tls_certificates" ORDER BY not_after LIMIT 10`,
+		},
+		{
+			name:      "should return the certificates matching the search in the requested order",
+			listArgs:  &coreServices.ListArguments{Page: 1, Size: 10, Search: "last_renewal_error <> ''", OrderBy: []string{"domain asc"}},
+			wantQuery: `SELECT * FROM "kafka_tls_certificates" WHERE last_renewal_error <> $1 ORDER BY domain asc LIMIT 10`,
+		},
+		{
+			name:     "should return an error when the search uses an unknown column",
+			listArgs: &coreServices.ListArguments{Page: 1, Size: 10, Search: "tls_key_ref = key"},
+			wantErr:  errors.New(errors.ErrorFailedToParseSearch, "unable to list certificates"),
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "kafka_tls_certificates"`).WithReply([]map[string]interface{}{{"count": 1}})
+			query := mocket.Catcher.NewMock().WithQuery(tt.wantQuery).WithReply([]map[string]interface{}{{"id": "certificate-id"}})
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			certificates, paging, err := NewKafkaTLSCertificateInventoryService(db.NewMockConnectionFactory(nil), nil).List(tt.listArgs)
+			if tt.wantErr != nil {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErr.Code))
+				g.Expect(err.Reason).To(gomega.ContainSubstring(tt.wantErr.Reason))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(query.Triggered).To(gomega.BeTrue())
+			g.Expect(certificates).To(gomega.HaveLen(1))
+			g.Expect(paging.Total).To(gomega.Equal(1))
+		})
+	}
+}
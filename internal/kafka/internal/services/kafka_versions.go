@@ -0,0 +1,60 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+)
+
+// ValidateKafkaVersionsCompatibility verifies that the given kafka can be moved to the given desired versions: the
+// versions must be available and the Strimzi version ready in the data plane cluster of the kafka, the Kafka IBP
+// version cannot be greater than the Kafka version and neither the Kafka nor the Kafka IBP version can be downgraded.
+// A validation error is returned when the versions are not compatible with the kafka.
+func ValidateKafkaVersionsCompatibility(clusterService ClusterService, kafkaRequest *dbapi.KafkaRequest, desiredStrimziVersion, desiredKafkaVersion, desiredKafkaIBPVersion string) *errors.ServiceError {
+	cluster, err := clusterService.FindClusterByID(kafkaRequest.ClusterID)
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to find cluster associated with kafka request: %s", kafkaRequest.ID)
+	}
+	if cluster == nil {
+		return errors.New(errors.ErrorValidation, fmt.Sprintf("unable to get cluster for kafka %s", kafkaRequest.ID))
+	}
+
+	if kafkaVersionAvailable, err := clusterService.IsStrimziKafkaVersionAvailableInCluster(cluster, desiredStrimziVersion, desiredKafkaVersion, desiredKafkaIBPVersion); err != nil {
+		return errors.Validation(err.Error())
+	} else if !kafkaVersionAvailable {
+		return errors.New(errors.ErrorValidation, fmt.Sprintf("unable to update kafka: %s with kafka version: %s", kafkaRequest.ID, desiredKafkaVersion))
+	}
+
+	if strimziVersionReady, err := clusterService.CheckStrimziVersionReady(cluster, desiredStrimziVersion); err != nil {
+		return errors.Validation(err.Error())
+	} else if !strimziVersionReady {
+		return errors.New(errors.ErrorValidation, fmt.Sprintf("unable to update kafka: %s with strimzi version: %s", kafkaRequest.ID, desiredStrimziVersion))
+	}
+
+	currentIBPVersion, _ := arrays.FirstNonEmpty(kafkaRequest.ActualKafkaIBPVersion, desiredKafkaIBPVersion)
+
+	if vCompOldNewIbp, err := api.CompareBuildAwareSemanticVersions(currentIBPVersion, desiredKafkaIBPVersion); err != nil {
+		return errors.New(errors.ErrorValidation, fmt.Sprintf("unable to compare actual ibp version: %s with desired ibp version: %s", currentIBPVersion, desiredKafkaIBPVersion))
+	} else if vCompOldNewIbp > 0 {
+		return errors.New(errors.ErrorValidation, fmt.Sprintf("unable to downgrade kafka: %s ibp version: %s to a lower version: %s", kafkaRequest.ID, desiredKafkaIBPVersion, currentIBPVersion))
+	}
+
+	if vCompIbpKafka, err := api.CompareBuildAwareSemanticVersions(desiredKafkaIBPVersion, desiredKafkaVersion); err != nil {
+		return errors.New(errors.ErrorValidation, fmt.Sprintf("unable to compare kafka ibp version: %s with kafka version: %s", desiredKafkaIBPVersion, desiredKafkaVersion))
+	} else if vCompIbpKafka > 0 {
+		return errors.New(errors.ErrorValidation, fmt.Sprintf("unable to update kafka: %s ibp version: %s with kafka version: %s", kafkaRequest.ID, desiredKafkaIBPVersion, desiredKafkaVersion))
+	}
+
+	currentKafkaVersion, _ := arrays.FirstNonEmpty(kafkaRequest.ActualKafkaVersion, desiredKafkaVersion)
+
+	if vCompKafka, err := api.CompareSemanticVersionsMajorAndMinor(currentKafkaVersion, desiredKafkaVersion); err != nil {
+		return errors.New(errors.ErrorValidation, fmt.Sprintf("unable to compare desired kafka version: %s with actual kafka version: %s", desiredKafkaVersion, currentKafkaVersion))
+	} else if vCompKafka > 0 {
+		return errors.New(errors.ErrorValidation, fmt.Sprintf("unable to downgrade kafka: %s version: %s to the following kafka version: %s", kafkaRequest.ID, currentKafkaVersion, desiredKafkaVersion))
+	}
+
+	return nil
+}
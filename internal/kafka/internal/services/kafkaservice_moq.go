@@ -5,15 +5,16 @@ package services
 
 import (
 	"context"
-	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
 	kafkaTypes "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	managedkafka "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api/managedkafkas.managedkafka.bf2.org/v1"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/dns"
 	apiErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
 	"sync"
+	"time"
 )
 
 // Ensure, that KafkaServiceMock does implement KafkaService.
@@ -26,13 +27,16 @@ var _ KafkaService = &KafkaServiceMock{}
 //
 //		// make and configure a mocked KafkaService
 //		mockedKafkaService := &KafkaServiceMock{
+//			AbortResizeFunc: func(kafkaRequest *dbapi.KafkaRequest, reason string) *apiErrors.ServiceError {
+//				panic("mock out the AbortResize method")
+//			},
 //			AssignBootstrapServerHostFunc: func(kafkaRequest *dbapi.KafkaRequest) error {
 //				panic("mock out the AssignBootstrapServerHost method")
 //			},
 //			AssignInstanceTypeFunc: func(owner string, organisationID string) (kafkaTypes.KafkaInstanceType, *apiErrors.ServiceError) {
 //				panic("mock out the AssignInstanceType method")
 //			},
-//			ChangeKafkaCNAMErecordsFunc: func(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*route53.ChangeResourceRecordSetsOutput, *apiErrors.ServiceError) {
+//			ChangeKafkaCNAMErecordsFunc: func(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*dns.Change, *apiErrors.ServiceError) {
 //				panic("mock out the ChangeKafkaCNAMErecords method")
 //			},
 //			CountByStatusFunc: func(status []constants.KafkaStatus) ([]KafkaStatusCount, error) {
@@ -59,10 +63,10 @@ var _ KafkaService = &KafkaServiceMock{}
 //			GetByIDFunc: func(id string) (*dbapi.KafkaRequest, *apiErrors.ServiceError) {
 //				panic("mock out the GetByID method")
 //			},
-//			GetCNAMERecordStatusFunc: func(kafkaRequest *dbapi.KafkaRequest) (*CNameRecordStatus, error) {
+//			GetCNAMERecordStatusFunc: func(kafkaRequest *dbapi.KafkaRequest) (*dns.Change, error) {
 //				panic("mock out the GetCNAMERecordStatus method")
 //			},
-//			GetManagedKafkaByClusterIDFunc: func(clusterID string) ([]managedkafka.ManagedKafka, *apiErrors.ServiceError) {
+//			GetManagedKafkaByClusterIDFunc: func(clusterID string, gtVersion int64) ([]managedkafka.ManagedKafka, *apiErrors.ServiceError) {
 //				panic("mock out the GetManagedKafkaByClusterID method")
 //			},
 //			HasAvailableCapacityInRegionFunc: func(kafkaRequest *dbapi.KafkaRequest) (bool, *apiErrors.ServiceError) {
@@ -83,9 +87,21 @@ var _ KafkaService = &KafkaServiceMock{}
 //			ListComponentVersionsFunc: func() ([]KafkaComponentVersions, error) {
 //				panic("mock out the ListComponentVersions method")
 //			},
+//			ListDeletedKafkasWithRoutesFunc: func(deletedSince time.Time) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+//				panic("mock out the ListDeletedKafkasWithRoutes method")
+//			},
+//			ListKafkasByClusterIDFunc: func(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+//				panic("mock out the ListKafkasByClusterID method")
+//			},
 //			ListKafkasToBePromotedFunc: func() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
 //				panic("mock out the ListKafkasToBePromoted method")
 //			},
+//			ListKafkasToBeResizedFunc: func() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+//				panic("mock out the ListKafkasToBeResized method")
+//			},
+//			ListKafkasWithPendingUpgradeFunc: func() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+//				panic("mock out the ListKafkasWithPendingUpgrade method")
+//			},
 //			ListKafkasWithRoutesNotCreatedFunc: func() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
 //				panic("mock out the ListKafkasWithRoutesNotCreated method")
 //			},
@@ -101,6 +117,12 @@ var _ KafkaService = &KafkaServiceMock{}
 //			RegisterKafkaJobFunc: func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
 //				panic("mock out the RegisterKafkaJob method")
 //			},
+//			ResumeFunc: func(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
+//				panic("mock out the Resume method")
+//			},
+//			SuspendFunc: func(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
+//				panic("mock out the Suspend method")
+//			},
 //			UpdateFunc: func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
 //				panic("mock out the Update method")
 //			},
@@ -123,6 +145,9 @@ var _ KafkaService = &KafkaServiceMock{}
 //
 //	}
 type KafkaServiceMock struct {
+	// AbortResizeFunc mocks the AbortResize method.
+	AbortResizeFunc func(kafkaRequest *dbapi.KafkaRequest, reason string) *apiErrors.ServiceError
+
 	// AssignBootstrapServerHostFunc mocks the AssignBootstrapServerHost method.
 	AssignBootstrapServerHostFunc func(kafkaRequest *dbapi.KafkaRequest) error
 
@@ -130,7 +155,7 @@ type KafkaServiceMock struct {
 	AssignInstanceTypeFunc func(owner string, organisationID string) (kafkaTypes.KafkaInstanceType, *apiErrors.ServiceError)
 
 	// ChangeKafkaCNAMErecordsFunc mocks the ChangeKafkaCNAMErecords method.
-	ChangeKafkaCNAMErecordsFunc func(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*route53.ChangeResourceRecordSetsOutput, *apiErrors.ServiceError)
+	ChangeKafkaCNAMErecordsFunc func(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*dns.Change, *apiErrors.ServiceError)
 
 	// CountByStatusFunc mocks the CountByStatus method.
 	CountByStatusFunc func(status []constants.KafkaStatus) ([]KafkaStatusCount, error)
@@ -157,10 +182,10 @@ type KafkaServiceMock struct {
 	GetByIDFunc func(id string) (*dbapi.KafkaRequest, *apiErrors.ServiceError)
 
 	// GetCNAMERecordStatusFunc mocks the GetCNAMERecordStatus method.
-	GetCNAMERecordStatusFunc func(kafkaRequest *dbapi.KafkaRequest) (*CNameRecordStatus, error)
+	GetCNAMERecordStatusFunc func(kafkaRequest *dbapi.KafkaRequest) (*dns.Change, error)
 
 	// GetManagedKafkaByClusterIDFunc mocks the GetManagedKafkaByClusterID method.
-	GetManagedKafkaByClusterIDFunc func(clusterID string) ([]managedkafka.ManagedKafka, *apiErrors.ServiceError)
+	GetManagedKafkaByClusterIDFunc func(clusterID string, gtVersion int64) ([]managedkafka.ManagedKafka, *apiErrors.ServiceError)
 
 	// HasAvailableCapacityInRegionFunc mocks the HasAvailableCapacityInRegion method.
 	HasAvailableCapacityInRegionFunc func(kafkaRequest *dbapi.KafkaRequest) (bool, *apiErrors.ServiceError)
@@ -180,9 +205,21 @@ type KafkaServiceMock struct {
 	// ListComponentVersionsFunc mocks the ListComponentVersions method.
 	ListComponentVersionsFunc func() ([]KafkaComponentVersions, error)
 
+	// ListDeletedKafkasWithRoutesFunc mocks the ListDeletedKafkasWithRoutes method.
+	ListDeletedKafkasWithRoutesFunc func(deletedSince time.Time) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError)
+
+	// ListKafkasByClusterIDFunc mocks the ListKafkasByClusterID method.
+	ListKafkasByClusterIDFunc func(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError)
+
 	// ListKafkasToBePromotedFunc mocks the ListKafkasToBePromoted method.
 	ListKafkasToBePromotedFunc func() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError)
 
+	// ListKafkasToBeResizedFunc mocks the ListKafkasToBeResized method.
+	ListKafkasToBeResizedFunc func() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError)
+
+	// ListKafkasWithPendingUpgradeFunc mocks the ListKafkasWithPendingUpgrade method.
+	ListKafkasWithPendingUpgradeFunc func() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError)
+
 	// ListKafkasWithRoutesNotCreatedFunc mocks the ListKafkasWithRoutesNotCreated method.
 	ListKafkasWithRoutesNotCreatedFunc func() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError)
 
@@ -198,6 +235,12 @@ type KafkaServiceMock struct {
 	// RegisterKafkaJobFunc mocks the RegisterKafkaJob method.
 	RegisterKafkaJobFunc func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError
 
+	// ResumeFunc mocks the Resume method.
+	ResumeFunc func(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError
+
+	// SuspendFunc mocks the Suspend method.
+	SuspendFunc func(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError
+
 	// UpdateFunc mocks the Update method.
 	UpdateFunc func(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError
 
@@ -215,6 +258,13 @@ type KafkaServiceMock struct {
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// AbortResize holds details about calls to the AbortResize method.
+		AbortResize []struct {
+			// KafkaRequest is the kafkaRequest argument value.
+			KafkaRequest *dbapi.KafkaRequest
+			// Reason is the reason argument value.
+			Reason string
+		}
 		// AssignBootstrapServerHost holds details about calls to the AssignBootstrapServerHost method.
 		AssignBootstrapServerHost []struct {
 			// KafkaRequest is the kafkaRequest argument value.
@@ -283,6 +333,8 @@ type KafkaServiceMock struct {
 		GetManagedKafkaByClusterID []struct {
 			// ClusterID is the clusterID argument value.
 			ClusterID string
+			// GtVersion is the gtVersion argument value.
+			GtVersion int64
 		}
 		// HasAvailableCapacityInRegion holds details about calls to the HasAvailableCapacityInRegion method.
 		HasAvailableCapacityInRegion []struct {
@@ -312,9 +364,25 @@ type KafkaServiceMock struct {
 		// ListComponentVersions holds details about calls to the ListComponentVersions method.
 		ListComponentVersions []struct {
 		}
+		// ListDeletedKafkasWithRoutes holds details about calls to the ListDeletedKafkasWithRoutes method.
+		ListDeletedKafkasWithRoutes []struct {
+			// DeletedSince is the deletedSince argument value.
+			DeletedSince time.Time
+		}
+		// ListKafkasByClusterID holds details about calls to the ListKafkasByClusterID method.
+		ListKafkasByClusterID []struct {
+			// ClusterID is the clusterID argument value.
+			ClusterID string
+		}
 		// ListKafkasToBePromoted holds details about calls to the ListKafkasToBePromoted method.
 		ListKafkasToBePromoted []struct {
 		}
+		// ListKafkasToBeResized holds details about calls to the ListKafkasToBeResized method.
+		ListKafkasToBeResized []struct {
+		}
+		// ListKafkasWithPendingUpgrade holds details about calls to the ListKafkasWithPendingUpgrade method.
+		ListKafkasWithPendingUpgrade []struct {
+		}
 		// ListKafkasWithRoutesNotCreated holds details about calls to the ListKafkasWithRoutesNotCreated method.
 		ListKafkasWithRoutesNotCreated []struct {
 		}
@@ -340,6 +408,20 @@ type KafkaServiceMock struct {
 			// KafkaRequest is the kafkaRequest argument value.
 			KafkaRequest *dbapi.KafkaRequest
 		}
+		// Resume holds details about calls to the Resume method.
+		Resume []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// KafkaRequest is the kafkaRequest argument value.
+			KafkaRequest *dbapi.KafkaRequest
+		}
+		// Suspend holds details about calls to the Suspend method.
+		Suspend []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// KafkaRequest is the kafkaRequest argument value.
+			KafkaRequest *dbapi.KafkaRequest
+		}
 		// Update holds details about calls to the Update method.
 		Update []struct {
 			// KafkaRequest is the kafkaRequest argument value.
@@ -380,6 +462,7 @@ type KafkaServiceMock struct {
 			KafkaRequest *dbapi.KafkaRequest
 		}
 	}
+	lockAbortResize                              sync.RWMutex
 	lockAssignBootstrapServerHost                sync.RWMutex
 	lockAssignInstanceType                       sync.RWMutex
 	lockChangeKafkaCNAMErecords                  sync.RWMutex
@@ -399,12 +482,18 @@ type KafkaServiceMock struct {
 	lockListAll                                  sync.RWMutex
 	lockListByStatus                             sync.RWMutex
 	lockListComponentVersions                    sync.RWMutex
+	lockListDeletedKafkasWithRoutes              sync.RWMutex
+	lockListKafkasByClusterID                    sync.RWMutex
 	lockListKafkasToBePromoted                   sync.RWMutex
+	lockListKafkasToBeResized                    sync.RWMutex
+	lockListKafkasWithPendingUpgrade             sync.RWMutex
 	lockListKafkasWithRoutesNotCreated           sync.RWMutex
 	lockManagedKafkasRoutesTLSCertificate        sync.RWMutex
 	lockPrepareKafkaRequest                      sync.RWMutex
 	lockRegisterKafkaDeprovisionJob              sync.RWMutex
 	lockRegisterKafkaJob                         sync.RWMutex
+	lockResume                                   sync.RWMutex
+	lockSuspend                                  sync.RWMutex
 	lockUpdate                                   sync.RWMutex
 	lockUpdateStatus                             sync.RWMutex
 	lockUpdates                                  sync.RWMutex
@@ -412,6 +501,42 @@ type KafkaServiceMock struct {
 	lockVerifyAndUpdateKafkaAdmin                sync.RWMutex
 }
 
+// AbortResize calls AbortResizeFunc.
+func (mock *KafkaServiceMock) AbortResize(kafkaRequest *dbapi.KafkaRequest, reason string) *apiErrors.ServiceError {
+	if mock.AbortResizeFunc == nil {
+		panic("KafkaServiceMock.AbortResizeFunc: method is nil but KafkaService.AbortResize was just called")
+	}
+	callInfo := struct {
+		KafkaRequest *dbapi.KafkaRequest
+		Reason       string
+	}{
+		KafkaRequest: kafkaRequest,
+		Reason:       reason,
+	}
+	mock.lockAbortResize.Lock()
+	mock.calls.AbortResize = append(mock.calls.AbortResize, callInfo)
+	mock.lockAbortResize.Unlock()
+	return mock.AbortResizeFunc(kafkaRequest, reason)
+}
+
+// AbortResizeCalls gets all the calls that were made to AbortResize.
+// Check the length with:
+//
+//	len(mockedKafkaService.AbortResizeCalls())
+func (mock *KafkaServiceMock) AbortResizeCalls() []struct {
+	KafkaRequest *dbapi.KafkaRequest
+	Reason       string
+} {
+	var calls []struct {
+		KafkaRequest *dbapi.KafkaRequest
+		Reason       string
+	}
+	mock.lockAbortResize.RLock()
+	calls = mock.calls.AbortResize
+	mock.lockAbortResize.RUnlock()
+	return calls
+}
+
 // AssignBootstrapServerHost calls AssignBootstrapServerHostFunc.
 func (mock *KafkaServiceMock) AssignBootstrapServerHost(kafkaRequest *dbapi.KafkaRequest) error {
 	if mock.AssignBootstrapServerHostFunc == nil {
@@ -481,7 +606,7 @@ func (mock *KafkaServiceMock) AssignInstanceTypeCalls() []struct {
 }
 
 // ChangeKafkaCNAMErecords calls ChangeKafkaCNAMErecordsFunc.
-func (mock *KafkaServiceMock) ChangeKafkaCNAMErecords(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*route53.ChangeResourceRecordSetsOutput, *apiErrors.ServiceError) {
+func (mock *KafkaServiceMock) ChangeKafkaCNAMErecords(kafkaRequest *dbapi.KafkaRequest, action KafkaRoutesAction) (*dns.Change, *apiErrors.ServiceError) {
 	if mock.ChangeKafkaCNAMErecordsFunc == nil {
 		panic("KafkaServiceMock.ChangeKafkaCNAMErecordsFunc: method is nil but KafkaService.ChangeKafkaCNAMErecords was just called")
 	}
@@ -772,7 +897,7 @@ func (mock *KafkaServiceMock) GetByIDCalls() []struct {
 }
 
 // GetCNAMERecordStatus calls GetCNAMERecordStatusFunc.
-func (mock *KafkaServiceMock) GetCNAMERecordStatus(kafkaRequest *dbapi.KafkaRequest) (*CNameRecordStatus, error) {
+func (mock *KafkaServiceMock) GetCNAMERecordStatus(kafkaRequest *dbapi.KafkaRequest) (*dns.Change, error) {
 	if mock.GetCNAMERecordStatusFunc == nil {
 		panic("KafkaServiceMock.GetCNAMERecordStatusFunc: method is nil but KafkaService.GetCNAMERecordStatus was just called")
 	}
@@ -804,19 +929,21 @@ func (mock *KafkaServiceMock) GetCNAMERecordStatusCalls() []struct {
 }
 
 // GetManagedKafkaByClusterID calls GetManagedKafkaByClusterIDFunc.
-func (mock *KafkaServiceMock) GetManagedKafkaByClusterID(clusterID string) ([]managedkafka.ManagedKafka, *apiErrors.ServiceError) {
+func (mock *KafkaServiceMock) GetManagedKafkaByClusterID(clusterID string, gtVersion int64) ([]managedkafka.ManagedKafka, *apiErrors.ServiceError) {
 	if mock.GetManagedKafkaByClusterIDFunc == nil {
 		panic("KafkaServiceMock.GetManagedKafkaByClusterIDFunc: method is nil but KafkaService.GetManagedKafkaByClusterID was just called")
 	}
 	callInfo := struct {
 		ClusterID string
+		GtVersion int64
 	}{
 		ClusterID: clusterID,
+		GtVersion: gtVersion,
 	}
 	mock.lockGetManagedKafkaByClusterID.Lock()
 	mock.calls.GetManagedKafkaByClusterID = append(mock.calls.GetManagedKafkaByClusterID, callInfo)
 	mock.lockGetManagedKafkaByClusterID.Unlock()
-	return mock.GetManagedKafkaByClusterIDFunc(clusterID)
+	return mock.GetManagedKafkaByClusterIDFunc(clusterID, gtVersion)
 }
 
 // GetManagedKafkaByClusterIDCalls gets all the calls that were made to GetManagedKafkaByClusterID.
@@ -825,9 +952,11 @@ func (mock *KafkaServiceMock) GetManagedKafkaByClusterID(clusterID string) ([]ma
 //	len(mockedKafkaService.GetManagedKafkaByClusterIDCalls())
 func (mock *KafkaServiceMock) GetManagedKafkaByClusterIDCalls() []struct {
 	ClusterID string
+	GtVersion int64
 } {
 	var calls []struct {
 		ClusterID string
+		GtVersion int64
 	}
 	mock.lockGetManagedKafkaByClusterID.RLock()
 	calls = mock.calls.GetManagedKafkaByClusterID
@@ -1021,6 +1150,70 @@ func (mock *KafkaServiceMock) ListComponentVersionsCalls() []struct {
 	return calls
 }
 
+// ListDeletedKafkasWithRoutes calls ListDeletedKafkasWithRoutesFunc.
+func (mock *KafkaServiceMock) ListDeletedKafkasWithRoutes(deletedSince time.Time) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+	if mock.ListDeletedKafkasWithRoutesFunc == nil {
+		panic("KafkaServiceMock.ListDeletedKafkasWithRoutesFunc: method is nil but KafkaService.ListDeletedKafkasWithRoutes was just called")
+	}
+	callInfo := struct {
+		DeletedSince time.Time
+	}{
+		DeletedSince: deletedSince,
+	}
+	mock.lockListDeletedKafkasWithRoutes.Lock()
+	mock.calls.ListDeletedKafkasWithRoutes = append(mock.calls.ListDeletedKafkasWithRoutes, callInfo)
+	mock.lockListDeletedKafkasWithRoutes.Unlock()
+	return mock.ListDeletedKafkasWithRoutesFunc(deletedSince)
+}
+
+// ListDeletedKafkasWithRoutesCalls gets all the calls that were made to ListDeletedKafkasWithRoutes.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListDeletedKafkasWithRoutesCalls())
+func (mock *KafkaServiceMock) ListDeletedKafkasWithRoutesCalls() []struct {
+	DeletedSince time.Time
+} {
+	var calls []struct {
+		DeletedSince time.Time
+	}
+	mock.lockListDeletedKafkasWithRoutes.RLock()
+	calls = mock.calls.ListDeletedKafkasWithRoutes
+	mock.lockListDeletedKafkasWithRoutes.RUnlock()
+	return calls
+}
+
+// ListKafkasByClusterID calls ListKafkasByClusterIDFunc.
+func (mock *KafkaServiceMock) ListKafkasByClusterID(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+	if mock.ListKafkasByClusterIDFunc == nil {
+		panic("KafkaServiceMock.ListKafkasByClusterIDFunc: method is nil but KafkaService.ListKafkasByClusterID was just called")
+	}
+	callInfo := struct {
+		ClusterID string
+	}{
+		ClusterID: clusterID,
+	}
+	mock.lockListKafkasByClusterID.Lock()
+	mock.calls.ListKafkasByClusterID = append(mock.calls.ListKafkasByClusterID, callInfo)
+	mock.lockListKafkasByClusterID.Unlock()
+	return mock.ListKafkasByClusterIDFunc(clusterID)
+}
+
+// ListKafkasByClusterIDCalls gets all the calls that were made to ListKafkasByClusterID.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListKafkasByClusterIDCalls())
+func (mock *KafkaServiceMock) ListKafkasByClusterIDCalls() []struct {
+	ClusterID string
+} {
+	var calls []struct {
+		ClusterID string
+	}
+	mock.lockListKafkasByClusterID.RLock()
+	calls = mock.calls.ListKafkasByClusterID
+	mock.lockListKafkasByClusterID.RUnlock()
+	return calls
+}
+
 // ListKafkasToBePromoted calls ListKafkasToBePromotedFunc.
 func (mock *KafkaServiceMock) ListKafkasToBePromoted() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
 	if mock.ListKafkasToBePromotedFunc == nil {
@@ -1048,6 +1241,60 @@ func (mock *KafkaServiceMock) ListKafkasToBePromotedCalls() []struct {
 	return calls
 }
 
+// ListKafkasToBeResized calls ListKafkasToBeResizedFunc.
+func (mock *KafkaServiceMock) ListKafkasToBeResized() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+	if mock.ListKafkasToBeResizedFunc == nil {
+		panic("KafkaServiceMock.ListKafkasToBeResizedFunc: method is nil but KafkaService.ListKafkasToBeResized was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockListKafkasToBeResized.Lock()
+	mock.calls.ListKafkasToBeResized = append(mock.calls.ListKafkasToBeResized, callInfo)
+	mock.lockListKafkasToBeResized.Unlock()
+	return mock.ListKafkasToBeResizedFunc()
+}
+
+// ListKafkasToBeResizedCalls gets all the calls that were made to ListKafkasToBeResized.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListKafkasToBeResizedCalls())
+func (mock *KafkaServiceMock) ListKafkasToBeResizedCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockListKafkasToBeResized.RLock()
+	calls = mock.calls.ListKafkasToBeResized
+	mock.lockListKafkasToBeResized.RUnlock()
+	return calls
+}
+
+// ListKafkasWithPendingUpgrade calls ListKafkasWithPendingUpgradeFunc.
+func (mock *KafkaServiceMock) ListKafkasWithPendingUpgrade() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+	if mock.ListKafkasWithPendingUpgradeFunc == nil {
+		panic("KafkaServiceMock.ListKafkasWithPendingUpgradeFunc: method is nil but KafkaService.ListKafkasWithPendingUpgrade was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockListKafkasWithPendingUpgrade.Lock()
+	mock.calls.ListKafkasWithPendingUpgrade = append(mock.calls.ListKafkasWithPendingUpgrade, callInfo)
+	mock.lockListKafkasWithPendingUpgrade.Unlock()
+	return mock.ListKafkasWithPendingUpgradeFunc()
+}
+
+// ListKafkasWithPendingUpgradeCalls gets all the calls that were made to ListKafkasWithPendingUpgrade.
+// Check the length with:
+//
+//	len(mockedKafkaService.ListKafkasWithPendingUpgradeCalls())
+func (mock *KafkaServiceMock) ListKafkasWithPendingUpgradeCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockListKafkasWithPendingUpgrade.RLock()
+	calls = mock.calls.ListKafkasWithPendingUpgrade
+	mock.lockListKafkasWithPendingUpgrade.RUnlock()
+	return calls
+}
+
 // ListKafkasWithRoutesNotCreated calls ListKafkasWithRoutesNotCreatedFunc.
 func (mock *KafkaServiceMock) ListKafkasWithRoutesNotCreated() ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
 	if mock.ListKafkasWithRoutesNotCreatedFunc == nil {
@@ -1207,6 +1454,78 @@ func (mock *KafkaServiceMock) RegisterKafkaJobCalls() []struct {
 	return calls
 }
 
+// Resume calls ResumeFunc.
+func (mock *KafkaServiceMock) Resume(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
+	if mock.ResumeFunc == nil {
+		panic("KafkaServiceMock.ResumeFunc: method is nil but KafkaService.Resume was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		KafkaRequest *dbapi.KafkaRequest
+	}{
+		Ctx:          ctx,
+		KafkaRequest: kafkaRequest,
+	}
+	mock.lockResume.Lock()
+	mock.calls.Resume = append(mock.calls.Resume, callInfo)
+	mock.lockResume.Unlock()
+	return mock.ResumeFunc(ctx, kafkaRequest)
+}
+
+// ResumeCalls gets all the calls that were made to Resume.
+// Check the length with:
+//
+//	len(mockedKafkaService.ResumeCalls())
+func (mock *KafkaServiceMock) ResumeCalls() []struct {
+	Ctx          context.Context
+	KafkaRequest *dbapi.KafkaRequest
+} {
+	var calls []struct {
+		Ctx          context.Context
+		KafkaRequest *dbapi.KafkaRequest
+	}
+	mock.lockResume.RLock()
+	calls = mock.calls.Resume
+	mock.lockResume.RUnlock()
+	return calls
+}
+
+// Suspend calls SuspendFunc.
+func (mock *KafkaServiceMock) Suspend(ctx context.Context, kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
+	if mock.SuspendFunc == nil {
+		panic("KafkaServiceMock.SuspendFunc: method is nil but KafkaService.Suspend was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		KafkaRequest *dbapi.KafkaRequest
+	}{
+		Ctx:          ctx,
+		KafkaRequest: kafkaRequest,
+	}
+	mock.lockSuspend.Lock()
+	mock.calls.Suspend = append(mock.calls.Suspend, callInfo)
+	mock.lockSuspend.Unlock()
+	return mock.SuspendFunc(ctx, kafkaRequest)
+}
+
+// SuspendCalls gets all the calls that were made to Suspend.
+// Check the length with:
+//
+//	len(mockedKafkaService.SuspendCalls())
+func (mock *KafkaServiceMock) SuspendCalls() []struct {
+	Ctx          context.Context
+	KafkaRequest *dbapi.KafkaRequest
+} {
+	var calls []struct {
+		Ctx          context.Context
+		KafkaRequest *dbapi.KafkaRequest
+	}
+	mock.lockSuspend.RLock()
+	calls = mock.calls.Suspend
+	mock.lockSuspend.RUnlock()
+	return calls
+}
+
 // Update calls UpdateFunc.
 func (mock *KafkaServiceMock) Update(kafkaRequest *dbapi.KafkaRequest) *apiErrors.ServiceError {
 	if mock.UpdateFunc == nil {
@@ -24,6 +24,9 @@ var _ certMagicClientWrapper = &certMagicClientWrapperMock{}
 //			ManageCertificateFunc: func(ctx context.Context, domainNames []string) error {
 //				panic("mock out the ManageCertificate method")
 //			},
+//			RenewCertificateFunc: func(ctx context.Context, domain string) error {
+//				panic("mock out the RenewCertificate method")
+//			},
 //			RevokeCertificateFunc: func(ctx context.Context, domain string, reason int) error {
 //				panic("mock out the RevokeCertificate method")
 //			},
@@ -40,6 +43,9 @@ type certMagicClientWrapperMock struct {
 	// ManageCertificateFunc mocks the ManageCertificate method.
 	ManageCertificateFunc func(ctx context.Context, domainNames []string) error
 
+	// RenewCertificateFunc mocks the RenewCertificate method.
+	RenewCertificateFunc func(ctx context.Context, domain string) error
+
 	// RevokeCertificateFunc mocks the RevokeCertificate method.
 	RevokeCertificateFunc func(ctx context.Context, domain string, reason int) error
 
@@ -57,6 +63,13 @@ type certMagicClientWrapperMock struct {
 			// DomainNames is the domainNames argument value.
 			DomainNames []string
 		}
+		// RenewCertificate holds details about calls to the RenewCertificate method.
+		RenewCertificate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Domain is the domain argument value.
+			Domain string
+		}
 		// RevokeCertificate holds details about calls to the RevokeCertificate method.
 		RevokeCertificate []struct {
 			// Ctx is the ctx argument value.
@@ -69,6 +82,7 @@ type certMagicClientWrapperMock struct {
 	}
 	lockGetCerticateRefs  sync.RWMutex
 	lockManageCertificate sync.RWMutex
+	lockRenewCertificate  sync.RWMutex
 	lockRevokeCertificate sync.RWMutex
 }
 
@@ -140,6 +154,42 @@ func (mock *certMagicClientWrapperMock) ManageCertificateCalls() []struct {
 	return calls
 }
 
+// RenewCertificate calls RenewCertificateFunc.
+func (mock *certMagicClientWrapperMock) RenewCertificate(ctx context.Context, domain string) error {
+	if mock.RenewCertificateFunc == nil {
+		panic("certMagicClientWrapperMock.RenewCertificateFunc: method is nil but certMagicClientWrapper.RenewCertificate was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Domain string
+	}{
+		Ctx:    ctx,
+		Domain: domain,
+	}
+	mock.lockRenewCertificate.Lock()
+	mock.calls.RenewCertificate = append(mock.calls.RenewCertificate, callInfo)
+	mock.lockRenewCertificate.Unlock()
+	return mock.RenewCertificateFunc(ctx, domain)
+}
+
+// RenewCertificateCalls gets all the calls that were made to RenewCertificate.
+// Check the length with:
+//
+//	len(mockedcertMagicClientWrapper.RenewCertificateCalls())
+func (mock *certMagicClientWrapperMock) RenewCertificateCalls() []struct {
+	Ctx    context.Context
+	Domain string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Domain string
+	}
+	mock.lockRenewCertificate.RLock()
+	calls = mock.calls.RenewCertificate
+	mock.lockRenewCertificate.RUnlock()
+	return calls
+}
+
 // RevokeCertificate calls RevokeCertificateFunc.
 func (mock *certMagicClientWrapperMock) RevokeCertificate(ctx context.Context, domain string, reason int) error {
 	if mock.RevokeCertificateFunc == nil {
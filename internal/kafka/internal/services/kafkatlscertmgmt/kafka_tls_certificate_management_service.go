@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 	"github.com/caddyserver/certmagic"
 	"github.com/libdns/route53"
@@ -42,6 +43,10 @@ type KafkaTLSCertificateManagementService interface {
 	// It returns the keys referencing the wildcard certificates location in the Storage
 	ManageCertificate(ctx context.Context, domain string) (CertificateManagementOutput, error)
 
+	// RenewCertificate renews the wildcard tls certificate of a given domain, even if it is not yet in the renewal window
+	// of the certificate management library. The renewed certificate is stored under the same keys.
+	RenewCertificate(ctx context.Context, domain string) error
+
 	// GetCertificate returns the tls certificate given the request.
 	// The certificate is returned from the underlying certificate storage when certificate management is automatic
 	// and that the certificate keys are defined i.e non empty string.
@@ -66,6 +71,8 @@ type KafkaTLSCertificateManagementService interface {
 type certMagicClientWrapper interface {
 	//ManageCertificate manages certificate (generation and renewals) by relying on the certificate management library
 	ManageCertificate(ctx context.Context, domainNames []string) error
+	//RenewCertificate renews the certificate for the given domain even if it is not close to expiring. This relies on the certificate management library renewal method.
+	RenewCertificate(ctx context.Context, domain string) error
 	//RevokeCertificate revoke the certificate for the given domain. This relies on the certificate management library revocation method.
 	RevokeCertificate(ctx context.Context, domain string, reason int) error
 	//GetCerticateRefs returns the certificate's references keys in the Storage for a given domain
@@ -80,6 +87,10 @@ func (w wrapper) ManageCertificate(ctx context.Context, domainNames []string) er
 	return w.wrappedClient.ManageSync(ctx, domainNames)
 }
 
+func (w wrapper) RenewCertificate(ctx context.Context, domain string) error {
+	return w.wrappedClient.RenewCertSync(ctx, domain, true)
+}
+
 func (w wrapper) RevokeCertificate(ctx context.Context, domain string, reason int) error {
 	return w.wrappedClient.RevokeCert(ctx, domain, reason, false)
 }
@@ -142,6 +153,14 @@ func (certManagementService *kafkaTLSCertificateManagementService) ManageCertifi
 	return certManagementService.certManagementClient.GetCerticateRefs(wildcardDomain), nil
 }
 
+func (certManagementService *kafkaTLSCertificateManagementService) RenewCertificate(ctx context.Context, domain string) error {
+	if certManagementService.config.CertificateManagementStrategy == config.ManualCertificateManagement {
+		return nil // the certificate is renewed manually in manual mode
+	}
+
+	return certManagementService.certManagementClient.RenewCertificate(ctx, fmt.Sprintf("*.%s", domain))
+}
+
 func (certManagementService *kafkaTLSCertificateManagementService) RevokeCertificate(ctx context.Context, domain string, reason CertificateRevocationReason) error {
 	if certManagementService.config.CertificateManagementStrategy == config.ManualCertificateManagement {
 		return nil // the certificate is revoked manually in manual mode
@@ -163,6 +182,7 @@ func (certManagementService *kafkaTLSCertificateManagementService) IsAutomaticCe
 func NewKafkaTLSCertificateManagementService(
 	awsConfig *config.AWSConfig,
 	kafkaTLSCertificateManagementConfig *config.KafkaTLSCertificateManagementConfig,
+	connectionFactory *db.ConnectionFactory,
 ) (KafkaTLSCertificateManagementService, error) {
 	var storage certmagic.Storage
 	var err error
@@ -177,6 +197,8 @@ func NewKafkaTLSCertificateManagementService(
 		}
 	case config.SecureTLSCertStorageType:
 		storage, err = newSecureStorage(awsConfig, kafkaTLSCertificateManagementConfig.AutomaticCertificateManagementConfig)
+	case config.PostgresTLSCertStorageType:
+		storage, err = newPostgresStorage(connectionFactory)
 	}
 
 	var certManagementClient certMagicClientWrapper
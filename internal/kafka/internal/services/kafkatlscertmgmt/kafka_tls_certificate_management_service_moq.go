@@ -30,6 +30,9 @@ var _ KafkaTLSCertificateManagementService = &KafkaTLSCertificateManagementServi
 //			ManageCertificateFunc: func(ctx context.Context, domain string) (CertificateManagementOutput, error) {
 //				panic("mock out the ManageCertificate method")
 //			},
+//			RenewCertificateFunc: func(ctx context.Context, domain string) error {
+//				panic("mock out the RenewCertificate method")
+//			},
 //			RevokeCertificateFunc: func(ctx context.Context, domain string, reason CertificateRevocationReason) error {
 //				panic("mock out the RevokeCertificate method")
 //			},
@@ -52,6 +55,9 @@ type KafkaTLSCertificateManagementServiceMock struct {
 	// ManageCertificateFunc mocks the ManageCertificate method.
 	ManageCertificateFunc func(ctx context.Context, domain string) (CertificateManagementOutput, error)
 
+	// RenewCertificateFunc mocks the RenewCertificate method.
+	RenewCertificateFunc func(ctx context.Context, domain string) error
+
 	// RevokeCertificateFunc mocks the RevokeCertificate method.
 	RevokeCertificateFunc func(ctx context.Context, domain string, reason CertificateRevocationReason) error
 
@@ -77,6 +83,13 @@ type KafkaTLSCertificateManagementServiceMock struct {
 			// Domain is the domain argument value.
 			Domain string
 		}
+		// RenewCertificate holds details about calls to the RenewCertificate method.
+		RenewCertificate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Domain is the domain argument value.
+			Domain string
+		}
 		// RevokeCertificate holds details about calls to the RevokeCertificate method.
 		RevokeCertificate []struct {
 			// Ctx is the ctx argument value.
@@ -91,6 +104,7 @@ type KafkaTLSCertificateManagementServiceMock struct {
 	lockIsAutomaticCertificateManagementEnabled sync.RWMutex
 	lockIsKafkaExternalCertificateEnabled       sync.RWMutex
 	lockManageCertificate                       sync.RWMutex
+	lockRenewCertificate                        sync.RWMutex
 	lockRevokeCertificate                       sync.RWMutex
 }
 
@@ -220,6 +234,42 @@ func (mock *KafkaTLSCertificateManagementServiceMock) ManageCertificateCalls() [
 	return calls
 }
 
+// RenewCertificate calls RenewCertificateFunc.
+func (mock *KafkaTLSCertificateManagementServiceMock) RenewCertificate(ctx context.Context, domain string) error {
+	if mock.RenewCertificateFunc == nil {
+		panic("KafkaTLSCertificateManagementServiceMock.RenewCertificateFunc: method is nil but KafkaTLSCertificateManagementService.RenewCertificate was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Domain string
+	}{
+		Ctx:    ctx,
+		Domain: domain,
+	}
+	mock.lockRenewCertificate.Lock()
+	mock.calls.RenewCertificate = append(mock.calls.RenewCertificate, callInfo)
+	mock.lockRenewCertificate.Unlock()
+	return mock.RenewCertificateFunc(ctx, domain)
+}
+
+// RenewCertificateCalls gets all the calls that were made to RenewCertificate.
+// Check the length with:
+//
+//	len(mockedKafkaTLSCertificateManagementService.RenewCertificateCalls())
+func (mock *KafkaTLSCertificateManagementServiceMock) RenewCertificateCalls() []struct {
+	Ctx    context.Context
+	Domain string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Domain string
+	}
+	mock.lockRenewCertificate.RLock()
+	calls = mock.calls.RenewCertificate
+	mock.lockRenewCertificate.RUnlock()
+	return calls
+}
+
 // RevokeCertificate calls RevokeCertificateFunc.
 func (mock *KafkaTLSCertificateManagementServiceMock) RevokeCertificate(ctx context.Context, domain string, reason CertificateRevocationReason) error {
 	if mock.RevokeCertificateFunc == nil {
@@ -216,6 +216,88 @@ func Test_kafkaTLSCertificateManagementService_RevokeCertificate(t *testing.T) {
 	}
 }
 
+func Test_kafkaTLSCertificateManagementService_RenewCertificate(t *testing.T) {
+	type fields struct {
+		config               *config.KafkaTLSCertificateManagementConfig
+		certManagementClient certMagicClientWrapper
+	}
+	type args struct {
+		domain string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "should return an error when renewing the certificate returns an error",
+			fields: fields{
+				config: &config.KafkaTLSCertificateManagementConfig{
+					CertificateManagementStrategy: config.AutomaticCertificateManagement,
+				},
+				certManagementClient: &certMagicClientWrapperMock{
+					RenewCertificateFunc: func(ctx context.Context, domain string) error {
+						return errors.New("some error")
+					},
+				},
+			},
+			args: args{
+				domain: "some-domain",
+			},
+			wantErr: true,
+		},
+		{
+			name: "should not renew the certificate if running in manual mode",
+			fields: fields{
+				config: &config.KafkaTLSCertificateManagementConfig{
+					CertificateManagementStrategy: config.ManualCertificateManagement,
+				},
+				certManagementClient: &certMagicClientWrapperMock{
+					RenewCertificateFunc: nil, // it should never be called
+				},
+			},
+			args: args{
+				domain: "some-domain",
+			},
+			wantErr: false,
+		},
+		{
+			name: "should renew the wildcard certificate of the domain",
+			fields: fields{
+				config: &config.KafkaTLSCertificateManagementConfig{
+					CertificateManagementStrategy: config.AutomaticCertificateManagement,
+				},
+				certManagementClient: &certMagicClientWrapperMock{
+					RenewCertificateFunc: func(ctx context.Context, domain string) error {
+						if domain != "*.some-domain" {
+							return errors.New("unexpected domain")
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				domain: "some-domain",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		testcase := tt
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+			certManagementService := &kafkaTLSCertificateManagementService{
+				certManagementClient: testcase.fields.certManagementClient,
+				config:               testcase.fields.config,
+			}
+			err := certManagementService.RenewCertificate(context.Background(), testcase.args.domain)
+			g := gomega.NewWithT(t)
+			g.Expect(err != nil).To(gomega.Equal(testcase.wantErr))
+		})
+	}
+}
+
 func Test_kafkaTLSCertificateManagementService_ManageCertificate(t *testing.T) {
 	type fields struct {
 		config               *config.KafkaTLSCertificateManagementConfig
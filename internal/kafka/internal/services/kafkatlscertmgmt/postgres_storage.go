@@ -0,0 +1,253 @@
+package kafkatlscertmgmt
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/encryption"
+	"github.com/caddyserver/certmagic"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const defaultPostgresStorageLockPollInterval = time.Second
+
+var _ certmagic.Storage = &postgresStorage{}
+
+// postgresStorage stores the items of the certificate management library in the tls_certificate_storage_items table
+// so that they are shared by all the replicas of the fleet manager. The values are encrypted with the db encryption.
+//
+// The locks are Postgres session level advisory locks: a lock is held by a dedicated connection of the pool until it is
+// unlocked, and it is released by the database if the replica holding it goes away. This ensures that only one
+// replica obtains or renews a given certificate at a time.
+type postgresStorage struct {
+	connectionFactory *db.ConnectionFactory
+	lockPollInterval  time.Duration
+
+	mu sync.Mutex
+	// locks are the connections holding the advisory locks by their name
+	locks map[string]*sql.Conn
+}
+
+func newPostgresStorage(connectionFactory *db.ConnectionFactory) (*postgresStorage, error) {
+	if encryption.DefaultCipher() == nil {
+		return nil, fmt.Errorf("the postgres tls certificate storage requires the db encryption, set --db-encryption-key-provider")
+	}
+	return &postgresStorage{
+		connectionFactory: connectionFactory,
+		lockPollInterval:  defaultPostgresStorageLockPollInterval,
+		locks:             map[string]*sql.Conn{},
+	}, nil
+}
+
+func (storage *postgresStorage) Lock(ctx context.Context, name string) error {
+	sqlDB, err := storage.connectionFactory.New().DB()
+	if err != nil {
+		return err
+	}
+	// the advisory locks belong to the session, so the lock is taken and released on the same connection
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get a connection to lock %q", name)
+	}
+
+	for {
+		var acquired bool
+		err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", advisoryLockID(name)).Scan(&acquired)
+		if err != nil {
+			_ = conn.Close()
+			return errors.Wrapf(err, "failed to lock %q", name)
+		}
+		if acquired {
+			storage.mu.Lock()
+			storage.locks[name] = conn
+			storage.mu.Unlock()
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+			return ctx.Err()
+		case <-time.After(storage.lockPollInterval):
+		}
+	}
+}
+
+func (storage *postgresStorage) Unlock(ctx context.Context, name string) error {
+	storage.mu.Lock()
+	conn, ok := storage.locks[name]
+	delete(storage.locks, name)
+	storage.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("lock %q is not held", name)
+	}
+
+	var released bool
+	err := conn.QueryRowContext(ctx, "SELECT pg_advisory_unlock($1)", advisoryLockID(name)).Scan(&released)
+	if err == nil && !released {
+		err = fmt.Errorf("lock %q is not held by the session", name)
+	}
+	if err != nil {
+		// the connection is discarded rather than returned to the pool, which ends the session and so releases the lock
+		if rawErr := conn.Raw(func(driverConn interface{}) error { return driver.ErrBadConn }); rawErr != nil && rawErr != driver.ErrBadConn {
+			glog.Errorf("failed to discard the connection holding lock %q: %v", name, rawErr)
+		}
+		_ = conn.Close()
+		return errors.Wrapf(err, "failed to unlock %q", name)
+	}
+	return conn.Close()
+}
+
+func (storage *postgresStorage) Store(ctx context.Context, key string, value []byte) error {
+	item := &dbapi.TLSCertificateStorageItem{
+		ID:    api.NewID(),
+		Key:   key,
+		Value: encryption.EncryptedString(base64.StdEncoding.EncodeToString(value)),
+	}
+	dbConn := storage.connectionFactory.New().WithContext(ctx)
+	err := dbConn.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+	}).Create(item).Error
+	return errors.Wrapf(err, "failed to store %q", key)
+}
+
+func (storage *postgresStorage) Load(ctx context.Context, key string) ([]byte, error) {
+	item, err := storage.get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	value, err := base64.StdEncoding.DecodeString(item.Value.String())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode %q", key)
+	}
+	return value, nil
+}
+
+// Delete deletes the key along with the keys it contains, if any
+func (storage *postgresStorage) Delete(ctx context.Context, key string) error {
+	dbConn := storage.connectionFactory.New().WithContext(ctx)
+	err := dbConn.Where("key = ? OR key LIKE ?", key, childKeysPattern(key)).Delete(&dbapi.TLSCertificateStorageItem{}).Error
+	return errors.Wrapf(err, "failed to delete %q", key)
+}
+
+func (storage *postgresStorage) Exists(ctx context.Context, key string) bool {
+	var count int64
+	dbConn := storage.connectionFactory.New().WithContext(ctx)
+	err := dbConn.Model(&dbapi.TLSCertificateStorageItem{}).Where("key = ? OR key LIKE ?", key, childKeysPattern(key)).Count(&count).Error
+	return err == nil && count > 0
+}
+
+func (storage *postgresStorage) List(ctx context.Context, prefix string, recursive bool) ([]string, error) {
+	var keys []string
+	dbConn := storage.connectionFactory.New().WithContext(ctx)
+	err := dbConn.Model(&dbapi.TLSCertificateStorageItem{}).Where("key LIKE ?", childKeysPattern(prefix)).Order("key").Pluck("key", &keys).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list %q", prefix)
+	}
+	if len(keys) == 0 {
+		return nil, fs.ErrNotExist
+	}
+	if recursive {
+		return keys, nil
+	}
+	return directChildKeys(prefix, keys), nil
+}
+
+func (storage *postgresStorage) Stat(ctx context.Context, key string) (certmagic.KeyInfo, error) {
+	item, err := storage.get(ctx, key)
+	if err == nil {
+		value, err := base64.StdEncoding.DecodeString(item.Value.String())
+		if err != nil {
+			return certmagic.KeyInfo{}, errors.Wrapf(err, "failed to decode %q", key)
+		}
+		return certmagic.KeyInfo{
+			Key:        key,
+			Modified:   item.UpdatedAt,
+			Size:       int64(len(value)),
+			IsTerminal: true,
+		}, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return certmagic.KeyInfo{}, err
+	}
+
+	// the key may be a "directory" of other keys
+	if !storage.Exists(ctx, key) {
+		return certmagic.KeyInfo{}, fs.ErrNotExist
+	}
+	return certmagic.KeyInfo{
+		Key:        key,
+		IsTerminal: false,
+	}, nil
+}
+
+func (storage *postgresStorage) String() string {
+	return "PostgresStorage"
+}
+
+func (storage *postgresStorage) get(ctx context.Context, key string) (*dbapi.TLSCertificateStorageItem, error) {
+	var item dbapi.TLSCertificateStorageItem
+	dbConn := storage.connectionFactory.New().WithContext(ctx)
+	if err := dbConn.Where("key = ?", key).First(&item).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fs.ErrNotExist
+		}
+		return nil, errors.Wrapf(err, "failed to load %q", key)
+	}
+	return &item, nil
+}
+
+// advisoryLockID maps the name of a lock to the 64 bits key of a Postgres advisory lock
+func advisoryLockID(name string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte("kafka-tls-certificate-storage/" + name))
+	return int64(h.Sum64())
+}
+
+// childKeysPattern returns the LIKE pattern matching the keys contained by the given key, as with the paths of a
+// directory
+func childKeysPattern(key string) string {
+	key = strings.TrimSuffix(key, "/")
+	if key == "" {
+		return "%"
+	}
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(key) + "/%"
+}
+
+// directChildKeys returns the keys directly contained by the prefix, the keys of the nested "directories" being
+// replaced by the key of the "directory"
+func directChildKeys(prefix string, keys []string) []string {
+	prefix = strings.TrimSuffix(prefix, "/")
+	var children []string
+	seen := map[string]bool{}
+	for _, key := range keys {
+		rest := strings.TrimPrefix(key, prefix+"/")
+		if prefix == "" {
+			rest = key
+		}
+		child, _, _ := strings.Cut(rest, "/")
+		if prefix != "" {
+			child = prefix + "/" + child
+		}
+		if !seen[child] {
+			seen[child] = true
+			children = append(children, child)
+		}
+	}
+	return children
+}
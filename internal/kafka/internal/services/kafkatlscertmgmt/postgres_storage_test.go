@@ -0,0 +1,201 @@
+package kafkatlscertmgmt
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/base64"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/encryption"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func setTestCipher(t *testing.T) *encryption.Cipher {
+	keyFile := filepath.Join(t.TempDir(), "db.encryption_key")
+	if err := os.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(make([]byte, 32))), 0600); err != nil {
+		t.Fatal(err)
+	}
+	keyProvider, err := encryption.NewLocalKeyProvider(keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cipher := encryption.NewCipher(keyProvider)
+	encryption.SetDefaultCipher(cipher)
+	return cipher
+}
+
+func Test_newPostgresStorage(t *testing.T) {
+	g := gomega.NewWithT(t)
+	defer encryption.SetDefaultCipher(nil)
+
+	_, err := newPostgresStorage(db.NewMockConnectionFactory(nil))
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("requires the db encryption")))
+
+	setTestCipher(t)
+	_, err = newPostgresStorage(db.NewMockConnectionFactory(nil))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+}
+
+func TestPostgresStorage_StoreLoad(t *testing.T) {
+	g := gomega.NewWithT(t)
+	defer encryption.SetDefaultCipher(nil)
+	cipher := setTestCipher(t)
+	storage, err := newPostgresStorage(db.NewMockConnectionFactory(nil))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	ctx := context.Background()
+
+	var stored string
+	mocket.Catcher.Reset()
+	mocket.Catcher.NewMock().WithQuery(`INSERT INTO "tls_certificate_storage_items"`).WithRowsNum(1).
+		WithCallback(func(query string, args []driver.NamedValue) {
+			g.Expect(query).To(gomega.ContainSubstring(`ON CONFLICT ("key") DO UPDATE SET "value"="excluded"."value","updated_at"="excluded"."updated_at"`))
+			for _, arg := range args {
+				if s, ok := arg.Value.(string); ok && encryption.IsEncrypted(s) {
+					stored = s
+				}
+			}
+		})
+	mocket.Catcher.NewMock().WithExecException().WithQueryException()
+	g.Expect(storage.Store(ctx, "certificates/acme/wildcard_.example.com/wildcard_.example.com.key", []byte("private key"))).To(gomega.Succeed())
+	// the value is encrypted before being written to the database
+	g.Expect(stored).ToNot(gomega.BeEmpty())
+	g.Expect(cipher.Decrypt(stored)).To(gomega.Equal(base64.StdEncoding.EncodeToString([]byte("private key"))))
+
+	mocket.Catcher.Reset()
+	mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "tls_certificate_storage_items" WHERE key = $1`).
+		WithArgs("certificates/acme/wildcard_.example.com/wildcard_.example.com.key").
+		WithReply([]map[string]interface{}{{
+			"id":         "id",
+			"key":        "certificates/acme/wildcard_.example.com/wildcard_.example.com.key",
+			"value":      stored,
+			"updated_at": time.Now(),
+		}})
+	mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "tls_certificate_storage_items"`).WithReply([]map[string]interface{}{})
+	mocket.Catcher.NewMock().WithExecException().WithQueryException()
+	g.Expect(storage.Load(ctx, "certificates/acme/wildcard_.example.com/wildcard_.example.com.key")).To(gomega.Equal([]byte("private key")))
+
+	_, err = storage.Load(ctx, "certificates/acme/unknown/unknown.key")
+	g.Expect(err).To(gomega.MatchError(fs.ErrNotExist))
+}
+
+func TestPostgresStorage_LockUnlock(t *testing.T) {
+	g := gomega.NewWithT(t)
+	defer encryption.SetDefaultCipher(nil)
+	setTestCipher(t)
+	storage, err := newPostgresStorage(db.NewMockConnectionFactory(nil))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	storage.lockPollInterval = time.Millisecond
+	ctx := context.Background()
+
+	attempts := 0
+	mocket.Catcher.Reset()
+	// the lock is held by another replica on the first attempt
+	mocket.Catcher.NewMock().WithQuery("SELECT pg_try_advisory_lock($1)").OneTime().
+		WithReply([]map[string]interface{}{{"pg_try_advisory_lock": false}}).
+		WithCallback(func(query string, args []driver.NamedValue) { attempts++ })
+	mocket.Catcher.NewMock().WithQuery("SELECT pg_try_advisory_lock($1)").
+		WithReply([]map[string]interface{}{{"pg_try_advisory_lock": true}}).
+		WithCallback(func(query string, args []driver.NamedValue) { attempts++ })
+	mocket.Catcher.NewMock().WithQuery("SELECT pg_advisory_unlock($1)").
+		WithReply([]map[string]interface{}{{"pg_advisory_unlock": true}})
+	mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+	g.Expect(storage.Lock(ctx, "issue_cert_*.example.com")).To(gomega.Succeed())
+	g.Expect(attempts).To(gomega.Equal(2))
+	g.Expect(storage.locks).To(gomega.HaveKey("issue_cert_*.example.com"))
+	g.Expect(storage.Unlock(ctx, "issue_cert_*.example.com")).To(gomega.Succeed())
+	g.Expect(storage.locks).To(gomega.BeEmpty())
+	g.Expect(storage.Unlock(ctx, "issue_cert_*.example.com")).To(gomega.MatchError(gomega.ContainSubstring("is not held")))
+
+	// the lock is never released by the other replica
+	mocket.Catcher.Reset()
+	mocket.Catcher.NewMock().WithQuery("SELECT pg_try_advisory_lock($1)").
+		WithReply([]map[string]interface{}{{"pg_try_advisory_lock": false}})
+	timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	g.Expect(storage.Lock(timeoutCtx, "issue_cert_*.example.com")).To(gomega.MatchError(context.DeadlineExceeded))
+	g.Expect(storage.locks).To(gomega.BeEmpty())
+}
+
+func Test_childKeysPattern(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		want string
+	}{
+		{
+			name: "should match all the keys of the root",
+			key:  "",
+			want: "%",
+		},
+		{
+			name: "should match the keys of the directory",
+			key:  "certificates/",
+			want: "certificates/%",
+		},
+		{
+			name: "should escape the wildcards of the key",
+			key:  `certificates/acme/wildcard_.example%\com`,
+			want: `certificates/acme/wildcard\_.example\%\\com/%`,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(childKeysPattern(tt.key)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_directChildKeys(t *testing.T) {
+	keys := []string{
+		"acme/ca/users/admin@example.com/admin.json",
+		"acme/ca/users/admin@example.com/admin.key",
+		"certificates/ca/a.example.com/a.example.com.crt",
+		"certificates/ca/a.example.com/a.example.com.key",
+		"certificates/ca/b.example.com/b.example.com.crt",
+		"last_clean.json",
+	}
+
+	tests := []struct {
+		name   string
+		prefix string
+		keys   []string
+		want   []string
+	}{
+		{
+			name:   "should return the top level keys",
+			prefix: "",
+			keys:   keys,
+			want:   []string{"acme", "certificates", "last_clean.json"},
+		},
+		{
+			name:   "should return the directories of the prefix",
+			prefix: "certificates/ca",
+			keys:   keys[2:5],
+			want:   []string{"certificates/ca/a.example.com", "certificates/ca/b.example.com"},
+		},
+		{
+			name:   "should return the keys of the prefix",
+			prefix: "certificates/ca/a.example.com/",
+			keys:   keys[2:4],
+			want:   []string{"certificates/ca/a.example.com/a.example.com.crt", "certificates/ca/a.example.com/a.example.com.key"},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(directChildKeys(tt.prefix, tt.keys)).To(gomega.Equal(tt.want))
+		})
+	}
+}
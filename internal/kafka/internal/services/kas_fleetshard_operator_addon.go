@@ -67,6 +67,7 @@ type kasFleetshardOperatorAddon struct {
 	KasFleetShardConfig *config.KasFleetshardConfig
 	OCMConfig           *ocm.OCMConfig
 	KeycloakConfig      *keycloak.KeycloakConfig
+	ClientSecretService ClusterClientSecretService
 }
 
 func (o *kasFleetshardOperatorAddon) Provision(cluster api.Cluster) (bool, ParameterList, *errors.ServiceError) {
@@ -130,15 +131,23 @@ func (o *kasFleetshardOperatorAddon) ReconcileParameters(cluster api.Cluster) (P
 }
 
 func (o *kasFleetshardOperatorAddon) GetAddonParams(cluster *api.Cluster) (ParameterList, *errors.ServiceError) {
-	var acc *api.ServiceAccount
-	if cluster.ClientID == "" || cluster.ClientSecret == "" {
-		var pErr *errors.ServiceError
-		acc, pErr = o.provisionServiceAccount(cluster.ClusterID)
+	clientId := cluster.ClientID
+	clientSecret, err := o.ClientSecretService.GetClientSecret(cluster)
+	if err != nil {
+		return nil, err
+	}
+	if clientId == "" || clientSecret == "" {
+		acc, pErr := o.provisionServiceAccount(cluster.ClusterID)
 		if pErr != nil {
 			return nil, errors.GeneralError("failed to create service account for cluster %s due to error: %v", cluster.ClusterID, pErr)
 		}
+		if err := o.ClientSecretService.StoreClientSecret(cluster, acc.ClientSecret); err != nil {
+			return nil, err
+		}
+		clientId = acc.ClientID
+		clientSecret = acc.ClientSecret
 	}
-	params := o.buildAddonParams(cluster, acc)
+	params := o.buildAddonParams(cluster, clientId, clientSecret)
 	return params, nil
 }
 
@@ -147,19 +156,7 @@ func (o *kasFleetshardOperatorAddon) provisionServiceAccount(clusterId string) (
 	return o.SsoService.RegisterKasFleetshardOperatorServiceAccount(clusterId)
 }
 
-func (o *kasFleetshardOperatorAddon) buildAddonParams(cluster *api.Cluster, serviceAccount *api.ServiceAccount) []types.Parameter {
-
-	var clientId string
-	var clientSecret string
-
-	if cluster.ClientID != "" && cluster.ClientSecret != "" {
-		clientId = cluster.ClientID
-		clientSecret = cluster.ClientSecret
-	} else {
-		clientId = serviceAccount.ClientID
-		clientSecret = serviceAccount.ClientSecret
-	}
-
+func (o *kasFleetshardOperatorAddon) buildAddonParams(cluster *api.Cluster, clientId string, clientSecret string) []types.Parameter {
 	p := []types.Parameter{
 
 		{
@@ -196,5 +193,8 @@ func (o *kasFleetshardOperatorAddon) buildAddonParams(cluster *api.Cluster, serv
 
 func (o *kasFleetshardOperatorAddon) RemoveServiceAccount(cluster api.Cluster) *errors.ServiceError {
 	glog.V(5).Infof("Removing kas-fleetshard-operator service account for cluster %s", cluster.ClusterID)
-	return o.SsoService.DeRegisterKasFleetshardOperatorServiceAccount(cluster.ClusterID)
+	if err := o.SsoService.DeRegisterKasFleetshardOperatorServiceAccount(cluster.ClusterID); err != nil {
+		return err
+	}
+	return o.ClientSecretService.DeleteClientSecret(&cluster)
 }
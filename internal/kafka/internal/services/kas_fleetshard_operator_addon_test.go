@@ -86,6 +86,14 @@ func Test_AgentOperatorAddon_Provision(t *testing.T) {
 				KeycloakConfig: &keycloak.KeycloakConfig{
 					KafkaRealm: &keycloak.KeycloakRealmConfig{},
 				},
+				ClientSecretService: &ClusterClientSecretServiceMock{
+					GetClientSecretFunc: func(cluster *api.Cluster) (string, *errors.ServiceError) {
+						return "", nil
+					},
+					StoreClientSecretFunc: func(cluster *api.Cluster, clientSecret string) *errors.ServiceError {
+						return nil
+					},
+				},
 			}
 			ready, _, err := agentOperatorAddon.Provision(api.Cluster{
 				ClusterID:    "test-cluster-id",
@@ -101,7 +109,8 @@ func Test_AgentOperatorAddon_Provision(t *testing.T) {
 
 func Test_AgentOperatorAddon_RemoveServiceAccount(t *testing.T) {
 	type fields struct {
-		ssoService sso.KeycloakService
+		ssoService          sso.KeycloakService
+		clientSecretService ClusterClientSecretService
 	}
 	tests := []struct {
 		name    string
@@ -119,6 +128,22 @@ func Test_AgentOperatorAddon_RemoveServiceAccount(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "receives error when the client secret of the cluster cannot be deleted",
+			fields: fields{
+				ssoService: &sso.KeycloakServiceMock{
+					DeRegisterKasFleetshardOperatorServiceAccountFunc: func(agentClusterId string) *errors.ServiceError {
+						return nil
+					},
+				},
+				clientSecretService: &ClusterClientSecretServiceMock{
+					DeleteClientSecretFunc: func(cluster *api.Cluster) *errors.ServiceError {
+						return errors.GeneralError("failed to delete the client secret")
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "succesful removes the service account when fleetshard operator is turned on",
 			fields: fields{
@@ -127,6 +152,11 @@ func Test_AgentOperatorAddon_RemoveServiceAccount(t *testing.T) {
 						return nil
 					},
 				},
+				clientSecretService: &ClusterClientSecretServiceMock{
+					DeleteClientSecretFunc: func(cluster *api.Cluster) *errors.ServiceError {
+						return nil
+					},
+				},
 			},
 			wantErr: false,
 		},
@@ -138,7 +168,8 @@ func Test_AgentOperatorAddon_RemoveServiceAccount(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
 			agentOperatorAddon := &kasFleetshardOperatorAddon{
-				SsoService: tt.fields.ssoService,
+				SsoService:          tt.fields.ssoService,
+				ClientSecretService: tt.fields.clientSecretService,
 			}
 			err := agentOperatorAddon.RemoveServiceAccount(api.Cluster{
 				ClusterID:    "test-cluster-id",
@@ -215,6 +246,14 @@ func Test_KasFleetshardOperatorAddon_ReconcileParameters(t *testing.T) {
 				KeycloakConfig: &keycloak.KeycloakConfig{
 					KafkaRealm: &keycloak.KeycloakRealmConfig{},
 				},
+				ClientSecretService: &ClusterClientSecretServiceMock{
+					GetClientSecretFunc: func(cluster *api.Cluster) (string, *errors.ServiceError) {
+						return "", nil
+					},
+					StoreClientSecretFunc: func(cluster *api.Cluster, clientSecret string) *errors.ServiceError {
+						return nil
+					},
+				},
 			}
 			_, err := agentOperatorAddon.ReconcileParameters(api.Cluster{
 				ClusterID:    "test-cluster-id",
@@ -227,6 +266,80 @@ func Test_KasFleetshardOperatorAddon_ReconcileParameters(t *testing.T) {
 	}
 }
 
+func Test_KasFleetshardOperatorAddon_GetAddonParams(t *testing.T) {
+	tests := []struct {
+		name               string
+		cluster            api.Cluster
+		storedClientSecret string
+		wantClientID       string
+		wantClientSecret   string
+		wantRegisterCount  int
+		wantStoreCount     int
+	}{
+		{
+			name:               "should use the service account of the cluster and its client secret from the vault",
+			cluster:            api.Cluster{ClusterID: "test-cluster-id", ClientID: "client-id"},
+			storedClientSecret: "client-secret",
+			wantClientID:       "client-id",
+			wantClientSecret:   "client-secret",
+		},
+		{
+			name:              "should create a service account and store its client secret when the cluster has none",
+			cluster:           api.Cluster{ClusterID: "test-cluster-id"},
+			wantClientID:      "new-client-id",
+			wantClientSecret:  "new-client-secret",
+			wantRegisterCount: 1,
+			wantStoreCount:    1,
+		},
+		{
+			name:              "should create a service account when the client secret of the cluster is missing",
+			cluster:           api.Cluster{ClusterID: "test-cluster-id", ClientID: "client-id"},
+			wantClientID:      "new-client-id",
+			wantClientSecret:  "new-client-secret",
+			wantRegisterCount: 1,
+			wantStoreCount:    1,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			ssoService := &sso.KeycloakServiceMock{
+				RegisterKasFleetshardOperatorServiceAccountFunc: func(agentClusterId string) (*api.ServiceAccount, *errors.ServiceError) {
+					return &api.ServiceAccount{ClientID: "new-client-id", ClientSecret: "new-client-secret"}, nil
+				},
+				GetRealmConfigFunc: func() *keycloak.KeycloakRealmConfig {
+					return &keycloak.KeycloakRealmConfig{}
+				},
+			}
+			clientSecretService := &ClusterClientSecretServiceMock{
+				GetClientSecretFunc: func(cluster *api.Cluster) (string, *errors.ServiceError) {
+					return tt.storedClientSecret, nil
+				},
+				StoreClientSecretFunc: func(cluster *api.Cluster, clientSecret string) *errors.ServiceError {
+					g.Expect(clientSecret).To(gomega.Equal(tt.wantClientSecret))
+					return nil
+				},
+			}
+			agentOperatorAddon := &kasFleetshardOperatorAddon{
+				SsoService:          ssoService,
+				ServerConfig:        &server.ServerConfig{},
+				KasFleetShardConfig: &config.KasFleetshardConfig{},
+				ClientSecretService: clientSecretService,
+			}
+
+			params, err := agentOperatorAddon.GetAddonParams(&tt.cluster)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(params.GetParam(KasFleetshardOperatorParamServiceAccountId)).To(gomega.Equal(tt.wantClientID))
+			g.Expect(params.GetParam(KasFleetshardOperatorParamServiceAccountSecret)).To(gomega.Equal(tt.wantClientSecret))
+			g.Expect(ssoService.RegisterKasFleetshardOperatorServiceAccountCalls()).To(gomega.HaveLen(tt.wantRegisterCount))
+			g.Expect(clientSecretService.StoreClientSecretCalls()).To(gomega.HaveLen(tt.wantStoreCount))
+		})
+	}
+}
+
 func Test_ParameterList_GetParam(t *testing.T) {
 	type args struct {
 		name string
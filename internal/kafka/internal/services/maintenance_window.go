@@ -0,0 +1,97 @@
+package services
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"gorm.io/gorm/clause"
+)
+
+// MaintenanceWindowService manages the weekly maintenance windows of the organisations. The maintenance window of an
+// organisation applies to its Kafka instances for which no maintenance window has been chosen.
+//
+//go:generate moq -out maintenance_window_moq.go . MaintenanceWindowService
+type MaintenanceWindowService interface {
+	// GetByOrganisationId returns the maintenance window of the given organisation. It is nil when the organisation
+	// has not chosen a maintenance window.
+	GetByOrganisationId(organisationId string) (*dbapi.MaintenanceWindow, *errors.ServiceError)
+	// ListByOrganisationIds returns the maintenance windows of the given organisations indexed by organisation id
+	ListByOrganisationIds(organisationIds []string) (map[string]*dbapi.MaintenanceWindow, *errors.ServiceError)
+	// Upsert creates or replaces the maintenance window of the organisation of the given maintenance window
+	Upsert(window *dbapi.MaintenanceWindow) *errors.ServiceError
+	// DeleteByOrganisationId removes the maintenance window of the given organisation
+	DeleteByOrganisationId(organisationId string) *errors.ServiceError
+	// GetKafkaSchedule returns the maintenance window applying to the given kafka: its own maintenance window if one
+	// has been chosen, the maintenance window of its organisation otherwise. It is nil when neither has been chosen.
+	GetKafkaSchedule(kafka *dbapi.KafkaRequest) (*dbapi.MaintenanceWindowSchedule, *errors.ServiceError)
+}
+
+var _ MaintenanceWindowService = &maintenanceWindowService{}
+
+type maintenanceWindowService struct {
+	connectionFactory *db.ConnectionFactory
+}
+
+func NewMaintenanceWindowService(connectionFactory *db.ConnectionFactory) MaintenanceWindowService {
+	return &maintenanceWindowService{
+		connectionFactory: connectionFactory,
+	}
+}
+
+func (s *maintenanceWindowService) GetByOrganisationId(organisationId string) (*dbapi.MaintenanceWindow, *errors.ServiceError) {
+	windows, err := s.ListByOrganisationIds([]string{organisationId})
+	if err != nil {
+		return nil, err
+	}
+	return windows[organisationId], nil
+}
+
+func (s *maintenanceWindowService) ListByOrganisationIds(organisationIds []string) (map[string]*dbapi.MaintenanceWindow, *errors.ServiceError) {
+	res := map[string]*dbapi.MaintenanceWindow{}
+	if len(organisationIds) == 0 {
+		return res, nil
+	}
+
+	var windows []*dbapi.MaintenanceWindow
+	if err := s.connectionFactory.New().Where("organisation_id IN (?)", organisationIds).Find(&windows).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list the maintenance windows of the organisations")
+	}
+	for _, window := range windows {
+		res[window.OrganisationId] = window
+	}
+
+	return res, nil
+}
+
+func (s *maintenanceWindowService) Upsert(window *dbapi.MaintenanceWindow) *errors.ServiceError {
+	onConflict := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "organisation_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"day_of_week", "start_hour", "updated_at"}),
+	}
+	if err := s.connectionFactory.New().Clauses(onConflict).Create(window).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to save the maintenance window of organisation %q", window.OrganisationId)
+	}
+
+	return nil
+}
+
+func (s *maintenanceWindowService) DeleteByOrganisationId(organisationId string) *errors.ServiceError {
+	if err := s.connectionFactory.New().Where("organisation_id = ?", organisationId).Delete(&dbapi.MaintenanceWindow{}).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to delete the maintenance window of organisation %q", organisationId)
+	}
+
+	return nil
+}
+
+func (s *maintenanceWindowService) GetKafkaSchedule(kafka *dbapi.KafkaRequest) (*dbapi.MaintenanceWindowSchedule, *errors.ServiceError) {
+	if schedule := kafka.OwnMaintenanceWindow(); schedule != nil {
+		return schedule, nil
+	}
+
+	window, err := s.GetByOrganisationId(kafka.OrganisationId)
+	if err != nil || window == nil {
+		return nil, err
+	}
+
+	return window.Schedule(), nil
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_maintenanceWindowService_ListByOrganisationIds(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	mocket.Catcher.Reset()
+	mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "maintenance_windows" WHERE organisation_id IN ($1,$2)`).WithArgs("org-1", "org-2").WithReply([]map[string]interface{}{
+		{"id": "window-1", "organisation_id": "org-1", "day_of_week": "sunday", "start_hour": 2},
+	})
+	mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+	windows, err := NewMaintenanceWindowService(db.NewMockConnectionFactory(nil)).ListByOrganisationIds([]string{"org-1", "org-2"})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(windows).To(gomega.Equal(map[string]*dbapi.MaintenanceWindow{
+		"org-1": {ID: "window-1", OrganisationId: "org-1", DayOfWeek: "sunday", StartHour: 2},
+	}))
+}
+
+func Test_maintenanceWindowService_Upsert(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	mocket.Catcher.Reset()
+	upsert := mocket.Catcher.NewMock().WithQuery(`INSERT INTO "maintenance_windows" ("id","organisation_id","day_of_week","start_hour","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6) ON CONFLICT ("organisation_id") DO UPDATE SET "day_of_week"="excluded"."day_of_week","start_hour"="excluded"."start_hour","updated_at"="excluded"."updated_at"`)
+	mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+	err := NewMaintenanceWindowService(db.NewMockConnectionFactory(nil)).Upsert(&dbapi.MaintenanceWindow{OrganisationId: "org-1", DayOfWeek: "sunday", StartHour: 2})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(upsert.Triggered).To(gomega.BeTrue())
+}
+
+func Test_maintenanceWindowService_DeleteByOrganisationId(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	mocket.Catcher.Reset()
+	deletion := mocket.Catcher.NewMock().WithQuery(`DELETE FROM "maintenance_windows" WHERE organisation_id = $1`).WithArgs("org-1")
+	mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+	err := NewMaintenanceWindowService(db.NewMockConnectionFactory(nil)).DeleteByOrganisationId("org-1")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(deletion.Triggered).To(gomega.BeTrue())
+}
+
+func Test_maintenanceWindowService_GetKafkaSchedule(t *testing.T) {
+	organisationWindowQuery := `SELECT * FROM "maintenance_windows" WHERE organisation_id IN ($1)`
+
+	tests := []struct {
+		name               string
+		kafka              *dbapi.KafkaRequest
+		organisationWindow []map[string]interface{}
+		want               *dbapi.MaintenanceWindowSchedule
+	}{
+		{
+			name:               "should return the maintenance window of the kafka",
+			kafka:              &dbapi.KafkaRequest{OrganisationId: "org-1", MaintenanceWindowDay: "friday", MaintenanceWindowStartHour: 20},
+			organisationWindow: []map[string]interface{}{{"id": "window-1", "organisation_id": "org-1", "day_of_week": "sunday", "start_hour": 2}},
+			want:               &dbapi.MaintenanceWindowSchedule{DayOfWeek: time.Friday, StartHour: 20},
+		},
+		{
+			name:               "should fall back on the maintenance window of the organisation",
+			kafka:              &dbapi.KafkaRequest{OrganisationId: "org-1"},
+			organisationWindow: []map[string]interface{}{{"id": "window-1", "organisation_id": "org-1", "day_of_week": "sunday", "start_hour": 2}},
+			want:               &dbapi.MaintenanceWindowSchedule{DayOfWeek: time.Sunday, StartHour: 2},
+		},
+		{
+			name:  "should return nil when no maintenance window has been chosen",
+			kafka: &dbapi.KafkaRequest{OrganisationId: "org-1"},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			mocket.Catcher.NewMock().WithQuery(organisationWindowQuery).WithArgs("org-1").WithReply(tt.organisationWindow)
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			schedule, err := NewMaintenanceWindowService(db.NewMockConnectionFactory(nil)).GetKafkaSchedule(tt.kafka)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(schedule).To(gomega.Equal(tt.want))
+		})
+	}
+}
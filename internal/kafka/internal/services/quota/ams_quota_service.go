@@ -14,6 +14,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	"net/http"
 )
 
 type AMSQuotaService interface {
@@ -359,7 +360,11 @@ func (q amsQuotaService) DeleteQuota(subscriptionID string) *errors.ServiceError
 		return nil
 	}
 
-	_, err := q.amsClient.DeleteSubscription(subscriptionID)
+	status, err := q.amsClient.DeleteSubscription(subscriptionID)
+	if status == http.StatusNotFound {
+		// the quota has already been released
+		return nil
+	}
 	if err != nil {
 		return errors.GeneralError("failed to delete the quota: %v", err)
 	}
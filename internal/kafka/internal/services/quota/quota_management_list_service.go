@@ -3,6 +3,7 @@ package quota
 import (
 	"fmt"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/quota_management"
@@ -24,7 +25,9 @@ const (
 type QuotaManagementListService struct {
 	connectionFactory   *db.ConnectionFactory
 	quotaManagementList *quota_management.QuotaManagementListConfig
-	kafkaConfig         *config.KafkaConfig
+	// quotaManagementListEntries gives access to the organisations and service accounts of the quota management list
+	quotaManagementListEntries services.QuotaManagementListEntriesService
+	kafkaConfig                *config.KafkaConfig
 }
 
 func (q QuotaManagementListService) ReserveQuotaIfNotAlreadyReserved(kafka *dbapi.KafkaRequest) (string, *errors.ServiceError) {
@@ -46,14 +49,20 @@ func (q QuotaManagementListService) ValidateBillingAccount(organisationId string
 func (q QuotaManagementListService) CheckIfQuotaIsDefinedForInstanceType(username string, organisationId string, instanceType types.KafkaInstanceType, kafkaBillingModel config.KafkaBillingModel) (bool, *errors.ServiceError) {
 	orgId := organisationId
 	var account quota_management.Account
-	org, orgFound := q.quotaManagementList.QuotaList.Organisations.GetById(orgId)
+	org, orgFound, err := q.findOrganisation(orgId)
+	if err != nil {
+		return false, err
+	}
 	userIsRegistered := false
 	serviceAccountIsRegistered := false
 
 	if orgFound && org.IsUserRegistered(username) {
 		userIsRegistered = true
 	} else {
-		account, serviceAccountIsRegistered = q.quotaManagementList.QuotaList.ServiceAccounts.GetByUsername(username)
+		account, serviceAccountIsRegistered, err = q.findServiceAccount(username)
+		if err != nil {
+			return false, err
+		}
 	}
 
 	// if the user is registered, check that he has quota defined for the desired instance type
@@ -94,14 +103,20 @@ func (q QuotaManagementListService) ReserveQuota(kafka *dbapi.KafkaRequest) (str
 	orgId := kafka.OrganisationId
 	var quotaManagementListItem quota_management.QuotaManagementListItem
 	message := fmt.Sprintf("user '%s' has reached a maximum number of %d allowed streaming units", username, quota_management.GetDefaultMaxAllowedInstances())
-	org, orgFound := q.quotaManagementList.QuotaList.Organisations.GetById(orgId)
+	org, orgFound, err := q.findOrganisation(orgId)
+	if err != nil {
+		return "", err
+	}
 	filterByOrg := false
 	if orgFound && org.IsUserRegistered(username) {
 		quotaManagementListItem = org
 		message = fmt.Sprintf("organization '%s' has reached a maximum number of %d allowed streaming units", orgId, org.GetMaxAllowedInstances(kafka.InstanceType, kafka.DesiredKafkaBillingModel))
 		filterByOrg = true
 	} else {
-		user, userFound := q.quotaManagementList.QuotaList.ServiceAccounts.GetByUsername(username)
+		user, userFound, err := q.findServiceAccount(username)
+		if err != nil {
+			return "", err
+		}
 		if userFound {
 			quotaManagementListItem = user
 			message = fmt.Sprintf("user '%s' has reached a maximum number of %d allowed streaming units", username, user.GetMaxAllowedInstances(kafka.InstanceType, kafka.DesiredKafkaBillingModel))
@@ -115,7 +130,15 @@ func (q QuotaManagementListService) ReserveQuota(kafka *dbapi.KafkaRequest) (str
 	dbConn := q.connectionFactory.New().
 		Model(&dbapi.KafkaRequest{}).
 		Where("instance_type = ?", kafka.InstanceType).
-		Where("actual_kafka_billing_model = ? or desired_kafka_billing_model = ?", kafka.DesiredKafkaBillingModel, kafka.DesiredKafkaBillingModel)
+		Where("actual_kafka_billing_model = ? or desired_kafka_billing_model = ?", kafka.DesiredKafkaBillingModel, kafka.DesiredKafkaBillingModel).
+		// instances suspended by a user, or being suspended as their quota is released as soon as the suspension is
+		// requested, do not consume quota. Their quota is reserved again when they are resumed
+		Where("NOT (status IN (?) AND suspended_by <> '')", constants.GetSuspendedStatuses())
+	if kafka.ID != "" {
+		// the quota is reserved for the whole size of the kafka, e.g. when it is resized, so the size it consumes so far is
+		// not counted
+		dbConn = dbConn.Where("id <> ?", kafka.ID)
+	}
 
 	if kafka.InstanceType != types.DEVELOPER.String() && filterByOrg {
 		dbConn = dbConn.Where("organisation_id = ?", orgId)
@@ -188,12 +211,18 @@ func (q QuotaManagementListService) detectBillingModel(kafka *dbapi.KafkaRequest
 
 	var grantedQuota []quota_management.Quota
 
-	org, orgFound := q.quotaManagementList.QuotaList.Organisations.GetById(kafka.OrganisationId)
+	org, orgFound, err := q.findOrganisation(kafka.OrganisationId)
+	if err != nil {
+		return "", err
+	}
 	username := kafka.Owner
 	if orgFound {
 		grantedQuota = org.GetGrantedQuota()
 	} else {
-		user, userFound := q.quotaManagementList.QuotaList.ServiceAccounts.GetByUsername(username)
+		user, userFound, err := q.findServiceAccount(username)
+		if err != nil {
+			return "", err
+		}
 		if userFound {
 			grantedQuota = user.GetGrantedQuota()
 		} else {
@@ -239,7 +268,10 @@ func (q QuotaManagementListService) IsQuotaEntitlementActive(kafka *dbapi.KafkaR
 
 	var billingModel *quota_management.BillingModel
 
-	org, orgFound := q.quotaManagementList.QuotaList.Organisations.GetById(kafka.OrganisationId)
+	org, orgFound, err := q.findOrganisation(kafka.OrganisationId)
+	if err != nil {
+		return false, err
+	}
 	if orgFound && org.IsUserRegistered(kafka.Owner) {
 		logger.Logger.Infof("user registered by organisation, checking quota entitlement for organisation %q", org.Id)
 		bm, ok := org.GetBillingModel(kafka.InstanceType, kafka.ActualKafkaBillingModel)
@@ -248,7 +280,10 @@ func (q QuotaManagementListService) IsQuotaEntitlementActive(kafka *dbapi.KafkaR
 		}
 	} else {
 		logger.Logger.Infof("user is not registered by organisation, checking quota entitlement for %q as an individual account", kafka.Owner)
-		account, accountFound := q.quotaManagementList.QuotaList.ServiceAccounts.GetByUsername(kafka.Owner)
+		account, accountFound, err := q.findServiceAccount(kafka.Owner)
+		if err != nil {
+			return false, err
+		}
 		if accountFound {
 			bm, ok := account.GetBillingModel(kafka.InstanceType, kafka.ActualKafkaBillingModel)
 			if ok {
@@ -264,3 +299,21 @@ func (q QuotaManagementListService) IsQuotaEntitlementActive(kafka *dbapi.KafkaR
 
 	return true, nil
 }
+
+// findOrganisation returns the organisation with the given id from the quota management list, if any
+func (q QuotaManagementListService) findOrganisation(orgId string) (quota_management.Organisation, bool, *errors.ServiceError) {
+	org, err := q.quotaManagementListEntries.FindOrganisationById(orgId)
+	if err != nil || org == nil {
+		return quota_management.Organisation{}, false, err
+	}
+	return *org, true, nil
+}
+
+// findServiceAccount returns the service account with the given username from the quota management list, if any
+func (q QuotaManagementListService) findServiceAccount(username string) (quota_management.Account, bool, *errors.ServiceError) {
+	account, err := q.quotaManagementListEntries.FindServiceAccountByUsername(username)
+	if err != nil || account == nil {
+		return quota_management.Account{}, false, err
+	}
+	return *account, true, nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/converters"
@@ -273,7 +274,7 @@ func Test_QuotaManagementListCheckQuota(t *testing.T) {
 
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			factory := NewDefaultQuotaServiceFactory(nil, tt.fields.connectionFactory, tt.fields.QuotaManagementList, &defaultKafkaConf)
+			factory := NewDefaultQuotaServiceFactory(nil, tt.fields.connectionFactory, tt.fields.QuotaManagementList, newQuotaManagementListEntriesMock(tt.fields.QuotaManagementList), &defaultKafkaConf)
 			quotaService, _ := factory.GetQuotaService(api.QuotaManagementListQuotaType)
 			kafka := &dbapi.KafkaRequest{
 				Owner:          "username",
@@ -328,6 +329,7 @@ func Test_QuotaManagementListReserveQuota(t *testing.T) {
 
 	type args struct {
 		instanceType types.KafkaInstanceType
+		kafkaId      string
 	}
 
 	tests := []struct {
@@ -394,8 +396,8 @@ func Test_QuotaManagementListReserveQuota(t *testing.T) {
 			setupFn: func() {
 				mocket.Catcher.Reset()
 				mocket.Catcher.NewMock().
-					WithQuery(`SELECT * FROM "kafka_requests" WHERE instance_type = $1 AND (actual_kafka_billing_model = $2 or desired_kafka_billing_model = $3) AND (organisation_id = $4) AND "kafka_requests"."deleted_at" IS NULL`).
-					WithArgs(types.STANDARD.String(), "standard", "standard", "org-id").
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE instance_type = $1 AND (actual_kafka_billing_model = $2 or desired_kafka_billing_model = $3) AND (NOT (status IN ($4,$5) AND suspended_by <> '')) AND (organisation_id = $6) AND "kafka_requests"."deleted_at" IS NULL`).
+					WithArgs(types.STANDARD.String(), "standard", "standard", constants.KafkaRequestStatusSuspending.String(), constants.KafkaRequestStatusSuspended.String(), "org-id").
 					WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(nil)))
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
@@ -430,8 +432,8 @@ func Test_QuotaManagementListReserveQuota(t *testing.T) {
 			setupFn: func() {
 				mocket.Catcher.Reset()
 				mocket.Catcher.NewMock().
-					WithQuery(`SELECT * FROM "kafka_requests" WHERE instance_type = $1 AND (actual_kafka_billing_model = $2 or desired_kafka_billing_model = $3) AND owner = $4 AND "kafka_requests"."deleted_at" IS NULL`).
-					WithArgs(types.DEVELOPER.String(), "standard", "standard", "username").
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE instance_type = $1 AND (actual_kafka_billing_model = $2 or desired_kafka_billing_model = $3) AND (NOT (status IN ($4,$5) AND suspended_by <> '')) AND owner = $6 AND "kafka_requests"."deleted_at" IS NULL`).
+					WithArgs(types.DEVELOPER.String(), "standard", "standard", constants.KafkaRequestStatusSuspending.String(), constants.KafkaRequestStatusSuspended.String(), "username").
 					WithReply(nil)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
@@ -457,8 +459,8 @@ func Test_QuotaManagementListReserveQuota(t *testing.T) {
 			setupFn: func() {
 				mocket.Catcher.Reset()
 				mocket.Catcher.NewMock().
-					WithQuery(`SELECT * FROM "kafka_requests" WHERE instance_type = $1 AND (actual_kafka_billing_model = $2 or desired_kafka_billing_model = $3) AND owner = $4 AND "kafka_requests"."deleted_at" IS NULL`).
-					WithArgs(types.DEVELOPER.String(), "standard", "standard", "username").
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE instance_type = $1 AND (actual_kafka_billing_model = $2 or desired_kafka_billing_model = $3) AND (NOT (status IN ($4,$5) AND suspended_by <> '')) AND owner = $6 AND "kafka_requests"."deleted_at" IS NULL`).
+					WithArgs(types.DEVELOPER.String(), "standard", "standard", constants.KafkaRequestStatusSuspending.String(), constants.KafkaRequestStatusSuspended.String(), "username").
 					WithReply(converters.ConvertKafkaRequest(
 						buildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
 							kafkaRequest.Owner = "username"
@@ -497,8 +499,8 @@ func Test_QuotaManagementListReserveQuota(t *testing.T) {
 			setupFn: func() {
 				mocket.Catcher.Reset()
 				mocket.Catcher.NewMock().
-					WithQuery(`SELECT * FROM "kafka_requests" WHERE instance_type = $1 AND (actual_kafka_billing_model = $2 or desired_kafka_billing_model = $3) AND (organisation_id = $4) AND "kafka_requests"."deleted_at" IS NULL`).
-					WithArgs(types.STANDARD.String(), "standard", "standard", "org-id").
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE instance_type = $1 AND (actual_kafka_billing_model = $2 or desired_kafka_billing_model = $3) AND (NOT (status IN ($4,$5) AND suspended_by <> '')) AND (organisation_id = $6) AND "kafka_requests"."deleted_at" IS NULL`).
+					WithArgs(types.STANDARD.String(), "standard", "standard", constants.KafkaRequestStatusSuspending.String(), constants.KafkaRequestStatusSuspended.String(), "org-id").
 					WithReply(converters.ConvertKafkaRequest(buildKafkaRequest(nil)))
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
@@ -507,6 +509,37 @@ func Test_QuotaManagementListReserveQuota(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "does not count the size of the kafka the quota is reserved for, e.g. when it is resized",
+			fields: fields{
+				connectionFactory: db.NewMockConnectionFactory(nil),
+				QuotaManagementList: &quota_management.QuotaManagementListConfig{
+					EnableInstanceLimitControl: true,
+					QuotaList: quota_management.RegisteredUsersListConfiguration{
+						Organisations: quota_management.OrganisationList{
+							quota_management.Organisation{
+								Id:                  "org-id",
+								MaxAllowedInstances: 1,
+								AnyUser:             true,
+							},
+						},
+					},
+				},
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE instance_type = $1 AND (actual_kafka_billing_model = $2 or desired_kafka_billing_model = $3) AND (NOT (status IN ($4,$5) AND suspended_by <> '')) AND id <> $6 AND (organisation_id = $7) AND "kafka_requests"."deleted_at" IS NULL`).
+					WithArgs(types.STANDARD.String(), "standard", "standard", constants.KafkaRequestStatusSuspending.String(), constants.KafkaRequestStatusSuspended.String(), "kafka-id", "org-id").
+					WithReply(nil)
+				mocket.Catcher.NewMock().WithExecException().WithQueryException()
+			},
+			args: args{
+				instanceType: types.STANDARD,
+				kafkaId:      "kafka-id",
+			},
+			wantErr: nil,
+		},
 		{
 			name: "do not return an error when user who's not in the quota list can developer instances",
 			fields: fields{
@@ -518,8 +551,8 @@ func Test_QuotaManagementListReserveQuota(t *testing.T) {
 			setupFn: func() {
 				mocket.Catcher.Reset()
 				mocket.Catcher.NewMock().
-					WithQuery(`SELECT * FROM "kafka_requests" WHERE instance_type = $1 AND (actual_kafka_billing_model = $2 or desired_kafka_billing_model = $3) AND owner = $4 AND "kafka_requests"."deleted_at" IS NULL`).
-					WithArgs(types.DEVELOPER.String(), "standard", "standard", "username").
+					WithQuery(`SELECT * FROM "kafka_requests" WHERE instance_type = $1 AND (actual_kafka_billing_model = $2 or desired_kafka_billing_model = $3) AND (NOT (status IN ($4,$5) AND suspended_by <> '')) AND owner = $6 AND "kafka_requests"."deleted_at" IS NULL`).
+					WithArgs(types.DEVELOPER.String(), "standard", "standard", constants.KafkaRequestStatusSuspending.String(), constants.KafkaRequestStatusSuspended.String(), "username").
 					WithReply(nil)
 				mocket.Catcher.NewMock().WithExecException().WithQueryException()
 			},
@@ -538,9 +571,10 @@ func Test_QuotaManagementListReserveQuota(t *testing.T) {
 			if tt.setupFn != nil {
 				tt.setupFn()
 			}
-			factory := NewDefaultQuotaServiceFactory(nil, tt.fields.connectionFactory, tt.fields.QuotaManagementList, &defaultKafkaConf)
+			factory := NewDefaultQuotaServiceFactory(nil, tt.fields.connectionFactory, tt.fields.QuotaManagementList, newQuotaManagementListEntriesMock(tt.fields.QuotaManagementList), &defaultKafkaConf)
 			quotaService, _ := factory.GetQuotaService(api.QuotaManagementListQuotaType)
 			kafka := &dbapi.KafkaRequest{
+				Meta:           api.Meta{ID: tt.args.kafkaId},
 				Owner:          "username",
 				OrganisationId: "org-id",
 				SizeId:         "x1",
@@ -781,7 +815,7 @@ func TestQuotaManagementListService_IsQuotaEntitlementActive(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			factory := NewDefaultQuotaServiceFactory(nil, nil, tt.fields.quotaManagementList, &defaultKafkaConf)
+			factory := NewDefaultQuotaServiceFactory(nil, nil, tt.fields.quotaManagementList, newQuotaManagementListEntriesMock(tt.fields.quotaManagementList), &defaultKafkaConf)
 			quotaService, _ := factory.GetQuotaService(api.QuotaManagementListQuotaType)
 
 			got, err := quotaService.IsQuotaEntitlementActive(tt.args.kafka)
@@ -790,3 +824,21 @@ func TestQuotaManagementListService_IsQuotaEntitlementActive(t *testing.T) {
 		})
 	}
 }
+
+// newQuotaManagementListEntriesMock returns a mock serving the organisations and service accounts of the given configuration
+func newQuotaManagementListEntriesMock(quotaManagementList *quota_management.QuotaManagementListConfig) *services.QuotaManagementListEntriesServiceMock {
+	return &services.QuotaManagementListEntriesServiceMock{
+		FindOrganisationByIdFunc: func(orgId string) (*quota_management.Organisation, *errors.ServiceError) {
+			if org, ok := quotaManagementList.QuotaList.Organisations.GetById(orgId); ok {
+				return &org, nil
+			}
+			return nil, nil
+		},
+		FindServiceAccountByUsernameFunc: func(username string) (*quota_management.Account, *errors.ServiceError) {
+			if account, ok := quotaManagementList.QuotaList.ServiceAccounts.GetByUsername(username); ok {
+				return &account, nil
+			}
+			return nil, nil
+		},
+	}
+}
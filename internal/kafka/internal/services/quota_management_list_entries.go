@@ -0,0 +1,345 @@
+package services
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/quota_management"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// QuotaManagementListEntriesService manages the organisations and service accounts of the quota management list
+// that are stored in the database.
+//
+//go:generate moq -out quota_management_list_entries_moq.go . QuotaManagementListEntriesService
+type QuotaManagementListEntriesService interface {
+	ListOrganisations(listArgs *coreServices.ListArguments) (quota_management.OrganisationList, *api.PagingMeta, *errors.ServiceError)
+	// FindOrganisationById returns the organisation with the given id. nil is returned if the organisation is not in the quota management list
+	FindOrganisationById(orgId string) (*quota_management.Organisation, *errors.ServiceError)
+	// CreateOrganisation adds the organisation to the quota management list. A conflict error is returned if the organisation already exists
+	CreateOrganisation(org *quota_management.Organisation) *errors.ServiceError
+	// UpdateOrganisation updates the organisation of the quota management list. A conflict error is returned if the organisation is defined in the configuration file
+	UpdateOrganisation(org *quota_management.Organisation) *errors.ServiceError
+	// DeleteOrganisation removes the organisation from the quota management list. A conflict error is returned if the organisation is defined in the configuration file
+	DeleteOrganisation(orgId string) *errors.ServiceError
+
+	ListServiceAccounts(listArgs *coreServices.ListArguments) (quota_management.AccountList, *api.PagingMeta, *errors.ServiceError)
+	// FindServiceAccountByUsername returns the service account with the given username. nil is returned if the service account is not in the quota management list
+	FindServiceAccountByUsername(username string) (*quota_management.Account, *errors.ServiceError)
+	// CreateServiceAccount adds the service account to the quota management list. A conflict error is returned if the service account already exists
+	CreateServiceAccount(account *quota_management.Account) *errors.ServiceError
+	// UpdateServiceAccount updates the service account of the quota management list. A conflict error is returned if the service account is defined in the configuration file
+	UpdateServiceAccount(account *quota_management.Account) *errors.ServiceError
+	// DeleteServiceAccount removes the service account from the quota management list. A conflict error is returned if the service account is defined in the configuration file
+	DeleteServiceAccount(username string) *errors.ServiceError
+
+	// SeedFromConfiguration stores the organisations and service accounts of the given configuration. The configuration is
+	// the source of truth of the entries it defines: the entries already in the database are updated with it and the
+	// entries previously seeded from it which it no longer defines are removed.
+	// The entries only added through the admin API are left untouched.
+	SeedFromConfiguration(configuration quota_management.RegisteredUsersListConfiguration) *errors.ServiceError
+}
+
+var _ QuotaManagementListEntriesService = &quotaManagementListEntriesService{}
+
+type quotaManagementListEntriesService struct {
+	connectionFactory *db.ConnectionFactory
+}
+
+func NewQuotaManagementListEntriesService(connectionFactory *db.ConnectionFactory) QuotaManagementListEntriesService {
+	return &quotaManagementListEntriesService{
+		connectionFactory: connectionFactory,
+	}
+}
+
+func (s *quotaManagementListEntriesService) ListOrganisations(listArgs *coreServices.ListArguments) (quota_management.OrganisationList, *api.PagingMeta, *errors.ServiceError) {
+	var entries []*dbapi.QuotaManagementListOrganisation
+	pagingMeta, err := s.list(&entries, "organisation_id", listArgs)
+	if err != nil {
+		return nil, nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list quota management list organisations")
+	}
+
+	orgs := quota_management.OrganisationList{}
+	for _, entry := range entries {
+		org, err := entry.ToOrganisation()
+		if err != nil {
+			return nil, nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to read quota management list organisation %q", entry.OrganisationId)
+		}
+		orgs = append(orgs, org)
+	}
+
+	return orgs, pagingMeta, nil
+}
+
+func (s *quotaManagementListEntriesService) FindOrganisationById(orgId string) (*quota_management.Organisation, *errors.ServiceError) {
+	var entry dbapi.QuotaManagementListOrganisation
+	if err := s.connectionFactory.New().Where("organisation_id = ?", orgId).First(&entry).Error; err != nil {
+		if coreServices.IsRecordNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to find quota management list organisation %q", orgId)
+	}
+
+	org, err := entry.ToOrganisation()
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to read quota management list organisation %q", orgId)
+	}
+
+	return &org, nil
+}
+
+func (s *quotaManagementListEntriesService) CreateOrganisation(org *quota_management.Organisation) *errors.ServiceError {
+	entry, err := dbapi.NewQuotaManagementListOrganisation(*org)
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to create quota management list organisation %q", org.Id)
+	}
+
+	if err := s.connectionFactory.New().Create(entry).Error; err != nil {
+		return coreServices.HandleCreateError("quota management list organisation", err)
+	}
+
+	return nil
+}
+
+func (s *quotaManagementListEntriesService) UpdateOrganisation(org *quota_management.Organisation) *errors.ServiceError {
+	entry, err := dbapi.NewQuotaManagementListOrganisation(*org)
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to update quota management list organisation %q", org.Id)
+	}
+
+	// select all the columns so that zero values (e.g. any_user=false) are updated too
+	result := s.connectionFactory.New().
+		Model(entry).
+		Where("managed_by_configuration = ?", false).
+		Select("any_user", "max_allowed_instances", "registered_users", "granted_quota", "updated_at").
+		Updates(entry)
+	if result.Error != nil {
+		return coreServices.HandleUpdateError("quota management list organisation", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return s.unchangedEntryError(&dbapi.QuotaManagementListOrganisation{}, "quota management list organisation", "organisation_id", org.Id)
+	}
+
+	return nil
+}
+
+func (s *quotaManagementListEntriesService) DeleteOrganisation(orgId string) *errors.ServiceError {
+	result := s.connectionFactory.New().
+		Where("organisation_id = ?", orgId).
+		Where("managed_by_configuration = ?", false).
+		Delete(&dbapi.QuotaManagementListOrganisation{})
+	if result.Error != nil {
+		return coreServices.HandleDeleteError("quota management list organisation", "organisation_id", orgId, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return s.unchangedEntryError(&dbapi.QuotaManagementListOrganisation{}, "quota management list organisation", "organisation_id", orgId)
+	}
+
+	return nil
+}
+
+func (s *quotaManagementListEntriesService) ListServiceAccounts(listArgs *coreServices.ListArguments) (quota_management.AccountList, *api.PagingMeta, *errors.ServiceError) {
+	var entries []*dbapi.QuotaManagementListServiceAccount
+	pagingMeta, err := s.list(&entries, "username", listArgs)
+	if err != nil {
+		return nil, nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list quota management list service accounts")
+	}
+
+	accounts := quota_management.AccountList{}
+	for _, entry := range entries {
+		account, err := entry.ToAccount()
+		if err != nil {
+			return nil, nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to read quota management list service account %q", entry.Username)
+		}
+		accounts = append(accounts, account)
+	}
+
+	return accounts, pagingMeta, nil
+}
+
+func (s *quotaManagementListEntriesService) FindServiceAccountByUsername(username string) (*quota_management.Account, *errors.ServiceError) {
+	var entry dbapi.QuotaManagementListServiceAccount
+	if err := s.connectionFactory.New().Where("username = ?", username).First(&entry).Error; err != nil {
+		if coreServices.IsRecordNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to find quota management list service account %q", username)
+	}
+
+	account, err := entry.ToAccount()
+	if err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to read quota management list service account %q", username)
+	}
+
+	return &account, nil
+}
+
+func (s *quotaManagementListEntriesService) CreateServiceAccount(account *quota_management.Account) *errors.ServiceError {
+	entry, err := dbapi.NewQuotaManagementListServiceAccount(*account)
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to create quota management list service account %q", account.Username)
+	}
+
+	if err := s.connectionFactory.New().Create(entry).Error; err != nil {
+		return coreServices.HandleCreateError("quota management list service account", err)
+	}
+
+	return nil
+}
+
+func (s *quotaManagementListEntriesService) UpdateServiceAccount(account *quota_management.Account) *errors.ServiceError {
+	entry, err := dbapi.NewQuotaManagementListServiceAccount(*account)
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to update quota management list service account %q", account.Username)
+	}
+
+	// select all the columns so that zero values are updated too
+	result := s.connectionFactory.New().
+		Model(entry).
+		Where("managed_by_configuration = ?", false).
+		Select("max_allowed_instances", "granted_quota", "updated_at").
+		Updates(entry)
+	if result.Error != nil {
+		return coreServices.HandleUpdateError("quota management list service account", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return s.unchangedEntryError(&dbapi.QuotaManagementListServiceAccount{}, "quota management list service account", "username", account.Username)
+	}
+
+	return nil
+}
+
+func (s *quotaManagementListEntriesService) DeleteServiceAccount(username string) *errors.ServiceError {
+	result := s.connectionFactory.New().
+		Where("username = ?", username).
+		Where("managed_by_configuration = ?", false).
+		Delete(&dbapi.QuotaManagementListServiceAccount{})
+	if result.Error != nil {
+		return coreServices.HandleDeleteError("quota management list service account", "username", username, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return s.unchangedEntryError(&dbapi.QuotaManagementListServiceAccount{}, "quota management list service account", "username", username)
+	}
+
+	return nil
+}
+
+func (s *quotaManagementListEntriesService) SeedFromConfiguration(configuration quota_management.RegisteredUsersListConfiguration) *errors.ServiceError {
+	var orgs []*dbapi.QuotaManagementListOrganisation
+	var orgIds []string
+	for _, org := range configuration.Organisations {
+		entry, err := dbapi.NewQuotaManagementListOrganisation(org)
+		if err != nil {
+			return errors.NewWithCause(errors.ErrorGeneral, err, "unable to seed quota management list organisation %q", org.Id)
+		}
+		entry.ManagedByConfiguration = true
+		orgs = append(orgs, entry)
+		orgIds = append(orgIds, org.Id)
+	}
+
+	var accounts []*dbapi.QuotaManagementListServiceAccount
+	var usernames []string
+	for _, account := range configuration.ServiceAccounts {
+		entry, err := dbapi.NewQuotaManagementListServiceAccount(account)
+		if err != nil {
+			return errors.NewWithCause(errors.ErrorGeneral, err, "unable to seed quota management list service account %q", account.Username)
+		}
+		entry.ManagedByConfiguration = true
+		accounts = append(accounts, entry)
+		usernames = append(usernames, account.Username)
+	}
+
+	var removedOrgs, removedAccounts int64
+	err := s.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		// the columns managed by the configuration of the entries already stored are updated with it
+		if len(orgs) > 0 {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "organisation_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"any_user", "max_allowed_instances", "registered_users", "granted_quota", "managed_by_configuration", "updated_at"}),
+			}).Create(&orgs).Error; err != nil {
+				return err
+			}
+		}
+		if len(accounts) > 0 {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "username"}},
+				DoUpdates: clause.AssignmentColumns([]string{"max_allowed_instances", "granted_quota", "managed_by_configuration", "updated_at"}),
+			}).Create(&accounts).Error; err != nil {
+				return err
+			}
+		}
+
+		// the entries removed from the configuration are removed from the database too
+		staleOrgs := tx.Where("managed_by_configuration = ?", true)
+		if len(orgIds) > 0 {
+			staleOrgs = staleOrgs.Where("organisation_id NOT IN ?", orgIds)
+		}
+		result := staleOrgs.Delete(&dbapi.QuotaManagementListOrganisation{})
+		if result.Error != nil {
+			return result.Error
+		}
+		removedOrgs = result.RowsAffected
+
+		staleAccounts := tx.Where("managed_by_configuration = ?", true)
+		if len(usernames) > 0 {
+			staleAccounts = staleAccounts.Where("username NOT IN ?", usernames)
+		}
+		result = staleAccounts.Delete(&dbapi.QuotaManagementListServiceAccount{})
+		if result.Error != nil {
+			return result.Error
+		}
+		removedAccounts = result.RowsAffected
+
+		return nil
+	})
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to seed the quota management list")
+	}
+
+	logger.Logger.Infof("seeded the quota management list with %d organisation(s) and %d service account(s) from the configuration, removed %d organisation(s) and %d service account(s) no longer in it", len(orgs), len(accounts), removedOrgs, removedAccounts)
+	return nil
+}
+
+// unchangedEntryError returns the error explaining why the entry of the quota management list table backing the given
+// model, identified by the given key, has not been changed: either it does not exist, or it is defined in the
+// configuration file and can only be changed there
+func (s *quotaManagementListEntriesService) unchangedEntryError(model interface{}, kind string, keyColumn string, key string) *errors.ServiceError {
+	var count int64
+	if err := s.connectionFactory.New().Model(model).Where(keyColumn+" = ?", key).Count(&count).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to find %s %q", kind, key)
+	}
+	if count > 0 {
+		return errors.Conflict("%s %q is defined in the quota management list configuration file and can only be changed there", kind, key)
+	}
+
+	return errors.NotFound("%s %q not found", kind, key)
+}
+
+// list lists the entries of the quota management list table backing the given destination, ordered by the given column
+func (s *quotaManagementListEntriesService) list(dest interface{}, orderBy string, listArgs *coreServices.ListArguments) (*api.PagingMeta, error) {
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+
+	dbConn := s.connectionFactory.New().Model(dest)
+
+	var total int64
+	if err := dbConn.Count(&total).Error; err != nil {
+		return nil, err
+	}
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+
+	if err := dbConn.Order(orderBy).Offset((pagingMeta.Page - 1) * listArgs.Size).Limit(listArgs.Size).Find(dest).Error; err != nil {
+		return nil, err
+	}
+
+	return pagingMeta, nil
+}
@@ -0,0 +1,237 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/quota_management"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_quotaManagementListEntriesService_FindOrganisationById(t *testing.T) {
+	tests := []struct {
+		name    string
+		setupFn func()
+		want    *quota_management.Organisation
+		wantErr bool
+	}{
+		{
+			name: "should return nil if the organisation is not in the quota management list",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "quota_management_list_organisations"`).WithReply(nil)
+			},
+			want: nil,
+		},
+		{
+			name: "should return an error if the query fails",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "quota_management_list_organisations"`).WithQueryException()
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return the organisation with its registered users and granted quota",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`SELECT * FROM "quota_management_list_organisations"`).WithReply([]map[string]interface{}{
+					{
+						"organisation_id":       "org-id",
+						"any_user":              true,
+						"max_allowed_instances": 3,
+						"registered_users":      []byte(`[{"username":"user-1","max_allowed_instances":0}]`),
+						"granted_quota":         []byte(`[{"instance_type_id":"standard","kafka_billing_models":[{"id":"eval","expiration_date":"2023-01-01 +00:00","max_allowed_instances":2}]}]`),
+					},
+				})
+			},
+			want: &quota_management.Organisation{
+				Id:                  "org-id",
+				AnyUser:             true,
+				MaxAllowedInstances: 3,
+				RegisteredUsers:     quota_management.AccountList{{Username: "user-1"}},
+				GrantedQuota: quota_management.QuotaList{
+					{
+						InstanceTypeID: "standard",
+						KafkaBillingModels: quota_management.BillingModelList{
+							{
+								Id: "eval",
+								ExpirationDate: func() *quota_management.ExpirationDate {
+									date, _ := quota_management.ParseExpirationDate("2023-01-01 +00:00")
+									return &date
+								}(),
+								MaxAllowedInstances: 2,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			s := NewQuotaManagementListEntriesService(db.NewMockConnectionFactory(nil))
+			got, err := s.FindOrganisationById("org-id")
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_quotaManagementListEntriesService_ListServiceAccounts(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	mocket.Catcher.Reset()
+	mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "quota_management_list_service_accounts"`).WithReply([]map[string]interface{}{{"count": 2}})
+	mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "quota_management_list_service_accounts" ORDER BY username`).WithReply([]map[string]interface{}{
+		{"username": "account-1", "max_allowed_instances": 1, "granted_quota": []byte(`[]`)},
+		{"username": "account-2", "max_allowed_instances": 2, "granted_quota": []byte(`[{"instance_type_id":"standard"}]`)},
+	})
+
+	s := NewQuotaManagementListEntriesService(db.NewMockConnectionFactory(nil))
+	accounts, paging, err := s.ListServiceAccounts(&coreServices.ListArguments{Page: 1, Size: 100})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(paging.Total).To(gomega.Equal(2))
+	g.Expect(paging.Size).To(gomega.Equal(2))
+	g.Expect(accounts).To(gomega.Equal(quota_management.AccountList{
+		{Username: "account-1", MaxAllowedInstances: 1, GrantedQuota: quota_management.QuotaList{}},
+		{Username: "account-2", MaxAllowedInstances: 2, GrantedQuota: quota_management.QuotaList{{InstanceTypeID: "standard"}}},
+	}))
+}
+
+func Test_quotaManagementListEntriesService_UpdateAndDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  func(s QuotaManagementListEntriesService) *errors.ServiceError
+		setupFn func()
+		wantErr *errors.ServiceError
+	}{
+		{
+			name: "should return not found when updating an organisation that is not in the quota management list",
+			action: func(s QuotaManagementListEntriesService) *errors.ServiceError {
+				return s.UpdateOrganisation(&quota_management.Organisation{Id: "org-id"})
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "quota_management_list_organisations"`).WithRowsNum(0)
+			},
+			wantErr: errors.NotFound(`quota management list organisation "org-id" not found`),
+		},
+		{
+			name: "should return a conflict when updating an organisation defined in the configuration file",
+			action: func(s QuotaManagementListEntriesService) *errors.ServiceError {
+				return s.UpdateOrganisation(&quota_management.Organisation{Id: "org-id"})
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "quota_management_list_organisations"`).WithRowsNum(0)
+				mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "quota_management_list_organisations"`).WithReply([]map[string]interface{}{{"count": 1}})
+			},
+			wantErr: errors.Conflict(`quota management list organisation "org-id" is defined in the quota management list configuration file and can only be changed there`),
+		},
+		{
+			name: "should update an organisation of the quota management list",
+			action: func(s QuotaManagementListEntriesService) *errors.ServiceError {
+				return s.UpdateOrganisation(&quota_management.Organisation{Id: "org-id"})
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "quota_management_list_organisations"`).WithRowsNum(1)
+			},
+		},
+		{
+			name: "should return not found when deleting a service account that is not in the quota management list",
+			action: func(s QuotaManagementListEntriesService) *errors.ServiceError {
+				return s.DeleteServiceAccount("account-1")
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`DELETE FROM "quota_management_list_service_accounts"`).WithRowsNum(0)
+			},
+			wantErr: errors.NotFound(`quota management list service account "account-1" not found`),
+		},
+		{
+			name: "should return a conflict when deleting a service account defined in the configuration file",
+			action: func(s QuotaManagementListEntriesService) *errors.ServiceError {
+				return s.DeleteServiceAccount("account-1")
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`DELETE FROM "quota_management_list_service_accounts"`).WithRowsNum(0)
+				mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "quota_management_list_service_accounts"`).WithReply([]map[string]interface{}{{"count": 1}})
+			},
+			wantErr: errors.Conflict(`quota management list service account "account-1" is defined in the quota management list configuration file and can only be changed there`),
+		},
+		{
+			name: "should delete a service account of the quota management list",
+			action: func(s QuotaManagementListEntriesService) *errors.ServiceError {
+				return s.DeleteServiceAccount("account-1")
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`DELETE FROM "quota_management_list_service_accounts"`).WithRowsNum(1)
+			},
+		},
+		{
+			name: "should return an error when the delete fails",
+			action: func(s QuotaManagementListEntriesService) *errors.ServiceError {
+				return s.DeleteOrganisation("org-id")
+			},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`DELETE FROM "quota_management_list_organisations"`).WithExecException()
+			},
+			wantErr: errors.GeneralError(`unable to delete quota management list organisation with organisation_id="org-id"`),
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			err := tt.action(NewQuotaManagementListEntriesService(db.NewMockConnectionFactory(nil)))
+			if tt.wantErr == nil {
+				g.Expect(err).To(gomega.BeNil())
+				return
+			}
+			g.Expect(err).ToNot(gomega.BeNil())
+			g.Expect(err.Code).To(gomega.Equal(tt.wantErr.Code))
+		})
+	}
+}
+
+func Test_quotaManagementListEntriesService_SeedFromConfiguration(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	mocket.Catcher.Reset()
+	// the columns managed by the configuration of the entries already in the database are overwritten
+	orgsInsert := mocket.Catcher.NewMock().WithQuery(`"managed_by_configuration","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) ON CONFLICT ("organisation_id") DO UPDATE SET "any_user"="excluded"."any_user","max_allowed_instances"="excluded"."max_allowed_instances","registered_users"="excluded"."registered_users","granted_quota"="excluded"."granted_quota","managed_by_configuration"="excluded"."managed_by_configuration","updated_at"="excluded"."updated_at"`)
+	accountsInsert := mocket.Catcher.NewMock().WithQuery(`"managed_by_configuration","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6) ON CONFLICT ("username") DO UPDATE SET "max_allowed_instances"="excluded"."max_allowed_instances","granted_quota"="excluded"."granted_quota","managed_by_configuration"="excluded"."managed_by_configuration","updated_at"="excluded"."updated_at"`)
+	// the entries seeded from the configuration which are no longer in it are removed
+	orgsDelete := mocket.Catcher.NewMock().WithQuery(`DELETE FROM "quota_management_list_organisations" WHERE managed_by_configuration = $1 AND (organisation_id NOT IN ($2))`).WithRowsNum(1)
+	accountsDelete := mocket.Catcher.NewMock().WithQuery(`DELETE FROM "quota_management_list_service_accounts" WHERE managed_by_configuration = $1 AND username NOT IN ($2)`).WithRowsNum(1)
+
+	s := NewQuotaManagementListEntriesService(db.NewMockConnectionFactory(nil))
+	err := s.SeedFromConfiguration(quota_management.RegisteredUsersListConfiguration{
+		Organisations:   quota_management.OrganisationList{{Id: "org-id", AnyUser: true}},
+		ServiceAccounts: quota_management.AccountList{{Username: "account-1"}},
+	})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(orgsInsert.Triggered).To(gomega.BeTrue())
+	g.Expect(accountsInsert.Triggered).To(gomega.BeTrue())
+	g.Expect(orgsDelete.Triggered).To(gomega.BeTrue())
+	g.Expect(accountsDelete.Triggered).To(gomega.BeTrue())
+}
+
+func Test_quotaManagementListEntriesService_SeedFromEmptyConfiguration(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	mocket.Catcher.Reset()
+	// all the entries seeded from the configuration are removed when it no longer defines any entry
+	orgsDelete := mocket.Catcher.NewMock().WithQuery(`DELETE FROM "quota_management_list_organisations" WHERE managed_by_configuration = $1`).WithRowsNum(1)
+	accountsDelete := mocket.Catcher.NewMock().WithQuery(`DELETE FROM "quota_management_list_service_accounts" WHERE managed_by_configuration = $1`).WithRowsNum(1)
+
+	s := NewQuotaManagementListEntriesService(db.NewMockConnectionFactory(nil))
+	err := s.SeedFromConfiguration(quota_management.RegisteredUsersListConfiguration{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(orgsDelete.Triggered).To(gomega.BeTrue())
+	g.Expect(accountsDelete.Triggered).To(gomega.BeTrue())
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/quota_management"
+)
+
+// QuotaManagementListSeeder stores the organisations and service accounts read from the quota management list
+// configuration file in the database when the service starts. The entries defined in the configuration file are updated
+// with it and the ones removed from it are deleted, the entries only added through the admin API are preserved across restarts.
+type QuotaManagementListSeeder struct {
+	quotaManagementListEntries QuotaManagementListEntriesService
+	quotaManagementList        *quota_management.QuotaManagementListConfig
+}
+
+var _ environments.BootService = &QuotaManagementListSeeder{}
+
+func NewQuotaManagementListSeeder(quotaManagementListEntries QuotaManagementListEntriesService, quotaManagementList *quota_management.QuotaManagementListConfig) *QuotaManagementListSeeder {
+	return &QuotaManagementListSeeder{
+		quotaManagementListEntries: quotaManagementListEntries,
+		quotaManagementList:        quotaManagementList,
+	}
+}
+
+func (s *QuotaManagementListSeeder) Start() {
+	if err := s.quotaManagementListEntries.SeedFromConfiguration(s.quotaManagementList.QuotaList); err != nil {
+		logger.Logger.Errorf("failed to seed the quota management list from %q: %v", s.quotaManagementList.QuotaListConfigFile, err)
+	}
+}
+
+func (s *QuotaManagementListSeeder) Stop() {}
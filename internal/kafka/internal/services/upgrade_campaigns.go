@@ -0,0 +1,281 @@
+package services
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	coreServices "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/queryparser"
+	"gorm.io/gorm"
+)
+
+// ValidUpgradeCampaignKafkaColumns are the columns of the kafkas that can be used in the kafka selector of an upgrade campaign
+var ValidUpgradeCampaignKafkaColumns = []string{"id", "name", "region", "cloud_provider", "cluster_id", "multi_az", "owner", "organisation_id", "instance_type", "size_id",
+	"actual_kafka_billing_model", "actual_strimzi_version", "actual_kafka_version", "actual_kafka_ibp_version"}
+
+// UpgradeCampaignService stores the upgrade campaigns and the processing of the kafkas by each campaign
+//
+//go:generate moq -out upgrade_campaigns_moq.go . UpgradeCampaignService
+type UpgradeCampaignService interface {
+	Create(campaign *dbapi.UpgradeCampaign) *errors.ServiceError
+	// Get returns the campaign with the given id. It is nil when no such campaign exists.
+	Get(id string) (*dbapi.UpgradeCampaign, *errors.ServiceError)
+	// List returns the campaigns, the most recent first
+	List(listArgs *coreServices.ListArguments) (dbapi.UpgradeCampaignList, *api.PagingMeta, *errors.ServiceError)
+	ListByStatus(status dbapi.UpgradeCampaignStatus) (dbapi.UpgradeCampaignList, *errors.ServiceError)
+	Updates(campaign *dbapi.UpgradeCampaign, values map[string]interface{}) *errors.ServiceError
+	// ListKafkas returns the kafkas processed by the given campaign with the given status
+	ListKafkas(campaignId string, status dbapi.UpgradeCampaignKafkaStatus) (dbapi.UpgradeCampaignKafkaList, *errors.ServiceError)
+	// ListCampaignKafkas returns the kafkas processed by the given campaign, the oldest first
+	ListCampaignKafkas(campaignId string, listArgs *coreServices.ListArguments) (dbapi.UpgradeCampaignKafkaList, *api.PagingMeta, *errors.ServiceError)
+	// CountKafkasByStatus returns the number of kafkas processed by the given campaign indexed by status
+	CountKafkasByStatus(campaignId string) (map[dbapi.UpgradeCampaignKafkaStatus]int, *errors.ServiceError)
+	RecordKafka(campaignKafka *dbapi.UpgradeCampaignKafka) *errors.ServiceError
+	// StartKafkaUpgrade records the kafka of the campaign and sets the given versions as the desired versions of the
+	// kafka in a single transaction. The kafka is recorded as skipped when it is being deleted.
+	StartKafkaUpgrade(campaignKafka *dbapi.UpgradeCampaignKafka, strimziVersion, kafkaVersion, kafkaIBPVersion string) *errors.ServiceError
+	UpdateKafka(campaignKafka *dbapi.UpgradeCampaignKafka) *errors.ServiceError
+	// FindCandidateKafkas returns at most limit ready kafkas matching the selectors of the campaign that have not been
+	// processed by the campaign yet and that do not run the target versions of the campaign, the oldest first. The
+	// kafkas running a newer version than the target versions are returned too: the caller must check the
+	// compatibility of the target versions with each kafka, see ValidateKafkaVersionsCompatibility.
+	FindCandidateKafkas(campaign *dbapi.UpgradeCampaign, limit int) (dbapi.KafkaList, *errors.ServiceError)
+}
+
+var _ UpgradeCampaignService = &upgradeCampaignService{}
+
+type upgradeCampaignService struct {
+	connectionFactory *db.ConnectionFactory
+}
+
+func NewUpgradeCampaignService(connectionFactory *db.ConnectionFactory) UpgradeCampaignService {
+	return &upgradeCampaignService{
+		connectionFactory: connectionFactory,
+	}
+}
+
+// ValidateUpgradeCampaignSelectors verifies that the selectors of the campaign can be parsed
+func ValidateUpgradeCampaignSelectors(kafkaSelector, clusterSelector string) error {
+	if kafkaSelector != "" {
+		if _, err := queryparser.NewQueryParser(ValidUpgradeCampaignKafkaColumns...).Parse(kafkaSelector); err != nil {
+			return err
+		}
+	}
+	if clusterSelector != "" {
+		if _, err := queryparser.NewQueryParser(ValidClusterColumns...).Parse(clusterSelector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *upgradeCampaignService) Create(campaign *dbapi.UpgradeCampaign) *errors.ServiceError {
+	if err := s.connectionFactory.New().Create(campaign).Error; err != nil {
+		return coreServices.HandleCreateError("upgrade campaign", err)
+	}
+	return nil
+}
+
+func (s *upgradeCampaignService) Get(id string) (*dbapi.UpgradeCampaign, *errors.ServiceError) {
+	var campaigns dbapi.UpgradeCampaignList
+	if err := s.connectionFactory.New().Where("id = ?", id).Limit(1).Find(&campaigns).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to find upgrade campaign %q", id)
+	}
+	if len(campaigns) == 0 {
+		return nil, nil
+	}
+	return campaigns[0], nil
+}
+
+func (s *upgradeCampaignService) List(listArgs *coreServices.ListArguments) (dbapi.UpgradeCampaignList, *api.PagingMeta, *errors.ServiceError) {
+	var campaigns dbapi.UpgradeCampaignList
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+
+	dbConn := s.connectionFactory.New().Model(&dbapi.UpgradeCampaign{})
+
+	var total int64
+	if err := dbConn.Count(&total).Error; err != nil {
+		return nil, nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to count the upgrade campaigns")
+	}
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+
+	if err := dbConn.Order("created_at desc").Offset((pagingMeta.Page - 1) * listArgs.Size).Limit(listArgs.Size).Find(&campaigns).Error; err != nil {
+		return nil, nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list the upgrade campaigns")
+	}
+
+	return campaigns, pagingMeta, nil
+}
+
+func (s *upgradeCampaignService) ListByStatus(status dbapi.UpgradeCampaignStatus) (dbapi.UpgradeCampaignList, *errors.ServiceError) {
+	var campaigns dbapi.UpgradeCampaignList
+	if err := s.connectionFactory.New().Where("status = ?", status).Order("created_at").Find(&campaigns).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list the upgrade campaigns with status %q", status)
+	}
+	return campaigns, nil
+}
+
+func (s *upgradeCampaignService) Updates(campaign *dbapi.UpgradeCampaign, values map[string]interface{}) *errors.ServiceError {
+	if err := s.connectionFactory.New().Model(campaign).Updates(values).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to update upgrade campaign %q", campaign.ID)
+	}
+	return nil
+}
+
+func (s *upgradeCampaignService) ListKafkas(campaignId string, status dbapi.UpgradeCampaignKafkaStatus) (dbapi.UpgradeCampaignKafkaList, *errors.ServiceError) {
+	var campaignKafkas dbapi.UpgradeCampaignKafkaList
+	if err := s.connectionFactory.New().Where("campaign_id = ? AND status = ?", campaignId, status).Order("created_at").Find(&campaignKafkas).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list the %s kafkas of upgrade campaign %q", status, campaignId)
+	}
+	return campaignKafkas, nil
+}
+
+func (s *upgradeCampaignService) ListCampaignKafkas(campaignId string, listArgs *coreServices.ListArguments) (dbapi.UpgradeCampaignKafkaList, *api.PagingMeta, *errors.ServiceError) {
+	var campaignKafkas dbapi.UpgradeCampaignKafkaList
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+
+	dbConn := s.connectionFactory.New().Model(&dbapi.UpgradeCampaignKafka{}).Where("campaign_id = ?", campaignId)
+
+	var total int64
+	if err := dbConn.Count(&total).Error; err != nil {
+		return nil, nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to count the kafkas of upgrade campaign %q", campaignId)
+	}
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+
+	if err := dbConn.Order("created_at").Offset((pagingMeta.Page - 1) * listArgs.Size).Limit(listArgs.Size).Find(&campaignKafkas).Error; err != nil {
+		return nil, nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list the kafkas of upgrade campaign %q", campaignId)
+	}
+
+	return campaignKafkas, pagingMeta, nil
+}
+
+func (s *upgradeCampaignService) CountKafkasByStatus(campaignId string) (map[dbapi.UpgradeCampaignKafkaStatus]int, *errors.ServiceError) {
+	type statusCount struct {
+		Status dbapi.UpgradeCampaignKafkaStatus
+		Count  int
+	}
+	var statusCounts []statusCount
+	if err := s.connectionFactory.New().Model(&dbapi.UpgradeCampaignKafka{}).
+		Select("status, count(1) as count").
+		Where("campaign_id = ?", campaignId).
+		Group("status").
+		Scan(&statusCounts).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to count the kafkas of upgrade campaign %q", campaignId)
+	}
+
+	res := map[dbapi.UpgradeCampaignKafkaStatus]int{}
+	for _, statusCount := range statusCounts {
+		res[statusCount.Status] = statusCount.Count
+	}
+	return res, nil
+}
+
+func (s *upgradeCampaignService) RecordKafka(campaignKafka *dbapi.UpgradeCampaignKafka) *errors.ServiceError {
+	if err := s.connectionFactory.New().Create(campaignKafka).Error; err != nil {
+		return coreServices.HandleCreateError("upgrade campaign kafka", err)
+	}
+	return nil
+}
+
+func (s *upgradeCampaignService) StartKafkaUpgrade(campaignKafka *dbapi.UpgradeCampaignKafka, strimziVersion, kafkaVersion, kafkaIBPVersion string) *errors.ServiceError {
+	err := s.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&dbapi.KafkaRequest{}).
+			Where("id = ?", campaignKafka.KafkaId).
+			Where("status NOT IN (?)", kafkaDeletionStatuses).
+			Updates(map[string]interface{}{
+				"desired_strimzi_version":   strimziVersion,
+				"desired_kafka_version":     kafkaVersion,
+				"desired_kafka_ibp_version": kafkaIBPVersion,
+				// the target versions wait for the next maintenance window of the kafka
+				"upgrade_released_at": nil,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			campaignKafka.Status = dbapi.UpgradeCampaignKafkaStatusSkipped
+			campaignKafka.Reason = "the kafka has been deleted"
+		}
+		return tx.Create(campaignKafka).Error
+	})
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to start the upgrade of kafka %q of upgrade campaign %q", campaignKafka.KafkaId, campaignKafka.CampaignId)
+	}
+	return nil
+}
+
+func (s *upgradeCampaignService) UpdateKafka(campaignKafka *dbapi.UpgradeCampaignKafka) *errors.ServiceError {
+	if err := s.connectionFactory.New().Model(campaignKafka).Updates(map[string]interface{}{
+		"status": campaignKafka.Status,
+		"reason": campaignKafka.Reason,
+	}).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to update kafka %q of upgrade campaign %q", campaignKafka.KafkaId, campaignKafka.CampaignId)
+	}
+	return nil
+}
+
+func (s *upgradeCampaignService) FindCandidateKafkas(campaign *dbapi.UpgradeCampaign, limit int) (dbapi.KafkaList, *errors.ServiceError) {
+	dbConn := s.connectionFactory.New().
+		Where("status = ?", constants.KafkaRequestStatusReady.String()).
+		Where("id NOT IN (?)", s.connectionFactory.New().Model(&dbapi.UpgradeCampaignKafka{}).Select("kafka_id").Where("campaign_id = ?", campaign.ID)).
+		Where("NOT (strimzi_upgrading OR kafka_upgrading OR kafka_ibp_upgrading)")
+
+	// the kafkas already running the target versions have nothing to upgrade
+	var notUpgraded *gorm.DB
+	targetVersions := []struct {
+		column  string
+		version string
+	}{
+		{column: "actual_strimzi_version", version: campaign.StrimziVersion},
+		{column: "actual_kafka_version", version: campaign.KafkaVersion},
+		{column: "actual_kafka_ibp_version", version: campaign.KafkaIBPVersion},
+	}
+	for _, target := range targetVersions {
+		if target.version == "" {
+			continue
+		}
+		if notUpgraded == nil {
+			notUpgraded = s.connectionFactory.New().Where(target.column+" <> ?", target.version)
+		} else {
+			notUpgraded = notUpgraded.Or(target.column+" <> ?", target.version)
+		}
+	}
+	if notUpgraded != nil {
+		dbConn = dbConn.Where(notUpgraded)
+	}
+
+	if campaign.KafkaSelector != "" {
+		kafkaQuery, err := queryparser.NewQueryParser(ValidUpgradeCampaignKafkaColumns...).Parse(campaign.KafkaSelector)
+		if err != nil {
+			return nil, errors.NewWithCause(errors.ErrorFailedToParseSearch, err, "unable to parse the kafka selector of upgrade campaign %q", campaign.ID)
+		}
+		dbConn = dbConn.Where(kafkaQuery.Query, kafkaQuery.Values...)
+	}
+
+	if campaign.ClusterSelector != "" {
+		clusterQuery, err := queryparser.NewQueryParser(ValidClusterColumns...).Parse(campaign.ClusterSelector)
+		if err != nil {
+			return nil, errors.NewWithCause(errors.ErrorFailedToParseSearch, err, "unable to parse the cluster selector of upgrade campaign %q", campaign.ID)
+		}
+		dbConn = dbConn.Where("cluster_id IN (?)", s.connectionFactory.New().Model(&api.Cluster{}).Select("cluster_id").Where(clusterQuery.Query, clusterQuery.Values...))
+	}
+
+	var kafkas dbapi.KafkaList
+	if err := dbConn.Order("created_at").Limit(limit).Find(&kafkas).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to find the kafkas to upgrade by upgrade campaign %q", campaign.ID)
+	}
+	return kafkas, nil
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_ValidateUpgradeCampaignSelectors(t *testing.T) {
+	tests := []struct {
+		name            string
+		kafkaSelector   string
+		clusterSelector string
+		wantErr         bool
+	}{
+		{
+			name: "should accept empty selectors",
+		},
+		{
+			name:            "should accept valid selectors",
+			kafkaSelector:   "instance_type = standard and actual_kafka_version = 3.3.1",
+			clusterSelector: "region = us-east-1",
+		},
+		{
+			name:          "should reject a kafka selector using an unknown column",
+			kafkaSelector: "bootstrap_server_host = host",
+			wantErr:       true,
+		},
+		{
+			name:            "should reject a cluster selector using an unknown column",
+			clusterSelector: "instance_type = standard",
+			wantErr:         true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			err := ValidateUpgradeCampaignSelectors(tt.kafkaSelector, tt.clusterSelector)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
+
+func Test_upgradeCampaignService_FindCandidateKafkas(t *testing.T) {
+	tests := []struct {
+		name      string
+		campaign  *dbapi.UpgradeCampaign
+		wantQuery string
+		wantArgs  []interface{}
+		wantErr   *errors.ServiceError
+	}{
+		{
+			name:      "should select the ready kafkas not running the target version",
+			campaign:  &dbapi.UpgradeCampaign{Meta: api.Meta{ID: "campaign-id"}, KafkaVersion: "3.3.1"},
+			wantQuery: `SELECT * FROM "kafka_requests" WHERE status = $1 AND id NOT IN (SELECT "kafka_id" FROM "upgrade_campaign_kafkas" WHERE campaign_id = $2) AND (NOT (strimzi_upgrading OR kafka_upgrading OR kafka_ibp_upgrading)) AND actual_kafka_version <> $3 AND "kafka_requests"."deleted_at" IS NULL ORDER BY created_at LIMIT 5`,
+			wantArgs:  []interface{}{"ready", "campaign-id", "3.3.1"},
+		},
+		{
+			name: "should select the kafkas matching the selectors not running one of the target versions",
+			campaign: &dbapi.UpgradeCampaign{Meta: api.Meta{ID: "campaign-id"}, StrimziVersion: "strimzi-cluster-operator.v0.32.0-0", KafkaVersion: "3.3.1",
+				KafkaSelector: "instance_type = standard", ClusterSelector: "region = us-east-1"},
+			wantQuery: `SELECT * FROM "kafka_requests" WHERE status = $1 AND id NOT IN (SELECT "kafka_id" FROM "upgrade_campaign_kafkas" WHERE campaign_id = $2) AND (NOT (strimzi_upgrading OR kafka_upgrading OR kafka_ibp_upgrading)) AND (actual_strimzi_version <> $3 OR actual_kafka_version <> $4) AND instance_type = $5 AND cluster_id IN (SELECT "cluster_id" FROM "clusters" WHERE region = $6 AND "clusters"."deleted_at" IS NULL) AND "kafka_requests"."deleted_at" IS NULL ORDER BY created_at LIMIT 5`,
+			wantArgs:  []interface{}{"ready", "campaign-id", "strimzi-cluster-operator.v0.32.0-0", "3.3.1", "standard", "us-east-1"},
+		},
+		{
+			name:     "should return an error if the kafka selector is invalid",
+			campaign: &dbapi.UpgradeCampaign{Meta: api.Meta{ID: "campaign-id"}, KafkaVersion: "3.3.1", KafkaSelector: "unknown = value"},
+			wantErr:  errors.New(errors.ErrorFailedToParseSearch, "unable to parse the kafka selector of upgrade campaign \"campaign-id\""),
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			query := mocket.Catcher.NewMock().WithQuery(tt.wantQuery).WithArgs(tt.wantArgs...).WithReply([]map[string]interface{}{{"id": "kafka-id"}})
+			mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+			kafkas, err := NewUpgradeCampaignService(db.NewMockConnectionFactory(nil)).FindCandidateKafkas(tt.campaign, 5)
+			if tt.wantErr != nil {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErr.Code))
+				g.Expect(err.Reason).To(gomega.ContainSubstring(tt.wantErr.Reason))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(query.Triggered).To(gomega.BeTrue())
+			g.Expect(kafkas).To(gomega.HaveLen(1))
+		})
+	}
+}
+
+func Test_upgradeCampaignService_CountKafkasByStatus(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	mocket.Catcher.Reset()
+	mocket.Catcher.NewMock().WithQuery(`SELECT status, count(1) as count FROM "upgrade_campaign_kafkas" WHERE campaign_id = $1 GROUP BY "status"`).WithArgs("campaign-id").WithReply([]map[string]interface{}{
+		{"status": "upgrading", "count": 2},
+		{"status": "skipped", "count": 1},
+	})
+
+	counts, err := NewUpgradeCampaignService(db.NewMockConnectionFactory(nil)).CountKafkasByStatus("campaign-id")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(counts).To(gomega.Equal(map[dbapi.UpgradeCampaignKafkaStatus]int{
+		dbapi.UpgradeCampaignKafkaStatusUpgrading: 2,
+		dbapi.UpgradeCampaignKafkaStatusSkipped:   1,
+	}))
+}
+
+func Test_upgradeCampaignService_StartKafkaUpgrade(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		insertErr    bool
+		wantStatus   dbapi.UpgradeCampaignKafkaStatus
+		wantErr      bool
+	}{
+		{
+			name:         "should record the kafka along with its desired versions",
+			rowsAffected: 1,
+			wantStatus:   dbapi.UpgradeCampaignKafkaStatusUpgrading,
+		},
+		{
+			name:       "should record the kafka as skipped when it has been deleted",
+			wantStatus: dbapi.UpgradeCampaignKafkaStatusSkipped,
+		},
+		{
+			name:         "should return an error when the kafka cannot be recorded",
+			rowsAffected: 1,
+			insertErr:    true,
+			wantStatus:   dbapi.UpgradeCampaignKafkaStatusUpgrading,
+			wantErr:      true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			mocket.Catcher.Reset()
+			update := mocket.Catcher.NewMock().WithQuery(`UPDATE "kafka_requests" SET "desired_kafka_ibp_version"=$1,"desired_kafka_version"=$2,"desired_strimzi_version"=$3`).WithRowsNum(tt.rowsAffected)
+			insert := mocket.Catcher.NewMock().WithQuery(`INSERT INTO "upgrade_campaign_kafkas"`)
+			if tt.insertErr {
+				insert.WithExecException()
+			}
+
+			campaignKafka := &dbapi.UpgradeCampaignKafka{CampaignId: "campaign-id", KafkaId: "kafka-id", Status: dbapi.UpgradeCampaignKafkaStatusUpgrading}
+			err := NewUpgradeCampaignService(db.NewMockConnectionFactory(nil)).StartKafkaUpgrade(campaignKafka, "strimzi-cluster-operator.v0.32.0-0", "3.3.1", "3.3")
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(update.Triggered).To(gomega.BeTrue())
+			g.Expect(insert.Triggered).To(gomega.BeTrue())
+			g.Expect(campaignKafka.Status).To(gomega.Equal(tt.wantStatus))
+		})
+	}
+}
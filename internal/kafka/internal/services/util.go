@@ -72,7 +72,12 @@ func BuildKeycloakClientNameIdentifier(kafkaRequestID string) string {
 
 func BuildCustomClaimCheck(kafkaRequest *dbapi.KafkaRequest, ssoconfigProvider string) string {
 	if ssoconfigProvider == keycloak.REDHAT_SSO {
-		return fmt.Sprintf("@.rh-org-id == '%s'|| @.org_id == '%s' || @.clientId == '%s'", kafkaRequest.OrganisationId, kafkaRequest.OrganisationId, kafkaRequest.CanaryServiceAccountClientID)
+		customClaimCheck := fmt.Sprintf("@.rh-org-id == '%s'|| @.org_id == '%s' || @.clientId == '%s'", kafkaRequest.OrganisationId, kafkaRequest.OrganisationId, kafkaRequest.CanaryServiceAccountClientID)
+		// both canary service accounts must be accepted while the canary credentials are being rotated
+		if kafkaRequest.CanaryServiceAccountRotationInProgress() {
+			customClaimCheck = fmt.Sprintf("%s || @.clientId == '%s'", customClaimCheck, kafkaRequest.PendingCanaryServiceAccountClientID)
+		}
+		return customClaimCheck
 	} else {
 		return fmt.Sprintf("@.rh-org-id == '%s'|| @.org_id == '%s'", kafkaRequest.OrganisationId, kafkaRequest.OrganisationId)
 	}
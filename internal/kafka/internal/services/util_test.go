@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/encryption"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
@@ -36,7 +37,7 @@ func buildKafkaDBApiRequest() dbapi.KafkaRequest {
 		Name:                             "test-cluster",
 		Status:                           "Creating",
 		CanaryServiceAccountClientID:     uuid.NewString(),
-		CanaryServiceAccountClientSecret: uuid.NewString(),
+		CanaryServiceAccountClientSecret: encryption.EncryptedString(uuid.NewString()),
 		SubscriptionId:                   "test",
 		Owner:                            "unit=test-user",
 		OwnerAccountId:                   uuid.NewString(),
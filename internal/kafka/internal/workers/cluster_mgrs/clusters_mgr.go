@@ -14,6 +14,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/ocm"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/constants"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 
 	"strings"
 	"sync"
@@ -111,6 +112,7 @@ type ClusterManagerOptions struct {
 	ClusterService             services.ClusterService
 	CloudProvidersService      services.CloudProvidersService
 	KasFleetshardOperatorAddon services.KasFleetshardOperatorAddon
+	ClusterClientSecretService services.ClusterClientSecretService
 	SsoService                 sso.KafkaKeycloakService
 	OsdIdpKeycloakService      sso.OsdKeycloakService
 	ProviderFactory            clusters.ProviderFactory
@@ -181,6 +183,7 @@ func (c *ClusterManager) Reconcile() []error {
 		c.processProvisionedClusters,
 		c.processWaitingForKasFleetshardOperatorClusters,
 		c.processReadyClusters,
+		c.processResourcesReconcileRequests,
 	}
 
 	for _, p := range processors {
@@ -347,6 +350,37 @@ func (c *ClusterManager) processReadyClusters() []error {
 	return errs
 }
 
+// processResourcesReconcileRequests reconciles the resources of the clusters an administrator requested it for, whether
+// the reconcile of the ready clusters is enabled or not. The requests of the clusters whose status no longer allows it,
+// e.g. because they are being deprovisioned, are withdrawn. A request renewed while the resources are reconciled is
+// kept so that the resources are reconciled again.
+func (c *ClusterManager) processResourcesReconcileRequests() []error {
+	var errs []error
+	requestedClusters, err := c.ClusterService.FindAllClusters(services.FindClusterCriteria{ResourcesReconcileRequested: true})
+	if err != nil {
+		errs = append(errs, errors.Wrap(err, "failed to list clusters whose resources reconcile has been requested"))
+		return errs
+	}
+
+	for _, requestedCluster := range requestedClusters {
+		if arrays.Contains(api.ClusterResourcesReconcilableStatuses, requestedCluster.Status) {
+			glog.Infof("reconciling the resources of cluster %q as requested at %s", requestedCluster.ClusterID, requestedCluster.ResourcesReconcileRequestedAt)
+			if err := c.reconcileClusterResources(*requestedCluster); err != nil {
+				errs = append(errs, errors.Wrapf(err, "failed to reconcile the requested resources of cluster %s", requestedCluster.ClusterID))
+				continue
+			}
+		} else {
+			glog.Infof("withdrawing the resources reconcile request of cluster %q as its status is %q", requestedCluster.ClusterID, requestedCluster.Status)
+		}
+
+		if err := c.ClusterService.WithdrawResourcesReconcileRequest(requestedCluster.ClusterID, *requestedCluster.ResourcesReconcileRequestedAt); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to withdraw the resources reconcile request of cluster %s", requestedCluster.ClusterID))
+		}
+	}
+
+	return errs
+}
+
 func (c *ClusterManager) processWaitingForKasFleetshardOperatorClusters() []error {
 	var errs []error
 	waitingClusters, listErr := c.ClusterService.ListByStatus(api.ClusterWaitingForKasFleetShardOperator)
@@ -569,19 +603,25 @@ func (c *ClusterManager) reconcileKasFleetshardOperator(cluster api.Cluster) err
 	if params, err := c.KasFleetshardOperatorAddon.ReconcileParameters(cluster); err != nil {
 		return errors.WithMessagef(err, "failed to reconcile kas-fleet-shard parameters of %s cluster %s: %s", cluster.Status, cluster.ClusterID, err.Error())
 	} else {
-		if cluster.ClientID == "" || cluster.ClientSecret == "" {
+		if cluster.ClientID == "" {
 			cluster.ClientID = params.GetParam(services.KasFleetshardOperatorParamServiceAccountId)
-			cluster.ClientSecret = params.GetParam(services.KasFleetshardOperatorParamServiceAccountSecret)
 			if err := c.ClusterService.Update(cluster); err != nil {
 				return errors.WithMessagef(err, "failed to reconcile clientID of %s cluster %s: %s", cluster.Status, cluster.ClusterID, err.Error())
 			}
 		}
+		if err := c.ClusterClientSecretService.MigrateClientSecret(&cluster); err != nil {
+			return errors.WithMessagef(err, "failed to migrate the client secret of %s cluster %s to the vault: %s", cluster.Status, cluster.ClusterID, err.Error())
+		}
 	}
 	return nil
 }
 
 func (c *ClusterManager) reconcileClusterResources(cluster api.Cluster) error {
-	resourceSet := c.buildResourceSet(cluster)
+	clientSecret, err := c.ClusterClientSecretService.GetClientSecret(&cluster)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the client secret of cluster %s", cluster.ClusterID)
+	}
+	resourceSet := c.buildResourceSet(cluster, clientSecret)
 	if err := c.ClusterService.ApplyResources(&cluster, resourceSet); err != nil {
 		return errors.Wrapf(err, "failed to apply resources for cluster %s", cluster.ClusterID)
 	}
@@ -633,9 +673,8 @@ func (c *ClusterManager) reconcileAddonOperator(provisionedCluster api.Cluster)
 		return false, errs
 	}
 
-	if provisionedCluster.ClientID == "" || provisionedCluster.ClientSecret == "" {
+	if provisionedCluster.ClientID == "" {
 		provisionedCluster.ClientID = params.GetParam(services.KasFleetshardOperatorParamServiceAccountId)
-		provisionedCluster.ClientSecret = params.GetParam(services.KasFleetshardOperatorParamServiceAccountSecret)
 		if err := c.ClusterService.Update(provisionedCluster); err != nil {
 			return false, errors.WithMessagef(err, "failed to reconcile clientID of %s cluster %s: %s", provisionedCluster.Status, provisionedCluster.ClusterID, err.Error())
 		}
@@ -745,7 +784,7 @@ func (c *ClusterManager) reconcileClusterWithManualConfig() []error {
 	return []error{}
 }
 
-func (c *ClusterManager) buildResourceSet(cluster api.Cluster) types.ResourceSet {
+func (c *ClusterManager) buildResourceSet(cluster api.Cluster, clientSecret string) types.ResourceSet {
 	var r []interface{}
 	switch cluster.ClusterType {
 	case api.ManagedDataPlaneClusterType.String():
@@ -771,7 +810,7 @@ func (c *ClusterManager) buildResourceSet(cluster api.Cluster) types.ResourceSet
 		c.buildObservabilityCatalogSourceResource(),
 		c.buildObservabilityOperatorGroupResource(),
 		c.buildObservabilitySubscriptionResource(),
-		c.buildObservabilityRemoteWriteServiceAccountCredential(&cluster),
+		c.buildObservabilityRemoteWriteServiceAccountCredential(&cluster, clientSecret),
 	)
 
 	strimziNamespace := strimziAddonNamespace
@@ -879,7 +918,7 @@ func (c *ClusterManager) buildObservatoriumDexSecretResource() *k8sCoreV1.Secret
 	}
 }
 
-func (c *ClusterManager) buildObservabilityRemoteWriteServiceAccountCredential(cluster *api.Cluster) *k8sCoreV1.Secret {
+func (c *ClusterManager) buildObservabilityRemoteWriteServiceAccountCredential(cluster *api.Cluster, clientSecret string) *k8sCoreV1.Secret {
 	return &k8sCoreV1.Secret{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: k8sCoreV1.SchemeGroupVersion.String(),
@@ -891,7 +930,7 @@ func (c *ClusterManager) buildObservabilityRemoteWriteServiceAccountCredential(c
 		},
 		StringData: map[string]string{
 			"client_id":     cluster.ClientID,
-			"client_secret": cluster.ClientSecret,
+			"client_secret": clientSecret,
 			"issuer_url":    c.SsoService.GetRealmConfig().ValidIssuerURI,
 		},
 	}
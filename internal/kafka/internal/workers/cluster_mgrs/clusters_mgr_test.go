@@ -3,6 +3,7 @@ package cluster_mgrs
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/cloudproviders"
@@ -232,6 +233,9 @@ func TestClusterManager_reconcile(t *testing.T) {
 					ListGroupByProviderAndRegionFunc: func(providers []string, regions []string, status []string) ([]*services.ResGroupCPRegion, *apiErrors.ServiceError) {
 						return []*services.ResGroupCPRegion{}, nil
 					},
+					FindAllClustersFunc: func(criteria services.FindClusterCriteria) ([]*api.Cluster, error) {
+						return []*api.Cluster{}, nil
+					},
 				},
 				dataplaneClusterConfig: &config.DataplaneClusterConfig{
 					DataPlaneClusterScalingType: config.AutoScaling,
@@ -819,6 +823,7 @@ func TestClusterManager_processProvisionedClusters(t *testing.T) {
 			g := gomega.NewWithT(t)
 			c := &ClusterManager{
 				ClusterManagerOptions: ClusterManagerOptions{
+					ClusterClientSecretService: newClusterClientSecretServiceMock(),
 					ClusterService:             tt.fields.clusterService,
 					OsdIdpKeycloakService:      tt.fields.osdIdpKeycloakService,
 					DataplaneClusterConfig:     tt.fields.dataplaneClusterConfig,
@@ -1086,6 +1091,7 @@ func TestClusterManager_reconcileReadyCluster(t *testing.T) {
 			g := gomega.NewWithT(t)
 			c := &ClusterManager{
 				ClusterManagerOptions: ClusterManagerOptions{
+					ClusterClientSecretService: newClusterClientSecretServiceMock(),
 					ClusterService:             tt.fields.clusterService,
 					DataplaneClusterConfig:     tt.fields.dataplaneClusterConfig,
 					OCMConfig:                  &ocm.OCMConfig{StrimziOperatorAddonID: strimziAddonID},
@@ -1268,6 +1274,7 @@ func TestClusterManager_reconcileWaitingForKasFleetshardOperatorCluster(t *testi
 		t.Run(tt.name, func(t *testing.T) {
 			c := &ClusterManager{
 				ClusterManagerOptions: ClusterManagerOptions{
+					ClusterClientSecretService: newClusterClientSecretServiceMock(),
 					ClusterService:             tt.fields.clusterService,
 					DataplaneClusterConfig:     tt.fields.dataplaneClusterConfig,
 					OCMConfig:                  &ocm.OCMConfig{StrimziOperatorAddonID: strimziAddonID},
@@ -1557,6 +1564,7 @@ func TestClusterManager_reconcileProvisionedCluster(t *testing.T) {
 			g := gomega.NewWithT(t)
 			c := &ClusterManager{
 				ClusterManagerOptions: ClusterManagerOptions{
+					ClusterClientSecretService: newClusterClientSecretServiceMock(),
 					ClusterService:             tt.fields.clusterService,
 					DataplaneClusterConfig:     tt.fields.dataplaneClusterConfig,
 					OCMConfig:                  &ocm.OCMConfig{StrimziOperatorAddonID: strimziAddonID},
@@ -1662,6 +1670,7 @@ func TestClusterManager_processWaitingForKasFleetshardOperatorClusters(t *testin
 			g := gomega.NewWithT(t)
 			c := &ClusterManager{
 				ClusterManagerOptions: ClusterManagerOptions{
+					ClusterClientSecretService: newClusterClientSecretServiceMock(),
 					ClusterService:             tt.fields.clusterService,
 					DataplaneClusterConfig:     tt.fields.dataplaneClusterConfig,
 					ObservabilityConfiguration: tt.fields.observabilityConfiguration,
@@ -1680,6 +1689,7 @@ func TestClusterManager_reconcileKasFleetshardOperator(t *testing.T) {
 	type fields struct {
 		clusterService             services.ClusterService
 		kasFleetshardOperatorAddon services.KasFleetshardOperatorAddon
+		clusterClientSecretService services.ClusterClientSecretService
 	}
 	tests := []struct {
 		name    string
@@ -1712,6 +1722,24 @@ func TestClusterManager_reconcileKasFleetshardOperator(t *testing.T) {
 			arg:     api.Cluster{ClientID: "Client ID", ClientSecret: "secret"},
 			wantErr: false,
 		},
+		{
+			name: "error when the client secret of the cluster cannot be migrated to the vault",
+			fields: fields{
+				clusterService: &services.ClusterServiceMock{},
+				kasFleetshardOperatorAddon: &services.KasFleetshardOperatorAddonMock{
+					ReconcileParametersFunc: func(cluster api.Cluster) (services.ParameterList, *apiErrors.ServiceError) {
+						return nil, nil
+					},
+				},
+				clusterClientSecretService: &services.ClusterClientSecretServiceMock{
+					MigrateClientSecretFunc: func(cluster *api.Cluster) *apiErrors.ServiceError {
+						return &apiErrors.ServiceError{}
+					},
+				},
+			},
+			arg:     api.Cluster{ClientID: "Client ID", ClientSecret: "secret"},
+			wantErr: true,
+		},
 		{
 			name: "error when UpdateFunc returns error",
 			fields: fields{
@@ -1750,8 +1778,13 @@ func TestClusterManager_reconcileKasFleetshardOperator(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
+			clusterClientSecretService := tt.fields.clusterClientSecretService
+			if clusterClientSecretService == nil {
+				clusterClientSecretService = newClusterClientSecretServiceMock()
+			}
 			c := &ClusterManager{
 				ClusterManagerOptions: ClusterManagerOptions{
+					ClusterClientSecretService: clusterClientSecretService,
 					ClusterService:             tt.fields.clusterService,
 					KasFleetshardOperatorAddon: tt.fields.kasFleetshardOperatorAddon,
 				},
@@ -2433,7 +2466,7 @@ func buildResourceSet(observabilityConfig observatorium.ObservabilityConfigurati
 			},
 			StringData: map[string]string{
 				"client_id":     cluster.ClientID,
-				"client_secret": cluster.ClientSecret,
+				"client_secret": testClientSecret,
 				"issuer_url":    "dummy",
 			},
 		})
@@ -2513,6 +2546,114 @@ func buildResourceSet(observabilityConfig observatorium.ObservabilityConfigurati
 	}, nil
 }
 
+func TestClusterManager_processResourcesReconcileRequests(t *testing.T) {
+	requestedAt := time.Now()
+	requestedCluster := &api.Cluster{ClusterID: "test-cluster-id", ProviderType: "ocm", ClusterType: api.ManagedDataPlaneClusterType.String(), Status: api.ClusterReady, ResourcesReconcileRequestedAt: &requestedAt}
+	deprovisioningCluster := &api.Cluster{ClusterID: "test-cluster-id", ProviderType: "ocm", ClusterType: api.ManagedDataPlaneClusterType.String(), Status: api.ClusterDeprovisioning, ResourcesReconcileRequestedAt: &requestedAt}
+
+	tests := []struct {
+		name                       string
+		clusterService             *services.ClusterServiceMock
+		wantErr                    bool
+		wantWithdrawnRequestsCount int
+	}{
+		{
+			name: "should return an error if finding the clusters fails",
+			clusterService: &services.ClusterServiceMock{
+				FindAllClustersFunc: func(criteria services.FindClusterCriteria) ([]*api.Cluster, error) {
+					return nil, errors.New("failed to find clusters")
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should not withdraw the request if the resources can not be applied",
+			clusterService: &services.ClusterServiceMock{
+				FindAllClustersFunc: func(criteria services.FindClusterCriteria) ([]*api.Cluster, error) {
+					return []*api.Cluster{requestedCluster}, nil
+				},
+				ApplyResourcesFunc: func(cluster *api.Cluster, resources types.ResourceSet) *apiErrors.ServiceError {
+					return apiErrors.GeneralError("failed to apply resources")
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should reconcile the resources of the requested clusters and withdraw their requests",
+			clusterService: &services.ClusterServiceMock{
+				FindAllClustersFunc: func(criteria services.FindClusterCriteria) ([]*api.Cluster, error) {
+					return []*api.Cluster{requestedCluster}, nil
+				},
+				ApplyResourcesFunc: func(cluster *api.Cluster, resources types.ResourceSet) *apiErrors.ServiceError {
+					return nil
+				},
+				WithdrawResourcesReconcileRequestFunc: func(clusterID string, requestedAt time.Time) *apiErrors.ServiceError {
+					return nil
+				},
+			},
+			wantWithdrawnRequestsCount: 1,
+		},
+		{
+			name: "should withdraw the requests of the clusters whose resources cannot be reconciled anymore without reconciling them",
+			clusterService: &services.ClusterServiceMock{
+				FindAllClustersFunc: func(criteria services.FindClusterCriteria) ([]*api.Cluster, error) {
+					return []*api.Cluster{deprovisioningCluster}, nil
+				},
+				WithdrawResourcesReconcileRequestFunc: func(clusterID string, requestedAt time.Time) *apiErrors.ServiceError {
+					return nil
+				},
+			},
+			wantWithdrawnRequestsCount: 1,
+		},
+		{
+			name: "should return an error if the request cannot be withdrawn",
+			clusterService: &services.ClusterServiceMock{
+				FindAllClustersFunc: func(criteria services.FindClusterCriteria) ([]*api.Cluster, error) {
+					return []*api.Cluster{requestedCluster}, nil
+				},
+				ApplyResourcesFunc: func(cluster *api.Cluster, resources types.ResourceSet) *apiErrors.ServiceError {
+					return nil
+				},
+				WithdrawResourcesReconcileRequestFunc: func(clusterID string, requestedAt time.Time) *apiErrors.ServiceError {
+					return apiErrors.GeneralError("failed to withdraw the request")
+				},
+			},
+			wantErr:                    true,
+			wantWithdrawnRequestsCount: 1,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			obsConfig := buildObservabilityConfig()
+			c := &ClusterManager{
+				ClusterManagerOptions: ClusterManagerOptions{
+					ClusterClientSecretService: newClusterClientSecretServiceMock(),
+					ClusterService:             tt.clusterService,
+					SupportedProviders:         &config.ProviderConfig{},
+					ObservabilityConfiguration: &obsConfig,
+					DataplaneClusterConfig:     &config.DataplaneClusterConfig{},
+					OCMConfig:                  &ocm.OCMConfig{},
+					SsoService:                 keycloakServiceMock,
+				},
+			}
+
+			g.Expect(len(c.processResourcesReconcileRequests()) > 0).To(gomega.Equal(tt.wantErr))
+			if calls := tt.clusterService.FindAllClustersCalls(); len(calls) > 0 {
+				g.Expect(calls[0].Criteria).To(gomega.Equal(services.FindClusterCriteria{ResourcesReconcileRequested: true}))
+			}
+			withdrawnRequests := tt.clusterService.WithdrawResourcesReconcileRequestCalls()
+			g.Expect(withdrawnRequests).To(gomega.HaveLen(tt.wantWithdrawnRequestsCount))
+			for _, call := range withdrawnRequests {
+				g.Expect(call.ClusterID).To(gomega.Equal(requestedCluster.ClusterID))
+				g.Expect(call.RequestedAt).To(gomega.Equal(requestedAt))
+			}
+		})
+	}
+}
+
 func TestClusterManager_reconcileClusterResourceSet(t *testing.T) {
 	g := gomega.NewWithT(t)
 
@@ -2617,6 +2758,7 @@ func TestClusterManager_reconcileClusterResourceSet(t *testing.T) {
 			obsConfig := tt.fields.observabilityConfigFactory()
 			c := &ClusterManager{
 				ClusterManagerOptions: ClusterManagerOptions{
+					ClusterClientSecretService: newClusterClientSecretServiceMock(),
 					ClusterService:             tt.fields.clusterService,
 					SupportedProviders:         &config.ProviderConfig{},
 					ObservabilityConfiguration: &obsConfig,
@@ -3928,3 +4070,18 @@ func TestClusterManager_setClusterProviderResourceQuotaMetrics(t *testing.T) {
 		})
 	}
 }
+
+const testClientSecret = "test-client-secret"
+
+// newClusterClientSecretServiceMock returns a client secret service whose clusters have their client secret in the
+// vault
+func newClusterClientSecretServiceMock() *services.ClusterClientSecretServiceMock {
+	return &services.ClusterClientSecretServiceMock{
+		GetClientSecretFunc: func(cluster *api.Cluster) (string, *apiErrors.ServiceError) {
+			return testClientSecret, nil
+		},
+		MigrateClientSecretFunc: func(cluster *api.Cluster) *apiErrors.ServiceError {
+			return nil
+		},
+	}
+}
@@ -1,6 +1,10 @@
 package cluster_mgrs
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
 	fleeterrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
@@ -23,6 +27,9 @@ type DynamicScaleDownManager struct {
 	clusterProvidersConfig *config.ProviderConfig
 	kafkaConfig            *config.KafkaConfig
 	clusterService         services.ClusterService
+	kafkaService           services.KafkaService
+	kafkaMigrationService  services.KafkaMigrationService
+	scalingDecisions       services.ClusterScalingDecisionService
 }
 
 var _ workers.Worker = &DynamicScaleDownManager{}
@@ -33,6 +40,9 @@ func NewDynamicScaleDownManager(
 	clusterProvidersConfig *config.ProviderConfig,
 	kafkaConfig *config.KafkaConfig,
 	clusterService services.ClusterService,
+	kafkaService services.KafkaService,
+	kafkaMigrationService services.KafkaMigrationService,
+	scalingDecisions services.ClusterScalingDecisionService,
 ) *DynamicScaleDownManager {
 
 	return &DynamicScaleDownManager{
@@ -46,6 +56,9 @@ func NewDynamicScaleDownManager(
 		clusterProvidersConfig: clusterProvidersConfig,
 		kafkaConfig:            kafkaConfig,
 		clusterService:         clusterService,
+		kafkaService:           kafkaService,
+		kafkaMigrationService:  kafkaMigrationService,
+		scalingDecisions:       scalingDecisions,
 	}
 }
 
@@ -106,24 +119,30 @@ func (m *DynamicScaleDownManager) processDynamicScaleDownReconcileEvent() error
 			regionsSupportedInstanceType:           regionsSupportedInstanceType,
 			supportedKafkaInstanceTypesConfig:      &m.kafkaConfig.SupportedInstanceTypes.Configuration,
 			clusterService:                         m.clusterService,
+			kafkaService:                           m.kafkaService,
+			kafkaMigrationService:                  m.kafkaMigrationService,
+			scalingDecisions:                       m.scalingDecisions,
+			drainPolicy:                            &m.dataplaneClusterConfig.DynamicScalingConfig.ScaleDownDrainPolicy,
 			dryRun:                                 !m.dataplaneClusterConfig.DynamicScalingConfig.IsDataplaneScaleDownTriggerEnabled(),
 			clusterID:                              clusterID,
 			indexesOfStreamingUnitForSameClusterID: existing.indexesOfStreamingUnitForSameClusterID,
 		}
 
 		glog.Infof("evaluating dynamic scale down for cluster %q", clusterID)
-		shouldScaleDown, err := dynamicScaleDownProcessor.ShouldScaleDown()
+		shouldScaleDown, reason, err := dynamicScaleDownProcessor.ShouldScaleDown()
 		if err != nil {
 			errList.AddErrors(err)
 			continue
 		}
 		if shouldScaleDown {
 			glog.Infof("data plane scale down need detected for cluster %q", clusterID)
-			err := dynamicScaleDownProcessor.ScaleDown()
-			if err != nil {
-				errList.AddErrors(err)
-				continue
-			}
+			err = dynamicScaleDownProcessor.ScaleDown(reason)
+		} else {
+			err = dynamicScaleDownProcessor.KeepCluster(reason)
+		}
+		if err != nil {
+			errList.AddErrors(err)
+			continue
 		}
 	}
 
@@ -201,12 +220,16 @@ func (m *DynamicScaleDownManager) findRegionInstanceTypeConfiguration(suCount se
 
 // dynamicScaleDownExecutor is able to perform dynamic ScaleDown execution actions
 type dynamicScaleDownExecutor interface {
-	ScaleDown() error
+	// ScaleDown progresses the removal of the cluster. The reason explains why the cluster can be removed.
+	ScaleDown(reason string) error
+	// KeepCluster stops the removal of the cluster, if any. The reason explains why the cluster cannot be removed.
+	KeepCluster(reason string) error
 }
 
 // dynamicScaleDownEvaluator is able to perform dynamic ScaleDown evaluation actions
 type dynamicScaleDownEvaluator interface {
-	ShouldScaleDown() (bool, error)
+	// ShouldScaleDown indicates whether the cluster can be removed along with the reason of the outcome
+	ShouldScaleDown() (bool, string, error)
 }
 
 // dynamicScaleDownProcessor is able to process dynamic ScaleDown reconcile events
@@ -230,9 +253,14 @@ type standardDynamicScaleDownProcessor struct {
 	indexesOfStreamingUnitForSameClusterID []int
 	supportedKafkaInstanceTypesConfig      *config.SupportedKafkaInstanceTypesConfig
 	clusterService                         services.ClusterService
+	kafkaService                           services.KafkaService
+	kafkaMigrationService                  services.KafkaMigrationService
+	scalingDecisions                       services.ClusterScalingDecisionService
+	drainPolicy                            *config.ScaleDownDrainPolicy
 
-	// dryRun controls whether the ScaleDown method performs real actions.
+	// dryRun controls whether the ScaleDown and KeepCluster methods perform real actions.
 	// Useful when you don't want to trigger a real scale down.
+	// The decisions are recorded as dry run ones.
 	dryRun bool
 }
 
@@ -242,9 +270,11 @@ var _ dynamicScaleDownProcessor = &standardDynamicScaleDownProcessor{}
 
 // ShouldScaleDown indicates whether a data plane cluster can de deprovisioned.
 // It returns true if all the following conditions happen:
-// 1. If specified the cluster is empty i.e it does not contain any streaming unit
+// 0. The cluster has not been cordoned by an administrator: such a cluster no longer receives new kafkas but it is kept
+// 1. If specified the cluster is empty i.e it does not contain any streaming unit, or the drain policy allows
+// migrating all the kafkas left on the cluster
 // 2. If the cluster can be removed without triggering a scale up action
-// Otherwise false is returned.
+// Otherwise false is returned. The reason of the outcome is returned as well.
 // Note:
 // 1. This method assumes kafkaStreamingUnitCountPerClusterList does not
 // contain elements with the Status attribute with the 'failed' value.
@@ -253,26 +283,52 @@ var _ dynamicScaleDownProcessor = &standardDynamicScaleDownProcessor{}
 // the calculations.
 // 2. Clusters in deprovisioning and cleanup state are excluded, as clusters into those states don't accept kafka instances anymore.
 // 3. Clusters that are still not ready to accept kafka instance are also excluded from the capacity calculation
-func (p *standardDynamicScaleDownProcessor) ShouldScaleDown() (bool, error) {
-	// First let's check if the cluster is empty
+func (p *standardDynamicScaleDownProcessor) ShouldScaleDown() (bool, string, error) {
+	if p.isManuallyCordoned() {
+		glog.Infof("cluster with cluster id %q has been cordoned by an administrator. It is not going to be removed", p.clusterID)
+		return false, "the cluster has been cordoned by an administrator", nil
+	}
+
+	// First let's check if the cluster is empty or if its kafkas can be migrated off it
 	if p.isClusterNotEmpty() {
-		return false, nil
+		if p.drainPolicy == nil || !p.drainPolicy.MigrateRemainingKafkas {
+			return false, "the cluster is not empty", nil
+		}
+		unmigratableKafka, unmigratableReason, err := p.findUnmigratableKafka()
+		if err != nil {
+			return false, "", err
+		}
+		if unmigratableKafka != nil {
+			glog.Infof("kafka %q cannot be migrated off the cluster with cluster id %q: %s. It is not going to be removed", unmigratableKafka.ID, p.clusterID, unmigratableReason)
+			return false, fmt.Sprintf("the cluster is not empty and kafka %q cannot be migrated off it: %s", unmigratableKafka.ID, unmigratableReason), nil
+		}
 	}
 
 	// let's check if the cluster can be safely removed without causing a scale up event
-	if len(p.regionsSupportedInstanceType) == 0 { // if no region limits are available it means that this cluster is in a region that's not supported anymore, we can safely delete it if it is empty
-		glog.Infof("no region limits are available. cluster with cluster id %q is going to be removed as it is empty", p.clusterID)
-		return true, nil
+	if len(p.regionsSupportedInstanceType) == 0 { // if no region limits are available it means that this cluster is in a region that's not supported anymore, we can safely delete it if it is empty or drained
+		if p.isClusterNotEmpty() {
+			glog.Infof("no region limits are available. cluster with cluster id %q is going to be removed once its kafkas are migrated off it", p.clusterID)
+		} else {
+			glog.Infof("no region limits are available. cluster with cluster id %q is going to be removed as it is empty", p.clusterID)
+		}
+		return true, "the region of the cluster is no longer supported", nil
 	}
 
-	newkafkaStreamingUnitCountPerClusterList := p.createNewStreamingUnitPerClusterListAfterRemovalOfCandidateCluster()
+	newkafkaStreamingUnitCountPerClusterList, candidateStreamingUnitsFit := p.createNewStreamingUnitPerClusterListAfterRemovalOfCandidateCluster()
+	if !candidateStreamingUnitsFit {
+		glog.Infof("the kafkas of the cluster with cluster id %q do not fit in the other clusters. It is not going to be removed", p.clusterID)
+		return false, "the cluster is not empty and its kafkas do not fit in the other clusters", nil
+	}
 	scaleUpNeededAfterRemoval, err := p.isScaleUpNeededAfterCandidateClusterRemoval(newkafkaStreamingUnitCountPerClusterList)
 	if err != nil {
-		return false, err
+		return false, "", err
 	}
 
 	// to safely perform scale down, there shouldn't a need of scale up immediately afterwards
-	return !scaleUpNeededAfterRemoval, nil
+	if scaleUpNeededAfterRemoval {
+		return false, "removing the cluster would trigger a scale up", nil
+	}
+	return true, "the cluster can be removed without triggering a scale up", nil
 }
 
 // isClusterNotEmpty checks whether the cluster is not empty.
@@ -281,7 +337,7 @@ func (p *standardDynamicScaleDownProcessor) isClusterNotEmpty() bool {
 	for _, i := range p.indexesOfStreamingUnitForSameClusterID {
 		clusterIsNotEmpty := p.kafkaStreamingUnitCountPerClusterList[i].Count > 0
 		if clusterIsNotEmpty {
-			glog.Infof("cluster with cluster id %q is not empty. It is not going to be removed", p.clusterID)
+			glog.Infof("cluster with cluster id %q is not empty", p.clusterID)
 			return true
 		}
 	}
@@ -289,10 +345,48 @@ func (p *standardDynamicScaleDownProcessor) isClusterNotEmpty() bool {
 	return false
 }
 
+// findUnmigratableKafka returns the first kafka of the cluster that cannot be migrated off the cluster, along with the
+// reason: the kafka is not ready, it is being promoted or resized, or the drain policy does not allow migrating it.
+// Nil is returned when all the kafkas of the cluster are migratable.
+func (p *standardDynamicScaleDownProcessor) findUnmigratableKafka() (*dbapi.KafkaRequest, string, error) {
+	kafkas, err := p.kafkaService.ListKafkasByClusterID(p.clusterID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for _, kafka := range kafkas {
+		// the kafkas migrated off the cluster already are being deprovisioned from it and the kafkas being migrated
+		// are leaving it
+		if kafka.ClusterID != p.clusterID || kafka.MigrationInProgress() {
+			continue
+		}
+		if err := services.ValidateKafkaCanBeMigrated(kafka); err != nil {
+			return kafka, err.Reason, nil
+		}
+		instanceType, err := p.supportedKafkaInstanceTypesConfig.GetKafkaInstanceTypeByID(kafka.InstanceType)
+		if err != nil {
+			return nil, "", err
+		}
+		instanceSize, err := instanceType.GetKafkaInstanceSizeByID(kafka.SizeId)
+		if err != nil {
+			return nil, "", err
+		}
+		if !p.drainPolicy.IsKafkaMigratable(kafka.InstanceType, instanceSize.CapacityConsumed) {
+			return kafka, "the drain policy does not allow migrating it", nil
+		}
+	}
+
+	return nil, "", nil
+}
+
 // createNewStreamingUnitPerClusterListAfterRemovalOfCandidateCluster creates a new kafka streaming unit list with the following charcteristics:
 // 1. The candidate deletion cluster is removed from the original list
 // 2. Ignore clusters in terraforming states for scale up evaluation as they are not ready yet. We only want to delete the cluster if we've a sibling ready cluster
-func (p *standardDynamicScaleDownProcessor) createNewStreamingUnitPerClusterListAfterRemovalOfCandidateCluster() services.KafkaStreamingUnitCountPerClusterList {
+// 3. Ignore the other cordoned clusters as they are being removed as well
+// 4. The streaming units consumed on the candidate cluster are added to its sibling clusters, as its kafkas are migrated
+// to them when the drain policy allows it
+// It also returns whether the streaming units consumed on the candidate cluster fit in its sibling clusters.
+func (p *standardDynamicScaleDownProcessor) createNewStreamingUnitPerClusterListAfterRemovalOfCandidateCluster() (services.KafkaStreamingUnitCountPerClusterList, bool) {
 	newkafkaStreamingUnitCountPerClusterList := services.KafkaStreamingUnitCountPerClusterList{}
 
 	clusterStatesTowardReadyState := []string{
@@ -316,9 +410,53 @@ func (p *standardDynamicScaleDownProcessor) createNewStreamingUnitPerClusterList
 			continue
 		}
 
+		if suCount.CordonedAt != nil {
+			glog.V(10).Infof("skipping cordoned cluster with cluster id %q", suCount.ClusterId)
+			continue
+		}
+
 		newkafkaStreamingUnitCountPerClusterList = append(newkafkaStreamingUnitCountPerClusterList, suCount)
 	}
-	return newkafkaStreamingUnitCountPerClusterList
+
+	candidateStreamingUnitsFit := true
+	for _, i := range p.indexesOfStreamingUnitForSameClusterID {
+		if !p.moveStreamingUnitsToSiblingClusters(p.kafkaStreamingUnitCountPerClusterList[i], newkafkaStreamingUnitCountPerClusterList) {
+			candidateStreamingUnitsFit = false
+		}
+	}
+	return newkafkaStreamingUnitCountPerClusterList, candidateStreamingUnitsFit
+}
+
+// moveStreamingUnitsToSiblingClusters adds the streaming units consumed by the given streaming unit count of the
+// candidate cluster to the sibling clusters supporting the same instance type in the same region, up to their free
+// capacity. It returns false if the streaming units do not fit in the sibling clusters.
+func (p *standardDynamicScaleDownProcessor) moveStreamingUnitsToSiblingClusters(candidate services.KafkaStreamingUnitCountPerCluster, siblings services.KafkaStreamingUnitCountPerClusterList) bool {
+	clusterStatesTowardDeletion := []string{api.ClusterDeprovisioning.String(), api.ClusterCleanup.String()}
+
+	remaining := candidate.Count
+	for j := range siblings {
+		if remaining <= 0 {
+			break
+		}
+		sibling := &siblings[j]
+		if sibling.CloudProvider != candidate.CloudProvider || sibling.Region != candidate.Region ||
+			sibling.InstanceType != candidate.InstanceType || sibling.ClusterType != candidate.ClusterType ||
+			arrays.Contains(clusterStatesTowardDeletion, sibling.Status) {
+			continue
+		}
+		moved := sibling.FreeStreamingUnits()
+		if moved <= 0 {
+			continue
+		}
+		if moved > remaining {
+			moved = remaining
+		}
+		glog.V(10).Infof("moving %d streaming unit(s) of instance type %q from candidate cluster with cluster id %q to cluster with cluster id %q", moved, candidate.InstanceType, p.clusterID, sibling.ClusterId)
+		sibling.Count += moved
+		remaining -= moved
+	}
+
+	return remaining <= 0
 }
 
 // isScaleUpNeededAfterCandidateClusterRemoval evaluates wheteher scale up is needed given the newkafkaStreamingUnitCountPerClusterList which does not contain the
@@ -364,13 +502,49 @@ func (p *standardDynamicScaleDownProcessor) isScaleUpNeededAfterCandidateCluster
 	return false, nil
 }
 
-// ScaleDown marks the cluster as deprovisioning.
-func (p *standardDynamicScaleDownProcessor) ScaleDown() error {
+// ScaleDown progresses the removal of the cluster according to the drain policy:
+// 1. the cluster is cordoned so that it no longer receives new kafkas
+// 2. the cluster stays cordoned until the grace period elapses
+// 3. the kafkas left on the cluster are migrated off it, if any
+// 4. the cluster is marked as deprovisioning once it is empty
+//
+// It is meant to be called on every reconcile until the cluster is marked as deprovisioning. Each step is recorded as a
+// scaling decision of the cluster.
+func (p *standardDynamicScaleDownProcessor) ScaleDown(reason string) error {
 	if p.dryRun {
 		glog.Infof("scale down running in dryRun mode. No action is taken for cluster with cluster id %q.", p.clusterID)
+		p.recordDecision(dbapi.ClusterScalingDecisionWouldCordon, reason)
 		return nil
 	}
 
+	cordonedAt := p.cordonedAt()
+	if cordonedAt == nil {
+		now := time.Now()
+		glog.Infof("cordoning the cluster with cluster id %q", p.clusterID)
+		if err := p.updateCordonedAt(&now); err != nil {
+			return err
+		}
+		p.recordDecision(dbapi.ClusterScalingDecisionCordoned, reason)
+		cordonedAt = &now
+	}
+
+	var gracePeriod time.Duration
+	if p.drainPolicy != nil {
+		gracePeriod = p.drainPolicy.CordonGracePeriod
+	}
+	if gracePeriodEnd := cordonedAt.Add(gracePeriod); time.Now().Before(gracePeriodEnd) {
+		glog.Infof("cluster with cluster id %q is cordoned until %s", p.clusterID, gracePeriodEnd.Format(time.RFC3339))
+		p.recordDecision(dbapi.ClusterScalingDecisionWaitingForGracePeriod, fmt.Sprintf("the cluster is cordoned until %s", gracePeriodEnd.UTC().Format(time.RFC3339)))
+		return nil
+	}
+
+	if p.isClusterNotEmpty() || (p.drainPolicy != nil && p.drainPolicy.MigrateRemainingKafkas) {
+		drained, err := p.drain()
+		if err != nil || !drained {
+			return err
+		}
+	}
+
 	glog.Infof("marking the cluster with cluster id %q as deprovisioning", p.clusterID)
 	err := p.clusterService.UpdateStatus(api.Cluster{ClusterID: p.clusterID}, api.ClusterDeprovisioning)
 	if err != nil {
@@ -383,6 +557,113 @@ func (p *standardDynamicScaleDownProcessor) ScaleDown() error {
 		p.kafkaStreamingUnitCountPerClusterList[i].Status = api.ClusterDeprovisioning.String()
 	}
 
+	p.recordDecision(dbapi.ClusterScalingDecisionDeprovisioned, reason)
 	glog.Infof("cluster with cluster id %q marked as 'deprovisioning' successfully", p.clusterID)
 	return nil
 }
+
+// drain migrates the kafkas left on the cluster off it. It returns true once no kafka consumes resources on the
+// cluster anymore, including the kafkas that were migrated off it and that are being deprovisioned from it.
+func (p *standardDynamicScaleDownProcessor) drain() (bool, error) {
+	kafkas, err := p.kafkaService.ListKafkasByClusterID(p.clusterID)
+	if err != nil {
+		return false, err
+	}
+	if len(kafkas) == 0 {
+		return true, nil
+	}
+
+	var errList fleeterrors.ErrorList
+	for _, kafka := range kafkas {
+		// the kafkas migrated off the cluster already are being deprovisioned from it and the kafkas being migrated
+		// are leaving it
+		if kafka.ClusterID != p.clusterID || kafka.MigrationInProgress() {
+			continue
+		}
+		// the kafkas that cannot be migrated yet keep the cluster from being removed until they can
+		if err := services.ValidateKafkaCanBeMigrated(kafka); err != nil {
+			glog.Infof("kafka %q cannot be migrated off the cluster with cluster id %q yet: %s", kafka.ID, p.clusterID, err.Reason)
+			continue
+		}
+		// the failed migrations are retried. The failure is recorded so that it is visible in the decisions of the cluster
+		if kafka.MigrationStatus == dbapi.KafkaMigrationStatusFailed {
+			glog.Warningf("retrying the failed migration of kafka %q off the cluster with cluster id %q: %s", kafka.ID, p.clusterID, kafka.MigrationDetails)
+			p.recordDecision(dbapi.ClusterScalingDecisionMigratingKafkas, fmt.Sprintf("retrying the failed migration of kafka %q: %s", kafka.ID, kafka.MigrationDetails))
+		}
+		glog.Infof("migrating kafka %q off the cluster with cluster id %q", kafka.ID, p.clusterID)
+		if err := p.kafkaMigrationService.StartMigration(kafka, ""); err != nil {
+			errList.AddErrors(fmt.Errorf("failed to migrate kafka %q off cluster %q: %w", kafka.ID, p.clusterID, err))
+		}
+	}
+
+	p.recordDecision(dbapi.ClusterScalingDecisionMigratingKafkas, fmt.Sprintf("waiting for %d kafka(s) to leave the cluster", len(kafkas)))
+	if errList.IsEmpty() {
+		return false, nil
+	}
+	return false, errList
+}
+
+// KeepCluster uncordons the cluster if it was cordoned by the dynamic scale down: it receives new kafkas again.
+// The clusters cordoned by an administrator stay cordoned.
+func (p *standardDynamicScaleDownProcessor) KeepCluster(reason string) error {
+	if p.dryRun || p.cordonedAt() == nil || p.isManuallyCordoned() {
+		p.recordDecision(dbapi.ClusterScalingDecisionKept, reason)
+		return nil
+	}
+
+	glog.Infof("uncordoning the cluster with cluster id %q", p.clusterID)
+	if err := p.updateCordonedAt(nil); err != nil {
+		return err
+	}
+	p.recordDecision(dbapi.ClusterScalingDecisionUncordoned, reason)
+	return nil
+}
+
+// cordonedAt returns the time the cluster has been cordoned, nil if it is not cordoned
+func (p *standardDynamicScaleDownProcessor) cordonedAt() *time.Time {
+	for _, i := range p.indexesOfStreamingUnitForSameClusterID {
+		if cordonedAt := p.kafkaStreamingUnitCountPerClusterList[i].CordonedAt; cordonedAt != nil {
+			return cordonedAt
+		}
+	}
+	return nil
+}
+
+// isManuallyCordoned returns true when the cluster has been cordoned by an administrator
+func (p *standardDynamicScaleDownProcessor) isManuallyCordoned() bool {
+	for _, i := range p.indexesOfStreamingUnitForSameClusterID {
+		if p.kafkaStreamingUnitCountPerClusterList[i].ManuallyCordoned {
+			return true
+		}
+	}
+	return false
+}
+
+// updateCordonedAt cordons or uncordons the cluster. The in memory streaming units are updated as well so that the
+// evaluation of the next clusters takes it into account.
+func (p *standardDynamicScaleDownProcessor) updateCordonedAt(cordonedAt *time.Time) error {
+	if err := p.clusterService.UpdateCordonedAt(p.clusterID, cordonedAt); err != nil {
+		return err
+	}
+	for _, i := range p.indexesOfStreamingUnitForSameClusterID {
+		p.kafkaStreamingUnitCountPerClusterList[i].CordonedAt = cordonedAt
+	}
+	return nil
+}
+
+// recordDecision records the decision taken about the cluster. Failures are only logged as recording the decision must
+// never prevent the scale down from progressing.
+func (p *standardDynamicScaleDownProcessor) recordDecision(decision dbapi.ClusterScalingDecisionType, reason string) {
+	if p.scalingDecisions == nil {
+		return
+	}
+	scalingDecision := &dbapi.ClusterScalingDecision{
+		ClusterId: p.clusterID,
+		Decision:  decision,
+		Reason:    reason,
+		DryRun:    p.dryRun,
+	}
+	if err := p.scalingDecisions.Record(scalingDecision); err != nil {
+		glog.Errorf("failed to record the scaling decision %q about cluster %q: %v", decision, p.clusterID, err)
+	}
+}
@@ -3,14 +3,61 @@ package cluster_mgrs
 import (
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	apiErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
 	"github.com/onsi/gomega"
 )
 
 func Test_standardDynamicScaleDownProcessor_ShouldScaleDown(t *testing.T) {
+	readyStatus := constants.KafkaRequestStatusReady.String()
+	manuallyCordonedAt := time.Now().Add(-2 * time.Hour)
+	drainPolicy := &config.ScaleDownDrainPolicy{
+		MigrateRemainingKafkas:      true,
+		MigratableInstanceTypes:     []string{"developer"},
+		MaxMigratableStreamingUnits: 1,
+	}
+	drainedInstanceTypesConfig := &config.SupportedKafkaInstanceTypesConfig{
+		SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+			{
+				Id:    "developer",
+				Sizes: []config.KafkaInstanceSize{{Id: "x1", CapacityConsumed: 1}},
+			},
+			{
+				Id:    "standard",
+				Sizes: []config.KafkaInstanceSize{{Id: "x1", CapacityConsumed: 1}, {Id: "x2", CapacityConsumed: 2}},
+			},
+		},
+	}
+
+	// drainedStreamingUnitCount returns the streaming units consumed by the developer kafkas of a ready cluster with
+	// a capacity of 5 streaming units
+	drainedStreamingUnitCount := func(clusterID string, count int32) services.KafkaStreamingUnitCountPerCluster {
+		return services.KafkaStreamingUnitCountPerCluster{
+			Status:        api.ClusterReady.String(),
+			ClusterType:   api.ManagedDataPlaneClusterType.String(),
+			Region:        "region",
+			CloudProvider: "cp",
+			ClusterId:     clusterID,
+			InstanceType:  "developer",
+			MaxUnits:      5,
+			Count:         count,
+		}
+	}
+	drainedKafkaService := &services.KafkaServiceMock{
+		ListKafkasByClusterIDFunc: func(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+			return []*dbapi.KafkaRequest{
+				{ClusterID: "cluster-1", InstanceType: "developer", SizeId: "x1", Status: readyStatus},
+				{ClusterID: "cluster-1", InstanceType: "developer", SizeId: "x1", Status: readyStatus},
+			}, nil
+		},
+	}
+
 	type fields struct {
 		standardDynamicScaleDownProcessor *standardDynamicScaleDownProcessor
 	}
@@ -379,13 +426,261 @@ func Test_standardDynamicScaleDownProcessor_ShouldScaleDown(t *testing.T) {
 			wantErr: false,
 			want:    true,
 		},
+		{
+			name: "should scale down a non empty cluster when the drain policy allows migrating all its kafkas",
+			fields: fields{
+				standardDynamicScaleDownProcessor: &standardDynamicScaleDownProcessor{
+					clusterID:                         "cluster-1",
+					regionsSupportedInstanceType:      config.InstanceTypeMap{},
+					supportedKafkaInstanceTypesConfig: drainedInstanceTypesConfig,
+					kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{
+						services.KafkaStreamingUnitCountPerCluster{
+							Status:    api.ClusterReady.String(),
+							ClusterId: "cluster-1",
+							Count:     2,
+						},
+					},
+					indexesOfStreamingUnitForSameClusterID: []int{0},
+					kafkaService: &services.KafkaServiceMock{
+						ListKafkasByClusterIDFunc: func(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+							return []*dbapi.KafkaRequest{
+								{ClusterID: "cluster-1", InstanceType: "developer", SizeId: "x1", Status: readyStatus},
+								{ClusterID: "cluster-1", InstanceType: "standard", SizeId: "x1", Status: readyStatus},
+								{ClusterID: "cluster-1", InstanceType: "standard", SizeId: "x2", Status: readyStatus, MigrationTargetClusterId: "cluster-2"}, // being migrated off the cluster
+								{ClusterID: "cluster-2", InstanceType: "standard", SizeId: "x2"},                                                             // already migrated off the cluster
+							}, nil
+						},
+					},
+					drainPolicy: drainPolicy,
+				},
+			},
+			wantErr: false,
+			want:    true,
+		},
+		{
+			name: "should scale down a non empty cluster when its kafkas fit in the sibling clusters without triggering a scale up",
+			fields: fields{
+				standardDynamicScaleDownProcessor: &standardDynamicScaleDownProcessor{
+					clusterID: "cluster-1",
+					regionsSupportedInstanceType: config.InstanceTypeMap{
+						"developer": config.InstanceTypeConfig{MinAvailableCapacitySlackStreamingUnits: 1},
+					},
+					supportedKafkaInstanceTypesConfig: drainedInstanceTypesConfig,
+					kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{
+						drainedStreamingUnitCount("cluster-1", 2),
+						drainedStreamingUnitCount("cluster-2", 1),
+					},
+					indexesOfStreamingUnitForSameClusterID: []int{0},
+					kafkaService:                           drainedKafkaService,
+					drainPolicy:                            drainPolicy,
+				},
+			},
+			wantErr: false,
+			want:    true,
+		},
+		{
+			name: "should not scale down a non empty cluster when its kafkas do not fit in the sibling clusters",
+			fields: fields{
+				standardDynamicScaleDownProcessor: &standardDynamicScaleDownProcessor{
+					clusterID: "cluster-1",
+					regionsSupportedInstanceType: config.InstanceTypeMap{
+						"developer": config.InstanceTypeConfig{MinAvailableCapacitySlackStreamingUnits: 0},
+					},
+					supportedKafkaInstanceTypesConfig: drainedInstanceTypesConfig,
+					kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{
+						drainedStreamingUnitCount("cluster-1", 2),
+						drainedStreamingUnitCount("cluster-2", 3),
+					},
+					indexesOfStreamingUnitForSameClusterID: []int{0},
+					kafkaService:                           drainedKafkaService,
+					drainPolicy:                            drainPolicy,
+				},
+			},
+			wantErr: false,
+			want:    false,
+		},
+		{
+			name: "should not scale down a non empty cluster when migrating its kafkas to the sibling clusters would trigger a scale up",
+			fields: fields{
+				standardDynamicScaleDownProcessor: &standardDynamicScaleDownProcessor{
+					clusterID: "cluster-1",
+					regionsSupportedInstanceType: config.InstanceTypeMap{
+						"developer": config.InstanceTypeConfig{MinAvailableCapacitySlackStreamingUnits: 3},
+					},
+					supportedKafkaInstanceTypesConfig: drainedInstanceTypesConfig,
+					kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{
+						drainedStreamingUnitCount("cluster-1", 2),
+						drainedStreamingUnitCount("cluster-2", 1),
+					},
+					indexesOfStreamingUnitForSameClusterID: []int{0},
+					kafkaService:                           drainedKafkaService,
+					drainPolicy:                            drainPolicy,
+				},
+			},
+			wantErr: false,
+			want:    false,
+		},
+		{
+			name: "should not scale down a non empty cluster when one of its kafkas cannot be migrated",
+			fields: fields{
+				standardDynamicScaleDownProcessor: &standardDynamicScaleDownProcessor{
+					clusterID:                         "cluster-1",
+					regionsSupportedInstanceType:      config.InstanceTypeMap{},
+					supportedKafkaInstanceTypesConfig: drainedInstanceTypesConfig,
+					kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{
+						services.KafkaStreamingUnitCountPerCluster{
+							Status:    api.ClusterReady.String(),
+							ClusterId: "cluster-1",
+							Count:     2,
+						},
+					},
+					indexesOfStreamingUnitForSameClusterID: []int{0},
+					kafkaService: &services.KafkaServiceMock{
+						ListKafkasByClusterIDFunc: func(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+							return []*dbapi.KafkaRequest{
+								{ClusterID: "cluster-1", InstanceType: "standard", SizeId: "x2", Status: readyStatus},
+							}, nil
+						},
+					},
+					drainPolicy: drainPolicy,
+				},
+			},
+			wantErr: false,
+			want:    false,
+		},
+		{
+			name: "should not scale down a non empty cluster when one of its kafkas is not ready",
+			fields: fields{
+				standardDynamicScaleDownProcessor: &standardDynamicScaleDownProcessor{
+					clusterID:                         "cluster-1",
+					regionsSupportedInstanceType:      config.InstanceTypeMap{},
+					supportedKafkaInstanceTypesConfig: drainedInstanceTypesConfig,
+					kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{
+						services.KafkaStreamingUnitCountPerCluster{
+							Status:    api.ClusterReady.String(),
+							ClusterId: "cluster-1",
+							Count:     1,
+						},
+					},
+					indexesOfStreamingUnitForSameClusterID: []int{0},
+					kafkaService: &services.KafkaServiceMock{
+						ListKafkasByClusterIDFunc: func(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+							return []*dbapi.KafkaRequest{
+								{ClusterID: "cluster-1", InstanceType: "standard", SizeId: "x1", Status: constants.KafkaRequestStatusSuspended.String()},
+							}, nil
+						},
+					},
+					drainPolicy: drainPolicy,
+				},
+			},
+			wantErr: false,
+			want:    false,
+		},
+		{
+			name: "should not scale down a non empty cluster when one of its kafkas is being promoted",
+			fields: fields{
+				standardDynamicScaleDownProcessor: &standardDynamicScaleDownProcessor{
+					clusterID:                         "cluster-1",
+					regionsSupportedInstanceType:      config.InstanceTypeMap{},
+					supportedKafkaInstanceTypesConfig: drainedInstanceTypesConfig,
+					kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{
+						services.KafkaStreamingUnitCountPerCluster{
+							Status:    api.ClusterReady.String(),
+							ClusterId: "cluster-1",
+							Count:     1,
+						},
+					},
+					indexesOfStreamingUnitForSameClusterID: []int{0},
+					kafkaService: &services.KafkaServiceMock{
+						ListKafkasByClusterIDFunc: func(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+							return []*dbapi.KafkaRequest{
+								{ClusterID: "cluster-1", InstanceType: "standard", SizeId: "x1", Status: readyStatus, PromotionStatus: dbapi.KafkaPromotionStatusPromoting},
+							}, nil
+						},
+					},
+					drainPolicy: drainPolicy,
+				},
+			},
+			wantErr: false,
+			want:    false,
+		},
+		{
+			name: "should not scale down a non empty cluster when one of its kafkas is being resized",
+			fields: fields{
+				standardDynamicScaleDownProcessor: &standardDynamicScaleDownProcessor{
+					clusterID:                         "cluster-1",
+					regionsSupportedInstanceType:      config.InstanceTypeMap{},
+					supportedKafkaInstanceTypesConfig: drainedInstanceTypesConfig,
+					kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{
+						services.KafkaStreamingUnitCountPerCluster{
+							Status:    api.ClusterReady.String(),
+							ClusterId: "cluster-1",
+							Count:     1,
+						},
+					},
+					indexesOfStreamingUnitForSameClusterID: []int{0},
+					kafkaService: &services.KafkaServiceMock{
+						ListKafkasByClusterIDFunc: func(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+							return []*dbapi.KafkaRequest{
+								{ClusterID: "cluster-1", InstanceType: "standard", SizeId: "x1", Status: readyStatus, ResizeStatus: dbapi.KafkaResizeStatusResizing},
+							}, nil
+						},
+					},
+					drainPolicy: drainPolicy,
+				},
+			},
+			wantErr: false,
+			want:    false,
+		},
+		{
+			name: "should return an error when listing the kafkas of a non empty cluster returns an error",
+			fields: fields{
+				standardDynamicScaleDownProcessor: &standardDynamicScaleDownProcessor{
+					clusterID: "cluster-1",
+					kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{
+						services.KafkaStreamingUnitCountPerCluster{
+							Status:    api.ClusterReady.String(),
+							ClusterId: "cluster-1",
+							Count:     2,
+						},
+					},
+					indexesOfStreamingUnitForSameClusterID: []int{0},
+					kafkaService: &services.KafkaServiceMock{
+						ListKafkasByClusterIDFunc: func(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+							return nil, apiErrors.GeneralError("some error")
+						},
+					},
+					drainPolicy: drainPolicy,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should not scale down a cluster cordoned by an administrator even if it could be removed",
+			fields: fields{
+				standardDynamicScaleDownProcessor: &standardDynamicScaleDownProcessor{
+					regionsSupportedInstanceType: config.InstanceTypeMap{}, // an empty supported instance type
+					kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{
+						services.KafkaStreamingUnitCountPerCluster{
+							Status:           api.ClusterReady.String(),
+							ClusterId:        "cluster-1",
+							CordonedAt:       &manuallyCordonedAt,
+							ManuallyCordoned: true,
+						},
+					},
+					indexesOfStreamingUnitForSameClusterID: []int{0},
+				},
+			},
+			wantErr: false,
+			want:    false,
+		},
 	}
 
 	for _, testcase := range tests {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			shouldScaleDown, err := tt.fields.standardDynamicScaleDownProcessor.ShouldScaleDown()
+			shouldScaleDown, _, err := tt.fields.standardDynamicScaleDownProcessor.ShouldScaleDown()
 			if tt.wantErr {
 				g.Expect(err).To(gomega.HaveOccurred())
 				g.Expect(shouldScaleDown).To(gomega.BeFalse())
@@ -398,15 +693,28 @@ func Test_standardDynamicScaleDownProcessor_ShouldScaleDown(t *testing.T) {
 }
 
 func Test_standardDynamicScaleDownProcessor_ScaleDown(t *testing.T) {
+	readyStatus := constants.KafkaRequestStatusReady.String()
+	cordonedAt := time.Now().Add(-2 * time.Hour)
+	recentlyCordonedAt := time.Now().Add(-10 * time.Minute)
+	drainPolicy := &config.ScaleDownDrainPolicy{
+		CordonGracePeriod:           time.Hour,
+		MigrateRemainingKafkas:      true,
+		MigratableInstanceTypes:     []string{"developer"},
+		MaxMigratableStreamingUnits: 1,
+	}
+
 	type fields struct {
 		standardDynamicScaleDownProcessor *standardDynamicScaleDownProcessor
 	}
 
 	tests := []struct {
-		name    string
-		fields  fields
-		wantErr bool
-		want    services.KafkaStreamingUnitCountPerClusterList
+		name                      string
+		fields                    fields
+		wantErr                   bool
+		want                      services.KafkaStreamingUnitCountPerClusterList
+		wantDecisions             []dbapi.ClusterScalingDecisionType
+		wantUpdateStatusCallCount int
+		wantStartMigrationCount   int
 	}{
 		{
 			name: "When dryRun is true, no error should be returned and clusterService should never be called",
@@ -420,13 +728,197 @@ func Test_standardDynamicScaleDownProcessor_ScaleDown(t *testing.T) {
 					dryRun: true,
 				},
 			},
+			wantErr:       false,
+			wantDecisions: []dbapi.ClusterScalingDecisionType{dbapi.ClusterScalingDecisionWouldCordon},
+		},
+		{
+			name: "Should cordon the cluster and wait for the grace period to elapse",
+			fields: fields{
+				standardDynamicScaleDownProcessor: &standardDynamicScaleDownProcessor{
+					clusterID: "some-cluster-id",
+					kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{
+						services.KafkaStreamingUnitCountPerCluster{
+							Status: api.ClusterReady.String(),
+						},
+						services.KafkaStreamingUnitCountPerCluster{
+							Status: api.ClusterReady.String(),
+						},
+					},
+					indexesOfStreamingUnitForSameClusterID: []int{1},
+					clusterService: &services.ClusterServiceMock{
+						UpdateCordonedAtFunc: func(clusterID string, cordonedAt *time.Time) *apiErrors.ServiceError {
+							return nil
+						},
+					},
+					drainPolicy: drainPolicy,
+				},
+			},
+			wantErr:       false,
+			wantDecisions: []dbapi.ClusterScalingDecisionType{dbapi.ClusterScalingDecisionCordoned, dbapi.ClusterScalingDecisionWaitingForGracePeriod},
+		},
+		{
+			name: "Should expect an error to be returned when cordoning the cluster returns an error",
+			fields: fields{
+				standardDynamicScaleDownProcessor: &standardDynamicScaleDownProcessor{
+					kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{},
+					clusterService: &services.ClusterServiceMock{
+						UpdateCordonedAtFunc: func(clusterID string, cordonedAt *time.Time) *apiErrors.ServiceError {
+							return apiErrors.GeneralError("some error")
+						},
+					},
+					drainPolicy: drainPolicy,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should keep waiting while the grace period has not elapsed",
+			fields: fields{
+				standardDynamicScaleDownProcessor: &standardDynamicScaleDownProcessor{
+					kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{
+						services.KafkaStreamingUnitCountPerCluster{
+							Status:     api.ClusterReady.String(),
+							CordonedAt: &recentlyCordonedAt,
+						},
+					},
+					indexesOfStreamingUnitForSameClusterID: []int{0},
+					clusterService:                         &services.ClusterServiceMock{},
+					drainPolicy:                            drainPolicy,
+				},
+			},
 			wantErr: false,
+			want: services.KafkaStreamingUnitCountPerClusterList{
+				services.KafkaStreamingUnitCountPerCluster{
+					Status:     api.ClusterReady.String(),
+					CordonedAt: &recentlyCordonedAt,
+				},
+			},
+			wantDecisions: []dbapi.ClusterScalingDecisionType{dbapi.ClusterScalingDecisionWaitingForGracePeriod},
+		},
+		{
+			name: "Should migrate the kafkas left on the cluster once the grace period elapsed",
+			fields: fields{
+				standardDynamicScaleDownProcessor: &standardDynamicScaleDownProcessor{
+					clusterID: "some-cluster-id",
+					kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{
+						services.KafkaStreamingUnitCountPerCluster{
+							Status:     api.ClusterReady.String(),
+							CordonedAt: &cordonedAt,
+							Count:      1,
+						},
+					},
+					indexesOfStreamingUnitForSameClusterID: []int{0},
+					clusterService:                         &services.ClusterServiceMock{},
+					kafkaService: &services.KafkaServiceMock{
+						ListKafkasByClusterIDFunc: func(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+							return []*dbapi.KafkaRequest{
+								{ClusterID: "some-cluster-id", Status: readyStatus},
+								{ClusterID: "some-cluster-id", Status: readyStatus, MigrationStatus: dbapi.KafkaMigrationStatusProvisioning, MigrationTargetClusterId: "some-other-cluster-id"},
+								{ClusterID: "some-other-cluster-id", Status: readyStatus, MigrationStatus: dbapi.KafkaMigrationStatusDeprovisioning, MigrationSourceClusterId: "some-cluster-id"},
+								{ClusterID: "some-cluster-id", Status: readyStatus, ResizeStatus: dbapi.KafkaResizeStatusResizing},
+							}, nil
+						},
+					},
+					kafkaMigrationService: &services.KafkaMigrationServiceMock{
+						StartMigrationFunc: func(kafka *dbapi.KafkaRequest, targetClusterID string) *apiErrors.ServiceError {
+							return nil
+						},
+					},
+					drainPolicy: drainPolicy,
+				},
+			},
+			wantErr: false,
+			want: services.KafkaStreamingUnitCountPerClusterList{
+				services.KafkaStreamingUnitCountPerCluster{
+					Status:     api.ClusterReady.String(),
+					CordonedAt: &cordonedAt,
+					Count:      1,
+				},
+			},
+			wantDecisions:           []dbapi.ClusterScalingDecisionType{dbapi.ClusterScalingDecisionMigratingKafkas},
+			wantStartMigrationCount: 1,
+		},
+		{
+			name: "Should expect an error to be returned when migrating a kafka off the cluster returns an error",
+			fields: fields{
+				standardDynamicScaleDownProcessor: &standardDynamicScaleDownProcessor{
+					clusterID: "some-cluster-id",
+					kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{
+						services.KafkaStreamingUnitCountPerCluster{
+							Status:     api.ClusterReady.String(),
+							CordonedAt: &cordonedAt,
+							Count:      1,
+						},
+					},
+					indexesOfStreamingUnitForSameClusterID: []int{0},
+					clusterService:                         &services.ClusterServiceMock{},
+					kafkaService: &services.KafkaServiceMock{
+						ListKafkasByClusterIDFunc: func(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+							return []*dbapi.KafkaRequest{{ClusterID: "some-cluster-id", Status: readyStatus}}, nil
+						},
+					},
+					kafkaMigrationService: &services.KafkaMigrationServiceMock{
+						StartMigrationFunc: func(kafka *dbapi.KafkaRequest, targetClusterID string) *apiErrors.ServiceError {
+							return apiErrors.GeneralError("some error")
+						},
+					},
+					drainPolicy: drainPolicy,
+				},
+			},
+			wantErr:                 true,
+			wantDecisions:           []dbapi.ClusterScalingDecisionType{dbapi.ClusterScalingDecisionMigratingKafkas},
+			wantStartMigrationCount: 1,
+		},
+		{
+			name: "Should retry the failed migrations of the kafkas left on the cluster",
+			fields: fields{
+				standardDynamicScaleDownProcessor: &standardDynamicScaleDownProcessor{
+					clusterID: "some-cluster-id",
+					kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{
+						services.KafkaStreamingUnitCountPerCluster{
+							Status:     api.ClusterReady.String(),
+							CordonedAt: &cordonedAt,
+							Count:      2,
+						},
+					},
+					indexesOfStreamingUnitForSameClusterID: []int{0},
+					clusterService:                         &services.ClusterServiceMock{},
+					kafkaService: &services.KafkaServiceMock{
+						ListKafkasByClusterIDFunc: func(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+							return []*dbapi.KafkaRequest{
+								// deprovisioned from the target cluster of its failed migration
+								{ClusterID: "some-cluster-id", Status: readyStatus, MigrationStatus: dbapi.KafkaMigrationStatusFailed, MigrationDetails: "rejected by target cluster"},
+								// still being deprovisioned from the target cluster of its failed migration
+								{ClusterID: "some-cluster-id", Status: readyStatus, MigrationStatus: dbapi.KafkaMigrationStatusFailed, MigrationTargetClusterId: "some-other-cluster-id"},
+							}, nil
+						},
+					},
+					kafkaMigrationService: &services.KafkaMigrationServiceMock{
+						StartMigrationFunc: func(kafka *dbapi.KafkaRequest, targetClusterID string) *apiErrors.ServiceError {
+							return nil
+						},
+					},
+					drainPolicy: drainPolicy,
+				},
+			},
+			wantErr: false,
+			wantDecisions: []dbapi.ClusterScalingDecisionType{
+				dbapi.ClusterScalingDecisionMigratingKafkas,
+				dbapi.ClusterScalingDecisionMigratingKafkas,
+			},
+			wantStartMigrationCount: 1,
 		},
 		{
 			name: "Should expect an error to be returned when cluster service status update returns an error",
 			fields: fields{
 				standardDynamicScaleDownProcessor: &standardDynamicScaleDownProcessor{
-					kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{},
+					kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{
+						services.KafkaStreamingUnitCountPerCluster{
+							Status:     api.ClusterReady.String(),
+							CordonedAt: &cordonedAt,
+						},
+					},
+					indexesOfStreamingUnitForSameClusterID: []int{0},
 					clusterService: &services.ClusterServiceMock{
 						UpdateStatusFunc: func(cluster api.Cluster, status api.ClusterStatus) error {
 							return errors.New("some error")
@@ -435,7 +927,43 @@ func Test_standardDynamicScaleDownProcessor_ScaleDown(t *testing.T) {
 					dryRun: false,
 				},
 			},
-			wantErr: true,
+			wantErr:                   true,
+			wantUpdateStatusCallCount: 1,
+		},
+		{
+			name: "Should successful set the status of the cluster to deprovisioning once all its kafkas left it",
+			fields: fields{
+				standardDynamicScaleDownProcessor: &standardDynamicScaleDownProcessor{
+					clusterID: "some-cluster-id",
+					kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{
+						services.KafkaStreamingUnitCountPerCluster{
+							Status:     api.ClusterReady.String(),
+							CordonedAt: &cordonedAt,
+						},
+					},
+					indexesOfStreamingUnitForSameClusterID: []int{0},
+					clusterService: &services.ClusterServiceMock{
+						UpdateStatusFunc: func(cluster api.Cluster, status api.ClusterStatus) error {
+							return nil
+						},
+					},
+					kafkaService: &services.KafkaServiceMock{
+						ListKafkasByClusterIDFunc: func(clusterID string) ([]*dbapi.KafkaRequest, *apiErrors.ServiceError) {
+							return nil, nil
+						},
+					},
+					drainPolicy: drainPolicy,
+				},
+			},
+			wantErr: false,
+			want: services.KafkaStreamingUnitCountPerClusterList{
+				services.KafkaStreamingUnitCountPerCluster{
+					Status:     api.ClusterDeprovisioning.String(),
+					CordonedAt: &cordonedAt,
+				},
+			},
+			wantDecisions:             []dbapi.ClusterScalingDecisionType{dbapi.ClusterScalingDecisionDeprovisioned},
+			wantUpdateStatusCallCount: 1,
 		},
 		{
 			name: "Should successful set the status of the cluster to deprovisioning",
@@ -447,13 +975,15 @@ func Test_standardDynamicScaleDownProcessor_ScaleDown(t *testing.T) {
 							Status: api.ClusterReady.String(),
 						},
 						services.KafkaStreamingUnitCountPerCluster{
-							Status: api.ClusterReady.String(),
+							Status:     api.ClusterReady.String(),
+							CordonedAt: &cordonedAt,
 						},
 						services.KafkaStreamingUnitCountPerCluster{
 							Status: api.ClusterReady.String(),
 						},
 						services.KafkaStreamingUnitCountPerCluster{
-							Status: api.ClusterReady.String(),
+							Status:     api.ClusterReady.String(),
+							CordonedAt: &cordonedAt,
 						},
 					},
 					indexesOfStreamingUnitForSameClusterID: []int{1, 3}, // the second and the fourth streaming unit in kafkaStreamingUnitCountPerClusterList should see their status changing to deprovisioning
@@ -471,15 +1001,19 @@ func Test_standardDynamicScaleDownProcessor_ScaleDown(t *testing.T) {
 					Status: api.ClusterReady.String(),
 				},
 				services.KafkaStreamingUnitCountPerCluster{
-					Status: api.ClusterDeprovisioning.String(), // status changed to deprovisioning
+					Status:     api.ClusterDeprovisioning.String(), // status changed to deprovisioning
+					CordonedAt: &cordonedAt,
 				},
 				services.KafkaStreamingUnitCountPerCluster{
 					Status: api.ClusterReady.String(),
 				},
 				services.KafkaStreamingUnitCountPerCluster{
-					Status: api.ClusterDeprovisioning.String(), // status changed to deprovisioning
+					Status:     api.ClusterDeprovisioning.String(), // status changed to deprovisioning
+					CordonedAt: &cordonedAt,
 				},
 			},
+			wantDecisions:             []dbapi.ClusterScalingDecisionType{dbapi.ClusterScalingDecisionDeprovisioned},
+			wantUpdateStatusCallCount: 1,
 		},
 	}
 
@@ -487,26 +1021,150 @@ func Test_standardDynamicScaleDownProcessor_ScaleDown(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			err := tt.fields.standardDynamicScaleDownProcessor.ScaleDown()
-			if tt.wantErr {
-				g.Expect(err).To(gomega.HaveOccurred())
-			} else {
-				g.Expect(err).ToNot(gomega.HaveOccurred())
+			scalingDecisions := &services.ClusterScalingDecisionServiceMock{
+				RecordFunc: func(decision *dbapi.ClusterScalingDecision) *apiErrors.ServiceError {
+					return nil
+				},
+			}
+			tt.fields.standardDynamicScaleDownProcessor.scalingDecisions = scalingDecisions
+			err := tt.fields.standardDynamicScaleDownProcessor.ScaleDown("the cluster can be removed without triggering a scale up")
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if tt.want != nil {
 				g.Expect(tt.fields.standardDynamicScaleDownProcessor.kafkaStreamingUnitCountPerClusterList).To(gomega.Equal(tt.want))
-				clusterServiceMock, ok := tt.fields.standardDynamicScaleDownProcessor.clusterService.(*services.ClusterServiceMock)
-				g.Expect(ok).To(gomega.BeTrue())
-				updateStatusCalls := clusterServiceMock.UpdateStatusCalls()
-				updateStatusCallsCount := len(updateStatusCalls)
-				g.Expect(updateStatusCallsCount > 0).To(gomega.Equal(!tt.fields.standardDynamicScaleDownProcessor.dryRun)) // should only be called if not dryRun
-				if updateStatusCallsCount > 0 {
-					g.Expect(updateStatusCallsCount == 1).To(gomega.BeTrue()) // check that it update status is only called once
-					// now check that the arguments matches what we expect
-					g.Expect(updateStatusCalls[0].Status).To(gomega.Equal(api.ClusterDeprovisioning))
-					g.Expect(updateStatusCalls[0].Cluster).To(gomega.Equal(api.Cluster{
-						ClusterID: tt.fields.standardDynamicScaleDownProcessor.clusterID,
-					}))
+			}
+
+			var decisions []dbapi.ClusterScalingDecisionType
+			for _, call := range scalingDecisions.RecordCalls() {
+				g.Expect(call.Decision.DryRun).To(gomega.Equal(tt.fields.standardDynamicScaleDownProcessor.dryRun))
+				decisions = append(decisions, call.Decision.Decision)
+			}
+			g.Expect(decisions).To(gomega.Equal(tt.wantDecisions))
+
+			clusterServiceMock, ok := tt.fields.standardDynamicScaleDownProcessor.clusterService.(*services.ClusterServiceMock)
+			g.Expect(ok).To(gomega.BeTrue())
+			updateStatusCalls := clusterServiceMock.UpdateStatusCalls()
+			g.Expect(updateStatusCalls).To(gomega.HaveLen(tt.wantUpdateStatusCallCount))
+			if len(updateStatusCalls) > 0 {
+				// now check that the arguments matches what we expect
+				g.Expect(updateStatusCalls[0].Status).To(gomega.Equal(api.ClusterDeprovisioning))
+				g.Expect(updateStatusCalls[0].Cluster).To(gomega.Equal(api.Cluster{
+					ClusterID: tt.fields.standardDynamicScaleDownProcessor.clusterID,
+				}))
+			}
+
+			for _, call := range clusterServiceMock.UpdateCordonedAtCalls() {
+				g.Expect(call.CordonedAt).ToNot(gomega.BeNil())
+				for _, i := range tt.fields.standardDynamicScaleDownProcessor.indexesOfStreamingUnitForSameClusterID {
+					g.Expect(tt.fields.standardDynamicScaleDownProcessor.kafkaStreamingUnitCountPerClusterList[i].CordonedAt).To(gomega.Equal(call.CordonedAt))
 				}
 			}
+
+			if kafkaMigrationServiceMock, ok := tt.fields.standardDynamicScaleDownProcessor.kafkaMigrationService.(*services.KafkaMigrationServiceMock); ok {
+				g.Expect(kafkaMigrationServiceMock.StartMigrationCalls()).To(gomega.HaveLen(tt.wantStartMigrationCount))
+			}
+		})
+	}
+}
+
+func Test_standardDynamicScaleDownProcessor_KeepCluster(t *testing.T) {
+	cordonedAt := time.Now().Add(-2 * time.Hour)
+
+	tests := []struct {
+		name          string
+		processor     *standardDynamicScaleDownProcessor
+		wantErr       bool
+		wantDecisions []dbapi.ClusterScalingDecisionType
+		wantCordoned  bool
+	}{
+		{
+			name: "should only record the decision when the cluster is not cordoned",
+			processor: &standardDynamicScaleDownProcessor{
+				kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{
+					services.KafkaStreamingUnitCountPerCluster{Status: api.ClusterReady.String()},
+				},
+				indexesOfStreamingUnitForSameClusterID: []int{0},
+				clusterService:                         &services.ClusterServiceMock{},
+			},
+			wantDecisions: []dbapi.ClusterScalingDecisionType{dbapi.ClusterScalingDecisionKept},
+		},
+		{
+			name: "should not uncordon the cluster when dryRun is true",
+			processor: &standardDynamicScaleDownProcessor{
+				kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{
+					services.KafkaStreamingUnitCountPerCluster{Status: api.ClusterReady.String(), CordonedAt: &cordonedAt},
+				},
+				indexesOfStreamingUnitForSameClusterID: []int{0},
+				clusterService:                         &services.ClusterServiceMock{},
+				dryRun:                                 true,
+			},
+			wantDecisions: []dbapi.ClusterScalingDecisionType{dbapi.ClusterScalingDecisionKept},
+			wantCordoned:  true,
+		},
+		{
+			name: "should not uncordon a cluster cordoned by an administrator",
+			processor: &standardDynamicScaleDownProcessor{
+				kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{
+					services.KafkaStreamingUnitCountPerCluster{Status: api.ClusterReady.String(), CordonedAt: &cordonedAt, ManuallyCordoned: true},
+				},
+				indexesOfStreamingUnitForSameClusterID: []int{0},
+				clusterService:                         &services.ClusterServiceMock{},
+			},
+			wantDecisions: []dbapi.ClusterScalingDecisionType{dbapi.ClusterScalingDecisionKept},
+			wantCordoned:  true,
+		},
+		{
+			name: "should uncordon a cordoned cluster",
+			processor: &standardDynamicScaleDownProcessor{
+				kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{
+					services.KafkaStreamingUnitCountPerCluster{Status: api.ClusterReady.String(), CordonedAt: &cordonedAt},
+				},
+				indexesOfStreamingUnitForSameClusterID: []int{0},
+				clusterService: &services.ClusterServiceMock{
+					UpdateCordonedAtFunc: func(clusterID string, cordonedAt *time.Time) *apiErrors.ServiceError {
+						return nil
+					},
+				},
+			},
+			wantDecisions: []dbapi.ClusterScalingDecisionType{dbapi.ClusterScalingDecisionUncordoned},
+		},
+		{
+			name: "should return an error when uncordoning the cluster fails",
+			processor: &standardDynamicScaleDownProcessor{
+				kafkaStreamingUnitCountPerClusterList: services.KafkaStreamingUnitCountPerClusterList{
+					services.KafkaStreamingUnitCountPerCluster{Status: api.ClusterReady.String(), CordonedAt: &cordonedAt},
+				},
+				indexesOfStreamingUnitForSameClusterID: []int{0},
+				clusterService: &services.ClusterServiceMock{
+					UpdateCordonedAtFunc: func(clusterID string, cordonedAt *time.Time) *apiErrors.ServiceError {
+						return apiErrors.GeneralError("some error")
+					},
+				},
+			},
+			wantErr:      true,
+			wantCordoned: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			scalingDecisions := &services.ClusterScalingDecisionServiceMock{
+				RecordFunc: func(decision *dbapi.ClusterScalingDecision) *apiErrors.ServiceError {
+					return nil
+				},
+			}
+			tt.processor.scalingDecisions = scalingDecisions
+			err := tt.processor.KeepCluster("the cluster is not empty")
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+
+			var decisions []dbapi.ClusterScalingDecisionType
+			for _, call := range scalingDecisions.RecordCalls() {
+				g.Expect(call.Decision.Reason).To(gomega.Equal("the cluster is not empty"))
+				decisions = append(decisions, call.Decision.Decision)
+			}
+			g.Expect(decisions).To(gomega.Equal(tt.wantDecisions))
+			g.Expect(tt.processor.cordonedAt() != nil).To(gomega.Equal(tt.wantCordoned))
 		})
 	}
 }
@@ -624,6 +1282,9 @@ func Test_DynamicScaleDownManager_Reconcile(t *testing.T) {
 							},
 						}, nil
 					},
+					UpdateCordonedAtFunc: func(clusterID string, cordonedAt *time.Time) *apiErrors.ServiceError {
+						return nil
+					},
 					UpdateStatusFunc: func(cluster api.Cluster, status api.ClusterStatus) error {
 						return nil
 					},
@@ -642,6 +1303,47 @@ func Test_DynamicScaleDownManager_Reconcile(t *testing.T) {
 			wantErr:                   false,
 			wantUpdateStatusCallCount: 1,
 		},
+		{
+			name: "Should never call clusterService.UpdateStatus for a cluster cordoned by an administrator",
+			fields: fields{
+				dataplaneClusterConfig: &config.DataplaneClusterConfig{
+					DataPlaneClusterScalingType: config.AutoScaling,
+					DynamicScalingConfig: config.DynamicScalingConfig{
+						EnableDynamicScaleDownManagerScaleDownTrigger: true,
+					},
+				},
+				clusterService: &services.ClusterServiceMock{
+					FindStreamingUnitCountByClusterAndInstanceTypeFunc: func() (services.KafkaStreamingUnitCountPerClusterList, error) {
+						cordonedAt := time.Now().Add(-48 * time.Hour)
+						return services.KafkaStreamingUnitCountPerClusterList{
+							services.KafkaStreamingUnitCountPerCluster{
+								Count:            0,
+								ClusterType:      api.ManagedDataPlaneClusterType.String(),
+								ClusterId:        "2",
+								Status:           api.ClusterReady.String(),
+								CloudProvider:    "cp",
+								Region:           "r",
+								CordonedAt:       &cordonedAt,
+								ManuallyCordoned: true,
+							},
+						}, nil
+					},
+					UpdateCordonedAtFunc: nil, // should never be called
+					UpdateStatusFunc:     nil, // should never be called
+				},
+				kafkaConfig: &config.KafkaConfig{
+					SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
+						Configuration: config.SupportedKafkaInstanceTypesConfig{},
+					},
+				},
+				clusterProvidersConfig: &config.ProviderConfig{
+					ProvidersConfig: config.ProviderConfiguration{
+						SupportedProviders: config.ProviderList{},
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "Should not check for scale up evaluation after cluster removal when region limits are not provided",
 			fields: fields{
@@ -663,6 +1365,9 @@ func Test_DynamicScaleDownManager_Reconcile(t *testing.T) {
 							},
 						}, nil
 					},
+					UpdateCordonedAtFunc: func(clusterID string, cordonedAt *time.Time) *apiErrors.ServiceError {
+						return nil
+					},
 					UpdateStatusFunc: func(cluster api.Cluster, status api.ClusterStatus) error {
 						return nil
 					},
@@ -793,6 +1498,9 @@ func Test_DynamicScaleDownManager_Reconcile(t *testing.T) {
 							},
 						}, nil
 					},
+					UpdateCordonedAtFunc: func(clusterID string, cordonedAt *time.Time) *apiErrors.ServiceError {
+						return nil
+					},
 					UpdateStatusFunc: func(cluster api.Cluster, status api.ClusterStatus) error {
 						return nil
 					},
@@ -927,6 +1635,9 @@ func Test_DynamicScaleDownManager_Reconcile(t *testing.T) {
 							},
 						}, nil
 					},
+					UpdateCordonedAtFunc: func(clusterID string, cordonedAt *time.Time) *apiErrors.ServiceError {
+						return nil
+					},
 					UpdateStatusFunc: func(cluster api.Cluster, status api.ClusterStatus) error {
 						return nil
 					},
@@ -1024,6 +1735,9 @@ func Test_DynamicScaleDownManager_Reconcile(t *testing.T) {
 							},
 						}, nil
 					},
+					UpdateCordonedAtFunc: func(clusterID string, cordonedAt *time.Time) *apiErrors.ServiceError {
+						return nil
+					},
 					UpdateStatusFunc: func(cluster api.Cluster, status api.ClusterStatus) error {
 						return errors.New("some errors")
 					},
@@ -1089,6 +1803,9 @@ func Test_DynamicScaleDownManager_Reconcile(t *testing.T) {
 							},
 						}, nil
 					},
+					UpdateCordonedAtFunc: func(clusterID string, cordonedAt *time.Time) *apiErrors.ServiceError {
+						return nil
+					},
 					UpdateStatusFunc: func(cluster api.Cluster, status api.ClusterStatus) error {
 						return errors.New("some errors")
 					},
@@ -1133,6 +1850,9 @@ func Test_DynamicScaleDownManager_Reconcile(t *testing.T) {
 							},
 						}, nil
 					},
+					UpdateCordonedAtFunc: func(clusterID string, cordonedAt *time.Time) *apiErrors.ServiceError {
+						return nil
+					},
 					UpdateStatusFunc: func(cluster api.Cluster, status api.ClusterStatus) error {
 						return errors.New("some errors")
 					},
@@ -0,0 +1,179 @@
+package kafka_mgrs
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
+	serviceErr "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// CanaryServiceAccountRotationManager periodically rotates the credentials of the canaries of the ready kafkas.
+// A new service account is created and published in the ManagedKafka CR of the kafka in place of the current one, which
+// is kept in mas sso until kas-fleetshard reports the canary ready with the new service account. The previous one is
+// then deleted along with the service accounts left over by the rotations which did not complete, found through the
+// client secrets the kafka owns in the vault.
+// It also creates the canary service account of the kafkas which do not have one yet and moves the client secrets
+// still stored in the database to the vault.
+type CanaryServiceAccountRotationManager struct {
+	workers.BaseWorker
+	kafkaService                services.KafkaService
+	canaryServiceAccountService services.CanaryServiceAccountService
+	keycloakConfig              *keycloak.KeycloakConfig
+	kafkaConfig                 *config.KafkaConfig
+}
+
+var _ workers.Worker = &CanaryServiceAccountRotationManager{}
+
+func NewCanaryServiceAccountRotationManager(kafkaService services.KafkaService, canaryServiceAccountService services.CanaryServiceAccountService,
+	keycloakConfig *keycloak.KeycloakConfig, kafkaConfig *config.KafkaConfig, reconciler workers.Reconciler) *CanaryServiceAccountRotationManager {
+	return &CanaryServiceAccountRotationManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "canary_service_account_rotation",
+			Reconciler: reconciler,
+		},
+		kafkaService:                kafkaService,
+		canaryServiceAccountService: canaryServiceAccountService,
+		keycloakConfig:              keycloakConfig,
+		kafkaConfig:                 kafkaConfig,
+	}
+}
+
+func (k *CanaryServiceAccountRotationManager) Start() {
+	k.StartWorker(k)
+}
+
+func (k *CanaryServiceAccountRotationManager) Stop() {
+	k.StopWorker(k)
+}
+
+func (k *CanaryServiceAccountRotationManager) Reconcile() []error {
+	glog.Infoln("reconciling the canary service accounts of ready kafkas")
+	if !k.keycloakConfig.EnableAuthenticationOnKafka {
+		return nil
+	}
+
+	var errs []error
+
+	readyKafkas, listErr := k.kafkaService.ListByStatus(constants.KafkaRequestStatusReady)
+	if listErr != nil {
+		return []error{errors.Wrap(listErr, "failed to list ready kafkas")}
+	}
+
+	for _, kafka := range readyKafkas {
+		if err := k.reconcileCanaryServiceAccount(kafka); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to reconcile the canary service account of kafka %q", kafka.ID))
+		}
+	}
+
+	return errs
+}
+
+func (k *CanaryServiceAccountRotationManager) reconcileCanaryServiceAccount(kafka *dbapi.KafkaRequest) *serviceErr.ServiceError {
+	// the kafkas created before the canary was introduced do not have a canary service account
+	if kafka.CanaryServiceAccountClientID == "" {
+		clientId, err := k.canaryServiceAccountService.Create(kafka)
+		if err != nil {
+			return err
+		}
+		glog.Infof("created canary service account %q of kafka %q", clientId, kafka.ID)
+		return k.kafkaService.Updates(kafka, map[string]interface{}{
+			"canary_service_account_client_id":  clientId,
+			"canary_service_account_rotated_at": time.Now(),
+		})
+	}
+
+	if kafka.CanaryServiceAccountClientSecret != "" {
+		if err := k.migrateClientSecret(kafka); err != nil {
+			return err
+		}
+	}
+
+	if kafka.CanaryServiceAccountRotationInProgress() {
+		if !kafka.PendingCanaryServiceAccountReady {
+			glog.V(10).Infof("waiting for the canary of kafka %q to be ready with service account %q", kafka.ID, kafka.PendingCanaryServiceAccountClientID)
+			return nil
+		}
+		return k.completeRotation(kafka)
+	}
+
+	if k.isRotationDue(kafka) {
+		return k.startRotation(kafka)
+	}
+	return nil
+}
+
+// migrateClientSecret moves the client secret of the canary service account stored in the database to the vault
+func (k *CanaryServiceAccountRotationManager) migrateClientSecret(kafka *dbapi.KafkaRequest) *serviceErr.ServiceError {
+	if err := k.canaryServiceAccountService.StoreClientSecret(kafka, kafka.CanaryServiceAccountClientID, kafka.CanaryServiceAccountClientSecret.String()); err != nil {
+		return err
+	}
+	kafka.CanaryServiceAccountClientSecret = ""
+	glog.Infof("moved the client secret of canary service account %q of kafka %q to the vault", kafka.CanaryServiceAccountClientID, kafka.ID)
+	return k.kafkaService.Updates(kafka, map[string]interface{}{"canary_service_account_client_secret": ""})
+}
+
+func (k *CanaryServiceAccountRotationManager) isRotationDue(kafka *dbapi.KafkaRequest) bool {
+	if k.kafkaConfig.CanaryServiceAccountRotationInterval <= 0 {
+		return false
+	}
+	rotatedAt := kafka.CreatedAt
+	if kafka.CanaryServiceAccountRotatedAt != nil {
+		rotatedAt = *kafka.CanaryServiceAccountRotatedAt
+	}
+	return time.Since(rotatedAt) >= k.kafkaConfig.CanaryServiceAccountRotationInterval
+}
+
+// startRotation creates the service account the canary is rotated to. It is published in the ManagedKafka CR as soon
+// as it is stored as the pending canary service account of the kafka.
+func (k *CanaryServiceAccountRotationManager) startRotation(kafka *dbapi.KafkaRequest) *serviceErr.ServiceError {
+	clientId, err := k.canaryServiceAccountService.Create(kafka)
+	if err != nil {
+		return err
+	}
+	glog.Infof("rotating the canary service account of kafka %q from %q to %q", kafka.ID, kafka.CanaryServiceAccountClientID, clientId)
+	return k.kafkaService.Updates(kafka, map[string]interface{}{
+		"pending_canary_service_account_client_id": clientId,
+		"pending_canary_service_account_ready":     false,
+	})
+}
+
+// completeRotation deletes the canary service accounts other than the pending one once the canary is ready with it
+func (k *CanaryServiceAccountRotationManager) completeRotation(kafka *dbapi.KafkaRequest) *serviceErr.ServiceError {
+	clientIds, err := k.canaryServiceAccountService.ListClientIds(kafka)
+	if err != nil {
+		return err
+	}
+	// the previous service account is deleted even if its client secret is not in the vault
+	if !arrays.Contains(clientIds, kafka.CanaryServiceAccountClientID) {
+		clientIds = append(clientIds, kafka.CanaryServiceAccountClientID)
+	}
+	for _, clientId := range clientIds {
+		if clientId == kafka.PendingCanaryServiceAccountClientID {
+			continue
+		}
+		if err := k.canaryServiceAccountService.Delete(kafka, clientId); err != nil {
+			return err
+		}
+		if clientId != kafka.CanaryServiceAccountClientID {
+			glog.Infof("deleted canary service account %q of kafka %q left over by a previous rotation", clientId, kafka.ID)
+		}
+	}
+
+	glog.Infof("rotated the canary service account of kafka %q from %q to %q", kafka.ID, kafka.CanaryServiceAccountClientID, kafka.PendingCanaryServiceAccountClientID)
+	return k.kafkaService.Updates(kafka, map[string]interface{}{
+		"canary_service_account_client_id":         kafka.PendingCanaryServiceAccountClientID,
+		"pending_canary_service_account_client_id": "",
+		"pending_canary_service_account_ready":     false,
+		"canary_service_account_rotated_at":        time.Now(),
+	})
+}
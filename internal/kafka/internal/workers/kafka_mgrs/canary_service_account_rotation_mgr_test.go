@@ -0,0 +1,163 @@
+package kafka_mgrs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	w "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+
+	"github.com/onsi/gomega"
+)
+
+func TestCanaryServiceAccountRotationManager_Reconcile(t *testing.T) {
+	recently := time.Now().Add(-time.Hour)
+	longAgo := time.Now().Add(-31 * 24 * time.Hour)
+
+	tests := []struct {
+		name           string
+		keycloakConfig *keycloak.KeycloakConfig
+		kafka          *dbapi.KafkaRequest
+		listErr        *errors.ServiceError
+		// rotationInterval defaults to 30 days
+		rotationInterval time.Duration
+		// clientIds are the client ids of the canary service accounts whose client secret is in the vault
+		clientIds        []string
+		listClientIdsErr *errors.ServiceError
+		wantErr          bool
+		wantCreateCount  int
+		wantStored       []string
+		wantDeleted      []string
+		wantUpdates      []string
+	}{
+		{
+			name:           "should skip the reconciliation when the authentication is disabled on kafkas",
+			keycloakConfig: &keycloak.KeycloakConfig{},
+		},
+		{
+			name:    "should return an error when the ready kafkas cannot be listed",
+			listErr: errors.GeneralError("test"),
+			wantErr: true,
+		},
+		{
+			name:            "should create the canary service account of a kafka which does not have one",
+			kafka:           &dbapi.KafkaRequest{},
+			wantCreateCount: 1,
+			wantUpdates:     []string{"canary_service_account_client_id", "canary_service_account_rotated_at"},
+		},
+		{
+			name:        "should move the client secret stored in the database to the vault",
+			kafka:       &dbapi.KafkaRequest{CanaryServiceAccountClientID: "canary-kafka-id", CanaryServiceAccountClientSecret: "secret", CanaryServiceAccountRotatedAt: &recently},
+			wantStored:  []string{"canary-kafka-id"},
+			wantUpdates: []string{"canary_service_account_client_secret"},
+		},
+		{
+			name:  "should not rotate the credentials before the rotation interval has elapsed",
+			kafka: &dbapi.KafkaRequest{CanaryServiceAccountClientID: "canary-kafka-id", CanaryServiceAccountRotatedAt: &recently},
+		},
+		{
+			name:             "should not rotate the credentials when the rotation is disabled",
+			kafka:            &dbapi.KafkaRequest{Meta: api.Meta{CreatedAt: longAgo}, CanaryServiceAccountClientID: "canary-kafka-id"},
+			rotationInterval: -1,
+		},
+		{
+			name:            "should start the rotation of the credentials once the rotation interval has elapsed",
+			kafka:           &dbapi.KafkaRequest{Meta: api.Meta{CreatedAt: longAgo}, CanaryServiceAccountClientID: "canary-kafka-id"},
+			wantCreateCount: 1,
+			wantUpdates:     []string{"pending_canary_service_account_client_id", "pending_canary_service_account_ready"},
+		},
+		{
+			name: "should wait for the canary to be ready with the pending service account",
+			kafka: &dbapi.KafkaRequest{Meta: api.Meta{CreatedAt: longAgo}, CanaryServiceAccountClientID: "canary-kafka-id",
+				PendingCanaryServiceAccountClientID: "canary-kafka-id-1679616000"},
+		},
+		{
+			name: "should delete the previous service account once the canary is ready with the pending one",
+			kafka: &dbapi.KafkaRequest{Meta: api.Meta{CreatedAt: longAgo}, CanaryServiceAccountClientID: "canary-kafka-id",
+				PendingCanaryServiceAccountClientID: "canary-kafka-id-1679616000", PendingCanaryServiceAccountReady: true},
+			clientIds:   []string{"canary-kafka-id", "canary-kafka-id-1679616000"},
+			wantDeleted: []string{"canary-kafka-id"},
+			wantUpdates: []string{"canary_service_account_client_id", "pending_canary_service_account_client_id", "pending_canary_service_account_ready", "canary_service_account_rotated_at"},
+		},
+		{
+			name: "should delete the service accounts left over by the previous rotations once the rotation completes",
+			kafka: &dbapi.KafkaRequest{Meta: api.Meta{CreatedAt: longAgo}, CanaryServiceAccountClientID: "canary-kafka-id",
+				PendingCanaryServiceAccountClientID: "canary-kafka-id-1679616000", PendingCanaryServiceAccountReady: true},
+			clientIds:   []string{"canary-kafka-id-1679600000", "canary-kafka-id-1679616000"},
+			wantDeleted: []string{"canary-kafka-id-1679600000", "canary-kafka-id"},
+			wantUpdates: []string{"canary_service_account_client_id", "pending_canary_service_account_client_id", "pending_canary_service_account_ready", "canary_service_account_rotated_at"},
+		},
+		{
+			name: "should not complete the rotation when the service accounts of the kafka cannot be listed",
+			kafka: &dbapi.KafkaRequest{Meta: api.Meta{CreatedAt: longAgo}, CanaryServiceAccountClientID: "canary-kafka-id",
+				PendingCanaryServiceAccountClientID: "canary-kafka-id-1679616000", PendingCanaryServiceAccountReady: true},
+			listClientIdsErr: errors.GeneralError("test"),
+			wantErr:          true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			if tt.keycloakConfig == nil {
+				tt.keycloakConfig = &keycloak.KeycloakConfig{EnableAuthenticationOnKafka: true}
+			}
+			if tt.rotationInterval == 0 {
+				tt.rotationInterval = 30 * 24 * time.Hour
+			}
+			var updated []string
+			kafkaService := &services.KafkaServiceMock{
+				ListByStatusFunc: func(status ...constants.KafkaStatus) ([]*dbapi.KafkaRequest, *errors.ServiceError) {
+					if tt.kafka == nil {
+						return nil, tt.listErr
+					}
+					return []*dbapi.KafkaRequest{tt.kafka}, nil
+				},
+				UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *errors.ServiceError {
+					for column := range values {
+						updated = append(updated, column)
+					}
+					return nil
+				},
+			}
+			canaryServiceAccountService := &services.CanaryServiceAccountServiceMock{
+				CreateFunc: func(kafka *dbapi.KafkaRequest) (string, *errors.ServiceError) {
+					return "canary-kafka-id-1679616000", nil
+				},
+				StoreClientSecretFunc: func(kafka *dbapi.KafkaRequest, clientId string, clientSecret string) *errors.ServiceError {
+					return nil
+				},
+				DeleteFunc: func(kafka *dbapi.KafkaRequest, clientId string) *errors.ServiceError {
+					return nil
+				},
+				ListClientIdsFunc: func(kafka *dbapi.KafkaRequest) ([]string, *errors.ServiceError) {
+					return tt.clientIds, tt.listClientIdsErr
+				},
+			}
+
+			k := NewCanaryServiceAccountRotationManager(kafkaService, canaryServiceAccountService, tt.keycloakConfig,
+				&config.KafkaConfig{CanaryServiceAccountRotationInterval: tt.rotationInterval}, w.Reconciler{})
+			g.Expect(len(k.Reconcile()) > 0).To(gomega.Equal(tt.wantErr))
+
+			g.Expect(canaryServiceAccountService.CreateCalls()).To(gomega.HaveLen(tt.wantCreateCount))
+			var stored, deleted []string
+			for _, call := range canaryServiceAccountService.StoreClientSecretCalls() {
+				stored = append(stored, call.ClientId)
+			}
+			for _, call := range canaryServiceAccountService.DeleteCalls() {
+				deleted = append(deleted, call.ClientId)
+			}
+			g.Expect(stored).To(gomega.Equal(tt.wantStored))
+			g.Expect(deleted).To(gomega.Equal(tt.wantDeleted))
+			g.Expect(updated).To(gomega.ConsistOf(tt.wantUpdates))
+		})
+	}
+}
@@ -97,6 +97,12 @@ func (k *DeletingKafkaManager) reconcileDeletingKafkas(kafka *dbapi.KafkaRequest
 	if factoryErr != nil {
 		return factoryErr
 	}
+	// the quota reserved for a resize which did not complete is released along with the quota of the kafka
+	if kafka.ResizeSubscriptionId != "" && kafka.ResizeSubscriptionId != kafka.SubscriptionId {
+		if err := quotaService.DeleteQuota(kafka.ResizeSubscriptionId); err != nil {
+			return errors.Wrapf(err, "failed to delete resize subscription id %s for kafka %s", kafka.ResizeSubscriptionId, kafka.ID)
+		}
+	}
 	err := quotaService.DeleteQuota(kafka.SubscriptionId)
 	if err != nil {
 		return errors.Wrapf(err, "failed to delete subscription id %s for kafka %s", kafka.SubscriptionId, kafka.ID)
@@ -142,6 +142,8 @@ func TestDeletingKafkaManager_reconcileDeletingKafkas(t *testing.T) {
 		fields  fields
 		args    args
 		wantErr bool
+		// wantDeletedSubscriptions are checked only when set
+		wantDeletedSubscriptions []string
 	}{
 		{
 			name: "successful reconcile",
@@ -199,6 +201,45 @@ func TestDeletingKafkaManager_reconcileDeletingKafkas(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "should release the quota reserved for the resize in progress along with the quota of the kafka",
+			args: args{
+				kafka: &dbapi.KafkaRequest{SubscriptionId: "subscription-id", ResizeSubscriptionId: "resize-subscription-id"},
+			},
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					DeleteFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+						return nil
+					},
+				},
+				quotaService: &services.QuotaServiceMock{
+					DeleteQuotaFunc: func(id string) *errors.ServiceError {
+						return nil
+					},
+				},
+			},
+			wantDeletedSubscriptions: []string{"resize-subscription-id", "subscription-id"},
+		},
+		{
+			name: "should fail if deleting the quota reserved for the resize fails",
+			args: args{
+				kafka: &dbapi.KafkaRequest{SubscriptionId: "subscription-id", ResizeSubscriptionId: "resize-subscription-id"},
+			},
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					DeleteFunc: func(kafkaRequest *dbapi.KafkaRequest) *errors.ServiceError {
+						return nil
+					},
+				},
+				quotaService: &services.QuotaServiceMock{
+					DeleteQuotaFunc: func(id string) *errors.ServiceError {
+						return errors.GeneralError("failed to delete quota")
+					},
+				},
+			},
+			wantErr:                  true,
+			wantDeletedSubscriptions: []string{"resize-subscription-id"},
+		},
 	}
 
 	for _, testcase := range tests {
@@ -215,6 +256,13 @@ func TestDeletingKafkaManager_reconcileDeletingKafkas(t *testing.T) {
 				},
 			}
 			g.Expect(k.reconcileDeletingKafkas(tt.args.kafka) != nil).To(gomega.Equal(tt.wantErr))
+			if tt.wantDeletedSubscriptions != nil {
+				var deletedSubscriptions []string
+				for _, call := range tt.fields.quotaService.(*services.QuotaServiceMock).DeleteQuotaCalls() {
+					deletedSubscriptions = append(deletedSubscriptions, call.SubscriptionId)
+				}
+				g.Expect(deletedSubscriptions).To(gomega.Equal(tt.wantDeletedSubscriptions))
+			}
 		})
 	}
 }
@@ -0,0 +1,264 @@
+package kafka_mgrs
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/dns"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/metrics"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	// dnsDriftMissing is the drift of the CNAME records of ready kafkas which are missing from the zone
+	dnsDriftMissing = "missing"
+	// dnsDriftMismatched is the drift of the CNAME records of ready kafkas which point to another target
+	dnsDriftMismatched = "mismatched"
+	// dnsDriftOrphaned is the drift of the CNAME records of deleted kafkas which are still in the zone
+	dnsDriftOrphaned = "orphaned"
+)
+
+var dnsDrifts = []string{dnsDriftMissing, dnsDriftMismatched, dnsDriftOrphaned}
+
+// KafkaDNSDriftManager periodically compares the CNAME records of the routes of the ready kafkas with the records of
+// the DNS zone of the kafkas, e.g. to detect the records manually changed or deleted once the routes were created. The
+// missing and mismatched records are upserted and the leftover records of the deleted kafkas are deleted, unless the
+// repair is disabled in which case the drift is only reported.
+type KafkaDNSDriftManager struct {
+	workers.BaseWorker
+	kafkaService       services.KafkaService
+	dnsProviderFactory services.DNSProviderFactory
+	kafkaConfig        *config.KafkaConfig
+	dnsConfig          *config.DNSConfig
+	lastCheck          time.Time
+}
+
+var _ workers.Worker = &KafkaDNSDriftManager{}
+
+func NewKafkaDNSDriftManager(kafkaService services.KafkaService, dnsProviderFactory services.DNSProviderFactory,
+	kafkaConfig *config.KafkaConfig, dnsConfig *config.DNSConfig, reconciler workers.Reconciler) *KafkaDNSDriftManager {
+	return &KafkaDNSDriftManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "kafka_dns_drift",
+			Reconciler: reconciler,
+		},
+		kafkaService:       kafkaService,
+		dnsProviderFactory: dnsProviderFactory,
+		kafkaConfig:        kafkaConfig,
+		dnsConfig:          dnsConfig,
+	}
+}
+
+func (k *KafkaDNSDriftManager) Start() {
+	k.StartWorker(k)
+}
+
+func (k *KafkaDNSDriftManager) Stop() {
+	k.StopWorker(k)
+	metrics.ResetKafkaDNSDriftRecordsCountMetric()
+}
+
+// dnsZone holds the CNAME records of the zone of the kafkas, as listed from a DNS provider, by their name
+type dnsZone struct {
+	provider   dns.Provider
+	recordSets map[string]dns.RecordSet
+}
+
+// dnsDriftReport counts the drifted records by cluster and drift
+type dnsDriftReport map[string]map[string]int
+
+func (r dnsDriftReport) add(clusterID string, drift string, count int) {
+	if _, ok := r[clusterID]; !ok {
+		r[clusterID] = map[string]int{}
+	}
+	r[clusterID][drift] += count
+}
+
+func (k *KafkaDNSDriftManager) Reconcile() []error {
+	interval := k.dnsConfig.DriftCheckInterval
+	if !k.kafkaConfig.EnableKafkaCNAMERegistration || interval <= 0 || time.Since(k.lastCheck) < interval {
+		return nil
+	}
+	glog.Infoln("reconciling the drift of the CNAME records of kafkas")
+
+	readyKafkas, listErr := k.kafkaService.ListByStatus(constants.KafkaRequestStatusReady)
+	if listErr != nil {
+		return []error{errors.Wrap(listErr, "failed to list ready kafkas")}
+	}
+	deletedKafkas, listErr := k.kafkaService.ListDeletedKafkasWithRoutes(time.Now().Add(-k.dnsConfig.DriftDeletedKafkasLookback))
+	if listErr != nil {
+		return []error{errors.Wrap(listErr, "failed to list deleted kafkas")}
+	}
+	checkedAt := time.Now()
+
+	var errs []error
+	// the zones are listed once per DNS provider. The check is retried on the next reconcile, rather than once the
+	// check interval has elapsed, when a zone cannot be listed.
+	zones := map[string]*dnsZone{}
+	zonesListed := true
+	report := dnsDriftReport{}
+	repairs := dnsDriftReport{}
+
+	expectedNames := map[string]bool{}
+	for _, kafka := range readyKafkas {
+		// the records are being pointed to the target cluster of the migration of the kafka
+		if !kafka.RoutesCreated || kafka.MigrationStatus == dbapi.KafkaMigrationStatusCuttingOver {
+			continue
+		}
+		routes, err := kafka.GetRoutes()
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to get the routes of kafka %q", kafka.ID))
+			continue
+		}
+		for _, route := range routes {
+			expectedNames[normalizeDNSName(route.Domain)] = true
+		}
+		report.add(kafka.ClusterID, dnsDriftMissing, 0)
+
+		zone, err := k.getZone(zones, kafka.CloudProvider)
+		if err != nil {
+			zonesListed = false
+			errs = append(errs, err)
+			continue
+		}
+		var drifted []dns.RecordSet
+		for _, expected := range services.BuildKafkaClusterCNAMERecordSets(routes) {
+			actual, ok := zone.recordSets[normalizeDNSName(expected.Name)]
+			switch {
+			case !ok:
+				glog.Warningf("CNAME record %q of kafka %q is missing", expected.Name, kafka.ID)
+				report.add(kafka.ClusterID, dnsDriftMissing, 1)
+			case !sameDNSValues(actual.Values, expected.Values):
+				glog.Warningf("CNAME record %q of kafka %q points to %v instead of %v", expected.Name, kafka.ID, actual.Values, expected.Values)
+				report.add(kafka.ClusterID, dnsDriftMismatched, 1)
+			default:
+				continue
+			}
+			drifted = append(drifted, expected)
+		}
+		if len(drifted) > 0 && k.dnsConfig.DriftRepair {
+			if err := k.repair(zone, kafka, dns.ChangeActionUpsert, drifted, repairs); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	for _, kafka := range deletedKafkas {
+		routes, err := kafka.GetRoutes()
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to get the routes of deleted kafka %q", kafka.ID))
+			continue
+		}
+		zone, err := k.getZone(zones, kafka.CloudProvider)
+		if err != nil {
+			zonesListed = false
+			errs = append(errs, err)
+			continue
+		}
+		var orphaned []dns.RecordSet
+		for _, route := range routes {
+			name := normalizeDNSName(route.Domain)
+			actual, ok := zone.recordSets[name]
+			if !ok || expectedNames[name] {
+				continue
+			}
+			glog.Warningf("CNAME record %q of deleted kafka %q is still in the zone", route.Domain, kafka.ID)
+			report.add(kafka.ClusterID, dnsDriftOrphaned, 1)
+			// the records are deleted as they are, Route53 only deletes the records matching their current values
+			orphaned = append(orphaned, actual)
+		}
+		if len(orphaned) > 0 && k.dnsConfig.DriftRepair {
+			if err := k.repair(zone, kafka, dns.ChangeActionDelete, orphaned, repairs); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	metrics.ResetKafkaDNSDriftRecordsCountMetric()
+	for clusterID, counts := range report {
+		for _, drift := range dnsDrifts {
+			metrics.UpdateKafkaDNSDriftRecordsCountMetric(clusterID, drift, counts[drift])
+		}
+	}
+	for clusterID, counts := range repairs {
+		for drift, count := range counts {
+			metrics.IncreaseKafkaDNSDriftRepairsCountMetric(clusterID, drift, count)
+		}
+	}
+
+	if zonesListed {
+		k.lastCheck = checkedAt
+	}
+	return errs
+}
+
+// getZone returns the CNAME records of the zone of the kafkas of the cloud provider, listing them from its DNS provider
+// when they have not been listed yet
+func (k *KafkaDNSDriftManager) getZone(zones map[string]*dnsZone, cloudProvider string) (*dnsZone, error) {
+	provider, svcErr := k.dnsProviderFactory.GetProvider(cloudProvider)
+	if svcErr != nil {
+		return nil, errors.Wrapf(svcErr, "failed to get the DNS provider of cloud provider %q", cloudProvider)
+	}
+	if zone, ok := zones[provider.Kind()]; ok {
+		return zone, nil
+	}
+
+	recordSets, err := provider.ListRecordSets(k.kafkaConfig.KafkaDomainName, dns.RecordTypeCNAME)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the CNAME records of zone %q", k.kafkaConfig.KafkaDomainName)
+	}
+	zone := &dnsZone{
+		provider:   provider,
+		recordSets: make(map[string]dns.RecordSet, len(recordSets)),
+	}
+	for _, recordSet := range recordSets {
+		zone.recordSets[normalizeDNSName(recordSet.Name)] = recordSet
+	}
+	zones[provider.Kind()] = zone
+	return zone, nil
+}
+
+func (k *KafkaDNSDriftManager) repair(zone *dnsZone, kafka *dbapi.KafkaRequest, action dns.ChangeAction, recordSets []dns.RecordSet, repairs dnsDriftReport) error {
+	if _, err := zone.provider.ChangeRecordSets(k.kafkaConfig.KafkaDomainName, action, recordSets); err != nil {
+		return errors.Wrapf(err, "failed to repair the CNAME records of kafka %q", kafka.ID)
+	}
+	glog.Infof("repaired %d drifted CNAME records of kafka %q", len(recordSets), kafka.ID)
+
+	for _, recordSet := range recordSets {
+		drift := dnsDriftOrphaned
+		if action == dns.ChangeActionUpsert {
+			drift = dnsDriftMissing
+			if actual, ok := zone.recordSets[normalizeDNSName(recordSet.Name)]; ok && !sameDNSValues(actual.Values, recordSet.Values) {
+				drift = dnsDriftMismatched
+			}
+		}
+		repairs.add(kafka.ClusterID, drift, 1)
+	}
+	return nil
+}
+
+// normalizeDNSName returns the domain name in lower case without its terminating dot, domain names being case
+// insensitive
+func normalizeDNSName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+func sameDNSValues(actual []string, expected []string) bool {
+	if len(actual) != len(expected) {
+		return false
+	}
+	for i := range actual {
+		if normalizeDNSName(actual[i]) != normalizeDNSName(expected[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,193 @@
+package kafka_mgrs
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/dns"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	w "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/onsi/gomega"
+)
+
+func TestKafkaDNSDriftManager_Reconcile(t *testing.T) {
+	newKafka := func(id string, routes ...dbapi.DataPlaneKafkaRoute) *dbapi.KafkaRequest {
+		kafka := &dbapi.KafkaRequest{ClusterID: "cluster-id", CloudProvider: "aws", RoutesCreated: true}
+		kafka.ID = id
+		_ = kafka.SetRoutes(routes)
+		return kafka
+	}
+	route := func(domain string, router string) dbapi.DataPlaneKafkaRoute {
+		return dbapi.DataPlaneKafkaRoute{Domain: domain, Router: router}
+	}
+	cname := func(name string, value string) dns.RecordSet {
+		return dns.RecordSet{Name: name, Type: dns.RecordTypeCNAME, TTL: 300, Values: []string{value}}
+	}
+
+	type change struct {
+		action     dns.ChangeAction
+		recordSets []dns.RecordSet
+	}
+
+	tests := []struct {
+		name          string
+		disabled      bool
+		lastCheck     time.Time
+		noRepair      bool
+		readyKafkas   []*dbapi.KafkaRequest
+		deletedKafkas []*dbapi.KafkaRequest
+		listErr       *errors.ServiceError
+		zone          []dns.RecordSet
+		zoneErr       error
+		wantErr       bool
+		wantListed    bool
+		wantChanges   []change
+		// wantChecked is true when the next check waits for the check interval
+		wantChecked bool
+	}{
+		{
+			name:     "should skip the reconciliation when the CNAME registration is disabled",
+			disabled: true,
+		},
+		{
+			name:      "should skip the reconciliation before the check interval has elapsed",
+			lastCheck: time.Now(),
+		},
+		{
+			name:    "should return an error when the kafkas cannot be listed",
+			listErr: errors.GeneralError("test"),
+			wantErr: true,
+		},
+		{
+			name:        "should not change the zone when the records are in sync",
+			readyKafkas: []*dbapi.KafkaRequest{newKafka("kafka-1", route("kafka-1.example.com", "router.example.com"))},
+			zone:        []dns.RecordSet{cname("Kafka-1.example.com", "router.example.com.")},
+			wantListed:  true,
+			wantChecked: true,
+		},
+		{
+			name:        "should check the zone again on the next reconcile when it cannot be listed",
+			readyKafkas: []*dbapi.KafkaRequest{newKafka("kafka-1", route("kafka-1.example.com", "router.example.com"))},
+			zoneErr:     fmt.Errorf("test"),
+			wantErr:     true,
+			wantListed:  true,
+		},
+		{
+			name: "should upsert the missing and mismatched records of ready kafkas",
+			readyKafkas: []*dbapi.KafkaRequest{newKafka("kafka-1",
+				route("kafka-1.example.com", "router.example.com"),
+				route("admin-server-kafka-1.example.com", "router.example.com"),
+			)},
+			zone:       []dns.RecordSet{cname("admin-server-kafka-1.example.com", "another-router.example.com")},
+			wantListed: true,
+			wantChanges: []change{{
+				action: dns.ChangeActionUpsert,
+				recordSets: []dns.RecordSet{
+					cname("kafka-1.example.com", "router.example.com"),
+					cname("admin-server-kafka-1.example.com", "router.example.com"),
+				},
+			}},
+			wantChecked: true,
+		},
+		{
+			name:        "should only report the drift when the repair is disabled",
+			noRepair:    true,
+			readyKafkas: []*dbapi.KafkaRequest{newKafka("kafka-1", route("kafka-1.example.com", "router.example.com"))},
+			wantListed:  true,
+			wantChecked: true,
+		},
+		{
+			name: "should skip the ready kafkas whose routes are not created or being cut over",
+			readyKafkas: []*dbapi.KafkaRequest{
+				func() *dbapi.KafkaRequest {
+					k := newKafka("kafka-1", route("kafka-1.example.com", "router.example.com"))
+					k.RoutesCreated = false
+					return k
+				}(),
+				func() *dbapi.KafkaRequest {
+					k := newKafka("kafka-2", route("kafka-2.example.com", "router.example.com"))
+					k.MigrationStatus = dbapi.KafkaMigrationStatusCuttingOver
+					return k
+				}(),
+			},
+			wantChecked: true,
+		},
+		{
+			name:          "should delete the leftover records of deleted kafkas",
+			deletedKafkas: []*dbapi.KafkaRequest{newKafka("kafka-1", route("kafka-1.example.com", "router.example.com"))},
+			zone:          []dns.RecordSet{cname("kafka-1.example.com", "old-router.example.com")},
+			wantListed:    true,
+			wantChanges: []change{{
+				action:     dns.ChangeActionDelete,
+				recordSets: []dns.RecordSet{cname("kafka-1.example.com", "old-router.example.com")},
+			}},
+			wantChecked: true,
+		},
+		{
+			name:          "should not delete the records of deleted kafkas which are used by ready kafkas",
+			readyKafkas:   []*dbapi.KafkaRequest{newKafka("kafka-2", route("kafka-1.example.com", "router.example.com"))},
+			deletedKafkas: []*dbapi.KafkaRequest{newKafka("kafka-1", route("kafka-1.example.com", "router.example.com"))},
+			zone:          []dns.RecordSet{cname("kafka-1.example.com", "router.example.com")},
+			wantListed:    true,
+			wantChecked:   true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			kafkaService := &services.KafkaServiceMock{
+				ListByStatusFunc: func(status ...constants.KafkaStatus) ([]*dbapi.KafkaRequest, *errors.ServiceError) {
+					return tt.readyKafkas, tt.listErr
+				},
+				ListDeletedKafkasWithRoutesFunc: func(deletedSince time.Time) ([]*dbapi.KafkaRequest, *errors.ServiceError) {
+					return tt.deletedKafkas, nil
+				},
+			}
+			provider := &dns.ProviderMock{
+				KindFunc: func() string {
+					return dns.KindRoute53
+				},
+				ListRecordSetsFunc: func(zone string, recordType string) ([]dns.RecordSet, error) {
+					return tt.zone, tt.zoneErr
+				},
+				ChangeRecordSetsFunc: func(zone string, action dns.ChangeAction, recordSets []dns.RecordSet) (*dns.Change, error) {
+					return &dns.Change{}, nil
+				},
+			}
+			dnsProviderFactory := &services.DNSProviderFactoryMock{
+				GetProviderFunc: func(cloudProvider string) (dns.Provider, *errors.ServiceError) {
+					return provider, nil
+				},
+			}
+			dnsConfig := config.NewDNSConfig()
+			dnsConfig.DriftRepair = !tt.noRepair
+
+			k := NewKafkaDNSDriftManager(kafkaService, dnsProviderFactory,
+				&config.KafkaConfig{EnableKafkaCNAMERegistration: !tt.disabled, KafkaDomainName: "example.com"}, dnsConfig, w.Reconciler{})
+			k.lastCheck = tt.lastCheck
+			g.Expect(len(k.Reconcile()) > 0).To(gomega.Equal(tt.wantErr))
+
+			// the zone is listed once for all the kafkas of the provider
+			if tt.wantListed {
+				g.Expect(provider.ListRecordSetsCalls()).To(gomega.HaveLen(1))
+			} else {
+				g.Expect(provider.ListRecordSetsCalls()).To(gomega.BeEmpty())
+			}
+			var changes []change
+			for _, call := range provider.ChangeRecordSetsCalls() {
+				g.Expect(call.Zone).To(gomega.Equal("example.com"))
+				changes = append(changes, change{action: call.Action, recordSets: call.RecordSets})
+			}
+			g.Expect(changes).To(gomega.Equal(tt.wantChanges))
+			g.Expect(k.lastCheck.After(tt.lastCheck)).To(gomega.Equal(tt.wantChecked))
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package kafka_mgrs
+
+import (
+	"context"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services/kafkatlscertmgmt"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/metrics"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// KafkaTLSCertificateRenewalManager periodically refreshes the inventory of the automatically managed tls certificates
+// of the base domains of the kafkas, reports the number of days before their expiry and renews the certificates
+// expiring within the proactive renewal window, regardless of the renewal window ratio of the certificate management
+// library.
+type KafkaTLSCertificateRenewalManager struct {
+	workers.BaseWorker
+	kafkaTLSCertificateInventoryService  services.KafkaTLSCertificateInventoryService
+	kafkaTLSCertificateManagementService kafkatlscertmgmt.KafkaTLSCertificateManagementService
+	kafkaTLSCertificateManagementConfig  *config.KafkaTLSCertificateManagementConfig
+	lastCheck                            time.Time
+}
+
+var _ workers.Worker = &KafkaTLSCertificateRenewalManager{}
+
+func NewKafkaTLSCertificateRenewalManager(kafkaTLSCertificateInventoryService services.KafkaTLSCertificateInventoryService,
+	kafkaTLSCertificateManagementService kafkatlscertmgmt.KafkaTLSCertificateManagementService,
+	kafkaTLSCertificateManagementConfig *config.KafkaTLSCertificateManagementConfig, reconciler workers.Reconciler) *KafkaTLSCertificateRenewalManager {
+	return &KafkaTLSCertificateRenewalManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "kafka_tls_certificate_renewal",
+			Reconciler: reconciler,
+		},
+		kafkaTLSCertificateInventoryService:  kafkaTLSCertificateInventoryService,
+		kafkaTLSCertificateManagementService: kafkaTLSCertificateManagementService,
+		kafkaTLSCertificateManagementConfig:  kafkaTLSCertificateManagementConfig,
+	}
+}
+
+func (k *KafkaTLSCertificateRenewalManager) Start() {
+	k.StartWorker(k)
+}
+
+func (k *KafkaTLSCertificateRenewalManager) Stop() {
+	k.StopWorker(k)
+	metrics.ResetKafkaTLSCertificateDaysToExpiryMetric()
+}
+
+func (k *KafkaTLSCertificateRenewalManager) Reconcile() []error {
+	automaticConfig := k.kafkaTLSCertificateManagementConfig.AutomaticCertificateManagementConfig
+	interval := automaticConfig.InventoryRefreshInterval
+	if k.kafkaTLSCertificateManagementConfig.CertificateManagementStrategy != config.AutomaticCertificateManagement ||
+		interval <= 0 || time.Since(k.lastCheck) < interval {
+		return nil
+	}
+	glog.Infoln("reconciling the tls certificates of kafkas")
+
+	certificates, errs := k.kafkaTLSCertificateInventoryService.Refresh()
+	k.lastCheck = time.Now()
+
+	window := automaticConfig.ProactiveRenewalWindow
+	for _, certificate := range certificates {
+		if window <= 0 || !certificate.ExpiresWithin(window) {
+			continue
+		}
+		// renewing a certificate whose whole validity period is within the window would renew it over and over
+		if certificate.Lifetime() <= window {
+			glog.Warningf("the lifetime of the tls certificate of domain %q is shorter than the proactive renewal window %s, skipping its renewal", certificate.Domain, window)
+			continue
+		}
+
+		glog.Infof("renewing the tls certificate of domain %q expiring on %s", certificate.Domain, certificate.NotAfter)
+		renewalErr := k.kafkaTLSCertificateManagementService.RenewCertificate(context.Background(), certificate.Domain)
+		if renewalErr != nil {
+			errs = append(errs, errors.Wrapf(renewalErr, "failed to renew the tls certificate of domain %q", certificate.Domain))
+		}
+		if err := k.kafkaTLSCertificateInventoryService.RecordRenewal(certificate, renewalErr); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to record the renewal of the tls certificate of domain %q", certificate.Domain))
+		}
+	}
+
+	// the certificates of the base domains no longer used are not reported
+	metrics.ResetKafkaTLSCertificateDaysToExpiryMetric()
+	for _, certificate := range certificates {
+		if certificate.NotAfter == nil {
+			continue
+		}
+		metrics.UpdateKafkaTLSCertificateDaysToExpiryMetric(certificate.Domain, time.Until(*certificate.NotAfter).Hours()/24)
+	}
+
+	return errs
+}
@@ -0,0 +1,139 @@
+package kafka_mgrs
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services/kafkatlscertmgmt"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	w "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/onsi/gomega"
+)
+
+func TestKafkaTLSCertificateRenewalManager_Reconcile(t *testing.T) {
+	newCertificate := func(domain string, lifetime time.Duration, expiresIn time.Duration) *dbapi.KafkaTLSCertificate {
+		notAfter := time.Now().Add(expiresIn)
+		notBefore := notAfter.Add(-lifetime)
+		return &dbapi.KafkaTLSCertificate{Domain: domain, NotBefore: &notBefore, NotAfter: &notAfter}
+	}
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name             string
+		strategy         string
+		lastCheck        time.Time
+		renewalWindow    time.Duration
+		certificates     dbapi.KafkaTLSCertificateList
+		refreshErrs      []error
+		renewalErr       error
+		wantErr          bool
+		wantRefreshed    bool
+		wantRenewed      []string
+		wantRenewalError bool
+	}{
+		{
+			name:     "should skip the reconciliation when the certificates are manually managed",
+			strategy: config.ManualCertificateManagement,
+		},
+		{
+			name:      "should skip the reconciliation before the refresh interval has elapsed",
+			strategy:  config.AutomaticCertificateManagement,
+			lastCheck: time.Now(),
+		},
+		{
+			name:          "should renew the certificates expiring within the window",
+			strategy:      config.AutomaticCertificateManagement,
+			renewalWindow: 21 * day,
+			certificates: dbapi.KafkaTLSCertificateList{
+				newCertificate("kafka-1.example.com", 90*day, 10*day),
+				newCertificate("kafka-2.example.com", 90*day, 60*day),
+				{Domain: "kafka-3.example.com"},
+			},
+			wantRefreshed: true,
+			wantRenewed:   []string{"kafka-1.example.com"},
+		},
+		{
+			name:          "should not renew the certificates whose lifetime is shorter than the window",
+			strategy:      config.AutomaticCertificateManagement,
+			renewalWindow: 21 * day,
+			certificates:  dbapi.KafkaTLSCertificateList{newCertificate("kafka-1.example.com", 14*day, 10*day)},
+			wantRefreshed: true,
+		},
+		{
+			name:          "should not renew the certificates when the proactive renewal is disabled",
+			strategy:      config.AutomaticCertificateManagement,
+			certificates:  dbapi.KafkaTLSCertificateList{newCertificate("kafka-1.example.com", 90*day, 10*day)},
+			wantRefreshed: true,
+		},
+		{
+			name:             "should record the failed renewal and return its error",
+			strategy:         config.AutomaticCertificateManagement,
+			renewalWindow:    21 * day,
+			certificates:     dbapi.KafkaTLSCertificateList{newCertificate("kafka-1.example.com", 90*day, 10*day)},
+			renewalErr:       fmt.Errorf("rate limited"),
+			wantErr:          true,
+			wantRefreshed:    true,
+			wantRenewed:      []string{"kafka-1.example.com"},
+			wantRenewalError: true,
+		},
+		{
+			name:          "should return the errors of the refresh of the inventory",
+			strategy:      config.AutomaticCertificateManagement,
+			certificates:  dbapi.KafkaTLSCertificateList{{Domain: "kafka-1.example.com"}},
+			refreshErrs:   []error{errors.GeneralError("test")},
+			wantErr:       true,
+			wantRefreshed: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			inventoryService := &services.KafkaTLSCertificateInventoryServiceMock{
+				RefreshFunc: func() (dbapi.KafkaTLSCertificateList, []error) {
+					return tt.certificates, tt.refreshErrs
+				},
+				RecordRenewalFunc: func(certificate *dbapi.KafkaTLSCertificate, renewalErr error) *errors.ServiceError {
+					return nil
+				},
+			}
+			certificateManagementService := &kafkatlscertmgmt.KafkaTLSCertificateManagementServiceMock{
+				RenewCertificateFunc: func(ctx context.Context, domain string) error {
+					return tt.renewalErr
+				},
+			}
+			certificateManagementConfig := config.NewCertificateManagementConfig()
+			certificateManagementConfig.CertificateManagementStrategy = tt.strategy
+			certificateManagementConfig.AutomaticCertificateManagementConfig.ProactiveRenewalWindow = tt.renewalWindow
+
+			k := NewKafkaTLSCertificateRenewalManager(inventoryService, certificateManagementService, certificateManagementConfig, w.Reconciler{})
+			k.lastCheck = tt.lastCheck
+			g.Expect(len(k.Reconcile()) > 0).To(gomega.Equal(tt.wantErr))
+
+			if tt.wantRefreshed {
+				g.Expect(inventoryService.RefreshCalls()).To(gomega.HaveLen(1))
+			} else {
+				g.Expect(inventoryService.RefreshCalls()).To(gomega.BeEmpty())
+			}
+			var renewed []string
+			for _, call := range certificateManagementService.RenewCertificateCalls() {
+				renewed = append(renewed, call.Domain)
+			}
+			g.Expect(renewed).To(gomega.Equal(tt.wantRenewed))
+
+			// every renewal attempt is recorded in the inventory
+			recorded := inventoryService.RecordRenewalCalls()
+			g.Expect(recorded).To(gomega.HaveLen(len(tt.wantRenewed)))
+			for _, call := range recorded {
+				g.Expect(call.RenewalErr != nil).To(gomega.Equal(tt.wantRenewalError))
+			}
+		})
+	}
+}
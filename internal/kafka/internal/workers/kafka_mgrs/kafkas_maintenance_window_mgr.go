@@ -0,0 +1,98 @@
+package kafka_mgrs
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// KafkaMaintenanceWindowManager releases the upgrades held until the maintenance window of their kafka. The desired
+// versions are only sent to the data plane while the maintenance window is open, marking the release of the upgrade
+// on the kafka notifies the data plane that its managed kafka has changed.
+type KafkaMaintenanceWindowManager struct {
+	workers.BaseWorker
+	kafkaService             services.KafkaService
+	maintenanceWindowService services.MaintenanceWindowService
+	kafkaConfig              *config.KafkaConfig
+}
+
+var _ workers.Worker = &KafkaMaintenanceWindowManager{}
+
+func NewKafkaMaintenanceWindowManager(kafkaService services.KafkaService, maintenanceWindowService services.MaintenanceWindowService,
+	kafkaConfig *config.KafkaConfig, reconciler workers.Reconciler) *KafkaMaintenanceWindowManager {
+	return &KafkaMaintenanceWindowManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "kafka_maintenance_window",
+			Reconciler: reconciler,
+		},
+		kafkaService:             kafkaService,
+		maintenanceWindowService: maintenanceWindowService,
+		kafkaConfig:              kafkaConfig,
+	}
+}
+
+func (k *KafkaMaintenanceWindowManager) Start() {
+	k.StartWorker(k)
+}
+
+func (k *KafkaMaintenanceWindowManager) Stop() {
+	k.StopWorker(k)
+}
+
+func (k *KafkaMaintenanceWindowManager) Reconcile() []error {
+	glog.Infoln("reconciling the upgrades of the kafkas waiting for their maintenance window")
+	var errs []error
+
+	kafkas, listErr := k.kafkaService.ListKafkasWithPendingUpgrade()
+	if listErr != nil {
+		return []error{errors.Wrap(listErr, "failed to list kafkas with a pending upgrade")}
+	}
+	glog.Infof("kafkas with a pending upgrade count = %d", len(kafkas))
+
+	var organisationIds []string
+	for _, kafka := range kafkas {
+		if kafka.OwnMaintenanceWindow() == nil {
+			organisationIds = append(organisationIds, kafka.OrganisationId)
+		}
+	}
+	organisationWindows, listErr := k.maintenanceWindowService.ListByOrganisationIds(organisationIds)
+	if listErr != nil {
+		return []error{errors.Wrap(listErr, "failed to list the maintenance windows of the organisations")}
+	}
+
+	now := time.Now()
+	for _, kafka := range kafkas {
+		schedule := kafka.OwnMaintenanceWindow()
+		if window, ok := organisationWindows[kafka.OrganisationId]; ok && schedule == nil {
+			schedule = window.Schedule()
+		}
+		if !k.isUpgradeToBeReleased(kafka, schedule, now) {
+			continue
+		}
+
+		windowStart := schedule.LatestStart(now)
+		glog.Infof("releasing the upgrade of kafka %q in the maintenance window started at %s", kafka.ID, windowStart)
+		if err := k.kafkaService.Updates(kafka, map[string]interface{}{"upgrade_released_at": windowStart}); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to release the upgrade of kafka %q", kafka.ID))
+		}
+	}
+
+	return errs
+}
+
+// isUpgradeToBeReleased returns true when the maintenance window of the kafka is open and the upgrade of the kafka
+// has not been released in it yet. The upgrades of the kafkas without a maintenance window and the forced upgrades
+// are sent to the data plane straight away.
+func (k *KafkaMaintenanceWindowManager) isUpgradeToBeReleased(kafka *dbapi.KafkaRequest, schedule *dbapi.MaintenanceWindowSchedule, now time.Time) bool {
+	if schedule == nil || kafka.UpgradeForced || !schedule.IsOpen(now, k.kafkaConfig.MaintenanceWindowDuration) {
+		return false
+	}
+	return kafka.UpgradeReleasedAt == nil || kafka.UpgradeReleasedAt.Before(schedule.LatestStart(now))
+}
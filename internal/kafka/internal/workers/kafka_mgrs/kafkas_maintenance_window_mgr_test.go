@@ -0,0 +1,120 @@
+package kafka_mgrs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	w "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+
+	"github.com/onsi/gomega"
+
+	mockKafkas "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/kafkas"
+)
+
+func TestKafkaMaintenanceWindowManager_Reconcile(t *testing.T) {
+	// a window lasting a whole week is always open
+	alwaysOpen := &config.KafkaConfig{MaintenanceWindowDuration: 7 * 24 * time.Hour}
+	neverOpen := &config.KafkaConfig{}
+	longAgo := time.Now().AddDate(0, 0, -30)
+	justNow := time.Now()
+
+	pendingUpgrade := func(modifyFn func(kafka *dbapi.KafkaRequest)) *dbapi.KafkaRequest {
+		return mockKafkas.BuildKafkaRequest(func(kafka *dbapi.KafkaRequest) {
+			kafka.OrganisationId = "org-id"
+			kafka.DesiredKafkaVersion = "3.4.0"
+			kafka.ActualKafkaVersion = "3.3.1"
+			modifyFn(kafka)
+		})
+	}
+	noOrganisationWindow := func(organisationIds []string) (map[string]*dbapi.MaintenanceWindow, *errors.ServiceError) {
+		return map[string]*dbapi.MaintenanceWindow{}, nil
+	}
+
+	tests := []struct {
+		name                     string
+		kafkas                   []*dbapi.KafkaRequest
+		listErr                  *errors.ServiceError
+		listOrganisationWindows  func(organisationIds []string) (map[string]*dbapi.MaintenanceWindow, *errors.ServiceError)
+		kafkaConfig              *config.KafkaConfig
+		wantErrCount             int
+		wantReleasedUpgradeCount int
+	}{
+		{
+			name:         "should return an error when the kafkas with a pending upgrade cannot be listed",
+			listErr:      errors.GeneralError("test"),
+			kafkaConfig:  alwaysOpen,
+			wantErrCount: 1,
+		},
+		{
+			name:                    "should not release the upgrades of the kafkas without maintenance window",
+			kafkas:                  []*dbapi.KafkaRequest{pendingUpgrade(func(kafka *dbapi.KafkaRequest) {})},
+			listOrganisationWindows: noOrganisationWindow,
+			kafkaConfig:             alwaysOpen,
+		},
+		{
+			name: "should release the upgrades in the open maintenance window of the organisation",
+			kafkas: []*dbapi.KafkaRequest{pendingUpgrade(func(kafka *dbapi.KafkaRequest) {
+				kafka.UpgradeReleasedAt = &longAgo
+			})},
+			listOrganisationWindows: func(organisationIds []string) (map[string]*dbapi.MaintenanceWindow, *errors.ServiceError) {
+				return map[string]*dbapi.MaintenanceWindow{"org-id": {OrganisationId: "org-id", DayOfWeek: "monday"}}, nil
+			},
+			kafkaConfig:              alwaysOpen,
+			wantReleasedUpgradeCount: 1,
+		},
+		{
+			name: "should not release the upgrades already released in the open maintenance window",
+			kafkas: []*dbapi.KafkaRequest{pendingUpgrade(func(kafka *dbapi.KafkaRequest) {
+				kafka.MaintenanceWindowDay = "monday"
+				kafka.UpgradeReleasedAt = &justNow
+			})},
+			listOrganisationWindows: noOrganisationWindow,
+			kafkaConfig:             alwaysOpen,
+		},
+		{
+			name: "should not release the upgrades outside of the maintenance window",
+			kafkas: []*dbapi.KafkaRequest{pendingUpgrade(func(kafka *dbapi.KafkaRequest) {
+				kafka.MaintenanceWindowDay = "monday"
+			})},
+			listOrganisationWindows: noOrganisationWindow,
+			kafkaConfig:             neverOpen,
+		},
+		{
+			name: "should not release the forced upgrades",
+			kafkas: []*dbapi.KafkaRequest{pendingUpgrade(func(kafka *dbapi.KafkaRequest) {
+				kafka.MaintenanceWindowDay = "monday"
+				kafka.UpgradeForced = true
+			})},
+			listOrganisationWindows: noOrganisationWindow,
+			kafkaConfig:             alwaysOpen,
+		},
+	}
+
+	for _, testcase := range tests {
+		test := testcase
+		t.Run(test.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			kafkaService := &services.KafkaServiceMock{
+				ListKafkasWithPendingUpgradeFunc: func() ([]*dbapi.KafkaRequest, *errors.ServiceError) {
+					return test.kafkas, test.listErr
+				},
+				UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *errors.ServiceError {
+					return nil
+				},
+			}
+			maintenanceWindowService := &services.MaintenanceWindowServiceMock{
+				ListByOrganisationIdsFunc: test.listOrganisationWindows,
+			}
+			errs := NewKafkaMaintenanceWindowManager(kafkaService, maintenanceWindowService, test.kafkaConfig, w.Reconciler{}).Reconcile()
+			g.Expect(errs).To(gomega.HaveLen(test.wantErrCount))
+			g.Expect(kafkaService.UpdatesCalls()).To(gomega.HaveLen(test.wantReleasedUpgradeCount))
+			for _, call := range kafkaService.UpdatesCalls() {
+				g.Expect(call.Values).To(gomega.HaveKey("upgrade_released_at"))
+			}
+		})
+	}
+}
@@ -459,6 +459,13 @@ func (k *KafkaManager) reconcileKafkaExpiresAt(kafkas dbapi.KafkaList) serviceEr
 			continue
 		}
 
+		// the quota of the kafkas suspended by a user is released: their entitlement cannot be checked from their
+		// subscription and must not be cached for the other kafkas of the organisation
+		if kafka.IsSuspendedByUser() {
+			logger.Logger.Infof("kafka %q has been suspended by a user, skipping expires_at reconciliation", kafka.ID)
+			continue
+		}
+
 		instanceSize, err := k.kafkaConfig.GetKafkaInstanceSize(kafka.InstanceType, kafka.SizeId)
 		if err != nil {
 			svcErrors = append(svcErrors, errors.Wrapf(err,
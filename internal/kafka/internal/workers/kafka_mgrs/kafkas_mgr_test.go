@@ -1730,6 +1730,114 @@ func TestKafkaManager_reconcileKafkaExpiresAt(t *testing.T) {
 			wantUpdateCallCount:   1,
 			wantErrCount:          0,
 		},
+		{
+			name: "should update expires_at to null without resuming the kafka if quota entitlement is active and the kafka was suspended on entering its grace period",
+			fields: fields{
+				kafkaConfig: &config.KafkaConfig{
+					SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
+						Configuration: config.SupportedKafkaInstanceTypesConfig{
+							SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+								{
+									Id: "instance-type-1",
+									Sizes: []config.KafkaInstanceSize{
+										{
+											Id: "x1",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				kafkaService: func(updatedExpiresAt *sql.NullTime) *services.KafkaServiceMock {
+					return &services.KafkaServiceMock{
+						IsQuotaEntitlementActiveFunc: func(kafkaRequest *dbapi.KafkaRequest) (bool, error) {
+							return true, nil
+						},
+						UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *errors.ServiceError {
+							*updatedExpiresAt = values["expires_at"].(sql.NullTime)
+							return nil
+						},
+					}
+				},
+			},
+			args: args{
+				kafkas: dbapi.KafkaList{
+					{
+						InstanceType: "instance-type-1",
+						SizeId:       "x1",
+						Status:       constants.KafkaRequestStatusSuspended.String(),
+						ExpiresAt: sql.NullTime{
+							Time:  time.Now().AddDate(0, 0, 10),
+							Valid: true,
+						},
+					},
+				},
+			},
+			wantNewExpiresAtValue: sql.NullTime{},
+			wantUpdateCallCount:   1,
+			wantErrCount:          0,
+		},
+		{
+			name: "should skip the kafkas suspended by a user and not cache the entitlement of their released subscription",
+			fields: fields{
+				kafkaConfig: &config.KafkaConfig{
+					SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
+						Configuration: config.SupportedKafkaInstanceTypesConfig{
+							SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+								{
+									Id: "instance-type-1",
+									Sizes: []config.KafkaInstanceSize{
+										{
+											Id: "x1",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				kafkaService: func(updatedExpiresAt *sql.NullTime) *services.KafkaServiceMock {
+					return &services.KafkaServiceMock{
+						// the subscription of the kafkas suspended by a user is released
+						IsQuotaEntitlementActiveFunc: func(kafkaRequest *dbapi.KafkaRequest) (bool, error) {
+							return kafkaRequest.SubscriptionId != "", nil
+						},
+						UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *errors.ServiceError {
+							*updatedExpiresAt = values["expires_at"].(sql.NullTime)
+							return nil
+						},
+					}
+				},
+			},
+			args: args{
+				kafkas: dbapi.KafkaList{
+					{
+						InstanceType:            "instance-type-1",
+						SizeId:                  "x1",
+						OrganisationId:          "org-id",
+						ActualKafkaBillingModel: "standard",
+						Status:                  constants.KafkaRequestStatusSuspended.String(),
+						SuspendedBy:             "some-user",
+					},
+					{
+						InstanceType:            "instance-type-1",
+						SizeId:                  "x1",
+						OrganisationId:          "org-id",
+						ActualKafkaBillingModel: "standard",
+						Status:                  constants.KafkaRequestStatusReady.String(),
+						SubscriptionId:          "subscription-id",
+						ExpiresAt: sql.NullTime{
+							Time:  time.Now().AddDate(0, 0, 10),
+							Valid: true,
+						},
+					},
+				},
+			},
+			wantNewExpiresAtValue: sql.NullTime{},
+			wantUpdateCallCount:   1,
+			wantErrCount:          0,
+		},
 		{
 			name: "should skip update if quota entitlement is active and expires_at is already null",
 			fields: fields{
@@ -2157,6 +2265,10 @@ func TestKafkaManager_reconcileKafkaExpiresAt(t *testing.T) {
 			g.Expect(len(err)).To(gomega.Equal(tt.wantErrCount))
 
 			g.Expect(len(mockKafkaService.UpdatesCalls())).To(gomega.Equal(tt.wantUpdateCallCount), "expected update call count does not match actual")
+			// the suspended kafkas are never resumed by the reconciliation of their expiration date
+			for _, call := range mockKafkaService.UpdatesCalls() {
+				g.Expect(call.Values).ToNot(gomega.HaveKey("status"))
+			}
 			g.Expect(updatedExpiresAt.Valid).To(gomega.Equal(tt.wantNewExpiresAtValue.Valid), "expected expires_at.valid does not match actual")
 
 			// The expires_at value set by checking the quota entitlement status is based on time.Now() captured during reconcile.
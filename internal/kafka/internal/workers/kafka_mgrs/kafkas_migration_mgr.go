@@ -0,0 +1,55 @@
+package kafka_mgrs
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// KafkaMigrationManager cuts over the kafkas that are ready on the target cluster of their migration
+type KafkaMigrationManager struct {
+	workers.BaseWorker
+	kafkaMigrationService services.KafkaMigrationService
+}
+
+var _ workers.Worker = &KafkaMigrationManager{}
+
+func NewKafkaMigrationManager(kafkaMigrationService services.KafkaMigrationService, reconciler workers.Reconciler) *KafkaMigrationManager {
+	return &KafkaMigrationManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "migrating_kafka",
+			Reconciler: reconciler,
+		},
+		kafkaMigrationService: kafkaMigrationService,
+	}
+}
+
+func (k *KafkaMigrationManager) Start() {
+	k.StartWorker(k)
+}
+
+func (k *KafkaMigrationManager) Stop() {
+	k.StopWorker(k)
+}
+
+func (k *KafkaMigrationManager) Reconcile() []error {
+	glog.Infoln("reconciling kafkas to be cut over to the target cluster of their migration")
+	var errs []error
+
+	kafkas, listErr := k.kafkaMigrationService.ListKafkasToBeCutOver()
+	if listErr != nil {
+		return []error{errors.Wrap(listErr, "failed to list kafkas to be cut over")}
+	}
+	glog.Infof("kafkas to be cut over count = %d", len(kafkas))
+
+	for _, kafka := range kafkas {
+		if err := k.kafkaMigrationService.CutOver(kafka); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to cut over kafka %q to cluster %q", kafka.ID, kafka.MigrationTargetClusterId))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,79 @@
+package kafka_mgrs
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	w "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+
+	"github.com/onsi/gomega"
+
+	mockKafkas "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/kafkas"
+)
+
+func TestKafkaMigrationManager_Reconcile(t *testing.T) {
+	cuttingOverKafka := func(id string) *dbapi.KafkaRequest {
+		return mockKafkas.BuildKafkaRequest(func(kafkaRequest *dbapi.KafkaRequest) {
+			kafkaRequest.ID = id
+			kafkaRequest.MigrationStatus = dbapi.KafkaMigrationStatusCuttingOver
+			kafkaRequest.MigrationTargetClusterId = "target-cluster-id"
+		})
+	}
+
+	tests := []struct {
+		name                  string
+		kafkaMigrationService *services.KafkaMigrationServiceMock
+		wantErrCount          int
+		wantCutOverCalls      int
+	}{
+		{
+			name: "should return an error when the kafkas to be cut over cannot be listed",
+			kafkaMigrationService: &services.KafkaMigrationServiceMock{
+				ListKafkasToBeCutOverFunc: func() ([]*dbapi.KafkaRequest, *errors.ServiceError) {
+					return nil, errors.GeneralError("test")
+				},
+			},
+			wantErrCount: 1,
+		},
+		{
+			name: "should cut over all the kafkas even when some of them fail",
+			kafkaMigrationService: &services.KafkaMigrationServiceMock{
+				ListKafkasToBeCutOverFunc: func() ([]*dbapi.KafkaRequest, *errors.ServiceError) {
+					return []*dbapi.KafkaRequest{cuttingOverKafka("failing"), cuttingOverKafka("succeeding")}, nil
+				},
+				CutOverFunc: func(kafka *dbapi.KafkaRequest) *errors.ServiceError {
+					if kafka.ID == "failing" {
+						return errors.GeneralError("test")
+					}
+					return nil
+				},
+			},
+			wantErrCount:     1,
+			wantCutOverCalls: 2,
+		},
+		{
+			name: "should succeed when all the kafkas are cut over",
+			kafkaMigrationService: &services.KafkaMigrationServiceMock{
+				ListKafkasToBeCutOverFunc: func() ([]*dbapi.KafkaRequest, *errors.ServiceError) {
+					return []*dbapi.KafkaRequest{cuttingOverKafka("succeeding")}, nil
+				},
+				CutOverFunc: func(kafka *dbapi.KafkaRequest) *errors.ServiceError {
+					return nil
+				},
+			},
+			wantCutOverCalls: 1,
+		},
+	}
+
+	for _, testcase := range tests {
+		test := testcase
+		t.Run(test.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			errs := NewKafkaMigrationManager(test.kafkaMigrationService, w.Reconciler{}).Reconcile()
+			g.Expect(errs).To(gomega.HaveLen(test.wantErrCount))
+			g.Expect(test.kafkaMigrationService.CutOverCalls()).To(gomega.HaveLen(test.wantCutOverCalls))
+		})
+	}
+}
@@ -1,13 +1,7 @@
 package kafka_mgrs
 
 import (
-	"fmt"
-	"strings"
-
-	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sso"
-
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
-	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
@@ -19,22 +13,20 @@ import (
 // ReadyKafkaManager represents a kafka manager that periodically reconciles ready kafka requests.
 type ReadyKafkaManager struct {
 	workers.BaseWorker
-	kafkaService    services.KafkaService
-	keycloakService sso.KeycloakService
-	keycloakConfig  *keycloak.KeycloakConfig
+	kafkaService   services.KafkaService
+	keycloakConfig *keycloak.KeycloakConfig
 }
 
 // NewReadyKafkaManager creates a new kafka manager to reconcile ready kafkas.
-func NewReadyKafkaManager(kafkaService services.KafkaService, keycloakService sso.KafkaKeycloakService, keycloakConfig *keycloak.KeycloakConfig, reconciler workers.Reconciler) *ReadyKafkaManager {
+func NewReadyKafkaManager(kafkaService services.KafkaService, keycloakConfig *keycloak.KeycloakConfig, reconciler workers.Reconciler) *ReadyKafkaManager {
 	return &ReadyKafkaManager{
 		BaseWorker: workers.BaseWorker{
 			Id:         uuid.New().String(),
 			WorkerType: "ready_kafka",
 			Reconciler: reconciler,
 		},
-		kafkaService:    kafkaService,
-		keycloakService: keycloakService,
-		keycloakConfig:  keycloakConfig,
+		kafkaService:   kafkaService,
+		keycloakConfig: keycloakConfig,
 	}
 }
 
@@ -69,39 +61,7 @@ func (k *ReadyKafkaManager) Reconcile() []error {
 		if err := k.kafkaService.ManagedKafkasRoutesTLSCertificate(kafka); err != nil {
 			encounteredErrors = append(encounteredErrors, errors.Wrapf(err, "failed to create ready kafka routes tls certificates%q", kafka.ID))
 		}
-
-		if err := k.reconcileCanaryServiceAccount(kafka); err != nil {
-			encounteredErrors = append(encounteredErrors, errors.Wrapf(err, "failed to create ready kafka canary service account: %q", kafka.ID))
-		}
 	}
 
 	return encounteredErrors
 }
-
-// reconcileCanaryServiceAccount migrates all existing kafkas so that they will have the canary service account created.
-// This is only meant to be a temporary code, in the future it can be replaced with the service account rotation logic.
-func (k *ReadyKafkaManager) reconcileCanaryServiceAccount(kafkaRequest *dbapi.KafkaRequest) error {
-	if kafkaRequest.CanaryServiceAccountClientID == "" && kafkaRequest.CanaryServiceAccountClientSecret == "" {
-		clientId := strings.ToLower(fmt.Sprintf("%s-%s", services.CanaryServiceAccountPrefix, kafkaRequest.ID))
-		serviceAccountRequest := sso.CompleteServiceAccountRequest{
-			Owner:          kafkaRequest.Owner,
-			OwnerAccountId: kafkaRequest.OwnerAccountId,
-			ClientId:       clientId,
-			OrgId:          kafkaRequest.OrganisationId,
-			Name:           fmt.Sprintf("canary-service-account-for-kafka %s", kafkaRequest.ID),
-			Description:    fmt.Sprintf("canary service account for kafka %s", kafkaRequest.ID),
-		}
-
-		serviceAccount, err := k.keycloakService.CreateServiceAccountInternal(serviceAccountRequest)
-		if err != nil {
-			return errors.Wrapf(err, "failed to create canary service account: %s", err.Error())
-		}
-		kafkaRequest.CanaryServiceAccountClientID = serviceAccount.ClientID
-		kafkaRequest.CanaryServiceAccountClientSecret = serviceAccount.ClientSecret
-		if err = k.kafkaService.Update(kafkaRequest); err != nil {
-			return errors.Wrapf(err, "failed to update kafka %s with canary service account details", kafkaRequest.ID)
-		}
-	}
-
-	return nil
-}
@@ -0,0 +1,74 @@
+package actions
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/workers/kafka_mgrs/promotion/chain"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/golang/glog"
+)
+
+var _ chain.ReconcileAction[ResizeContext] = &CheckClusterCapacityAction{}
+
+type CheckClusterCapacityAction struct {
+	clusterService services.ClusterService
+	kafkaConfig    config.KafkaConfig
+}
+
+func NewCheckClusterCapacityAction(kafkaConfig config.KafkaConfig, clusterService services.ClusterService) chain.ReconcileAction[ResizeContext] {
+	return &CheckClusterCapacityAction{
+		clusterService: clusterService,
+		kafkaConfig:    kafkaConfig,
+	}
+}
+
+// PerformJob checks that the data plane cluster of the kafkaRequest has enough capacity left for the kafka to grow
+// to its DesiredSizeId. The streaming units of the current size of the kafka are given back to the cluster as they are
+// counted in the consumed capacity of the cluster.
+func (c *CheckClusterCapacityAction) PerformJob(kafkaRequest *dbapi.KafkaRequest, currentResult chain.ActionResult[ResizeContext]) (chain.ActionResult[ResizeContext], bool, error) {
+	glog.Infof("checking the capacity of cluster with ID '%s' for kafka '%s' to be resized from '%s' to '%s'", kafkaRequest.ClusterID, kafkaRequest.ID, kafkaRequest.SizeId, kafkaRequest.DesiredSizeId)
+	res := chain.ActionResult[ResizeContext]{}
+
+	actualSize, err := c.kafkaConfig.GetKafkaInstanceSize(kafkaRequest.InstanceType, kafkaRequest.SizeId)
+	if err != nil {
+		// actual size was validated at creation stage. This should never happen.
+		return res, true, err
+	}
+	desiredSize, err := c.kafkaConfig.GetKafkaInstanceSize(kafkaRequest.InstanceType, kafkaRequest.DesiredSizeId)
+	if err != nil {
+		// the size could have been removed from the configuration since the resize was requested
+		return res, true, errors.InstancePlanNotSupported("unsupported size %q for instance type %q", kafkaRequest.DesiredSizeId, kafkaRequest.InstanceType)
+	}
+
+	cluster, svcErr := c.clusterService.FindClusterByID(kafkaRequest.ClusterID)
+	if svcErr != nil || cluster == nil {
+		// the cluster is expected to exist: mark the error as recoverable so that the reconciler will keep on retrying
+		return res, true, errors.NewServiceErrorBuilder().
+			Wrap(*errors.GeneralError("failed to get cluster with id: %q", kafkaRequest.ClusterID)).
+			Recoverable().
+			Build()
+	}
+
+	capacityInfo, ok := cluster.RetrieveDynamicCapacityInfo()[kafkaRequest.InstanceType]
+	if !ok {
+		return res, true, errors.GeneralError("instance type %q not supported on cluster %q", kafkaRequest.InstanceType, kafkaRequest.ClusterID)
+	}
+
+	streamingUnitCounts, err := c.clusterService.ComputeConsumedStreamingUnitCountPerInstanceType(kafkaRequest.ClusterID)
+	if err != nil {
+		return res, true, errors.NewServiceErrorBuilder().
+			Wrap(*errors.NewWithCause(errors.ErrorGeneral, err, "failed to compute the consumed capacity of cluster %q", kafkaRequest.ClusterID)).
+			Recoverable().
+			Build()
+	}
+
+	usedCapacity := streamingUnitCounts[types.KafkaInstanceType(kafkaRequest.InstanceType)]
+	futureUsedCapacity := usedCapacity - int64(actualSize.CapacityConsumed) + int64(desiredSize.CapacityConsumed)
+	if futureUsedCapacity > int64(capacityInfo.MaxUnits) {
+		return res, true, errors.TooManyKafkaInstancesReached("cluster %q does not have enough capacity to resize kafka %q to size %q", kafkaRequest.ClusterID, kafkaRequest.ID, kafkaRequest.DesiredSizeId)
+	}
+
+	return res, false, nil
+}
@@ -0,0 +1,49 @@
+package actions
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/workers/kafka_mgrs/promotion/chain"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/golang/glog"
+)
+
+var _ chain.ReconcileAction[ResizeContext] = &ReleaseActualSizeQuotaAction{}
+
+type ReleaseActualSizeQuotaAction struct {
+	quotaServiceFactory services.QuotaServiceFactory
+	kafkaConfig         config.KafkaConfig
+}
+
+func NewReleaseActualSizeQuotaAction(kafkaConfig config.KafkaConfig, quotaServiceFactory services.QuotaServiceFactory) chain.ReconcileAction[ResizeContext] {
+	return &ReleaseActualSizeQuotaAction{
+		quotaServiceFactory: quotaServiceFactory,
+		kafkaConfig:         kafkaConfig,
+	}
+}
+
+// PerformJob releases the quota reserved for the actual size of the kafkaRequest.
+// WARNING: It is expected that one of the previous chain ring should populate the `SubscriptionID` field of the result.
+// The quota is not released when the quota of the desired size was reserved in the same subscription. Releasing the
+// quota again, when the resize is retried after a failure of the next action, has no effect.
+func (r *ReleaseActualSizeQuotaAction) PerformJob(kafkaRequest *dbapi.KafkaRequest, currentResult chain.ActionResult[ResizeContext]) (chain.ActionResult[ResizeContext], bool, error) {
+	if kafkaRequest.SubscriptionId == "" || kafkaRequest.SubscriptionId == currentResult.Value().SubscriptionID {
+		return currentResult, false, nil
+	}
+
+	glog.Infof("releasing quota of size '%s' for kafka '%s' (subscription ID: '%s')", kafkaRequest.SizeId, kafkaRequest.ID, kafkaRequest.SubscriptionId)
+	quotaService, factoryErr := r.quotaServiceFactory.GetQuotaService(api.QuotaType(r.kafkaConfig.Quota.Type))
+	if factoryErr != nil {
+		return currentResult, true, errors.NewWithCause(errors.ErrorGeneral, factoryErr, "unable to release quota")
+	}
+
+	if err := quotaService.DeleteQuota(kafkaRequest.SubscriptionId); err != nil {
+		// at this stage we have already reserved the new quota, so we need the reconciler to keep retrying
+		// mark the error as recoverable
+		return currentResult, true, errors.NewServiceErrorBuilder().Wrap(*err).Recoverable().Build()
+	}
+
+	return currentResult, false, nil
+}
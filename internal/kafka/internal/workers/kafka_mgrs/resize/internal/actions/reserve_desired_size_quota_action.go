@@ -0,0 +1,71 @@
+package actions
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/workers/kafka_mgrs/promotion/chain"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/golang/glog"
+)
+
+var _ chain.ReconcileAction[ResizeContext] = &ReserveDesiredSizeQuotaAction{}
+
+type ReserveDesiredSizeQuotaAction struct {
+	quotaServiceFactory services.QuotaServiceFactory
+	kafkaService        services.KafkaService
+	kafkaConfig         config.KafkaConfig
+}
+
+func NewReserveDesiredSizeQuotaAction(kafkaConfig config.KafkaConfig, quotaServiceFactory services.QuotaServiceFactory, kafkaService services.KafkaService) chain.ReconcileAction[ResizeContext] {
+	return &ReserveDesiredSizeQuotaAction{
+		quotaServiceFactory: quotaServiceFactory,
+		kafkaService:        kafkaService,
+		kafkaConfig:         kafkaConfig,
+	}
+}
+
+// PerformJob reserves the quota of the DesiredSizeId of the received kafkaRequest object. The quota of the actual
+// size is still reserved at this stage: it is released by the next action of the chain once the new quota is reserved.
+// The subscription of the new quota is recorded in the ResizeSubscriptionId of the kafkaRequest before going any
+// further, so that a resize retried after a failure of one of the next actions reuses it instead of reserving
+// the quota again.
+func (r *ReserveDesiredSizeQuotaAction) PerformJob(kafkaRequest *dbapi.KafkaRequest, currentResult chain.ActionResult[ResizeContext]) (chain.ActionResult[ResizeContext], bool, error) {
+	res := chain.ActionResult[ResizeContext]{}
+	if kafkaRequest.ResizeSubscriptionId != "" {
+		glog.Infof("quota of size '%s' already reserved for kafka '%s': %s", kafkaRequest.DesiredSizeId, kafkaRequest.ID, kafkaRequest.ResizeSubscriptionId)
+		res.SetValue(ResizeContext{SubscriptionID: kafkaRequest.ResizeSubscriptionId})
+		return res, false, nil
+	}
+
+	glog.Infof("reserving quota of size '%s' for kafka '%s'", kafkaRequest.DesiredSizeId, kafkaRequest.ID)
+	quotaService, factoryErr := r.quotaServiceFactory.GetQuotaService(api.QuotaType(r.kafkaConfig.Quota.Type))
+	if factoryErr != nil {
+		return res, true, errors.NewWithCause(errors.ErrorGeneral, factoryErr, "unable to check quota")
+	}
+
+	// quota reservation methods has side effects that we don't need in this flow
+	// and the quota is reserved for the desired size: work on a copy of `kafkaRequest`
+	kafkaCopy := *kafkaRequest
+	kafkaCopy.SizeId = kafkaRequest.DesiredSizeId
+	kafkaCopy.DesiredKafkaBillingModel = kafkaRequest.ActualKafkaBillingModel
+	subscriptionID, err := quotaService.ReserveQuota(&kafkaCopy)
+	if err != nil {
+		return res, true, err
+	}
+
+	glog.Infof("reserved quota of size '%s' for kafka '%s': %s", kafkaRequest.DesiredSizeId, kafkaRequest.ID, subscriptionID)
+	if subscriptionID != "" && subscriptionID != kafkaRequest.SubscriptionId {
+		if err := r.kafkaService.Updates(kafkaRequest, map[string]any{"resize_subscription_id": subscriptionID}); err != nil {
+			// the reservation would be leaked if it was kept without being recorded
+			if deleteErr := quotaService.DeleteQuota(subscriptionID); deleteErr != nil {
+				glog.Errorf("failed to release the unrecorded quota of size '%s' for kafka '%s' (subscription ID: '%s'): %v", kafkaRequest.DesiredSizeId, kafkaRequest.ID, subscriptionID, deleteErr)
+			}
+			return res, true, errors.NewServiceErrorBuilder().Wrap(*err).Recoverable().Build()
+		}
+		kafkaRequest.ResizeSubscriptionId = subscriptionID
+	}
+	res.SetValue(ResizeContext{SubscriptionID: subscriptionID})
+	return res, false, nil
+}
@@ -0,0 +1,5 @@
+package actions
+
+type ResizeContext struct {
+	SubscriptionID string
+}
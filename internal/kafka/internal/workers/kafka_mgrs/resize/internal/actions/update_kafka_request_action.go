@@ -0,0 +1,51 @@
+package actions
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/workers/kafka_mgrs/promotion/chain"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/golang/glog"
+)
+
+var _ chain.ReconcileAction[ResizeContext] = &UpdateKafkaRequestAction{}
+
+/**************
+ * This action updates the kafka request after a successful resize
+ * WARNING: It is expected that one of the previous chain ring should populate the `SubscriptionID` field of the result
+ * When updating the kafka request, it will:
+ * * Assign the `DesiredSizeId` to `SizeId`: the new size is then sent to the data plane with the managed kafka
+ * * Empty the `DesiredSizeId`, `ResizeStatus`, `ResizeDetails` and `ResizeSubscriptionId` fields
+ * * Update the `SubscriptionId` field
+ */
+type UpdateKafkaRequestAction struct {
+	kafkaService services.KafkaService
+}
+
+func NewUpdateKafkaRequestAction(kafkaService services.KafkaService) chain.ReconcileAction[ResizeContext] {
+	return &UpdateKafkaRequestAction{
+		kafkaService: kafkaService,
+	}
+}
+
+func (u UpdateKafkaRequestAction) PerformJob(kafkaRequest *dbapi.KafkaRequest, currentResult chain.ActionResult[ResizeContext]) (chain.ActionResult[ResizeContext], bool, error) {
+	glog.Infof("kafka with ID '%s' resized from '%s' to '%s'. Updating the database info", kafkaRequest.ID, kafkaRequest.SizeId, kafkaRequest.DesiredSizeId)
+
+	// gorm ignores zero values, so to zero `DesiredSizeId`, `ResizeStatus`, `ResizeDetails` and `ResizeSubscriptionId` we need to use a map
+	updates := map[string]any{}
+	updates["size_id"] = kafkaRequest.DesiredSizeId
+	updates["desired_size_id"] = ""
+	updates["resize_status"] = ""
+	updates["resize_details"] = ""
+	updates["resize_subscription_id"] = ""
+	// current result must have the subscription id at this point
+	updates["subscription_id"] = currentResult.Value().SubscriptionID
+
+	err := u.kafkaService.Updates(kafkaRequest, updates)
+	if err != nil {
+		// we need to mark the error as recoverable so that the reconciler will keep on retrying
+		return currentResult, true, errors.NewServiceErrorBuilder().Wrap(*err).Recoverable().Build()
+	}
+
+	return currentResult, false, nil
+}
@@ -0,0 +1,132 @@
+package resize
+
+import (
+	"fmt"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/workers/kafka_mgrs/promotion/chain"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/workers/kafka_mgrs/resize/internal/actions"
+	apiErrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// ResizeKafkaManager resizes the kafkas whose size has been changed by the users. The new size is sent to the data
+// plane with the managed kafka once the quota of the new size has been reserved.
+type ResizeKafkaManager struct {
+	workers.BaseWorker
+	kafkaService        services.KafkaService
+	clusterService      services.ClusterService
+	quotaServiceFactory services.QuotaServiceFactory
+	kafkaConfig         *config.KafkaConfig
+}
+
+func NewResizeKafkaManager(reconciler workers.Reconciler,
+	kafkaService services.KafkaService,
+	clusterService services.ClusterService,
+	kafkaConfig *config.KafkaConfig,
+	quotaServiceFactory services.QuotaServiceFactory) *ResizeKafkaManager {
+	return &ResizeKafkaManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "resizing_kafka",
+			Reconciler: reconciler,
+		},
+		kafkaService:        kafkaService,
+		clusterService:      clusterService,
+		quotaServiceFactory: quotaServiceFactory,
+		kafkaConfig:         kafkaConfig,
+	}
+}
+
+// Start initializes the kafka manager to reconcile kafka requests to be resized.
+func (k *ResizeKafkaManager) Start() {
+	k.StartWorker(k)
+}
+
+// Stop causes the process for reconciling kafka requests to be resized to stop.
+func (k *ResizeKafkaManager) Stop() {
+	k.StopWorker(k)
+}
+
+// updateFailedResizeDetails records the error of a failed resize attempt. The resize is retried when the error is
+// recoverable. Otherwise it is aborted so that the quota reserved for the desired size, if any, is released.
+func (k *ResizeKafkaManager) updateFailedResizeDetails(kafkaRequest *dbapi.KafkaRequest, resizeError error) error {
+	if serviceError, ok := resizeError.(*apiErrors.ServiceError); !ok || !serviceError.Recoverable() {
+		if err := k.kafkaService.AbortResize(kafkaRequest, resizeError.Error()); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	kafkaRequest.ResizeDetails = resizeError.Error()
+	kafkaRequest.ResizeStatus = dbapi.KafkaResizeStatusResizing
+
+	// only the resize fields are updated so that the failure of the resize is recorded in the history of the kafka
+	err := k.kafkaService.Updates(kafkaRequest, map[string]interface{}{
+		"resize_status":  kafkaRequest.ResizeStatus,
+		"resize_details": kafkaRequest.ResizeDetails,
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (k *ResizeKafkaManager) Reconcile() []error {
+	glog.Infoln("reconciling kafkas to be resized")
+	kafkasToResize, err := k.kafkaService.ListKafkasToBeResized()
+	if err != nil {
+		return []error{errors.Wrap(err, "failed to list kafkas to resize")}
+	}
+	glog.Infof("found %d kafkas to resize", len(kafkasToResize))
+
+	var resizeErrors apiErrors.ErrorList
+
+	for _, kafka := range kafkasToResize {
+		// the quota reserved for the resize of a kafka which is no longer ready would otherwise be leaked
+		if kafka.Status != constants.KafkaRequestStatusReady.String() {
+			glog.Infof("aborting the resize of kafka with ID '%s' as its status is '%s'", kafka.ID, kafka.Status)
+			if err := k.kafkaService.AbortResize(kafka, fmt.Sprintf("the resize has been aborted as the status of the kafka instance is %q", kafka.Status)); err != nil {
+				resizeErrors.AddErrors(errors.Wrapf(err, "failed to abort the resize of kafka with id '%s'", kafka.ID))
+			}
+			continue
+		}
+
+		desiredSizeId := kafka.DesiredSizeId
+		subscriptionID, resizeError := k.resize(kafka)
+
+		if resizeError != nil {
+			resizeErrors.AddErrors(errors.Wrapf(resizeError, "failed to resize kafka with id '%s'", kafka.ID))
+			glog.Errorf("failed resizing kafka with ID '%s' : %s", kafka.ID, resizeError.Error())
+			if err := k.updateFailedResizeDetails(kafka, resizeError); err != nil {
+				// log the error
+				glog.Errorf("failed saving resize error details for kafka '%s' into the database: %s", kafka.ID, err.Error())
+			}
+		} else {
+			glog.Infof("kafka with ID '%s' resized to '%s'. New subscription ID: %s", kafka.ID, desiredSizeId, subscriptionID)
+		}
+	}
+
+	return resizeErrors.ToErrorSlice()
+}
+
+func (k *ResizeKafkaManager) resize(kafka *dbapi.KafkaRequest) (string, error) {
+	// setup pipeline
+	resizeChain := chain.NewReconcileActionRunner(
+		actions.NewCheckClusterCapacityAction(*k.kafkaConfig, k.clusterService),
+		actions.NewReserveDesiredSizeQuotaAction(*k.kafkaConfig, k.quotaServiceFactory, k.kafkaService),
+		actions.NewReleaseActualSizeQuotaAction(*k.kafkaConfig, k.quotaServiceFactory),
+		actions.NewUpdateKafkaRequestAction(k.kafkaService),
+	)
+	res, err := resizeChain.Run(kafka)
+	if err != nil {
+		return "", err
+	}
+	return res.SubscriptionID, nil
+}
@@ -0,0 +1,350 @@
+package resize
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/kafkas/types"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/onsi/gomega"
+)
+
+func TestResizeKafkaManager_Reconcile(t *testing.T) {
+	kafkaConfig := config.KafkaConfig{
+		Quota: &config.KafkaQuotaConfig{
+			Type: api.AMSQuotaType.String(),
+		},
+		SupportedInstanceTypes: &config.KafkaSupportedInstanceTypesConfig{
+			Configuration: config.SupportedKafkaInstanceTypesConfig{
+				SupportedKafkaInstanceTypes: []config.KafkaInstanceType{
+					{
+						Id:          types.STANDARD.String(),
+						DisplayName: "Standard",
+						Sizes: []config.KafkaInstanceSize{
+							{Id: "x1", CapacityConsumed: 1, QuotaConsumed: 1},
+							{Id: "x2", CapacityConsumed: 2, QuotaConsumed: 2},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	kafkaToResize := func(modifyFn func(kafka *dbapi.KafkaRequest)) *dbapi.KafkaRequest {
+		kafka := &dbapi.KafkaRequest{
+			Meta:                    api.Meta{ID: "123-kafka"},
+			ClusterID:               "cluster-id",
+			Status:                  constants.KafkaRequestStatusReady.String(),
+			InstanceType:            types.STANDARD.String(),
+			SizeId:                  "x1",
+			DesiredSizeId:           "x2",
+			ResizeStatus:            dbapi.KafkaResizeStatusResizing,
+			SubscriptionId:          "old-subscription-id",
+			ActualKafkaBillingModel: "standard",
+		}
+		if modifyFn != nil {
+			modifyFn(kafka)
+		}
+		return kafka
+	}
+
+	kafkaServiceToResize := func(kafka *dbapi.KafkaRequest, updatesErr *errors.ServiceError) *services.KafkaServiceMock {
+		return &services.KafkaServiceMock{
+			ListKafkasToBeResizedFunc: func() ([]*dbapi.KafkaRequest, *errors.ServiceError) {
+				return []*dbapi.KafkaRequest{kafka}, nil
+			},
+			UpdatesFunc: func(kafkaRequest *dbapi.KafkaRequest, values map[string]interface{}) *errors.ServiceError {
+				return updatesErr
+			},
+			AbortResizeFunc: func(kafkaRequest *dbapi.KafkaRequest, reason string) *errors.ServiceError {
+				return nil
+			},
+		}
+	}
+
+	clusterServiceWithConsumedUnits := func(maxUnits int32, consumedUnits int64) *services.ClusterServiceMock {
+		return &services.ClusterServiceMock{
+			FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+				cluster := &api.Cluster{ClusterID: clusterID}
+				_ = cluster.SetDynamicCapacityInfo(map[string]api.DynamicCapacityInfo{
+					types.STANDARD.String(): {MaxUnits: maxUnits},
+				})
+				return cluster, nil
+			},
+			ComputeConsumedStreamingUnitCountPerInstanceTypeFunc: func(clusterID string) (services.StreamingUnitCountPerInstanceType, error) {
+				return services.StreamingUnitCountPerInstanceType{types.STANDARD: consumedUnits}, nil
+			},
+		}
+	}
+
+	quotaServiceReservingIn := func(subscriptionId string, deleteErr *errors.ServiceError) *services.QuotaServiceMock {
+		return &services.QuotaServiceMock{
+			ReserveQuotaFunc: func(kafka *dbapi.KafkaRequest) (string, *errors.ServiceError) {
+				return subscriptionId, nil
+			},
+			DeleteQuotaFunc: func(subscriptionId string) *errors.ServiceError {
+				return deleteErr
+			},
+		}
+	}
+
+	type fields struct {
+		kafkaService   *services.KafkaServiceMock
+		clusterService *services.ClusterServiceMock
+		quotaService   *services.QuotaServiceMock
+	}
+	type expect struct {
+		reserveQuotaCalls    int
+		deletedSubscriptions []string
+		// recordedSubscription is the subscription recorded in the kafka once the quota of the new size is reserved
+		recordedSubscription string
+		// resizedSubscription is the subscription of the kafka once resized, empty if the kafka is not resized
+		resizedSubscription string
+		// failedResizeStatus is the resize status recorded with the details of a failed attempt, empty if the resize
+		// did not fail
+		failedResizeStatus dbapi.KafkaResizeStatus
+		abortResizeCalls   int
+		// abortedResizeSubscription is the subscription reserved for the desired size to be released when the resize
+		// is aborted
+		abortedResizeSubscription string
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		wantErrCount int
+		expect       expect
+	}{
+		{
+			name: "should do nothing if there is no kafka to resize",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					ListKafkasToBeResizedFunc: func() ([]*dbapi.KafkaRequest, *errors.ServiceError) {
+						return nil, nil
+					},
+				},
+				clusterService: &services.ClusterServiceMock{},
+				quotaService:   &services.QuotaServiceMock{},
+			},
+			wantErrCount: 0,
+		},
+		{
+			name: "should resize the kafka when the cluster has enough capacity and the quota is reserved",
+			fields: fields{
+				kafkaService:   kafkaServiceToResize(kafkaToResize(nil), nil),
+				clusterService: clusterServiceWithConsumedUnits(5, 4),
+				quotaService:   quotaServiceReservingIn("subscription-id", nil),
+			},
+			wantErrCount: 0,
+			expect: expect{
+				reserveQuotaCalls:    1,
+				deletedSubscriptions: []string{"old-subscription-id"},
+				recordedSubscription: "subscription-id",
+				resizedSubscription:  "subscription-id",
+			},
+		},
+		{
+			name: "should fail the resize when the cluster does not have enough capacity",
+			fields: fields{
+				kafkaService:   kafkaServiceToResize(kafkaToResize(nil), nil),
+				clusterService: clusterServiceWithConsumedUnits(5, 5),
+				quotaService:   &services.QuotaServiceMock{},
+			},
+			wantErrCount: 1,
+			expect: expect{
+				abortResizeCalls: 1,
+			},
+		},
+		{
+			name: "should abort the resize and release the quota reserved by a previous attempt when the resize fails",
+			fields: fields{
+				kafkaService: kafkaServiceToResize(kafkaToResize(func(kafka *dbapi.KafkaRequest) {
+					kafka.ResizeSubscriptionId = "subscription-id"
+				}), nil),
+				clusterService: clusterServiceWithConsumedUnits(5, 5),
+				quotaService:   &services.QuotaServiceMock{},
+			},
+			wantErrCount: 1,
+			expect: expect{
+				abortResizeCalls:          1,
+				abortedResizeSubscription: "subscription-id",
+			},
+		},
+		{
+			name: "should fail the resize when the quota of the new size cannot be reserved",
+			fields: fields{
+				kafkaService:   kafkaServiceToResize(kafkaToResize(nil), nil),
+				clusterService: clusterServiceWithConsumedUnits(5, 4),
+				quotaService: &services.QuotaServiceMock{
+					ReserveQuotaFunc: func(kafka *dbapi.KafkaRequest) (string, *errors.ServiceError) {
+						return "", errors.InsufficientQuotaError("insufficient quota")
+					},
+				},
+			},
+			wantErrCount: 1,
+			expect: expect{
+				reserveQuotaCalls: 1,
+				abortResizeCalls:  1,
+			},
+		},
+		{
+			name: "should release the quota of the new size and keep on resizing when its subscription cannot be recorded",
+			fields: fields{
+				kafkaService:   kafkaServiceToResize(kafkaToResize(nil), errors.GeneralError("failed to update the kafka")),
+				clusterService: clusterServiceWithConsumedUnits(5, 4),
+				quotaService:   quotaServiceReservingIn("subscription-id", nil),
+			},
+			wantErrCount: 1,
+			expect: expect{
+				reserveQuotaCalls:    1,
+				deletedSubscriptions: []string{"subscription-id"},
+				recordedSubscription: "subscription-id",
+				failedResizeStatus:   dbapi.KafkaResizeStatusResizing,
+			},
+		},
+		{
+			name: "should keep on resizing when the quota of the actual size cannot be released",
+			fields: fields{
+				kafkaService:   kafkaServiceToResize(kafkaToResize(nil), nil),
+				clusterService: clusterServiceWithConsumedUnits(5, 4),
+				quotaService:   quotaServiceReservingIn("subscription-id", errors.GeneralError("failed to delete the quota")),
+			},
+			wantErrCount: 1,
+			expect: expect{
+				reserveQuotaCalls:    1,
+				deletedSubscriptions: []string{"old-subscription-id"},
+				recordedSubscription: "subscription-id",
+				failedResizeStatus:   dbapi.KafkaResizeStatusResizing,
+			},
+		},
+		{
+			name: "should not reserve the quota again when it has been reserved by a previous attempt",
+			fields: fields{
+				kafkaService: kafkaServiceToResize(kafkaToResize(func(kafka *dbapi.KafkaRequest) {
+					kafka.ResizeSubscriptionId = "subscription-id"
+				}), nil),
+				clusterService: clusterServiceWithConsumedUnits(5, 4),
+				quotaService:   quotaServiceReservingIn("another-subscription-id", nil),
+			},
+			wantErrCount: 0,
+			expect: expect{
+				deletedSubscriptions: []string{"old-subscription-id"},
+				resizedSubscription:  "subscription-id",
+			},
+		},
+		{
+			name: "should not release the quota when the new quota is reserved in the same subscription",
+			fields: fields{
+				kafkaService:   kafkaServiceToResize(kafkaToResize(nil), nil),
+				clusterService: clusterServiceWithConsumedUnits(5, 4),
+				quotaService:   quotaServiceReservingIn("old-subscription-id", nil),
+			},
+			wantErrCount: 0,
+			expect: expect{
+				reserveQuotaCalls:   1,
+				resizedSubscription: "old-subscription-id",
+			},
+		},
+		{
+			name: "should abort the resize of the kafka when it is no longer ready",
+			fields: fields{
+				kafkaService: kafkaServiceToResize(kafkaToResize(func(kafka *dbapi.KafkaRequest) {
+					kafka.Status = constants.KafkaRequestStatusSuspended.String()
+					kafka.ResizeSubscriptionId = "subscription-id"
+				}), nil),
+				clusterService: clusterServiceWithConsumedUnits(5, 4),
+				quotaService:   quotaServiceReservingIn("another-subscription-id", nil),
+			},
+			wantErrCount: 0,
+			expect: expect{
+				abortResizeCalls: 1,
+			},
+		},
+		{
+			name: "should return an error when the resize of a kafka which is no longer ready cannot be aborted",
+			fields: fields{
+				kafkaService: &services.KafkaServiceMock{
+					ListKafkasToBeResizedFunc: func() ([]*dbapi.KafkaRequest, *errors.ServiceError) {
+						return []*dbapi.KafkaRequest{kafkaToResize(func(kafka *dbapi.KafkaRequest) {
+							kafka.Status = constants.KafkaRequestStatusFailed.String()
+						})}, nil
+					},
+					AbortResizeFunc: func(kafkaRequest *dbapi.KafkaRequest, reason string) *errors.ServiceError {
+						return errors.GeneralError("failed to release the quota")
+					},
+				},
+				clusterService: &services.ClusterServiceMock{},
+				quotaService:   &services.QuotaServiceMock{},
+			},
+			wantErrCount: 1,
+			expect: expect{
+				abortResizeCalls: 1,
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			k := NewResizeKafkaManager(
+				workers.Reconciler{},
+				tt.fields.kafkaService,
+				tt.fields.clusterService,
+				&kafkaConfig,
+				&services.QuotaServiceFactoryMock{
+					GetQuotaServiceFunc: func(quotaType api.QuotaType) (services.QuotaService, *errors.ServiceError) {
+						return tt.fields.quotaService, nil
+					},
+				},
+			)
+
+			errs := k.Reconcile()
+			g.Expect(errs).To(gomega.HaveLen(tt.wantErrCount))
+
+			g.Expect(tt.fields.kafkaService.ListKafkasToBeResizedCalls()).To(gomega.HaveLen(1))
+			g.Expect(tt.fields.quotaService.ReserveQuotaCalls()).To(gomega.HaveLen(tt.expect.reserveQuotaCalls))
+			if tt.expect.reserveQuotaCalls > 0 {
+				g.Expect(tt.fields.quotaService.ReserveQuotaCalls()[0].Kafka.SizeId).To(gomega.Equal("x2"))
+			}
+			var deletedSubscriptions []string
+			for _, call := range tt.fields.quotaService.DeleteQuotaCalls() {
+				deletedSubscriptions = append(deletedSubscriptions, call.SubscriptionId)
+			}
+			g.Expect(deletedSubscriptions).To(gomega.Equal(tt.expect.deletedSubscriptions))
+
+			updatesCalls := tt.fields.kafkaService.UpdatesCalls()
+			if tt.expect.recordedSubscription != "" {
+				g.Expect(updatesCalls).ToNot(gomega.BeEmpty())
+				g.Expect(updatesCalls[0].Values).To(gomega.Equal(map[string]interface{}{"resize_subscription_id": tt.expect.recordedSubscription}))
+				updatesCalls = updatesCalls[1:]
+			}
+			if tt.expect.resizedSubscription != "" {
+				g.Expect(updatesCalls).To(gomega.HaveLen(1))
+				values := updatesCalls[0].Values
+				g.Expect(values["size_id"]).To(gomega.Equal("x2"))
+				g.Expect(values["desired_size_id"]).To(gomega.Equal(""))
+				g.Expect(values["resize_status"]).To(gomega.Equal(""))
+				g.Expect(values["resize_subscription_id"]).To(gomega.Equal(""))
+				g.Expect(values["subscription_id"]).To(gomega.Equal(tt.expect.resizedSubscription))
+			} else if tt.expect.failedResizeStatus != "" {
+				g.Expect(updatesCalls).To(gomega.HaveLen(1))
+				values := updatesCalls[0].Values
+				g.Expect(values["resize_status"]).To(gomega.Equal(tt.expect.failedResizeStatus))
+				g.Expect(values["resize_details"]).ToNot(gomega.BeEmpty())
+			} else {
+				g.Expect(updatesCalls).To(gomega.BeEmpty())
+			}
+			g.Expect(tt.fields.kafkaService.AbortResizeCalls()).To(gomega.HaveLen(tt.expect.abortResizeCalls))
+			if tt.expect.abortedResizeSubscription != "" {
+				g.Expect(tt.fields.kafkaService.AbortResizeCalls()[0].KafkaRequest.ResizeSubscriptionId).To(gomega.Equal(tt.expect.abortedResizeSubscription))
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package kafka_mgrs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	serviceErr "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// UpgradeCampaignManager advances the upgrade campaigns in progress. The kafkas of a campaign are upgraded in waves:
+// a new wave is started once all the kafkas of the previous wave have either finished upgrading, failed or been
+// skipped. The campaign is paused when the number of kafkas that failed to upgrade reaches its failure threshold or
+// when the kafkas of a wave do not finish upgrading within the wave timeout once their upgrade has been released to
+// the data plane.
+type UpgradeCampaignManager struct {
+	workers.BaseWorker
+	upgradeCampaignService   services.UpgradeCampaignService
+	kafkaService             services.KafkaService
+	clusterService           services.ClusterService
+	maintenanceWindowService services.MaintenanceWindowService
+	kafkaConfig              *config.KafkaConfig
+}
+
+var _ workers.Worker = &UpgradeCampaignManager{}
+
+func NewUpgradeCampaignManager(upgradeCampaignService services.UpgradeCampaignService, kafkaService services.KafkaService,
+	clusterService services.ClusterService, maintenanceWindowService services.MaintenanceWindowService, kafkaConfig *config.KafkaConfig,
+	reconciler workers.Reconciler) *UpgradeCampaignManager {
+	return &UpgradeCampaignManager{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "upgrade_campaign",
+			Reconciler: reconciler,
+		},
+		upgradeCampaignService:   upgradeCampaignService,
+		kafkaService:             kafkaService,
+		clusterService:           clusterService,
+		maintenanceWindowService: maintenanceWindowService,
+		kafkaConfig:              kafkaConfig,
+	}
+}
+
+func (k *UpgradeCampaignManager) Start() {
+	k.StartWorker(k)
+}
+
+func (k *UpgradeCampaignManager) Stop() {
+	k.StopWorker(k)
+}
+
+func (k *UpgradeCampaignManager) Reconcile() []error {
+	glog.Infoln("reconciling the upgrade campaigns in progress")
+	var errs []error
+
+	campaigns, listErr := k.upgradeCampaignService.ListByStatus(dbapi.UpgradeCampaignStatusInProgress)
+	if listErr != nil {
+		return []error{errors.Wrap(listErr, "failed to list the upgrade campaigns in progress")}
+	}
+	glog.Infof("upgrade campaigns in progress count = %d", len(campaigns))
+
+	for _, campaign := range campaigns {
+		if err := k.reconcileCampaign(campaign); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to reconcile upgrade campaign %q", campaign.ID))
+		}
+	}
+
+	return errs
+}
+
+func (k *UpgradeCampaignManager) reconcileCampaign(campaign *dbapi.UpgradeCampaign) *serviceErr.ServiceError {
+	upgradingKafkas, err := k.upgradeCampaignService.ListKafkas(campaign.ID, dbapi.UpgradeCampaignKafkaStatusUpgrading)
+	if err != nil {
+		return err
+	}
+
+	waveFinished := true
+	timeout := k.kafkaConfig.UpgradeCampaignWaveTimeout
+	timedOut := 0
+	for _, campaignKafka := range upgradingKafkas {
+		kafka, finished, err := k.updateCampaignKafka(campaign, campaignKafka)
+		if err != nil {
+			return err
+		}
+		if !finished && timeout > 0 {
+			releasedAt, err := k.upgradeReleasedAt(campaignKafka, kafka)
+			if err != nil {
+				return err
+			}
+			if releasedAt != nil && time.Since(*releasedAt) > timeout {
+				campaignKafka.Status = dbapi.UpgradeCampaignKafkaStatusFailed
+				campaignKafka.Reason = fmt.Sprintf("the upgrade did not finish within the wave timeout of %s", timeout)
+				glog.Infof("kafka %q of upgrade campaign %q is %s: %s", campaignKafka.KafkaId, campaign.ID, campaignKafka.Status, campaignKafka.Reason)
+				if err := k.upgradeCampaignService.UpdateKafka(campaignKafka); err != nil {
+					return err
+				}
+				finished = true
+				timedOut++
+			}
+		}
+		waveFinished = waveFinished && finished
+	}
+
+	kafkaCounts, err := k.upgradeCampaignService.CountKafkasByStatus(campaign.ID)
+	if err != nil {
+		return err
+	}
+	// the kafkas which failed before the campaign was last resumed have been looked into by an administrator
+	if failed := kafkaCounts[dbapi.UpgradeCampaignKafkaStatusFailed] - campaign.FailedKafkasAtResume; failed >= campaign.FailureThreshold {
+		reason := fmt.Sprintf("%d kafkas failed to upgrade, reaching the failure threshold of %d", failed, campaign.FailureThreshold)
+		glog.Infof("pausing upgrade campaign %q: %s", campaign.ID, reason)
+		return k.upgradeCampaignService.Updates(campaign, map[string]interface{}{
+			"status":        dbapi.UpgradeCampaignStatusPaused,
+			"status_reason": reason,
+		})
+	}
+
+	// the next wave is only started once the timed out kafkas have been looked into and the campaign resumed
+	if timedOut > 0 {
+		reason := fmt.Sprintf("%d kafkas of wave %d did not finish upgrading within the wave timeout of %s", timedOut, campaign.CurrentWave, timeout)
+		glog.Infof("pausing upgrade campaign %q: %s", campaign.ID, reason)
+		return k.upgradeCampaignService.Updates(campaign, map[string]interface{}{
+			"status":        dbapi.UpgradeCampaignStatusPaused,
+			"status_reason": reason,
+		})
+	}
+
+	if !waveFinished {
+		glog.Infof("wave %d of upgrade campaign %q is in progress", campaign.CurrentWave, campaign.ID)
+		return nil
+	}
+
+	return k.startWave(campaign)
+}
+
+// updateCampaignKafka updates the status of a kafka of the ongoing wave of the campaign. It returns the kafka, nil if
+// it has been deleted, and true when the kafka is no longer upgrading.
+func (k *UpgradeCampaignManager) updateCampaignKafka(campaign *dbapi.UpgradeCampaign, campaignKafka *dbapi.UpgradeCampaignKafka) (*dbapi.KafkaRequest, bool, *serviceErr.ServiceError) {
+	kafka, err := k.kafkaService.GetByID(campaignKafka.KafkaId)
+	if err != nil && !err.Is404() {
+		return nil, false, err
+	}
+
+	switch {
+	case kafka == nil || kafka.Status == constants.KafkaRequestStatusDeprovision.String() || kafka.Status == constants.KafkaRequestStatusDeleting.String():
+		campaignKafka.Status = dbapi.UpgradeCampaignKafkaStatusSkipped
+		campaignKafka.Reason = "the kafka has been deleted"
+	case kafka.Status == constants.KafkaRequestStatusFailed.String():
+		campaignKafka.Status = dbapi.UpgradeCampaignKafkaStatusFailed
+		campaignKafka.Reason = kafka.FailedReason
+	case !campaign.IsTargetOf(kafka):
+		campaignKafka.Status = dbapi.UpgradeCampaignKafkaStatusSkipped
+		campaignKafka.Reason = "the desired versions of the kafka have been changed outside of the campaign"
+	case campaign.IsUpgradeFinished(kafka):
+		campaignKafka.Status = dbapi.UpgradeCampaignKafkaStatusUpgraded
+		campaignKafka.Reason = ""
+	default:
+		return kafka, false, nil
+	}
+
+	glog.Infof("kafka %q of upgrade campaign %q is %s", campaignKafka.KafkaId, campaign.ID, campaignKafka.Status)
+	if err := k.upgradeCampaignService.UpdateKafka(campaignKafka); err != nil {
+		return nil, false, err
+	}
+	return kafka, true, nil
+}
+
+// upgradeReleasedAt returns when the upgrade of the kafka of the campaign has been released to the data plane, nil while
+// it is held until the maintenance window of the kafka: the kafkas waiting for their maintenance window do not count
+// against the wave timeout. The upgrades of the kafkas without a maintenance window are released when they are added
+// to the wave.
+func (k *UpgradeCampaignManager) upgradeReleasedAt(campaignKafka *dbapi.UpgradeCampaignKafka, kafka *dbapi.KafkaRequest) (*time.Time, *serviceErr.ServiceError) {
+	schedule, err := k.maintenanceWindowService.GetKafkaSchedule(kafka)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil || kafka.UpgradeForced {
+		return &campaignKafka.CreatedAt, nil
+	}
+
+	// the upgrade is released in the first maintenance window still open once the kafka has been added to the wave
+	if kafka.UpgradeReleasedAt == nil || !kafka.UpgradeReleasedAt.Add(k.kafkaConfig.MaintenanceWindowDuration).After(campaignKafka.CreatedAt) {
+		return nil, nil
+	}
+	if kafka.UpgradeReleasedAt.Before(campaignKafka.CreatedAt) {
+		return &campaignKafka.CreatedAt, nil
+	}
+	return kafka.UpgradeReleasedAt, nil
+}
+
+// startWave sets the target versions of the campaign on at most BatchSize kafkas. The kafkas the target versions are
+// not compatible with are skipped and do not count against the size of the wave. The campaign is completed when no
+// kafka is left to upgrade.
+func (k *UpgradeCampaignManager) startWave(campaign *dbapi.UpgradeCampaign) *serviceErr.ServiceError {
+	wave := campaign.CurrentWave + 1
+	started := 0
+
+	for started < campaign.BatchSize {
+		kafkas, err := k.upgradeCampaignService.FindCandidateKafkas(campaign, campaign.BatchSize-started)
+		if err != nil {
+			return err
+		}
+		if len(kafkas) == 0 {
+			break
+		}
+
+		for _, kafka := range kafkas {
+			campaignKafka := &dbapi.UpgradeCampaignKafka{
+				CampaignId: campaign.ID,
+				KafkaId:    kafka.ID,
+				ClusterId:  kafka.ClusterID,
+				Wave:       wave,
+				Status:     dbapi.UpgradeCampaignKafkaStatusUpgrading,
+			}
+
+			strimziVersion, kafkaVersion, kafkaIBPVersion := campaign.TargetVersions(kafka)
+			skipReason, err := k.findIncompatibility(kafka, strimziVersion, kafkaVersion, kafkaIBPVersion)
+			if err != nil {
+				return err
+			}
+
+			if skipReason != "" {
+				campaignKafka.Status = dbapi.UpgradeCampaignKafkaStatusSkipped
+				campaignKafka.Reason = skipReason
+				if err := k.upgradeCampaignService.RecordKafka(campaignKafka); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := k.upgradeCampaignService.StartKafkaUpgrade(campaignKafka, strimziVersion, kafkaVersion, kafkaIBPVersion); err != nil {
+				return err
+			}
+			if campaignKafka.Status == dbapi.UpgradeCampaignKafkaStatusUpgrading {
+				started++
+			}
+		}
+	}
+
+	if started == 0 {
+		glog.Infof("upgrade campaign %q has no kafka left to upgrade", campaign.ID)
+		return k.upgradeCampaignService.Updates(campaign, map[string]interface{}{
+			"status":        dbapi.UpgradeCampaignStatusCompleted,
+			"status_reason": "",
+		})
+	}
+
+	glog.Infof("started wave %d of upgrade campaign %q with %d kafkas", wave, campaign.ID, started)
+	return k.upgradeCampaignService.Updates(campaign, map[string]interface{}{"current_wave": wave})
+}
+
+// findIncompatibility returns the reason why the kafka cannot be upgraded to the given versions, an empty string if
+// it can. The versions are checked as when an administrator changes the versions of the kafka: e.g. the kafkas already
+// running a newer version than the target versions of the campaign are not downgraded.
+func (k *UpgradeCampaignManager) findIncompatibility(kafka *dbapi.KafkaRequest, strimziVersion, kafkaVersion, kafkaIBPVersion string) (string, *serviceErr.ServiceError) {
+	if err := services.ValidateKafkaVersionsCompatibility(k.clusterService, kafka, strimziVersion, kafkaVersion, kafkaIBPVersion); err != nil {
+		if err.Code != serviceErr.ErrorValidation {
+			return "", err
+		}
+		return err.Reason, nil
+	}
+	return "", nil
+}
@@ -0,0 +1,309 @@
+package kafka_mgrs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/constants"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/api/dbapi"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/config"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	w "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+
+	"github.com/onsi/gomega"
+
+	mockClusters "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/clusters"
+	mockKafkas "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/test/mocks/kafkas"
+)
+
+func TestUpgradeCampaignManager_Reconcile(t *testing.T) {
+	campaign := func(failedKafkasAtResume int) *dbapi.UpgradeCampaign {
+		return &dbapi.UpgradeCampaign{
+			Meta:                 api.Meta{ID: "campaign-id"},
+			KafkaVersion:         "3.4.0",
+			BatchSize:            2,
+			FailureThreshold:     1,
+			FailedKafkasAtResume: failedKafkasAtResume,
+			Status:               dbapi.UpgradeCampaignStatusInProgress,
+			CurrentWave:          1,
+		}
+	}
+	kafka := func(id string, modifyFn func(kafka *dbapi.KafkaRequest)) *dbapi.KafkaRequest {
+		return mockKafkas.BuildKafkaRequest(func(kafka *dbapi.KafkaRequest) {
+			kafka.ID = id
+			kafka.ClusterID = "cluster-id"
+			kafka.Status = constants.KafkaRequestStatusReady.String()
+			kafka.DesiredStrimziVersion = "strimzi-cluster-operator.v0.32.0-1"
+			kafka.ActualStrimziVersion = "strimzi-cluster-operator.v0.32.0-1"
+			kafka.DesiredKafkaVersion = "3.3.1"
+			kafka.ActualKafkaVersion = "3.3.1"
+			kafka.DesiredKafkaIBPVersion = "3.3"
+			kafka.ActualKafkaIBPVersion = "3.3"
+			modifyFn(kafka)
+		})
+	}
+	upgrading := func(kafka *dbapi.KafkaRequest) {
+		kafka.DesiredKafkaVersion = "3.4.0"
+		kafka.KafkaUpgrading = true
+	}
+	upgraded := func(kafka *dbapi.KafkaRequest) {
+		kafka.DesiredKafkaVersion = "3.4.0"
+		kafka.ActualKafkaVersion = "3.4.0"
+	}
+	released := func(releasedAt time.Time) func(kafka *dbapi.KafkaRequest) {
+		return func(kafka *dbapi.KafkaRequest) {
+			upgrading(kafka)
+			kafka.UpgradeReleasedAt = &releasedAt
+		}
+	}
+	schedule, _ := dbapi.NewMaintenanceWindowSchedule("monday", 0)
+	waveKafka := &dbapi.UpgradeCampaignKafka{ID: "campaign-kafka-id", CampaignId: "campaign-id", KafkaId: "kafka-1", Wave: 1, Status: dbapi.UpgradeCampaignKafkaStatusUpgrading}
+
+	tests := []struct {
+		name string
+		// kafkas are the kafkas returned by the kafka service indexed by id
+		kafkas map[string]*dbapi.KafkaRequest
+		// candidates are returned by the successive calls to FindCandidateKafkas
+		candidates []dbapi.KafkaList
+		// waveStartedAt is when the kafka of the current wave has been recorded, now when not set
+		waveStartedAt time.Time
+		waveTimeout   time.Duration
+		// schedule is the maintenance window of the kafkas, none when not set
+		schedule    *dbapi.MaintenanceWindowSchedule
+		startErr    *errors.ServiceError
+		listErr     *errors.ServiceError
+		failedCount int
+		// failedAtResume is the number of kafkas that had failed when the campaign was resumed
+		failedAtResume    int
+		compatibleKafkaId string
+		wantErrCount      int
+		wantKafkaStatus   dbapi.UpgradeCampaignKafkaStatus
+		wantRecorded      map[string]dbapi.UpgradeCampaignKafkaStatus
+		wantStartedCount  int
+		wantCampaignValue map[string]interface{}
+	}{
+		{
+			name:         "should return an error when the campaigns in progress cannot be listed",
+			listErr:      errors.GeneralError("test"),
+			wantErrCount: 1,
+		},
+		{
+			name:   "should not start a new wave while a kafka of the current wave is upgrading",
+			kafkas: map[string]*dbapi.KafkaRequest{"kafka-1": kafka("kafka-1", upgrading)},
+		},
+		{
+			name:              "should start a new wave once the kafkas of the current wave are upgraded and skip the incompatible kafkas",
+			kafkas:            map[string]*dbapi.KafkaRequest{"kafka-1": kafka("kafka-1", upgraded)},
+			candidates:        []dbapi.KafkaList{{kafka("kafka-2", func(kafka *dbapi.KafkaRequest) {}), kafka("kafka-3", func(kafka *dbapi.KafkaRequest) { kafka.ClusterID = "incompatible-cluster-id" })}, {}},
+			compatibleKafkaId: "kafka-2",
+			wantKafkaStatus:   dbapi.UpgradeCampaignKafkaStatusUpgraded,
+			wantRecorded: map[string]dbapi.UpgradeCampaignKafkaStatus{
+				"kafka-2": dbapi.UpgradeCampaignKafkaStatusUpgrading,
+				"kafka-3": dbapi.UpgradeCampaignKafkaStatusSkipped,
+			},
+			wantStartedCount:  1,
+			wantCampaignValue: map[string]interface{}{"current_wave": 2},
+		},
+		{
+			name:              "should skip the kafkas the target versions are not compatible with e.g. running a newer kafka version",
+			kafkas:            map[string]*dbapi.KafkaRequest{"kafka-1": kafka("kafka-1", upgraded)},
+			candidates:        []dbapi.KafkaList{{kafka("kafka-2", func(kafka *dbapi.KafkaRequest) {}), kafka("kafka-3", func(kafka *dbapi.KafkaRequest) { kafka.ActualKafkaVersion = "3.5.0" })}, {}},
+			compatibleKafkaId: "kafka-2",
+			wantKafkaStatus:   dbapi.UpgradeCampaignKafkaStatusUpgraded,
+			wantRecorded: map[string]dbapi.UpgradeCampaignKafkaStatus{
+				"kafka-2": dbapi.UpgradeCampaignKafkaStatusUpgrading,
+				"kafka-3": dbapi.UpgradeCampaignKafkaStatusSkipped,
+			},
+			wantStartedCount:  1,
+			wantCampaignValue: map[string]interface{}{"current_wave": 2},
+		},
+		{
+			name:              "should skip the kafkas whose desired versions have been changed outside of the campaign",
+			kafkas:            map[string]*dbapi.KafkaRequest{"kafka-1": kafka("kafka-1", func(kafka *dbapi.KafkaRequest) { kafka.DesiredKafkaVersion = "3.5.0" })},
+			candidates:        []dbapi.KafkaList{{}},
+			wantKafkaStatus:   dbapi.UpgradeCampaignKafkaStatusSkipped,
+			wantCampaignValue: map[string]interface{}{"status": dbapi.UpgradeCampaignStatusCompleted, "status_reason": ""},
+		},
+		{
+			name: "should pause the campaign when the failure threshold is reached",
+			kafkas: map[string]*dbapi.KafkaRequest{"kafka-1": kafka("kafka-1", func(kafka *dbapi.KafkaRequest) {
+				kafka.Status = constants.KafkaRequestStatusFailed.String()
+			})},
+			failedCount:     1,
+			wantKafkaStatus: dbapi.UpgradeCampaignKafkaStatusFailed,
+			wantCampaignValue: map[string]interface{}{
+				"status":        dbapi.UpgradeCampaignStatusPaused,
+				"status_reason": "1 kafkas failed to upgrade, reaching the failure threshold of 1",
+			},
+		},
+		{
+			name:   "should not pause again a resumed campaign because of the kafkas which failed before it was resumed",
+			kafkas: map[string]*dbapi.KafkaRequest{"kafka-1": kafka("kafka-1", upgrading)},
+			// the kafka that paused the campaign
+			failedCount:    1,
+			failedAtResume: 1,
+		},
+		{
+			name:            "should fail the kafkas not upgraded within the wave timeout and pause the campaign",
+			kafkas:          map[string]*dbapi.KafkaRequest{"kafka-1": kafka("kafka-1", upgrading)},
+			waveStartedAt:   time.Now().Add(-2 * time.Hour),
+			waveTimeout:     time.Hour,
+			wantKafkaStatus: dbapi.UpgradeCampaignKafkaStatusFailed,
+			wantCampaignValue: map[string]interface{}{
+				"status":        dbapi.UpgradeCampaignStatusPaused,
+				"status_reason": "1 kafkas of wave 1 did not finish upgrading within the wave timeout of 1h0m0s",
+			},
+		},
+		{
+			name:          "should not time out the kafkas whose upgrade is held until their maintenance window",
+			kafkas:        map[string]*dbapi.KafkaRequest{"kafka-1": kafka("kafka-1", upgrading)},
+			waveStartedAt: time.Now().Add(-2 * time.Hour),
+			waveTimeout:   time.Hour,
+			schedule:      schedule,
+		},
+		{
+			name:          "should not time out the kafkas whose upgrade has been released in a maintenance window closed before the wave",
+			kafkas:        map[string]*dbapi.KafkaRequest{"kafka-1": kafka("kafka-1", released(time.Now().Add(-7*24*time.Hour)))},
+			waveStartedAt: time.Now().Add(-2 * time.Hour),
+			waveTimeout:   time.Hour,
+			schedule:      schedule,
+		},
+		{
+			name:          "should start the wave timeout of the kafkas with a maintenance window when their upgrade is released",
+			kafkas:        map[string]*dbapi.KafkaRequest{"kafka-1": kafka("kafka-1", released(time.Now().Add(-30*time.Minute)))},
+			waveStartedAt: time.Now().Add(-2 * time.Hour),
+			waveTimeout:   time.Hour,
+			schedule:      schedule,
+		},
+		{
+			name:            "should fail the kafkas with a maintenance window not upgraded within the wave timeout once their upgrade is released",
+			kafkas:          map[string]*dbapi.KafkaRequest{"kafka-1": kafka("kafka-1", released(time.Now().Add(-2*time.Hour)))},
+			waveStartedAt:   time.Now().Add(-3 * time.Hour),
+			waveTimeout:     time.Hour,
+			schedule:        schedule,
+			wantKafkaStatus: dbapi.UpgradeCampaignKafkaStatusFailed,
+			wantCampaignValue: map[string]interface{}{
+				"status":        dbapi.UpgradeCampaignStatusPaused,
+				"status_reason": "1 kafkas of wave 1 did not finish upgrading within the wave timeout of 1h0m0s",
+			},
+		},
+		{
+			name:              "should return an error when the upgrade of a kafka of the new wave cannot be started",
+			kafkas:            map[string]*dbapi.KafkaRequest{"kafka-1": kafka("kafka-1", upgraded)},
+			candidates:        []dbapi.KafkaList{{kafka("kafka-2", func(kafka *dbapi.KafkaRequest) {})}},
+			startErr:          errors.GeneralError("test"),
+			compatibleKafkaId: "kafka-2",
+			wantErrCount:      1,
+			wantKafkaStatus:   dbapi.UpgradeCampaignKafkaStatusUpgraded,
+			wantStartedCount:  1,
+		},
+		{
+			name:          "should not time out the wave when the wave timeout is disabled",
+			kafkas:        map[string]*dbapi.KafkaRequest{"kafka-1": kafka("kafka-1", upgrading)},
+			waveStartedAt: time.Now().Add(-2 * time.Hour),
+		},
+		{
+			name:              "should not time out the kafkas upgraded after the wave timeout",
+			kafkas:            map[string]*dbapi.KafkaRequest{"kafka-1": kafka("kafka-1", upgraded)},
+			candidates:        []dbapi.KafkaList{{}},
+			waveStartedAt:     time.Now().Add(-2 * time.Hour),
+			waveTimeout:       time.Hour,
+			wantKafkaStatus:   dbapi.UpgradeCampaignKafkaStatusUpgraded,
+			wantCampaignValue: map[string]interface{}{"status": dbapi.UpgradeCampaignStatusCompleted, "status_reason": ""},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			var updatedKafkaStatus dbapi.UpgradeCampaignKafkaStatus
+			recorded := map[string]dbapi.UpgradeCampaignKafkaStatus{}
+			var campaignValues map[string]interface{}
+			findCount := 0
+
+			upgradeCampaignService := &services.UpgradeCampaignServiceMock{
+				ListByStatusFunc: func(status dbapi.UpgradeCampaignStatus) (dbapi.UpgradeCampaignList, *errors.ServiceError) {
+					return dbapi.UpgradeCampaignList{campaign(tt.failedAtResume)}, tt.listErr
+				},
+				ListKafkasFunc: func(campaignId string, status dbapi.UpgradeCampaignKafkaStatus) (dbapi.UpgradeCampaignKafkaList, *errors.ServiceError) {
+					campaignKafka := *waveKafka
+					campaignKafka.CreatedAt = tt.waveStartedAt
+					if campaignKafka.CreatedAt.IsZero() {
+						campaignKafka.CreatedAt = time.Now()
+					}
+					return dbapi.UpgradeCampaignKafkaList{&campaignKafka}, nil
+				},
+				UpdateKafkaFunc: func(campaignKafka *dbapi.UpgradeCampaignKafka) *errors.ServiceError {
+					updatedKafkaStatus = campaignKafka.Status
+					return nil
+				},
+				CountKafkasByStatusFunc: func(campaignId string) (map[dbapi.UpgradeCampaignKafkaStatus]int, *errors.ServiceError) {
+					return map[dbapi.UpgradeCampaignKafkaStatus]int{dbapi.UpgradeCampaignKafkaStatusFailed: tt.failedCount}, nil
+				},
+				FindCandidateKafkasFunc: func(campaign *dbapi.UpgradeCampaign, limit int) (dbapi.KafkaList, *errors.ServiceError) {
+					candidates := tt.candidates[findCount]
+					findCount++
+					return candidates, nil
+				},
+				RecordKafkaFunc: func(campaignKafka *dbapi.UpgradeCampaignKafka) *errors.ServiceError {
+					recorded[campaignKafka.KafkaId] = campaignKafka.Status
+					return nil
+				},
+				StartKafkaUpgradeFunc: func(campaignKafka *dbapi.UpgradeCampaignKafka, strimziVersion string, kafkaVersion string, kafkaIBPVersion string) *errors.ServiceError {
+					if tt.startErr != nil {
+						return tt.startErr
+					}
+					recorded[campaignKafka.KafkaId] = campaignKafka.Status
+					return nil
+				},
+				UpdatesFunc: func(campaign *dbapi.UpgradeCampaign, values map[string]interface{}) *errors.ServiceError {
+					campaignValues = values
+					return nil
+				},
+			}
+			kafkaService := &services.KafkaServiceMock{
+				GetByIDFunc: func(id string) (*dbapi.KafkaRequest, *errors.ServiceError) {
+					return tt.kafkas[id], nil
+				},
+			}
+			maintenanceWindowService := &services.MaintenanceWindowServiceMock{
+				GetKafkaScheduleFunc: func(kafka *dbapi.KafkaRequest) (*dbapi.MaintenanceWindowSchedule, *errors.ServiceError) {
+					return tt.schedule, nil
+				},
+			}
+			clusterService := &services.ClusterServiceMock{
+				FindClusterByIDFunc: func(clusterID string) (*api.Cluster, *errors.ServiceError) {
+					return mockClusters.BuildCluster(func(cluster *api.Cluster) { cluster.ClusterID = clusterID }), nil
+				},
+				IsStrimziKafkaVersionAvailableInClusterFunc: func(cluster *api.Cluster, strimziVersion string, kafkaVersion string, ibpVersion string) (bool, error) {
+					return cluster.ClusterID == "cluster-id", nil
+				},
+				CheckStrimziVersionReadyFunc: func(cluster *api.Cluster, strimziVersion string) (bool, error) {
+					return true, nil
+				},
+			}
+
+			kafkaConfig := config.NewKafkaConfig()
+			kafkaConfig.UpgradeCampaignWaveTimeout = tt.waveTimeout
+
+			k := NewUpgradeCampaignManager(upgradeCampaignService, kafkaService, clusterService, maintenanceWindowService, kafkaConfig, w.Reconciler{})
+			errs := k.Reconcile()
+			g.Expect(errs).To(gomega.HaveLen(tt.wantErrCount))
+			g.Expect(updatedKafkaStatus).To(gomega.Equal(tt.wantKafkaStatus))
+			if tt.wantRecorded == nil {
+				tt.wantRecorded = map[string]dbapi.UpgradeCampaignKafkaStatus{}
+			}
+			g.Expect(recorded).To(gomega.Equal(tt.wantRecorded))
+			g.Expect(upgradeCampaignService.StartKafkaUpgradeCalls()).To(gomega.HaveLen(tt.wantStartedCount))
+			for _, call := range upgradeCampaignService.StartKafkaUpgradeCalls() {
+				g.Expect(call.CampaignKafka.KafkaId).To(gomega.Equal(tt.compatibleKafkaId))
+				g.Expect(call.KafkaVersion).To(gomega.Equal("3.4.0"))
+			}
+			g.Expect(campaignValues).To(gomega.Equal(tt.wantCampaignValue))
+		})
+	}
+}
@@ -15,6 +15,9 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/workers/cluster_mgrs"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/workers/kafka_mgrs"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/workers/kafka_mgrs/promotion"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/internal/kafka/internal/workers/kafka_mgrs/resize"
+	kasMetrics "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/metrics"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/secrets"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
 
 	observatoriumClient "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/observatorium"
@@ -43,6 +46,7 @@ func ConfigProviders() di.Option {
 		// Configuration for the Kafka service...
 		di.Provide(config.NewAWSConfig, di.As(new(environments2.ConfigModule))),
 		di.Provide(config.NewGCPConfig, di.As(new(environments2.ConfigModule)), di.As(new(environments2.ServiceValidator))),
+		di.Provide(config.NewDNSConfig, di.As(new(environments2.ConfigModule)), di.As(new(environments2.ServiceValidator))),
 
 		di.Provide(config.NewSupportedProvidersConfig, di.As(new(environments2.ConfigModule)), di.As(new(environments2.ServiceValidator))),
 		di.Provide(observatoriumClient.NewObservabilityConfigurationConfig, di.As(new(environments2.ConfigModule)), di.As(new(environments2.ServiceValidator))),
@@ -57,6 +61,10 @@ func ConfigProviders() di.Option {
 		di.Provide(migrations.New),
 
 		metrics.ConfigProviders(),
+		secrets.ConfigProviders(func(c *secrets.Config) {
+			c.SecretPrefix = "managed-kafkas"
+			c.MetricsSubsystem = kasMetrics.KasFleetManager
+		}),
 	)
 }
 
@@ -75,6 +83,17 @@ func ServiceProviders() di.Option {
 		di.Provide(clusters.NewDefaultProviderFactory, di.As(new(clusters.ProviderFactory))),
 		di.Provide(routes.NewRouteLoader),
 		di.Provide(quota.NewDefaultQuotaServiceFactory),
+		di.Provide(services.NewDNSProviderFactory),
+		di.Provide(services.NewQuotaManagementListEntriesService),
+		di.Provide(services.NewKafkaEventService),
+		di.Provide(services.NewKafkaMigrationService),
+		di.Provide(services.NewClusterScalingDecisionService),
+		di.Provide(services.NewMaintenanceWindowService),
+		di.Provide(services.NewUpgradeCampaignService),
+		di.Provide(services.NewKafkaTLSCertificateInventoryService),
+		di.Provide(services.NewCanaryServiceAccountService),
+		di.Provide(services.NewClusterClientSecretService),
+		di.Provide(services.NewQuotaManagementListSeeder, di.As(new(environments2.BootService))),
 		di.Provide(cluster_mgrs.NewClusterManager, di.As(new(workers.Worker))),
 		di.Provide(cluster_mgrs.NewDynamicScaleUpManager, di.As(new(workers.Worker))),
 		di.Provide(cluster_mgrs.NewCleanupClustersManager, di.As(new(workers.Worker))),
@@ -87,8 +106,16 @@ func ServiceProviders() di.Option {
 		di.Provide(kafka_mgrs.NewProvisioningKafkaManager, di.As(new(workers.Worker))),
 		di.Provide(kafka_mgrs.NewReadyKafkaManager, di.As(new(workers.Worker))),
 		di.Provide(kafka_mgrs.NewKafkaCNAMEManager, di.As(new(workers.Worker))),
+		di.Provide(kafka_mgrs.NewKafkaMigrationManager, di.As(new(workers.Worker))),
+		di.Provide(kafka_mgrs.NewKafkaMaintenanceWindowManager, di.As(new(workers.Worker))),
+		di.Provide(kafka_mgrs.NewUpgradeCampaignManager, di.As(new(workers.Worker))),
+		di.Provide(kafka_mgrs.NewCanaryServiceAccountRotationManager, di.As(new(workers.Worker))),
+		di.Provide(kafka_mgrs.NewKafkaDNSDriftManager, di.As(new(workers.Worker))),
+		di.Provide(kafka_mgrs.NewKafkaTLSCertificateRenewalManager, di.As(new(workers.Worker))),
 		di.Provide(promotion.NewPromotionKafkaManager, di.As(new(workers.Worker))),
+		di.Provide(resize.NewResizeKafkaManager, di.As(new(workers.Worker))),
 		di.Provide(acl.NewEnterpriseClustersAccessControlMiddleware),
 		di.Provide(kafkatlscertmgmt.NewKafkaTLSCertificateManagementService),
+		environments.HealthCheckProviders(),
 	)
 }
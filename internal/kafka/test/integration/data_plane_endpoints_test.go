@@ -25,6 +25,7 @@ import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/ocm"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/encryption"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/metrics"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
@@ -328,7 +329,7 @@ func TestDataPlaneEndpoints_GetManagedKafkas(t *testing.T) {
 		return
 	}
 
-	list, resp, err := testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID)
+	list, resp, err := testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID, nil)
 	if resp != nil {
 		resp.Body.Close()
 	}
@@ -464,7 +465,7 @@ func TestDataPlaneEndpoints_UpdateManagedKafkas(t *testing.T) {
 		g.Expect(result.MaxDataRetentionSize.Bytes).To(gomega.Equal(dataRetentionSizeBytes))
 	}
 
-	list, resp, err := testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID)
+	list, resp, err := testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID, nil)
 	if resp != nil {
 		resp.Body.Close()
 	}
@@ -647,7 +648,7 @@ func TestDataPlaneEndpoints_GetAndUpdateManagedKafkasWithTlsCerts(t *testing.T)
 		Status:                           constants.KafkaRequestStatusReady.String(),
 		BootstrapServerHost:              bootstrapServerHost,
 		CanaryServiceAccountClientID:     canaryServiceAccountClientId,
-		CanaryServiceAccountClientSecret: canaryServiceAccountClientSecret,
+		CanaryServiceAccountClientSecret: encryption.EncryptedString(canaryServiceAccountClientSecret),
 		PlacementId:                      "some-placement-id",
 		DesiredKafkaVersion:              "2.7.0",
 		DesiredKafkaIBPVersion:           "2.7",
@@ -663,7 +664,7 @@ func TestDataPlaneEndpoints_GetAndUpdateManagedKafkasWithTlsCerts(t *testing.T)
 		return
 	}
 
-	list, resp, err := testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID)
+	list, resp, err := testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID, nil)
 	if resp != nil {
 		resp.Body.Close()
 	}
@@ -708,7 +709,7 @@ func TestDataPlaneEndpoints_GetAndUpdateManagedKafkasWithServiceAccounts(t *test
 		Status:                           constants.KafkaRequestStatusReady.String(),
 		BootstrapServerHost:              bootstrapServerHost,
 		CanaryServiceAccountClientID:     canaryServiceAccountClientId,
-		CanaryServiceAccountClientSecret: canaryServiceAccountClientSecret,
+		CanaryServiceAccountClientSecret: encryption.EncryptedString(canaryServiceAccountClientSecret),
 		PlacementId:                      "some-placement-id",
 		DesiredKafkaVersion:              "2.7.0",
 		DesiredKafkaIBPVersion:           "2.7",
@@ -724,7 +725,7 @@ func TestDataPlaneEndpoints_GetAndUpdateManagedKafkasWithServiceAccounts(t *test
 		return
 	}
 
-	list, resp, err := testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID)
+	list, resp, err := testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID, nil)
 	if resp != nil {
 		resp.Body.Close()
 	}
@@ -789,7 +790,7 @@ func TestDataPlaneEndpoints_GetManagedKafkasWithoutOAuthTLSCert(t *testing.T) {
 		return
 	}
 
-	list, resp, err := testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID)
+	list, resp, err := testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID, nil)
 	if resp != nil {
 		resp.Body.Close()
 	}
@@ -850,7 +851,7 @@ func TestDataPlaneEndpoints_GetManagedKafkasWithOauthMaximumSessionLifetime(t *t
 		return
 	}
 
-	list, resp, err := testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID)
+	list, resp, err := testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID, nil)
 	if resp != nil {
 		resp.Body.Close()
 	}
@@ -894,7 +895,7 @@ func TestDataPlaneEndpoints_GetManagedKafkasWithOauthMaximumSessionLifetime(t *t
 		return
 	}
 
-	list, resp, err = testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID)
+	list, resp, err = testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID, nil)
 	if resp != nil {
 		resp.Body.Close()
 	}
@@ -966,7 +967,7 @@ func TestDataPlaneEndpoints_UpdateManagedKafkasWithRoutesAndAdminApiServerUrl(t
 		return
 	}
 
-	list, resp, err := testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID)
+	list, resp, err := testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID, nil)
 	if resp != nil {
 		resp.Body.Close()
 	}
@@ -1100,7 +1101,7 @@ func TestDataPlaneEndpoints_GetManagedKafkasWithOAuthTLSCert(t *testing.T) {
 		return
 	}
 
-	list, resp, err := testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID)
+	list, resp, err := testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID, nil)
 	if resp != nil {
 		resp.Body.Close()
 	}
@@ -1158,7 +1159,7 @@ func TestDataPlaneEndpoints_UpdateManagedKafkaWithErrorStatus(t *testing.T) {
 		return
 	}
 
-	list, resp, err := testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID)
+	list, resp, err := testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID, nil)
 	if resp != nil {
 		resp.Body.Close()
 	}
@@ -1224,7 +1225,7 @@ func TestDataPlaneEndpoints_UpdateManagedKafka_RemoveFailedReason(t *testing.T)
 		return
 	}
 
-	list, resp, err := testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID)
+	list, resp, err := testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID, nil)
 	if resp != nil {
 		resp.Body.Close()
 	}
@@ -1478,7 +1479,7 @@ func TestDataPlaneEndpoints_ReassignRejectedKafkaDueToInsufficientResources(t *t
 		return
 	}
 
-	list, resp, err := testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID)
+	list, resp, err := testServer.PrivateClient.AgentClustersApi.GetKafkas(testServer.Ctx, testServer.ClusterID, nil)
 	if resp != nil {
 		resp.Body.Close()
 	}
@@ -22,6 +22,7 @@ var (
 	routeName                    = "bootstrap"
 	prefix                       = ""
 	router                       = "elb.test1.kafka.example.com"
+	canaryPrincipal              = "canary-kafka-id"
 )
 
 func BuildPrivateDataPlaneKafkaStatus(modifyFn func(status map[string]private.DataPlaneKafkaStatus)) map[string]private.DataPlaneKafkaStatus {
@@ -54,6 +55,10 @@ func BuildPrivateDataPlaneKafkaStatus(modifyFn func(status map[string]private.Da
 				Router: router,
 			},
 		},
+		Canary: private.DataPlaneKafkaStatusCanary{
+			Principal: canaryPrincipal,
+			Ready:     true,
+		},
 	}
 	if modifyFn != nil {
 		modifyFn(status)
@@ -81,6 +86,8 @@ func BuildDbDataPlaneKafkaStatus(modifyFn func(status *dbapi.DataPlaneKafkaStatu
 		KafkaVersion:    kafkaVersion,
 		StrimziVersion:  strimziVersion,
 		KafkaIBPVersion: ibpVersion,
+		CanaryPrincipal: canaryPrincipal,
+		CanaryReady:     true,
 	}
 	if modifyFn != nil {
 		modifyFn(status)
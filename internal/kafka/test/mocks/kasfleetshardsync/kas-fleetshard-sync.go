@@ -110,7 +110,7 @@ var defaultUpdateKafkaStatusFunc mockKasFleetshardSyncupdateKafkaClusterStatusFu
 			return err
 		}
 
-		kafkaList, _, err := privateClient.AgentClustersApi.GetKafkas(ctx, dataplaneCluster.ClusterID)
+		kafkaList, _, err := privateClient.AgentClustersApi.GetKafkas(ctx, dataplaneCluster.ClusterID, nil)
 		if err != nil {
 			return err
 		}
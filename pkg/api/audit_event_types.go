@@ -0,0 +1,36 @@
+package api
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AuditEvent is a record of an API call that was made against the service.
+// Audit events are never updated nor deleted once they are created.
+type AuditEvent struct {
+	ID        string `json:"id" gorm:"primaryKey"`
+	CreatedAt time.Time
+	// Actor is the username of the caller
+	Actor string
+	// OrgId is the organisation of the caller, when it is part of its token
+	OrgId  string
+	Method string
+	// RouteName is the type of the log event of the route that served the request (e.g. "update-kafka")
+	RouteName string
+	Path      string
+	// TargetId is the identifier of the resource the request was made against, if any
+	TargetId   string
+	StatusCode int
+	// RequestBody is the body of the request with the sensitive fields redacted
+	RequestBody string
+}
+
+type AuditEventList []*AuditEvent
+
+func (e *AuditEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = NewID()
+	}
+	return nil
+}
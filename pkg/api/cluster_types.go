@@ -6,7 +6,9 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/encryption"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared/utils/arrays"
 
 	kasfleetmanagererrors "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
@@ -133,26 +135,33 @@ func (t DataPlaneClusterType) String() string {
 	return string(t)
 }
 
+// ClusterStatuses are all the statuses a dataplane cluster can have
+var ClusterStatuses = []ClusterStatus{ClusterAccepted, ClusterProvisioning, ClusterProvisioned, ClusterCleanup,
+	ClusterWaitingForKasFleetShardOperator, ClusterReady, ClusterFull, ClusterFailed, ClusterDeprovisioning}
+
 // This represents the valid statuses of a dataplane cluster
 var StatusForValidCluster = []string{string(ClusterProvisioning), string(ClusterProvisioned), string(ClusterReady),
 	string(ClusterAccepted), string(ClusterWaitingForKasFleetShardOperator)}
 
+// ClusterResourcesReconcilableStatuses are the statuses of the dataplane clusters whose resources can be reconciled
+var ClusterResourcesReconcilableStatuses = []ClusterStatus{ClusterProvisioned, ClusterWaitingForKasFleetShardOperator, ClusterReady}
+
 // ClusterDeletionStatuses are statuses of clusters under deletion
 var ClusterDeletionStatuses = []string{ClusterCleanup.String(), ClusterDeprovisioning.String()}
 
 type Cluster struct {
 	Meta
-	CloudProvider      string        `json:"cloud_provider"`
-	ClusterID          string        `json:"cluster_id" gorm:"uniqueIndex"`
-	ExternalID         string        `json:"external_id"`
-	MultiAZ            bool          `json:"multi_az"`
-	Region             string        `json:"region"`
-	Status             ClusterStatus `json:"status" gorm:"index"`
-	StatusDetails      string        `json:"status_details" gorm:"-"`
-	IdentityProviderID string        `json:"identity_provider_id"`
-	ClusterDNS         string        `json:"cluster_dns"`
-	ClientID           string        `json:"client_id"`
-	ClientSecret       string        `json:"client_secret"`
+	CloudProvider      string                     `json:"cloud_provider"`
+	ClusterID          string                     `json:"cluster_id" gorm:"uniqueIndex"`
+	ExternalID         string                     `json:"external_id"`
+	MultiAZ            bool                       `json:"multi_az"`
+	Region             string                     `json:"region"`
+	Status             ClusterStatus              `json:"status" gorm:"index"`
+	StatusDetails      string                     `json:"status_details" gorm:"-"`
+	IdentityProviderID string                     `json:"identity_provider_id"`
+	ClusterDNS         string                     `json:"cluster_dns"`
+	ClientID           string                     `json:"client_id"`
+	ClientSecret       encryption.EncryptedString `json:"client_secret"`
 	// the provider type for the cluster, e.g. OCM, AWS, GCP, Standalone etc
 	ProviderType ClusterProviderType `json:"provider_type"`
 	// store the provider-specific information that can be used to managed the openshift/k8s cluster
@@ -180,6 +189,16 @@ type Cluster struct {
 
 	// AccessKafkasViaPrivateNetwork indicates whether Kafkas deployed on this OSD cluster have to be accessed via private network
 	AccessKafkasViaPrivateNetwork bool `json:"access_kafkas_via_private_network"`
+
+	// CordonedAt is the time the cluster has been cordoned by the dynamic scale down. A cordoned cluster keeps on
+	// serving its kafkas but it no longer receives new ones. It is nil when the cluster is not cordoned.
+	CordonedAt *time.Time `json:"cordoned_at"`
+	// ManuallyCordoned indicates that the cluster has been cordoned by an administrator. Unlike the clusters cordoned by the
+	// dynamic scale down, it stays cordoned until an administrator uncordons it.
+	ManuallyCordoned bool `json:"manually_cordoned"`
+	// ResourcesReconcileRequestedAt is the time an administrator requested the resources of the cluster to be
+	// reconciled. It is reset once the resources have been reconciled.
+	ResourcesReconcileRequestedAt *time.Time `json:"resources_reconcile_requested_at"`
 }
 
 type ClusterList []*Cluster
@@ -518,6 +537,11 @@ func (cluster *Cluster) RetrieveDynamicCapacityInfo() map[string]DynamicCapacity
 	return dynamicCapacityInfo
 }
 
+// IsCordoned returns true when the cluster no longer receives new kafkas
+func (cluster *Cluster) IsCordoned() bool {
+	return cluster.CordonedAt != nil
+}
+
 // GetSupportedInstanceTypes returns a list of the supported instance types for
 // the cluster. If there are no supported instance types the result is
 // an empty list
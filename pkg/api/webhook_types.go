@@ -0,0 +1,118 @@
+package api
+
+import (
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/encryption"
+
+	"gorm.io/gorm"
+)
+
+// WebhookSubscription is an endpoint registered by an organisation to be notified of the changes of phase of its resources
+type WebhookSubscription struct {
+	Meta
+	OrgId string
+	// Owner is the username of the user that registered the subscription
+	Owner string
+	Url   string
+	// Secret is the key used to sign the payloads delivered to the endpoint
+	Secret encryption.EncryptedString
+	// EventTypes is the list of the types of the events the endpoint is notified of. The endpoint is notified of all
+	// the events when the list is empty
+	EventTypes JSON
+}
+
+type WebhookSubscriptionList []*WebhookSubscription
+
+func (s *WebhookSubscription) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = NewID()
+	}
+	return nil
+}
+
+// GetEventTypes returns the list of the types of the events the subscription is notified of
+func (s *WebhookSubscription) GetEventTypes() ([]string, error) {
+	eventTypes := []string{}
+	if len(s.EventTypes) == 0 {
+		return eventTypes, nil
+	}
+	if err := s.EventTypes.Unmarshal(&eventTypes); err != nil {
+		return nil, err
+	}
+	return eventTypes, nil
+}
+
+// IsSubscribedTo returns true if the subscription is notified of the events of the given type
+func (s *WebhookSubscription) IsSubscribedTo(eventType string) (bool, error) {
+	eventTypes, err := s.GetEventTypes()
+	if err != nil {
+		return false, err
+	}
+	if len(eventTypes) == 0 {
+		return true, nil
+	}
+	for _, t := range eventTypes {
+		if t == eventType {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+func (s WebhookDeliveryStatus) String() string {
+	return string(s)
+}
+
+// WebhookEvent is an event queued in the outbox of the webhook notifications. The events are dispatched to the
+// subscriptions of their organisation by the delivery worker so that notifying an event never waits for the
+// subscriptions to be looked up.
+type WebhookEvent struct {
+	ID         string `gorm:"primaryKey"`
+	CreatedAt  time.Time
+	OrgId      string
+	EventType  string
+	OccurredAt time.Time
+	// Payload is the JSON body sent to the endpoints of the subscriptions
+	Payload JSON
+}
+
+type WebhookEventList []*WebhookEvent
+
+// WebhookDelivery is an entry of the outbox of the webhook notifications: a delivery is created for every subscription
+// that has to be notified of an event, and it is attempted until it succeeds or it runs out of attempts.
+type WebhookDelivery struct {
+	ID             string `gorm:"primaryKey"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	SubscriptionId string
+	// Subscription is loaded together with the pending deliveries. It is nil if the subscription has been deleted since
+	Subscription *WebhookSubscription `gorm:"foreignKey:SubscriptionId"`
+	EventId      string
+	EventType    string
+	// Payload is the JSON body sent to the endpoint of the subscription
+	Payload       JSON
+	Status        WebhookDeliveryStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	// LastError is the reason of the failure of the latest attempt, if any
+	LastError   string
+	DeliveredAt *time.Time
+}
+
+type WebhookDeliveryList []*WebhookDelivery
+
+func (d *WebhookDelivery) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == "" {
+		d.ID = NewID()
+	}
+	return nil
+}
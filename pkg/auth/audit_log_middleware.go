@@ -1,40 +1,96 @@
 package auth
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/server/logging"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/audit"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
-	"io"
-	"net/http"
+	"github.com/gorilla/mux"
+)
+
+const (
+	redactedValue = "REDACTED"
+	// tooLargeValue replaces the request bodies larger than maxAuditedRequestBodySize, they are neither parsed nor stored
+	tooLargeValue = "TOO_LARGE"
+	// maxAuditedRequestBodySize is the maximum size of the request bodies read to be audited
+	maxAuditedRequestBodySize = 1 << 20
+	// maxRecordedRequestBodyLength is the length the recorded request bodies are truncated to
+	maxRecordedRequestBodyLength = 4096
+	truncatedSuffix              = "...(truncated)"
 )
 
+// sensitiveFieldKeywords identify the fields of a request body whose value is never logged nor stored in the audit events
+var sensitiveFieldKeywords = []string{"password", "secret", "token", "credential", "private_key", "api_key"}
+
+var pathVariableRegexp = regexp.MustCompile(`{([^}:]+)(:[^}]*)?}`)
+
 type AuditLogMiddleware interface {
+	// AuditLog logs every request. An audit event is only stored for the requests that modify resources, i.e. POST, PUT, PATCH and DELETE requests,
+	// so that the audit events are not flooded by the read requests
 	AuditLog(code errors.ServiceErrorCode) func(handler http.Handler) http.Handler
+	// AuditLogMutatingRequests logs and stores an audit event only for the requests that modify resources, i.e. POST, PUT, PATCH and DELETE requests
+	AuditLogMutatingRequests(code errors.ServiceErrorCode) func(handler http.Handler) http.Handler
 }
 
 type auditInfo struct {
-	Type               string        `json:"type"`
-	Username           string        `json:"username"`
-	Method             string        `json:"request_method,omitempty"`
-	RequestURI         string        `json:"request_url,omitempty"`
-	Body               io.ReadCloser `json:"request_body,omitempty"`
-	RemoteAddr         string        `json:"request_remote_ip,omitempty"`
-	ResponseStatusCode int           `json:"response_status_code,omitempty"`
+	Type               string `json:"type"`
+	Username           string `json:"username"`
+	Method             string `json:"request_method,omitempty"`
+	RequestURI         string `json:"request_url,omitempty"`
+	Body               string `json:"request_body,omitempty"`
+	RemoteAddr         string `json:"request_remote_ip,omitempty"`
+	ResponseStatusCode int    `json:"response_status_code,omitempty"`
 }
 
 type auditLogMiddleware struct {
+	auditEvents audit.AuditEventService
 }
 
 var _ AuditLogMiddleware = &auditLogMiddleware{}
 
-func NewAuditLogMiddleware() AuditLogMiddleware {
-	return &auditLogMiddleware{}
+func NewAuditLogMiddleware(auditEvents audit.AuditEventService) AuditLogMiddleware {
+	return &auditLogMiddleware{
+		auditEvents: auditEvents,
+	}
 }
 
 func (a *auditLogMiddleware) AuditLog(code errors.ServiceErrorCode) func(handler http.Handler) http.Handler {
+	return a.auditLog(code, func(request *http.Request) bool {
+		return true
+	})
+}
+
+func (a *auditLogMiddleware) AuditLogMutatingRequests(code errors.ServiceErrorCode) func(handler http.Handler) http.Handler {
+	return a.auditLog(code, isMutatingRequest)
+}
+
+func isMutatingRequest(request *http.Request) bool {
+	switch request.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func (a *auditLogMiddleware) auditLog(code errors.ServiceErrorCode, shouldLog func(request *http.Request) bool) func(handler http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			if !shouldLog(request) {
+				next.ServeHTTP(writer, request)
+				return
+			}
+
 			ctx := request.Context()
 			claims, err := GetClaimsFromContext(ctx)
 			serviceErr := errors.New(code, "")
@@ -43,12 +99,30 @@ func (a *auditLogMiddleware) AuditLog(code errors.ServiceErrorCode) func(handler
 				return
 			}
 			username, _ := claims.GetUsername()
+			orgId, _ := claims.GetOrgId()
+
+			// the beginning of the body is read to be audited, it is then restored so that the whole body can be read by
+			// the next handlers
+			body, err := io.ReadAll(io.LimitReader(request.Body, maxAuditedRequestBodySize+1))
+			if err != nil {
+				shared.HandleError(request, writer, serviceErr)
+				return
+			}
+			request.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(body), request.Body), request.Body}
+			redactedBody := tooLargeValue
+			if len(body) <= maxAuditedRequestBodySize {
+				redactedBody = truncate(redactRequestBody(body), maxRecordedRequestBodyLength)
+			}
+
 			info := auditInfo{
 				Type:       "audit",
 				Username:   username,
 				Method:     request.Method,
 				RequestURI: request.RequestURI,
-				Body:       request.Body,
+				Body:       redactedBody,
 				RemoteAddr: request.RemoteAddr,
 			}
 			logWriter := logging.NewLoggingWriter(writer, request, logging.NewJSONLogFormatter())
@@ -59,6 +133,10 @@ func (a *auditLogMiddleware) AuditLog(code errors.ServiceErrorCode) func(handler
 			}
 			next.ServeHTTP(logWriter, request)
 			statusCode := logWriter.GetResponseStatusCode()
+			if statusCode == 0 {
+				// the status code is only recorded when it is explicitly written
+				statusCode = http.StatusOK
+			}
 			info = auditInfo{
 				Type:               "audit",
 				ResponseStatusCode: statusCode,
@@ -68,8 +146,113 @@ func (a *auditLogMiddleware) AuditLog(code errors.ServiceErrorCode) func(handler
 			if err != nil {
 				// response is already returned, just log the error if there is any
 				logWriter.Log(fmt.Sprintf("failed to log object %v", info), err)
+			}
+
+			if !isMutatingRequest(request) {
 				return
 			}
+
+			event := &api.AuditEvent{
+				Actor:       username,
+				OrgId:       orgId,
+				Method:      request.Method,
+				RouteName:   getRouteName(request),
+				Path:        request.URL.Path,
+				TargetId:    getTargetId(request, statusCode, logWriter.GetResponseBody()),
+				StatusCode:  statusCode,
+				RequestBody: redactedBody,
+			}
+			if err := a.auditEvents.Create(event); err != nil {
+				// response is already returned, just log the error if there is any
+				logWriter.Log(fmt.Sprintf("failed to store audit event for %s %s", event.Method, event.Path), err)
+			}
 		})
 	}
 }
+
+// getRouteName returns the type of the log event used as the name of the route that matched the request
+func getRouteName(request *http.Request) string {
+	route := mux.CurrentRoute(request)
+	if route == nil {
+		return ""
+	}
+	return logger.NewLogEventFromString(route.GetName()).Type
+}
+
+// getTargetId returns the value of the last variable of the path of the route that matched the request,
+// i.e. the id of the most specific resource. When the path has no variables, the id of the resource created by
+// a successful request is returned instead.
+func getTargetId(request *http.Request, statusCode int, responseBody []byte) string {
+	if route := mux.CurrentRoute(request); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			if variables := pathVariableRegexp.FindAllStringSubmatch(template, -1); len(variables) > 0 {
+				return mux.Vars(request)[variables[len(variables)-1][1]]
+			}
+		}
+	}
+
+	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		return ""
+	}
+	var resource struct {
+		Id string `json:"id"`
+	}
+	if err := json.Unmarshal(responseBody, &resource); err != nil {
+		return ""
+	}
+	return resource.Id
+}
+
+// redactRequestBody replaces the values of the sensitive fields of a JSON body. Bodies that are not in JSON format
+// are entirely redacted as their sensitive content cannot be identified.
+func redactRequestBody(body []byte) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+
+	var content interface{}
+	if err := json.Unmarshal(body, &content); err != nil {
+		return redactedValue
+	}
+	redacted, err := json.Marshal(redactValue(content))
+	if err != nil {
+		return redactedValue
+	}
+	return string(redacted)
+}
+
+// truncate truncates the given value to the given length, the truncated values end with truncatedSuffix
+func truncate(value string, length int) string {
+	if len(value) <= length {
+		return value
+	}
+	return value[:length-len(truncatedSuffix)] + truncatedSuffix
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, fieldValue := range v {
+			if isSensitiveField(key) {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactValue(fieldValue)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+	}
+	return value
+}
+
+func isSensitiveField(name string) bool {
+	name = strings.ToLower(name)
+	for _, keyword := range sensitiveFieldKeywords {
+		if strings.Contains(name, keyword) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,13 +1,20 @@
 package auth
 
 import (
+	"bytes"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/logger"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/audit"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/gorilla/mux"
 	"github.com/onsi/gomega"
 )
 
@@ -47,7 +54,11 @@ func TestAuditLogMiddleware_AuditLog(t *testing.T) {
 		tt := testcase
 		t.Run(tt.name, func(t *testing.T) {
 			g := gomega.NewWithT(t)
-			auditLogMW := NewAuditLogMiddleware()
+			auditLogMW := NewAuditLogMiddleware(&audit.AuditEventServiceMock{
+				CreateFunc: func(event *api.AuditEvent) *errors.ServiceError {
+					return nil
+				},
+			})
 			toTest := setContextToken(auditLogMW.AuditLog(tt.errCode)(tt.next), tt.token)
 			req := httptest.NewRequest("GET", "http://example.com", nil)
 			recorder := httptest.NewRecorder()
@@ -58,3 +69,185 @@ func TestAuditLogMiddleware_AuditLog(t *testing.T) {
 		})
 	}
 }
+
+func TestAuditLogMiddleware_StoresAuditEvents(t *testing.T) {
+	longBody := `{"name":"` + strings.Repeat("a", maxRecordedRequestBodyLength) + `"}`
+	tooLargeBody := `{"name":"` + strings.Repeat("a", maxAuditedRequestBodySize) + `"}`
+
+	tests := []struct {
+		name           string
+		mutatingOnly   bool
+		method         string
+		url            string
+		body           string
+		responseStatus int
+		responseBody   string
+		createErr      *errors.ServiceError
+		wantEvent      *api.AuditEvent
+	}{
+		{
+			name:           "should store the audit event of a request with the sensitive fields of the body redacted",
+			method:         http.MethodPatch,
+			url:            "/kafkas/kafka-id",
+			body:           `{"owner": "new-owner", "credentials": {"client_secret": "secret"}, "users": [{"password": "pwd"}]}`,
+			responseStatus: http.StatusOK,
+			wantEvent: &api.AuditEvent{
+				Actor:       "test-user",
+				OrgId:       "test-org",
+				Method:      http.MethodPatch,
+				RouteName:   "update-kafka",
+				Path:        "/kafkas/kafka-id",
+				TargetId:    "kafka-id",
+				StatusCode:  http.StatusOK,
+				RequestBody: `{"credentials":"REDACTED","owner":"new-owner","users":[{"password":"REDACTED"}]}`,
+			},
+		},
+		{
+			name:           "should use the id of the created resource as target when the path has no variables",
+			method:         http.MethodPost,
+			url:            "/kafkas",
+			body:           `{"name": "my-kafka"}`,
+			responseStatus: http.StatusAccepted,
+			responseBody:   `{"id": "new-kafka-id"}`,
+			wantEvent: &api.AuditEvent{
+				Actor:       "test-user",
+				OrgId:       "test-org",
+				Method:      http.MethodPost,
+				RouteName:   "create-kafka",
+				Path:        "/kafkas",
+				TargetId:    "new-kafka-id",
+				StatusCode:  http.StatusAccepted,
+				RequestBody: `{"name":"my-kafka"}`,
+			},
+		},
+		{
+			name:           "should entirely redact a body that is not in JSON format",
+			method:         http.MethodPost,
+			url:            "/kafkas",
+			body:           `not-json`,
+			responseStatus: http.StatusBadRequest,
+			responseBody:   `{"id": "400"}`,
+			wantEvent: &api.AuditEvent{
+				Actor:       "test-user",
+				OrgId:       "test-org",
+				Method:      http.MethodPost,
+				RouteName:   "create-kafka",
+				Path:        "/kafkas",
+				StatusCode:  http.StatusBadRequest,
+				RequestBody: "REDACTED",
+			},
+		},
+		{
+			name:           "should truncate the recorded body",
+			method:         http.MethodPost,
+			url:            "/kafkas",
+			body:           longBody,
+			responseStatus: http.StatusBadRequest,
+			wantEvent: &api.AuditEvent{
+				Actor:       "test-user",
+				OrgId:       "test-org",
+				Method:      http.MethodPost,
+				RouteName:   "create-kafka",
+				Path:        "/kafkas",
+				StatusCode:  http.StatusBadRequest,
+				RequestBody: longBody[:maxRecordedRequestBodyLength-len(truncatedSuffix)] + truncatedSuffix,
+			},
+		},
+		{
+			name:           "should not record a body larger than the audited size but still pass it to the handler",
+			method:         http.MethodPost,
+			url:            "/kafkas",
+			body:           tooLargeBody,
+			responseStatus: http.StatusBadRequest,
+			wantEvent: &api.AuditEvent{
+				Actor:       "test-user",
+				OrgId:       "test-org",
+				Method:      http.MethodPost,
+				RouteName:   "create-kafka",
+				Path:        "/kafkas",
+				StatusCode:  http.StatusBadRequest,
+				RequestBody: "TOO_LARGE",
+			},
+		},
+		{
+			name:           "should not store the audit event of a read request",
+			method:         http.MethodGet,
+			url:            "/kafkas/kafka-id",
+			responseStatus: http.StatusOK,
+		},
+		{
+			name:           "should not store the audit event of a read request when only mutating requests are audited",
+			mutatingOnly:   true,
+			method:         http.MethodGet,
+			url:            "/kafkas/kafka-id",
+			responseStatus: http.StatusOK,
+		},
+		{
+			name:           "should not fail the request when the audit event cannot be stored",
+			mutatingOnly:   true,
+			method:         http.MethodDelete,
+			url:            "/kafkas/kafka-id",
+			responseStatus: http.StatusAccepted,
+			createErr:      errors.GeneralError("failed to store audit event"),
+			wantEvent: &api.AuditEvent{
+				Actor:      "test-user",
+				OrgId:      "test-org",
+				Method:     http.MethodDelete,
+				RouteName:  "delete-kafka",
+				Path:       "/kafkas/kafka-id",
+				TargetId:   "kafka-id",
+				StatusCode: http.StatusAccepted,
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			auditEvents := &audit.AuditEventServiceMock{
+				CreateFunc: func(event *api.AuditEvent) *errors.ServiceError {
+					return tt.createErr
+				},
+			}
+			var receivedBody []byte
+			next := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				// the body must still be readable by the handler
+				receivedBody, _ = io.ReadAll(request.Body)
+				writer.WriteHeader(tt.responseStatus)
+				_, _ = writer.Write([]byte(tt.responseBody))
+			})
+
+			router := mux.NewRouter()
+			router.Handle("/kafkas", next).Name(logger.NewLogEvent("create-kafka", "create a kafka instance").ToString()).Methods(http.MethodPost)
+			router.Handle("/kafkas/{id}", next).Name(logger.NewLogEvent("get-kafka", "get a kafka instance").ToString()).Methods(http.MethodGet)
+			router.Handle("/kafkas/{id}", next).Name(logger.NewLogEvent("update-kafka", "update a kafka instance").ToString()).Methods(http.MethodPatch)
+			router.Handle("/kafkas/{id}", next).Name(logger.NewLogEvent("delete-kafka", "delete a kafka instance").ToString()).Methods(http.MethodDelete)
+			auditLogMW := NewAuditLogMiddleware(auditEvents)
+			if tt.mutatingOnly {
+				router.Use(auditLogMW.AuditLogMutatingRequests(errors.ErrorUnauthenticated))
+			} else {
+				router.Use(auditLogMW.AuditLog(errors.ErrorNotFound))
+			}
+
+			toTest := setContextToken(router, &jwt.Token{Claims: jwt.MapClaims{
+				"username": "test-user",
+				"org_id":   "test-org",
+			}})
+			req := httptest.NewRequest(tt.method, "http://example.com"+tt.url, bytes.NewBufferString(tt.body))
+			recorder := httptest.NewRecorder()
+			toTest.ServeHTTP(recorder, req)
+			resp := recorder.Result()
+			_ = resp.Body.Close()
+
+			g.Expect(resp.StatusCode).To(gomega.Equal(tt.responseStatus))
+			g.Expect(string(receivedBody)).To(gomega.Equal(tt.body))
+			if tt.wantEvent == nil {
+				g.Expect(auditEvents.CreateCalls()).To(gomega.BeEmpty())
+				return
+			}
+			g.Expect(auditEvents.CreateCalls()).To(gomega.HaveLen(1))
+			g.Expect(auditEvents.CreateCalls()[0].Event).To(gomega.Equal(tt.wantEvent))
+		})
+	}
+}
@@ -27,6 +27,8 @@ type AWSClient interface {
 	ListHostedZonesByNameInput(dnsName string) (*route53.ListHostedZonesByNameOutput, error)
 	ChangeResourceRecordSets(dnsName string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error)
 	GetChange(changeId string) (*route53.GetChangeOutput, error)
+	// ListResourceRecordSets lists all the record sets of the hosted zone of the domain name
+	ListResourceRecordSets(dnsName string) ([]*route53.ResourceRecordSet, error)
 }
 
 type ClientFactory interface {
@@ -157,6 +159,28 @@ func (client *awsCl) ChangeResourceRecordSets(dnsName string, recordChangeBatch
 	return recordSetsOutput, nil
 }
 
+func (client *awsCl) ListResourceRecordSets(dnsName string) ([]*route53.ResourceRecordSet, error) {
+	zones, err := client.ListHostedZonesByNameInput(dnsName)
+	if err != nil {
+		return nil, err
+	}
+	if len(zones.HostedZones) == 0 {
+		return nil, fmt.Errorf("no Hosted Zones found")
+	}
+
+	var recordSets []*route53.ResourceRecordSet
+	err = client.route53Client.ListResourceRecordSetsPages(&route53.ListResourceRecordSetsInput{
+		HostedZoneId: zones.HostedZones[0].Id,
+	}, func(page *route53.ListResourceRecordSetsOutput, lastPage bool) bool {
+		recordSets = append(recordSets, page.ResourceRecordSets...)
+		return true
+	})
+	if err != nil {
+		return nil, wrapAWSError(err, "Failed to list DNS records.")
+	}
+	return recordSets, nil
+}
+
 func wrapAWSError(err error, msg string) error {
 	switch err.(type) {
 	case awserr.RequestFailure:
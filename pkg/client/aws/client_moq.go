@@ -27,6 +27,9 @@ var _ AWSClient = &AWSClientMock{}
 //			ListHostedZonesByNameInputFunc: func(dnsName string) (*route53.ListHostedZonesByNameOutput, error) {
 //				panic("mock out the ListHostedZonesByNameInput method")
 //			},
+//			ListResourceRecordSetsFunc: func(dnsName string) ([]*route53.ResourceRecordSet, error) {
+//				panic("mock out the ListResourceRecordSets method")
+//			},
 //		}
 //
 //		// use mockedAWSClient in code that requires AWSClient
@@ -43,6 +46,9 @@ type AWSClientMock struct {
 	// ListHostedZonesByNameInputFunc mocks the ListHostedZonesByNameInput method.
 	ListHostedZonesByNameInputFunc func(dnsName string) (*route53.ListHostedZonesByNameOutput, error)
 
+	// ListResourceRecordSetsFunc mocks the ListResourceRecordSets method.
+	ListResourceRecordSetsFunc func(dnsName string) ([]*route53.ResourceRecordSet, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// ChangeResourceRecordSets holds details about calls to the ChangeResourceRecordSets method.
@@ -62,10 +68,16 @@ type AWSClientMock struct {
 			// DnsName is the dnsName argument value.
 			DnsName string
 		}
+		// ListResourceRecordSets holds details about calls to the ListResourceRecordSets method.
+		ListResourceRecordSets []struct {
+			// DnsName is the dnsName argument value.
+			DnsName string
+		}
 	}
 	lockChangeResourceRecordSets   sync.RWMutex
 	lockGetChange                  sync.RWMutex
 	lockListHostedZonesByNameInput sync.RWMutex
+	lockListResourceRecordSets     sync.RWMutex
 }
 
 // ChangeResourceRecordSets calls ChangeResourceRecordSetsFunc.
@@ -167,3 +179,35 @@ func (mock *AWSClientMock) ListHostedZonesByNameInputCalls() []struct {
 	mock.lockListHostedZonesByNameInput.RUnlock()
 	return calls
 }
+
+// ListResourceRecordSets calls ListResourceRecordSetsFunc.
+func (mock *AWSClientMock) ListResourceRecordSets(dnsName string) ([]*route53.ResourceRecordSet, error) {
+	if mock.ListResourceRecordSetsFunc == nil {
+		panic("AWSClientMock.ListResourceRecordSetsFunc: method is nil but AWSClient.ListResourceRecordSets was just called")
+	}
+	callInfo := struct {
+		DnsName string
+	}{
+		DnsName: dnsName,
+	}
+	mock.lockListResourceRecordSets.Lock()
+	mock.calls.ListResourceRecordSets = append(mock.calls.ListResourceRecordSets, callInfo)
+	mock.lockListResourceRecordSets.Unlock()
+	return mock.ListResourceRecordSetsFunc(dnsName)
+}
+
+// ListResourceRecordSetsCalls gets all the calls that were made to ListResourceRecordSets.
+// Check the length with:
+//
+//	len(mockedAWSClient.ListResourceRecordSetsCalls())
+func (mock *AWSClientMock) ListResourceRecordSetsCalls() []struct {
+	DnsName string
+} {
+	var calls []struct {
+		DnsName string
+	}
+	mock.lockListResourceRecordSets.RLock()
+	calls = mock.calls.ListResourceRecordSets
+	mock.lockListResourceRecordSets.RUnlock()
+	return calls
+}
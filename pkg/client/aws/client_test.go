@@ -290,3 +290,71 @@ func TestAwsClient_ChangeResourceRecordSets(t *testing.T) {
 		})
 	}
 }
+
+func TestAwsClient_ListResourceRecordSets(t *testing.T) {
+	type fields struct {
+		route53Client route53iface.Route53API
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Should fail when ListHostedZonesByNameInput returns an empty list of hosted zones",
+			fields: fields{
+				route53Client: &Route53APIMock{
+					ListHostedZonesByNameFunc: func(in1 *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error) {
+						return &route53.ListHostedZonesByNameOutput{}, nil
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail when ListResourceRecordSetsPages returns an error",
+			fields: fields{
+				route53Client: &Route53APIMock{
+					ListHostedZonesByNameFunc: func(in1 *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error) {
+						return testHostedZones, nil
+					},
+					ListResourceRecordSetsPagesFunc: func(in1 *route53.ListResourceRecordSetsInput, fn func(*route53.ListResourceRecordSetsOutput, bool) bool) error {
+						return awsErr
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should return the record sets of all the pages",
+			fields: fields{
+				route53Client: &Route53APIMock{
+					ListHostedZonesByNameFunc: func(in1 *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error) {
+						return testHostedZones, nil
+					},
+					ListResourceRecordSetsPagesFunc: func(in1 *route53.ListResourceRecordSetsInput, fn func(*route53.ListResourceRecordSetsOutput, bool) bool) error {
+						if *in1.HostedZoneId != intString {
+							return awsErr
+						}
+						fn(&route53.ListResourceRecordSetsOutput{ResourceRecordSets: []*route53.ResourceRecordSet{{Name: &testValue}}}, false)
+						fn(&route53.ListResourceRecordSetsOutput{ResourceRecordSets: []*route53.ResourceRecordSet{{Name: &testValue}}}, true)
+						return nil
+					},
+				},
+			},
+			want: 2,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			awsClient := testClientFactory{}.NewClient(&tt.fields.route53Client)
+			recordSets, err := awsClient.ListResourceRecordSets(testValue)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(recordSets).To(gomega.HaveLen(tt.want))
+		})
+	}
+}
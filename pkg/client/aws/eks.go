@@ -0,0 +1,196 @@
+package aws
+
+import (
+	"encoding/base64"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client"
+	awscredentials "github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/eks/eksiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+)
+
+const (
+	// eksClusterIDHeader is the header that binds a presigned STS request to an EKS cluster.
+	// See https://github.com/kubernetes-sigs/aws-iam-authenticator#api-authorization-from-outside-a-cluster
+	eksClusterIDHeader = "x-k8s-aws-id"
+	// eksTokenPrefix is the prefix expected by the EKS authenticator for bearer tokens
+	eksTokenPrefix = "k8s-aws-v1."
+	// eksTokenPresignExpiration is the validity of the presigned URL. EKS tokens are valid for 15 minutes at most
+	eksTokenPresignExpiration = 60 * time.Second
+)
+
+//go:generate moq -out eks_moq.go . EKSClient
+type EKSClient interface {
+	CreateCluster(input *eks.CreateClusterInput) (*eks.Cluster, error)
+	// DescribeCluster returns the cluster with the given name. nil is returned if the cluster does not exist
+	DescribeCluster(clusterName string) (*eks.Cluster, error)
+	// DeleteCluster deletes the cluster with the given name. No error is returned if the cluster does not exist
+	DeleteCluster(clusterName string) error
+	CreateNodegroup(input *eks.CreateNodegroupInput) (*eks.Nodegroup, error)
+	// DescribeNodegroup returns the node group of the given cluster. nil is returned if the node group does not exist
+	DescribeNodegroup(clusterName string, nodegroupName string) (*eks.Nodegroup, error)
+	ListNodegroups(clusterName string) ([]string, error)
+	// DeleteNodegroup deletes the node group of the given cluster. No error is returned if the node group does not exist
+	DeleteNodegroup(clusterName string, nodegroupName string) error
+	// GetToken returns a bearer token that can be used to authenticate against the kubernetes API server of the given cluster
+	GetToken(clusterName string) (string, error)
+}
+
+type EKSClientFactory interface {
+	NewEKSClient(credentials Config, region string) (EKSClient, error)
+}
+
+type DefaultEKSClientFactory struct{}
+
+func (f *DefaultEKSClientFactory) NewEKSClient(credentials Config, region string) (EKSClient, error) {
+	return newEKSClient(credentials, region)
+}
+
+func NewDefaultEKSClientFactory() *DefaultEKSClientFactory {
+	return &DefaultEKSClientFactory{}
+}
+
+type MockEKSClientFactory struct {
+	mock EKSClient
+}
+
+func (m *MockEKSClientFactory) NewEKSClient(credentials Config, region string) (EKSClient, error) {
+	return m.mock, nil
+}
+
+func NewMockEKSClientFactory(client EKSClient) *MockEKSClientFactory {
+	return &MockEKSClientFactory{
+		mock: client,
+	}
+}
+
+var _ EKSClient = &eksCl{}
+
+type eksCl struct {
+	eksClient eksiface.EKSAPI
+	stsClient stsiface.STSAPI
+}
+
+func newEKSClient(credentials Config, region string) (EKSClient, error) {
+	cfg := &aws.Config{
+		Credentials: awscredentials.NewStaticCredentials(
+			credentials.AccessKeyID,
+			credentials.SecretAccessKey,
+			""),
+		Region:  aws.String(region),
+		Retryer: client.DefaultRetryer{NumMaxRetries: 2},
+	}
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &eksCl{
+		eksClient: eks.New(sess),
+		stsClient: sts.New(sess),
+	}, nil
+}
+
+func (client *eksCl) CreateCluster(input *eks.CreateClusterInput) (*eks.Cluster, error) {
+	output, err := client.eksClient.CreateCluster(input)
+	if err != nil {
+		return nil, wrapAWSError(err, "Failed to create EKS cluster.")
+	}
+	return output.Cluster, nil
+}
+
+func (client *eksCl) DescribeCluster(clusterName string) (*eks.Cluster, error) {
+	output, err := client.eksClient.DescribeCluster(&eks.DescribeClusterInput{
+		Name: aws.String(clusterName),
+	})
+	if err != nil {
+		if isEKSResourceNotFound(err) {
+			return nil, nil
+		}
+		return nil, wrapAWSError(err, "Failed to describe EKS cluster.")
+	}
+	return output.Cluster, nil
+}
+
+func (client *eksCl) DeleteCluster(clusterName string) error {
+	_, err := client.eksClient.DeleteCluster(&eks.DeleteClusterInput{
+		Name: aws.String(clusterName),
+	})
+	if err != nil && !isEKSResourceNotFound(err) {
+		return wrapAWSError(err, "Failed to delete EKS cluster.")
+	}
+	return nil
+}
+
+func (client *eksCl) CreateNodegroup(input *eks.CreateNodegroupInput) (*eks.Nodegroup, error) {
+	output, err := client.eksClient.CreateNodegroup(input)
+	if err != nil {
+		return nil, wrapAWSError(err, "Failed to create EKS node group.")
+	}
+	return output.Nodegroup, nil
+}
+
+func (client *eksCl) DescribeNodegroup(clusterName string, nodegroupName string) (*eks.Nodegroup, error) {
+	output, err := client.eksClient.DescribeNodegroup(&eks.DescribeNodegroupInput{
+		ClusterName:   aws.String(clusterName),
+		NodegroupName: aws.String(nodegroupName),
+	})
+	if err != nil {
+		if isEKSResourceNotFound(err) {
+			return nil, nil
+		}
+		return nil, wrapAWSError(err, "Failed to describe EKS node group.")
+	}
+	return output.Nodegroup, nil
+}
+
+func (client *eksCl) ListNodegroups(clusterName string) ([]string, error) {
+	var nodegroups []string
+	err := client.eksClient.ListNodegroupsPages(&eks.ListNodegroupsInput{
+		ClusterName: aws.String(clusterName),
+	}, func(page *eks.ListNodegroupsOutput, lastPage bool) bool {
+		nodegroups = append(nodegroups, aws.StringValueSlice(page.Nodegroups)...)
+		return true
+	})
+	if err != nil {
+		if isEKSResourceNotFound(err) {
+			return nodegroups, nil
+		}
+		return nil, wrapAWSError(err, "Failed to list EKS node groups.")
+	}
+	return nodegroups, nil
+}
+
+func (client *eksCl) DeleteNodegroup(clusterName string, nodegroupName string) error {
+	_, err := client.eksClient.DeleteNodegroup(&eks.DeleteNodegroupInput{
+		ClusterName:   aws.String(clusterName),
+		NodegroupName: aws.String(nodegroupName),
+	})
+	if err != nil && !isEKSResourceNotFound(err) {
+		return wrapAWSError(err, "Failed to delete EKS node group.")
+	}
+	return nil
+}
+
+// GetToken generates a token the same way the aws-iam-authenticator does: a presigned
+// sts:GetCallerIdentity request bound to the cluster name, base64 encoded.
+func (client *eksCl) GetToken(clusterName string) (string, error) {
+	request, _ := client.stsClient.GetCallerIdentityRequest(&sts.GetCallerIdentityInput{})
+	request.HTTPRequest.Header.Add(eksClusterIDHeader, clusterName)
+	presignedURL, err := request.Presign(eksTokenPresignExpiration)
+	if err != nil {
+		return "", wrapAWSError(err, "Failed to presign EKS token request.")
+	}
+
+	return eksTokenPrefix + base64.RawURLEncoding.EncodeToString([]byte(presignedURL)), nil
+}
+
+func isEKSResourceNotFound(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == eks.ErrCodeResourceNotFoundException
+}
@@ -0,0 +1,289 @@
+package dns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2/jwt"
+)
+
+const (
+	cloudDNSBaseURL = "https://dns.googleapis.com/dns/v1"
+	cloudDNSScope   = "https://www.googleapis.com/auth/ndev.clouddns.readwrite"
+
+	cloudDNSChangeStatusDone = "done"
+)
+
+type CloudDNSConfig struct {
+	ProjectID string
+	// ManagedZone is the name of the managed zone of the record sets, the managed zone is looked up by the domain
+	// name of the zone when it is empty
+	ManagedZone string
+	// BaseURL is the URL of the Cloud DNS API, it defaults to the Google Cloud one
+	BaseURL string
+}
+
+// GoogleServiceAccount holds the credentials of the Google Cloud service account of the Cloud DNS API
+type GoogleServiceAccount struct {
+	ClientEmail  string
+	PrivateKeyID string
+	PrivateKey   string
+	TokenURI     string
+}
+
+// NewCloudDNSHTTPClient returns a HTTP client authenticated as the Google Cloud service account
+func NewCloudDNSHTTPClient(serviceAccount GoogleServiceAccount) *http.Client {
+	config := &jwt.Config{
+		Email:        serviceAccount.ClientEmail,
+		PrivateKeyID: serviceAccount.PrivateKeyID,
+		PrivateKey:   []byte(serviceAccount.PrivateKey),
+		TokenURL:     serviceAccount.TokenURI,
+		Scopes:       []string{cloudDNSScope},
+	}
+	client := config.Client(context.Background())
+	client.Timeout = 30 * time.Second
+	return client
+}
+
+var _ Provider = &cloudDNSProvider{}
+
+// cloudDNSProvider manages the record sets of the Google Cloud DNS managed zones through the REST API
+type cloudDNSProvider struct {
+	config CloudDNSConfig
+	client *http.Client
+}
+
+type cloudDNSRecordSet struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	TTL     int64    `json:"ttl"`
+	RRDatas []string `json:"rrdatas"`
+}
+
+type cloudDNSChange struct {
+	ID        string              `json:"id,omitempty"`
+	Status    string              `json:"status,omitempty"`
+	Additions []cloudDNSRecordSet `json:"additions,omitempty"`
+	Deletions []cloudDNSRecordSet `json:"deletions,omitempty"`
+}
+
+// NewCloudDNSProvider returns a provider managing the record sets of the Cloud DNS managed zones of the project with
+// the given authenticated client
+func NewCloudDNSProvider(config CloudDNSConfig, client *http.Client) Provider {
+	if config.BaseURL == "" {
+		config.BaseURL = cloudDNSBaseURL
+	}
+	return &cloudDNSProvider{
+		config: config,
+		client: client,
+	}
+}
+
+func (p *cloudDNSProvider) Kind() string {
+	return KindCloudDNS
+}
+
+func (p *cloudDNSProvider) ChangeRecordSets(zone string, action ChangeAction, recordSets []RecordSet) (*Change, error) {
+	managedZone, err := p.managedZone(zone)
+	if err != nil {
+		return nil, err
+	}
+
+	// the record sets are replaced by deleting their current values, which must be given as they are
+	change := &cloudDNSChange{}
+	for _, recordSet := range recordSets {
+		current, err := p.getRecordSet(managedZone, fqdn(recordSet.Name), recordSet.Type)
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case ChangeActionCreate:
+			if current == nil {
+				change.Additions = append(change.Additions, toCloudDNSRecordSet(recordSet))
+			}
+		case ChangeActionUpsert:
+			if current != nil {
+				change.Deletions = append(change.Deletions, *current)
+			}
+			change.Additions = append(change.Additions, toCloudDNSRecordSet(recordSet))
+		case ChangeActionDelete:
+			if current != nil {
+				change.Deletions = append(change.Deletions, *current)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported change action %q", action)
+		}
+	}
+	if len(change.Additions) == 0 && len(change.Deletions) == 0 {
+		return &Change{ID: QualifyChangeID(KindCloudDNS, ""), Status: ChangeStatusInSync}, nil
+	}
+
+	var created cloudDNSChange
+	if err := p.do(http.MethodPost, fmt.Sprintf("/managedZones/%s/changes", managedZone), nil, change, &created); err != nil {
+		return nil, errors.Wrapf(err, "failed to change the record sets of Cloud DNS managed zone %q", managedZone)
+	}
+	return p.toChange(managedZone, &created), nil
+}
+
+func (p *cloudDNSProvider) GetChange(changeID string) (*Change, error) {
+	_, id := ParseChangeID(changeID)
+	if id == "" {
+		return &Change{ID: changeID, Status: ChangeStatusInSync}, nil
+	}
+	// the ids of the changes are only unique within their managed zone
+	i := strings.Index(id, "/")
+	if i < 0 {
+		return nil, fmt.Errorf("invalid Cloud DNS change id %q", id)
+	}
+	managedZone := id[:i]
+
+	var change cloudDNSChange
+	if err := p.do(http.MethodGet, fmt.Sprintf("/managedZones/%s/changes/%s", managedZone, id[i+1:]), nil, nil, &change); err != nil {
+		return nil, errors.Wrapf(err, "failed to get the status of Cloud DNS change %q", id)
+	}
+	return p.toChange(managedZone, &change), nil
+}
+
+func (p *cloudDNSProvider) ListRecordSets(zone string, recordType string) ([]RecordSet, error) {
+	managedZone, err := p.managedZone(zone)
+	if err != nil {
+		return nil, err
+	}
+
+	var recordSets []RecordSet
+	query := url.Values{}
+	for {
+		var result struct {
+			RRSets        []cloudDNSRecordSet `json:"rrsets"`
+			NextPageToken string              `json:"nextPageToken"`
+		}
+		if err := p.do(http.MethodGet, fmt.Sprintf("/managedZones/%s/rrsets", managedZone), query, nil, &result); err != nil {
+			return nil, errors.Wrapf(err, "failed to list the record sets of Cloud DNS managed zone %q", managedZone)
+		}
+		for _, rrset := range result.RRSets {
+			// the record sets can only be filtered by type together with their name
+			if rrset.Type == recordType {
+				recordSets = append(recordSets, fromCloudDNSRecordSet(rrset))
+			}
+		}
+		if result.NextPageToken == "" {
+			return recordSets, nil
+		}
+		query.Set("pageToken", result.NextPageToken)
+	}
+}
+
+func (p *cloudDNSProvider) toChange(managedZone string, change *cloudDNSChange) *Change {
+	status := ChangeStatusPending
+	if change.Status == cloudDNSChangeStatusDone {
+		status = ChangeStatusInSync
+	}
+	return &Change{
+		ID:     QualifyChangeID(KindCloudDNS, managedZone+"/"+change.ID),
+		Status: status,
+	}
+}
+
+func (p *cloudDNSProvider) managedZone(zone string) (string, error) {
+	if p.config.ManagedZone != "" {
+		return p.config.ManagedZone, nil
+	}
+	var result struct {
+		ManagedZones []struct {
+			Name string `json:"name"`
+		} `json:"managedZones"`
+	}
+	if err := p.do(http.MethodGet, "/managedZones", url.Values{"dnsName": {fqdn(zone)}}, nil, &result); err != nil {
+		return "", errors.Wrapf(err, "failed to find the Cloud DNS managed zone of %q", zone)
+	}
+	if len(result.ManagedZones) == 0 {
+		return "", fmt.Errorf("no Cloud DNS managed zone found for %q", zone)
+	}
+	return result.ManagedZones[0].Name, nil
+}
+
+func (p *cloudDNSProvider) getRecordSet(managedZone string, name string, recordType string) (*cloudDNSRecordSet, error) {
+	var result struct {
+		RRSets []cloudDNSRecordSet `json:"rrsets"`
+	}
+	query := url.Values{"name": {name}, "type": {recordType}}
+	if err := p.do(http.MethodGet, fmt.Sprintf("/managedZones/%s/rrsets", managedZone), query, nil, &result); err != nil {
+		return nil, errors.Wrapf(err, "failed to get the %s record set %q of Cloud DNS managed zone %q", recordType, name, managedZone)
+	}
+	if len(result.RRSets) == 0 {
+		return nil, nil
+	}
+	return &result.RRSets[0], nil
+}
+
+func (p *cloudDNSProvider) do(method string, path string, query url.Values, in interface{}, out interface{}) error {
+	endpoint := fmt.Sprintf("%s/projects/%s%s", p.config.BaseURL, url.PathEscape(p.config.ProjectID), path)
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status %d from the Cloud DNS API: %s", resp.StatusCode, strings.TrimSpace(string(b)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func toCloudDNSRecordSet(recordSet RecordSet) cloudDNSRecordSet {
+	values := make([]string, 0, len(recordSet.Values))
+	for _, value := range recordSet.Values {
+		// the targets of the CNAME records must be fully qualified
+		if recordSet.Type == RecordTypeCNAME {
+			value = fqdn(value)
+		}
+		values = append(values, value)
+	}
+	return cloudDNSRecordSet{
+		Name:    fqdn(recordSet.Name),
+		Type:    recordSet.Type,
+		TTL:     recordSet.TTL,
+		RRDatas: values,
+	}
+}
+
+func fromCloudDNSRecordSet(rrset cloudDNSRecordSet) RecordSet {
+	values := make([]string, 0, len(rrset.RRDatas))
+	for _, value := range rrset.RRDatas {
+		values = append(values, unqualify(value))
+	}
+	return RecordSet{
+		Name:   unqualify(rrset.Name),
+		Type:   rrset.Type,
+		TTL:    rrset.TTL,
+		Values: values,
+	}
+}
@@ -0,0 +1,133 @@
+package dns
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+// fakeCloudDNS serves the Cloud DNS API of a project with a single managed zone
+type fakeCloudDNS struct {
+	rrsets  map[string]cloudDNSRecordSet
+	changes []cloudDNSChange
+}
+
+func (f *fakeCloudDNS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const prefix = "/projects/test-project/managedZones"
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == prefix:
+		zones := []map[string]string{}
+		if r.URL.Query().Get("dnsName") == "kafka.example.com." {
+			zones = append(zones, map[string]string{"name": "kafka-zone"})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"managedZones": zones})
+	case r.Method == http.MethodGet && r.URL.Path == prefix+"/kafka-zone/rrsets" && r.URL.Query().Get("name") == "":
+		// the record sets are listed one per page, sorted by name and type
+		var keys []string
+		for key := range f.rrsets {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		page := 0
+		if token := r.URL.Query().Get("pageToken"); token != "" {
+			page, _ = strconv.Atoi(token)
+		}
+		result := map[string]interface{}{"rrsets": []cloudDNSRecordSet{}}
+		if page < len(keys) {
+			result["rrsets"] = []cloudDNSRecordSet{f.rrsets[keys[page]]}
+		}
+		if page+1 < len(keys) {
+			result["nextPageToken"] = strconv.Itoa(page + 1)
+		}
+		_ = json.NewEncoder(w).Encode(result)
+	case r.Method == http.MethodGet && r.URL.Path == prefix+"/kafka-zone/rrsets":
+		rrsets := []cloudDNSRecordSet{}
+		if rrset, ok := f.rrsets[r.URL.Query().Get("name")+r.URL.Query().Get("type")]; ok {
+			rrsets = append(rrsets, rrset)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"rrsets": rrsets})
+	case r.Method == http.MethodPost && r.URL.Path == prefix+"/kafka-zone/changes":
+		var change cloudDNSChange
+		if err := json.NewDecoder(r.Body).Decode(&change); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for _, rrset := range change.Deletions {
+			if _, ok := f.rrsets[rrset.Name+rrset.Type]; !ok {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			delete(f.rrsets, rrset.Name+rrset.Type)
+		}
+		for _, rrset := range change.Additions {
+			if _, ok := f.rrsets[rrset.Name+rrset.Type]; ok {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			f.rrsets[rrset.Name+rrset.Type] = rrset
+		}
+		change.ID = "1"
+		change.Status = "pending"
+		f.changes = append(f.changes, change)
+		_ = json.NewEncoder(w).Encode(change)
+	case r.Method == http.MethodGet && r.URL.Path == prefix+"/kafka-zone/changes/1":
+		_ = json.NewEncoder(w).Encode(cloudDNSChange{ID: "1", Status: cloudDNSChangeStatusDone})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func Test_cloudDNSProvider(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	fake := &fakeCloudDNS{rrsets: map[string]cloudDNSRecordSet{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	provider := NewCloudDNSProvider(CloudDNSConfig{ProjectID: "test-project", BaseURL: server.URL}, server.Client())
+	recordSet := func(target string) []RecordSet {
+		return []RecordSet{{Name: "bootstrap.kafka.example.com", Type: RecordTypeCNAME, TTL: 300, Values: []string{target}}}
+	}
+
+	change, err := provider.ChangeRecordSets("kafka.example.com", ChangeActionCreate, recordSet("router.example.com"))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(change).To(gomega.Equal(&Change{ID: "clouddns:kafka-zone/1", Status: ChangeStatusPending}))
+	g.Expect(fake.rrsets).To(gomega.HaveKeyWithValue("bootstrap.kafka.example.com.CNAME", cloudDNSRecordSet{
+		Name: "bootstrap.kafka.example.com.", Type: RecordTypeCNAME, TTL: 300, RRDatas: []string{"router.example.com."},
+	}))
+
+	change, err = provider.GetChange(change.ID)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(change.Status).To(gomega.Equal(ChangeStatusInSync))
+
+	// creating the record sets again leaves them unchanged
+	change, err = provider.ChangeRecordSets("kafka.example.com", ChangeActionCreate, recordSet("other.example.com"))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(change.Status).To(gomega.Equal(ChangeStatusInSync))
+	g.Expect(fake.changes).To(gomega.HaveLen(1))
+
+	_, err = provider.ChangeRecordSets("kafka.example.com", ChangeActionUpsert, recordSet("other.example.com"))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(fake.rrsets["bootstrap.kafka.example.com.CNAME"].RRDatas).To(gomega.Equal([]string{"other.example.com."}))
+
+	fake.rrsets["kafka.example.com.SOA"] = cloudDNSRecordSet{Name: "kafka.example.com.", Type: "SOA", TTL: 900, RRDatas: []string{"ns.example.com. admin.example.com. 1 21600 3600 259200 300"}}
+	recordSets, err := provider.ListRecordSets("kafka.example.com", RecordTypeCNAME)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(recordSets).To(gomega.Equal([]RecordSet{
+		{Name: "bootstrap.kafka.example.com", Type: RecordTypeCNAME, TTL: 300, Values: []string{"other.example.com"}},
+	}))
+	delete(fake.rrsets, "kafka.example.com.SOA")
+
+	_, err = provider.ChangeRecordSets("kafka.example.com", ChangeActionDelete, recordSet("other.example.com"))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(fake.rrsets).To(gomega.BeEmpty())
+
+	_, err = provider.ChangeRecordSets("unknown.example.com", ChangeActionCreate, recordSet("router.example.com"))
+	g.Expect(err).To(gomega.HaveOccurred())
+}
@@ -0,0 +1,97 @@
+package dns
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	KindRoute53  = "route53"
+	KindCloudDNS = "clouddns"
+	KindRFC2136  = "rfc2136"
+
+	RecordTypeCNAME = "CNAME"
+)
+
+// Kinds lists the kinds of the DNS providers
+var Kinds = []string{KindRoute53, KindCloudDNS, KindRFC2136}
+
+type ChangeAction string
+
+const (
+	// ChangeActionCreate creates the record sets, the record sets which already exist are left unchanged
+	ChangeActionCreate ChangeAction = "CREATE"
+	// ChangeActionUpsert creates the record sets or replaces their values when they already exist
+	ChangeActionUpsert ChangeAction = "UPSERT"
+	// ChangeActionDelete deletes the record sets, the record sets which do not exist are ignored
+	ChangeActionDelete ChangeAction = "DELETE"
+)
+
+type ChangeStatus string
+
+const (
+	// ChangeStatusPending is the status of the changes which are not served by all the name servers yet
+	ChangeStatusPending ChangeStatus = "PENDING"
+	// ChangeStatusInSync is the status of the changes served by all the name servers
+	ChangeStatusInSync ChangeStatus = "INSYNC"
+)
+
+type RecordSet struct {
+	// Name is the fully qualified domain name of the records
+	Name   string
+	Type   string
+	TTL    int64
+	Values []string
+}
+
+// Change tracks the propagation of a change of record sets
+type Change struct {
+	// ID identifies the change, it is qualified by the kind of the provider which made it, see QualifyChangeID
+	ID     string
+	Status ChangeStatus
+}
+
+// Provider manages the record sets of the zones of a DNS service
+//
+//go:generate moq -out provider_moq.go . Provider
+type Provider interface {
+	// Kind returns the kind of the provider e.g. KindRoute53
+	Kind() string
+	// ChangeRecordSets applies the action to the record sets of the zone, the zone being its domain name
+	ChangeRecordSets(zone string, action ChangeAction, recordSets []RecordSet) (*Change, error)
+	// GetChange returns the status of the change with the given qualified id
+	GetChange(changeID string) (*Change, error)
+	// ListRecordSets lists the record sets of the given type of the zone. The names of the record sets and the targets
+	// of the CNAME records are returned without their terminating dot.
+	ListRecordSets(zone string, recordType string) ([]RecordSet, error)
+}
+
+// QualifyChangeID qualifies the id of a change made by a provider of the given kind, so that its status can still be
+// tracked once the provider of the record sets is changed
+func QualifyChangeID(kind string, id string) string {
+	return fmt.Sprintf("%s:%s", kind, id)
+}
+
+// ParseChangeID returns the kind of the provider which made the change and the id of the change. The ids of the
+// changes made before the providers were pluggable are not qualified, they are the ids of Route53 changes.
+func ParseChangeID(changeID string) (kind string, id string) {
+	for _, k := range Kinds {
+		if strings.HasPrefix(changeID, k+":") {
+			return k, strings.TrimPrefix(changeID, k+":")
+		}
+	}
+	return KindRoute53, changeID
+}
+
+// unqualify returns the domain name without its terminating dot
+func unqualify(name string) string {
+	return strings.TrimSuffix(name, ".")
+}
+
+// fqdn returns the domain name terminated by a dot, the format used by the DNS services
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
@@ -0,0 +1,51 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_ParseChangeID(t *testing.T) {
+	tests := []struct {
+		name     string
+		changeID string
+		wantKind string
+		wantID   string
+	}{
+		{
+			name:     "should parse the id of a Route53 change",
+			changeID: "route53:/change/C2682N5HXP0BZ4",
+			wantKind: KindRoute53,
+			wantID:   "/change/C2682N5HXP0BZ4",
+		},
+		{
+			name:     "should parse the id of a Cloud DNS change",
+			changeID: "clouddns:kafka-zone/42",
+			wantKind: KindCloudDNS,
+			wantID:   "kafka-zone/42",
+		},
+		{
+			name:     "should parse the id of a RFC 2136 change",
+			changeID: QualifyChangeID(KindRFC2136, "c9c1a5a4"),
+			wantKind: KindRFC2136,
+			wantID:   "c9c1a5a4",
+		},
+		{
+			name:     "should parse the ids of the changes made before the providers were pluggable as Route53 ones",
+			changeID: "/change/C2682N5HXP0BZ4",
+			wantKind: KindRoute53,
+			wantID:   "/change/C2682N5HXP0BZ4",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			kind, id := ParseChangeID(tt.changeID)
+			g.Expect(kind).To(gomega.Equal(tt.wantKind))
+			g.Expect(id).To(gomega.Equal(tt.wantID))
+		})
+	}
+}
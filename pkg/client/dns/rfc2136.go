@@ -0,0 +1,218 @@
+package dns
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	miekgdns "github.com/miekg/dns"
+	"github.com/pkg/errors"
+)
+
+const defaultRFC2136TSIGAlgorithm = miekgdns.HmacSHA256
+
+type RFC2136Config struct {
+	// Nameserver is the address, as host:port, of the primary name server of the zones accepting the dynamic updates
+	Nameserver string
+	// TSIGKeyName is the name of the key signing the updates, the updates are not signed when it is empty
+	TSIGKeyName string
+	// TSIGSecret is the base64 encoded secret of the TSIG key
+	TSIGSecret string
+	// TSIGAlgorithm is the algorithm of the TSIG key e.g. hmac-sha256, it defaults to hmac-sha256
+	TSIGAlgorithm string
+	Timeout       time.Duration
+}
+
+var _ Provider = &rfc2136Provider{}
+
+// rfc2136Provider manages the record sets of the zones of a name server accepting the dynamic updates of RFC 2136,
+// e.g. BIND or PowerDNS
+type rfc2136Provider struct {
+	config RFC2136Config
+	client *miekgdns.Client
+}
+
+// NewRFC2136Provider returns a provider managing the record sets through dynamic updates of the name server
+func NewRFC2136Provider(config RFC2136Config) Provider {
+	client := &miekgdns.Client{
+		Net:     "tcp",
+		Timeout: config.Timeout,
+	}
+	if config.TSIGKeyName != "" {
+		if config.TSIGAlgorithm == "" {
+			config.TSIGAlgorithm = defaultRFC2136TSIGAlgorithm
+		}
+		config.TSIGKeyName = strings.ToLower(fqdn(config.TSIGKeyName))
+		config.TSIGAlgorithm = fqdn(config.TSIGAlgorithm)
+		client.TsigSecret = map[string]string{config.TSIGKeyName: config.TSIGSecret}
+	}
+	return &rfc2136Provider{
+		config: config,
+		client: client,
+	}
+}
+
+func (p *rfc2136Provider) Kind() string {
+	return KindRFC2136
+}
+
+func (p *rfc2136Provider) ChangeRecordSets(zone string, action ChangeAction, recordSets []RecordSet) (*Change, error) {
+	msg := new(miekgdns.Msg)
+	msg.SetUpdate(fqdn(zone))
+	for _, recordSet := range recordSets {
+		rrs, err := toRRs(recordSet)
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case ChangeActionCreate:
+			exists, err := p.recordSetExists(recordSet)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				msg.Insert(rrs)
+			}
+		case ChangeActionUpsert:
+			msg.RemoveRRset(rrs)
+			msg.Insert(rrs)
+		case ChangeActionDelete:
+			// removing a record set which does not exist is a no-op
+			msg.RemoveRRset(rrs)
+		default:
+			return nil, fmt.Errorf("unsupported change action %q", action)
+		}
+	}
+
+	if len(msg.Ns) > 0 {
+		if _, err := p.exchange(msg); err != nil {
+			return nil, errors.Wrapf(err, "failed to update the record sets of zone %q", zone)
+		}
+	}
+	// the updates are applied by the primary name server before it answers, leaving nothing to track
+	return &Change{
+		ID:     QualifyChangeID(KindRFC2136, uuid.New().String()),
+		Status: ChangeStatusInSync,
+	}, nil
+}
+
+func (p *rfc2136Provider) GetChange(changeID string) (*Change, error) {
+	return &Change{ID: changeID, Status: ChangeStatusInSync}, nil
+}
+
+// ListRecordSets lists the record sets through a zone transfer, which the name server must allow to the TSIG key
+func (p *rfc2136Provider) ListRecordSets(zone string, recordType string) ([]RecordSet, error) {
+	rrType, ok := miekgdns.StringToType[recordType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported record type %q", recordType)
+	}
+
+	msg := new(miekgdns.Msg)
+	msg.SetAxfr(fqdn(zone))
+	transfer := &miekgdns.Transfer{
+		DialTimeout:  p.config.Timeout,
+		ReadTimeout:  p.config.Timeout,
+		WriteTimeout: p.config.Timeout,
+	}
+	if p.config.TSIGKeyName != "" {
+		msg.SetTsig(p.config.TSIGKeyName, p.config.TSIGAlgorithm, 300, time.Now().Unix())
+		transfer.TsigSecret = p.client.TsigSecret
+	}
+	envelopes, err := transfer.In(msg, p.config.Nameserver)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to transfer zone %q", zone)
+	}
+
+	var names []string
+	recordSets := map[string]*RecordSet{}
+	var transferErr error
+	// the envelopes are drained on error so that the transfer does not leak
+	for envelope := range envelopes {
+		if envelope.Error != nil {
+			transferErr = envelope.Error
+			continue
+		}
+		for _, rr := range envelope.RR {
+			header := rr.Header()
+			if header.Rrtype != rrType {
+				continue
+			}
+			name := unqualify(header.Name)
+			recordSet, ok := recordSets[name]
+			if !ok {
+				recordSet = &RecordSet{Name: name, Type: recordType, TTL: int64(header.Ttl)}
+				recordSets[name] = recordSet
+				names = append(names, name)
+			}
+			recordSet.Values = append(recordSet.Values, rrValue(rr))
+		}
+	}
+	if transferErr != nil {
+		return nil, errors.Wrapf(transferErr, "failed to transfer zone %q", zone)
+	}
+
+	result := make([]RecordSet, 0, len(names))
+	for _, name := range names {
+		result = append(result, *recordSets[name])
+	}
+	return result, nil
+}
+
+func (p *rfc2136Provider) recordSetExists(recordSet RecordSet) (bool, error) {
+	recordType, ok := miekgdns.StringToType[recordSet.Type]
+	if !ok {
+		return false, fmt.Errorf("unsupported record type %q", recordSet.Type)
+	}
+	msg := new(miekgdns.Msg)
+	msg.SetQuestion(fqdn(recordSet.Name), recordType)
+	answer, err := p.exchange(msg)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get the %s record set %q", recordSet.Type, recordSet.Name)
+	}
+	for _, rr := range answer.Answer {
+		if rr.Header().Rrtype == recordType && strings.EqualFold(rr.Header().Name, fqdn(recordSet.Name)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (p *rfc2136Provider) exchange(msg *miekgdns.Msg) (*miekgdns.Msg, error) {
+	if p.config.TSIGKeyName != "" {
+		msg.SetTsig(p.config.TSIGKeyName, p.config.TSIGAlgorithm, 300, time.Now().Unix())
+	}
+	answer, _, err := p.client.Exchange(msg, p.config.Nameserver)
+	if err != nil {
+		return nil, err
+	}
+	// NXDOMAIN answers the queries of the record sets which do not exist
+	if answer.Rcode != miekgdns.RcodeSuccess && answer.Rcode != miekgdns.RcodeNameError {
+		return nil, fmt.Errorf("name server %q answered %s", p.config.Nameserver, miekgdns.RcodeToString[answer.Rcode])
+	}
+	return answer, nil
+}
+
+func toRRs(recordSet RecordSet) ([]miekgdns.RR, error) {
+	rrs := make([]miekgdns.RR, 0, len(recordSet.Values))
+	for _, value := range recordSet.Values {
+		if recordSet.Type == RecordTypeCNAME {
+			value = fqdn(value)
+		}
+		rr, err := miekgdns.NewRR(fmt.Sprintf("%s %d IN %s %s", fqdn(recordSet.Name), recordSet.TTL, recordSet.Type, value))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s record %q", recordSet.Type, recordSet.Name)
+		}
+		rrs = append(rrs, rr)
+	}
+	return rrs, nil
+}
+
+// rrValue returns the data of the record as presented in a zone file, without the terminating dot of the targets of
+// the CNAME records
+func rrValue(rr miekgdns.RR) string {
+	if cname, ok := rr.(*miekgdns.CNAME); ok {
+		return unqualify(cname.Target)
+	}
+	return strings.TrimSpace(strings.TrimPrefix(rr.String(), rr.Header().String()))
+}
@@ -0,0 +1,154 @@
+package dns
+
+import (
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	miekgdns "github.com/miekg/dns"
+	"github.com/onsi/gomega"
+)
+
+const (
+	testTSIGKeyName = "kas-fleet-manager."
+	testTSIGSecret  = "c2VjcmV0LWtleS1vZi10aGUtdGVzdHM="
+)
+
+// fakeNameServer is a primary name server of a zone accepting the dynamic updates signed with the TSIG key of the tests
+type fakeNameServer struct {
+	mu      sync.Mutex
+	records map[string][]miekgdns.RR
+	updates int
+}
+
+func (s *fakeNameServer) ServeDNS(w miekgdns.ResponseWriter, r *miekgdns.Msg) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := new(miekgdns.Msg)
+	m.SetReply(r)
+	switch {
+	case r.IsTsig() == nil || w.TsigStatus() != nil:
+		m.Rcode = miekgdns.RcodeNotAuth
+	case r.Opcode == miekgdns.OpcodeUpdate:
+		s.updates++
+		for _, rr := range r.Ns {
+			key := strings.ToLower(rr.Header().Name) + miekgdns.TypeToString[rr.Header().Rrtype]
+			if rr.Header().Class == miekgdns.ClassANY {
+				delete(s.records, key)
+			} else {
+				s.records[key] = append(s.records[key], rr)
+			}
+		}
+	case r.Question[0].Qtype == miekgdns.TypeAXFR:
+		soa, _ := miekgdns.NewRR(r.Question[0].Name + " 900 IN SOA ns.example.com. admin.example.com. 1 21600 3600 259200 300")
+		m.Answer = append(m.Answer, soa)
+		for _, rrs := range s.records {
+			m.Answer = append(m.Answer, rrs...)
+		}
+		m.Answer = append(m.Answer, soa)
+	default:
+		q := r.Question[0]
+		rrs, ok := s.records[strings.ToLower(q.Name)+miekgdns.TypeToString[q.Qtype]]
+		if !ok {
+			m.Rcode = miekgdns.RcodeNameError
+		}
+		m.Answer = rrs
+	}
+	if r.IsTsig() != nil {
+		m.SetTsig(testTSIGKeyName, miekgdns.HmacSHA256, 300, time.Now().Unix())
+	}
+	_ = w.WriteMsg(m)
+}
+
+func startFakeNameServer(t *testing.T) (*fakeNameServer, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake := &fakeNameServer{records: map[string][]miekgdns.RR{}}
+	server := &miekgdns.Server{
+		Listener:   listener,
+		Handler:    fake,
+		TsigSecret: map[string]string{testTSIGKeyName: testTSIGSecret},
+		// the default accept function refuses the dynamic updates
+		MsgAcceptFunc: func(dh miekgdns.Header) miekgdns.MsgAcceptAction {
+			return miekgdns.MsgAccept
+		},
+	}
+	go func() {
+		_ = server.ActivateAndServe()
+	}()
+	t.Cleanup(func() {
+		_ = server.Shutdown()
+	})
+	return fake, listener.Addr().String()
+}
+
+func Test_rfc2136Provider(t *testing.T) {
+	g := gomega.NewWithT(t)
+	fake, addr := startFakeNameServer(t)
+
+	provider := NewRFC2136Provider(RFC2136Config{
+		Nameserver:  addr,
+		TSIGKeyName: "kas-fleet-manager",
+		TSIGSecret:  testTSIGSecret,
+		Timeout:     5 * time.Second,
+	})
+	recordSet := func(target string) []RecordSet {
+		return []RecordSet{{Name: "bootstrap.kafka.example.com", Type: RecordTypeCNAME, TTL: 300, Values: []string{target}}}
+	}
+	target := func() string {
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		rrs := fake.records["bootstrap.kafka.example.com.CNAME"]
+		if len(rrs) != 1 {
+			return ""
+		}
+		return rrs[0].(*miekgdns.CNAME).Target
+	}
+
+	change, err := provider.ChangeRecordSets("kafka.example.com", ChangeActionCreate, recordSet("router.example.com"))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(change.Status).To(gomega.Equal(ChangeStatusInSync))
+	g.Expect(target()).To(gomega.Equal("router.example.com."))
+
+	kind, _ := ParseChangeID(change.ID)
+	g.Expect(kind).To(gomega.Equal(KindRFC2136))
+	change, err = provider.GetChange(change.ID)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(change.Status).To(gomega.Equal(ChangeStatusInSync))
+
+	// creating the record sets again leaves them unchanged
+	_, err = provider.ChangeRecordSets("kafka.example.com", ChangeActionCreate, recordSet("other.example.com"))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(target()).To(gomega.Equal("router.example.com."))
+	g.Expect(fake.updates).To(gomega.Equal(1))
+
+	_, err = provider.ChangeRecordSets("kafka.example.com", ChangeActionUpsert, recordSet("other.example.com"))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(target()).To(gomega.Equal("other.example.com."))
+
+	recordSets, err := provider.ListRecordSets("kafka.example.com", RecordTypeCNAME)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(recordSets).To(gomega.Equal([]RecordSet{
+		{Name: "bootstrap.kafka.example.com", Type: RecordTypeCNAME, TTL: 300, Values: []string{"other.example.com"}},
+	}))
+
+	_, err = provider.ChangeRecordSets("kafka.example.com", ChangeActionDelete, recordSet("other.example.com"))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(target()).To(gomega.BeEmpty())
+}
+
+func Test_rfc2136Provider_UnsignedUpdatesAreRefused(t *testing.T) {
+	g := gomega.NewWithT(t)
+	_, addr := startFakeNameServer(t)
+
+	provider := NewRFC2136Provider(RFC2136Config{Nameserver: addr, Timeout: 5 * time.Second})
+	_, err := provider.ChangeRecordSets("kafka.example.com", ChangeActionUpsert, []RecordSet{
+		{Name: "bootstrap.kafka.example.com", Type: RecordTypeCNAME, TTL: 300, Values: []string{"router.example.com"}},
+	})
+	g.Expect(err).To(gomega.HaveOccurred())
+}
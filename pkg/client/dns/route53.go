@@ -0,0 +1,94 @@
+package dns
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	awsclient "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/aws"
+	"github.com/pkg/errors"
+)
+
+var _ Provider = &route53Provider{}
+
+type route53Provider struct {
+	client awsclient.AWSClient
+}
+
+// NewRoute53Provider returns a provider managing the record sets of the Route53 hosted zones
+func NewRoute53Provider(client awsclient.AWSClient) Provider {
+	return &route53Provider{client: client}
+}
+
+func (p *route53Provider) Kind() string {
+	return KindRoute53
+}
+
+func (p *route53Provider) ChangeRecordSets(zone string, action ChangeAction, recordSets []RecordSet) (*Change, error) {
+	batch := &route53.ChangeBatch{}
+	for _, recordSet := range recordSets {
+		records := make([]*route53.ResourceRecord, 0, len(recordSet.Values))
+		for _, value := range recordSet.Values {
+			records = append(records, &route53.ResourceRecord{Value: aws.String(value)})
+		}
+		batch.Changes = append(batch.Changes, &route53.Change{
+			Action: aws.String(string(action)),
+			ResourceRecordSet: &route53.ResourceRecordSet{
+				Name:            aws.String(recordSet.Name),
+				Type:            aws.String(recordSet.Type),
+				TTL:             aws.Int64(recordSet.TTL),
+				ResourceRecords: records,
+			},
+		})
+	}
+
+	output, err := p.client.ChangeResourceRecordSets(zone, batch)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to change the record sets of Route53 hosted zone %q", zone)
+	}
+	// the client ignores the record sets which already exist or do not exist, leaving nothing to track
+	if output == nil || output.ChangeInfo == nil {
+		return &Change{ID: QualifyChangeID(KindRoute53, ""), Status: ChangeStatusInSync}, nil
+	}
+	return &Change{
+		ID:     QualifyChangeID(KindRoute53, aws.StringValue(output.ChangeInfo.Id)),
+		Status: ChangeStatus(aws.StringValue(output.ChangeInfo.Status)),
+	}, nil
+}
+
+func (p *route53Provider) GetChange(changeID string) (*Change, error) {
+	_, id := ParseChangeID(changeID)
+	if id == "" {
+		return &Change{ID: changeID, Status: ChangeStatusInSync}, nil
+	}
+	output, err := p.client.GetChange(id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the status of Route53 change %q", id)
+	}
+	return &Change{
+		ID:     changeID,
+		Status: ChangeStatus(aws.StringValue(output.ChangeInfo.Status)),
+	}, nil
+}
+
+func (p *route53Provider) ListRecordSets(zone string, recordType string) ([]RecordSet, error) {
+	output, err := p.client.ListResourceRecordSets(zone)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the record sets of Route53 hosted zone %q", zone)
+	}
+	var recordSets []RecordSet
+	for _, recordSet := range output {
+		if aws.StringValue(recordSet.Type) != recordType {
+			continue
+		}
+		values := make([]string, 0, len(recordSet.ResourceRecords))
+		for _, record := range recordSet.ResourceRecords {
+			values = append(values, unqualify(aws.StringValue(record.Value)))
+		}
+		recordSets = append(recordSets, RecordSet{
+			Name:   unqualify(aws.StringValue(recordSet.Name)),
+			Type:   recordType,
+			TTL:    aws.Int64Value(recordSet.TTL),
+			Values: values,
+		})
+	}
+	return recordSets, nil
+}
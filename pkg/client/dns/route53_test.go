@@ -0,0 +1,146 @@
+package dns
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	awsclient "github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/aws"
+	"github.com/onsi/gomega"
+)
+
+func Test_route53Provider_ChangeRecordSets(t *testing.T) {
+	recordSets := []RecordSet{
+		{Name: "bootstrap.kafka.example.com", Type: RecordTypeCNAME, TTL: 300, Values: []string{"router.example.com"}},
+	}
+
+	tests := []struct {
+		name    string
+		client  *awsclient.AWSClientMock
+		want    *Change
+		wantErr bool
+	}{
+		{
+			name: "should change the record sets and qualify the id of the change",
+			client: &awsclient.AWSClientMock{
+				ChangeResourceRecordSetsFunc: func(dnsName string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
+					if dnsName != "kafka.example.com" || len(recordChangeBatch.Changes) != 1 {
+						return nil, errors.New("unexpected change")
+					}
+					change := recordChangeBatch.Changes[0]
+					if aws.StringValue(change.Action) != "UPSERT" || aws.StringValue(change.ResourceRecordSet.ResourceRecords[0].Value) != "router.example.com" {
+						return nil, errors.New("unexpected change")
+					}
+					return &route53.ChangeResourceRecordSetsOutput{
+						ChangeInfo: &route53.ChangeInfo{Id: aws.String("/change/1"), Status: aws.String(route53.ChangeStatusPending)},
+					}, nil
+				},
+			},
+			want: &Change{ID: "route53:/change/1", Status: ChangeStatusPending},
+		},
+		{
+			name: "should return an in sync change when there is nothing to change",
+			client: &awsclient.AWSClientMock{
+				ChangeResourceRecordSetsFunc: func(dnsName string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
+					return nil, nil
+				},
+			},
+			want: &Change{ID: "route53:", Status: ChangeStatusInSync},
+		},
+		{
+			name: "should return an error when the record sets cannot be changed",
+			client: &awsclient.AWSClientMock{
+				ChangeResourceRecordSetsFunc: func(dnsName string, recordChangeBatch *route53.ChangeBatch) (*route53.ChangeResourceRecordSetsOutput, error) {
+					return nil, errors.New("test")
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			got, err := NewRoute53Provider(tt.client).ChangeRecordSets("kafka.example.com", ChangeActionUpsert, recordSets)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_route53Provider_GetChange(t *testing.T) {
+	client := &awsclient.AWSClientMock{
+		GetChangeFunc: func(changeId string) (*route53.GetChangeOutput, error) {
+			if changeId != "/change/1" {
+				return nil, errors.New("unknown change")
+			}
+			return &route53.GetChangeOutput{
+				ChangeInfo: &route53.ChangeInfo{Id: aws.String(changeId), Status: aws.String(route53.ChangeStatusInsync)},
+			}, nil
+		},
+	}
+
+	tests := []struct {
+		name     string
+		changeID string
+		want     *Change
+		wantErr  bool
+	}{
+		{
+			name:     "should get the status of the change",
+			changeID: "route53:/change/1",
+			want:     &Change{ID: "route53:/change/1", Status: ChangeStatusInSync},
+		},
+		{
+			name:     "should get the status of a change whose id is not qualified",
+			changeID: "/change/1",
+			want:     &Change{ID: "/change/1", Status: ChangeStatusInSync},
+		},
+		{
+			name:     "should return an in sync change when nothing was changed",
+			changeID: "route53:",
+			want:     &Change{ID: "route53:", Status: ChangeStatusInSync},
+		},
+		{
+			name:     "should return an error when the status of the change cannot be got",
+			changeID: "route53:/change/2",
+			wantErr:  true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			got, err := NewRoute53Provider(client).GetChange(tt.changeID)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func Test_route53Provider_ListRecordSets(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	client := &awsclient.AWSClientMock{
+		ListResourceRecordSetsFunc: func(dnsName string) ([]*route53.ResourceRecordSet, error) {
+			return []*route53.ResourceRecordSet{
+				{Name: aws.String("kafka.example.com."), Type: aws.String("SOA"), TTL: aws.Int64(900)},
+				{
+					Name:            aws.String("bootstrap.kafka.example.com."),
+					Type:            aws.String(RecordTypeCNAME),
+					TTL:             aws.Int64(300),
+					ResourceRecords: []*route53.ResourceRecord{{Value: aws.String("router.example.com")}},
+				},
+			}, nil
+		},
+	}
+
+	recordSets, err := NewRoute53Provider(client).ListRecordSets("kafka.example.com", RecordTypeCNAME)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(recordSets).To(gomega.Equal([]RecordSet{
+		{Name: "bootstrap.kafka.example.com", Type: RecordTypeCNAME, TTL: 300, Values: []string{"router.example.com"}},
+	}))
+}
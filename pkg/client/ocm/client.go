@@ -1,6 +1,7 @@
 package ocm
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -26,6 +27,8 @@ type Client interface {
 	GetCluster(clusterID string) (*clustersmgmtv1.Cluster, error)
 	GetClusterStatus(id string) (*clustersmgmtv1.ClusterStatus, error)
 	GetCloudProviders() (*clustersmgmtv1.CloudProviderList, error)
+	// GetCloudProvidersContext returns the cloud providers, the request is abandoned once the context is done
+	GetCloudProvidersContext(ctx context.Context) (*clustersmgmtv1.CloudProviderList, error)
 	GetRegions(provider *clustersmgmtv1.CloudProvider) (*clustersmgmtv1.CloudRegionList, error)
 	GetAddon(clusterId string, addonId string) (*clustersmgmtv1.AddOnInstallation, error)
 	CreateAddonWithParams(clusterId string, addonId string, parameters []Parameter) (*clustersmgmtv1.AddOnInstallation, error)
@@ -56,6 +59,9 @@ type Client interface {
 	GetQuotaCostsForProduct(organizationID, resourceName, product string) ([]*amsv1.QuotaCost, error)
 	// GetCurrentAccount returns the account information of the current authenticated user
 	GetCurrentAccount() (*amsv1.Account, error)
+	// GetCurrentAccountContext returns the account information of the current authenticated user, the request is
+	// abandoned once the context is done
+	GetCurrentAccountContext(ctx context.Context) (*amsv1.Account, error)
 }
 
 var _ Client = &client{}
@@ -209,8 +215,12 @@ func (c client) GetClusterStatus(id string) (*clustersmgmtv1.ClusterStatus, erro
 }
 
 func (c *client) GetCloudProviders() (*clustersmgmtv1.CloudProviderList, error) {
+	return c.GetCloudProvidersContext(context.Background())
+}
+
+func (c *client) GetCloudProvidersContext(ctx context.Context) (*clustersmgmtv1.CloudProviderList, error) {
 	providersCollection := c.connection.ClustersMgmt().V1().CloudProviders()
-	providersResponse, err := providersCollection.List().Send()
+	providersResponse, err := providersCollection.List().SendContext(ctx)
 	if err != nil {
 		return nil, pkgerrors.Wrap(err, "error retrieving cloud provider list")
 	}
@@ -604,8 +614,12 @@ func (c client) GetQuotaCostsForProduct(organizationID, resourceName, product st
 
 // GetCurrentAccount returns the account information of the current authenticated user
 func (c *client) GetCurrentAccount() (*amsv1.Account, error) {
+	return c.GetCurrentAccountContext(context.Background())
+}
+
+func (c *client) GetCurrentAccountContext(ctx context.Context) (*amsv1.Account, error) {
 	currentAccountClient := c.connection.AccountsMgmt().V1().CurrentAccount()
-	response, err := currentAccountClient.Get().Send()
+	response, err := currentAccountClient.Get().SendContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -4,6 +4,7 @@
 package ocm
 
 import (
+	"context"
 	sdkClient "github.com/openshift-online/ocm-sdk-go"
 	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 	clustersmgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
@@ -62,6 +63,9 @@ var _ Client = &ClientMock{}
 //			GetCloudProvidersFunc: func() (*clustersmgmtv1.CloudProviderList, error) {
 //				panic("mock out the GetCloudProviders method")
 //			},
+//			GetCloudProvidersContextFunc: func(ctx context.Context) (*clustersmgmtv1.CloudProviderList, error) {
+//				panic("mock out the GetCloudProvidersContext method")
+//			},
 //			GetClusterFunc: func(clusterID string) (*clustersmgmtv1.Cluster, error) {
 //				panic("mock out the GetCluster method")
 //			},
@@ -77,6 +81,9 @@ var _ Client = &ClientMock{}
 //			GetCurrentAccountFunc: func() (*amsv1.Account, error) {
 //				panic("mock out the GetCurrentAccount method")
 //			},
+//			GetCurrentAccountContextFunc: func(ctx context.Context) (*amsv1.Account, error) {
+//				panic("mock out the GetCurrentAccountContext method")
+//			},
 //			GetIdentityProviderListFunc: func(clusterID string) (*clustersmgmtv1.IdentityProviderList, error) {
 //				panic("mock out the GetIdentityProviderList method")
 //			},
@@ -159,6 +166,9 @@ type ClientMock struct {
 	// GetCloudProvidersFunc mocks the GetCloudProviders method.
 	GetCloudProvidersFunc func() (*clustersmgmtv1.CloudProviderList, error)
 
+	// GetCloudProvidersContextFunc mocks the GetCloudProvidersContext method.
+	GetCloudProvidersContextFunc func(ctx context.Context) (*clustersmgmtv1.CloudProviderList, error)
+
 	// GetClusterFunc mocks the GetCluster method.
 	GetClusterFunc func(clusterID string) (*clustersmgmtv1.Cluster, error)
 
@@ -174,6 +184,9 @@ type ClientMock struct {
 	// GetCurrentAccountFunc mocks the GetCurrentAccount method.
 	GetCurrentAccountFunc func() (*amsv1.Account, error)
 
+	// GetCurrentAccountContextFunc mocks the GetCurrentAccountContext method.
+	GetCurrentAccountContextFunc func(ctx context.Context) (*amsv1.Account, error)
+
 	// GetIdentityProviderListFunc mocks the GetIdentityProviderList method.
 	GetIdentityProviderListFunc func(clusterID string) (*clustersmgmtv1.IdentityProviderList, error)
 
@@ -291,6 +304,11 @@ type ClientMock struct {
 		// GetCloudProviders holds details about calls to the GetCloudProviders method.
 		GetCloudProviders []struct {
 		}
+		// GetCloudProvidersContext holds details about calls to the GetCloudProvidersContext method.
+		GetCloudProvidersContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// GetCluster holds details about calls to the GetCluster method.
 		GetCluster []struct {
 			// ClusterID is the clusterID argument value.
@@ -314,6 +332,11 @@ type ClientMock struct {
 		// GetCurrentAccount holds details about calls to the GetCurrentAccount method.
 		GetCurrentAccount []struct {
 		}
+		// GetCurrentAccountContext holds details about calls to the GetCurrentAccountContext method.
+		GetCurrentAccountContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// GetIdentityProviderList holds details about calls to the GetIdentityProviderList method.
 		GetIdentityProviderList []struct {
 			// ClusterID is the clusterID argument value.
@@ -406,11 +429,13 @@ type ClientMock struct {
 	lockFindSubscriptions               sync.RWMutex
 	lockGetAddon                        sync.RWMutex
 	lockGetCloudProviders               sync.RWMutex
+	lockGetCloudProvidersContext        sync.RWMutex
 	lockGetCluster                      sync.RWMutex
 	lockGetClusterDNS                   sync.RWMutex
 	lockGetClusterIngresses             sync.RWMutex
 	lockGetClusterStatus                sync.RWMutex
 	lockGetCurrentAccount               sync.RWMutex
+	lockGetCurrentAccountContext        sync.RWMutex
 	lockGetIdentityProviderList         sync.RWMutex
 	lockGetMachinePool                  sync.RWMutex
 	lockGetOrganisationIdFromExternalId sync.RWMutex
@@ -894,6 +919,38 @@ func (mock *ClientMock) GetCloudProvidersCalls() []struct {
 	return calls
 }
 
+// GetCloudProvidersContext calls GetCloudProvidersContextFunc.
+func (mock *ClientMock) GetCloudProvidersContext(ctx context.Context) (*clustersmgmtv1.CloudProviderList, error) {
+	if mock.GetCloudProvidersContextFunc == nil {
+		panic("ClientMock.GetCloudProvidersContextFunc: method is nil but Client.GetCloudProvidersContext was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetCloudProvidersContext.Lock()
+	mock.calls.GetCloudProvidersContext = append(mock.calls.GetCloudProvidersContext, callInfo)
+	mock.lockGetCloudProvidersContext.Unlock()
+	return mock.GetCloudProvidersContextFunc(ctx)
+}
+
+// GetCloudProvidersContextCalls gets all the calls that were made to GetCloudProvidersContext.
+// Check the length with:
+//
+//	len(mockedClient.GetCloudProvidersContextCalls())
+func (mock *ClientMock) GetCloudProvidersContextCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetCloudProvidersContext.RLock()
+	calls = mock.calls.GetCloudProvidersContext
+	mock.lockGetCloudProvidersContext.RUnlock()
+	return calls
+}
+
 // GetCluster calls GetClusterFunc.
 func (mock *ClientMock) GetCluster(clusterID string) (*clustersmgmtv1.Cluster, error) {
 	if mock.GetClusterFunc == nil {
@@ -1049,6 +1106,38 @@ func (mock *ClientMock) GetCurrentAccountCalls() []struct {
 	return calls
 }
 
+// GetCurrentAccountContext calls GetCurrentAccountContextFunc.
+func (mock *ClientMock) GetCurrentAccountContext(ctx context.Context) (*amsv1.Account, error) {
+	if mock.GetCurrentAccountContextFunc == nil {
+		panic("ClientMock.GetCurrentAccountContextFunc: method is nil but Client.GetCurrentAccountContext was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetCurrentAccountContext.Lock()
+	mock.calls.GetCurrentAccountContext = append(mock.calls.GetCurrentAccountContext, callInfo)
+	mock.lockGetCurrentAccountContext.Unlock()
+	return mock.GetCurrentAccountContextFunc(ctx)
+}
+
+// GetCurrentAccountContextCalls gets all the calls that were made to GetCurrentAccountContext.
+// Check the length with:
+//
+//	len(mockedClient.GetCurrentAccountContextCalls())
+func (mock *ClientMock) GetCurrentAccountContextCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetCurrentAccountContext.RLock()
+	calls = mock.calls.GetCurrentAccountContext
+	mock.lockGetCurrentAccountContext.RUnlock()
+	return calls
+}
+
 // GetIdentityProviderList calls GetIdentityProviderListFunc.
 func (mock *ClientMock) GetIdentityProviderList(clusterID string) (*clustersmgmtv1.IdentityProviderList, error) {
 	if mock.GetIdentityProviderListFunc == nil {
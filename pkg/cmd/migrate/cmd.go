@@ -27,6 +27,7 @@ func NewMigrateCommand(env *environments.Env) *cobra.Command {
 	cmd.AddCommand(
 		NewRollbackAll(env),
 		NewRollbackLast(env),
+		NewReencryptColumns(env),
 	)
 	return cmd
 }
@@ -0,0 +1,34 @@
+package migrate
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+)
+
+// NewReencryptColumns returns the command encrypting the sensitive columns with the current db encryption key.
+// It is run once the encryption is enabled to encrypt the existing values, and after each key rotation, with the
+// previous key still configured, to re-encrypt the values with the new key.
+func NewReencryptColumns(env *environments.Env) *cobra.Command {
+	var batchSize int
+	cmd := &cobra.Command{
+		Use:   "reencrypt-columns",
+		Short: "encrypt the sensitive columns with the current db encryption key",
+		Long:  "encrypt the sensitive columns which are in plain text or encrypted with a previous db encryption key",
+		Run: func(cmd *cobra.Command, args []string) {
+			env.MustInvoke(func(migrations []*db.Migration) {
+				glog.Infoln("Re-encrypting the sensitive columns")
+				for _, migration := range migrations {
+					count, err := migration.ReencryptColumns(batchSize)
+					if err != nil {
+						glog.Fatalf("Could not re-encrypt the columns: %v", err)
+					}
+					glog.Infof("Re-encrypted %d values of the %d encrypted columns", count, len(migration.EncryptedColumns))
+				}
+			})
+		},
+	}
+	cmd.Flags().IntVar(&batchSize, "batch-size", 100, "The number of rows re-encrypted per query")
+	return cmd
+}
@@ -3,6 +3,7 @@ package db
 import (
 	"fmt"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/encryption"
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
 
 	"github.com/spf13/pflag"
@@ -26,6 +27,9 @@ type DatabaseConfig struct {
 	NameFile           string `json:"name_file"`
 	UsernameFile       string `json:"username_file"`
 	PasswordFile       string `json:"password_file"`
+
+	// Encryption configures the encryption of the sensitive columns, see encryption.EncryptedString
+	Encryption encryption.Config `json:"encryption"`
 }
 
 func NewDatabaseConfig() *DatabaseConfig {
@@ -41,6 +45,7 @@ func NewDatabaseConfig() *DatabaseConfig {
 		PasswordFile:       "secrets/db.password",
 		NameFile:           "secrets/db.name",
 		DatabaseCaCertFile: "secrets/db.ca_cert",
+		Encryption:         encryption.NewConfig(),
 	}
 }
 
@@ -54,6 +59,7 @@ func (c *DatabaseConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.SSLMode, "db-sslmode", c.SSLMode, "Database ssl mode (disable | require | verify-ca | verify-full)")
 	fs.BoolVar(&c.Debug, "enable-db-debug", c.Debug, " framework's debug mode")
 	fs.IntVar(&c.MaxOpenConnections, "db-max-open-connections", c.MaxOpenConnections, "Maximum open DB connections for this instance")
+	c.Encryption.AddFlags(fs)
 }
 
 func (c *DatabaseConfig) ReadFiles() error {
@@ -79,7 +85,11 @@ func (c *DatabaseConfig) ReadFiles() error {
 	}
 
 	err = shared.ReadFileValueString(c.NameFile, &c.Name)
-	return err
+	if err != nil {
+		return err
+	}
+
+	return c.Encryption.ReadFiles()
 }
 
 func (c *DatabaseConfig) ConnectionString() string {
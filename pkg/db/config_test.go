@@ -104,6 +104,26 @@ func Test_ReadFiles(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "should return an error with an invalid encryption key provider",
+			fields: fields{
+				config: NewDatabaseConfig(),
+			},
+			modifyFn: func(config *DatabaseConfig) {
+				config.Encryption.KeyProvider = "invalid"
+			},
+			wantErr: true,
+		},
+		{
+			name: "should return an error when the KMS key id of the aws-kms encryption key provider is not set",
+			fields: fields{
+				config: NewDatabaseConfig(),
+			},
+			modifyFn: func(config *DatabaseConfig) {
+				config.Encryption.KeyProvider = "aws-kms"
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, testcase := range tests {
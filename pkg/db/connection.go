@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/encryption"
 	"github.com/golang/glog"
 	_ "github.com/lib/pq"
 	mocket "github.com/selvatico/go-mocket"
@@ -52,6 +53,16 @@ func NewConnectionFactory(config *DatabaseConfig) (*ConnectionFactory, func()) {
 	}
 
 	sqlDB.SetMaxOpenConns(config.MaxOpenConnections)
+
+	// the cipher is set globally as the encrypted columns are encrypted and decrypted by the gorm value types
+	keyProvider, err := encryption.NewKeyProvider(&config.Encryption)
+	if err != nil {
+		panic(fmt.Sprintf("failed to create the %s db encryption key provider: %s", config.Encryption.KeyProvider, err.Error()))
+	}
+	if keyProvider != nil {
+		encryption.SetDefaultCipher(encryption.NewCipher(keyProvider))
+	}
+
 	dbFactory := &ConnectionFactory{Config: config, DB: db}
 	cleanup := func() {
 		if err := dbFactory.close(); err != nil {
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/encryption"
+	"github.com/golang/glog"
+	"gorm.io/gorm/clause"
+)
+
+// EncryptedColumn is a column mapped to an encryption.EncryptedString field. The table must have an id primary key.
+type EncryptedColumn struct {
+	Table  string
+	Column string
+}
+
+func (c EncryptedColumn) String() string {
+	return fmt.Sprintf("%s.%s", c.Table, c.Column)
+}
+
+// ReencryptColumns encrypts with the current key encryption key the values of the encrypted columns which are either
+// in plain text, because they were written before the encryption was enabled, or encrypted with a previous key.
+// The rows are processed by batches of the given size and only updated if their values did not change in between.
+// It returns the number of values that have been re-encrypted.
+func (m *Migration) ReencryptColumns(batchSize int) (int, error) {
+	cipher := encryption.DefaultCipher()
+	if cipher == nil {
+		return 0, fmt.Errorf("the db encryption is disabled, set --db-encryption-key-provider to re-encrypt the columns")
+	}
+
+	total := 0
+	for _, column := range m.EncryptedColumns {
+		count, err := m.reencryptColumn(cipher, column, batchSize)
+		total += count
+		if err != nil {
+			return total, fmt.Errorf("failed to re-encrypt column %s: %w", column, err)
+		}
+		glog.Infof("re-encrypted %d values of column %s", count, column)
+	}
+	return total, nil
+}
+
+func (m *Migration) reencryptColumn(cipher *encryption.Cipher, column EncryptedColumn, batchSize int) (int, error) {
+	type row struct {
+		ID    string
+		Value string
+	}
+
+	dbConn := m.DbFactory.New()
+	count := 0
+	lastID := ""
+	for {
+		var rows []row
+		// soft deleted rows are re-encrypted as well as no gorm model is involved
+		err := dbConn.Table(column.Table).
+			Select("id, ? AS value", clause.Column{Name: column.Column}).
+			Where("id > ? AND ? <> ''", lastID, clause.Column{Name: column.Column}).
+			Order("id").
+			Limit(batchSize).
+			Scan(&rows).Error
+		if err != nil {
+			return count, err
+		}
+		if len(rows) == 0 {
+			return count, nil
+		}
+
+		for _, r := range rows {
+			lastID = r.ID
+			if cipher.IsEncryptedWithCurrentKey(r.Value) {
+				continue
+			}
+			plaintext, err := cipher.Decrypt(r.Value)
+			if err != nil {
+				return count, fmt.Errorf("failed to decrypt the value of row %q: %w", r.ID, err)
+			}
+			encrypted, err := cipher.Encrypt(plaintext)
+			if err != nil {
+				return count, fmt.Errorf("failed to encrypt the value of row %q: %w", r.ID, err)
+			}
+			err = dbConn.Table(column.Table).
+				Where("id = ? AND ? = ?", r.ID, clause.Column{Name: column.Column}, r.Value).
+				Update(column.Column, encrypted).Error
+			if err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db/encryption"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func TestMigration_ReencryptColumns(t *testing.T) {
+	g := gomega.NewWithT(t)
+	defer encryption.SetDefaultCipher(nil)
+	migration := &Migration{
+		DbFactory:        NewMockConnectionFactory(nil),
+		EncryptedColumns: []EncryptedColumn{{Table: "clusters", Column: "client_secret"}},
+	}
+
+	_, err := migration.ReencryptColumns(10)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("the db encryption is disabled")))
+
+	keyFile := filepath.Join(t.TempDir(), "db.encryption_key")
+	g.Expect(os.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(make([]byte, 32))), 0600)).To(gomega.Succeed())
+	keyProvider, err := encryption.NewLocalKeyProvider(keyFile)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	cipher := encryption.NewCipher(keyProvider)
+	encryption.SetDefaultCipher(cipher)
+	encrypted, err := cipher.Encrypt("encrypted-secret")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	var updates [][]driver.NamedValue
+	mocket.Catcher.Reset()
+	mocket.Catcher.NewMock().WithQuery(`SELECT id, "client_secret" AS value FROM "clusters"`).OneTime().
+		WithReply([]map[string]interface{}{
+			{"id": "cluster-1", "value": "plain-text-secret"},
+			{"id": "cluster-2", "value": encrypted},
+		})
+	mocket.Catcher.NewMock().WithQuery(`SELECT id, "client_secret" AS value FROM "clusters"`).WithReply([]map[string]interface{}{})
+	mocket.Catcher.NewMock().WithQuery(`UPDATE "clusters" SET "client_secret"`).WithRowsNum(1).
+		WithCallback(func(query string, args []driver.NamedValue) {
+			updates = append(updates, args)
+		})
+	mocket.Catcher.NewMock().WithExecException().WithQueryException()
+
+	count, err := migration.ReencryptColumns(10)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	// only the plain text value is encrypted, the other one is already encrypted with the current key
+	g.Expect(count).To(gomega.Equal(1))
+	g.Expect(updates).To(gomega.HaveLen(1))
+	g.Expect(updates[0]).To(gomega.HaveLen(3))
+	g.Expect(cipher.Decrypt(updates[0][0].Value.(string))).To(gomega.Equal("plain-text-secret"))
+	g.Expect(updates[0][1].Value).To(gomega.Equal("cluster-1"))
+	g.Expect(updates[0][2].Value).To(gomega.Equal("plain-text-secret"))
+}
@@ -0,0 +1,194 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	dataKeySize = 32
+
+	// encryptedValuePrefix prefixes the encrypted values so that they can be told apart from the plain text values
+	// written before the encryption was enabled. It is followed by the id of the key encryption key, the encrypted data
+	// key and the encrypted value, all base64 encoded and separated by colons.
+	encryptedValuePrefix = "enc:v1:"
+)
+
+var encoding = base64.RawURLEncoding
+
+// Cipher does the envelope encryption of the database values: the values are encrypted with a data key which is
+// itself encrypted by the key provider and stored along with the value. A single data key is generated per process
+// and the decrypted data keys are cached to limit the calls to the key provider.
+type Cipher struct {
+	keyProvider KeyProvider
+
+	mutex sync.Mutex
+	// currentDataKey is nil until the first encryption
+	currentDataKey *dataKey
+	// dataKeys are the decrypted data keys indexed by the encoded id of their key encryption key and their encoded
+	// encrypted form
+	dataKeys map[string][]byte
+}
+
+type dataKey struct {
+	encodedKeyID string
+	encoded      string
+	plaintext    []byte
+}
+
+func NewCipher(keyProvider KeyProvider) *Cipher {
+	return &Cipher{
+		keyProvider: keyProvider,
+		dataKeys:    map[string][]byte{},
+	}
+}
+
+// IsEncrypted returns true if the value has been encrypted by a cipher
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedValuePrefix)
+}
+
+// Encrypt encrypts the value with the data key of the current key encryption key
+func (c *Cipher) Encrypt(plaintext string) (string, error) {
+	key, err := c.getCurrentDataKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate the data key: %w", err)
+	}
+	ciphertext, err := seal(key.plaintext, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	return encryptedValuePrefix + key.encodedKeyID + ":" + key.encoded + ":" + encoding.EncodeToString(ciphertext), nil
+}
+
+// Decrypt decrypts a value returned by Encrypt. The values that are not encrypted are returned as is.
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	encodedKeyID, encodedDataKey, encodedCiphertext, err := splitEncryptedValue(value)
+	if err != nil {
+		return "", err
+	}
+	key, err := c.getDataKey(encodedKeyID, encodedDataKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt the data key: %w", err)
+	}
+	ciphertext, err := encoding.DecodeString(encodedCiphertext)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode the encrypted value: %w", err)
+	}
+	plaintext, err := open(key, ciphertext)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// IsEncryptedWithCurrentKey returns true if the value has been encrypted with a data key of the current key encryption
+// key. The other values need to be re-encrypted after a key rotation.
+func (c *Cipher) IsEncryptedWithCurrentKey(value string) bool {
+	if !IsEncrypted(value) {
+		return false
+	}
+	encodedKeyID, _, _, err := splitEncryptedValue(value)
+	return err == nil && encodedKeyID == encoding.EncodeToString([]byte(c.keyProvider.KeyID()))
+}
+
+func (c *Cipher) getCurrentDataKey() (*dataKey, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.currentDataKey != nil {
+		return c.currentDataKey, nil
+	}
+	plaintext, encrypted, err := c.keyProvider.GenerateDataKey()
+	if err != nil {
+		return nil, err
+	}
+	c.currentDataKey = &dataKey{
+		encodedKeyID: encoding.EncodeToString([]byte(c.keyProvider.KeyID())),
+		encoded:      encoding.EncodeToString(encrypted),
+		plaintext:    plaintext,
+	}
+	c.dataKeys[c.currentDataKey.encodedKeyID+":"+c.currentDataKey.encoded] = plaintext
+	return c.currentDataKey, nil
+}
+
+func (c *Cipher) getDataKey(encodedKeyID string, encodedDataKey string) ([]byte, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if key, ok := c.dataKeys[encodedKeyID+":"+encodedDataKey]; ok {
+		return key, nil
+	}
+	keyID, err := encoding.DecodeString(encodedKeyID)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := encoding.DecodeString(encodedDataKey)
+	if err != nil {
+		return nil, err
+	}
+	key, err := c.keyProvider.DecryptDataKey(string(keyID), encrypted)
+	if err != nil {
+		return nil, err
+	}
+	c.dataKeys[encodedKeyID+":"+encodedDataKey] = key
+	return key, nil
+}
+
+func splitEncryptedValue(value string) (encodedKeyID string, encodedDataKey string, encodedCiphertext string, err error) {
+	parts := strings.Split(strings.TrimPrefix(value, encryptedValuePrefix), ":")
+	if len(parts) != 3 {
+		return "", "", "", fmt.Errorf("malformed encrypted value")
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+// seal encrypts the plaintext with AES-GCM, the random nonce is prepended to the returned ciphertext
+func seal(key []byte, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomBytes(gcm.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// open decrypts a ciphertext returned by seal
+func open(key []byte, ciphertext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, fmt.Errorf("malformed ciphertext")
+	}
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, sealed, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func randomBytes(size int) ([]byte, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
@@ -0,0 +1,136 @@
+package encryption
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+// writeKeyFile writes a random base64 encoded key to a temporary file and returns its path
+func writeKeyFile(t *testing.T) string {
+	key, err := randomBytes(dataKeySize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), "db.encryption_key")
+	if err := os.WriteFile(file, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func newLocalCipher(t *testing.T, keyFile string, previousKeyFiles ...string) *Cipher {
+	keyProvider, err := NewLocalKeyProvider(keyFile, previousKeyFiles...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewCipher(keyProvider)
+}
+
+func TestCipher_EncryptDecrypt(t *testing.T) {
+	g := gomega.NewWithT(t)
+	keyFile := writeKeyFile(t)
+	c := newLocalCipher(t, keyFile)
+
+	encrypted, err := c.Encrypt("client-secret")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(IsEncrypted(encrypted)).To(gomega.BeTrue())
+	g.Expect(encrypted).ToNot(gomega.ContainSubstring("client-secret"))
+	g.Expect(c.IsEncryptedWithCurrentKey(encrypted)).To(gomega.BeTrue())
+
+	// the same value is encrypted differently each time
+	encryptedAgain, err := c.Encrypt("client-secret")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(encryptedAgain).ToNot(gomega.Equal(encrypted))
+
+	decrypted, err := c.Decrypt(encrypted)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(decrypted).To(gomega.Equal("client-secret"))
+
+	// plain text values are returned as is
+	decrypted, err = c.Decrypt("plain-text")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(decrypted).To(gomega.Equal("plain-text"))
+	g.Expect(c.IsEncryptedWithCurrentKey("plain-text")).To(gomega.BeFalse())
+
+	// a new cipher, e.g. after a restart, decrypts the values with the same key
+	decrypted, err = newLocalCipher(t, keyFile).Decrypt(encrypted)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(decrypted).To(gomega.Equal("client-secret"))
+
+	_, err = c.Decrypt(encryptedValuePrefix + "malformed")
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestCipher_KeyRotation(t *testing.T) {
+	g := gomega.NewWithT(t)
+	previousKeyFile := writeKeyFile(t)
+	encrypted, err := newLocalCipher(t, previousKeyFile).Encrypt("client-secret")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	// the value cannot be decrypted once the previous key is no longer configured
+	_, err = newLocalCipher(t, writeKeyFile(t)).Decrypt(encrypted)
+	g.Expect(err).To(gomega.HaveOccurred())
+
+	c := newLocalCipher(t, writeKeyFile(t), previousKeyFile)
+	g.Expect(c.IsEncryptedWithCurrentKey(encrypted)).To(gomega.BeFalse())
+	decrypted, err := c.Decrypt(encrypted)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(decrypted).To(gomega.Equal("client-secret"))
+
+	reencrypted, err := c.Encrypt(decrypted)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(c.IsEncryptedWithCurrentKey(reencrypted)).To(gomega.BeTrue())
+}
+
+func TestNewLocalKeyProvider(t *testing.T) {
+	invalidKeyFile := filepath.Join(t.TempDir(), "invalid_key")
+	if err := os.WriteFile(invalidKeyFile, []byte(base64.StdEncoding.EncodeToString([]byte("too-short"))), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name             string
+		keyFile          string
+		previousKeyFiles []string
+		wantErr          bool
+	}{
+		{
+			name:    "should return an error when the key file does not exist",
+			keyFile: filepath.Join(t.TempDir(), "missing"),
+			wantErr: true,
+		},
+		{
+			name:    "should return an error when the key is not 256 bits long",
+			keyFile: invalidKeyFile,
+			wantErr: true,
+		},
+		{
+			name:             "should return an error when a previous key is invalid",
+			keyFile:          writeKeyFile(t),
+			previousKeyFiles: []string{invalidKeyFile},
+			wantErr:          true,
+		},
+		{
+			name:             "should load the current and previous keys",
+			keyFile:          writeKeyFile(t),
+			previousKeyFiles: []string{writeKeyFile(t)},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			p, err := NewLocalKeyProvider(tt.keyFile, tt.previousKeyFiles...)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			if !tt.wantErr {
+				g.Expect(p.keys).To(gomega.HaveLen(1 + len(tt.previousKeyFiles)))
+				g.Expect(p.keys).To(gomega.HaveKey(p.KeyID()))
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package encryption
+
+import (
+	"fmt"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/spf13/pflag"
+)
+
+const (
+	// KeyProviderNone disables the encryption of the database columns
+	KeyProviderNone = "none"
+	// KeyProviderLocal encrypts the data keys with keys read from local files. It is meant for development only
+	KeyProviderLocal = "local"
+	// KeyProviderAwsKms encrypts the data keys with a key managed by AWS KMS
+	KeyProviderAwsKms = "aws-kms"
+)
+
+type Config struct {
+	KeyProvider string `json:"key_provider"`
+	// KeyFile is the file containing the base64 encoded 256 bits key used by the local key provider
+	KeyFile string `json:"key_file"`
+	// PreviousKeyFiles are the files containing the keys previously used by the local key provider. They are only used
+	// to decrypt the values that have not been re-encrypted with the current key yet
+	PreviousKeyFiles []string `json:"previous_key_files"`
+
+	KmsKeyID               string `json:"kms_key_id"`
+	AwsRegion              string `json:"aws_region"`
+	AwsAccessKey           string `json:"aws_access_key"`
+	AwsAccessKeyFile       string `json:"aws_access_key_file"`
+	AwsSecretAccessKey     string `json:"aws_secret_access_key"`
+	AwsSecretAccessKeyFile string `json:"aws_secret_access_key_file"`
+}
+
+func NewConfig() Config {
+	return Config{
+		KeyProvider:            KeyProviderNone,
+		KeyFile:                "secrets/db.encryption_key",
+		AwsRegion:              "us-east-1",
+		AwsAccessKeyFile:       "secrets/db.encryption_aws_access_key_id",
+		AwsSecretAccessKeyFile: "secrets/db.encryption_aws_secret_access_key",
+	}
+}
+
+func (c *Config) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.KeyProvider, "db-encryption-key-provider", c.KeyProvider, "The provider of the keys encrypting the sensitive database columns: none|local|aws-kms")
+	fs.StringVar(&c.KeyFile, "db-encryption-key-file", c.KeyFile, "File containing the base64 encoded 256 bits key of the local key provider")
+	fs.StringArrayVar(&c.PreviousKeyFiles, "db-encryption-previous-key-files", c.PreviousKeyFiles, "Files containing the keys previously used by the local key provider, needed until the columns are re-encrypted with the current key")
+	fs.StringVar(&c.KmsKeyID, "db-encryption-kms-key-id", c.KmsKeyID, "The id, ARN or alias of the AWS KMS key of the aws-kms key provider")
+	fs.StringVar(&c.AwsRegion, "db-encryption-aws-region", c.AwsRegion, "The AWS region of the KMS key of the aws-kms key provider")
+	fs.StringVar(&c.AwsAccessKeyFile, "db-encryption-aws-access-key-file", c.AwsAccessKeyFile, "File containing the AWS access key of the aws-kms key provider")
+	fs.StringVar(&c.AwsSecretAccessKeyFile, "db-encryption-aws-secret-access-key-file", c.AwsSecretAccessKeyFile, "File containing the AWS secret access key of the aws-kms key provider")
+}
+
+func (c *Config) ReadFiles() error {
+	switch c.KeyProvider {
+	case "", KeyProviderNone, KeyProviderLocal:
+		// the local keys are read by the key provider as they must be decoded
+		return nil
+	case KeyProviderAwsKms:
+		if c.KmsKeyID == "" {
+			return fmt.Errorf("db-encryption-kms-key-id must be set when the db encryption key provider is %q", KeyProviderAwsKms)
+		}
+		err := shared.ReadFileValueString(c.AwsAccessKeyFile, &c.AwsAccessKey)
+		if err != nil {
+			return err
+		}
+		return shared.ReadFileValueString(c.AwsSecretAccessKeyFile, &c.AwsSecretAccessKey)
+	default:
+		return fmt.Errorf("invalid db encryption key provider %q, the allowed values are %q, %q and %q", c.KeyProvider, KeyProviderNone, KeyProviderLocal, KeyProviderAwsKms)
+	}
+}
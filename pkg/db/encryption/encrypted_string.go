@@ -0,0 +1,74 @@
+package encryption
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"sync"
+)
+
+var (
+	defaultCipherMutex sync.RWMutex
+	defaultCipher      *Cipher
+)
+
+// SetDefaultCipher sets the cipher used by EncryptedString. A nil cipher disables the encryption.
+func SetDefaultCipher(c *Cipher) {
+	defaultCipherMutex.Lock()
+	defer defaultCipherMutex.Unlock()
+	defaultCipher = c
+}
+
+// DefaultCipher returns the cipher used by EncryptedString, nil if the encryption is disabled
+func DefaultCipher() *Cipher {
+	defaultCipherMutex.RLock()
+	defer defaultCipherMutex.RUnlock()
+	return defaultCipher
+}
+
+// EncryptedString is a string which is encrypted with the default cipher when written to the database and decrypted
+// when read from it. Empty strings are stored as is so that the column can still be checked for emptiness, and so are
+// all values when the encryption is disabled. The plain text values read from the database are returned as is until
+// they are re-encrypted by the migrate reencrypt-columns command.
+type EncryptedString string
+
+var _ driver.Valuer = EncryptedString("")
+
+func (s EncryptedString) String() string {
+	return string(s)
+}
+
+func (s EncryptedString) Value() (driver.Value, error) {
+	c := DefaultCipher()
+	if c == nil || s == "" {
+		return string(s), nil
+	}
+	return c.Encrypt(string(s))
+}
+
+func (s *EncryptedString) Scan(src interface{}) error {
+	var value string
+	switch v := src.(type) {
+	case nil:
+		value = ""
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("unable to scan %T into an encrypted string", src)
+	}
+
+	if IsEncrypted(value) {
+		c := DefaultCipher()
+		if c == nil {
+			return fmt.Errorf("unable to decrypt the value read from the database: the db encryption is disabled")
+		}
+		plaintext, err := c.Decrypt(value)
+		if err != nil {
+			return err
+		}
+		value = plaintext
+	}
+	*s = EncryptedString(value)
+	return nil
+}
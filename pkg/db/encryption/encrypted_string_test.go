@@ -0,0 +1,82 @@
+package encryption
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestEncryptedString(t *testing.T) {
+	g := gomega.NewWithT(t)
+	defer SetDefaultCipher(nil)
+
+	// the values are stored in plain text while the encryption is disabled
+	value, err := EncryptedString("secret").Value()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(value).To(gomega.Equal("secret"))
+
+	SetDefaultCipher(newLocalCipher(t, writeKeyFile(t)))
+
+	encrypted, err := EncryptedString("secret").Value()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(IsEncrypted(encrypted.(string))).To(gomega.BeTrue())
+
+	// empty values are not encrypted
+	value, err = EncryptedString("").Value()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(value).To(gomega.Equal(""))
+
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    EncryptedString
+		wantErr bool
+	}{
+		{
+			name: "should decrypt an encrypted value",
+			src:  encrypted,
+			want: "secret",
+		},
+		{
+			name: "should decrypt an encrypted value read as bytes",
+			src:  []byte(encrypted.(string)),
+			want: "secret",
+		},
+		{
+			name: "should return a plain text value as is",
+			src:  "plain-text",
+			want: "plain-text",
+		},
+		{
+			name: "should scan a null value as an empty string",
+			src:  nil,
+			want: "",
+		},
+		{
+			name:    "should return an error for a value which is not a string",
+			src:     42,
+			wantErr: true,
+		},
+		{
+			name:    "should return an error for a malformed encrypted value",
+			src:     encryptedValuePrefix + "malformed",
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			var s EncryptedString
+			err := s.Scan(tt.src)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(s).To(gomega.Equal(tt.want))
+		})
+	}
+
+	// the encrypted values cannot be read once the encryption is disabled
+	SetDefaultCipher(nil)
+	var s EncryptedString
+	g.Expect(s.Scan(encrypted)).ToNot(gomega.Succeed())
+}
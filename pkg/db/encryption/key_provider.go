@@ -0,0 +1,39 @@
+package encryption
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+)
+
+// KeyProvider generates the data keys encrypting the database columns and encrypts them with a key it manages, the
+// key encryption key. Only the encrypted form of the data keys is stored along with the values they encrypt.
+type KeyProvider interface {
+	// KeyID returns the id of the key encryption key currently used to encrypt the new data keys
+	KeyID() string
+	// GenerateDataKey returns a new 256 bits data key in plain text and encrypted with the current key encryption key
+	GenerateDataKey() (plaintext []byte, encrypted []byte, err error)
+	// DecryptDataKey decrypts a data key that has been encrypted with the key encryption key of the given id
+	DecryptDataKey(keyID string, encrypted []byte) ([]byte, error)
+}
+
+// NewKeyProvider returns the key provider of the given config, nil if the encryption of the database columns is
+// disabled
+func NewKeyProvider(config *Config) (KeyProvider, error) {
+	switch config.KeyProvider {
+	case KeyProviderLocal:
+		return NewLocalKeyProvider(config.KeyFile, config.PreviousKeyFiles...)
+	case KeyProviderAwsKms:
+		sess, err := session.NewSession(&aws.Config{
+			Credentials: credentials.NewStaticCredentials(config.AwsAccessKey, config.AwsSecretAccessKey, ""),
+			Region:      aws.String(config.AwsRegion),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return NewKmsKeyProvider(kms.New(sess), config.KmsKeyID), nil
+	default:
+		return nil, nil
+	}
+}
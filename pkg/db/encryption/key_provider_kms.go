@@ -0,0 +1,59 @@
+package encryption
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+)
+
+// KmsClient is the subset of the AWS KMS API used by the aws-kms key provider
+//
+//go:generate moq -out kms_client_moq.go . KmsClient
+type KmsClient interface {
+	GenerateDataKey(input *kms.GenerateDataKeyInput) (*kms.GenerateDataKeyOutput, error)
+	Decrypt(input *kms.DecryptInput) (*kms.DecryptOutput, error)
+}
+
+var _ KmsClient = &kms.KMS{}
+
+var _ KeyProvider = &kmsKeyProvider{}
+
+// kmsKeyProvider generates the data keys with AWS KMS. The key encryption key never leaves KMS.
+type kmsKeyProvider struct {
+	client KmsClient
+	keyID  string
+}
+
+func NewKmsKeyProvider(client KmsClient, keyID string) *kmsKeyProvider {
+	return &kmsKeyProvider{
+		client: client,
+		keyID:  keyID,
+	}
+}
+
+func (p *kmsKeyProvider) KeyID() string {
+	return p.keyID
+}
+
+func (p *kmsKeyProvider) GenerateDataKey() ([]byte, []byte, error) {
+	output, err := p.client.GenerateDataKey(&kms.GenerateDataKeyInput{
+		KeyId:   aws.String(p.keyID),
+		KeySpec: aws.String(kms.DataKeySpecAes256),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return output.Plaintext, output.CiphertextBlob, nil
+}
+
+// DecryptDataKey decrypts the data key with the given KMS key. The previous keys remain usable after a rotation as
+// long as they are enabled in KMS.
+func (p *kmsKeyProvider) DecryptDataKey(keyID string, encrypted []byte) ([]byte, error) {
+	output, err := p.client.Decrypt(&kms.DecryptInput{
+		KeyId:          aws.String(keyID),
+		CiphertextBlob: encrypted,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.Plaintext, nil
+}
@@ -0,0 +1,65 @@
+package encryption
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/onsi/gomega"
+)
+
+func TestKmsKeyProvider(t *testing.T) {
+	g := gomega.NewWithT(t)
+	dataKey, err := randomBytes(dataKeySize)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	client := &KmsClientMock{
+		GenerateDataKeyFunc: func(input *kms.GenerateDataKeyInput) (*kms.GenerateDataKeyOutput, error) {
+			return &kms.GenerateDataKeyOutput{
+				KeyId:          input.KeyId,
+				Plaintext:      dataKey,
+				CiphertextBlob: []byte("encrypted-by-" + aws.StringValue(input.KeyId)),
+			}, nil
+		},
+		DecryptFunc: func(input *kms.DecryptInput) (*kms.DecryptOutput, error) {
+			if string(input.CiphertextBlob) != "encrypted-by-"+aws.StringValue(input.KeyId) {
+				return nil, fmt.Errorf("invalid ciphertext")
+			}
+			return &kms.DecryptOutput{KeyId: input.KeyId, Plaintext: dataKey}, nil
+		},
+	}
+
+	c := NewCipher(NewKmsKeyProvider(client, "alias/db-encryption"))
+	first, err := c.Encrypt("first")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	second, err := c.Encrypt("second")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	// a single data key is generated per cipher
+	g.Expect(client.GenerateDataKeyCalls()).To(gomega.HaveLen(1))
+	g.Expect(aws.StringValue(client.GenerateDataKeyCalls()[0].Input.KeySpec)).To(gomega.Equal(kms.DataKeySpecAes256))
+
+	// the data key is decrypted once by a new cipher, with the key that encrypted it
+	c = NewCipher(NewKmsKeyProvider(client, "alias/rotated-db-encryption"))
+	g.Expect(c.IsEncryptedWithCurrentKey(first)).To(gomega.BeFalse())
+	for value, want := range map[string]string{first: "first", second: "second"} {
+		decrypted, err := c.Decrypt(value)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(decrypted).To(gomega.Equal(want))
+	}
+	g.Expect(client.DecryptCalls()).To(gomega.HaveLen(1))
+	g.Expect(aws.StringValue(client.DecryptCalls()[0].Input.KeyId)).To(gomega.Equal("alias/db-encryption"))
+}
+
+func TestKmsKeyProvider_GenerateDataKeyError(t *testing.T) {
+	g := gomega.NewWithT(t)
+	client := &KmsClientMock{
+		GenerateDataKeyFunc: func(input *kms.GenerateDataKeyInput) (*kms.GenerateDataKeyOutput, error) {
+			return nil, fmt.Errorf("access denied")
+		},
+	}
+
+	_, err := NewCipher(NewKmsKeyProvider(client, "alias/db-encryption")).Encrypt("value")
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("access denied")))
+}
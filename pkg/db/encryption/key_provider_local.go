@@ -0,0 +1,76 @@
+package encryption
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+)
+
+var _ KeyProvider = &localKeyProvider{}
+
+// localKeyProvider encrypts the data keys with AES-256-GCM keys read from local files
+type localKeyProvider struct {
+	currentKeyID string
+	// keys are indexed by their ids
+	keys map[string][]byte
+}
+
+// NewLocalKeyProvider returns a key provider encrypting the new data keys with the key of the given file. The keys of
+// the previous key files are only used to decrypt the data keys encrypted before a key rotation.
+func NewLocalKeyProvider(keyFile string, previousKeyFiles ...string) (*localKeyProvider, error) {
+	p := &localKeyProvider{keys: map[string][]byte{}}
+	for i, file := range append([]string{keyFile}, previousKeyFiles...) {
+		content, err := shared.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the db encryption key of file %q: %w", file, err)
+		}
+		if len(key) != dataKeySize {
+			return nil, fmt.Errorf("the db encryption key of file %q must be %d bytes long, got %d", file, dataKeySize, len(key))
+		}
+
+		keyID := localKeyID(key)
+		if i == 0 {
+			p.currentKeyID = keyID
+		}
+		p.keys[keyID] = key
+	}
+	return p, nil
+}
+
+// localKeyID identifies a key by its fingerprint so that the key itself does not need to be stored
+func localKeyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return "local-" + hex.EncodeToString(sum[:8])
+}
+
+func (p *localKeyProvider) KeyID() string {
+	return p.currentKeyID
+}
+
+func (p *localKeyProvider) GenerateDataKey() ([]byte, []byte, error) {
+	dataKey, err := randomBytes(dataKeySize)
+	if err != nil {
+		return nil, nil, err
+	}
+	encrypted, err := seal(p.keys[p.currentKeyID], dataKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return dataKey, encrypted, nil
+}
+
+func (p *localKeyProvider) DecryptDataKey(keyID string, encrypted []byte) ([]byte, error) {
+	key, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown db encryption key %q, it may be missing from the previous key files", keyID)
+	}
+	return open(key, encrypted)
+}
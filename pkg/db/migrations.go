@@ -15,6 +15,8 @@ type Migration struct {
 	DbFactory   *ConnectionFactory
 	Gormigrate  *gormigrate.Gormigrate
 	GormOptions *gormigrate.Options
+	// EncryptedColumns are the columns re-encrypted by ReencryptColumns
+	EncryptedColumns []EncryptedColumn
 }
 
 func NewMigration(dbConfig *DatabaseConfig, gormOptions *gormigrate.Options, migrations []*gormigrate.Migration) (*Migration, func(), error) {
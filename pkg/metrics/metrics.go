@@ -83,6 +83,16 @@ const (
 	// PrewarmingStatusInfoCount - metric name for the total number of prewarmed instances per cluster_id, status and instance type.
 	PrewarmingStatusInfoCount = "prewarmed_kafka_instances"
 
+	// KafkaDNSDriftRecordsCount - metric name for the number of drifted CNAME records of the Kafka instances
+	KafkaDNSDriftRecordsCount = "kafka_dns_drift_records_count"
+	// KafkaDNSDriftRepairsCount - metric name for the number of repaired drifted CNAME records of the Kafka instances
+	KafkaDNSDriftRepairsCount = "kafka_dns_drift_repairs_count"
+	labelDrift                = "drift"
+
+	// KafkaTLSCertificateDaysToExpiry - metric name for the number of days before the expiry of the tls certificate of a base domain of the Kafka instances
+	KafkaTLSCertificateDaysToExpiry = "kafka_tls_certificate_days_to_expiry"
+	labelBaseDomain                 = "base_domain"
+
 	LabelStatusCode = "code"
 	LabelMethod     = "method"
 	LabelPath       = "path"
@@ -188,6 +198,15 @@ var ClusterProviderResourceQuotaLabels = []string{
 	LabelClusterProvider,
 }
 
+var kafkaDNSDriftMetricsLabels = []string{
+	LabelClusterID,
+	labelDrift,
+}
+
+var kafkaTLSCertificateMetricsLabels = []string{
+	labelBaseDomain,
+}
+
 var prewarmingMetricLabels = []string{
 	prewarmingClusterIDLabel,
 	prewarmingInstanceTypeLabel,
@@ -809,6 +828,76 @@ func UpdateClusterPrewarmingStatusInfoCountMetric(prewarmingStatusInfo Prewarmin
 	prewarmingStatusInfoCountMetric.With(labels).Set(float64(prewarmingStatusInfo.Count))
 }
 
+// create a new gaugeVec for the number of drifted CNAME records of the Kafka instances per cluster_id and drift
+var kafkaDNSDriftRecordsCountMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Subsystem: KasFleetManager,
+		Name:      KafkaDNSDriftRecordsCount,
+		Help:      "number of CNAME records of the Kafka instances deployed on the given cluster_id found drifted by the last DNS drift check",
+	},
+	kafkaDNSDriftMetricsLabels,
+)
+
+// create a new counterVec for the number of repaired drifted CNAME records of the Kafka instances per cluster_id and drift
+var kafkaDNSDriftRepairsCountMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: KasFleetManager,
+		Name:      KafkaDNSDriftRepairsCount,
+		Help:      "count of drifted CNAME records of the Kafka instances deployed on the given cluster_id repaired by the DNS drift check",
+	},
+	kafkaDNSDriftMetricsLabels,
+)
+
+// UpdateKafkaDNSDriftRecordsCountMetric - Updates the kas_fleet_manager_kafka_dns_drift_records_count metric.
+//
+//	drift: the kind of drift e.g. missing, mismatched or orphaned.
+func UpdateKafkaDNSDriftRecordsCountMetric(clusterId string, drift string, count int) {
+	labels := prometheus.Labels{
+		LabelClusterID: clusterId,
+		labelDrift:     drift,
+	}
+	kafkaDNSDriftRecordsCountMetric.With(labels).Set(float64(count))
+}
+
+// IncreaseKafkaDNSDriftRepairsCountMetric - Increases the kas_fleet_manager_kafka_dns_drift_repairs_count metric.
+func IncreaseKafkaDNSDriftRepairsCountMetric(clusterId string, drift string, count int) {
+	labels := prometheus.Labels{
+		LabelClusterID: clusterId,
+		labelDrift:     drift,
+	}
+	kafkaDNSDriftRepairsCountMetric.With(labels).Add(float64(count))
+}
+
+// ResetKafkaDNSDriftRecordsCountMetric resets the drifted CNAME records count so that the clusters which are no longer
+// checked are not reported
+func ResetKafkaDNSDriftRecordsCountMetric() {
+	kafkaDNSDriftRecordsCountMetric.Reset()
+}
+
+// create a new gaugeVec for the number of days before the expiry of the tls certificate per base_domain
+var kafkaTLSCertificateDaysToExpiryMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Subsystem: KasFleetManager,
+		Name:      KafkaTLSCertificateDaysToExpiry,
+		Help:      "number of days before the expiry of the tls certificate of the given base_domain of the Kafka instances, negative once expired",
+	},
+	kafkaTLSCertificateMetricsLabels,
+)
+
+// UpdateKafkaTLSCertificateDaysToExpiryMetric - Updates the kas_fleet_manager_kafka_tls_certificate_days_to_expiry metric.
+func UpdateKafkaTLSCertificateDaysToExpiryMetric(baseDomain string, days float64) {
+	labels := prometheus.Labels{
+		labelBaseDomain: baseDomain,
+	}
+	kafkaTLSCertificateDaysToExpiryMetric.With(labels).Set(days)
+}
+
+// ResetKafkaTLSCertificateDaysToExpiryMetric resets the days to expiry of the certificates so that the base domains
+// which are no longer used are not reported
+func ResetKafkaTLSCertificateDaysToExpiryMetric() {
+	kafkaTLSCertificateDaysToExpiryMetric.Reset()
+}
+
 // register the metric(s)
 func init() {
 	// metrics for data plane clusters
@@ -832,6 +921,9 @@ func init() {
 	prometheus.MustRegister(kafkaStatusSinceCreatedMetric)
 	prometheus.MustRegister(kafkaRequestsCurrentStatusInfoMetric)
 	prometheus.MustRegister(KafkaStatusCountMetric)
+	prometheus.MustRegister(kafkaDNSDriftRecordsCountMetric)
+	prometheus.MustRegister(kafkaDNSDriftRepairsCountMetric)
+	prometheus.MustRegister(kafkaTLSCertificateDaysToExpiryMetric)
 
 	// metrics for reconcilers
 	prometheus.MustRegister(reconcilerDurationMetric)
@@ -905,6 +997,9 @@ func Reset() {
 	kafkaOperationsTotalCountMetric.Reset()
 	kafkaStatusSinceCreatedMetric.Reset()
 	KafkaStatusCountMetric.Reset()
+	kafkaDNSDriftRecordsCountMetric.Reset()
+	kafkaDNSDriftRepairsCountMetric.Reset()
+	kafkaTLSCertificateDaysToExpiryMetric.Reset()
 
 	reconcilerDurationMetric.Reset()
 	reconcilerSuccessCountMetric.Reset()
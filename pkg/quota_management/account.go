@@ -5,9 +5,9 @@ import (
 )
 
 type Account struct {
-	Username            string    `yaml:"username"`
-	MaxAllowedInstances int       `yaml:"max_allowed_instances"`
-	GrantedQuota        QuotaList `yaml:"granted_quota,omitempty"`
+	Username            string    `yaml:"username" json:"username"`
+	MaxAllowedInstances int       `yaml:"max_allowed_instances" json:"max_allowed_instances"`
+	GrantedQuota        QuotaList `yaml:"granted_quota,omitempty" json:"granted_quota,omitempty"`
 }
 
 var _ QuotaManagementListItem = &Account{}
@@ -1,9 +1,9 @@
 package quota_management
 
 type BillingModel struct {
-	Id                  string          `yaml:"id"`
-	ExpirationDate      *ExpirationDate `yaml:"expiration_date,omitempty"`
-	MaxAllowedInstances int             `yaml:"max_allowed_instances"`
+	Id                  string          `yaml:"id" json:"id"`
+	ExpirationDate      *ExpirationDate `yaml:"expiration_date,omitempty" json:"expiration_date,omitempty"`
+	MaxAllowedInstances int             `yaml:"max_allowed_instances" json:"max_allowed_instances"`
 }
 
 func (bm *BillingModel) HasExpired() bool {
@@ -18,6 +18,20 @@ var _ yaml.Unmarshaler = &ExpirationDate{}
 
 type ExpirationDate time.Time
 
+// ParseExpirationDate parses a date in the 'YYYY-MM-DD {+,-}HH:MM' format used by the quota management list
+func ParseExpirationDate(value string) (ExpirationDate, error) {
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return ExpirationDate{}, err
+	}
+	return ExpirationDate(t), nil
+}
+
+// String formats the date in the 'YYYY-MM-DD {+,-}HH:MM' format used by the quota management list
+func (e ExpirationDate) String() string {
+	return time.Time(e).Format(layout)
+}
+
 func (e *ExpirationDate) UnmarshalJSON(b []byte) error {
 	value := strings.Trim(string(b), `"`) //get rid of "
 	if value == "" || value == "null" {
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type HealthCheckKind string
+
+const (
+	// ReadinessCheck checks are run by /healthcheck/ready, the replica stops receiving requests while they fail. The
+	// database is the only shared dependency checked this way: its check pings the connection pool of the replica,
+	// which can fail on its own, and no request can be served without it anyway.
+	ReadinessCheck HealthCheckKind = "readiness"
+	// LivenessCheck checks are run by both /healthcheck/live and /healthcheck/ready, the replica is restarted while
+	// they fail. They must not check the dependencies shared by the replicas e.g. the database.
+	LivenessCheck HealthCheckKind = "liveness"
+	// DependencyCheck checks are reported by /healthcheck/ready but never fail it. They check the external services
+	// shared by all the replicas, whose outage would take every replica out of service while none of them can fix it.
+	DependencyCheck HealthCheckKind = "dependency"
+
+	HealthCheckStatusOK     = "ok"
+	HealthCheckStatusFailed = "failed"
+)
+
+// HealthCheck checks a dependency of the service. The checks are contributed by the environments through the DI
+// container e.g.
+//
+//	di.Provide(func(c *Config) *server.HealthCheck { return &server.HealthCheck{Name: "dependency", ...} })
+type HealthCheck struct {
+	// Name identifies the dependency in the reports
+	Name string
+	Kind HealthCheckKind
+	// Check returns an error when the dependency is unhealthy. The check is abandoned once the context is done.
+	Check func(ctx context.Context) error
+	// Details optionally returns information reported with the status of the dependency
+	Details func() interface{}
+	// uncached checks are run by every probe
+	uncached bool
+}
+
+type HealthCheckResult struct {
+	Status    string      `json:"status"`
+	Error     string      `json:"error,omitempty"`
+	Details   interface{} `json:"details,omitempty"`
+	CheckedAt time.Time   `json:"checked_at"`
+	Duration  string      `json:"duration"`
+}
+
+type HealthStatus struct {
+	Status string                        `json:"status"`
+	Checks map[string]*HealthCheckResult `json:"checks"`
+}
+
+// healthChecks runs the health checks concurrently, the results are cached so that frequent probes do not overload
+// the dependencies
+type healthChecks struct {
+	checks   []*HealthCheck
+	timeout  time.Duration
+	cacheTTL time.Duration
+	// the lock of each check is held while it runs so that concurrent probes share its result
+	locks   map[string]*sync.Mutex
+	mu      sync.Mutex
+	results map[string]*HealthCheckResult
+	// running holds the checks still running, including the ones abandoned once they timed out
+	running map[string]bool
+}
+
+func newHealthChecks(checks []*HealthCheck, timeout time.Duration, cacheTTL time.Duration) *healthChecks {
+	locks := map[string]*sync.Mutex{}
+	for _, check := range checks {
+		locks[check.Name] = &sync.Mutex{}
+	}
+	return &healthChecks{
+		checks:   checks,
+		timeout:  timeout,
+		cacheTTL: cacheTTL,
+		locks:    locks,
+		results:  map[string]*HealthCheckResult{},
+		running:  map[string]bool{},
+	}
+}
+
+// run runs the checks of the given kinds, the status is failed when any of the checks but the dependency checks fails
+func (h *healthChecks) run(kinds ...HealthCheckKind) *HealthStatus {
+	status := &HealthStatus{
+		Status: HealthCheckStatusOK,
+		Checks: map[string]*HealthCheckResult{},
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range h.checks {
+		if !hasKind(kinds, check.Kind) {
+			continue
+		}
+		wg.Add(1)
+		go func(check *HealthCheck) {
+			defer wg.Done()
+			result := h.runCheck(check)
+			mu.Lock()
+			defer mu.Unlock()
+			status.Checks[check.Name] = result
+			if result.Status != HealthCheckStatusOK && check.Kind != DependencyCheck {
+				status.Status = HealthCheckStatusFailed
+			}
+		}(check)
+	}
+	wg.Wait()
+	return status
+}
+
+func (h *healthChecks) runCheck(check *HealthCheck) *HealthCheckResult {
+	lock := h.locks[check.Name]
+	lock.Lock()
+	defer lock.Unlock()
+
+	if result := h.cachedResult(check); result != nil {
+		return result
+	}
+
+	// the results are shared by the probes, so the checks are not cancelled when a probe is
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+	start := time.Now()
+	var err error
+	// the checks which ignore the context keep running in the background once they time out, they are not run again
+	// until they complete so that they cannot pile up
+	if h.startRunning(check) {
+		done := make(chan error, 1)
+		go func() {
+			defer h.stopRunning(check)
+			done <- check.Check(ctx)
+		}()
+		select {
+		case err = <-done:
+		case <-ctx.Done():
+			err = fmt.Errorf("check did not complete within %s", h.timeout)
+		}
+	} else {
+		err = fmt.Errorf("check timed out previously and is still running")
+	}
+
+	result := &HealthCheckResult{
+		Status:    HealthCheckStatusOK,
+		CheckedAt: start,
+		Duration:  time.Since(start).String(),
+	}
+	if err != nil {
+		result.Status = HealthCheckStatusFailed
+		result.Error = err.Error()
+	}
+	if check.Details != nil {
+		result.Details = check.Details()
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.results[check.Name] = result
+	return result
+}
+
+func (h *healthChecks) startRunning(check *HealthCheck) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.running[check.Name] {
+		return false
+	}
+	h.running[check.Name] = true
+	return true
+}
+
+func (h *healthChecks) stopRunning(check *HealthCheck) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.running, check.Name)
+}
+
+func (h *healthChecks) cachedResult(check *HealthCheck) *HealthCheckResult {
+	if check.uncached {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	result, ok := h.results[check.Name]
+	if !ok || time.Since(result.CheckedAt) >= h.cacheTTL {
+		return nil
+	}
+	return result
+}
+
+func hasKind(kinds []HealthCheckKind, kind HealthCheckKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/client/keycloak"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+)
+
+// NewDatabaseHealthCheck checks that the database accepts the connections of the replica
+func NewDatabaseHealthCheck(connectionFactory *db.ConnectionFactory) *HealthCheck {
+	return &HealthCheck{
+		Name: "database",
+		Kind: ReadinessCheck,
+		Check: func(ctx context.Context) error {
+			sqlDB, err := connectionFactory.DB.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		},
+	}
+}
+
+// NewSSOHealthCheck checks that the signing keys of the SSO provider managing the service accounts can be fetched.
+// It does not fail the readiness probe.
+func NewSSOHealthCheck(keycloakConfig *keycloak.KeycloakConfig) *HealthCheck {
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: keycloakConfig.InsecureSkipVerify,
+			},
+		},
+	}
+	return &HealthCheck{
+		Name: "sso",
+		Kind: DependencyCheck,
+		Check: func(ctx context.Context) error {
+			realm := keycloakConfig.SSOProviderRealm()
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.JwksEndpointURI, nil)
+			if err != nil {
+				return err
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, realm.JwksEndpointURI)
+			}
+			return nil
+		},
+	}
+}
+
+// NewLeaderElectionHealthCheck checks that the leader election of the workers is not stalled, in which case the
+// replica would lose its leader leases without releasing them. The leases held by the replica are reported as details.
+func NewLeaderElectionHealthCheck(leaderElectionManager *workers.LeaderElectionManager) *HealthCheck {
+	return &HealthCheck{
+		Name: "leader_election",
+		Kind: LivenessCheck,
+		Check: func(ctx context.Context) error {
+			lastElection := leaderElectionManager.LastElection()
+			if lastElection.IsZero() {
+				// not started yet
+				return nil
+			}
+			if since := time.Since(lastElection); since > leaderElectionManager.ElectionStallTimeout() {
+				return fmt.Errorf("the leader election last ran %s ago", since.Round(time.Second))
+			}
+			return nil
+		},
+		Details: func() interface{} {
+			return map[string]interface{}{"leader_leases": leaderElectionManager.LeaderLeases()}
+		},
+	}
+}
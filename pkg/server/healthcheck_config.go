@@ -2,6 +2,7 @@ package server
 
 import (
 	"crypto/tls"
+	"time"
 
 	"github.com/spf13/pflag"
 )
@@ -14,6 +15,10 @@ type HealthCheckConfig struct {
 	// tls package accepts the versions in uint16 format, whose values
 	// are available as constants in that same package
 	MinTLSVersion uint16
+	// CheckTimeout is how long a dependency check of the readiness and liveness probes can run before it fails
+	CheckTimeout time.Duration `json:"check_timeout"`
+	// CheckCacheTTL is how long the result of a dependency check is reused by the probes
+	CheckCacheTTL time.Duration `json:"check_cache_ttl"`
 }
 
 func NewHealthCheckConfig() *HealthCheckConfig {
@@ -21,12 +26,16 @@ func NewHealthCheckConfig() *HealthCheckConfig {
 		BindAddress:   "localhost:8083",
 		EnableHTTPS:   false,
 		MinTLSVersion: tls.VersionTLS12,
+		CheckTimeout:  5 * time.Second,
+		CheckCacheTTL: 10 * time.Second,
 	}
 }
 
 func (c *HealthCheckConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.BindAddress, "health-check-server-bindaddress", c.BindAddress, "Health check server bind address")
 	fs.BoolVar(&c.EnableHTTPS, "enable-health-check-https", c.EnableHTTPS, "Enable HTTPS for health check server")
+	fs.DurationVar(&c.CheckTimeout, "health-check-timeout", c.CheckTimeout, "How long a dependency check of the readiness and liveness probes can run before it fails")
+	fs.DurationVar(&c.CheckCacheTTL, "health-check-cache-ttl", c.CheckCacheTTL, "How long the result of a dependency check of the readiness and liveness probes is reused")
 }
 
 func (c *HealthCheckConfig) ReadFiles() error {
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
@@ -28,7 +29,7 @@ type HealthCheckServer struct {
 	healthCheckConfig *HealthCheckConfig
 }
 
-func NewHealthCheckServer(healthCheckConfig *HealthCheckConfig, serverConfig *ServerConfig, sentryConfig *sentry.Config) *HealthCheckServer {
+func NewHealthCheckServer(healthCheckConfig *HealthCheckConfig, serverConfig *ServerConfig, sentryConfig *sentry.Config, checks []*HealthCheck) *HealthCheckServer {
 	router := mux.NewRouter()
 	health.DefaultRegistry = health.NewRegistry()
 	health.Register("maintenance_status", updater)
@@ -36,6 +37,26 @@ func NewHealthCheckServer(healthCheckConfig *HealthCheckConfig, serverConfig *Se
 	router.HandleFunc("/healthcheck/down", downHandler).Methods(http.MethodPost)
 	router.HandleFunc("/healthcheck/up", upHandler).Methods(http.MethodPost)
 
+	// the maintenance mode takes the replicas out of service as soon as it is enabled
+	maintenanceCheck := &HealthCheck{
+		Name: "maintenance_status",
+		Kind: ReadinessCheck,
+		Check: func(ctx context.Context) error {
+			return updater.Check()
+		},
+		uncached: true,
+	}
+	enabledChecks := []*HealthCheck{maintenanceCheck}
+	for _, check := range checks {
+		// the providers of the checks return nil when their dependency is disabled
+		if check != nil {
+			enabledChecks = append(enabledChecks, check)
+		}
+	}
+	dependencyChecks := newHealthChecks(enabledChecks, healthCheckConfig.CheckTimeout, healthCheckConfig.CheckCacheTTL)
+	router.HandleFunc("/healthcheck/ready", healthStatusHandler(dependencyChecks, ReadinessCheck, LivenessCheck, DependencyCheck)).Methods(http.MethodGet)
+	router.HandleFunc("/healthcheck/live", healthStatusHandler(dependencyChecks, LivenessCheck)).Methods(http.MethodGet)
+
 	srv := &http.Server{
 		Handler: router,
 		Addr:    healthCheckConfig.BindAddress,
@@ -93,6 +114,21 @@ func (s HealthCheckServer) Listen() (listener net.Listener, err error) {
 func (s HealthCheckServer) Serve(listener net.Listener) {
 }
 
+// healthStatusHandler reports the status of the checks of the given kinds, the response status is 503 when any of the
+// checks but the dependency checks fails
+func healthStatusHandler(checks *healthChecks, kinds ...HealthCheckKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status := checks.run(kinds...)
+		w.Header().Set("Content-Type", "application/json")
+		if status.Status != HealthCheckStatusOK {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if err := json.NewEncoder(w).Encode(status); err != nil {
+			glog.Errorf("failed to write the health status: %v", err)
+		}
+	}
+}
+
 func upHandler(w http.ResponseWriter, r *http.Request) {
 	updater.Update(nil)
 }
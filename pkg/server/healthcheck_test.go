@@ -0,0 +1,178 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/sentry"
+	"github.com/onsi/gomega"
+)
+
+func Test_healthChecks_run(t *testing.T) {
+	tests := []struct {
+		name       string
+		checks     []*HealthCheck
+		kinds      []HealthCheckKind
+		wantStatus string
+		wantChecks map[string]string
+	}{
+		{
+			name: "should report the status of each check",
+			checks: []*HealthCheck{
+				{Name: "ok", Kind: ReadinessCheck, Check: func(ctx context.Context) error { return nil }},
+				{Name: "failed", Kind: ReadinessCheck, Check: func(ctx context.Context) error { return fmt.Errorf("failed") }},
+			},
+			kinds:      []HealthCheckKind{ReadinessCheck},
+			wantStatus: HealthCheckStatusFailed,
+			wantChecks: map[string]string{"ok": HealthCheckStatusOK, "failed": HealthCheckStatusFailed},
+		},
+		{
+			name: "should only run the checks of the given kinds",
+			checks: []*HealthCheck{
+				{Name: "live", Kind: LivenessCheck, Check: func(ctx context.Context) error { return nil }},
+				{Name: "ready", Kind: ReadinessCheck, Check: func(ctx context.Context) error { return fmt.Errorf("failed") }},
+			},
+			kinds:      []HealthCheckKind{LivenessCheck},
+			wantStatus: HealthCheckStatusOK,
+			wantChecks: map[string]string{"live": HealthCheckStatusOK},
+		},
+		{
+			name: "should not fail the status when a dependency check fails",
+			checks: []*HealthCheck{
+				{Name: "ready", Kind: ReadinessCheck, Check: func(ctx context.Context) error { return nil }},
+				{Name: "dependency", Kind: DependencyCheck, Check: func(ctx context.Context) error { return fmt.Errorf("failed") }},
+			},
+			kinds:      []HealthCheckKind{ReadinessCheck, DependencyCheck},
+			wantStatus: HealthCheckStatusOK,
+			wantChecks: map[string]string{"ready": HealthCheckStatusOK, "dependency": HealthCheckStatusFailed},
+		},
+		{
+			name: "should fail the checks which do not complete in time",
+			checks: []*HealthCheck{
+				{Name: "slow", Kind: ReadinessCheck, Check: func(ctx context.Context) error {
+					time.Sleep(time.Second)
+					return nil
+				}},
+			},
+			kinds:      []HealthCheckKind{ReadinessCheck},
+			wantStatus: HealthCheckStatusFailed,
+			wantChecks: map[string]string{"slow": HealthCheckStatusFailed},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			status := newHealthChecks(tt.checks, 50*time.Millisecond, time.Minute).run(tt.kinds...)
+			g.Expect(status.Status).To(gomega.Equal(tt.wantStatus))
+			checks := map[string]string{}
+			for name, result := range status.Checks {
+				checks[name] = result.Status
+			}
+			g.Expect(checks).To(gomega.Equal(tt.wantChecks))
+		})
+	}
+}
+
+func Test_healthChecks_cache(t *testing.T) {
+	g := gomega.NewWithT(t)
+	calls := 0
+	uncachedCalls := 0
+	checks := newHealthChecks([]*HealthCheck{
+		{Name: "cached", Kind: ReadinessCheck, Check: func(ctx context.Context) error {
+			calls++
+			return nil
+		}},
+		{Name: "uncached", Kind: ReadinessCheck, Check: func(ctx context.Context) error {
+			uncachedCalls++
+			return nil
+		}, uncached: true},
+	}, time.Second, 100*time.Millisecond)
+
+	checks.run(ReadinessCheck)
+	checks.run(ReadinessCheck)
+	g.Expect(calls).To(gomega.Equal(1))
+	g.Expect(uncachedCalls).To(gomega.Equal(2))
+
+	time.Sleep(100 * time.Millisecond)
+	checks.run(ReadinessCheck)
+	g.Expect(calls).To(gomega.Equal(2))
+}
+
+func Test_healthChecks_timedOutChecks(t *testing.T) {
+	g := gomega.NewWithT(t)
+	var calls int32
+	release := make(chan struct{})
+	checks := newHealthChecks([]*HealthCheck{
+		{Name: "stuck", Kind: ReadinessCheck, Check: func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			<-release // ignores the context
+			return nil
+		}},
+	}, 10*time.Millisecond, 0)
+
+	// the check which timed out is not run again while it is still running
+	g.Expect(checks.run(ReadinessCheck).Status).To(gomega.Equal(HealthCheckStatusFailed))
+	g.Expect(checks.run(ReadinessCheck).Status).To(gomega.Equal(HealthCheckStatusFailed))
+	g.Expect(atomic.LoadInt32(&calls)).To(gomega.Equal(int32(1)))
+
+	close(release)
+	g.Eventually(func() string { return checks.run(ReadinessCheck).Status }).Should(gomega.Equal(HealthCheckStatusOK))
+	g.Expect(atomic.LoadInt32(&calls)).To(gomega.Equal(int32(2)))
+}
+
+func TestHealthCheckServer_probes(t *testing.T) {
+	g := gomega.NewWithT(t)
+	var dependencyErr error
+	config := NewHealthCheckConfig()
+	config.CheckCacheTTL = 0
+	server := NewHealthCheckServer(config, NewServerConfig(), &sentry.Config{}, []*HealthCheck{
+		{Name: "dependency", Kind: ReadinessCheck, Check: func(ctx context.Context) error { return dependencyErr }},
+		{Name: "external", Kind: DependencyCheck, Check: func(ctx context.Context) error { return fmt.Errorf("unavailable") }},
+		nil,
+	})
+
+	probe := func(method string, path string) (int, *HealthStatus) {
+		w := httptest.NewRecorder()
+		server.httpServer.Handler.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		var status HealthStatus
+		if method == http.MethodGet {
+			g.Expect(json.Unmarshal(w.Body.Bytes(), &status)).To(gomega.Succeed())
+		}
+		return w.Code, &status
+	}
+
+	code, status := probe(http.MethodGet, "/healthcheck/ready")
+	g.Expect(code).To(gomega.Equal(http.StatusOK))
+	g.Expect(status.Checks).To(gomega.HaveKey("dependency"))
+	g.Expect(status.Checks).To(gomega.HaveKey("maintenance_status"))
+	// the outage of the external services is only reported
+	g.Expect(status.Checks["external"].Status).To(gomega.Equal(HealthCheckStatusFailed))
+
+	dependencyErr = fmt.Errorf("unavailable")
+	code, status = probe(http.MethodGet, "/healthcheck/ready")
+	g.Expect(code).To(gomega.Equal(http.StatusServiceUnavailable))
+	g.Expect(status.Checks["dependency"].Error).To(gomega.Equal("unavailable"))
+
+	// the replica is only restarted when its liveness checks fail
+	code, status = probe(http.MethodGet, "/healthcheck/live")
+	g.Expect(code).To(gomega.Equal(http.StatusOK))
+	g.Expect(status.Checks).To(gomega.BeEmpty())
+
+	dependencyErr = nil
+	probe(http.MethodPost, "/healthcheck/down")
+	code, status = probe(http.MethodGet, "/healthcheck/ready")
+	g.Expect(code).To(gomega.Equal(http.StatusServiceUnavailable))
+	g.Expect(status.Checks["maintenance_status"].Status).To(gomega.Equal(HealthCheckStatusFailed))
+
+	probe(http.MethodPost, "/healthcheck/up")
+	code, _ = probe(http.MethodGet, "/healthcheck/ready")
+	g.Expect(code).To(gomega.Equal(http.StatusOK))
+}
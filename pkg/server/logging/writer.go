@@ -56,6 +56,10 @@ func (writer *loggingWriter) GetResponseStatusCode() int {
 	return writer.responseStatus
 }
 
+func (writer *loggingWriter) GetResponseBody() []byte {
+	return writer.responseBody
+}
+
 func (writer *loggingWriter) prepareRequestLog() (string, error) {
 	return writer.formatter.FormatRequestLog(writer.request)
 }
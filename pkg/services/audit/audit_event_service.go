@@ -0,0 +1,86 @@
+package audit
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/queryparser"
+)
+
+// ValidColumns are the columns of the audit events that can be used in the search query and in the order by clause
+var ValidColumns = []string{"actor", "org_id", "method", "route_name", "path", "target_id", "status_code"}
+
+// AcceptedOrderByParams are the accepted values of the orderBy parameter when listing the audit events
+var AcceptedOrderByParams = append([]string{"created_at"}, ValidColumns...)
+
+// AuditEventService stores the audit events of the API calls made against the service and allows to query them.
+//
+//go:generate moq -out audit_event_service_moq.go . AuditEventService
+type AuditEventService interface {
+	Create(event *api.AuditEvent) *errors.ServiceError
+	// List returns the audit events matching the search query of the list arguments. The most recent events are returned first
+	// unless a different order is requested.
+	List(listArgs *services.ListArguments) (api.AuditEventList, *api.PagingMeta, *errors.ServiceError)
+}
+
+var _ AuditEventService = &auditEventService{}
+
+type auditEventService struct {
+	connectionFactory *db.ConnectionFactory
+}
+
+func NewAuditEventService(connectionFactory *db.ConnectionFactory) AuditEventService {
+	return &auditEventService{
+		connectionFactory: connectionFactory,
+	}
+}
+
+func (s *auditEventService) Create(event *api.AuditEvent) *errors.ServiceError {
+	// the event is stored outside of the transaction of the request, so that it is kept even when the request fails
+	if err := s.connectionFactory.New().Create(event).Error; err != nil {
+		return services.HandleCreateError("audit event", err)
+	}
+	return nil
+}
+
+func (s *auditEventService) List(listArgs *services.ListArguments) (api.AuditEventList, *api.PagingMeta, *errors.ServiceError) {
+	var events api.AuditEventList
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+
+	dbConn := s.connectionFactory.New().Model(&api.AuditEvent{})
+
+	if len(listArgs.Search) > 0 {
+		searchDbQuery, err := queryparser.NewQueryParser(ValidColumns...).Parse(listArgs.Search)
+		if err != nil {
+			return nil, nil, errors.NewWithCause(errors.ErrorFailedToParseSearch, err, "unable to list audit events: %s", err.Error())
+		}
+		dbConn = dbConn.Where(searchDbQuery.Query, searchDbQuery.Values...)
+	}
+
+	var total int64
+	if err := dbConn.Count(&total).Error; err != nil {
+		return nil, nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to count audit events")
+	}
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+
+	if len(listArgs.OrderBy) == 0 {
+		// most recent events first by default
+		dbConn = dbConn.Order("created_at desc")
+	}
+	for _, orderByArg := range listArgs.OrderBy {
+		dbConn = dbConn.Order(orderByArg)
+	}
+
+	if err := dbConn.Offset((pagingMeta.Page - 1) * listArgs.Size).Limit(listArgs.Size).Find(&events).Error; err != nil {
+		return nil, nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list audit events")
+	}
+
+	return events, pagingMeta, nil
+}
@@ -0,0 +1,96 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_auditEventService_Create(t *testing.T) {
+	tests := []struct {
+		name    string
+		setupFn func()
+		wantErr bool
+	}{
+		{
+			name: "should store the audit event",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`INSERT INTO "audit_events"`)
+			},
+		},
+		{
+			name: "should return an error if the audit event cannot be stored",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`INSERT INTO "audit_events"`).WithExecException()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			s := NewAuditEventService(db.NewMockConnectionFactory(nil))
+			event := &api.AuditEvent{Actor: "test-user", Method: "POST", Path: "/api/kafkas_mgmt/v1/kafkas", StatusCode: 202}
+			err := s.Create(event)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(event.ID).ToNot(gomega.BeEmpty())
+		})
+	}
+}
+
+func Test_auditEventService_List(t *testing.T) {
+	tests := []struct {
+		name        string
+		listArgs    *services.ListArguments
+		setupFn     func()
+		wantEvents  api.AuditEventList
+		wantErrCode errors.ServiceErrorCode
+	}{
+		{
+			name:        "should return an error if the search query is invalid",
+			listArgs:    &services.ListArguments{Page: 1, Size: 100, Search: "password = secret"},
+			setupFn:     func() { mocket.Catcher.Reset() },
+			wantErrCode: errors.ErrorFailedToParseSearch,
+		},
+		{
+			name:     "should return the audit events matching the search query, most recent first",
+			listArgs: &services.ListArguments{Page: 1, Size: 100, Search: "actor = test-user and status_code = 202"},
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().WithQuery(`SELECT count(1) FROM "audit_events" WHERE actor = $1 and status_code = $2`).WithReply([]map[string]interface{}{{"count": 1}})
+				mocket.Catcher.NewMock().WithQuery(`SELECT * FROM "audit_events" WHERE actor = $1 and status_code = $2 ORDER BY created_at desc`).WithReply([]map[string]interface{}{
+					{"id": "event-id", "actor": "test-user", "method": "POST", "route_name": "create-kafka", "status_code": 202},
+				})
+			},
+			wantEvents: api.AuditEventList{
+				{ID: "event-id", Actor: "test-user", Method: "POST", RouteName: "create-kafka", StatusCode: 202},
+			},
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			s := NewAuditEventService(db.NewMockConnectionFactory(nil))
+			events, paging, err := s.List(tt.listArgs)
+			if tt.wantErrCode != 0 {
+				g.Expect(err).ToNot(gomega.BeNil())
+				g.Expect(err.Code).To(gomega.Equal(tt.wantErrCode))
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(paging.Total).To(gomega.Equal(len(tt.wantEvents)))
+			g.Expect(events).To(gomega.Equal(tt.wantEvents))
+		})
+	}
+}
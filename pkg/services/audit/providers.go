@@ -0,0 +1,14 @@
+package audit
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/goava/di"
+)
+
+func ConfigProviders() di.Option {
+	return di.Provide(environments.Func(ServiceProviders))
+}
+
+func ServiceProviders() di.Option {
+	return di.Provide(NewAuditEventService)
+}
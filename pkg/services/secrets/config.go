@@ -0,0 +1,101 @@
+package secrets
+
+import (
+	"fmt"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/spf13/pflag"
+)
+
+type Config struct {
+	Kind string `json:"kind"`
+
+	// Used by the aws vault
+	AccessKey           string `json:"access_key"`
+	AccessKeyFile       string `json:"access_key_file"`
+	SecretAccessKey     string `json:"secret_access_key"`
+	SecretAccessKeyFile string `json:"secret_access_key_file"`
+	Region              string `json:"region"`
+
+	// Used by the aws and hashicorp vaults
+	SecretPrefix       string `json:"secret_prefix"`
+	SecretPrefixEnable bool   `json:"secret_prefix_enable"`
+
+	// Used by the hashicorp vault, the secrets are stored in its KV version 2 secrets engine
+	Address   string `json:"address"`
+	Token     string `json:"token"`
+	TokenFile string `json:"token_file"`
+	MountPath string `json:"mount_path"`
+	Namespace string `json:"namespace"`
+
+	// Used by the file vault
+	Directory string `json:"directory"`
+
+	// MetricsSubsystem prefixes the metrics of the vault service, it is set by the service using the vault
+	MetricsSubsystem string `json:"-"`
+}
+
+func NewConfig() *Config {
+	return &Config{
+		Kind:                KindTmp,
+		AccessKeyFile:       "secrets/vault/aws_access_key_id",
+		SecretAccessKeyFile: "secrets/vault/aws_secret_access_key",
+		Region:              DefaultRegion,
+		SecretPrefixEnable:  false,
+		TokenFile:           "secrets/vault/token",
+		MountPath:           "secret",
+		Directory:           "secrets/vault/data",
+	}
+}
+
+func (c *Config) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.Kind, "vault-kind", c.Kind, "The kind of vault to use: aws|hashicorp|file|tmp")
+	fs.StringVar(&c.AccessKeyFile, "vault-access-key-file", c.AccessKeyFile, "File containing vault access key")
+	fs.StringVar(&c.SecretAccessKeyFile, "vault-secret-access-key-file", c.SecretAccessKeyFile, "File containing vault secret access key")
+	fs.BoolVar(&c.SecretPrefixEnable, "vault-secret-prefix-enable", c.SecretPrefixEnable, "Enable use of a prefix for all secret names in AWS or HashiCorp vault, default false")
+	fs.StringVar(&c.SecretPrefix, "vault-secret-prefix", c.SecretPrefix, "Prefix to use for all secret names in AWS or HashiCorp vault")
+	fs.StringVar(&c.Region, "vault-region", c.Region, "The region of the vault")
+	fs.StringVar(&c.Address, "vault-address", c.Address, "The address of the HashiCorp vault e.g. https://vault.example.com:8200")
+	fs.StringVar(&c.TokenFile, "vault-token-file", c.TokenFile, "File containing the HashiCorp vault token")
+	fs.StringVar(&c.MountPath, "vault-mount-path", c.MountPath, "The mount path of the KV version 2 secrets engine of the HashiCorp vault")
+	fs.StringVar(&c.Namespace, "vault-namespace", c.Namespace, "The namespace of the HashiCorp vault, if any")
+	fs.StringVar(&c.Directory, "vault-directory", c.Directory, "The directory in which the file vault stores the secrets")
+}
+
+func (c *Config) Validate(env *environments.Env) error {
+	switch c.Kind {
+	case KindAws, KindHashicorp:
+		if c.SecretPrefixEnable && len(c.SecretPrefix) == 0 {
+			return fmt.Errorf("error validating %s vault config, vault-secret-prefix must be set to a non-empty value if vault-secret-prefix-enable is true", c.Kind)
+		}
+		if c.Kind == KindHashicorp && (c.Address == "" || c.MountPath == "") {
+			return fmt.Errorf("error validating hashicorp vault config, vault-address and vault-mount-path must be set")
+		}
+	case KindFile:
+		if c.Directory == "" {
+			return fmt.Errorf("error validating file vault config, vault-directory must be set")
+		}
+	case KindTmp:
+	default:
+		return fmt.Errorf("error validating vault config, invalid vault-kind %q", c.Kind)
+	}
+	return nil
+}
+
+func (c *Config) ReadFiles() error {
+	switch c.Kind {
+	case KindAws:
+		err := shared.ReadFileValueString(c.AccessKeyFile, &c.AccessKey)
+		if err != nil {
+			return err
+		}
+		err = shared.ReadFileValueString(c.SecretAccessKeyFile, &c.SecretAccessKey)
+		if err != nil {
+			return err
+		}
+	case KindHashicorp:
+		return shared.ReadFileValueString(c.TokenFile, &c.Token)
+	}
+	return nil
+}
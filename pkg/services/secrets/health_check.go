@@ -0,0 +1,27 @@
+package secrets
+
+import (
+	"context"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/server"
+)
+
+// healthCheckSecretName is the name of a secret which is never stored, reading it checks that the vault can be reached
+const healthCheckSecretName = "health-check"
+
+// NewVaultHealthCheck checks that the secrets of the vault can be read. It does not fail the readiness probe.
+func NewVaultHealthCheck(vaultService VaultService) *server.HealthCheck {
+	return &server.HealthCheck{
+		Name: "vault",
+		Kind: server.DependencyCheck,
+		Check: func(ctx context.Context) error {
+			if _, err := vaultService.GetSecretString(healthCheckSecretName); err != nil && !IsNotFound(err) {
+				return err
+			}
+			return nil
+		},
+		Details: func() interface{} {
+			return map[string]interface{}{"kind": vaultService.Kind()}
+		},
+	}
+}
@@ -0,0 +1,98 @@
+package secrets
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// label for operation name
+	labelOperation = "operation"
+
+	VaultServiceTotalCount   = "vault_service_total_count"
+	VaultServiceSuccessCount = "vault_service_success_count"
+	VaultServiceFailureCount = "vault_service_failure_count"
+	VaultServiceErrorsCount  = "vault_service_errors_count"
+)
+
+var VaultServiceMetricsLabels = []string{
+	labelOperation,
+}
+
+// vaultMetrics count the operations of a vault service. The metrics are prefixed by the subsystem of the service
+// using the vault, e.g. cos_fleet_manager.
+type vaultMetrics struct {
+	totalCount   *prometheus.CounterVec
+	successCount *prometheus.CounterVec
+	failureCount *prometheus.CounterVec
+	errorsCount  *prometheus.CounterVec
+}
+
+func newVaultMetrics(subsystem string) *vaultMetrics {
+	return &vaultMetrics{
+		totalCount: registerCounterVec(prometheus.CounterOpts{
+			Subsystem: subsystem,
+			Name:      VaultServiceTotalCount,
+			Help:      "total count of operations since start of vault service",
+		}),
+		successCount: registerCounterVec(prometheus.CounterOpts{
+			Subsystem: subsystem,
+			Name:      VaultServiceSuccessCount,
+			Help:      "count of successful operations of vault service",
+		}),
+		failureCount: registerCounterVec(prometheus.CounterOpts{
+			Subsystem: subsystem,
+			Name:      VaultServiceFailureCount,
+			Help:      "count of system failures (e.g. connectivity issues) in the vault service",
+		}),
+		errorsCount: registerCounterVec(prometheus.CounterOpts{
+			Subsystem: subsystem,
+			Name:      VaultServiceErrorsCount,
+			Help:      "count of user level errors (e.g. missing secrets) in the vault service",
+		}),
+	}
+}
+
+// registerCounterVec registers the counter, the counter already registered is returned when a vault service is
+// created several times with the same subsystem e.g. in tests
+func registerCounterVec(opts prometheus.CounterOpts) *prometheus.CounterVec {
+	counter := prometheus.NewCounterVec(opts, VaultServiceMetricsLabels)
+	if err := prometheus.Register(counter); err != nil {
+		var alreadyRegistered prometheus.AlreadyRegisteredError
+		if errors.As(err, &alreadyRegistered) {
+			return alreadyRegistered.ExistingCollector.(*prometheus.CounterVec)
+		}
+		panic(err)
+	}
+	return counter
+}
+
+// observe counts an operation, the missing secrets are counted as user level errors. It is a no-op on nil metrics so
+// that the vault services created outside of NewVaultService do not need any.
+func (m *vaultMetrics) observe(operation string, err error) {
+	if m == nil {
+		return
+	}
+	labels := prometheus.Labels{
+		labelOperation: operation,
+	}
+	m.totalCount.With(labels).Inc()
+	switch {
+	case err == nil:
+		m.successCount.With(labels).Inc()
+	case IsNotFound(err):
+		m.errorsCount.With(labels).Inc()
+	default:
+		m.failureCount.With(labels).Inc()
+	}
+}
+
+// reset resets the metrics of the vault service
+// This is needed because if current process is not the leader anymore, the metrics need to be reset otherwise staled data will be scraped
+func (m *vaultMetrics) reset() {
+	m.totalCount.Reset()
+	m.successCount.Reset()
+	m.failureCount.Reset()
+	m.errorsCount.Reset()
+}
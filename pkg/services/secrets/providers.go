@@ -0,0 +1,27 @@
+package secrets
+
+import (
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/goava/di"
+)
+
+// ConfigProviders provides the vault config and service. The defaults of the config, e.g. the secret prefix and the
+// metrics subsystem, are set by the service using the vault with the given functions.
+func ConfigProviders(defaults ...func(config *Config)) di.Option {
+	return di.Options(
+		di.Provide(func() *Config {
+			config := NewConfig()
+			for _, setDefaults := range defaults {
+				setDefaults(config)
+			}
+			return config
+		}, di.As(new(environments.ConfigModule)), di.As(new(environments.ServiceValidator))),
+		di.Provide(environments.Func(ServiceProviders)),
+	)
+}
+
+func ServiceProviders() di.Option {
+	return di.Options(
+		di.Provide(NewVaultService),
+	)
+}
@@ -0,0 +1,45 @@
+package secrets
+
+import (
+	"github.com/pkg/errors"
+)
+
+// RotationHook is called by RotateSecret to create the new value of a secret and to revoke the previous one, e.g. to
+// delete the previous credentials of a service account once the new ones are stored.
+type RotationHook interface {
+	// NewSecretValue returns the value of the new version of the secret
+	NewSecretValue(name string, currentValue string) (string, error)
+	// SecretRotated is called once the new version of the secret is the current one
+	SecretRotated(name string, previousValue string) error
+}
+
+// RotateSecret stores a new version of the secret, created by the hook, and returns it. The previous version is left
+// in the vault so that it can still be read with GetSecretVersion.
+func RotateSecret(vaultService VaultService, name string, hook RotationHook) (string, error) {
+	currentValue, err := vaultService.GetSecretString(name)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get secret %q", name)
+	}
+
+	newValue, err := hook.NewSecretValue(name, currentValue)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to create the new value of secret %q", name)
+	}
+	// the owning resource of an existing secret is left unchanged
+	if err := vaultService.SetSecretString(name, newValue, ""); err != nil {
+		return "", errors.Wrapf(err, "failed to store the new value of secret %q", name)
+	}
+
+	versions, err := vaultService.ListSecretVersions(name)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to list the versions of secret %q", name)
+	}
+	if len(versions) == 0 {
+		return "", errors.Errorf("secret %q has no version", name)
+	}
+
+	if err := hook.SecretRotated(name, currentValue); err != nil {
+		return "", errors.Wrapf(err, "failed to complete the rotation of secret %q", name)
+	}
+	return versions[len(versions)-1].Version, nil
+}
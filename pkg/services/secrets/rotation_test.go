@@ -0,0 +1,94 @@
+package secrets
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+type testRotationHook struct {
+	newValue         string
+	newValueErr      error
+	rotatedErr       error
+	rotatedPrevValue string
+}
+
+func (h *testRotationHook) NewSecretValue(name string, currentValue string) (string, error) {
+	return h.newValue, h.newValueErr
+}
+
+func (h *testRotationHook) SecretRotated(name string, previousValue string) error {
+	h.rotatedPrevValue = previousValue
+	return h.rotatedErr
+}
+
+func TestRotateSecret(t *testing.T) {
+	tests := []struct {
+		name             string
+		secretName       string
+		hook             *testRotationHook
+		wantErr          bool
+		wantVersion      string
+		wantValue        string
+		wantPrevValue    string
+		wantVersionCount int
+	}{
+		{
+			name:             "should store the new value as the current version of the secret",
+			secretName:       "secret",
+			hook:             &testRotationHook{newValue: "new"},
+			wantVersion:      "2",
+			wantValue:        "new",
+			wantPrevValue:    "current",
+			wantVersionCount: 2,
+		},
+		{
+			name:             "should keep the current value when the hook fails to create the new one",
+			secretName:       "secret",
+			hook:             &testRotationHook{newValueErr: fmt.Errorf("failed")},
+			wantErr:          true,
+			wantValue:        "current",
+			wantVersionCount: 1,
+		},
+		{
+			name:             "should return an error when the rotation cannot be completed",
+			secretName:       "secret",
+			hook:             &testRotationHook{newValue: "new", rotatedErr: fmt.Errorf("failed")},
+			wantErr:          true,
+			wantValue:        "new",
+			wantPrevValue:    "current",
+			wantVersionCount: 2,
+		},
+		{
+			name:             "should return an error when the secret does not exist",
+			secretName:       "missing",
+			hook:             &testRotationHook{newValue: "new"},
+			wantErr:          true,
+			wantValue:        "current",
+			wantVersionCount: 1,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			vaultService, err := NewTmpVaultService()
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(vaultService.SetSecretString("secret", "current", "owner")).To(gomega.Succeed())
+
+			version, err := RotateSecret(vaultService, tt.secretName, tt.hook)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(version).To(gomega.Equal(tt.wantVersion))
+			g.Expect(tt.hook.rotatedPrevValue).To(gomega.Equal(tt.wantPrevValue))
+
+			value, err := vaultService.GetSecretString("secret")
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(value).To(gomega.Equal(tt.wantValue))
+			versions, err := vaultService.ListSecretVersions("secret")
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(versions).To(gomega.HaveLen(tt.wantVersionCount))
+		})
+	}
+}
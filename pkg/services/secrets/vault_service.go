@@ -0,0 +1,97 @@
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+)
+
+const (
+	KindTmp       = "tmp"
+	KindAws       = "aws"
+	KindFile      = "file"
+	KindHashicorp = "hashicorp"
+
+	DefaultRegion = "us-east-1"
+)
+
+// NotFound is returned by the vaults other than AWS when the secret or its version does not exist, see IsNotFound
+var NotFound = fmt.Errorf("not found")
+
+// SecretVersion is a version of a secret. Storing a new value for an existing secret adds a new version which
+// becomes the current one.
+type SecretVersion struct {
+	Version   string
+	CreatedAt time.Time
+}
+
+// VaultService stores secrets outside of the database. Each secret can be owned by a resource, e.g. the connector or
+// the kafka it belongs to, so that the secrets of a resource can be listed and cleaned up.
+//
+//go:generate moq -out vault_service_moq.go . VaultService
+type VaultService interface {
+	// SetSecretString stores the value as the current version of the secret. The secret is created with the given
+	// owning resource if it does not exist, the owning resource of an existing secret is left unchanged.
+	SetSecretString(name string, value string, owningResource string) error
+	// GetSecretString returns the value of the current version of the secret
+	GetSecretString(name string) (string, error)
+	// GetSecretVersion returns the value of the given version of the secret
+	GetSecretVersion(name string, version string) (string, error)
+	// ListSecretVersions returns the versions of the secret ordered from the oldest to the current one
+	ListSecretVersions(name string) ([]SecretVersion, error)
+	// DeleteSecretString deletes all the versions of the secret
+	DeleteSecretString(name string) error
+	// ForEachSecret calls f with each secret until it returns false
+	ForEachSecret(f func(name string, owningResource string) bool) error
+	// ListSecretsByOwner returns the names of the secrets owned by the given resource
+	ListSecretsByOwner(owningResource string) ([]string, error)
+	Kind() string
+}
+
+func NewVaultService(vaultConfig *Config) (VaultService, error) {
+	metrics := newVaultMetrics(vaultConfig.MetricsSubsystem)
+	metrics.reset()
+	switch vaultConfig.Kind {
+	case KindAws:
+		svc, err := NewAwsVaultService(vaultConfig)
+		if err != nil {
+			return nil, err
+		}
+		svc.metrics = metrics
+		return svc, nil
+	case KindTmp:
+		svc, err := NewTmpVaultService()
+		if err != nil {
+			return nil, err
+		}
+		svc.metrics = metrics
+		return svc, nil
+	case KindFile:
+		svc, err := NewFileVaultService(vaultConfig.Directory)
+		if err != nil {
+			return nil, err
+		}
+		svc.metrics = metrics
+		return svc, nil
+	case KindHashicorp:
+		svc, err := NewHashicorpVaultService(vaultConfig)
+		if err != nil {
+			return nil, err
+		}
+		svc.metrics = metrics
+		return svc, nil
+	default:
+		return nil, fmt.Errorf("invalid vault kind: %s", vaultConfig.Kind)
+	}
+}
+
+// IsNotFound returns true when the error is returned because the secret or its version does not exist
+func IsNotFound(err error) bool {
+	if errors.Is(err, NotFound) {
+		return true
+	}
+	var awsErr *secretsmanager.ResourceNotFoundException
+	return errors.As(err, &awsErr)
+}
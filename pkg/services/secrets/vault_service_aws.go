@@ -0,0 +1,202 @@
+package secrets
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-secretsmanager-caching-go/secretcache"
+)
+
+var OwnerResourceTagKey = "owner-resource"
+
+var _ VaultService = &awsVaultService{}
+
+type awsVaultService struct {
+	secretCache        *secretcache.Cache
+	secretClient       *secretsmanager.SecretsManager
+	secretPrefixEnable bool
+	secretPrefix       string
+	metrics            *vaultMetrics
+}
+
+func NewAwsVaultService(vaultConfig *Config) (*awsVaultService, error) {
+	awsConfig := &aws.Config{
+		Credentials: credentials.NewStaticCredentials(
+			vaultConfig.AccessKey,
+			vaultConfig.SecretAccessKey,
+			""),
+		Region:  aws.String(vaultConfig.Region),
+		Retryer: client.DefaultRetryer{NumMaxRetries: 2},
+	}
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	secretClient := secretsmanager.New(sess)
+	secretCache, err := secretcache.New(func(cache *secretcache.Cache) {
+		cache.Client = secretClient
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &awsVaultService{
+		secretClient:       secretClient,
+		secretCache:        secretCache,
+		secretPrefixEnable: vaultConfig.SecretPrefixEnable,
+		secretPrefix:       vaultConfig.SecretPrefix + "/",
+	}, nil
+}
+
+func (k *awsVaultService) Kind() string {
+	return KindAws
+}
+
+// GetSecretString returns the cached value of the secret, a new version may only be returned once the cached value
+// expires. GetSecretVersion always returns the stored value.
+func (k *awsVaultService) GetSecretString(name string) (string, error) {
+	result, err := k.secretCache.GetSecretString(k.getVaultSecretName(name))
+	k.metrics.observe("get", err)
+	return result, err
+}
+
+func (k *awsVaultService) GetSecretVersion(name string, version string) (string, error) {
+	output, err := k.secretClient.GetSecretValue(&secretsmanager.GetSecretValueInput{
+		SecretId:  aws.String(k.getVaultSecretName(name)),
+		VersionId: aws.String(version),
+	})
+	k.metrics.observe("get", err)
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(output.SecretString), nil
+}
+
+func (k *awsVaultService) ListSecretVersions(name string) ([]SecretVersion, error) {
+	var versions []SecretVersion
+	err := k.secretClient.ListSecretVersionIdsPages(&secretsmanager.ListSecretVersionIdsInput{
+		SecretId: aws.String(k.getVaultSecretName(name)),
+	}, func(output *secretsmanager.ListSecretVersionIdsOutput, lastPage bool) bool {
+		for _, entry := range output.Versions {
+			versions = append(versions, SecretVersion{
+				Version:   aws.StringValue(entry.VersionId),
+				CreatedAt: aws.TimeValue(entry.CreatedDate),
+			})
+		}
+		return true
+	})
+	k.metrics.observe("get", err)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].CreatedAt.Before(versions[j].CreatedAt)
+	})
+	return versions, nil
+}
+
+func (k *awsVaultService) SetSecretString(name string, value string, owningResource string) error {
+	name = k.getVaultSecretName(name)
+	var tags []*secretsmanager.Tag
+	if owningResource != "" {
+		tags = append(tags,
+			&secretsmanager.Tag{
+				Key:   &OwnerResourceTagKey,
+				Value: &owningResource,
+			})
+	}
+
+	_, err := k.secretClient.CreateSecret(&secretsmanager.CreateSecretInput{
+		Name:         &name,
+		SecretString: &value,
+		Tags:         tags,
+	})
+	if _, exists := err.(*secretsmanager.ResourceExistsException); exists {
+		// the new value becomes the current version of the existing secret
+		_, err = k.secretClient.PutSecretValue(&secretsmanager.PutSecretValueInput{
+			SecretId:     &name,
+			SecretString: &value,
+		})
+	}
+	k.metrics.observe("set", err)
+	return err
+}
+
+func (k *awsVaultService) ForEachSecret(f func(name string, owningResource string) bool) error {
+	return k.listSecrets(nil, f)
+}
+
+func (k *awsVaultService) ListSecretsByOwner(owningResource string) ([]string, error) {
+	names := []string{}
+	err := k.listSecrets([]*secretsmanager.Filter{
+		{Key: aws.String(secretsmanager.FilterNameStringTypeTagKey), Values: []*string{&OwnerResourceTagKey}},
+		{Key: aws.String(secretsmanager.FilterNameStringTypeTagValue), Values: []*string{&owningResource}},
+	}, func(name string, owner string) bool {
+		// the filters match the secrets having the tag key and any tag with the value
+		if owner == owningResource {
+			names = append(names, name)
+		}
+		return true
+	})
+	return names, err
+}
+
+func (k *awsVaultService) listSecrets(filters []*secretsmanager.Filter, f func(name string, owningResource string) bool) error {
+	if k.secretPrefixEnable {
+		// filter secret keys with prefix
+		filters = append(filters, &secretsmanager.Filter{Key: aws.String(secretsmanager.FilterNameStringTypeName), Values: []*string{&k.secretPrefix}})
+	}
+	err := k.secretClient.ListSecretsPages(&secretsmanager.ListSecretsInput{Filters: filters}, func(output *secretsmanager.ListSecretsOutput, lastPage bool) bool {
+		for _, entry := range output.SecretList {
+			k.metrics.observe("get", nil)
+			owner := getTag(entry.Tags, OwnerResourceTagKey)
+			if !f(k.getSecretName(aws.StringValue(entry.Name)), owner) {
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		k.metrics.observe("get", err)
+		return err
+	}
+	return nil
+}
+
+func getTag(tags []*secretsmanager.Tag, key string) string {
+	for _, tag := range tags {
+		if *tag.Key == key {
+			return *tag.Value
+		}
+	}
+	return ""
+}
+
+func (k *awsVaultService) DeleteSecretString(name string) error {
+	name = k.getVaultSecretName(name)
+	_, err := k.secretClient.DeleteSecret(&secretsmanager.DeleteSecretInput{
+		SecretId: &name,
+	})
+	k.metrics.observe("delete", err)
+	return err
+}
+
+func (k *awsVaultService) getVaultSecretName(name string) string {
+	if k.secretPrefixEnable {
+		return k.secretPrefix + name
+	}
+	return name
+}
+
+// getSecretName is the reverse of getVaultSecretName
+func (k *awsVaultService) getSecretName(vaultSecretName string) string {
+	if k.secretPrefixEnable {
+		return strings.TrimPrefix(vaultSecretName, k.secretPrefix)
+	}
+	return vaultSecretName
+}
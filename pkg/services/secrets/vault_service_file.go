@@ -0,0 +1,177 @@
+package secrets
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+)
+
+var _ VaultService = &fileVaultService{}
+
+const secretFileExtension = ".json"
+
+// fileVaultService stores each secret, with all its versions, in a JSON file of a local directory. The secrets are
+// not encrypted, it is meant to be used for development and testing only e.g. to keep the secrets across restarts.
+type fileVaultService struct {
+	mu        sync.Mutex
+	directory string
+	metrics   *vaultMetrics
+}
+
+func NewFileVaultService(directory string) (*fileVaultService, error) {
+	directory = shared.BuildFullFilePath(directory)
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return nil, err
+	}
+	return &fileVaultService{
+		directory: directory,
+	}, nil
+}
+
+func (k *fileVaultService) Kind() string {
+	return KindFile
+}
+
+func (k *fileVaultService) SetSecretString(name string, value string, owningResource string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	record, err := k.readSecret(name)
+	if IsNotFound(err) {
+		record, err = &secretRecord{Name: name, OwningResource: owningResource}, nil
+	}
+	if err == nil {
+		record.addVersion(value)
+		err = k.writeSecret(record)
+	}
+	k.metrics.observe("set", err)
+	return err
+}
+
+func (k *fileVaultService) GetSecretString(name string) (string, error) {
+	return k.GetSecretVersion(name, "")
+}
+
+func (k *fileVaultService) GetSecretVersion(name string, version string) (string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	var value string
+	record, err := k.readSecret(name)
+	if err == nil {
+		value, err = record.getVersion(version)
+	}
+	k.metrics.observe("get", err)
+	return value, err
+}
+
+func (k *fileVaultService) ListSecretVersions(name string) ([]SecretVersion, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	record, err := k.readSecret(name)
+	k.metrics.observe("get", err)
+	if err != nil {
+		return nil, err
+	}
+	return record.listVersions(), nil
+}
+
+func (k *fileVaultService) DeleteSecretString(name string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	err := os.Remove(k.secretFile(name))
+	if errors.Is(err, os.ErrNotExist) {
+		err = NotFound
+	}
+	k.metrics.observe("delete", err)
+	return err
+}
+
+func (k *fileVaultService) ForEachSecret(f func(name string, owningResource string) bool) error {
+	k.mu.Lock()
+	var records []*secretRecord
+	entries, err := os.ReadDir(k.directory)
+	for i := 0; err == nil && i < len(entries); i++ {
+		if entries[i].IsDir() || !strings.HasSuffix(entries[i].Name(), secretFileExtension) {
+			continue
+		}
+		var record *secretRecord
+		record, err = k.readFile(filepath.Join(k.directory, entries[i].Name()))
+		records = append(records, record)
+	}
+	k.mu.Unlock()
+
+	if err != nil {
+		k.metrics.observe("get", err)
+		return err
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Name < records[j].Name
+	})
+	for _, record := range records {
+		k.metrics.observe("get", nil)
+		if !f(record.Name, record.OwningResource) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (k *fileVaultService) ListSecretsByOwner(owningResource string) ([]string, error) {
+	return listSecretsByOwner(k, owningResource)
+}
+
+// secretFile returns the file of the secret, the name is escaped as it may contain path separators
+func (k *fileVaultService) secretFile(name string) string {
+	return filepath.Join(k.directory, url.PathEscape(name)+secretFileExtension)
+}
+
+func (k *fileVaultService) readSecret(name string) (*secretRecord, error) {
+	record, err := k.readFile(k.secretFile(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, NotFound
+	}
+	return record, err
+}
+
+func (k *fileVaultService) readFile(file string) (*secretRecord, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var record secretRecord
+	if err := json.Unmarshal(content, &record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// writeSecret writes the secret to a temporary file first so that a secret is never partially written
+func (k *fileVaultService) writeSecret(record *secretRecord) error {
+	content, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(k.directory, ".secret-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(content); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), k.secretFile(record.Name))
+}
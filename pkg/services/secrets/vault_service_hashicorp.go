@@ -0,0 +1,304 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var _ VaultService = &hashicorpVaultService{}
+
+// secretValueKey is the key of the value of the secrets in the data of the KV entries
+const secretValueKey = "value"
+
+// ownersIndexFolder is the folder of the index of the secrets by owning resource. The KV engine cannot filter the
+// secrets on their custom metadata: the index holds an empty entry per owned secret under the folder of its owner so
+// that the secrets of an owner are listed without walking the whole mount.
+const ownersIndexFolder = ".owners/"
+
+// hashicorpVaultService stores the secrets in the KV version 2 secrets engine of a HashiCorp vault through its HTTP
+// API. The engine keeps the versions of the secrets, the owning resources are stored in their custom metadata and
+// indexed in the ownersIndexFolder.
+type hashicorpVaultService struct {
+	client    *http.Client
+	address   string
+	token     string
+	namespace string
+	mountPath string
+	prefix    string
+	metrics   *vaultMetrics
+}
+
+func NewHashicorpVaultService(vaultConfig *Config) (*hashicorpVaultService, error) {
+	if _, err := url.Parse(vaultConfig.Address); err != nil {
+		return nil, fmt.Errorf("invalid hashicorp vault address %q: %w", vaultConfig.Address, err)
+	}
+	prefix := ""
+	if vaultConfig.SecretPrefixEnable {
+		prefix = strings.Trim(vaultConfig.SecretPrefix, "/") + "/"
+	}
+	return &hashicorpVaultService{
+		client:    &http.Client{Timeout: 30 * time.Second},
+		address:   strings.TrimSuffix(vaultConfig.Address, "/"),
+		token:     vaultConfig.Token,
+		namespace: vaultConfig.Namespace,
+		mountPath: strings.Trim(vaultConfig.MountPath, "/"),
+		prefix:    prefix,
+	}, nil
+}
+
+type kvDataResponse struct {
+	Data struct {
+		Data     map[string]string `json:"data"`
+		Metadata struct {
+			Version int `json:"version"`
+		} `json:"metadata"`
+	} `json:"data"`
+}
+
+type kvMetadataResponse struct {
+	Data struct {
+		CurrentVersion int               `json:"current_version"`
+		CustomMetadata map[string]string `json:"custom_metadata"`
+		Versions       map[string]struct {
+			CreatedTime  time.Time `json:"created_time"`
+			DeletionTime string    `json:"deletion_time"`
+			Destroyed    bool      `json:"destroyed"`
+		} `json:"versions"`
+	} `json:"data"`
+}
+
+type kvListResponse struct {
+	Data struct {
+		Keys []string `json:"keys"`
+	} `json:"data"`
+}
+
+func (k *hashicorpVaultService) Kind() string {
+	return KindHashicorp
+}
+
+func (k *hashicorpVaultService) SetSecretString(name string, value string, owningResource string) error {
+	var metadata kvMetadataResponse
+	err := k.do(http.MethodGet, "metadata", name, nil, &metadata)
+	created := IsNotFound(err)
+	// the secret is indexed first so that it can always be found from its owner, a dangling index entry is removed
+	// when the secrets of the owner are listed
+	if created && owningResource != "" {
+		if err := k.do(http.MethodPost, "data", ownerIndexEntry(owningResource, name), map[string]interface{}{
+			"data": map[string]string{},
+		}, nil); err != nil {
+			k.metrics.observe("set", err)
+			return err
+		}
+	}
+	if err == nil || created {
+		err = k.do(http.MethodPost, "data", name, map[string]interface{}{
+			"data": map[string]string{secretValueKey: value},
+		}, nil)
+	}
+	if err == nil && created && owningResource != "" {
+		err = k.do(http.MethodPost, "metadata", name, map[string]interface{}{
+			"custom_metadata": map[string]string{OwnerResourceTagKey: owningResource},
+		}, nil)
+	}
+	k.metrics.observe("set", err)
+	return err
+}
+
+func (k *hashicorpVaultService) GetSecretString(name string) (string, error) {
+	return k.GetSecretVersion(name, "")
+}
+
+func (k *hashicorpVaultService) GetSecretVersion(name string, version string) (string, error) {
+	query := ""
+	if version != "" {
+		query = "?version=" + url.QueryEscape(version)
+	}
+	var response kvDataResponse
+	err := k.do(http.MethodGet, "data", name+query, nil, &response)
+	k.metrics.observe("get", err)
+	if err != nil {
+		return "", err
+	}
+	return response.Data.Data[secretValueKey], nil
+}
+
+func (k *hashicorpVaultService) ListSecretVersions(name string) ([]SecretVersion, error) {
+	var response kvMetadataResponse
+	err := k.do(http.MethodGet, "metadata", name, nil, &response)
+	k.metrics.observe("get", err)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []SecretVersion{}
+	for version, metadata := range response.Data.Versions {
+		if metadata.Destroyed || metadata.DeletionTime != "" {
+			continue
+		}
+		versions = append(versions, SecretVersion{Version: version, CreatedAt: metadata.CreatedTime})
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		vi, _ := strconv.Atoi(versions[i].Version)
+		vj, _ := strconv.Atoi(versions[j].Version)
+		return vi < vj
+	})
+	return versions, nil
+}
+
+func (k *hashicorpVaultService) DeleteSecretString(name string) error {
+	// the metadata endpoint deletes all the versions of the secret. Unlike the other vaults, the KV engine does not
+	// report missing secrets on deletion so they are looked up first.
+	var metadata kvMetadataResponse
+	err := k.do(http.MethodGet, "metadata", name, nil, &metadata)
+	if err == nil {
+		err = k.do(http.MethodDelete, "metadata", name, nil, nil)
+	}
+	if owner := metadata.Data.CustomMetadata[OwnerResourceTagKey]; err == nil && owner != "" {
+		err = k.do(http.MethodDelete, "metadata", ownerIndexEntry(owner, name), nil, nil)
+	}
+	k.metrics.observe("delete", err)
+	return err
+}
+
+func (k *hashicorpVaultService) ForEachSecret(f func(name string, owningResource string) bool) error {
+	_, err := k.forEachSecret("", f)
+	return err
+}
+
+// forEachSecret walks the secrets of the given folder, it returns false once f returns false
+func (k *hashicorpVaultService) forEachSecret(folder string, f func(name string, owningResource string) bool) (bool, error) {
+	var response kvListResponse
+	err := k.do("LIST", "metadata", folder, nil, &response)
+	if IsNotFound(err) {
+		// the folder is empty
+		return true, nil
+	}
+	if err != nil {
+		k.metrics.observe("get", err)
+		return false, err
+	}
+
+	sort.Strings(response.Data.Keys)
+	for _, key := range response.Data.Keys {
+		if folder == "" && key == ownersIndexFolder {
+			continue
+		}
+		if strings.HasSuffix(key, "/") {
+			if next, err := k.forEachSecret(folder+key, f); err != nil || !next {
+				return next, err
+			}
+			continue
+		}
+		var metadata kvMetadataResponse
+		err := k.do(http.MethodGet, "metadata", folder+key, nil, &metadata)
+		k.metrics.observe("get", err)
+		if err != nil {
+			return false, err
+		}
+		if !f(folder+key, metadata.Data.CustomMetadata[OwnerResourceTagKey]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ListSecretsByOwner lists the secrets of the given owning resource in the index of the secrets by owner. The index
+// entries of the secrets which no longer exist are removed.
+func (k *hashicorpVaultService) ListSecretsByOwner(owningResource string) ([]string, error) {
+	ownerFolder := ownerIndexEntry(owningResource, "")
+	var response kvListResponse
+	err := k.do("LIST", "metadata", ownerFolder, nil, &response)
+	k.metrics.observe("get", err)
+	if IsNotFound(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, key := range response.Data.Keys {
+		name, err := base64.RawURLEncoding.DecodeString(key)
+		if err != nil {
+			// not an entry of the index
+			continue
+		}
+		err = k.do(http.MethodGet, "metadata", string(name), nil, nil)
+		k.metrics.observe("get", err)
+		switch {
+		case IsNotFound(err):
+			if err := k.do(http.MethodDelete, "metadata", ownerFolder+key, nil, nil); err != nil {
+				return nil, err
+			}
+		case err != nil:
+			return nil, err
+		default:
+			names = append(names, string(name))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ownerIndexEntry returns the index entry of the secret owned by the given resource, the folder of the owner in the
+// index when the name is empty. The owner and the name are encoded as they may contain path separators.
+func ownerIndexEntry(owningResource string, name string) string {
+	entry := ownersIndexFolder + base64.RawURLEncoding.EncodeToString([]byte(owningResource)) + "/"
+	if name != "" {
+		entry += base64.RawURLEncoding.EncodeToString([]byte(name))
+	}
+	return entry
+}
+
+// do sends a request to the given endpoint of the KV engine for the given secret and decodes the response in out,
+// unless it is nil. A 404 response is reported as NotFound.
+func (k *hashicorpVaultService) do(method string, endpoint string, name string, in interface{}, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, fmt.Sprintf("%s/v1/%s/%s/%s%s", k.address, k.mountPath, endpoint, k.prefix, name), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", k.token)
+	if k.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", k.namespace)
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return NotFound
+	case resp.StatusCode >= 300:
+		message, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("hashicorp vault request %s %s failed with status %d: %s", method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(message)))
+	case out == nil || resp.StatusCode == http.StatusNoContent:
+		return nil
+	default:
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+}
@@ -0,0 +1,271 @@
+package secrets
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+const FakeHashicorpVaultToken = "test-token"
+
+type fakeKvVersion struct {
+	value     string
+	createdAt time.Time
+}
+
+type fakeKvSecret struct {
+	versions       []fakeKvVersion
+	customMetadata map[string]string
+}
+
+// fakeHashicorpVault implements the endpoints of the KV version 2 secrets engine used by hashicorpVaultService
+type fakeHashicorpVault struct {
+	mu      sync.Mutex
+	secrets map[string]*fakeKvSecret
+	// listed are the folders listed
+	listed []string
+}
+
+// NewFakeHashicorpVault starts a fake HashiCorp vault, it is exported for the tests of the secrets_test package
+func NewFakeHashicorpVault(t *testing.T) *httptest.Server {
+	fake := &fakeHashicorpVault{secrets: map[string]*fakeKvSecret{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (f *fakeHashicorpVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Vault-Token") != FakeHashicorpVaultToken {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch path := r.URL.Path; {
+	case strings.HasPrefix(path, "/v1/secret/data/"):
+		f.serveData(w, r, strings.TrimPrefix(path, "/v1/secret/data/"))
+	case strings.HasPrefix(path, "/v1/secret/metadata/"):
+		f.serveMetadata(w, r, strings.TrimPrefix(path, "/v1/secret/metadata/"))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeHashicorpVault) serveData(w http.ResponseWriter, r *http.Request, name string) {
+	switch r.Method {
+	case http.MethodPost:
+		var body struct {
+			Data map[string]string `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		secret, ok := f.secrets[name]
+		if !ok {
+			secret = &fakeKvSecret{customMetadata: map[string]string{}}
+			f.secrets[name] = secret
+		}
+		secret.versions = append(secret.versions, fakeKvVersion{value: body.Data[secretValueKey], createdAt: time.Now()})
+		writeJSON(w, map[string]interface{}{"data": map[string]interface{}{"version": len(secret.versions)}})
+	case http.MethodGet:
+		secret, ok := f.secrets[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		version := len(secret.versions)
+		if v := r.URL.Query().Get("version"); v != "" {
+			version, _ = strconv.Atoi(v)
+		}
+		if version < 1 || version > len(secret.versions) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(w, map[string]interface{}{"data": map[string]interface{}{
+			"data":     map[string]string{secretValueKey: secret.versions[version-1].value},
+			"metadata": map[string]interface{}{"version": version},
+		}})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeHashicorpVault) serveMetadata(w http.ResponseWriter, r *http.Request, name string) {
+	if r.Method == "LIST" {
+		f.listed = append(f.listed, name)
+		f.list(w, name)
+		return
+	}
+	secret, ok := f.secrets[name]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		versions := map[string]interface{}{}
+		for i, v := range secret.versions {
+			versions[strconv.Itoa(i+1)] = map[string]interface{}{"created_time": v.createdAt, "deletion_time": "", "destroyed": false}
+		}
+		writeJSON(w, map[string]interface{}{"data": map[string]interface{}{
+			"current_version": len(secret.versions),
+			"custom_metadata": secret.customMetadata,
+			"versions":        versions,
+		}})
+	case http.MethodPost:
+		var body struct {
+			CustomMetadata map[string]string `json:"custom_metadata"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		secret.customMetadata = body.CustomMetadata
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		delete(f.secrets, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// list returns the secrets and the sub folders of the folder, like the KV engine does
+func (f *fakeHashicorpVault) list(w http.ResponseWriter, folder string) {
+	keys := map[string]bool{}
+	for name := range f.secrets {
+		if !strings.HasPrefix(name, folder) {
+			continue
+		}
+		key := strings.TrimPrefix(name, folder)
+		if i := strings.Index(key, "/"); i >= 0 {
+			key = key[:i+1]
+		}
+		keys[key] = true
+	}
+	if len(keys) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	result := []string{}
+	for key := range keys {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	writeJSON(w, map[string]interface{}{"data": map[string]interface{}{"keys": result}})
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func Test_hashicorpVaultService(t *testing.T) {
+	tests := []struct {
+		name               string
+		secretPrefixEnable bool
+		wantPath           string
+	}{
+		{
+			name:     "should store the secrets under the mount path",
+			wantPath: "folder/name",
+		},
+		{
+			name:               "should store the secrets under the secret prefix when enabled",
+			secretPrefixEnable: true,
+			wantPath:           "managed-kafkas/folder/name",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			fake := &fakeHashicorpVault{secrets: map[string]*fakeKvSecret{}}
+			server := httptest.NewServer(fake)
+			defer server.Close()
+
+			svc, err := NewHashicorpVaultService(&Config{
+				Address:            server.URL,
+				Token:              FakeHashicorpVaultToken,
+				MountPath:          "secret",
+				SecretPrefix:       "managed-kafkas",
+				SecretPrefixEnable: tt.secretPrefixEnable,
+			})
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+
+			g.Expect(svc.SetSecretString("folder/name", "value", "owner")).To(gomega.Succeed())
+			g.Expect(fake.secrets).To(gomega.HaveKey(tt.wantPath))
+			g.Expect(fake.secrets[tt.wantPath].customMetadata).To(gomega.Equal(map[string]string{OwnerResourceTagKey: "owner"}))
+
+			var names []string
+			g.Expect(svc.ForEachSecret(func(name string, owningResource string) bool {
+				names = append(names, name)
+				return true
+			})).To(gomega.Succeed())
+			g.Expect(names).To(gomega.Equal([]string{"folder/name"}))
+		})
+	}
+}
+
+func Test_hashicorpVaultService_InvalidToken(t *testing.T) {
+	g := gomega.NewWithT(t)
+	server := NewFakeHashicorpVault(t)
+
+	svc, err := NewHashicorpVaultService(&Config{Address: server.URL, Token: "invalid", MountPath: "secret"})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	_, err = svc.GetSecretString("name")
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(IsNotFound(err)).To(gomega.BeFalse())
+}
+
+func Test_hashicorpVaultService_ListSecretsByOwner(t *testing.T) {
+	g := gomega.NewWithT(t)
+	fake := &fakeHashicorpVault{secrets: map[string]*fakeKvSecret{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	svc, err := NewHashicorpVaultService(&Config{Address: server.URL, Token: FakeHashicorpVaultToken, MountPath: "secret"})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	g.Expect(svc.SetSecretString("folder/a", "value", "owner")).To(gomega.Succeed())
+	g.Expect(svc.SetSecretString("folder/b", "value", "other-owner")).To(gomega.Succeed())
+	g.Expect(svc.SetSecretString("c", "value", "owner")).To(gomega.Succeed())
+	g.Expect(svc.SetSecretString("d", "value", "")).To(gomega.Succeed())
+
+	// only the folder of the owner in the index is listed
+	names, err := svc.ListSecretsByOwner("owner")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(names).To(gomega.Equal([]string{"c", "folder/a"}))
+	g.Expect(fake.listed).To(gomega.Equal([]string{ownerIndexEntry("owner", "")}))
+
+	// the index entry of a deleted secret is deleted with it
+	g.Expect(svc.DeleteSecretString("c")).To(gomega.Succeed())
+	g.Expect(fake.secrets).ToNot(gomega.HaveKey(ownerIndexEntry("owner", "c")))
+
+	// a dangling index entry is removed when the secrets of the owner are listed
+	delete(fake.secrets, "folder/a")
+	names, err = svc.ListSecretsByOwner("owner")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(names).To(gomega.BeEmpty())
+	g.Expect(fake.secrets).ToNot(gomega.HaveKey(ownerIndexEntry("owner", "folder/a")))
+
+	// the index is not walked with the secrets
+	var walked []string
+	g.Expect(svc.ForEachSecret(func(name string, owningResource string) bool {
+		walked = append(walked, name)
+		return true
+	})).To(gomega.Succeed())
+	g.Expect(walked).To(gomega.Equal([]string{"d", "folder/b"}))
+}
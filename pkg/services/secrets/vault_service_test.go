@@ -0,0 +1,252 @@
+package secrets_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services/secrets"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/shared"
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestNewVaultService(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	vc := secrets.NewConfig()
+
+	// Enable testing against aws if the access keys are configured..
+	if content, err := os.ReadFile(shared.BuildFullFilePath(vc.AccessKeyFile)); err == nil && len(content) > 0 {
+		vc.Kind = secrets.KindAws
+	}
+	g.Expect(vc.ReadFiles()).To(gomega.BeNil())
+
+	hashicorpVault := secrets.NewFakeHashicorpVault(t)
+
+	tests := []struct {
+		config       *secrets.Config
+		wantErrOnNew bool
+		skip         bool
+		name         string
+	}{
+		{
+			config: &secrets.Config{Kind: secrets.KindTmp, MetricsSubsystem: metricsSubsystem},
+			name:   secrets.KindTmp,
+		},
+		{
+			config: &secrets.Config{Kind: secrets.KindFile, Directory: t.TempDir(), MetricsSubsystem: metricsSubsystem},
+			name:   secrets.KindFile,
+		},
+		{
+			config: &secrets.Config{
+				Kind:             secrets.KindHashicorp,
+				Address:          hashicorpVault.URL,
+				Token:            secrets.FakeHashicorpVaultToken,
+				MountPath:        "secret",
+				MetricsSubsystem: metricsSubsystem,
+			},
+			name: secrets.KindHashicorp,
+		},
+		{
+			config: &secrets.Config{
+				Kind:               secrets.KindAws,
+				AccessKey:          vc.AccessKey,
+				SecretAccessKey:    vc.SecretAccessKey,
+				Region:             vc.Region,
+				SecretPrefixEnable: false,
+				SecretPrefix:       "managed-connectors",
+				MetricsSubsystem:   metricsSubsystem,
+			},
+			skip: vc.Kind != secrets.KindAws,
+			name: secrets.KindAws + "-no-prefix",
+		},
+		{
+			config: &secrets.Config{
+				Kind:               secrets.KindAws,
+				AccessKey:          vc.AccessKey,
+				SecretAccessKey:    vc.SecretAccessKey,
+				Region:             vc.Region,
+				SecretPrefixEnable: true,
+				SecretPrefix:       "managed-connectors",
+				MetricsSubsystem:   metricsSubsystem,
+			},
+			skip: vc.Kind != secrets.KindAws,
+			name: secrets.KindAws + "-with-prefix",
+		},
+		{
+			config:       &secrets.Config{Kind: "wrong"},
+			wantErrOnNew: true,
+			name:         "wrong",
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			svc, err := secrets.NewVaultService(tt.config)
+			g.Expect(err != nil).Should(gomega.Equal(tt.wantErrOnNew), "NewVaultService() error = %v, wantErr %v", err, tt.wantErrOnNew)
+			if err == nil {
+				if tt.skip {
+					t.SkipNow()
+				}
+				happyPath(svc, t)
+			}
+		})
+	}
+}
+
+func happyPath(service secrets.VaultService, t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	numSecrets := 0
+	err := service.ForEachSecret(func(name string, owningResource string) bool {
+		numSecrets += 1
+		return true
+	})
+	g.Expect(err).Should(gomega.BeNil())
+
+	keyName := api.NewID()
+	err = service.SetSecretString(keyName, "hello", "/api/connector_mgmt/v1/connectors/thistest")
+	g.Expect(err).Should(gomega.BeNil())
+
+	value, err := service.GetSecretString(keyName)
+	g.Expect(err).Should(gomega.BeNil())
+	g.Expect(value).Should(gomega.Equal("hello"))
+
+	err = service.DeleteSecretString(keyName)
+	g.Expect(err).Should(gomega.BeNil())
+
+	_, err = service.GetSecretString("missing")
+	g.Expect(err).ShouldNot(gomega.BeNil())
+
+	err = service.DeleteSecretString("missing")
+	g.Expect(err).ShouldNot(gomega.BeNil())
+
+	var builder strings.Builder
+	err = tmpl.Execute(&builder, struct {
+		GetCount         int
+		SetCount         int
+		TotalGetCount    int
+		TotalDeleteCount int
+	}{numSecrets + 1, 1, numSecrets + 2, 2})
+	g.Expect(err).Should(gomega.BeNil())
+	err = testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(builder.String()), vaultMetrics...)
+	g.Expect(err).Should(gomega.BeNil())
+}
+
+const metricsSubsystem = "cos_fleet_manager"
+
+var vaultMetrics []string = getMetricNames()
+
+func getMetricNames() []string {
+	names := []string{secrets.VaultServiceTotalCount, secrets.VaultServiceSuccessCount,
+		secrets.VaultServiceErrorsCount, secrets.VaultServiceFailureCount}
+	var result []string
+	for _, m := range names {
+		result = append(result, metricsSubsystem+"_"+m)
+	}
+	return result
+}
+
+const expectedMetrics = `# HELP cos_fleet_manager_vault_service_errors_count count of user level errors (e.g. missing secrets) in the vault service
+# TYPE cos_fleet_manager_vault_service_errors_count counter
+cos_fleet_manager_vault_service_errors_count{operation="delete"} 1
+cos_fleet_manager_vault_service_errors_count{operation="get"} 1
+# HELP cos_fleet_manager_vault_service_success_count count of successful operations of vault service
+# TYPE cos_fleet_manager_vault_service_success_count counter
+cos_fleet_manager_vault_service_success_count{operation="delete"} {{.SetCount}}
+cos_fleet_manager_vault_service_success_count{operation="get"} {{.GetCount}}
+cos_fleet_manager_vault_service_success_count{operation="set"} {{.SetCount}}
+# HELP cos_fleet_manager_vault_service_total_count total count of operations since start of vault service
+# TYPE cos_fleet_manager_vault_service_total_count counter
+cos_fleet_manager_vault_service_total_count{operation="delete"} {{.TotalDeleteCount}}
+cos_fleet_manager_vault_service_total_count{operation="get"} {{.TotalGetCount}}
+cos_fleet_manager_vault_service_total_count{operation="set"} {{.SetCount}}
+`
+
+var tmpl *template.Template = getMetricsTemplate()
+
+func getMetricsTemplate() *template.Template {
+	t, err := template.New("expected").Parse(expectedMetrics)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// newLocalVaultServices returns the vaults which can be tested without external services
+func newLocalVaultServices(t *testing.T) map[string]secrets.VaultService {
+	g := gomega.NewWithT(t)
+	hashicorpVault := secrets.NewFakeHashicorpVault(t)
+	configs := []*secrets.Config{
+		{Kind: secrets.KindTmp},
+		{Kind: secrets.KindFile, Directory: t.TempDir()},
+		{Kind: secrets.KindHashicorp, Address: hashicorpVault.URL, Token: secrets.FakeHashicorpVaultToken, MountPath: "secret"},
+	}
+	services := map[string]secrets.VaultService{}
+	for _, config := range configs {
+		svc, err := secrets.NewVaultService(config)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		services[config.Kind] = svc
+	}
+	return services
+}
+
+func TestVaultService_Versions(t *testing.T) {
+	for kind, svc := range newLocalVaultServices(t) {
+		service := svc
+		t.Run(kind, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			g.Expect(service.SetSecretString("secret", "first", "owner")).To(gomega.Succeed())
+			g.Expect(service.SetSecretString("secret", "second", "other-owner")).To(gomega.Succeed())
+
+			value, err := service.GetSecretString("secret")
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(value).To(gomega.Equal("second"))
+
+			versions, err := service.ListSecretVersions("secret")
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(versions).To(gomega.HaveLen(2))
+
+			value, err = service.GetSecretVersion("secret", versions[0].Version)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(value).To(gomega.Equal("first"))
+
+			_, err = service.GetSecretVersion("secret", "10")
+			g.Expect(secrets.IsNotFound(err)).To(gomega.BeTrue())
+
+			_, err = service.ListSecretVersions("missing")
+			g.Expect(secrets.IsNotFound(err)).To(gomega.BeTrue())
+		})
+	}
+}
+
+func TestVaultService_ListSecretsByOwner(t *testing.T) {
+	for kind, svc := range newLocalVaultServices(t) {
+		service := svc
+		t.Run(kind, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			g.Expect(service.SetSecretString("folder/a", "value", "owner")).To(gomega.Succeed())
+			g.Expect(service.SetSecretString("folder/b", "value", "other-owner")).To(gomega.Succeed())
+			g.Expect(service.SetSecretString("c", "value", "owner")).To(gomega.Succeed())
+			// the owning resource of an existing secret is left unchanged
+			g.Expect(service.SetSecretString("c", "new-value", "other-owner")).To(gomega.Succeed())
+
+			names, err := service.ListSecretsByOwner("owner")
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(names).To(gomega.ConsistOf("folder/a", "c"))
+
+			names, err = service.ListSecretsByOwner("missing")
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(names).To(gomega.BeEmpty())
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package secrets
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var _ VaultService = &TmpVaultService{}
+
+// secretRecord holds all the versions of a secret for the vaults implemented by this package
+type secretRecord struct {
+	Name           string                `json:"name"`
+	OwningResource string                `json:"owning_resource"`
+	Versions       []secretRecordVersion `json:"versions"`
+}
+
+type secretRecordVersion struct {
+	Version   string    `json:"version"`
+	Value     string    `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// addVersion adds the value as the current version of the secret, the versions are numbered from 1
+func (r *secretRecord) addVersion(value string) {
+	r.Versions = append(r.Versions, secretRecordVersion{
+		Version:   strconv.Itoa(len(r.Versions) + 1),
+		Value:     value,
+		CreatedAt: time.Now(),
+	})
+}
+
+func (r *secretRecord) getVersion(version string) (string, error) {
+	if version == "" {
+		return r.Versions[len(r.Versions)-1].Value, nil
+	}
+	for _, v := range r.Versions {
+		if v.Version == version {
+			return v.Value, nil
+		}
+	}
+	return "", NotFound
+}
+
+func (r *secretRecord) listVersions() []SecretVersion {
+	versions := make([]SecretVersion, 0, len(r.Versions))
+	for _, v := range r.Versions {
+		versions = append(versions, SecretVersion{Version: v.Version, CreatedAt: v.CreatedAt})
+	}
+	return versions
+}
+
+// TmpVaultService keeps the secrets in memory. It is meant to be used for development and testing only
+type TmpVaultService struct {
+	mu            sync.Mutex
+	secrets       map[string]*secretRecord
+	metrics       *vaultMetrics
+	deleteCounter int64
+	insertCounter int64
+	updateCounter int64
+	getCounter    int64
+	missCounter   int64
+}
+
+type Counters struct {
+	Deletes int64
+	Inserts int64
+	Updates int64
+	Gets    int64
+	Misses  int64
+}
+
+func NewTmpVaultService() (*TmpVaultService, error) {
+	return &TmpVaultService{
+		secrets: map[string]*secretRecord{},
+	}, nil
+}
+
+func (k *TmpVaultService) Kind() string {
+	return KindTmp
+}
+
+func (k *TmpVaultService) ResetCounters() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.deleteCounter = 0
+	k.insertCounter = 0
+	k.updateCounter = 0
+	k.getCounter = 0
+	k.missCounter = 0
+}
+
+func (k *TmpVaultService) Counters() Counters {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return Counters{
+		Deletes: k.deleteCounter,
+		Inserts: k.insertCounter,
+		Updates: k.updateCounter,
+		Gets:    k.getCounter,
+		Misses:  k.missCounter,
+	}
+}
+
+func (k *TmpVaultService) SetSecretString(name string, value string, owningResource string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	record, found := k.secrets[name]
+	if found {
+		k.updateCounter += 1
+	} else {
+		k.insertCounter += 1
+		record = &secretRecord{Name: name, OwningResource: owningResource}
+		k.secrets[name] = record
+	}
+	record.addVersion(value)
+	k.metrics.observe("set", nil)
+	return nil
+}
+
+func (k *TmpVaultService) GetSecretString(name string) (string, error) {
+	return k.GetSecretVersion(name, "")
+}
+
+func (k *TmpVaultService) GetSecretVersion(name string, version string) (string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	var value string
+	err := NotFound
+	if record, found := k.secrets[name]; found {
+		value, err = record.getVersion(version)
+	}
+	k.metrics.observe("get", err)
+	if err != nil {
+		k.missCounter += 1
+		return "", err
+	}
+	k.getCounter += 1
+	return value, nil
+}
+
+func (k *TmpVaultService) ListSecretVersions(name string) ([]SecretVersion, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	record, found := k.secrets[name]
+	if !found {
+		k.metrics.observe("get", NotFound)
+		return nil, NotFound
+	}
+	k.metrics.observe("get", nil)
+	return record.listVersions(), nil
+}
+
+func (k *TmpVaultService) DeleteSecretString(name string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if _, ok := k.secrets[name]; !ok {
+		k.metrics.observe("delete", NotFound)
+		return NotFound
+	}
+	k.metrics.observe("delete", nil)
+	k.deleteCounter += 1
+	delete(k.secrets, name)
+	return nil
+}
+
+func (k *TmpVaultService) ForEachSecret(f func(name string, owningResource string) bool) error {
+	// Copy the secrets so that f can use the vault
+	k.mu.Lock()
+	secrets := make([]secretRecord, 0, len(k.secrets))
+	for _, s := range k.secrets {
+		secrets = append(secrets, *s)
+	}
+	k.mu.Unlock()
+
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Name < secrets[j].Name
+	})
+	for _, s := range secrets {
+		k.metrics.observe("get", nil)
+		if !f(s.Name, s.OwningResource) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (k *TmpVaultService) ListSecretsByOwner(owningResource string) ([]string, error) {
+	return listSecretsByOwner(k, owningResource)
+}
+
+// listSecretsByOwner lists the secrets owned by the given resource for the vaults which cannot filter them
+func listSecretsByOwner(vaultService VaultService, owningResource string) ([]string, error) {
+	names := []string{}
+	err := vaultService.ForEachSecret(func(name string, owner string) bool {
+		if owner == owningResource {
+			names = append(names, name)
+		}
+		return true
+	})
+	return names, err
+}
@@ -0,0 +1,62 @@
+package webhooks
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+type Config struct {
+	// MaxDeliveryAttempts is the number of times the delivery of a notification is attempted before it is given up
+	MaxDeliveryAttempts int           `json:"max_delivery_attempts"`
+	InitialRetryBackoff time.Duration `json:"initial_retry_backoff"`
+	MaxRetryBackoff     time.Duration `json:"max_retry_backoff"`
+	DeliveryTimeout     time.Duration `json:"delivery_timeout"`
+	// DeliveryBatchSize is the maximum number of notifications delivered by each run of the delivery worker
+	DeliveryBatchSize int `json:"delivery_batch_size"`
+	// DeliveryConcurrency is the maximum number of endpoints the notifications are delivered to at the same time
+	DeliveryConcurrency int `json:"delivery_concurrency"`
+	// AllowPrivateEndpoints allows the endpoints resolving to loopback, private or link-local addresses. It must only
+	// be enabled in development environments.
+	AllowPrivateEndpoints bool `json:"allow_private_endpoints"`
+}
+
+func NewConfig() *Config {
+	return &Config{
+		MaxDeliveryAttempts: 10,
+		InitialRetryBackoff: 30 * time.Second,
+		MaxRetryBackoff:     1 * time.Hour,
+		DeliveryTimeout:     10 * time.Second,
+		DeliveryBatchSize:   100,
+		DeliveryConcurrency: 10,
+	}
+}
+
+func (c *Config) AddFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&c.MaxDeliveryAttempts, "webhook-max-delivery-attempts", c.MaxDeliveryAttempts, "The number of times the delivery of a webhook notification is attempted before it is given up")
+	fs.DurationVar(&c.InitialRetryBackoff, "webhook-initial-retry-backoff", c.InitialRetryBackoff, "The time to wait before retrying a failed webhook delivery. It doubles after every failed attempt")
+	fs.DurationVar(&c.MaxRetryBackoff, "webhook-max-retry-backoff", c.MaxRetryBackoff, "The maximum time to wait before retrying a failed webhook delivery")
+	fs.DurationVar(&c.DeliveryTimeout, "webhook-delivery-timeout", c.DeliveryTimeout, "The timeout of the requests made to the webhook endpoints")
+	fs.IntVar(&c.DeliveryBatchSize, "webhook-delivery-batch-size", c.DeliveryBatchSize, "The maximum number of webhook notifications delivered at each reconciliation")
+	fs.IntVar(&c.DeliveryConcurrency, "webhook-delivery-concurrency", c.DeliveryConcurrency, "The maximum number of webhook endpoints the notifications are delivered to at the same time")
+	fs.BoolVar(&c.AllowPrivateEndpoints, "webhook-allow-private-endpoints", c.AllowPrivateEndpoints, "Allow the webhook endpoints resolving to loopback, private or link-local addresses. For development only")
+}
+
+func (c *Config) ReadFiles() error {
+	return nil
+}
+
+// RetryBackoff returns the time to wait before the next attempt of a delivery that failed the given number of times
+func (c *Config) RetryBackoff(attempts int) time.Duration {
+	backoff := c.InitialRetryBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= c.MaxRetryBackoff {
+			return c.MaxRetryBackoff
+		}
+	}
+	if backoff > c.MaxRetryBackoff {
+		return c.MaxRetryBackoff
+	}
+	return backoff
+}
@@ -0,0 +1,45 @@
+package webhooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func TestConfig_RetryBackoff(t *testing.T) {
+	config := &Config{
+		InitialRetryBackoff: 30 * time.Second,
+		MaxRetryBackoff:     5 * time.Minute,
+	}
+
+	tests := []struct {
+		name     string
+		attempts int
+		want     time.Duration
+	}{
+		{
+			name:     "should wait for the initial backoff after the first attempt",
+			attempts: 1,
+			want:     30 * time.Second,
+		},
+		{
+			name:     "should double the backoff after every attempt",
+			attempts: 3,
+			want:     2 * time.Minute,
+		},
+		{
+			name:     "should not wait for longer than the maximum backoff",
+			attempts: 10,
+			want:     5 * time.Minute,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(config.RetryBackoff(tt.attempts)).To(gomega.Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// DeliveryWorker dispatches the queued webhook events to the subscriptions of their organisation, then delivers the
+// pending webhook notifications of the outbox. As the outbox is stored in the database, the events and the
+// notifications that are not delivered yet survive the restarts of the service and the changes of leader.
+// Notifications are delivered at least once: a receiver may get a notification again if the leader changes while
+// it is being delivered. The notifications of the different endpoints are delivered concurrently, and those of an
+// endpoint are delivered in order until one of them fails so that an unresponsive endpoint delays its own
+// notifications only.
+type DeliveryWorker struct {
+	workers.BaseWorker
+	webhookService WebhookService
+	config         *Config
+	client         *http.Client
+}
+
+// NewDeliveryWorker creates a new worker that delivers the pending webhook notifications
+func NewDeliveryWorker(webhookService WebhookService, config *Config, reconciler workers.Reconciler) *DeliveryWorker {
+	return &DeliveryWorker{
+		BaseWorker: workers.BaseWorker{
+			Id:         uuid.New().String(),
+			WorkerType: "webhook_delivery",
+			Reconciler: reconciler,
+		},
+		webhookService: webhookService,
+		config:         config,
+		client: &http.Client{
+			Timeout: config.DeliveryTimeout,
+			Transport: &http.Transport{
+				DialContext:         config.newDialer().DialContext,
+				TLSHandshakeTimeout: config.DeliveryTimeout,
+				MaxIdleConnsPerHost: 1,
+			},
+		},
+	}
+}
+
+// Start initializes the worker to deliver the pending webhook notifications.
+func (w *DeliveryWorker) Start() {
+	w.StartWorker(w)
+}
+
+// Stop causes the process for delivering the pending webhook notifications to stop.
+func (w *DeliveryWorker) Stop() {
+	w.StopWorker(w)
+}
+
+func (w *DeliveryWorker) Reconcile() []error {
+	glog.Infoln("delivering pending webhook notifications")
+
+	// a failure to dispatch the events does not prevent the deliveries already queued from being attempted
+	var dispatchErrors []error
+	if err := w.webhookService.DispatchEvents(w.config.DeliveryBatchSize); err != nil {
+		dispatchErrors = append(dispatchErrors, errors.Wrap(err, "failed to dispatch queued webhook events"))
+	}
+
+	deliveries, err := w.webhookService.ListPendingDeliveries(w.config.DeliveryBatchSize)
+	if err != nil {
+		return append(dispatchErrors, errors.Wrap(err, "failed to list pending webhook deliveries"))
+	}
+
+	// the deliveries are grouped by endpoint, in the order they are listed in
+	var subscriptionIds []string
+	deliveriesBySubscription := map[string]api.WebhookDeliveryList{}
+	for _, delivery := range deliveries {
+		if _, ok := deliveriesBySubscription[delivery.SubscriptionId]; !ok {
+			subscriptionIds = append(subscriptionIds, delivery.SubscriptionId)
+		}
+		deliveriesBySubscription[delivery.SubscriptionId] = append(deliveriesBySubscription[delivery.SubscriptionId], delivery)
+	}
+
+	encounteredErrors := dispatchErrors
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	concurrency := w.config.DeliveryConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	semaphore := make(chan struct{}, concurrency)
+	for _, subscriptionId := range subscriptionIds {
+		subscriptionDeliveries := deliveriesBySubscription[subscriptionId]
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			errs := w.deliver(subscriptionDeliveries)
+			mutex.Lock()
+			encounteredErrors = append(encounteredErrors, errs...)
+			mutex.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return encounteredErrors
+}
+
+// deliver attempts the deliveries of an endpoint in order. The remaining deliveries are left pending as soon as the
+// endpoint fails one of them, without using any of their attempts, as the endpoint is unlikely to accept them either.
+func (w *DeliveryWorker) deliver(deliveries api.WebhookDeliveryList) []error {
+	var encounteredErrors []error
+	for _, delivery := range deliveries {
+		w.attempt(delivery)
+		if err := w.webhookService.UpdateDelivery(delivery); err != nil {
+			encounteredErrors = append(encounteredErrors, errors.Wrapf(err, "failed to update webhook delivery %q", delivery.ID))
+		}
+		if delivery.Subscription != nil && delivery.Status != api.WebhookDeliveryStatusDelivered {
+			break
+		}
+	}
+	return encounteredErrors
+}
+
+// attempt sends the notification of the delivery and updates the delivery with the outcome. Failed deliveries
+// are retried with an exponential backoff until they run out of attempts.
+func (w *DeliveryWorker) attempt(delivery *api.WebhookDelivery) {
+	delivery.Attempts++
+
+	if delivery.Subscription == nil {
+		delivery.Status = api.WebhookDeliveryStatusFailed
+		delivery.LastError = "the webhook subscription has been deleted"
+		return
+	}
+
+	if err := w.send(delivery); err != nil {
+		// failures of the endpoints are not errors of the worker: they are recorded in the delivery only
+		glog.Warningf("attempt %d of webhook delivery %q to subscription %q failed: %v", delivery.Attempts, delivery.ID, delivery.SubscriptionId, err)
+		delivery.LastError = err.Error()
+		if delivery.Attempts >= w.config.MaxDeliveryAttempts {
+			delivery.Status = api.WebhookDeliveryStatusFailed
+			return
+		}
+		delivery.NextAttemptAt = time.Now().Add(w.config.RetryBackoff(delivery.Attempts))
+		return
+	}
+
+	now := time.Now()
+	delivery.Status = api.WebhookDeliveryStatusDelivered
+	delivery.DeliveredAt = &now
+	delivery.LastError = ""
+}
+
+func (w *DeliveryWorker) send(delivery *api.WebhookDelivery) error {
+	ctx, cancel := context.WithTimeout(context.Background(), w.config.DeliveryTimeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Subscription.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+	timestamp := time.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(EventTypeHeader, delivery.EventType)
+	request.Header.Set(DeliveryIdHeader, delivery.ID)
+	request.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(SignatureHeader, Sign(delivery.Subscription.Secret.String(), timestamp, delivery.Payload))
+
+	response, err := w.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	// the body is drained so that the connection can be reused
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("the endpoint responded with status code %d", response.StatusCode)
+	}
+	return nil
+}
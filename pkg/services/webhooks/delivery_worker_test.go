@@ -0,0 +1,240 @@
+package webhooks
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
+	"github.com/onsi/gomega"
+)
+
+func TestDeliveryWorker_Reconcile(t *testing.T) {
+	payload := api.JSON(`{"id":"event-id","type":"kafka.phase_changed"}`)
+
+	tests := []struct {
+		name          string
+		statusCode    int
+		subscription  bool
+		attempts      int
+		privateHosts  bool
+		wantStatus    api.WebhookDeliveryStatus
+		wantLastError bool
+		wantRequest   bool
+	}{
+		{
+			name:         "should mark the delivery as delivered when the endpoint accepts the notification",
+			statusCode:   http.StatusOK,
+			subscription: true,
+			wantStatus:   api.WebhookDeliveryStatusDelivered,
+			wantRequest:  true,
+		},
+		{
+			name:          "should schedule a new attempt when the endpoint rejects the notification",
+			statusCode:    http.StatusInternalServerError,
+			subscription:  true,
+			wantStatus:    api.WebhookDeliveryStatusPending,
+			wantLastError: true,
+			wantRequest:   true,
+		},
+		{
+			name:          "should give up the delivery when it runs out of attempts",
+			statusCode:    http.StatusInternalServerError,
+			subscription:  true,
+			attempts:      2,
+			wantStatus:    api.WebhookDeliveryStatusFailed,
+			wantLastError: true,
+			wantRequest:   true,
+		},
+		{
+			name:          "should not connect to an endpoint resolving to a private address",
+			statusCode:    http.StatusOK,
+			subscription:  true,
+			privateHosts:  true,
+			wantStatus:    api.WebhookDeliveryStatusPending,
+			wantLastError: true,
+		},
+		{
+			name:          "should give up the delivery when the subscription has been deleted",
+			wantStatus:    api.WebhookDeliveryStatusFailed,
+			wantLastError: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+
+			var received *http.Request
+			var receivedBody []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r
+				receivedBody, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			delivery := &api.WebhookDelivery{
+				ID:             "delivery-id",
+				SubscriptionId: "subscription-id",
+				EventType:      KafkaPhaseChanged.String(),
+				Payload:        payload,
+				Status:         api.WebhookDeliveryStatusPending,
+				Attempts:       tt.attempts,
+			}
+			if tt.subscription {
+				delivery.Subscription = &api.WebhookSubscription{Url: server.URL, Secret: "subscription-secret"}
+			}
+
+			webhookService := &WebhookServiceMock{
+				DispatchEventsFunc: func(limit int) *errors.ServiceError {
+					return nil
+				},
+				ListPendingDeliveriesFunc: func(limit int) (api.WebhookDeliveryList, *errors.ServiceError) {
+					return api.WebhookDeliveryList{delivery}, nil
+				},
+				UpdateDeliveryFunc: func(delivery *api.WebhookDelivery) *errors.ServiceError {
+					return nil
+				},
+			}
+			config := &Config{
+				MaxDeliveryAttempts: 3,
+				InitialRetryBackoff: time.Minute,
+				MaxRetryBackoff:     time.Hour,
+				DeliveryTimeout:     time.Second,
+				DeliveryBatchSize:   10,
+				DeliveryConcurrency: 2,
+				// the test server listens on the loopback interface
+				AllowPrivateEndpoints: !tt.privateHosts,
+			}
+			w := NewDeliveryWorker(webhookService, config, workers.Reconciler{})
+
+			g.Expect(w.Reconcile()).To(gomega.BeEmpty())
+			g.Expect(webhookService.DispatchEventsCalls()).To(gomega.HaveLen(1))
+			g.Expect(webhookService.DispatchEventsCalls()[0].Limit).To(gomega.Equal(config.DeliveryBatchSize))
+			g.Expect(webhookService.UpdateDeliveryCalls()).To(gomega.HaveLen(1))
+			g.Expect(delivery.Attempts).To(gomega.Equal(tt.attempts + 1))
+			g.Expect(delivery.Status).To(gomega.Equal(tt.wantStatus))
+			g.Expect(delivery.LastError != "").To(gomega.Equal(tt.wantLastError))
+			g.Expect(delivery.DeliveredAt != nil).To(gomega.Equal(tt.wantStatus == api.WebhookDeliveryStatusDelivered))
+			if tt.wantStatus == api.WebhookDeliveryStatusPending {
+				g.Expect(delivery.NextAttemptAt).To(gomega.BeTemporally(">", time.Now()))
+			}
+
+			g.Expect(received != nil).To(gomega.Equal(tt.wantRequest))
+			if tt.wantRequest {
+				g.Expect(receivedBody).To(gomega.Equal([]byte(payload)))
+				g.Expect(received.Header.Get(EventTypeHeader)).To(gomega.Equal(KafkaPhaseChanged.String()))
+				g.Expect(received.Header.Get(DeliveryIdHeader)).To(gomega.Equal("delivery-id"))
+				timestamp, err := strconv.ParseInt(received.Header.Get(TimestampHeader), 10, 64)
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+				g.Expect(received.Header.Get(SignatureHeader)).To(gomega.Equal(Sign("subscription-secret", timestamp, payload)))
+			}
+		})
+	}
+}
+
+func TestDeliveryWorker_Reconcile_Endpoints(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var mutex sync.Mutex
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		requests[r.URL.Path]++
+		mutex.Unlock()
+		if r.URL.Path == "/unavailable" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	unavailable := &api.WebhookSubscription{Meta: api.Meta{ID: "unavailable"}, Url: server.URL + "/unavailable", Secret: "subscription-secret"}
+	available := &api.WebhookSubscription{Meta: api.Meta{ID: "available"}, Url: server.URL + "/available", Secret: "subscription-secret"}
+	newDelivery := func(id string, subscription *api.WebhookSubscription) *api.WebhookDelivery {
+		return &api.WebhookDelivery{
+			ID:             id,
+			SubscriptionId: subscription.ID,
+			Subscription:   subscription,
+			EventType:      KafkaPhaseChanged.String(),
+			Payload:        api.JSON(`{}`),
+			Status:         api.WebhookDeliveryStatusPending,
+		}
+	}
+	deliveries := api.WebhookDeliveryList{
+		newDelivery("unavailable-1", unavailable),
+		newDelivery("available-1", available),
+		newDelivery("unavailable-2", unavailable),
+		newDelivery("available-2", available),
+	}
+
+	webhookService := &WebhookServiceMock{
+		DispatchEventsFunc: func(limit int) *errors.ServiceError {
+			return nil
+		},
+		ListPendingDeliveriesFunc: func(limit int) (api.WebhookDeliveryList, *errors.ServiceError) {
+			return deliveries, nil
+		},
+		UpdateDeliveryFunc: func(delivery *api.WebhookDelivery) *errors.ServiceError {
+			return nil
+		},
+	}
+	config := NewConfig()
+	config.AllowPrivateEndpoints = true
+	w := NewDeliveryWorker(webhookService, config, workers.Reconciler{})
+
+	g.Expect(w.Reconcile()).To(gomega.BeEmpty())
+	// the remaining deliveries of the endpoint which failed are left pending without using any of their attempts
+	g.Expect(requests).To(gomega.Equal(map[string]int{"/unavailable": 1, "/available": 2}))
+	g.Expect(webhookService.UpdateDeliveryCalls()).To(gomega.HaveLen(3))
+	g.Expect(deliveries[0].Attempts).To(gomega.Equal(1))
+	g.Expect(deliveries[0].Status).To(gomega.Equal(api.WebhookDeliveryStatusPending))
+	g.Expect(deliveries[2].Attempts).To(gomega.Equal(0))
+	g.Expect(deliveries[1].Status).To(gomega.Equal(api.WebhookDeliveryStatusDelivered))
+	g.Expect(deliveries[3].Status).To(gomega.Equal(api.WebhookDeliveryStatusDelivered))
+}
+
+func TestDeliveryWorker_Reconcile_DispatchFailure(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	delivery := &api.WebhookDelivery{
+		ID:             "delivery-id",
+		SubscriptionId: "subscription-id",
+		Subscription:   &api.WebhookSubscription{Meta: api.Meta{ID: "subscription-id"}, Url: server.URL, Secret: "subscription-secret"},
+		EventType:      KafkaPhaseChanged.String(),
+		Payload:        api.JSON(`{}`),
+		Status:         api.WebhookDeliveryStatusPending,
+	}
+	webhookService := &WebhookServiceMock{
+		DispatchEventsFunc: func(limit int) *errors.ServiceError {
+			return errors.GeneralError("failed to dispatch the events")
+		},
+		ListPendingDeliveriesFunc: func(limit int) (api.WebhookDeliveryList, *errors.ServiceError) {
+			return api.WebhookDeliveryList{delivery}, nil
+		},
+		UpdateDeliveryFunc: func(delivery *api.WebhookDelivery) *errors.ServiceError {
+			return nil
+		},
+	}
+	config := NewConfig()
+	config.AllowPrivateEndpoints = true
+	w := NewDeliveryWorker(webhookService, config, workers.Reconciler{})
+
+	// the deliveries already queued are attempted even though the queued events cannot be dispatched
+	g.Expect(w.Reconcile()).To(gomega.HaveLen(1))
+	g.Expect(delivery.Status).To(gomega.Equal(api.WebhookDeliveryStatusDelivered))
+}
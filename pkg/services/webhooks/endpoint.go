@@ -0,0 +1,59 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"syscall"
+)
+
+// sharedAddressSpace is the range of the carrier-grade NAT addresses (RFC 6598), which are not reachable from the internet
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// IsPublicIP returns true if the address can be reached from the internet. Loopback, private (RFC 1918 and RFC 4193),
+// link-local, like the address of the instance metadata service of the cloud providers, and shared addresses are not
+// public.
+func IsPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip))
+}
+
+// ValidateEndpoint verifies that the host of the endpoint only resolves to public addresses so that the webhook
+// notifications cannot be used to reach the internal services of the fleet manager network. All the addresses are
+// accepted when the private endpoints are allowed.
+func (c *Config) ValidateEndpoint(ctx context.Context, endpoint *url.URL) error {
+	if c.AllowPrivateEndpoints {
+		return nil
+	}
+	addresses, err := net.DefaultResolver.LookupIPAddr(ctx, endpoint.Hostname())
+	if err != nil {
+		return fmt.Errorf("unable to resolve host %q: %w", endpoint.Hostname(), err)
+	}
+	for _, address := range addresses {
+		if !IsPublicIP(address.IP) {
+			return fmt.Errorf("host %q resolves to the non public address %s", endpoint.Hostname(), address.IP)
+		}
+	}
+	return nil
+}
+
+// newDialer returns the dialer of the connections to the webhook endpoints. The address is checked again once it is
+// resolved at delivery time as the records of the host may have changed since the subscription was validated.
+func (c *Config) newDialer() *net.Dialer {
+	dialer := &net.Dialer{Timeout: c.DeliveryTimeout}
+	if c.AllowPrivateEndpoints {
+		return dialer
+	}
+	dialer.Control = func(network, address string, _ syscall.RawConn) error {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return err
+		}
+		if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+			return fmt.Errorf("connections to the non public address %s are not allowed", host)
+		}
+		return nil
+	}
+	return dialer
+}
@@ -0,0 +1,80 @@
+package webhooks
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "203.0.113.10", want: true},
+		{ip: "2001:db8::1", want: true},
+		{ip: "127.0.0.1"},
+		{ip: "::1"},
+		{ip: "10.1.2.3"},
+		{ip: "172.16.0.1"},
+		{ip: "192.168.1.1"},
+		{ip: "169.254.169.254"},
+		{ip: "fe80::1"},
+		{ip: "fd00::1"},
+		{ip: "100.64.0.1"},
+		{ip: "0.0.0.0"},
+		{ip: "224.0.0.1"},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.ip, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(IsPublicIP(net.ParseIP(tt.ip))).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func TestConfig_ValidateEndpoint(t *testing.T) {
+	tests := []struct {
+		name                  string
+		url                   string
+		allowPrivateEndpoints bool
+		wantErr               bool
+	}{
+		{
+			name: "should accept a public address",
+			url:  "https://203.0.113.10/webhook",
+		},
+		{
+			name:    "should reject a host resolving to a loopback address",
+			url:     "https://localhost/webhook",
+			wantErr: true,
+		},
+		{
+			name:    "should reject the address of the instance metadata service",
+			url:     "https://169.254.169.254/latest/meta-data",
+			wantErr: true,
+		},
+		{
+			name:                  "should accept a private address when the private endpoints are allowed",
+			url:                   "https://10.0.0.1/webhook",
+			allowPrivateEndpoints: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			endpoint, err := url.Parse(tt.url)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			config := NewConfig()
+			config.AllowPrivateEndpoints = tt.allowPrivateEndpoints
+			g.Expect(config.ValidateEndpoint(context.Background(), endpoint) != nil).To(gomega.Equal(tt.wantErr))
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+type EventType string
+
+const (
+	KafkaPhaseChanged              EventType = "kafka.phase_changed"
+	ConnectorPhaseChanged          EventType = "connector.phase_changed"
+	ConnectorNamespacePhaseChanged EventType = "connector_namespace.phase_changed"
+	EnterpriseClusterPhaseChanged  EventType = "enterprise_cluster.phase_changed"
+)
+
+// EventTypes are the types of the events the webhook subscriptions can be notified of
+var EventTypes = []string{
+	KafkaPhaseChanged.String(),
+	ConnectorPhaseChanged.String(),
+	ConnectorNamespacePhaseChanged.String(),
+	EnterpriseClusterPhaseChanged.String(),
+}
+
+func (t EventType) String() string {
+	return string(t)
+}
+
+const (
+	// SignatureHeader is the header holding the signature of the payload, in the form "sha256=<hex encoded signature>"
+	SignatureHeader = "X-Webhook-Signature"
+	// TimestampHeader is the header holding the time the payload was sent at, in seconds since the Unix epoch
+	TimestampHeader  = "X-Webhook-Timestamp"
+	EventTypeHeader  = "X-Webhook-Event"
+	DeliveryIdHeader = "X-Webhook-Delivery"
+)
+
+// Event is the JSON payload delivered to the webhook subscriptions when a resource changes phase
+type Event struct {
+	Id             string    `json:"id"`
+	Type           EventType `json:"type"`
+	OccurredAt     time.Time `json:"occurred_at"`
+	OrganisationId string    `json:"organisation_id"`
+	Resource       Resource  `json:"resource"`
+	PreviousPhase  string    `json:"previous_phase,omitempty"`
+	Phase          string    `json:"phase"`
+}
+
+// Resource references the resource that changed phase
+type Resource struct {
+	Id   string `json:"id"`
+	Kind string `json:"kind"`
+	Href string `json:"href"`
+}
+
+// Sign returns the value of the SignatureHeader of a payload sent at the given timestamp: the HMAC-SHA256 of
+// "<timestamp>.<payload>" keyed with the secret of the subscription. Including the timestamp in the signature
+// allows the receivers to reject replayed notifications.
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%d.", timestamp)))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// GenerateSecret returns a random secret for the subscriptions that are registered without one
+func GenerateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,34 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestSign(t *testing.T) {
+	g := gomega.NewWithT(t)
+	payload := []byte(`{"id":"event-id"}`)
+
+	mac := hmac.New(sha256.New, []byte("subscription-secret"))
+	mac.Write([]byte(`1678272000.{"id":"event-id"}`))
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	g.Expect(Sign("subscription-secret", 1678272000, payload)).To(gomega.Equal(want))
+	g.Expect(Sign("subscription-secret", 1678272001, payload)).ToNot(gomega.Equal(want))
+	g.Expect(Sign("another-secret-value", 1678272000, payload)).ToNot(gomega.Equal(want))
+}
+
+func TestGenerateSecret(t *testing.T) {
+	g := gomega.NewWithT(t)
+	secret, err := GenerateSecret()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(secret).To(gomega.HaveLen(64))
+
+	another, err := GenerateSecret()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(another).ToNot(gomega.Equal(secret))
+}
@@ -1,19 +1,21 @@
-package vault
+package webhooks
 
 import (
 	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/environments"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/workers"
 	"github.com/goava/di"
 )
 
 func ConfigProviders() di.Option {
 	return di.Options(
-		di.Provide(NewConfig, di.As(new(environments.ConfigModule)), di.As(new(environments.ServiceValidator))),
+		di.Provide(NewConfig, di.As(new(environments.ConfigModule))),
 		di.Provide(environments.Func(ServiceProviders)),
 	)
 }
 
 func ServiceProviders() di.Option {
 	return di.Options(
-		di.Provide(NewVaultService),
+		di.Provide(NewWebhookService),
+		di.Provide(NewDeliveryWorker, di.As(new(workers.Worker))),
 	)
 }
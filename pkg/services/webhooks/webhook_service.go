@@ -0,0 +1,215 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/api"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/services"
+	"gorm.io/gorm"
+)
+
+// WebhookService manages the webhook subscriptions of the organisations and queues the notifications of the events
+// they are subscribed to in the outbox: the events are queued when they occur, then dispatched into the deliveries of
+// the subscriptions.
+//
+//go:generate moq -out webhook_service_moq.go . WebhookService
+type WebhookService interface {
+	CreateSubscription(subscription *api.WebhookSubscription) *errors.ServiceError
+	// GetSubscription returns the subscription with the given id of the given organisation
+	GetSubscription(orgId string, id string) (*api.WebhookSubscription, *errors.ServiceError)
+	ListSubscriptions(orgId string, listArgs *services.ListArguments) (api.WebhookSubscriptionList, *api.PagingMeta, *errors.ServiceError)
+	// DeleteSubscription deletes the subscription with the given id of the given organisation. The notifications
+	// that are still pending for the subscription are given up.
+	DeleteSubscription(orgId string, id string) *errors.ServiceError
+	// Notify queues the event in the outbox. The event is dispatched to the subscriptions of its organisation
+	// asynchronously, by DispatchEvents.
+	Notify(event *Event) *errors.ServiceError
+	// DispatchEvents queues a delivery of the oldest queued events for every subscription of their organisation that
+	// is subscribed to their type, and removes them from the outbox
+	DispatchEvents(limit int) *errors.ServiceError
+	// ListPendingDeliveries returns the pending deliveries whose next attempt is due, the oldest first
+	ListPendingDeliveries(limit int) (api.WebhookDeliveryList, *errors.ServiceError)
+	// UpdateDelivery stores the outcome of the latest attempt of a delivery
+	UpdateDelivery(delivery *api.WebhookDelivery) *errors.ServiceError
+}
+
+var _ WebhookService = &webhookService{}
+
+type webhookService struct {
+	connectionFactory *db.ConnectionFactory
+}
+
+func NewWebhookService(connectionFactory *db.ConnectionFactory) WebhookService {
+	return &webhookService{
+		connectionFactory: connectionFactory,
+	}
+}
+
+func (s *webhookService) CreateSubscription(subscription *api.WebhookSubscription) *errors.ServiceError {
+	// api.JSON can not be scanned from NULL: always store a list
+	if len(subscription.EventTypes) == 0 {
+		subscription.EventTypes = api.JSON("[]")
+	}
+	if err := s.connectionFactory.New().Create(subscription).Error; err != nil {
+		return services.HandleCreateError("webhook subscription", err)
+	}
+	return nil
+}
+
+func (s *webhookService) GetSubscription(orgId string, id string) (*api.WebhookSubscription, *errors.ServiceError) {
+	var subscription api.WebhookSubscription
+	if err := s.connectionFactory.New().Where("id = ? AND org_id = ?", id, orgId).First(&subscription).Error; err != nil {
+		return nil, services.HandleGetError("webhook subscription", "id", id, err)
+	}
+	return &subscription, nil
+}
+
+func (s *webhookService) ListSubscriptions(orgId string, listArgs *services.ListArguments) (api.WebhookSubscriptionList, *api.PagingMeta, *errors.ServiceError) {
+	var subscriptions api.WebhookSubscriptionList
+	pagingMeta := &api.PagingMeta{
+		Page: listArgs.Page,
+		Size: listArgs.Size,
+	}
+
+	dbConn := s.connectionFactory.New().Model(&api.WebhookSubscription{}).Where("org_id = ?", orgId)
+
+	var total int64
+	if err := dbConn.Count(&total).Error; err != nil {
+		return nil, nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to count webhook subscriptions")
+	}
+	pagingMeta.Total = int(total)
+	if pagingMeta.Size > pagingMeta.Total {
+		pagingMeta.Size = pagingMeta.Total
+	}
+
+	if err := dbConn.Order("created_at").Offset((pagingMeta.Page - 1) * listArgs.Size).Limit(listArgs.Size).Find(&subscriptions).Error; err != nil {
+		return nil, nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list webhook subscriptions")
+	}
+
+	return subscriptions, pagingMeta, nil
+}
+
+func (s *webhookService) DeleteSubscription(orgId string, id string) *errors.ServiceError {
+	result := s.connectionFactory.New().Where("id = ? AND org_id = ?", id, orgId).Delete(&api.WebhookSubscription{})
+	if result.Error != nil {
+		return services.HandleDeleteError("webhook subscription", "id", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NotFound("webhook subscription with id='%s' not found", id)
+	}
+	return nil
+}
+
+func (s *webhookService) Notify(event *Event) *errors.ServiceError {
+	// subscriptions are registered by organisations: the events of the resources of users without an organisation are never delivered
+	if event.OrganisationId == "" {
+		return nil
+	}
+	if event.Id == "" {
+		event.Id = api.NewID()
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to marshal event %q", event.Id)
+	}
+
+	// the event is queued outside of any transaction so that it is kept even when the caller fails afterwards
+	if err := s.connectionFactory.New().Create(&api.WebhookEvent{
+		ID:         event.Id,
+		OrgId:      event.OrganisationId,
+		EventType:  event.Type.String(),
+		OccurredAt: event.OccurredAt,
+		Payload:    payload,
+	}).Error; err != nil {
+		return services.HandleCreateError("webhook event", err)
+	}
+	return nil
+}
+
+func (s *webhookService) DispatchEvents(limit int) *errors.ServiceError {
+	var events api.WebhookEventList
+	if err := s.connectionFactory.New().Order("created_at").Limit(limit).Find(&events).Error; err != nil {
+		return errors.NewWithCause(errors.ErrorGeneral, err, "unable to list the queued webhook events")
+	}
+
+	// the events are dispatched in order: the following events stay queued when one of them cannot be dispatched
+	for _, event := range events {
+		if err := s.dispatchEvent(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dispatchEvent queues the deliveries of the event and removes it from the outbox in a single transaction so that the
+// subscriptions are notified of the event exactly once
+func (s *webhookService) dispatchEvent(event *api.WebhookEvent) *errors.ServiceError {
+	if err := s.connectionFactory.New().Transaction(func(tx *gorm.DB) error {
+		var subscriptions api.WebhookSubscriptionList
+		if err := tx.Where("org_id = ?", event.OrgId).Find(&subscriptions).Error; err != nil {
+			return errors.NewWithCause(errors.ErrorGeneral, err, "unable to find the webhook subscriptions of organisation %q", event.OrgId)
+		}
+
+		deliveries := api.WebhookDeliveryList{}
+		for _, subscription := range subscriptions {
+			subscribed, err := subscription.IsSubscribedTo(event.EventType)
+			if err != nil {
+				return errors.NewWithCause(errors.ErrorGeneral, err, "unable to read the event types of webhook subscription %q", subscription.ID)
+			}
+			if !subscribed {
+				continue
+			}
+			deliveries = append(deliveries, &api.WebhookDelivery{
+				SubscriptionId: subscription.ID,
+				EventId:        event.ID,
+				EventType:      event.EventType,
+				Payload:        event.Payload,
+				Status:         api.WebhookDeliveryStatusPending,
+				NextAttemptAt:  event.OccurredAt,
+			})
+		}
+
+		if len(deliveries) > 0 {
+			if err := tx.Create(&deliveries).Error; err != nil {
+				return services.HandleCreateError("webhook delivery", err)
+			}
+		}
+		if err := tx.Delete(event).Error; err != nil {
+			return services.HandleDeleteError("webhook event", "id", event.ID, err)
+		}
+		return nil
+	}); err != nil {
+		return errors.ToServiceError(err)
+	}
+	return nil
+}
+
+func (s *webhookService) ListPendingDeliveries(limit int) (api.WebhookDeliveryList, *errors.ServiceError) {
+	var deliveries api.WebhookDeliveryList
+	if err := s.connectionFactory.New().
+		Preload("Subscription").
+		Where("status = ? AND next_attempt_at <= ?", api.WebhookDeliveryStatusPending, time.Now()).
+		Order("next_attempt_at").
+		Limit(limit).
+		Find(&deliveries).Error; err != nil {
+		return nil, errors.NewWithCause(errors.ErrorGeneral, err, "unable to list pending webhook deliveries")
+	}
+	return deliveries, nil
+}
+
+func (s *webhookService) UpdateDelivery(delivery *api.WebhookDelivery) *errors.ServiceError {
+	// the fields are explicitly selected so that their zero value is stored as well, e.g. the error of the previous attempt is cleared
+	if err := s.connectionFactory.New().Model(delivery).
+		Select("status", "attempts", "next_attempt_at", "last_error", "delivered_at", "updated_at").
+		Updates(delivery).Error; err != nil {
+		return services.HandleUpdateError("webhook delivery", err)
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/db"
+	"github.com/bf2fc6cc711aee1a0c2a/kas-fleet-manager/pkg/errors"
+	"github.com/onsi/gomega"
+	mocket "github.com/selvatico/go-mocket"
+)
+
+func Test_webhookService_Notify(t *testing.T) {
+	const insertQuery = `INSERT INTO "webhook_events"`
+
+	tests := []struct {
+		name       string
+		event      *Event
+		setupFn    func()
+		wantQueued bool
+		wantErr    bool
+	}{
+		{
+			name:    "should not queue the events of resources without an organisation",
+			event:   &Event{Type: KafkaPhaseChanged},
+			setupFn: func() { mocket.Catcher.Reset().NewMock().WithQuery(insertQuery) },
+		},
+		{
+			name:  "should queue the event without looking up the subscriptions of its organisation",
+			event: &Event{Type: KafkaPhaseChanged, OrganisationId: "org-id", Phase: "ready", PreviousPhase: "provisioning"},
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().WithQuery(`SELECT`).WithQueryException()
+				mocket.Catcher.NewMock().WithQuery(insertQuery)
+			},
+			wantQueued: true,
+		},
+		{
+			name:  "should return an error when the event cannot be queued",
+			event: &Event{Type: KafkaPhaseChanged, OrganisationId: "org-id", Phase: "ready"},
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(insertQuery).WithExecException()
+			},
+			wantQueued: true,
+			wantErr:    true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			s := NewWebhookService(db.NewMockConnectionFactory(nil))
+
+			err := s.Notify(tt.event)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(isTriggered(insertQuery)).To(gomega.Equal(tt.wantQueued))
+		})
+	}
+}
+
+func Test_webhookService_DispatchEvents(t *testing.T) {
+	const (
+		selectEventsQuery        = `SELECT * FROM "webhook_events"`
+		selectSubscriptionsQuery = `SELECT * FROM "webhook_subscriptions" WHERE (org_id = $1)`
+		insertQuery              = `INSERT INTO "webhook_deliveries"`
+		deleteQuery              = `DELETE FROM "webhook_events"`
+	)
+	queuedEvent := []map[string]interface{}{
+		{"id": "event-id", "org_id": "org-id", "event_type": KafkaPhaseChanged.String(), "payload": []byte(`{}`)},
+	}
+
+	tests := []struct {
+		name           string
+		setupFn        func()
+		wantDeliveries bool
+		wantDispatched bool
+		wantErr        bool
+	}{
+		{
+			name: "should do nothing when no event is queued",
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().WithQuery(selectEventsQuery).WithReply(nil)
+				mocket.Catcher.NewMock().WithQuery(insertQuery)
+				mocket.Catcher.NewMock().WithQuery(deleteQuery)
+			},
+		},
+		{
+			name: "should queue a delivery for the subscriptions subscribed to the type of the event",
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().WithQuery(selectEventsQuery).WithReply(queuedEvent)
+				mocket.Catcher.NewMock().WithQuery(selectSubscriptionsQuery).WithReply([]map[string]interface{}{
+					{"id": "subscription-id", "org_id": "org-id", "event_types": []byte(`["kafka.phase_changed"]`)},
+				})
+				mocket.Catcher.NewMock().WithQuery(insertQuery)
+				mocket.Catcher.NewMock().WithQuery(deleteQuery).WithRowsNum(1)
+			},
+			wantDeliveries: true,
+			wantDispatched: true,
+		},
+		{
+			name: "should queue a delivery for the subscriptions subscribed to all the events",
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().WithQuery(selectEventsQuery).WithReply(queuedEvent)
+				mocket.Catcher.NewMock().WithQuery(selectSubscriptionsQuery).WithReply([]map[string]interface{}{
+					{"id": "subscription-id", "org_id": "org-id", "event_types": []byte(`[]`)},
+				})
+				mocket.Catcher.NewMock().WithQuery(insertQuery)
+				mocket.Catcher.NewMock().WithQuery(deleteQuery).WithRowsNum(1)
+			},
+			wantDeliveries: true,
+			wantDispatched: true,
+		},
+		{
+			name: "should remove the event without queuing any delivery when no subscription is subscribed to its type",
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().WithQuery(selectEventsQuery).WithReply(queuedEvent)
+				mocket.Catcher.NewMock().WithQuery(selectSubscriptionsQuery).WithReply([]map[string]interface{}{
+					{"id": "subscription-id", "org_id": "org-id", "event_types": []byte(`["connector.phase_changed"]`)},
+				})
+				mocket.Catcher.NewMock().WithQuery(insertQuery).WithExecException()
+				mocket.Catcher.NewMock().WithQuery(deleteQuery).WithRowsNum(1)
+			},
+			wantDispatched: true,
+		},
+		{
+			name: "should return an error when the queued events cannot be listed",
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().WithQuery(selectEventsQuery).WithQueryException()
+				mocket.Catcher.NewMock().WithQuery(insertQuery)
+				mocket.Catcher.NewMock().WithQuery(deleteQuery)
+			},
+			wantErr: true,
+		},
+		{
+			name: "should keep the event queued when the subscriptions cannot be found",
+			setupFn: func() {
+				mocket.Catcher.Reset()
+				mocket.Catcher.NewMock().WithQuery(selectEventsQuery).WithReply(queuedEvent)
+				mocket.Catcher.NewMock().WithQuery(selectSubscriptionsQuery).WithQueryException()
+				mocket.Catcher.NewMock().WithQuery(insertQuery)
+				mocket.Catcher.NewMock().WithQuery(deleteQuery)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			s := NewWebhookService(db.NewMockConnectionFactory(nil))
+
+			err := s.DispatchEvents(10)
+			g.Expect(err != nil).To(gomega.Equal(tt.wantErr))
+			g.Expect(isTriggered(insertQuery)).To(gomega.Equal(tt.wantDeliveries))
+			g.Expect(isTriggered(deleteQuery)).To(gomega.Equal(tt.wantDispatched))
+		})
+	}
+}
+
+// isTriggered returns true when the mock of the given query has been triggered
+func isTriggered(query string) bool {
+	for _, mock := range mocket.Catcher.Mocks {
+		if mock.Pattern == query {
+			return mock.Triggered
+		}
+	}
+	return false
+}
+
+func Test_webhookService_DeleteSubscription(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupFn     func()
+		wantErrCode errors.ServiceErrorCode
+	}{
+		{
+			name: "should delete the subscription of the organisation",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "webhook_subscriptions" SET "deleted_at"=$1 WHERE (id = $2 AND org_id = $3)`).WithRowsNum(1)
+			},
+		},
+		{
+			name: "should return a not found error when the organisation has no subscription with the id",
+			setupFn: func() {
+				mocket.Catcher.Reset().NewMock().WithQuery(`UPDATE "webhook_subscriptions" SET "deleted_at"=$1 WHERE (id = $2 AND org_id = $3)`).WithRowsNum(0)
+			},
+			wantErrCode: errors.ErrorNotFound,
+		},
+	}
+
+	for _, testcase := range tests {
+		tt := testcase
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tt.setupFn()
+			s := NewWebhookService(db.NewMockConnectionFactory(nil))
+
+			err := s.DeleteSubscription("org-id", "subscription-id")
+			if tt.wantErrCode == 0 {
+				g.Expect(err).To(gomega.BeNil())
+				return
+			}
+			g.Expect(err).ToNot(gomega.BeNil())
+			g.Expect(err.Code).To(gomega.Equal(tt.wantErrCode))
+		})
+	}
+}
@@ -19,6 +19,10 @@ type LeaderElectionManager struct {
 	leaderElectionReconcilerRepeatInterval time.Duration
 	leaderLeaseExpirationTime              time.Duration
 	workerGrp                              sync.WaitGroup
+	// the outcome of the last leader election, read by the health checks
+	electionMu   sync.RWMutex
+	lastElection time.Time
+	leaders      []Worker
 }
 
 // leaderLeaseAcquisition a wrapper for a lease and whether it's been acquired/is owned by another worker
@@ -88,6 +92,7 @@ func (s *LeaderElectionManager) Stop() {
 
 func (s *LeaderElectionManager) startWorkers() {
 	newWorkers := make([]Worker, 0)
+	leaders := make([]Worker, 0)
 	for _, worker := range s.workers {
 		if worker.HasTerminated() {
 			if worker.IsRunning() {
@@ -100,6 +105,9 @@ func (s *LeaderElectionManager) startWorkers() {
 		newWorkers = append(newWorkers, worker)
 
 		isLeader := s.isWorkerLeader(worker)
+		if isLeader {
+			leaders = append(leaders, worker)
+		}
 		if isLeader && !worker.IsRunning() {
 			glog.V(1).Infoln(fmt.Sprintf("Running as the leader and starting worker %T [%s]", worker, worker.GetID()))
 			worker.Start()
@@ -115,6 +123,34 @@ func (s *LeaderElectionManager) startWorkers() {
 	if len(newWorkers) != len(s.workers) {
 		s.workers = newWorkers
 	}
+
+	s.electionMu.Lock()
+	defer s.electionMu.Unlock()
+	s.lastElection = time.Now()
+	s.leaders = leaders
+}
+
+// LastElection returns when the leader leases were last acquired or renewed, it is zero until the manager is started
+func (s *LeaderElectionManager) LastElection() time.Time {
+	s.electionMu.RLock()
+	defer s.electionMu.RUnlock()
+	return s.lastElection
+}
+
+// LeaderLeases returns the types of the workers whose leader lease is held by this replica
+func (s *LeaderElectionManager) LeaderLeases() []string {
+	s.electionMu.RLock()
+	defer s.electionMu.RUnlock()
+	leaderLeases := make([]string, 0, len(s.leaders))
+	for _, worker := range s.leaders {
+		leaderLeases = append(leaderLeases, worker.GetWorkerType())
+	}
+	return leaderLeases
+}
+
+// ElectionStallTimeout returns how long the leader election can stall before the leases of the replica expire
+func (s *LeaderElectionManager) ElectionStallTimeout() time.Duration {
+	return s.leaderElectionReconcilerRepeatInterval + s.leaderLeaseExpirationTime
 }
 
 func (s *LeaderElectionManager) isWorkerLeader(worker Worker) bool {
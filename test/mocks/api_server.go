@@ -73,6 +73,7 @@ const (
 	EndpointPathSubscriptionSearch    = "/api/accounts_mgmt/v1/subscriptions"
 	EndpointPathServiceAccount        = "/api/accounts_mgmt/v1/current_account"
 	EndpointPathOrganisationQuotaCost = "/api/accounts_mgmt/v1/organizations/{orgId}/quota_cost"
+	EndpointPathOrganisations         = "/api/accounts_mgmt/v1/organizations"
 
 	EndpointPathTermsReview = "/api/authorizations/v1/terms_review"
 
@@ -126,6 +127,8 @@ const (
 	MockIdentityProviderID = "identity-provider-id"
 	//
 	MockSubID = "pphCb6sIQPqtjMtL0GQaX6i4bP"
+	// MockOrganisationID default internal id of the organisation owning the subscriptions
+	MockOrganisationID = "mock-organisation-id"
 	// MockQuotaConsumed default quota consumed value returned by the get quota costs by organisation endpoint
 	MockQuotaConsumed = 1
 	// MockQuotaMaxAllowed default quota max allowed value returned by the get quota costs by organisation endpoint
@@ -171,9 +174,11 @@ var (
 	EndpointClusterLoggingOperatorAddonInstallationPatch = Endpoint{EndpointPathClusterLoggingOperatorAddonInstallation, http.MethodPatch}
 	EndpointClusterLoggingOperatorAddonInstallationPost  = Endpoint{EndpointPathClusterLoggingOperatorAddonInstallation, http.MethodPost}
 	EndpointClusterAuthorizationPost                     = Endpoint{EndpointPathClusterAuthorization, http.MethodPost}
+	EndpointSubscriptionGet                              = Endpoint{EndpointPathSubscription, http.MethodGet}
 	EndpointSubscriptionDelete                           = Endpoint{EndpointPathSubscription, http.MethodDelete}
 	EndpointSubscriptionSearch                           = Endpoint{EndpointPathSubscriptionSearch, http.MethodGet}
 	EndpointTermsReviewPost                              = Endpoint{EndpointPathTermsReview, http.MethodPost}
+	EndpointOrganisationSearch                           = Endpoint{EndpointPathOrganisations, http.MethodGet}
 )
 
 // variables for mocked ocm types
@@ -202,6 +207,7 @@ var (
 	MockOrganizationQuotaCost                      *amsv1.QuotaCostList
 	MockServiceAccount                             *amsv1.Account
 	MockSubscriptionSearch                         []*amsv1.Subscription
+	MockOrganisationSearch                         *amsv1.OrganizationList
 	MockTermsReview                                *authorizationsv1.TermsReviewResponse
 )
 
@@ -416,6 +422,14 @@ func (b *MockConfigurableServerBuilder) SetClusterLoggingOperatorAddonInstallati
 	b.handlerRegister[EndpointClusterLoggingOperatorAddonInstallationPost] = buildMockRequestHandler(ai, err)
 }
 
+func (b *MockConfigurableServerBuilder) SetSubscriptionGetResponse(sub *amsv1.Subscription, err *ocmErrors.ServiceError) {
+	b.handlerRegister[EndpointSubscriptionGet] = buildMockRequestHandler(sub, err)
+}
+
+func (b *MockConfigurableServerBuilder) SetOrganisationSearchResponse(ol *amsv1.OrganizationList, err *ocmErrors.ServiceError) {
+	b.handlerRegister[EndpointOrganisationSearch] = buildMockRequestHandler(ol, err)
+}
+
 func (b *MockConfigurableServerBuilder) SetSubscriptionPathDeleteResponse(idp *amsv1.Subscription, err *ocmErrors.ServiceError) {
 	b.handlerRegister[EndpointSubscriptionDelete] = buildMockRequestHandler(idp, err)
 }
@@ -522,9 +536,11 @@ func getDefaultHandlerRegister() (HandlerRegister, error) {
 		EndpointClusterLoggingOperatorAddonInstallationPatch: buildMockRequestHandler(MockKasClusterLoggingOperatorAddonInstallation, nil),
 		EndpointClusterLoggingOperatorAddonInstallationPost:  buildMockRequestHandler(MockKasClusterLoggingOperatorAddonInstallation, nil),
 		EndpointClusterAuthorizationPost:                     buildMockRequestHandler(MockClusterAuthorization, nil),
+		EndpointSubscriptionGet:                              buildMockRequestHandler(MockSubscription, nil),
 		EndpointSubscriptionDelete:                           buildMockRequestHandler(MockSubscription, nil),
 		EndpointSubscriptionSearch:                           buildMockRequestHandler(MockSubscriptionSearch, nil),
 		EndpointTermsReviewPost:                              buildMockRequestHandler(MockTermsReview, nil),
+		EndpointOrganisationSearch:                           buildMockRequestHandler(MockOrganisationSearch, nil),
 	}, nil
 }
 
@@ -654,6 +670,14 @@ func marshalOCMType(t interface{}, w io.Writer) error {
 		return json.NewEncoder(w).Encode(subscList)
 		//list := t.(*amsv1.SubscriptionList)
 		//return amsv1.MarshalSubscriptionList(list.Slice(), w)
+	case []*amsv1.Organization:
+		return amsv1.MarshalOrganizationList(v, w)
+	case *amsv1.OrganizationList:
+		organisationList, err := NewSubscriptionList().WithItems(v.Slice())
+		if err != nil {
+			return err
+		}
+		return json.NewEncoder(w).Encode(organisationList)
 	// handle ocm error type
 	case *ocmErrors.ServiceError:
 		return json.NewEncoder(w).Encode(v.AsOpenapiError("", ""))
@@ -818,16 +842,29 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+	MockOrganisationSearch, err = GetMockOrganisationList(nil)
+	if err != nil {
+		panic(err)
+	}
 }
 
 func GetMockSubscription(modifyFn func(b *amsv1.Subscription)) (*amsv1.Subscription, error) {
-	builder, err := amsv1.NewSubscription().ID(MockSubID).Build()
+	builder, err := amsv1.NewSubscription().ID(MockSubID).OrganizationID(MockOrganisationID).Build()
 	if modifyFn != nil {
 		modifyFn(builder)
 	}
 	return builder, err
 }
 
+// GetMockOrganisationList returns the organisations found when searching an organisation by its external id
+func GetMockOrganisationList(modifyFn func(*amsv1.OrganizationList, error)) (*amsv1.OrganizationList, error) {
+	list, err := amsv1.NewOrganizationList().Items(amsv1.NewOrganization().ID(MockOrganisationID)).Build()
+	if modifyFn != nil {
+		modifyFn(list, err)
+	}
+	return list, err
+}
+
 func GetMockClusterAuthorization(modifyFn func(b *amsv1.ClusterAuthorizationResponse)) (*amsv1.ClusterAuthorizationResponse, error) {
 	sub := amsv1.SubscriptionBuilder{}
 	sub.ID(MockSubID)
@@ -1040,7 +1077,8 @@ func GetMockClusterBuilder(modifyFn func(*clustersmgmtv1.ClusterBuilder)) *clust
 		Nodes(GetMockClusterNodesBuilder(nil)).
 		CloudProvider(GetMockCloudProviderBuilder(nil)).
 		Region(GetMockCloudProviderRegionBuilder(nil)).
-		Version(GetMockOpenshiftVersionBuilder(nil))
+		Version(GetMockOpenshiftVersionBuilder(nil)).
+		Subscription(clustersmgmtv1.NewSubscription().ID(MockSubID))
 	if modifyFn != nil {
 		modifyFn(builder)
 	}